@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -58,8 +59,14 @@ func run() error {
 	return nil
 }
 
+// main runs the program and exits with the code carried by the error, such as a budget
+// threshold or conformance failure, or 1 for any other error.
 func main() {
 	if err := run(); err != nil {
+		var coded interface{ ExitCode() int }
+		if errors.As(err, &coded) {
+			os.Exit(coded.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
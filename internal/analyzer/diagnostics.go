@@ -11,10 +11,12 @@ import (
 
 // Policy pack and policy name constants for diagnostic messages.
 const (
-	policyPackName  = "finfocus"
-	policyNameCost  = "cost-estimate"
-	policyNameSum   = "stack-cost-summary"
-	defaultCurrency = "USD"
+	policyPackName   = "finfocus"
+	policyNameCost   = "cost-estimate"
+	policyNameSum    = "stack-cost-summary"
+	policyNameBudget = "environment-budget"
+	policyNameUnused = "possibly-unused-resource"
+	defaultCurrency  = "USD"
 )
 
 // CostToDiagnostic converts a CostResult to an AnalyzeDiagnostic.
@@ -109,6 +111,51 @@ func StackSummaryDiagnostic(
 	}
 }
 
+// budgetSeverities maps budget statuses to diagnostic severities.
+var budgetSeverities = map[engine.BudgetStatus]pulumirpc.PolicySeverity{
+	engine.BudgetStatusOK:       pulumirpc.PolicySeverity_POLICY_SEVERITY_LOW,
+	engine.BudgetStatusWarning:  pulumirpc.PolicySeverity_POLICY_SEVERITY_MEDIUM,
+	engine.BudgetStatusCritical: pulumirpc.PolicySeverity_POLICY_SEVERITY_HIGH,
+}
+
+// BudgetDiagnostic creates a stack-level diagnostic reporting an environment's projected
+// monthly cost against its budget. Its severity follows the threshold crossed: low within
+// budget, medium past the warning threshold and high past the critical threshold. Like
+// every other diagnostic it is ADVISORY.
+func BudgetDiagnostic(eval engine.BudgetEvaluation, version string) *pulumirpc.AnalyzeDiagnostic {
+	message := fmt.Sprintf("Environment %s: projected %.2f of %.2f %s monthly budget (%.0f%%), status %s",
+		eval.Environment, eval.Total, eval.Budget, eval.Currency, eval.PercentUsed, strings.ToUpper(string(eval.Status)))
+	if eval.Excluded > 0 {
+		message += fmt.Sprintf(" (%d resources in other currencies not counted)", eval.Excluded)
+	}
+
+	return &pulumirpc.AnalyzeDiagnostic{
+		PolicyName:        policyNameBudget,
+		PolicyPackName:    policyPackName,
+		PolicyPackVersion: version,
+		Description:       "Environment budget",
+		Message:           message,
+		EnforcementLevel:  pulumirpc.EnforcementLevel_ADVISORY,
+		Severity:          budgetSeverities[eval.Status],
+	}
+}
+
+// UnusedResourceDiagnostic creates a diagnostic for a resource that costs money but that
+// no other resource in the stack references, with its monthly cost as the savings of
+// deleting it. It has medium severity and, like every other diagnostic, is ADVISORY.
+func UnusedResourceDiagnostic(rec engine.Recommendation, urn, version string) *pulumirpc.AnalyzeDiagnostic {
+	return &pulumirpc.AnalyzeDiagnostic{
+		PolicyName:        policyNameUnused,
+		PolicyPackName:    policyPackName,
+		PolicyPackVersion: version,
+		Description:       "Possibly unused resource",
+		Message:           fmt.Sprintf("%s (save $%.2f %s/mo)", rec.Description, rec.EstimatedSavings, rec.Currency),
+		EnforcementLevel:  pulumirpc.EnforcementLevel_ADVISORY,
+		Urn:               urn,
+		Severity:          pulumirpc.PolicySeverity_POLICY_SEVERITY_MEDIUM,
+	}
+}
+
 // formatCostMessage formats a cost result into a human-readable message.
 //
 // Message formats:
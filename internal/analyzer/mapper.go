@@ -63,15 +63,33 @@ type MappingResult struct {
 //   - ID: Extracted from URN (last :: segment)
 //   - Provider: Extracted from provider resource type or resource type prefix
 //   - Properties: Converted from protobuf Struct to Go map
+//   - Parent, Dependencies: The parent and dependency URNs, reduced to IDs like ID
 func MapResource(r *pulumirpc.AnalyzerResource) engine.ResourceDescriptor {
 	return engine.ResourceDescriptor{
-		Type:       r.GetType(),
-		ID:         extractResourceID(r.GetUrn()),
-		Provider:   extractProvider(r),
-		Properties: structToMap(r.GetProperties()),
+		Type:         r.GetType(),
+		ID:           extractResourceID(r.GetUrn()),
+		Provider:     extractProvider(r),
+		Properties:   structToMap(r.GetProperties()),
+		Parent:       extractResourceID(r.GetParent()),
+		Dependencies: mapDependencies(r),
 	}
 }
 
+// mapDependencies returns the IDs of the resource's parent followed by those of the
+// resources it depends on, without duplicates.
+func mapDependencies(r *pulumirpc.AnalyzerResource) []string {
+	var deps []string
+	seen := make(map[string]bool)
+	for _, urn := range append([]string{r.GetParent()}, r.GetDependencies()...) {
+		id := extractResourceID(urn)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
 // MapResources converts a slice of AnalyzerResource to ResourceDescriptors.
 //
 // This is the primary entry point for batch resource mapping. All resources
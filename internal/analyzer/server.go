@@ -2,11 +2,13 @@ package analyzer
 
 import (
 	"context"
-	"strings"
+	"strconv"
 	"sync"
+	"time"
 
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/ingest"
 	"github.com/rshade/finfocus/internal/logging"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -20,33 +22,8 @@ const (
 
 	// analyzerDescription provides a description of the analyzer's purpose.
 	analyzerDescription = "Provides real-time cost estimation for Pulumi infrastructure resources during preview operations."
-
-	// internalTypePrefix identifies internal Pulumi resource types that have no cost.
-	internalTypePrefix = "pulumi:"
 )
 
-// isInternalPulumiType checks if a resource type is an internal Pulumi type
-// that has no associated cloud cost (e.g., pulumi:pulumi:Stack, pulumi:providers:aws).
-// isInternalPulumiType reports whether resourceType is an internal Pulumi resource
-// type (has the "pulumi:" prefix) and therefore should be treated as having zero
-// cloud cost.
-func isInternalPulumiType(resourceType string) bool {
-	return strings.HasPrefix(resourceType, internalTypePrefix)
-}
-
-// zeroCostResult returns an engine.CostResult representing zero cloud cost for an internal Pulumi resource.
-// The result uses USD currency, sets monthly and hourly costs to 0, and includes a note indicating the resource is internal.
-func zeroCostResult(resourceType, resourceID string) engine.CostResult {
-	return engine.CostResult{
-		ResourceType: resourceType,
-		ResourceID:   resourceID,
-		Currency:     "USD",
-		Monthly:      0,
-		Hourly:       0,
-		Notes:        "Internal Pulumi resource (no cloud cost)",
-	}
-}
-
 // CostCalculator is the interface for calculating projected costs.
 //
 // This interface abstracts the cost calculation engine, allowing for
@@ -91,6 +68,19 @@ type Server struct {
 	// Used by AnalyzeStack() to generate accurate stack summaries
 	costCacheMu sync.RWMutex
 	costCache   map[string]engine.CostResult // resourceID -> CostResult
+	envCache    map[string]string            // resourceID -> environment, when budgets are set
+
+	// Budgets evaluated in AnalyzeStack; nil disables budget diagnostics
+	budgets      *engine.BudgetPolicy
+	budgetTagKey string
+
+	// Resource types reported as $0 without pricing; the zero value matches internal
+	// Pulumi types only
+	zeroCostTypes engine.ZeroCostTypes
+
+	// Bounds on pricing the resources AnalyzeStack finds uncached; zero disables them
+	totalTimeout  time.Duration
+	warnThreshold time.Duration
 
 	// Cancellation support
 	cancelMu sync.Mutex
@@ -114,7 +104,67 @@ func NewServer(calculator CostCalculator, version string) *Server {
 		calculator: calculator,
 		version:    version,
 		costCache:  make(map[string]engine.CostResult),
+		envCache:   make(map[string]string),
+	}
+}
+
+// WithBudgetPolicy enables budget diagnostics in AnalyzeStack. Resources are assigned to
+// environments by their tagKey tag, falling back to the configured stack name.
+func (s *Server) WithBudgetPolicy(policy *engine.BudgetPolicy, tagKey string) *Server {
+	s.budgets = policy
+	s.budgetTagKey = tagKey
+	return s
+}
+
+// WithTimeouts bounds the cost calculation AnalyzeStack runs for resources that Analyze
+// did not price: it is canceled after total, and a warning is logged when it takes longer
+// than warnThreshold. Zero disables either bound.
+func (s *Server) WithTimeouts(total, warnThreshold time.Duration) *Server {
+	s.totalTimeout = total
+	s.warnThreshold = warnThreshold
+	return s
+}
+
+// WithZeroCostTypes reports the given resource types, in addition to internal Pulumi
+// types, as $0 without pricing them.
+func (s *Server) WithZeroCostTypes(types engine.ZeroCostTypes) *Server {
+	s.zeroCostTypes = types
+	return s
+}
+
+// cacheEnvironment records the environment of a resource for budget evaluation.
+func (s *Server) cacheEnvironment(resourceID, urn string, properties map[string]interface{}) {
+	if s.budgets == nil {
+		return
 	}
+	env := ingest.ResourceEnvironment(urn, properties, s.budgetTagKey)
+	s.costCacheMu.Lock()
+	defer s.costCacheMu.Unlock()
+	s.envCache[resourceID] = env
+}
+
+// budgetDiagnostics evaluates the configured budgets against the cached costs. Resources
+// without an environment count towards the stack's budget.
+func (s *Server) budgetDiagnostics(costs []engine.CostResult) []*pulumirpc.AnalyzeDiagnostic {
+	if s.budgets == nil {
+		return nil
+	}
+	s.costCacheMu.RLock()
+	envs := make(map[string]string, len(s.envCache))
+	for id, env := range s.envCache {
+		if env == "" {
+			env = s.stackName
+		}
+		envs[id] = env
+	}
+	s.costCacheMu.RUnlock()
+
+	evaluations := s.budgets.Evaluate(costs, envs)
+	diagnostics := make([]*pulumirpc.AnalyzeDiagnostic, 0, len(evaluations))
+	for _, eval := range evaluations {
+		diagnostics = append(diagnostics, BudgetDiagnostic(eval, s.version))
+	}
+	return diagnostics
 }
 
 // cacheCost stores a cost result in the cache for later use by AnalyzeStack.
@@ -140,6 +190,7 @@ func (s *Server) clearCostCache() {
 	s.costCacheMu.Lock()
 	defer s.costCacheMu.Unlock()
 	clear(s.costCache)
+	clear(s.envCache)
 }
 
 // Analyze analyzes a single resource and returns cost diagnostics.
@@ -157,10 +208,10 @@ func (s *Server) Analyze(
 	resourceType := req.GetType()
 	resourceID := extractResourceID(req.GetUrn())
 
-	// Handle internal Pulumi types (e.g., pulumi:pulumi:Stack, pulumi:providers:aws)
-	// These have no cloud cost and should return $0.00
-	if isInternalPulumiType(resourceType) {
-		cost := zeroCostResult(resourceType, resourceID)
+	// Handle internal Pulumi types (e.g., pulumi:pulumi:Stack, pulumi:providers:aws) and
+	// configured zero-cost types. These have no cloud cost and should return $0.00
+	if s.zeroCostTypes.Match(resourceType) {
+		cost := engine.ZeroCostResult(resourceType, resourceID)
 		// Cache the cost for AnalyzeStack summary
 		s.cacheCost(resourceID, cost)
 		return &pulumirpc.AnalyzeResponse{
@@ -177,6 +228,7 @@ func (s *Server) Analyze(
 		Provider:   extractProviderFromRequest(req),
 		Properties: structToMap(req.GetProperties()),
 	}
+	s.cacheEnvironment(resourceID, req.GetUrn(), resource.Properties)
 
 	// Calculate costs using the engine
 	costs, calcErr := s.calculator.GetProjectedCost(ctx, []engine.ResourceDescriptor{resource})
@@ -250,28 +302,117 @@ func (s *Server) Analyze(
 // preview or update. It receives the complete list of resources.
 //
 // Since Analyze() is called for each resource individually and already returns
-// per-resource cost diagnostics, AnalyzeStack() only returns the stack-level
-// summary to avoid duplicate diagnostics in the output.
+// per-resource cost diagnostics, AnalyzeStack() reuses those cached costs and only
+// prices the resources Analyze() never saw. They are priced in a single engine call,
+// so the engine's worker pool calculates them in parallel, and their per-resource
+// diagnostics precede one diagnostic per possibly unused resource and the stack-level
+// summary, followed by one budget diagnostic per environment when budgets are configured.
 //
 // All diagnostics use ADVISORY enforcement per FR-005.
 func (s *Server) AnalyzeStack(
-	_ context.Context,
-	_ *pulumirpc.AnalyzeStackRequest,
+	ctx context.Context,
+	req *pulumirpc.AnalyzeStackRequest,
 ) (*pulumirpc.AnalyzeResponse, error) {
+	diagnostics := s.priceUncachedResources(ctx, req.GetResources())
+
 	// Use costs cached from individual Analyze() calls for accurate summary
 	// This avoids re-querying plugins which may return different results
 	// due to different property formats between AnalyzeRequest and AnalyzerResource
 	cachedCosts := s.getCachedCosts()
-
-	// Only return the stack summary diagnostic
-	// Per-resource diagnostics are already returned by Analyze() calls
-	summary := StackSummaryDiagnostic(cachedCosts, s.version)
+	diagnostics = append(diagnostics, s.unusedResourceDiagnostics(req.GetResources(), cachedCosts)...)
+	diagnostics = append(diagnostics, StackSummaryDiagnostic(cachedCosts, s.version))
 
 	return &pulumirpc.AnalyzeResponse{
-		Diagnostics: []*pulumirpc.AnalyzeDiagnostic{summary},
+		Diagnostics: append(diagnostics, s.budgetDiagnostics(cachedCosts)...),
 	}, nil
 }
 
+// unusedResourceDiagnostics flags the stack's waste-prone resources that cost money but
+// that no other resource references.
+func (s *Server) unusedResourceDiagnostics(
+	resources []*pulumirpc.AnalyzerResource,
+	costs []engine.CostResult,
+) []*pulumirpc.AnalyzeDiagnostic {
+	urns := make(map[string]string, len(resources))
+	for _, r := range resources {
+		urns[extractResourceID(r.GetUrn())] = r.GetUrn()
+	}
+	recommendations := engine.DetectUnusedResources(MapResources(resources), costs)
+	diagnostics := make([]*pulumirpc.AnalyzeDiagnostic, 0, len(recommendations))
+	for _, rec := range recommendations {
+		diagnostics = append(diagnostics, UnusedResourceDiagnostic(rec, urns[rec.ResourceID], s.version))
+	}
+	return diagnostics
+}
+
+// priceUncachedResources calculates the costs of the stack's resources that have no
+// cached cost, caches them and returns their diagnostics. A failed or timed-out
+// calculation yields a single warning diagnostic so the summary is still reported.
+func (s *Server) priceUncachedResources(
+	ctx context.Context,
+	resources []*pulumirpc.AnalyzerResource,
+) []*pulumirpc.AnalyzeDiagnostic {
+	urns := make(map[string]string)
+	var pending []engine.ResourceDescriptor
+	s.costCacheMu.RLock()
+	for _, r := range resources {
+		if r == nil {
+			continue
+		}
+		id := extractResourceID(r.GetUrn())
+		if _, cached := s.costCache[id]; cached {
+			continue
+		}
+		if _, seen := urns[id]; seen {
+			continue
+		}
+		urns[id] = r.GetUrn()
+		pending = append(pending, MapResource(r))
+	}
+	s.costCacheMu.RUnlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	for _, resource := range pending {
+		s.cacheEnvironment(resource.ID, urns[resource.ID], resource.Properties)
+	}
+
+	if s.totalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.totalTimeout)
+		defer cancel()
+	}
+
+	log := logging.FromContext(ctx)
+	start := time.Now()
+	costs, err := s.calculator.GetProjectedCost(ctx, pending)
+	if elapsed := time.Since(start); s.warnThreshold > 0 && elapsed > s.warnThreshold {
+		log.Warn().
+			Ctx(ctx).
+			Str("component", "analyzer").
+			Int("resource_count", len(pending)).
+			Dur("duration", elapsed).
+			Dur("warn_threshold", s.warnThreshold).
+			Msg("stack cost calculation exceeded warning threshold")
+	}
+	if err != nil {
+		return []*pulumirpc.AnalyzeDiagnostic{
+			WarningDiagnostic(
+				"Cost calculation failed for "+strconv.Itoa(len(pending))+" resources: "+err.Error(),
+				"",
+				s.version,
+			),
+		}
+	}
+
+	diagnostics := make([]*pulumirpc.AnalyzeDiagnostic, 0, len(costs))
+	for _, cost := range costs {
+		s.cacheCost(cost.ResourceID, cost)
+		diagnostics = append(diagnostics, CostToDiagnostic(cost, urns[cost.ResourceID], s.version))
+	}
+	return diagnostics
+}
+
 // GetAnalyzerInfo returns metadata about this analyzer.
 //
 // This method provides information about the policies contained in this
@@ -280,7 +421,7 @@ func (s *Server) GetAnalyzerInfo(
 	_ context.Context,
 	_ *emptypb.Empty,
 ) (*pulumirpc.AnalyzerInfo, error) {
-	return &pulumirpc.AnalyzerInfo{
+	info := &pulumirpc.AnalyzerInfo{
 		Name:        policyPackName,
 		DisplayName: analyzerDisplayName,
 		Version:     s.version,
@@ -298,9 +439,24 @@ func (s *Server) GetAnalyzerInfo(
 				Description:      "Provides total estimated monthly cost across all resources in the stack",
 				EnforcementLevel: pulumirpc.EnforcementLevel_ADVISORY,
 			},
+			{
+				Name:             policyNameUnused,
+				DisplayName:      "Possibly Unused Resource",
+				Description:      "Flags resources that cost money but that no other resource in the stack references",
+				EnforcementLevel: pulumirpc.EnforcementLevel_ADVISORY,
+			},
 		},
 		SupportsConfig: false,
-	}, nil
+	}
+	if s.budgets != nil {
+		info.Policies = append(info.Policies, &pulumirpc.PolicyInfo{
+			Name:             policyNameBudget,
+			DisplayName:      "Environment Budget",
+			Description:      "Reports each environment's estimated monthly cost against its configured budget",
+			EnforcementLevel: pulumirpc.EnforcementLevel_ADVISORY,
+		})
+	}
+	return info, nil
 }
 
 // GetPluginInfo returns generic information about this plugin.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 	"github.com/rshade/finfocus/internal/engine"
@@ -232,6 +233,42 @@ func TestServer_AnalyzeStack(t *testing.T) {
 	}
 }
 
+func TestServer_AnalyzeStack_UnusedResources(t *testing.T) {
+	const (
+		instanceURN = "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::web"
+		dataURN     = "urn:pulumi:dev::myapp::aws:ebs/volume:Volume::data"
+		orphanURN   = "urn:pulumi:dev::myapp::aws:ebs/volume:Volume::orphan"
+	)
+	resources := []*pulumirpc.AnalyzerResource{
+		{Type: "aws:ec2/instance:Instance", Urn: instanceURN, Name: "web", Dependencies: []string{dataURN}},
+		{Type: "aws:ebs/volume:Volume", Urn: dataURN, Name: "data"},
+		{Type: "aws:ebs/volume:Volume", Urn: orphanURN, Name: "orphan"},
+	}
+	calc := &mockCostCalculator{
+		results: []engine.CostResult{
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "USD", Monthly: 7.59},
+			{ResourceType: "aws:ebs/volume:Volume", ResourceID: "data", Currency: "USD", Monthly: 8},
+			{ResourceType: "aws:ebs/volume:Volume", ResourceID: "orphan", Currency: "USD", Monthly: 10},
+		},
+	}
+	server := NewServer(calc, "0.1.0")
+
+	resp, err := server.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{Resources: resources})
+	require.NoError(t, err)
+
+	var unused []*pulumirpc.AnalyzeDiagnostic
+	for _, diag := range resp.GetDiagnostics() {
+		if diag.GetPolicyName() == policyNameUnused {
+			unused = append(unused, diag)
+		}
+	}
+	require.Len(t, unused, 1)
+	assert.Equal(t, orphanURN, unused[0].GetUrn())
+	assert.Contains(t, unused[0].GetMessage(), "Possibly unused EBS volume")
+	assert.Contains(t, unused[0].GetMessage(), "$10.00 USD/mo")
+	assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, unused[0].GetEnforcementLevel())
+}
+
 func TestServer_AnalyzeStack_WithProperties(t *testing.T) {
 	// Test that properties are correctly passed through to the cost calculator
 	props, err := structpb.NewStruct(map[string]interface{}{
@@ -352,7 +389,7 @@ func TestServer_GetAnalyzerInfo(t *testing.T) {
 	assert.NotEmpty(t, resp.GetDescription())
 
 	// Check policies are defined
-	require.Len(t, resp.GetPolicies(), 2)
+	require.Len(t, resp.GetPolicies(), 3)
 
 	// Check cost-estimate policy
 	costPolicy := resp.GetPolicies()[0]
@@ -363,6 +400,11 @@ func TestServer_GetAnalyzerInfo(t *testing.T) {
 	summaryPolicy := resp.GetPolicies()[1]
 	assert.Equal(t, "stack-cost-summary", summaryPolicy.GetName())
 	assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, summaryPolicy.GetEnforcementLevel())
+
+	// Check possibly-unused-resource policy
+	unusedPolicy := resp.GetPolicies()[2]
+	assert.Equal(t, "possibly-unused-resource", unusedPolicy.GetName())
+	assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, unusedPolicy.GetEnforcementLevel())
 }
 
 func TestServer_GetPluginInfo(t *testing.T) {
@@ -550,68 +592,6 @@ func TestServer_Cancel_ThreadSafe(t *testing.T) {
 	assert.True(t, server.IsCanceled())
 }
 
-func TestIsInternalPulumiType(t *testing.T) {
-	tests := []struct {
-		name         string
-		resourceType string
-		want         bool
-	}{
-		{
-			name:         "pulumi stack resource",
-			resourceType: "pulumi:pulumi:Stack",
-			want:         true,
-		},
-		{
-			name:         "pulumi AWS provider",
-			resourceType: "pulumi:providers:aws",
-			want:         true,
-		},
-		{
-			name:         "pulumi Azure provider",
-			resourceType: "pulumi:providers:azure",
-			want:         true,
-		},
-		{
-			name:         "AWS EC2 instance",
-			resourceType: "aws:ec2/instance:Instance",
-			want:         false,
-		},
-		{
-			name:         "Azure VM",
-			resourceType: "azure:compute/virtualMachine:VirtualMachine",
-			want:         false,
-		},
-		{
-			name:         "GCP compute instance",
-			resourceType: "gcp:compute/instance:Instance",
-			want:         false,
-		},
-		{
-			name:         "empty type",
-			resourceType: "",
-			want:         false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := isInternalPulumiType(tt.resourceType)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
-func TestZeroCostResult(t *testing.T) {
-	result := zeroCostResult("pulumi:pulumi:Stack", "my-stack")
-
-	assert.Equal(t, "pulumi:pulumi:Stack", result.ResourceType)
-	assert.Equal(t, "my-stack", result.ResourceID)
-	assert.Equal(t, "USD", result.Currency)
-	assert.Equal(t, float64(0), result.Monthly)
-	assert.Equal(t, float64(0), result.Hourly)
-	assert.Equal(t, "Internal Pulumi resource (no cloud cost)", result.Notes)
-}
-
 func TestServer_Analyze_InternalPulumiType(t *testing.T) {
 	calc := &mockCostCalculator{
 		// Should NOT be called for internal types
@@ -622,7 +602,7 @@ func TestServer_Analyze_InternalPulumiType(t *testing.T) {
 			},
 		},
 	}
-	server := NewServer(calc, "1.0.0")
+	server := NewServer(calc, "1.0.0").WithZeroCostTypes(engine.NewZeroCostTypes([]string{"my-components:*"}))
 
 	tests := []struct {
 		name         string
@@ -630,6 +610,12 @@ func TestServer_Analyze_InternalPulumiType(t *testing.T) {
 		urn          string
 		wantNotes    string
 	}{
+		{
+			name:         "configured component type",
+			resourceType: "my-components:index:Vpc",
+			urn:          "urn:pulumi:dev::myapp::my-components:index:Vpc::network",
+			wantNotes:    "Internal Pulumi resource (no cloud cost)",
+		},
 		{
 			name:         "pulumi stack",
 			resourceType: "pulumi:pulumi:Stack",
@@ -849,3 +835,156 @@ func TestServer_AnalyzeStack_SummaryWithRecommendations(t *testing.T) {
 	summary := resp.GetDiagnostics()[0]
 	assert.Contains(t, summary.GetMessage(), "1 recommendations with $20.00/mo potential savings")
 }
+
+func TestServer_AnalyzeStack_Budgets(t *testing.T) {
+	calc := &mockCostCalculator{
+		results: []engine.CostResult{
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "USD", Monthly: 90},
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "api", Currency: "USD", Monthly: 40},
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "batch", Currency: "USD", Monthly: 5},
+		},
+	}
+	policy, err := engine.NewBudgetPolicy([]engine.EnvironmentBudget{
+		{Environment: "prod", Amount: 100},
+		{Environment: "staging", Amount: 50},
+		{Environment: "dev", Amount: 50},
+	})
+	require.NoError(t, err)
+	server := NewServer(calc, "1.0.0").WithBudgetPolicy(policy, "env")
+
+	info, err := server.GetAnalyzerInfo(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, info.GetPolicies(), 4)
+	assert.Equal(t, policyNameBudget, info.GetPolicies()[3].GetName())
+
+	_, err = server.ConfigureStack(context.Background(), &pulumirpc.AnalyzerStackConfigureRequest{Stack: "dev"})
+	require.NoError(t, err)
+	for name, tags := range map[string]map[string]interface{}{
+		"web":   {"Env": "prod"},
+		"api":   {"Env": "staging"},
+		"batch": nil,
+	} {
+		props, propErr := structpb.NewStruct(map[string]interface{}{"tags": tags})
+		require.NoError(t, propErr)
+		_, err = server.Analyze(context.Background(), &pulumirpc.AnalyzeRequest{
+			Type:       "aws:ec2/instance:Instance",
+			Urn:        "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::" + name,
+			Properties: props,
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := server.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{})
+	require.NoError(t, err)
+	diags := resp.GetDiagnostics()
+	require.Len(t, diags, 4, "summary plus one diagnostic per environment")
+
+	want := map[string]pulumirpc.PolicySeverity{
+		"Environment dev:":     pulumirpc.PolicySeverity_POLICY_SEVERITY_LOW,
+		"Environment prod:":    pulumirpc.PolicySeverity_POLICY_SEVERITY_MEDIUM,
+		"Environment staging:": pulumirpc.PolicySeverity_POLICY_SEVERITY_MEDIUM,
+	}
+	for _, diag := range diags[1:] {
+		assert.Equal(t, policyNameBudget, diag.GetPolicyName())
+		assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, diag.GetEnforcementLevel())
+		for prefix, severity := range want {
+			if strings.HasPrefix(diag.GetMessage(), prefix) {
+				assert.Equal(t, severity, diag.GetSeverity(), diag.GetMessage())
+				delete(want, prefix)
+			}
+		}
+	}
+	assert.Empty(t, want)
+	assert.Contains(t, diags[2].GetMessage(), "projected 90.00 of 100.00 USD monthly budget (90%), status WARNING")
+}
+
+// batchCostCalculator records every batch it is asked to price and, when block is set,
+// waits for the context to be done.
+type batchCostCalculator struct {
+	mockCostCalculator
+
+	block   bool
+	batches [][]engine.ResourceDescriptor
+}
+
+func (b *batchCostCalculator) GetProjectedCost(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+) ([]engine.CostResult, error) {
+	b.batches = append(b.batches, resources)
+	if b.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return b.mockCostCalculator.GetProjectedCost(ctx, resources)
+}
+
+func TestServer_AnalyzeStack_PricesUncachedResources(t *testing.T) {
+	calc := &batchCostCalculator{mockCostCalculator: mockCostCalculator{
+		results: []engine.CostResult{
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "USD", Monthly: 10},
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "api", Currency: "USD", Monthly: 20},
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "batch", Currency: "USD", Monthly: 30},
+		},
+	}}
+	server := NewServer(calc, "1.0.0")
+
+	_, err := server.Analyze(context.Background(), &pulumirpc.AnalyzeRequest{
+		Type: "aws:ec2/instance:Instance",
+		Urn:  "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::web",
+	})
+	require.NoError(t, err)
+	calc.batches = nil
+
+	resources := make([]*pulumirpc.AnalyzerResource, 0, 3)
+	for _, name := range []string{"web", "api", "batch"} {
+		resources = append(resources, &pulumirpc.AnalyzerResource{
+			Type: "aws:ec2/instance:Instance",
+			Urn:  "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::" + name,
+		})
+	}
+	resp, err := server.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{Resources: resources})
+	require.NoError(t, err)
+
+	require.Len(t, calc.batches, 1, "uncached resources are priced in one batch")
+	require.Len(t, calc.batches[0], 2)
+	assert.Equal(t, "api", calc.batches[0][0].ID)
+	assert.Equal(t, "batch", calc.batches[0][1].ID)
+
+	diags := resp.GetDiagnostics()
+	require.Len(t, diags, 3, "one diagnostic per newly priced resource plus the summary")
+	assert.Equal(t, "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::api", diags[0].GetUrn())
+	assert.Equal(t, "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::batch", diags[1].GetUrn())
+	assert.Equal(t, policyNameSum, diags[2].GetPolicyName())
+	assert.Contains(t, diags[2].GetMessage(), "$60.00")
+}
+
+func TestServer_AnalyzeStack_TotalTimeout(t *testing.T) {
+	calc := &batchCostCalculator{block: true}
+	server := NewServer(calc, "1.0.0").WithTimeouts(10*time.Millisecond, 0)
+
+	resp, err := server.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{
+		Resources: []*pulumirpc.AnalyzerResource{{
+			Type: "aws:ec2/instance:Instance",
+			Urn:  "urn:pulumi:dev::myapp::aws:ec2/instance:Instance::web",
+		}},
+	})
+	require.NoError(t, err)
+
+	diags := resp.GetDiagnostics()
+	require.Len(t, diags, 2, "a warning plus the summary")
+	assert.Contains(t, diags[0].GetMessage(), "Cost calculation failed for 1 resources")
+	assert.Contains(t, diags[0].GetMessage(), context.DeadlineExceeded.Error())
+	assert.Equal(t, policyNameSum, diags[1].GetPolicyName())
+}
+
+func TestBudgetDiagnostic_Critical(t *testing.T) {
+	diag := BudgetDiagnostic(engine.BudgetEvaluation{
+		Environment: "prod", Budget: 100, Total: 150, Currency: "USD", PercentUsed: 150,
+		Status: engine.BudgetStatusCritical, Excluded: 2,
+	}, "1.0.0")
+	assert.Equal(t, pulumirpc.PolicySeverity_POLICY_SEVERITY_HIGH, diag.GetSeverity())
+	assert.Empty(t, diag.GetUrn())
+	assert.Contains(t, diag.GetMessage(), "status CRITICAL")
+	assert.Contains(t, diag.GetMessage(), "2 resources in other currencies not counted")
+}
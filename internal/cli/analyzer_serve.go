@@ -99,8 +99,8 @@ func RunAnalyzerServe(cmd *cobra.Command) error {
 	// Load configuration
 	cfg := config.New()
 
-	// Create spec loader for fallback pricing
-	specLoader := spec.NewLoader(cfg.SpecDir)
+	// Create spec loader for fallback pricing; cached specs are reloaded when edited
+	specLoader := spec.NewCachingLoader(spec.NewLoader(cfg.SpecDir))
 
 	// Create registry for plugin discovery
 	reg := registry.NewDefault()
@@ -118,7 +118,35 @@ func RunAnalyzerServe(cmd *cobra.Command) error {
 	stderrLogger.Debug().Int("plugin_count", len(clients)).Msg("plugins loaded")
 
 	// Create the cost calculation engine
-	eng := engine.New(clients, specLoader)
+	opts := newEngineOptions(cfg)
+	opts.PerResourceTimeout = cfg.Analyzer.Timeout.PerResource.Duration()
+	eng, err := engine.New(clients, specLoader, opts)
+	if err != nil {
+		return fmt.Errorf("creating engine: %w", err)
+	}
+	eng.WithPluginLayers(newPluginLayers(cfg))
+	if suppressions, suppressErr := engine.ParseRecommendationSuppressions(
+		cfg.Recommendations.Suppress,
+	); suppressErr != nil {
+		stderrLogger.Warn().Err(suppressErr).Msg("ignoring invalid recommendations.suppress configuration")
+	} else {
+		eng.WithRecommendationSuppressions(suppressions)
+	}
+	if transforms, transformErr := newTransformChain(cfg); transformErr != nil {
+		stderrLogger.Warn().Err(transformErr).Msg("ignoring invalid transforms configuration")
+	} else {
+		eng.WithResultTransforms(transforms)
+	}
+	if customTypes, customErr := newCustomTypeRules(cfg); customErr != nil {
+		stderrLogger.Warn().Err(customErr).Msg("ignoring invalid custom_types configuration")
+	} else {
+		eng.WithCustomTypes(customTypes)
+	}
+	if costRules, rulesErr := newCostRules(cfg, ""); rulesErr != nil {
+		stderrLogger.Warn().Err(rulesErr).Msg("ignoring invalid cost_rules file")
+	} else {
+		eng.WithCostRules(costRules)
+	}
 
 	// Create the analyzer server
 	// Use the version from the command's root if available
@@ -126,7 +154,17 @@ func RunAnalyzerServe(cmd *cobra.Command) error {
 	if version == "" {
 		version = "0.0.0-dev"
 	}
-	server := analyzer.NewServer(eng, version)
+	server := analyzer.NewServer(eng, version).WithTimeouts(
+		cfg.Analyzer.Timeout.Total.Duration(),
+		cfg.Analyzer.Timeout.WarnThreshold.Duration(),
+	).WithZeroCostTypes(engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes))
+	if len(cfg.Budgets.Environments) > 0 {
+		if budgets, tagKey, budgetErr := newBudgetPolicy(cfg); budgetErr != nil {
+			stderrLogger.Warn().Err(budgetErr).Msg("ignoring invalid budgets configuration")
+		} else {
+			server.WithBudgetPolicy(budgets, tagKey)
+		}
+	}
 
 	// Listen on random port
 	//nolint:gosec,noctx // G102: Intentionally binds to all interfaces for Pulumi plugin protocol
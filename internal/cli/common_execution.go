@@ -3,13 +3,20 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/ingest"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/pluginhost"
 	"github.com/rshade/finfocus/internal/registry"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/rshade/finfocus/internal/tui"
+	"github.com/spf13/cobra"
 )
 
 // auditContext holds common context for audit logging within a cost command.
@@ -50,13 +57,18 @@ func (a *auditContext) logSuccess(ctx context.Context, count int, cost float64)
 	a.logger.Log(ctx, *entry)
 }
 
-// loadAndMapResources loads a Pulumi plan and maps its resources.
+// loadAndMapResources loads a Pulumi plan and maps its resources, extracting the
+// given annotation keys (if any) into each descriptor. Resources matching zeroCost are
+// dropped.
 func loadAndMapResources(
 	ctx context.Context,
 	planPath string,
 	audit *auditContext,
+	zeroCost engine.ZeroCostTypes,
+	annotationKeys ...string,
 ) ([]engine.ResourceDescriptor, error) {
 	log := logging.FromContext(ctx)
+	defer engine.TimingsFromContext(ctx).Track(engine.StageIngest)()
 
 	plan, err := ingest.LoadPulumiPlanWithContext(ctx, planPath)
 	if err != nil {
@@ -65,7 +77,7 @@ func loadAndMapResources(
 		return nil, fmt.Errorf("loading Pulumi plan: %w", err)
 	}
 
-	resources, err := ingest.MapResources(plan.GetResourcesWithContext(ctx))
+	resources, err := ingest.MapResourcesWithAnnotations(plan.GetResourcesWithContext(ctx), annotationKeys)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to map resources")
 		audit.logFailure(ctx, err)
@@ -73,14 +85,131 @@ func loadAndMapResources(
 	}
 	log.Debug().Ctx(ctx).Int("resource_count", len(resources)).Msg("resources loaded from plan")
 
-	return resources, nil
+	return excludeInternalResources(ctx, zeroCost, resources), nil
 }
 
-// openPlugins opens the requested adapter plugins.
-func openPlugins(ctx context.Context, adapter string, audit *auditContext) ([]*pluginhost.Client, func(), error) {
+// excludeInternalResources drops internal Pulumi resources (stack, providers) and the
+// configured zero-cost types so they do not appear as unpriced rows in reports.
+func excludeInternalResources(
+	ctx context.Context,
+	zeroCost engine.ZeroCostTypes,
+	resources []engine.ResourceDescriptor,
+) []engine.ResourceDescriptor {
+	kept, excluded := zeroCost.Exclude(resources)
+	if excluded > 0 {
+		logging.FromContext(ctx).Debug().Ctx(ctx).Int("excluded_count", excluded).
+			Msg("excluded internal Pulumi resources")
+	}
+	return kept
+}
+
+// applyAllocationTags resolves the allocation tag keys from the flag, or from
+// output.allocation_tags when the flag is empty, and attaches each resource's values. It
+// returns the normalized keys in order for use as output columns.
+func applyAllocationTags(
+	resources []engine.ResourceDescriptor,
+	flagKeys []string,
+	cfg *config.Config,
+) ([]string, error) {
+	keys := flagKeys
+	if len(keys) == 0 {
+		keys = cfg.Output.AllocationTags
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if n := ingest.NormalizeTagKey(key); !seen[n] {
+			seen[n] = true
+			normalized = append(normalized, n)
+		}
+	}
+	if err := engine.ValidateAllocationTagKeys(normalized); err != nil {
+		return nil, err
+	}
+	for i := range resources {
+		resources[i].AllocationTags = ingest.ExtractAllocationTags(resources[i].Properties, normalized)
+	}
+	return normalized, nil
+}
+
+// pluginLaunchParams holds the --max-plugins, --lazy-plugins, --offline, --locked and
+// --validate-plugins flags shared by the cost commands.
+type pluginLaunchParams struct {
+	maxPlugins int
+	lazy       bool
+	offline    bool
+	locked     bool
+	validate   bool
+}
+
+// addPluginLaunchFlags registers --max-plugins, --lazy-plugins, --offline, --locked and
+// --validate-plugins on cmd.
+func addPluginLaunchFlags(cmd *cobra.Command, params *pluginLaunchParams) {
+	cmd.Flags().IntVar(&params.maxPlugins, "max-plugins", 0,
+		"Maximum number of plugins launched and queried concurrently (0 = no limit)")
+	cmd.Flags().BoolVar(&params.lazy, "lazy-plugins", false,
+		"Only launch plugins whose manifest declares a provider used by the stack")
+	cmd.Flags().BoolVar(&params.offline, "offline", false,
+		"Never launch plugins; price resources from local specs only (also specs.offline)")
+	cmd.Flags().BoolVar(&params.locked, "locked", false,
+		"Only launch the plugin versions pinned in "+registry.LockfileName+"; fail if one is not installed")
+	cmd.Flags().BoolVar(&params.validate, "validate-plugins", false,
+		"Health-check plugins before pricing and skip those that are not ready")
+}
+
+// resolveOffline combines --offline with the specs.offline setting, recording the result
+// in both so that plugin launch and spec loading agree.
+func (p *pluginLaunchParams) resolveOffline(cfg *config.Config) {
+	p.offline = p.offline || cfg.Specs.Offline
+	cfg.Specs.Offline = p.offline
+}
+
+// openOptions converts the flags into registry options for resources.
+func (p pluginLaunchParams) openOptions(resources []engine.ResourceDescriptor) registry.OpenOptions {
+	opts := registry.OpenOptions{MaxConcurrent: p.maxPlugins, Offline: p.offline}
+	if p.locked {
+		opts.LockfilePath = registry.LockfileName
+	}
+	if p.lazy {
+		seen := make(map[string]bool)
+		for _, r := range resources {
+			if r.Provider != "" && !seen[r.Provider] {
+				seen[r.Provider] = true
+				opts.Providers = append(opts.Providers, r.Provider)
+			}
+		}
+	}
+	return opts
+}
+
+// engineOptions adds the --max-plugins and --validate-plugins flags to the engine
+// settings of cfg.
+func (p pluginLaunchParams) engineOptions(cfg *config.Config) engine.EngineOptions {
+	opts := newEngineOptions(cfg)
+	opts.MaxConcurrentPluginCalls = p.maxPlugins
+	opts.ValidatePlugins = p.validate
+	return opts
+}
+
+// openPlugins opens the requested adapter plugins, applying the optional launch options.
+func openPlugins(
+	ctx context.Context,
+	adapter string,
+	audit *auditContext,
+	opts ...registry.OpenOptions,
+) ([]*pluginhost.Client, func(), error) {
 	log := logging.FromContext(ctx)
+	defer engine.TimingsFromContext(ctx).Track(engine.StagePluginLaunch)()
 
-	clients, cleanup, err := registry.NewDefault().Open(ctx, adapter)
+	var openOpts registry.OpenOptions
+	if len(opts) > 0 {
+		openOpts = opts[0]
+	}
+	clients, cleanup, err := registry.NewDefault().OpenWithOptions(ctx, adapter, openOpts)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Str("adapter", adapter).Msg("failed to open plugins")
 		audit.logFailure(ctx, err)
@@ -90,3 +219,351 @@ func openPlugins(ctx context.Context, adapter string, audit *auditContext) ([]*p
 
 	return clients, cleanup, nil
 }
+
+// newSpecLoader returns a caching spec loader for specDir that also searches the cached
+// copy of the configured remote spec source, refreshing it first when its TTL has expired.
+func newSpecLoader(ctx context.Context, cfg *config.Config, specDir string) *spec.CachingLoader {
+	defer engine.TimingsFromContext(ctx).Track(engine.StageSpecLoad)()
+	if cfg.Specs.Remote.URL == "" {
+		return spec.NewCachingLoader(spec.NewLoader(specDir))
+	}
+	if cfg.Specs.Offline {
+		return spec.NewCachingLoader(spec.NewLoaderWithFallback(specDir, cfg.RemoteSpecCacheDir()))
+	}
+	return spec.NewCachingLoader(spec.NewLoaderWithFallback(specDir, newRemoteSpecSource(cfg).EnsureFresh(ctx)))
+}
+
+// newEngineOptions returns the engine settings from the engine section of cfg. Commands
+// add their flag-driven settings before passing them to engine.New, which validates them.
+func newEngineOptions(cfg *config.Config) engine.EngineOptions {
+	return engine.EngineOptions{
+		Workers:              cfg.Engine.Workers,
+		ActualCostWorkers:    cfg.Engine.ActualCostWorkers,
+		ActualCostRateLimit:  cfg.Engine.ActualCostRateLimit,
+		PluginConcurrency:    cfg.Engine.PluginConcurrency,
+		DisableResponseCache: cfg.Engine.DisableResponseCache,
+		HoursPerMonth:        cfg.Engine.HoursPerMonth,
+		RetryAttempts:        cfg.Engine.RetryAttempts,
+		RetryBaseDelay:       cfg.Engine.RetryBaseDelay.Duration(),
+		ZeroCostTypes:        cfg.Engine.ZeroCostTypes,
+	}
+}
+
+// newPricingCache returns the cache for plugin prices that carry an ETag, with the TTLs
+// configured per plugin. Invalid per-plugin TTLs are ignored with a warning, so those
+// prices fall back to the default TTL.
+func newPricingCache(cfg *config.Config) *engine.PricingCache {
+	cache := engine.NewPricingCache(cfg.PricingCacheDir(), cfg.PricingCacheTTL())
+	ttls, err := cfg.PluginCacheTTLs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring per-plugin cache TTLs: %v\n", err)
+		return cache
+	}
+	return cache.WithPluginTTLs(ttls)
+}
+
+// newPluginLayers returns the plugin roles that layer adjustment plugins over base
+// plugins. Invalid roles are ignored with a warning, so every plugin is reported on its own.
+func newPluginLayers(cfg *config.Config) []engine.PluginLayer {
+	configured, err := cfg.PluginLayers()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring plugin roles: %v\n", err)
+		return nil
+	}
+	layers := make([]engine.PluginLayer, 0, len(configured))
+	for _, l := range configured {
+		layers = append(layers, engine.PluginLayer{
+			Plugin: l.Name,
+			Role:   engine.PluginRole(l.Role),
+			Mode:   engine.AdjustmentMode(l.Adjustment),
+			Order:  l.Order,
+		})
+	}
+	return layers
+}
+
+// newTransformChain builds the result transform chain from the transforms configuration.
+func newTransformChain(cfg *config.Config) (engine.TransformChain, error) {
+	specs := make([]engine.TransformSpec, 0, len(cfg.Transforms))
+	for _, t := range cfg.Transforms {
+		specs = append(specs, engine.TransformSpec{
+			Type:     t.Type,
+			Percent:  t.Percent,
+			Decimals: t.Decimals,
+			From:     t.From,
+			To:       t.To,
+			Rate:     t.Rate,
+		})
+	}
+	chain, err := engine.NewTransformChain(specs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transforms configuration: %w", err)
+	}
+	return chain, nil
+}
+
+// newCustomTypeRules builds the pricing rules for unrecognized resource types from the
+// custom_types configuration.
+func newCustomTypeRules(cfg *config.Config) (engine.CustomTypeRules, error) {
+	rules := make([]engine.CustomTypeRule, 0, len(cfg.CustomTypes))
+	for _, ct := range cfg.CustomTypes {
+		rules = append(rules, engine.CustomTypeRule{
+			Type:     ct.Type,
+			Action:   ct.Action,
+			MapTo:    ct.MapTo,
+			Monthly:  ct.Monthly,
+			Currency: ct.Currency,
+		})
+	}
+	customTypes, err := engine.NewCustomTypeRules(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom_types configuration: %w", err)
+	}
+	return customTypes, nil
+}
+
+// newCostRules loads the cost rules file named by flagPath, or by the cost_rules
+// configuration when flagPath is empty. No file means no rules.
+func newCostRules(cfg *config.Config, flagPath string) (engine.CostRules, error) {
+	path := flagPath
+	if path == "" {
+		path = cfg.CostRules
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return engine.LoadCostRules(path)
+}
+
+// envAnonymizeSalt salts --anonymize pseudonyms so they cannot be reversed by hashing
+// guessed resource names.
+const envAnonymizeSalt = "FINFOCUS_ANONYMIZE_SALT"
+
+// newAnonymizer returns the anonymizer for --anonymize, redacting the tags listed in
+// output.redact_tags.
+func newAnonymizer(cfg *config.Config) *engine.Anonymizer {
+	return engine.NewAnonymizer(os.Getenv(envAnonymizeSalt), cfg.Output.RedactTags)
+}
+
+// startTimings attaches a timing recorder to ctx when enabled and returns a function that
+// prints the breakdown to stderr. Both are no-ops when timing is disabled.
+func startTimings(ctx context.Context, cmd *cobra.Command, enabled bool) (context.Context, func()) {
+	if !enabled {
+		return ctx, func() {}
+	}
+	timings := engine.NewTimings()
+	return engine.ContextWithTimings(ctx, timings), func() {
+		_ = timings.Write(cmd.ErrOrStderr())
+	}
+}
+
+// Exit codes of a command whose projected costs cross a budget threshold. They differ from
+// the generic failure code so CI can tell a budget alert from a broken run.
+const (
+	exitCodeBudgetWarning  = 3
+	exitCodeBudgetCritical = 4
+)
+
+// budgetFailOnNone reports budgets without failing the command.
+const budgetFailOnNone = "none"
+
+// newBudgetPolicy builds the budget policy from the budgets configuration, along with the
+// tag key that assigns resources to environments. The policy is empty when no budgets are
+// configured.
+func newBudgetPolicy(cfg *config.Config) (*engine.BudgetPolicy, string, error) {
+	budgets := make([]engine.EnvironmentBudget, 0, len(cfg.Budgets.Environments))
+	for env, b := range cfg.Budgets.Environments {
+		budgets = append(budgets, engine.EnvironmentBudget{
+			Environment:     env,
+			Amount:          b.Amount,
+			Currency:        b.Currency,
+			WarnPercent:     b.WarnPercent,
+			CriticalPercent: b.CriticalPercent,
+		})
+	}
+	policy, err := engine.NewBudgetPolicy(budgets)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid budgets configuration: %w", err)
+	}
+	tagKey := cfg.Budgets.TagKey
+	if tagKey == "" {
+		tagKey = engine.DefaultBudgetTagKey
+	}
+	return policy, tagKey, nil
+}
+
+// resourceEnvironments maps each resource ID to its environment: the value of tagKey, or
+// the stack in its URN.
+func resourceEnvironments(resources []engine.ResourceDescriptor, tagKey string) map[string]string {
+	envs := make(map[string]string, len(resources))
+	for _, r := range resources {
+		envs[r.ID] = ingest.ResourceEnvironment(r.ID, r.Properties, tagKey)
+	}
+	return envs
+}
+
+// budgetLimitParams holds the --budget-* flags that cap a stack's projected monthly cost.
+type budgetLimitParams struct {
+	total     float64
+	providers []string
+	currency  string
+}
+
+// addBudgetLimitFlags registers the --budget-total, --budget-provider and --budget-currency
+// flags on cmd.
+func addBudgetLimitFlags(cmd *cobra.Command, params *budgetLimitParams) {
+	cmd.Flags().Float64Var(&params.total, "budget-total", 0,
+		"Fail (exit 4) when the projected monthly total exceeds this amount")
+	cmd.Flags().StringSliceVar(&params.providers, "budget-provider", []string{},
+		"Fail (exit 4) when a provider's projected monthly cost exceeds its cap, as provider=amount")
+	cmd.Flags().StringVar(&params.currency, "budget-currency", "USD", "Currency of the --budget-* amounts")
+}
+
+// limits parses the flags into budget limits, reporting false when no limit is set.
+func (p budgetLimitParams) limits() (engine.BudgetLimits, bool, error) {
+	limits := engine.BudgetLimits{Currency: p.currency, TotalMonthly: p.total}
+	if p.total < 0 {
+		return limits, false, fmt.Errorf("invalid --budget-total %g: must not be negative", p.total)
+	}
+	for _, providerCap := range p.providers {
+		provider, amount, ok := strings.Cut(providerCap, "=")
+		value, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+		if !ok || strings.TrimSpace(provider) == "" || err != nil || value < 0 {
+			return limits, false, fmt.Errorf("invalid --budget-provider %q: use provider=amount", providerCap)
+		}
+		if limits.ByProvider == nil {
+			limits.ByProvider = make(map[string]float64)
+		}
+		limits.ByProvider[strings.TrimSpace(provider)] = value
+	}
+	return limits, limits.TotalMonthly > 0 || len(limits.ByProvider) > 0, nil
+}
+
+// reportBudgetLimits checks results against the --budget-* limits and renders the exceeded
+// limits like reportBudgets. It returns an exitError with the critical budget exit code when
+// any limit is exceeded, and an error when the limits and costs are in different currencies.
+func reportBudgetLimits(
+	cmd *cobra.Command,
+	output string,
+	params budgetLimitParams,
+	results []engine.CostResult,
+) error {
+	limits, set, err := params.limits()
+	if err != nil || !set {
+		return err
+	}
+	violations, err := engine.EvaluateBudget(engine.AggregateResults(results), limits)
+	if err != nil {
+		return fmt.Errorf("checking budget limits: %w", err)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	writer := cmd.ErrOrStderr()
+	if engine.OutputFormat(output) == engine.OutputTable {
+		cmd.Println()
+		writer = cmd.OutOrStdout()
+	}
+	if renderErr := engine.RenderBudgetViolations(writer, violations); renderErr != nil {
+		return renderErr
+	}
+	return &exitError{code: exitCodeBudgetCritical, message: "projected costs exceed the budget limits"}
+}
+
+// parseBudgetFailOn validates a --fail-on-budget value: warning, critical or none.
+func parseBudgetFailOn(value string) (engine.BudgetStatus, error) {
+	switch strings.ToLower(value) {
+	case string(engine.BudgetStatusWarning), string(engine.BudgetStatusCritical), budgetFailOnNone:
+		return engine.BudgetStatus(strings.ToLower(value)), nil
+	default:
+		return "", fmt.Errorf("invalid --fail-on-budget %q: must be warning, critical or none", value)
+	}
+}
+
+// reportBudgets evaluates configured budgets against results and renders them after the
+// command output: on stdout for table output and on stderr otherwise, so machine-readable
+// output stays valid. It returns an exitError when the worst status reaches failOn.
+func reportBudgets(
+	cmd *cobra.Command,
+	output string,
+	cfg *config.Config,
+	resources []engine.ResourceDescriptor,
+	results []engine.CostResult,
+	failOn engine.BudgetStatus,
+) error {
+	if len(cfg.Budgets.Environments) == 0 {
+		return nil
+	}
+	policy, tagKey, err := newBudgetPolicy(cfg)
+	if err != nil {
+		return err
+	}
+	evaluations := policy.Evaluate(results, resourceEnvironments(resources, tagKey))
+	if len(evaluations) == 0 {
+		return nil
+	}
+
+	var statusLabel func(engine.BudgetStatus) string
+	if tui.DetectOutputMode(false, false, false) != tui.OutputModePlain {
+		statusLabel = func(s engine.BudgetStatus) string { return tui.RenderStatus(string(s)) }
+	}
+	writer := cmd.ErrOrStderr()
+	if engine.OutputFormat(output) == engine.OutputTable {
+		cmd.Println()
+		writer = cmd.OutOrStdout()
+	}
+	if renderErr := engine.RenderBudgets(writer, evaluations, statusLabel); renderErr != nil {
+		return renderErr
+	}
+
+	worst := engine.WorstBudgetStatus(evaluations)
+	if failOn == budgetFailOnNone || worst == engine.BudgetStatusOK || !worst.AtLeast(failOn) {
+		return nil
+	}
+	if worst == engine.BudgetStatusCritical {
+		return &exitError{code: exitCodeBudgetCritical, message: "projected costs exceed the critical budget threshold"}
+	}
+	return &exitError{code: exitCodeBudgetWarning, message: "projected costs exceed the budget warning threshold"}
+}
+
+// addSortFlag registers the --sort flag on cmd.
+func addSortFlag(cmd *cobra.Command, sortBy *string) {
+	cmd.Flags().StringVar(sortBy, "sort", "",
+		"Order results by monthly, hourly, total, name or type, with an optional :asc or :desc "+
+			"(default: the order they were computed in)")
+}
+
+// parseSortFlag parses --sort, reporting false when it is not set.
+func parseSortFlag(value string) (engine.ResultSort, bool, error) {
+	if value == "" {
+		return engine.ResultSort{}, false, nil
+	}
+	s, err := engine.ParseResultSort(value)
+	return s, err == nil, err
+}
+
+// sortCostResults returns r with its results ordered by s.
+func sortCostResults(r *engine.CostResultWithErrors, s engine.ResultSort) *engine.CostResultWithErrors {
+	sorted := *r
+	sorted.Results = engine.SortResults(r.Results, s)
+	return &sorted
+}
+
+// addTopFlag registers the --top flag on cmd.
+func addTopFlag(cmd *cobra.Command, top *int) {
+	cmd.Flags().IntVar(top, "top", 0,
+		"Show only the N most expensive resources and summarize the rest (0 shows all)")
+}
+
+// topCostResults returns r limited to its first n results and a summary of the rest, or r
+// and nil when nothing is left out.
+func topCostResults(r *engine.CostResultWithErrors, n int) (*engine.CostResultWithErrors, *engine.OmittedResults) {
+	results, omitted := engine.TopResults(r.Results, n)
+	if omitted == nil {
+		return r, nil
+	}
+	top := *r
+	top.Results = results
+	return &top, omitted
+}
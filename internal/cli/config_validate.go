@@ -26,6 +26,9 @@ func NewConfigValidateCmd() *cobra.Command {
 			if err := cfg.Validate(); err != nil {
 				return fmt.Errorf("configuration validation failed: %w", err)
 			}
+			if _, err := newCostRules(cfg, ""); err != nil {
+				return fmt.Errorf("configuration validation failed: cost_rules: %w", err)
+			}
 
 			cmd.Printf("✅ Configuration is valid\n")
 
@@ -35,6 +38,9 @@ func NewConfigValidateCmd() *cobra.Command {
 				cmd.Printf("- Output precision: %d\n", cfg.Output.Precision)
 				cmd.Printf("- Logging level: %s\n", cfg.Logging.Level)
 				cmd.Printf("- Log file: %s\n", cfg.Logging.File)
+				if cfg.CostRules != "" {
+					cmd.Printf("- Cost rules: %s\n", cfg.CostRules)
+				}
 
 				if len(cfg.Plugins) > 0 {
 					cmd.Printf("- Configured plugins: %d\n", len(cfg.Plugins))
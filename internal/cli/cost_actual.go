@@ -29,11 +29,22 @@ type costActualParams struct {
 	statePath          string // Path to Pulumi state JSON (mutually exclusive with planPath)
 	estimateConfidence bool   // Show confidence level for cost estimates
 	adapter            string
+	specDir            string // Pricing specs that estimate resources no plugin reports costs for
 	output             string
 	fromStr            string
 	toStr              string
+	period             string
 	groupBy            string
+	groupByKey         string
 	filter             []string
+	jsonEnvelope       bool
+	validateOutput     bool
+	verifyTotals       bool
+	timing             bool
+	anonymize          bool
+	anomalyThreshold   float64 // Spike above the trailing daily average flagged as an anomaly; 0 disables
+	sortBy             string
+	launch             pluginLaunchParams
 }
 
 // defaultToNow returns s if non-empty, otherwise returns the current time in RFC3339 format.
@@ -52,9 +63,16 @@ func defaultToNow(s string) string {
 //   - --pulumi-state: path to Pulumi state JSON from `pulumi stack export` (mutually exclusive with --pulumi-json)
 //   - --from: start date (YYYY-MM-DD or RFC3339, auto-detected from state if using --pulumi-state)
 //   - --to: end date (YYYY-MM-DD or RFC3339; defaults to now)
+//   - --period: business-calendar period such as last-quarter or fiscal-ytd (instead of --from/--to)
 //   - --adapter: restrict to a specific adapter plugin
+//   - --spec-dir: pricing specs that estimate resources no plugin reports costs for
 //   - --output: output format (table, json, ndjson; defaults from configuration)
-//   - --group-by: grouping or tag filter (resource, type, provider, date, daily, monthly, or tag:key=value)
+//   - --group-by: grouping, group expression, or tag filter (resource, type, provider, tag, date, daily,
+//     monthly, quarterly, yearly, an expression over resource fields and tags, a comma-separated list of
+//     dimensions such as provider,service, or tag:key=value)
+//   - --group-by-key: the tag key to group by with --group-by tag
+//   - --anomaly-threshold: flag daily cost spikes and post them to anomalies.webhook_url
+//   - --sort: order results by monthly, hourly, total, name or type after grouping
 //
 // When using --pulumi-state:
 //   - The --from date is auto-detected from the earliest Created timestamp if not provided
@@ -95,9 +113,25 @@ timestamp if not provided.`,
   # Monthly cross-provider aggregation table
   finfocus cost actual --pulumi-json plan.json --from 2025-01-01 --to 2025-03-31 --group-by monthly
 
+  # Quarterly cross-provider aggregation table for a finance review
+  finfocus cost actual --pulumi-json plan.json --from 2025-01-01 --to 2025-12-31 --group-by quarterly
+
   # Output as JSON with grouping by provider
   finfocus cost actual --pulumi-json plan.json --from 2025-01-01 --output json --group-by provider
 
+  # Chargeback by cost center; resources without the tag are grouped as untagged
+  finfocus cost actual --pulumi-json plan.json --from 2025-01-01 --group-by tag --group-by-key costCenter
+
+  # Group by a computed key, e.g. provider and environment tag
+  finfocus cost actual --pulumi-json plan.json --from 2025-01-01 \
+    --group-by "split(type, ':')[0] + '/' + default(tag:environment, 'untagged')"
+
+  # Services within each provider, nested in table and JSON output
+  finfocus cost actual --pulumi-json plan.json --from 2025-01-01 --group-by provider,service
+
+  # Costs for the last fiscal quarter (fiscal year start from calendar.fiscal_year_start_month)
+  finfocus cost actual --pulumi-json plan.json --period last-quarter
+
   # Use RFC3339 timestamps
   finfocus cost actual --pulumi-json plan.json --from 2025-01-01T00:00:00Z --to 2025-01-31T23:59:59Z`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -113,13 +147,22 @@ timestamp if not provided.`,
 		&params.fromStr, "from", "", "Start date (YYYY-MM-DD or RFC3339, auto-detected with --pulumi-state)",
 	)
 	cmd.Flags().StringVar(&params.toStr, "to", "", "End date (YYYY-MM-DD or RFC3339) (defaults to now)")
+	cmd.Flags().StringVar(&params.period, "period", "",
+		"Business-calendar period instead of --from/--to: "+strings.Join(periodNames, ", "))
 	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.specDir, "spec-dir", "",
+		"Directory of pricing specs used to estimate resources no plugin reports costs for")
 
 	// Use configuration default if no output format specified
 	defaultFormat := config.GetDefaultOutputFormat()
-	cmd.Flags().StringVar(&params.output, "output", defaultFormat, "Output format: table, json, or ndjson")
+	cmd.Flags().StringVar(&params.output, "output", defaultFormat,
+		"Output format: table, json, ndjson, focus, or markdown")
 	cmd.Flags().
-		StringVar(&params.groupBy, "group-by", "", "Group results by: resource, type, provider, date, daily, monthly, or filter by tag:key=value")
+		StringVar(&params.groupBy, "group-by", "", "Group results by: resource, type, provider, tag, date, daily, monthly, "+
+			"quarterly, yearly, an expression such as \"provider + '/' + tag:env\", nested dimensions such as "+
+			"provider,service, or filter by tag:key=value")
+	cmd.Flags().StringVar(&params.groupByKey, "group-by-key", "",
+		"Tag key to group by with --group-by tag, such as costCenter")
 	cmd.Flags().BoolVar(
 		&params.estimateConfidence,
 		"estimate-confidence",
@@ -128,6 +171,20 @@ timestamp if not provided.`,
 	)
 	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
 		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+	cmd.Flags().BoolVar(&params.jsonEnvelope, "json-envelope", false,
+		"Wrap JSON output in a versioned envelope with summary, results, errors, and metadata")
+	cmd.Flags().BoolVar(&params.validateOutput, "validate-output", false,
+		"Check the --json-envelope output against the published envelope schema and fail if it does not match")
+	cmd.Flags().BoolVar(&params.verifyTotals, "verify-totals", false,
+		"Check that grouped totals and summary breakdowns add up to the resource costs and fail if they do not")
+	cmd.Flags().BoolVar(&params.timing, "timing", false, "Print a breakdown of time spent per stage to stderr")
+	cmd.Flags().BoolVar(&params.anonymize, "anonymize", false,
+		"Replace resource IDs with stable pseudonyms and redact the tags in output.redact_tags, for sharing")
+	cmd.Flags().Float64Var(&params.anomalyThreshold, "anomaly-threshold", 0,
+		"Flag days costing this much above the trailing daily average, e.g. 0.5 for 50% "+
+			"(default: anomalies.threshold; 0 disables)")
+	addSortFlag(cmd, &params.sortBy)
+	addPluginLaunchFlags(cmd, &params.launch)
 
 	// Note: --pulumi-json and --from are no longer required - validation is done in executeCostActual
 
@@ -158,13 +215,26 @@ timestamp if not provided.`,
 //   - Time range parsing fails
 //   - Plugin communication fails
 func executeCostActual(cmd *cobra.Command, params costActualParams) error {
-	ctx := cmd.Context()
+	ctx, printTimings := startTimings(cmd.Context(), cmd, params.timing)
+	defer printTimings()
 	log := logging.FromContext(ctx)
 
 	// Validate mutually exclusive flags
 	if err := validateActualInputFlags(params); err != nil {
 		return err
 	}
+	if _, groupBy := parseTagFilter(params.groupBy); groupBy != "" {
+		if err := engine.ValidateGroupBy(groupBy); err != nil {
+			return err
+		}
+		if err := engine.ValidateGroupByKey(groupBy, params.groupByKey); err != nil {
+			return fmt.Errorf("%w: set --group-by-key", err)
+		}
+	}
+	resultSort, sorted, err := parseSortFlag(params.sortBy)
+	if err != nil {
+		return err
+	}
 
 	log.Debug().Ctx(ctx).Str("operation", "cost_actual").
 		Str("plan_path", params.planPath).Str("state_path", params.statePath).
@@ -173,26 +243,29 @@ func executeCostActual(cmd *cobra.Command, params costActualParams) error {
 
 	audit := newAuditContext(ctx, "cost actual", buildActualAuditParams(params))
 
-	resources, err := loadActualResources(ctx, params, audit)
+	cfg := config.New()
+	resources, err := loadActualResources(ctx, params, audit, engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes))
 	if err != nil {
 		return err
 	}
 
-	resources = applyResourceFilters(ctx, resources, params.filter)
-
-	fromStr, err := resolveFromDate(ctx, params, resources)
+	resources, err = applyResourceFilters(ctx, resources, params.filter)
 	if err != nil {
 		return err
 	}
 
-	from, to, err := ParseTimeRange(fromStr, defaultToNow(params.toStr))
+	from, to, err := resolveActualTimeRange(ctx, cmd, params, resources, cfg)
 	if err != nil {
-		log.Error().Ctx(ctx).Err(err).Msg("failed to parse time range")
 		audit.logFailure(ctx, err)
-		return fmt.Errorf("parsing time range: %w", err)
+		return err
 	}
 
-	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	params.launch.resolveOffline(cfg)
+	transforms, err := newTransformChain(cfg)
+	if err != nil {
+		return err
+	}
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit, params.launch.openOptions(resources))
 	if err != nil {
 		return err
 	}
@@ -201,20 +274,72 @@ func executeCostActual(cmd *cobra.Command, params costActualParams) error {
 	tags, actualGroupBy := parseTagFilter(params.groupBy)
 	request := engine.ActualCostRequest{
 		Resources: resources, From: from, To: to,
-		Adapter: params.adapter, GroupBy: actualGroupBy, Tags: tags,
+		Adapter: params.adapter, GroupBy: actualGroupBy, GroupByKey: params.groupByKey, Tags: tags,
 		EstimateConfidence: params.estimateConfidence,
 	}
 
-	resultWithErrors, err := engine.New(clients, nil).GetActualCostWithOptionsAndErrors(ctx, request)
+	specDir := params.specDir
+	if specDir == "" {
+		specDir = cfg.SpecDir
+	}
+	opts := params.launch.engineOptions(cfg)
+	opts.VerifyTotals = params.verifyTotals
+	eng, err := engine.New(clients, newSpecLoader(ctx, cfg, specDir), opts)
+	if err != nil {
+		return err
+	}
+	resultWithErrors, err := eng.
+		WithResultTransforms(transforms).
+		GetActualCostWithOptionsAndErrors(ctx, request)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to fetch actual costs")
 		audit.logFailure(ctx, err)
 		return fmt.Errorf("fetching actual costs: %w", err)
 	}
 
-	if renderErr := RenderActualCostOutput(ctx, cmd, params.output, resultWithErrors, actualGroupBy, params.estimateConfidence); renderErr != nil {
+	anomalyThreshold := cfg.Anomalies.Threshold
+	if cmd.Flags().Changed("anomaly-threshold") {
+		anomalyThreshold = params.anomalyThreshold
+	}
+	var anomalies []engine.Anomaly
+	if anomalyThreshold > 0 {
+		anomalies = engine.DetectAnomalies(resultWithErrors.Results, anomalyThreshold)
+		notifyAnomalies(ctx, cmd, cfg, anomalies)
+	}
+
+	envelope, err := newEnvelopeMeta(params.jsonEnvelope, params.output, "cost actual", resources)
+	if err != nil {
+		return err
+	}
+	if params.validateOutput && envelope == nil {
+		return errors.New("--validate-output requires --json-envelope")
+	}
+	rendered := resultWithErrors
+	var anonymizer *engine.Anonymizer
+	if params.anonymize {
+		anonymizer = newAnonymizer(cfg)
+		rendered = anonymizer.Anonymize(resultWithErrors)
+	}
+	if sorted {
+		rendered = sortCostResults(rendered, resultSort)
+	}
+	renderOpts := engine.RenderOptions{Envelope: envelope, ValidateEnvelope: params.validateOutput}
+	stopRender := engine.TimingsFromContext(ctx).Track(engine.StageRender)
+	renderErr := RenderActualCostOutput(
+		ctx, cmd, params.output, rendered, actualGroupBy, params.estimateConfidence, renderOpts,
+	)
+	stopRender()
+	if renderErr != nil {
 		return renderErr
 	}
+	if anomalyThreshold > 0 {
+		if anonymizer != nil {
+			anomalies = anonymizer.AnonymizeAnomalies(anomalies)
+		}
+		if anomalyErr := renderAnomalies(cmd, params.output, anomalies); anomalyErr != nil {
+			return anomalyErr
+		}
+	}
 
 	log.Info().Ctx(ctx).Str("operation", "cost_actual").Int("result_count", len(resultWithErrors.Results)).
 		Dur("duration_ms", time.Since(audit.start)).Msg("actual cost calculation complete")
@@ -227,6 +352,32 @@ func executeCostActual(cmd *cobra.Command, params costActualParams) error {
 	return nil
 }
 
+// notifyAnomalies posts anomalies to the configured webhook as soon as they are detected.
+// Delivery failures are reported as warnings and never fail the cost run.
+func notifyAnomalies(ctx context.Context, cmd *cobra.Command, cfg *config.Config, anomalies []engine.Anomaly) {
+	if cfg.Anomalies.WebhookURL == "" || len(anomalies) == 0 {
+		return
+	}
+	minSeverity, err := engine.ParseAnomalySeverity(cfg.Anomalies.MinSeverity)
+	if err == nil {
+		notifier := &engine.AnomalyWebhook{URL: cfg.Anomalies.WebhookURL, MinSeverity: minSeverity}
+		err = notifier.Notify(ctx, anomalies)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warn().Ctx(ctx).Err(err).Msg("failed to send cost anomalies to webhook")
+		cmd.PrintErrf("Warning: cost anomalies were not sent to the webhook: %v\n", err)
+	}
+}
+
+// renderAnomalies lists detected anomalies, placed like renderCostChanges.
+func renderAnomalies(cmd *cobra.Command, output string, anomalies []engine.Anomaly) error {
+	if engine.OutputFormat(output) == engine.OutputTable {
+		cmd.Println()
+		return engine.RenderAnomalies(cmd.OutOrStdout(), anomalies)
+	}
+	return engine.RenderAnomalies(cmd.ErrOrStderr(), anomalies)
+}
+
 // ParseTimeRange parses the provided from and to date strings into time values and validates that the range is chronological.
 //
 // ParseTimeRange accepts two date strings, parses each into a time.Time, and ensures the 'to' time is after the 'from' time.
@@ -378,9 +529,13 @@ func validateActualInputFlags(params costActualParams) error {
 		return errors.New("either --pulumi-json or --pulumi-state is required")
 	}
 
-	// When using --pulumi-json, --from is required
-	if hasPlan && params.fromStr == "" {
-		return errors.New("--from is required when using --pulumi-json")
+	if params.period != "" && (params.fromStr != "" || params.toStr != "") {
+		return errors.New("--period and --from/--to are mutually exclusive; use only one")
+	}
+
+	// When using --pulumi-json, --from (or --period) is required
+	if hasPlan && params.fromStr == "" && params.period == "" {
+		return errors.New("--from is required when using --pulumi-json (or use --period)")
 	}
 
 	// When using --pulumi-state, --from is optional (auto-detected from timestamps)
@@ -433,11 +588,15 @@ func buildActualAuditParams(params costActualParams) map[string]string {
 	auditParams := map[string]string{
 		"from":                params.fromStr,
 		"to":                  params.toStr,
+		"period":              params.period,
 		"adapter":             params.adapter,
 		"output":              params.output,
 		"group_by":            params.groupBy,
 		"estimate_confidence": strconv.FormatBool(params.estimateConfidence),
 	}
+	if params.groupByKey != "" {
+		auditParams["group_by_key"] = params.groupByKey
+	}
 	if params.planPath != "" {
 		auditParams["plan_path"] = params.planPath
 	}
@@ -452,11 +611,17 @@ func loadActualResources(
 	ctx context.Context,
 	params costActualParams,
 	audit *auditContext,
+	zeroCost engine.ZeroCostTypes,
 ) ([]engine.ResourceDescriptor, error) {
 	log := logging.FromContext(ctx)
+	defer engine.TimingsFromContext(ctx).Track(engine.StageIngest)()
 
 	if params.statePath != "" {
-		return loadResourcesFromState(ctx, params.statePath, audit)
+		resources, err := loadResourcesFromState(ctx, params.statePath, audit)
+		if err != nil {
+			return nil, err
+		}
+		return excludeInternalResources(ctx, zeroCost, resources), nil
 	}
 
 	// Load from Pulumi plan
@@ -475,7 +640,7 @@ func loadActualResources(
 		return nil, fmt.Errorf("mapping resources: %w", err)
 	}
 
-	return resources, nil
+	return excludeInternalResources(ctx, zeroCost, resources), nil
 }
 
 // applyResourceFilters applies filter expressions to the resource list.
@@ -483,18 +648,61 @@ func applyResourceFilters(
 	ctx context.Context,
 	resources []engine.ResourceDescriptor,
 	filters []string,
-) []engine.ResourceDescriptor {
+) ([]engine.ResourceDescriptor, error) {
 	log := logging.FromContext(ctx)
 
 	for _, f := range filters {
-		resources = engine.FilterResources(resources, f)
+		var err error
+		if resources, err = engine.FilterResources(resources, f); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(resources) == 0 {
 		log.Warn().Ctx(ctx).Msg("no resources match filter criteria")
 	}
 
-	return resources
+	return resources, nil
+}
+
+// resolveActualTimeRange returns the range to fetch costs for, from --period when given and
+// otherwise from --from/--to. A resolved period is printed so the concrete dates are visible,
+// on stderr for machine-readable output.
+func resolveActualTimeRange(
+	ctx context.Context,
+	cmd *cobra.Command,
+	params costActualParams,
+	resources []engine.ResourceDescriptor,
+	cfg *config.Config,
+) (time.Time, time.Time, error) {
+	log := logging.FromContext(ctx)
+
+	if params.period != "" {
+		from, to, err := resolvePeriodRange(params.period, cfg)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("resolving --period: %w", err)
+		}
+		log.Debug().Ctx(ctx).Str("period", params.period).Time("from", from).Time("to", to).
+			Msg("resolved business-calendar period")
+		writer := cmd.ErrOrStderr()
+		if engine.OutputFormat(params.output) == engine.OutputTable {
+			writer = cmd.OutOrStdout()
+		}
+		fmt.Fprintf(writer, "Period %s: %s to %s\n",
+			params.period, from.Format(time.RFC3339), to.Format(time.RFC3339))
+		return from, to, nil
+	}
+
+	fromStr, err := resolveFromDate(ctx, params, resources)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from, to, err := ParseTimeRange(fromStr, defaultToNow(params.toStr))
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to parse time range")
+		return time.Time{}, time.Time{}, fmt.Errorf("parsing time range: %w", err)
+	}
+	return from, to, nil
 }
 
 // resolveFromDate determines the 'from' date, auto-detecting from state if needed.
@@ -117,6 +117,31 @@ func TestCostActualCmdFlags(t *testing.T) {
 	assert.NotNil(t, groupByFlag)
 	assert.Equal(t, "string", groupByFlag.Value.Type())
 	assert.Contains(t, groupByFlag.Usage, "resource, type, provider")
+
+	groupByKeyFlag := cmd.Flags().Lookup("group-by-key")
+	assert.NotNil(t, groupByKeyFlag)
+	assert.Equal(t, "string", groupByKeyFlag.Value.Type())
+
+	anonymizeFlag := cmd.Flags().Lookup("anonymize")
+	assert.NotNil(t, anonymizeFlag)
+	assert.Equal(t, "bool", anonymizeFlag.Value.Type())
+
+	envelopeFlag := cmd.Flags().Lookup("json-envelope")
+	assert.NotNil(t, envelopeFlag)
+	assert.Equal(t, "bool", envelopeFlag.Value.Type())
+
+	timingFlag := cmd.Flags().Lookup("timing")
+	assert.NotNil(t, timingFlag)
+	assert.Equal(t, "bool", timingFlag.Value.Type())
+
+	maxPluginsFlag := cmd.Flags().Lookup("max-plugins")
+	assert.NotNil(t, maxPluginsFlag)
+	assert.Equal(t, "int", maxPluginsFlag.Value.Type())
+	assert.Equal(t, "0", maxPluginsFlag.DefValue)
+
+	lazyFlag := cmd.Flags().Lookup("lazy-plugins")
+	assert.NotNil(t, lazyFlag)
+	assert.Equal(t, "false", lazyFlag.DefValue)
 }
 
 func TestCostActualCmdHelp(t *testing.T) {
@@ -290,6 +315,24 @@ func TestCostActualCmdMutuallyExclusiveInputs(t *testing.T) {
 	}
 }
 
+// TestCostActualCmdInvalidGroupExpression tests that a malformed --group-by expression is
+// rejected before any resources are loaded.
+func TestCostActualCmdInvalidGroupExpression(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+
+	for _, groupBy := range []string{"split(type, ':'", "owner + '/' + tag:env"} {
+		var buf bytes.Buffer
+		cmd := cli.NewCostActualCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"--pulumi-json", "missing.json", "--from", "2025-01-01", "--group-by", groupBy})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid group-by")
+	}
+}
+
 // TestCostActualCmdHelpWithStateFlag tests that help includes --pulumi-state documentation.
 func TestCostActualCmdHelpWithStateFlag(t *testing.T) {
 	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
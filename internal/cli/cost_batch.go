@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+// costBatchParams holds the parameters for the batch cost command execution.
+type costBatchParams struct {
+	manifest    string
+	specDir     string
+	adapter     string
+	output      string
+	concurrency int
+	outDir      string
+	webhook     string
+	launch      pluginLaunchParams
+}
+
+// NewCostBatchCmd creates the "batch" subcommand that estimates the projected cost of every
+// stack listed in a manifest and reports each stack and the portfolio rollup.
+func NewCostBatchCmd() *cobra.Command {
+	var params costBatchParams
+
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Estimate projected costs for many stacks from a manifest",
+		Long: `Estimate the projected cost of every stack listed in a manifest, then report each
+stack and a portfolio rollup by currency and by stack metadata such as owner and
+environment.
+
+Each stack names either a saved Pulumi preview JSON file (preview) or a command that
+prints one on stdout (command), run with sh from the manifest's directory:
+
+  concurrency: 4
+  stacks:
+    - name: payments-prod
+      preview: previews/payments-prod.json
+      metadata: {owner: payments, environment: prod}
+    - name: web-staging
+      command: pulumi preview --json --stack staging --cwd web
+      metadata: {owner: web, environment: staging}
+
+A stack that fails is reported and does not stop the others; the command exits with an
+error after reporting if any stack failed. Plugins are launched once and shared by all
+stacks.`,
+		Example: `  # Nightly report of every stack
+  finfocus cost batch --manifest stacks.yaml
+
+  # Write per-stack and portfolio JSON reports and post the report to a webhook
+  finfocus cost batch --manifest stacks.yaml --out-dir reports --webhook https://hooks.example.com/cost`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostBatch(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.manifest, "manifest", "", "YAML manifest listing the stacks to estimate (required)")
+	cmd.Flags().StringVar(&params.specDir, "spec-dir", "", "Directory containing pricing spec files")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", string(engine.OutputTable), "Output format: table or json")
+	cmd.Flags().IntVar(&params.concurrency, "concurrency", 0,
+		"Maximum number of stacks estimated at once (default: the manifest's, or 4)")
+	cmd.Flags().StringVar(&params.outDir, "out-dir", "",
+		"Directory to write <stack>.json reports and portfolio.json to")
+	cmd.Flags().StringVar(&params.webhook, "webhook", "", "URL to POST the batch report to as JSON")
+	addPluginLaunchFlags(cmd, &params.launch)
+	_ = cmd.MarkFlagRequired("manifest")
+
+	return cmd
+}
+
+// executeCostBatch loads the manifest, estimates every stack with shared plugins, and
+// renders, writes and posts the batch report.
+func executeCostBatch(cmd *cobra.Command, params costBatchParams) error {
+	ctx := cmd.Context()
+	output := engine.OutputFormat(params.output)
+	if output != engine.OutputTable && output != engine.OutputJSON {
+		return fmt.Errorf("unsupported output format %q for cost batch: use table or json", params.output)
+	}
+	manifest, err := engine.LoadBatchManifest(params.manifest)
+	if err != nil {
+		return err
+	}
+	log := logging.FromContext(ctx)
+	log.Debug().Ctx(ctx).Str("operation", "cost_batch").Str("manifest", params.manifest).
+		Int("stack_count", len(manifest.Stacks)).Msg("starting batch cost estimation")
+
+	cfg := config.New()
+	params.launch.resolveOffline(cfg)
+	transforms, err := newTransformChain(cfg)
+	if err != nil {
+		return err
+	}
+	customTypes, err := newCustomTypeRules(cfg)
+	if err != nil {
+		return err
+	}
+	costRules, err := newCostRules(cfg, "")
+	if err != nil {
+		return err
+	}
+	specDir := params.specDir
+	if specDir == "" {
+		specDir = cfg.SpecDir
+	}
+
+	audit := newAuditContext(ctx, "cost batch", map[string]string{"manifest": params.manifest})
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit, params.launch.openOptions(nil))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	newEngine := func() (*engine.Engine, error) {
+		eng, engErr := engine.New(clients, newSpecLoader(ctx, cfg, specDir), params.launch.engineOptions(cfg))
+		if engErr != nil {
+			return nil, engErr
+		}
+		return eng.
+			WithPricingCache(newPricingCache(cfg)).
+			WithPluginLayers(newPluginLayers(cfg)).
+			WithResultTransforms(transforms).
+			WithCustomTypes(customTypes).
+			WithCostRules(costRules), nil
+	}
+	zeroCost := engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes)
+	concurrency := params.concurrency
+	if concurrency <= 0 {
+		concurrency = manifest.Concurrency
+	}
+	report := engine.RunBatch(ctx, manifest.Stacks, concurrency,
+		func(ctx context.Context, stack engine.BatchStack) (*engine.CostResultWithErrors, error) {
+			return estimateBatchStack(ctx, manifest, stack, zeroCost, newEngine)
+		})
+
+	if err = engine.RenderBatchReport(cmd.OutOrStdout(), output, report); err != nil {
+		return err
+	}
+	if params.outDir != "" {
+		if err = engine.WriteBatchReports(params.outDir, report); err != nil {
+			return err
+		}
+	}
+	if params.webhook != "" {
+		if err = engine.PostBatchReport(ctx, params.webhook, nil, report); err != nil {
+			log.Warn().Ctx(ctx).Err(err).Msg("failed to post batch report to webhook")
+			cmd.PrintErrf("Warning: batch report was not sent to the webhook: %v\n", err)
+		}
+	}
+
+	var total float64
+	for _, s := range report.Stacks {
+		total += s.TotalMonthly
+	}
+	if report.Failed() {
+		failErr := fmt.Errorf("%w: %d of %d", engine.ErrBatchStacksFailed,
+			report.Portfolio.FailedStacks, report.Portfolio.StackCount)
+		audit.logFailure(ctx, failErr)
+		return failErr
+	}
+	audit.logSuccess(ctx, len(report.Stacks), total)
+	return nil
+}
+
+// estimateBatchStack loads the stack's preview, running its command when it has one, and
+// computes its projected cost.
+func estimateBatchStack(
+	ctx context.Context,
+	manifest *engine.BatchManifest,
+	stack engine.BatchStack,
+	zeroCost engine.ZeroCostTypes,
+	newEngine func() (*engine.Engine, error),
+) (*engine.CostResultWithErrors, error) {
+	logger := logging.FromContext(ctx).With().Str("stack", stack.Name).Logger()
+	ctx = logger.WithContext(ctx)
+	audit := newAuditContext(ctx, "cost batch", map[string]string{"stack": stack.Name})
+
+	planPath := manifest.PreviewPath(stack)
+	if stack.Command != "" {
+		path, cleanup, err := runPreviewCommand(ctx, manifest.Dir, stack.Command)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		planPath = path
+	}
+
+	resources, err := loadAndMapResources(ctx, planPath, audit, zeroCost)
+	if err != nil {
+		return nil, err
+	}
+	eng, err := newEngine()
+	if err != nil {
+		return nil, err
+	}
+	result, err := eng.GetProjectedCostWithErrors(ctx, resources)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	return result, nil
+}
+
+// runPreviewCommand runs a stack's preview command in dir and saves its stdout to a
+// temporary file, returned with a function that removes it.
+func runPreviewCommand(ctx context.Context, dir, command string) (string, func(), error) {
+	out, err := os.CreateTemp("", "finfocus-batch-preview-*.json")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating preview file: %w", err)
+	}
+	cleanup := func() { _ = os.Remove(out.Name()) }
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	var stderr bytes.Buffer
+	//nolint:gosec // The command comes from the user's own batch manifest.
+	c := exec.CommandContext(ctx, shell, flag, command)
+	c.Dir = dir
+	c.Stdout = out
+	c.Stderr = &stderr
+	runErr := c.Run()
+	closeErr := out.Close()
+	if runErr != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("running preview command: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+	}
+	if closeErr != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("saving preview output: %w", closeErr)
+	}
+	return out.Name(), cleanup, nil
+}
@@ -0,0 +1,73 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostBatchCmd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("manifest uses a POSIX shell command")
+	}
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	plan := `{"steps": [{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}]}`
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "previews"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "previews", "prod.json"), []byte(plan), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "staging.json"), []byte(plan), 0o600))
+
+	manifest := filepath.Join(dir, "stacks.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(`stacks:
+  - name: prod
+    preview: previews/prod.json
+    metadata: {environment: prod}
+  - name: staging
+    command: cat staging.json
+    metadata: {environment: staging}
+  - name: missing
+    preview: previews/missing.json
+`), 0o600))
+
+	outDir := filepath.Join(dir, "reports")
+	var buf bytes.Buffer
+	cmd := cli.NewCostBatchCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--manifest", manifest, "--spec-dir", specDir, "--offline", "--out-dir", outDir})
+	err := cmd.Execute()
+	require.ErrorIs(t, err, engine.ErrBatchStacksFailed)
+
+	out := buf.String()
+	assert.Regexp(t, `prod\s+ok\s+1\s+7.30 USD`, out)
+	assert.Regexp(t, `staging\s+ok\s+1\s+7.30 USD`, out)
+	assert.Regexp(t, `missing\s+failed`, out)
+	assert.Contains(t, out, "Total: 14.60 USD/month")
+	assert.Contains(t, out, "environment=staging: 7.30 USD/month (stacks: 1)")
+
+	data, err := os.ReadFile(filepath.Join(outDir, "portfolio.json"))
+	require.NoError(t, err)
+	var portfolio struct {
+		Portfolio engine.BatchPortfolio `json:"portfolio"`
+	}
+	require.NoError(t, json.Unmarshal(data, &portfolio))
+	assert.Equal(t, 1, portfolio.Portfolio.FailedStacks)
+	assert.FileExists(t, filepath.Join(outDir, "staging.json"))
+}
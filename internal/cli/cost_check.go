@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+// costCheckParams holds the parameters for the cost check command execution.
+type costCheckParams struct {
+	planPath          string
+	baselinePath      string
+	specDir           string
+	adapter           string
+	output            string
+	tolerance         float64
+	resourceTolerance float64
+	failOnBudget      string
+	prComment         bool
+	prNumber          int
+	matchBy           string
+	launch            pluginLaunchParams
+}
+
+// NewCostCheckCmd creates the "check" subcommand that gates a Pulumi preview on a committed
+// cost baseline.
+func NewCostCheckCmd() *cobra.Command {
+	var params costCheckParams
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Compare projected costs against a committed baseline",
+		Long: `Calculate projected costs for a Pulumi preview and compare them with a baseline
+committed to the repository, per resource and in total. The command exits non-zero
+when the total exceeds the baseline by more than --tolerance percent, or when any
+resource exceeds its baselined cost by more than --resource-tolerance percent.
+Resources missing from the baseline count as exceeding it when they have a cost.
+
+When budgets are configured, each environment's total is also reported against its
+budget, and the command exits 3 or 4 when a warning or critical threshold is crossed.
+
+A baseline is the JSON output of "finfocus cost projected --output json".
+
+With --pr-comment, the cost impact is also posted to the pull request as a single
+comment that later runs update in place. This needs GITHUB_TOKEN and GITHUB_REPOSITORY,
+as set in GitHub Actions, and takes the pull request from the workflow event unless
+--pr-number is given.
+
+Resources are matched with the baseline by URN unless --match-by says otherwise, so a
+renamed resource or one from another stack can still be compared with its baselined
+cost: "tag" uses the finfocus:id tag, "name" the resource type and name without the
+stack and project, and any other value is an expression over type, id, name, provider,
+service and tag:<key>. Unmatched resources are reported as added or removed.`,
+		Example: `  # Record the approved baseline
+  finfocus cost projected --pulumi-json plan.json --output json > cost-baseline.json
+
+  # Fail the build if the total grows by more than 10%
+  finfocus cost check --pulumi-json plan.json --baseline cost-baseline.json --tolerance 10
+
+  # Summarize the cost impact on the pull request in GitHub Actions
+  finfocus cost check --pulumi-json plan.json --baseline cost-baseline.json --pr-comment
+
+  # Compare a staging preview with the production baseline, matching resources by name
+  finfocus cost check --pulumi-json staging.json --baseline prod-baseline.json --match-by name`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostCheck(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output (required)")
+	cmd.Flags().StringVar(&params.baselinePath, "baseline", "", "Path to the committed cost baseline (required)")
+	cmd.Flags().StringVar(&params.specDir, "spec-dir", "", "Directory containing pricing spec files")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", "table", "Output format: table or json")
+	cmd.Flags().Float64Var(&params.tolerance, "tolerance", 0,
+		"Percentage by which the total may exceed the baseline")
+	cmd.Flags().Float64Var(&params.resourceTolerance, "resource-tolerance", 0,
+		"Percentage by which a single resource may exceed its baselined cost")
+	cmd.Flags().StringVar(&params.failOnBudget, "fail-on-budget", string(engine.BudgetStatusWarning),
+		"Budget threshold that fails the check: warning (exit 3), critical (exit 4), or none")
+	cmd.Flags().BoolVar(&params.prComment, "pr-comment", false,
+		"Post or update a pull request comment summarizing the cost impact (needs GITHUB_TOKEN)")
+	cmd.Flags().IntVar(&params.prNumber, "pr-number", 0,
+		"Pull request to comment on (default: from the GitHub Actions event)")
+	cmd.Flags().StringVar(&params.matchBy, "match-by", engine.IdentityByURN,
+		"How resources are matched with the baseline: urn, tag (finfocus:id), name, or an expression")
+	addPluginLaunchFlags(cmd, &params.launch)
+	_ = cmd.MarkFlagRequired("pulumi-json")
+	_ = cmd.MarkFlagRequired("baseline")
+
+	return cmd
+}
+
+// executeCostCheck calculates projected costs, compares them with the baseline and renders
+// the drift report. It returns engine.ErrBaselineExceeded when the check fails, and an
+// exitError when it passes but projected costs cross a configured budget threshold.
+func executeCostCheck(cmd *cobra.Command, params costCheckParams) error {
+	ctx := cmd.Context()
+	if params.tolerance < 0 || params.resourceTolerance < 0 {
+		return fmt.Errorf("tolerances must not be negative, got %g and %g", params.tolerance, params.resourceTolerance)
+	}
+	format := engine.OutputFormat(params.output)
+	if format != engine.OutputTable && format != engine.OutputJSON {
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+	failOnBudget, err := parseBudgetFailOn(params.failOnBudget)
+	if err != nil {
+		return err
+	}
+	if _, err = engine.ParseIdentityMatch(params.matchBy); err != nil {
+		return err
+	}
+	var commenter *engine.GitHubPRCommenter
+	if params.prComment {
+		if commenter, err = engine.NewGitHubPRCommenterFromEnv(params.prNumber); err != nil {
+			return err
+		}
+	}
+
+	log := logging.FromContext(ctx)
+	audit := newAuditContext(ctx, "cost check", map[string]string{
+		"pulumi_json": params.planPath,
+		"baseline":    params.baselinePath,
+		"tolerance":   strconv.FormatFloat(params.tolerance, 'f', -1, 64),
+	})
+
+	baseline, err := engine.LoadBaseline(params.baselinePath)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	cfg := config.New()
+	resources, err := loadAndMapResources(ctx, params.planPath, audit, engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes))
+	if err != nil {
+		return err
+	}
+
+	params.launch.resolveOffline(cfg)
+	transforms, err := newTransformChain(cfg)
+	if err != nil {
+		return err
+	}
+	customTypes, err := newCustomTypeRules(cfg)
+	if err != nil {
+		return err
+	}
+	costRules, err := newCostRules(cfg, "")
+	if err != nil {
+		return err
+	}
+	if _, _, budgetErr := newBudgetPolicy(cfg); budgetErr != nil {
+		return budgetErr
+	}
+	specDir := params.specDir
+	if specDir == "" {
+		specDir = cfg.SpecDir
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit, params.launch.openOptions(resources))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	eng, err := engine.New(clients, newSpecLoader(ctx, cfg, specDir), params.launch.engineOptions(cfg))
+	if err != nil {
+		return err
+	}
+	resultWithErrors, err := eng.
+		WithPricingCache(newPricingCache(cfg)).
+		WithPluginLayers(newPluginLayers(cfg)).
+		WithResultTransforms(transforms).
+		WithCustomTypes(customTypes).
+		WithCostRules(costRules).
+		GetProjectedCostWithErrors(ctx, resources)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("calculating projected costs: %w", err)
+	}
+
+	check := engine.CompareToBaseline(baseline, resultWithErrors.Results, engine.BaselineCheckOptions{
+		TotalTolerancePercent:    params.tolerance,
+		ResourceTolerancePercent: params.resourceTolerance,
+		IdentityMatch:            params.matchBy,
+	})
+	if renderErr := engine.RenderBaselineCheck(cmd.OutOrStdout(), format, check); renderErr != nil {
+		return renderErr
+	}
+	displayErrorSummary(cmd, resultWithErrors, format)
+	if commenter != nil {
+		postCostComment(ctx, cmd, commenter, check)
+	}
+	budgetErr := reportBudgets(cmd, params.output, cfg, resources, resultWithErrors.Results, failOnBudget)
+
+	log.Info().Ctx(ctx).Str("operation", "cost_check").
+		Float64("baseline_total", check.BaselineTotal).
+		Float64("current_total", check.CurrentTotal).
+		Int("drift_count", len(check.Drifts)).
+		Bool("passed", check.Passed()).
+		Msg("baseline check complete")
+
+	if !check.Passed() {
+		audit.logFailure(ctx, engine.ErrBaselineExceeded)
+		return engine.ErrBaselineExceeded
+	}
+	audit.logSuccess(ctx, len(resultWithErrors.Results), check.CurrentTotal)
+	return budgetErr
+}
+
+// postCostComment updates the pull request's cost comment. Failing to post only warns,
+// so the check result still decides the exit code.
+func postCostComment(
+	ctx context.Context, cmd *cobra.Command, commenter *engine.GitHubPRCommenter, check *engine.BaselineCheck,
+) {
+	if err := commenter.Upsert(ctx, engine.RenderBaselineCheckMarkdown(check)); err != nil {
+		logging.FromContext(ctx).Warn().Ctx(ctx).Err(err).Msg("failed to post cost comment to pull request")
+		cmd.PrintErrf("Warning: cost comment was not posted to pull request #%d: %v\n", commenter.PullRequest, err)
+		return
+	}
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("operation", "cost_check").
+		Str("repository", commenter.Repository).Int("pull_request", commenter.PullRequest).
+		Msg("posted cost comment")
+}
@@ -0,0 +1,78 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostCheckCmdFlags(t *testing.T) {
+	cmd := cli.NewCostCheckCmd()
+	for _, name := range []string{
+		"pulumi-json", "baseline", "tolerance", "resource-tolerance", "offline", "validate-plugins", "pr-comment",
+		"match-by",
+	} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "0", cmd.Flags().Lookup("tolerance").DefValue)
+}
+
+func TestCostCheckCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	run := func(baselineMonthly float64, extra ...string) (string, error) {
+		baselinePath := filepath.Join(dir, "baseline.json")
+		var baseline bytes.Buffer
+		require.NoError(t, engine.RenderResults(&baseline, engine.OutputJSON, []engine.CostResult{{
+			ResourceType: "aws:ec2/instance:Instance",
+			ResourceID:   "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			Currency:     "USD",
+			Monthly:      baselineMonthly,
+		}}))
+		require.NoError(t, os.WriteFile(baselinePath, baseline.Bytes(), 0o600))
+
+		var buf bytes.Buffer
+		cmd := cli.NewCostCheckCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{
+			"--pulumi-json", planPath, "--baseline", baselinePath, "--spec-dir", specDir, "--offline",
+		}, extra...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	// The spec prices the instance at 0.01 * 730 = 7.30/month.
+	out, err := run(7.3)
+	require.NoError(t, err)
+	assert.Contains(t, out, "PASS")
+	assert.Contains(t, out, "No resources drifted")
+
+	out, err = run(5)
+	require.ErrorIs(t, err, engine.ErrBaselineExceeded)
+	assert.Contains(t, out, "FAIL")
+	assert.Contains(t, out, "increased (exceeds baseline)")
+
+	_, err = run(7, "--tolerance", "10", "--resource-tolerance", "10")
+	require.NoError(t, err)
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+// costGraphParams holds the parameters for the cost graph command execution.
+type costGraphParams struct {
+	planPath string
+	specDir  string
+	adapter  string
+	output   string
+	collapse bool
+	launch   pluginLaunchParams
+}
+
+// NewCostGraphCmd creates the "graph" subcommand that exports the resource dependency graph
+// of a Pulumi preview, annotated with projected costs, as Graphviz DOT.
+func NewCostGraphCmd() *cobra.Command {
+	var params costGraphParams
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the dependency graph with projected costs as Graphviz DOT",
+		Long: `Calculate projected costs for a Pulumi preview and write its resource dependency
+graph in Graphviz DOT format. Each node shows the resource's monthly cost and is
+colored from green to red by cost relative to the most expensive node, so expensive
+clusters stand out. Solid edges point to the resources a resource depends on; dashed
+edges point from a component to its children.
+
+Use --collapse-components to draw each top-level component as a single node
+carrying the cost of everything beneath it, which keeps large stacks readable.`,
+		Example: `  # Render the cost graph as SVG
+  finfocus cost graph --pulumi-json plan.json --output dot | dot -Tsvg > costs.svg
+
+  # One node per top-level component
+  finfocus cost graph --pulumi-json plan.json --collapse-components > costs.dot`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostGraph(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output (required)")
+	cmd.Flags().StringVar(&params.specDir, "spec-dir", "", "Directory containing pricing spec files")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", string(engine.OutputDOT), "Output format: dot")
+	cmd.Flags().BoolVar(&params.collapse, "collapse-components", false,
+		"Draw each top-level component as one node with the total cost of its resources")
+	addPluginLaunchFlags(cmd, &params.launch)
+	_ = cmd.MarkFlagRequired("pulumi-json")
+
+	return cmd
+}
+
+// executeCostGraph prices the plan's resources and writes the annotated dependency graph.
+func executeCostGraph(cmd *cobra.Command, params costGraphParams) error {
+	ctx := cmd.Context()
+	if engine.OutputFormat(params.output) != engine.OutputDOT {
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+
+	log := logging.FromContext(ctx)
+	audit := newAuditContext(ctx, "cost graph", map[string]string{"pulumi_json": params.planPath})
+
+	cfg := config.New()
+	resources, err := loadAndMapResources(ctx, params.planPath, audit, engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes))
+	if err != nil {
+		return err
+	}
+
+	params.launch.resolveOffline(cfg)
+	transforms, err := newTransformChain(cfg)
+	if err != nil {
+		return err
+	}
+	customTypes, err := newCustomTypeRules(cfg)
+	if err != nil {
+		return err
+	}
+	costRules, err := newCostRules(cfg, "")
+	if err != nil {
+		return err
+	}
+	specDir := params.specDir
+	if specDir == "" {
+		specDir = cfg.SpecDir
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit, params.launch.openOptions(resources))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	eng, err := engine.New(clients, newSpecLoader(ctx, cfg, specDir), params.launch.engineOptions(cfg))
+	if err != nil {
+		return err
+	}
+	resultWithErrors, err := eng.
+		WithPricingCache(newPricingCache(cfg)).
+		WithPluginLayers(newPluginLayers(cfg)).
+		WithResultTransforms(transforms).
+		WithCustomTypes(customTypes).
+		WithCostRules(costRules).
+		GetProjectedCostWithErrors(ctx, resources)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("calculating projected costs: %w", err)
+	}
+
+	opts := engine.CostGraphOptions{CollapseComponents: params.collapse}
+	if renderErr := engine.RenderCostGraphDOT(
+		cmd.OutOrStdout(), resources, resultWithErrors.Results, opts,
+	); renderErr != nil {
+		return renderErr
+	}
+	// Errors go to stderr so the DOT output stays valid.
+	if resultWithErrors.HasErrors() {
+		fmt.Fprint(cmd.ErrOrStderr(), resultWithErrors.ErrorSummary())
+	}
+
+	log.Info().Ctx(ctx).Str("operation", "cost_graph").Int("resource_count", len(resources)).
+		Bool("collapse_components", params.collapse).Msg("cost graph exported")
+
+	totalCost := 0.0
+	for _, r := range resultWithErrors.Results {
+		totalCost += r.Monthly
+	}
+	audit.logSuccess(ctx, len(resultWithErrors.Results), totalCost)
+	return nil
+}
@@ -0,0 +1,61 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostGraphCmdFlags(t *testing.T) {
+	cmd := cli.NewCostGraphCmd()
+	for _, name := range []string{"pulumi-json", "spec-dir", "output", "collapse-components", "offline"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "dot", cmd.Flags().Lookup("output").DefValue)
+}
+
+func TestCostGraphCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main", "type": "aws:ec2/vpc:Vpc"},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"},
+		 "newState": {"dependencies": ["urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main"]}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	var stdout, stderr bytes.Buffer
+	cmd := cli.NewCostGraphCmd()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline"})
+	require.NoError(t, cmd.Execute())
+
+	out := stdout.String()
+	assert.Contains(t, out, "digraph costs {")
+	assert.Contains(t, out, `label="web\naws:ec2/instance:Instance\n7.30 USD/mo"`)
+	assert.Contains(t, out,
+		`"urn:pulumi:dev::app::aws:ec2/instance:Instance::web" -> "urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main";`)
+}
+
+func TestCostGraphCmd_UnsupportedOutput(t *testing.T) {
+	cmd := cli.NewCostGraphCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--pulumi-json", "plan.json", "--output", "json"})
+	require.ErrorContains(t, cmd.Execute(), "unsupported output format: json")
+}
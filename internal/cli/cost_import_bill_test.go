@@ -0,0 +1,76 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostImportBillCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	bill := filepath.Join(t.TempDir(), "bill.csv")
+	require.NoError(t, os.WriteFile(bill, []byte("ResourceId,ServiceName,UsageDate,Cost\n"+
+		"i-1,aws:ec2,2025-01-01,10\n"+
+		"i-1,aws:ec2,2025-01-02,10\n"+
+		"i-1,aws:ec2,2025-01-03,10\n"+
+		"i-1,aws:ec2,2025-01-04,40\n"+
+		"i-2,aws:ec2,2025-01-01,5\n"+
+		"logs,aws:s3,2025-01-02,2.5\n"), 0o600))
+	columns := "resource_id=ResourceId,cost=Cost,date=UsageDate,type=ServiceName"
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostImportBillCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"--file", bill, "--map", columns}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("--output", "json", "--group-by", "type")
+	require.NoError(t, err)
+	var results []engine.CostResult
+	require.NoError(t, json.Unmarshal([]byte(out), &results))
+	totals := make(map[string]float64, len(results))
+	for _, r := range results {
+		totals[r.ResourceType] = r.TotalCost
+	}
+	assert.InDelta(t, 75.0, totals["aws:ec2"], 0.0001)
+	assert.InDelta(t, 2.5, totals["aws:s3"], 0.0001)
+
+	out, err = run("--output", "table", "--anomaly-threshold", "0.5")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Cost anomalies:")
+	assert.Regexp(t, `aws:ec2/i-1\s+2025-01-04\s+10.00 USD\s+40.00 USD\s+\+300%`, out)
+
+	_, err = run("--group-by", "tag:env=prod")
+	require.ErrorContains(t, err, "not supported for bills")
+
+	out, err = run("--output", "json", "--group-by", "provider,type")
+	require.NoError(t, err)
+	var groups []engine.CostGroup
+	require.NoError(t, json.Unmarshal([]byte(out), &groups))
+	require.Len(t, groups, 1)
+	assert.Equal(t, "aws", groups[0].Key)
+	assert.InDelta(t, 77.5, groups[0].TotalCost, 0.0001)
+	require.Len(t, groups[0].Groups, 2)
+	assert.Equal(t, "aws:ec2", groups[0].Groups[0].Key)
+	assert.InDelta(t, 75.0, groups[0].Groups[0].TotalCost, 0.0001)
+
+	out, err = run("--output", "table", "--group-by", "provider,type")
+	require.NoError(t, err)
+	assert.Regexp(t, `(?m)^aws\s+77\.50\s+USD\n  aws:ec2\s+75\.00\s+USD\n  aws:s3\s+2\.50\s+USD$`, out)
+
+	_, err = run("--group-by", "provider,daily")
+	require.ErrorContains(t, err, "cannot be combined")
+}
@@ -2,14 +2,15 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/logging"
-	"github.com/rshade/finfocus/internal/spec"
 	"github.com/spf13/cobra"
 )
 
@@ -30,17 +31,42 @@ func displayErrorSummary(
 
 // costProjectedParams holds the parameters for the projected cost command execution.
 type costProjectedParams struct {
-	planPath    string
-	specDir     string
-	adapter     string
-	output      string
-	filter      []string
-	utilization float64
+	planPath      string
+	specDir       string
+	adapter       string
+	output        string
+	filter        []string
+	blastRadius   string
+	utilization   float64
+	annotations   []string
+	warnThreshold float64
+	jsonEnvelope  bool
+	validateOut   bool
+	verifyTotals  bool
+	noteDefaults  bool
+	timing        bool
+	normalize     bool
+	commitments   string
+	transfers     string
+	costRules     string
+	costHistory   string
+	allocTags     []string
+	provenance    bool
+	explainFrom   string
+	explain       bool
+	explainDiff   string
+	anonymize     bool
+	failOnBudget  string
+	budgetLimits  budgetLimitParams
+	costArtifact  string
+	sortBy        string
+	top           int
+	launch        pluginLaunchParams
 }
 
 // NewCostProjectedCmd creates the "projected" subcommand that calculates estimated costs from a Pulumi preview JSON.
 //
-// The returned command registers these flags: --pulumi-json (required), --spec-dir, --adapter, --output, --filter (can be provided multiple times), and --utilization.
+// The returned command registers these flags: --pulumi-json (required), --spec-dir, --adapter, --output, --filter (can be provided multiple times), --blast-radius, --utilization, --annotations, --warn-threshold, --json-envelope, --validate-output, --timing, --normalize, --commitment-report, --transfer-manifest, --cost-rules, --cost-history, --allocation-tags, --provenance, --explain-changes, --explain, --explain-diff, --anonymize, --fail-on-budget, --budget-total, --budget-provider, --budget-currency, --cost-artifact, --sort, --top, --max-plugins, --lazy-plugins, --offline, and --validate-plugins.
 // When executed the command collects the flag values and calls executeCostProjected with the assembled parameters.
 func NewCostProjectedCmd() *cobra.Command {
 	var params costProjectedParams
@@ -59,11 +85,58 @@ func NewCostProjectedCmd() *cobra.Command {
 	cmd.Flags().StringVar(&params.specDir, "spec-dir", "", "Directory containing pricing spec files")
 	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
 	cmd.Flags().StringVar(
-		&params.output, "output", config.GetDefaultOutputFormat(), "Output format: table, json, or ndjson")
+		&params.output, "output", config.GetDefaultOutputFormat(),
+		"Output format: table, json, ndjson, csv, focus, markdown, or github-actions")
 	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
 		"Resource filter expressions (e.g., 'type=aws:ec2/instance')")
+	cmd.Flags().StringVar(&params.blastRadius, "blast-radius", "",
+		"Only price this resource URN and the resources that depend on it, directly or indirectly")
 	cmd.Flags().Float64Var(
 		&params.utilization, "utilization", 1.0, "Utilization rate for sustainability calculations (0.0 to 1.0)")
+	cmd.Flags().StringSliceVar(&params.annotations, "annotations", []string{},
+		"Annotation keys to extract from resource properties or tags and show as columns (e.g., 'owner,ticket')")
+	cmd.Flags().Float64Var(&params.warnThreshold, "warn-threshold", 0,
+		"Monthly cost above which a resource is flagged in github-actions output (0 disables)")
+	cmd.Flags().BoolVar(&params.jsonEnvelope, "json-envelope", false,
+		"Wrap JSON output in a versioned envelope with summary, results, errors, and metadata")
+	cmd.Flags().BoolVar(&params.validateOut, "validate-output", false,
+		"Check the --json-envelope output against the published envelope schema and fail if it does not match")
+	cmd.Flags().BoolVar(&params.noteDefaults, "note-defaults", false,
+		"Explain the cost of provider default resources, such as the default VPC, and of instances placed in them")
+	cmd.Flags().BoolVar(&params.verifyTotals, "verify-totals", false,
+		"Check that summary totals and breakdowns add up to the resource costs and fail if they do not")
+	cmd.Flags().BoolVar(&params.timing, "timing", false, "Print a breakdown of time spent per stage to stderr")
+	cmd.Flags().BoolVar(&params.normalize, "normalize", false,
+		"Show cost per vCPU and per GB of memory, sorted from least to most cost-efficient")
+	cmd.Flags().StringVar(&params.commitments, "commitment-report", "",
+		"Commitment utilization export (CSV or JSON) used to blend on-demand and committed rates")
+	cmd.Flags().StringVar(&params.transfers, "transfer-manifest", "",
+		"YAML file of expected monthly data transfer per resource, priced as inter-AZ, inter-region or egress")
+	cmd.Flags().StringVar(&params.costRules, "cost-rules", "",
+		"YAML file of ordered rules that set or scale the cost of matching resources (default: cost_rules)")
+	cmd.Flags().StringVar(&params.costHistory, "cost-history", "",
+		"JSON file of past projected and actual costs used to attach confidence intervals to estimates")
+	cmd.Flags().StringSliceVar(&params.allocTags, "allocation-tags", []string{},
+		"Tag keys promoted to top-level JSON fields and CSV columns (default: output.allocation_tags)")
+	cmd.Flags().BoolVar(&params.provenance, "provenance", false,
+		"Record the pricing source, version and date behind each result")
+	cmd.Flags().StringVar(&params.explainFrom, "explain-changes", "",
+		"Prior JSON output recorded with --provenance; explains whether each cost change came from "+
+			"infrastructure or pricing data")
+	cmd.Flags().BoolVar(&params.explain, "explain", false,
+		"Show each estimate's confidence, completeness score and missing pricing properties")
+	cmd.Flags().StringVar(&params.explainDiff, "explain-diff", "",
+		"Compare the cost breakdowns of plugins that priced this resource (ID or URN name), or 'all'")
+	cmd.Flags().BoolVar(&params.anonymize, "anonymize", false,
+		"Replace resource IDs with stable pseudonyms and redact the tags in output.redact_tags, for sharing")
+	cmd.Flags().StringVar(&params.failOnBudget, "fail-on-budget", string(engine.BudgetStatusWarning),
+		"Budget threshold that fails the command: warning (exit 3), critical (exit 4), or none")
+	addBudgetLimitFlags(cmd, &params.budgetLimits)
+	cmd.Flags().StringVar(&params.costArtifact, "cost-artifact", "",
+		"Also write per-resource and total cost keyed by URN as JSON to this file, for Pulumi automation")
+	addSortFlag(cmd, &params.sortBy)
+	addTopFlag(cmd, &params.top)
+	addPluginLaunchFlags(cmd, &params.launch)
 	_ = cmd.MarkFlagRequired("pulumi-json")
 
 	return cmd
@@ -75,14 +148,57 @@ const costProjectedExample = `  # Basic usage
   # Filter resources by type
   finfocus cost projected --pulumi-json plan.json --filter "type=aws:ec2/instance"
 
+  # Cost of a shared component and everything that depends on it
+  finfocus cost projected --pulumi-json plan.json --blast-radius "urn:pulumi:prod::app::aws:ec2/vpc:Vpc::main"
+
   # Output as JSON
   finfocus cost projected --pulumi-json plan.json --output json
 
+  # Output as a versioned JSON envelope with summary and errors
+  finfocus cost projected --pulumi-json plan.json --output json --json-envelope
+
   # Use a specific adapter plugin
   finfocus cost projected --pulumi-json plan.json --adapter aws-plugin
 
   # Use custom spec directory
-  finfocus cost projected --pulumi-json plan.json --spec-dir ./custom-specs`
+  finfocus cost projected --pulumi-json plan.json --spec-dir ./custom-specs
+
+  # Show owner and ticket annotations as columns
+  finfocus cost projected --pulumi-json plan.json --annotations owner,ticket
+
+  # Export CSV with team and cost-center columns for a cost allocation tool
+  finfocus cost projected --pulumi-json plan.json --output csv --allocation-tags team,cost-center
+
+  # Explain whether costs moved because resources or pricing data changed
+  finfocus cost projected --pulumi-json plan.json --output json --provenance > snapshot.json
+  finfocus cost projected --pulumi-json plan.json --explain-changes snapshot.json
+
+  # Show which estimates rest on defaults because pricing properties are missing
+  finfocus cost projected --pulumi-json plan.json --explain
+
+  # Share a report without exposing resource names
+  finfocus cost projected --pulumi-json plan.json --anonymize
+
+  # Write cost by URN for a later pipeline step or Pulumi program to read
+  finfocus cost projected --pulumi-json plan.json --cost-artifact cost.json
+
+  # Report environment budgets but only fail when one is exhausted
+  finfocus cost projected --pulumi-json plan.json --fail-on-budget critical
+
+  # Fail CI when the stack exceeds $2000/month or AWS exceeds $1500/month
+  finfocus cost projected --pulumi-json plan.json --budget-total 2000 --budget-provider aws=1500
+
+  # Emit GitHub Actions annotations, warning on resources over $500/month
+  finfocus cost projected --pulumi-json plan.json --output github-actions --warn-threshold 500
+
+  # List the most expensive resources first
+  finfocus cost projected --pulumi-json plan.json --sort monthly:desc
+
+  # Show the ten most expensive resources and the total of the rest
+  finfocus cost projected --pulumi-json plan.json --top 10
+
+  # Write a Markdown cost table for a pull request comment
+  finfocus cost projected --pulumi-json plan.json --output markdown > cost-comment.md`
 
 // executeCostProjected runs the projected cost workflow for a Pulumi plan.
 // It validates and injects the utilization into the context, loads and maps resources
@@ -99,12 +215,31 @@ const costProjectedExample = `  # Basic usage
 // resource loading/mapping or plugin initialization fails, cost calculation fails, or
 // result rendering fails.
 func executeCostProjected(cmd *cobra.Command, params costProjectedParams) error {
-	ctx := cmd.Context()
+	ctx, printTimings := startTimings(cmd.Context(), cmd, params.timing)
+	defer printTimings()
 
 	if params.utilization < 0.0 || params.utilization > 1.0 {
 		return fmt.Errorf("utilization must be between 0.0 and 1.0, got %f", params.utilization)
 	}
 	ctx = context.WithValue(ctx, engine.ContextKeyUtilization, params.utilization)
+	failOnBudget, err := parseBudgetFailOn(params.failOnBudget)
+	if err != nil {
+		return err
+	}
+	if _, _, err = params.budgetLimits.limits(); err != nil {
+		return err
+	}
+	resultSort, sorted, err := parseSortFlag(params.sortBy)
+	if err != nil {
+		return err
+	}
+	if params.top < 0 {
+		return fmt.Errorf("--top must be zero or more, got %d", params.top)
+	}
+	if params.top > 0 && !sorted {
+		// The top resources are the most expensive unless --sort says otherwise.
+		resultSort, sorted = engine.ResultSort{Key: engine.SortByMonthly, Descending: true}, true
+	}
 
 	log := logging.FromContext(ctx)
 	log.Debug().Ctx(ctx).Str("operation", "cost_projected").Str("plan_path", params.planPath).
@@ -114,45 +249,197 @@ func executeCostProjected(cmd *cobra.Command, params costProjectedParams) error
 	if len(params.filter) > 0 {
 		auditParams["filter"] = strings.Join(params.filter, ",")
 	}
+	if params.blastRadius != "" {
+		auditParams["blast_radius"] = params.blastRadius
+	}
 	audit := newAuditContext(ctx, "cost projected", auditParams)
 
-	resources, err := loadAndMapResources(ctx, params.planPath, audit)
+	cfg := config.New()
+	resources, err := loadAndMapResources(ctx, params.planPath, audit,
+		engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes), params.annotations...)
 	if err != nil {
 		return err
 	}
 
+	if params.blastRadius != "" {
+		resources, err = engine.BlastRadius(resources, params.blastRadius)
+		if err != nil {
+			return err
+		}
+		log.Debug().Ctx(ctx).Str("target", params.blastRadius).Int("resource_count", len(resources)).
+			Msg("limited resources to blast radius")
+	}
+
 	for _, f := range params.filter {
 		if f != "" {
 			if filterErr := engine.ValidateFilter(f); filterErr != nil {
 				return filterErr
 			}
-			resources = engine.FilterResources(resources, f)
+			if resources, err = engine.FilterResources(resources, f); err != nil {
+				return err
+			}
 			log.Debug().Ctx(ctx).Str("filter", f).Int("filtered_count", len(resources)).
 				Msg("applied resource filter")
 		}
 	}
 
+	params.launch.resolveOffline(cfg)
+	allocationTags, err := applyAllocationTags(resources, params.allocTags, cfg)
+	if err != nil {
+		return err
+	}
+	transforms, err := newTransformChain(cfg)
+	if err != nil {
+		return err
+	}
+	customTypes, err := newCustomTypeRules(cfg)
+	if err != nil {
+		return err
+	}
+	costRules, err := newCostRules(cfg, params.costRules)
+	if err != nil {
+		return err
+	}
+	if _, _, budgetErr := newBudgetPolicy(cfg); budgetErr != nil {
+		return budgetErr
+	}
 	specDir := params.specDir
 	if specDir == "" {
-		specDir = config.New().SpecDir
+		specDir = cfg.SpecDir
 	}
 
-	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	var commitments *engine.CommitmentReport
+	if params.commitments != "" {
+		commitments, err = engine.LoadCommitmentReport(params.commitments)
+		if err != nil {
+			return err
+		}
+	}
+
+	var history *engine.CostHistory
+	if params.costHistory != "" {
+		history, err = engine.LoadCostHistory(params.costHistory)
+		if err != nil {
+			return err
+		}
+	}
+
+	var snapshot []engine.CostResult
+	if params.explainFrom != "" {
+		snapshot, err = engine.LoadBaseline(params.explainFrom)
+		if err != nil {
+			return fmt.Errorf("loading snapshot: %w", err)
+		}
+	}
+
+	var transfers []engine.TransferEstimate
+	if params.transfers != "" {
+		transfers, err = engine.LoadTransferManifest(params.transfers)
+		if err != nil {
+			return err
+		}
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit, params.launch.openOptions(resources))
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
-	resultWithErrors, err := engine.New(clients, spec.NewLoader(specDir)).GetProjectedCostWithErrors(ctx, resources)
+	opts := params.launch.engineOptions(cfg)
+	opts.PricingProvenance = params.provenance || params.explainFrom != ""
+	opts.VerifyTotals = params.verifyTotals
+	opts.DefaultResourceNotes = params.noteDefaults
+	eng, err := engine.New(clients, newSpecLoader(ctx, cfg, specDir), opts)
+	if err != nil {
+		return err
+	}
+	resultWithErrors, err := eng.
+		WithCommitmentCoverage(commitments).
+		WithTransferEstimates(transfers).
+		WithPricingCache(newPricingCache(cfg)).
+		WithPluginLayers(newPluginLayers(cfg)).
+		WithResultTransforms(transforms).
+		WithCustomTypes(customTypes).
+		WithCostRules(costRules).
+		WithCostHistory(history).
+		GetProjectedCostWithErrors(ctx, resources)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to calculate projected costs")
 		audit.logFailure(ctx, err)
 		return fmt.Errorf("calculating projected costs: %w", err)
 	}
 
-	if renderErr := RenderCostOutput(ctx, cmd, params.output, resultWithErrors); renderErr != nil {
+	var anonymizer *engine.Anonymizer
+	rendered := resultWithErrors
+	if params.anonymize {
+		anonymizer = newAnonymizer(cfg)
+		rendered = anonymizer.Anonymize(resultWithErrors)
+	}
+	if sorted {
+		rendered = sortCostResults(rendered, resultSort)
+	}
+	// Only the output is limited by --top; the cost artifact and budgets cover every resource.
+	displayed, omitted := topCostResults(rendered, params.top)
+
+	envelope, err := newEnvelopeMeta(params.jsonEnvelope, params.output, "cost projected", resources)
+	if err != nil {
+		return err
+	}
+	if params.validateOut && envelope == nil {
+		return errors.New("--validate-output requires --json-envelope")
+	}
+	if params.top > 0 && envelope != nil {
+		// The envelope is a complete machine-readable report, so it is never truncated.
+		return errors.New("--top cannot be combined with --json-envelope")
+	}
+	renderOpts := engine.RenderOptions{
+		Annotations:          params.annotations,
+		GitHubFile:           detectPulumiProjectFile(),
+		CostWarningThreshold: params.warnThreshold,
+		Envelope:             envelope,
+		ValidateEnvelope:     params.validateOut,
+		Normalize:            params.normalize,
+		AllocationTags:       allocationTags,
+		Omitted:              omitted,
+	}
+	if engine.OutputFormat(params.output) == engine.OutputGitHubActions && !engine.IsGitHubActions() {
+		log.Debug().Ctx(ctx).Msg("github-actions output requested outside a GitHub Actions runner")
+	}
+	stopRender := engine.TimingsFromContext(ctx).Track(engine.StageRender)
+	renderErr := RenderCostOutput(ctx, cmd, params.output, displayed, renderOpts)
+	stopRender()
+	if renderErr != nil {
 		return renderErr
 	}
+	if params.costArtifact != "" {
+		if artifactErr := engine.WriteCostArtifact(params.costArtifact, rendered.Results); artifactErr != nil {
+			return artifactErr
+		}
+	}
+	if params.explainFrom != "" {
+		explanation := engine.ExplainCostChanges(snapshot, resultWithErrors.Results)
+		if anonymizer != nil {
+			explanation = anonymizer.AnonymizeChanges(explanation)
+		}
+		if explainErr := renderCostChanges(cmd, params.output, explanation); explainErr != nil {
+			return explainErr
+		}
+	}
+	if params.explain {
+		if explainErr := renderCompleteness(cmd, params.output, rendered.Results); explainErr != nil {
+			return explainErr
+		}
+	}
+	if params.explainDiff != "" {
+		diffs := engine.ExplainPluginDiff(resultWithErrors.Results, params.explainDiff)
+		if anonymizer != nil {
+			diffs = anonymizer.AnonymizePluginDiffs(diffs)
+		}
+		if explainErr := renderPluginDiff(cmd, params.output, diffs); explainErr != nil {
+			return explainErr
+		}
+	}
 
 	log.Info().Ctx(ctx).Str("operation", "cost_projected").Int("result_count", len(resultWithErrors.Results)).
 		Dur("duration_ms", time.Since(audit.start)).Msg("projected cost calculation complete")
@@ -162,5 +449,49 @@ func executeCostProjected(cmd *cobra.Command, params costProjectedParams) error
 		totalCost += r.Monthly
 	}
 	audit.logSuccess(ctx, len(resultWithErrors.Results), totalCost)
-	return nil
+	limitErr := reportBudgetLimits(cmd, params.output, params.budgetLimits, resultWithErrors.Results)
+	if budgetErr := reportBudgets(cmd, params.output, cfg, resources, resultWithErrors.Results,
+		failOnBudget); budgetErr != nil && limitErr == nil {
+		return budgetErr
+	}
+	return limitErr
+}
+
+// renderCostChanges explains cost changes since a snapshot. It follows the table output on
+// stdout and goes to stderr for other formats so machine-readable output stays valid.
+func renderCostChanges(cmd *cobra.Command, output string, explanation *engine.CostChangeExplanation) error {
+	if engine.OutputFormat(output) == engine.OutputTable {
+		cmd.Println()
+		return engine.RenderCostChanges(cmd.OutOrStdout(), engine.OutputTable, explanation)
+	}
+	return engine.RenderCostChanges(cmd.ErrOrStderr(), engine.OutputTable, explanation)
+}
+
+// renderCompleteness explains the confidence of each estimate, placed like renderCostChanges.
+func renderCompleteness(cmd *cobra.Command, output string, results []engine.CostResult) error {
+	if engine.OutputFormat(output) == engine.OutputTable {
+		cmd.Println()
+		return engine.RenderCompleteness(cmd.OutOrStdout(), results)
+	}
+	return engine.RenderCompleteness(cmd.ErrOrStderr(), results)
+}
+
+// renderPluginDiff compares plugin breakdowns side by side, placed like renderCostChanges.
+func renderPluginDiff(cmd *cobra.Command, output string, diffs []engine.PluginDiff) error {
+	if engine.OutputFormat(output) == engine.OutputTable {
+		cmd.Println()
+		return engine.RenderPluginDiff(cmd.OutOrStdout(), diffs)
+	}
+	return engine.RenderPluginDiff(cmd.ErrOrStderr(), diffs)
+}
+
+// detectPulumiProjectFile returns the Pulumi project file in the working directory so that
+// GitHub Actions annotations can be anchored to it, or "" when none exists.
+func detectPulumiProjectFile() string {
+	for _, name := range []string{"Pulumi.yaml", "Pulumi.yml"} {
+		if info, err := os.Stat(name); err == nil && !info.IsDir() {
+			return name
+		}
+	}
+	return ""
 }
@@ -2,9 +2,14 @@ package cli_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/engine"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +105,143 @@ func TestCostProjectedCmdFlags(t *testing.T) {
 	assert.NotNil(t, filterFlag)
 	assert.Equal(t, "stringArray", filterFlag.Value.Type())
 	assert.Equal(t, "[]", filterFlag.DefValue)
+
+	annotationsFlag := cmd.Flags().Lookup("annotations")
+	assert.NotNil(t, annotationsFlag)
+	assert.Equal(t, "stringSlice", annotationsFlag.Value.Type())
+	assert.Equal(t, "[]", annotationsFlag.DefValue)
+
+	envelopeFlag := cmd.Flags().Lookup("json-envelope")
+	assert.NotNil(t, envelopeFlag)
+	assert.Equal(t, "bool", envelopeFlag.Value.Type())
+	assert.Equal(t, "false", envelopeFlag.DefValue)
+
+	timingFlag := cmd.Flags().Lookup("timing")
+	assert.NotNil(t, timingFlag)
+	assert.Equal(t, "bool", timingFlag.Value.Type())
+
+	normalizeFlag := cmd.Flags().Lookup("normalize")
+	assert.NotNil(t, normalizeFlag)
+	assert.Equal(t, "bool", normalizeFlag.Value.Type())
+	assert.Equal(t, "false", normalizeFlag.DefValue)
+
+	commitmentFlag := cmd.Flags().Lookup("commitment-report")
+	assert.NotNil(t, commitmentFlag)
+	assert.Equal(t, "string", commitmentFlag.Value.Type())
+
+	allocationFlag := cmd.Flags().Lookup("allocation-tags")
+	assert.NotNil(t, allocationFlag)
+	assert.Equal(t, "stringSlice", allocationFlag.Value.Type())
+
+	transferFlag := cmd.Flags().Lookup("transfer-manifest")
+	assert.NotNil(t, transferFlag)
+	assert.Equal(t, "string", transferFlag.Value.Type())
+
+	provenanceFlag := cmd.Flags().Lookup("provenance")
+	assert.NotNil(t, provenanceFlag)
+	assert.Equal(t, "bool", provenanceFlag.Value.Type())
+
+	explainFlag := cmd.Flags().Lookup("explain-changes")
+	assert.NotNil(t, explainFlag)
+	assert.Equal(t, "string", explainFlag.Value.Type())
+
+	explainConfidenceFlag := cmd.Flags().Lookup("explain")
+	assert.NotNil(t, explainConfidenceFlag)
+	assert.Equal(t, "false", explainConfidenceFlag.DefValue)
+
+	blastRadiusFlag := cmd.Flags().Lookup("blast-radius")
+	assert.NotNil(t, blastRadiusFlag)
+	assert.Equal(t, "string", blastRadiusFlag.Value.Type())
+
+	anonymizeFlag := cmd.Flags().Lookup("anonymize")
+	assert.NotNil(t, anonymizeFlag)
+	assert.Equal(t, "false", anonymizeFlag.DefValue)
+
+	maxPluginsFlag := cmd.Flags().Lookup("max-plugins")
+	assert.NotNil(t, maxPluginsFlag)
+	assert.Equal(t, "int", maxPluginsFlag.Value.Type())
+	assert.Equal(t, "0", maxPluginsFlag.DefValue)
+
+	lazyFlag := cmd.Flags().Lookup("lazy-plugins")
+	assert.NotNil(t, lazyFlag)
+	assert.Equal(t, "false", lazyFlag.DefValue)
+
+	offlineFlag := cmd.Flags().Lookup("offline")
+	assert.NotNil(t, offlineFlag)
+	assert.Equal(t, "false", offlineFlag.DefValue)
+
+	validateFlag := cmd.Flags().Lookup("validate-plugins")
+	assert.NotNil(t, validateFlag)
+	assert.Equal(t, "false", validateFlag.DefValue)
+}
+
+func TestCostProjectedCmd_Offline(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets",
+		 "type": "aws:s3/bucket:Bucket", "inputs": {}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{
+		"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--output", "json",
+	})
+	require.NoError(t, cmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, `"adapter": "local-spec"`)
+	assert.Contains(t, out, `"adapter": "none"`, "resources without a spec are reported as unpriced")
+}
+
+func TestCostProjectedCmd_CostArtifact(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	artifactPath := filepath.Join(dir, "cost.json")
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{
+		"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--cost-artifact", artifactPath,
+	})
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(artifactPath)
+	require.NoError(t, err)
+	var artifact engine.CostArtifact
+	require.NoError(t, json.Unmarshal(data, &artifact))
+	assert.Equal(t, "USD", artifact.Currency)
+	assert.InDelta(t, 7.3, artifact.TotalMonthly, 0.001)
+	web := artifact.Resources["urn:pulumi:dev::app::aws:ec2/instance:Instance::web"]
+	assert.Equal(t, "aws:ec2/instance:Instance", web.Type)
+	assert.InDelta(t, 0.01, web.Hourly, 0.0001)
 }
 
 func TestCostProjectedCmdHelp(t *testing.T) {
@@ -173,3 +315,632 @@ func TestCostProjectedCmdErrorSummaryDisplay(t *testing.T) {
 	outputFlag := cmd.Flags().Lookup("output")
 	assert.NotNil(t, outputFlag, "Should have output flag for format selection")
 }
+
+func TestCostProjectedCmd_CSVAllocationTags(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance",
+		 "inputs": {"instanceType": "t3.micro", "tags": {"Team": "platform", "CostCenter": "cc-42"}}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{
+		"--pulumi-json", planPath, "--offline", "--output", "csv",
+		"--allocation-tags", "team,cost_center,environment",
+	})
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t,
+		"resource_type,resource_id,adapter,currency,monthly,hourly,team,cost-center,environment,notes", lines[0])
+	assert.Contains(t, lines[1], ",platform,cc-42,,")
+}
+
+func TestCostProjectedCmd_MarkdownOutput(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  monthlyEstimate: 7.5\n"), 0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--pulumi-json", planPath, "--spec-dir", dir, "--offline", "--output", "markdown"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, buf.String(),
+		"| Resource | Type | Adapter | Monthly | Hourly |\n| --- | --- | --- | ---: | ---: |\n")
+	assert.Contains(t, buf.String(), "| \\$7.50 |")
+	assert.Contains(t, buf.String(), "**Total: \\$7.50/month** across 1 resource")
+}
+
+func TestCostProjectedCmd_Sort(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::small",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::large",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "m5.large"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	for sku, monthly := range map[string]string{"t3.micro": "7.5", "m5.large": "70.5"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-"+sku+".yaml"),
+			[]byte("provider: aws\nservice: ec2\nsku: "+sku+"\ncurrency: USD\npricing:\n  monthlyEstimate: "+monthly+"\n"),
+			0o600))
+	}
+	run := func(sortBy string) ([]float64, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{
+			"--pulumi-json", planPath, "--spec-dir", dir, "--offline", "--output", "ndjson", "--sort", sortBy,
+		})
+		if err := cmd.Execute(); err != nil {
+			return nil, err
+		}
+		var monthly []float64
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var result engine.CostResult
+			require.NoError(t, json.Unmarshal([]byte(line), &result))
+			monthly = append(monthly, result.Monthly)
+		}
+		return monthly, nil
+	}
+
+	monthly, err := run("monthly")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{70.5, 7.5}, monthly)
+	monthly, err = run("monthly:asc")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{7.5, 70.5}, monthly)
+
+	_, err = run("price")
+	require.ErrorIs(t, err, engine.ErrInvalidSort)
+}
+
+func TestCostProjectedCmd_Top(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::small",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::medium",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.large"}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::large",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "m5.large"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	for sku, monthly := range map[string]string{"t3.micro": "7.5", "t3.large": "60.25", "m5.large": "70.5"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-"+sku+".yaml"),
+			[]byte("provider: aws\nservice: ec2\nsku: "+sku+"\ncurrency: USD\npricing:\n  monthlyEstimate: "+monthly+"\n"),
+			0o600))
+	}
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"--pulumi-json", planPath, "--spec-dir", dir, "--offline"}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("--output", "table", "--top", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "aws-ec2-m5.large")
+	assert.NotContains(t, out, "aws-ec2-t3.micro")
+	assert.Contains(t, out, "... and 2 more (total $67.75/month)")
+
+	out, err = run("--output", "json", "--top", "2")
+	require.NoError(t, err)
+	var parsed struct {
+		FinFocus engine.AggregatedResults `json:"finfocus"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &parsed))
+	require.Len(t, parsed.FinFocus.Resources, 2)
+	assert.InDelta(t, 70.5, parsed.FinFocus.Resources[0].Monthly, 0.001)
+	require.NotNil(t, parsed.FinFocus.Omitted)
+	assert.Equal(t, 1, parsed.FinFocus.Omitted.Count)
+	assert.InDelta(t, 7.5, parsed.FinFocus.Omitted.Monthly, 0.001)
+	assert.NotContains(t, out, "... and")
+
+	out, err = run("--output", "json", "--top", "0")
+	require.NoError(t, err)
+	var all struct {
+		FinFocus engine.AggregatedResults `json:"finfocus"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &all))
+	assert.Len(t, all.FinFocus.Resources, 3)
+	assert.Nil(t, all.FinFocus.Omitted)
+
+	_, err = run("--top", "-1")
+	require.Error(t, err)
+}
+
+func TestCostProjectedCmd_ExplainChanges(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	writeSpec := func(hourly string) {
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+			[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: "+hourly+"\n"),
+			0o600))
+	}
+	run := func(args ...string) string {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline"}, args...))
+		require.NoError(t, cmd.Execute())
+		return buf.String()
+	}
+
+	writeSpec("0.01")
+	snapshot := run("--output", "json", "--provenance")
+	assert.Contains(t, snapshot, `"provenance"`)
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+	require.NoError(t, os.WriteFile(snapshotPath, []byte(snapshot), 0o600))
+
+	writeSpec("0.02")
+	out := run("--explain-changes", snapshotPath)
+	assert.Contains(t, out, "Cost change since snapshot: +7.30 USD (infrastructure +0.00, pricing +7.30, unknown +0.00)")
+}
+
+func TestCostProjectedCmd_Explain(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--explain"})
+	require.NoError(t, cmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "Estimate confidence:")
+	assert.Regexp(t, `MEDIUM\s+50%\s+region`, out)
+}
+
+func TestCostProjectedCmd_Transforms(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	run := func() (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--output", "json"})
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	config := "transforms:\n  - type: markup\n    percent: 100\n  - type: currency\n    to: EUR\n    rate: 0.5\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(config), 0o600))
+	out, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"monthly": 7.3`)
+	assert.Contains(t, out, `"currency": "EUR"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte("transforms:\n  - type: tax\n"), 0o600))
+	_, err = run()
+	require.ErrorIs(t, err, engine.ErrUnknownTransform)
+}
+
+func TestCostProjectedCmd_EngineConfig(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	run := func() (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--output", "json"})
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	config := "engine:\n  workers: 2\n  hours_per_month: 720\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(config), 0o600))
+	out, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"monthly": 7.2`)
+
+	config = "engine:\n  hours_per_month: -720\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(config), 0o600))
+	_, err = run()
+	require.ErrorIs(t, err, engine.ErrInvalidHoursPerMonth)
+}
+
+func TestCostProjectedCmd_CustomTypes(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::pulumi-nodejs:dynamic:Resource::runner",
+		 "type": "pulumi-nodejs:dynamic:Resource", "inputs": {"instanceType": "t3.micro"}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::pulumi-python:dynamic:Resource::hook",
+		 "type": "pulumi-python:dynamic:Resource", "inputs": {}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	run := func() (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--output", "json"})
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	config := "custom_types:\n  - type: pulumi-nodejs:dynamic:Resource\n    action: map\n" +
+		"    map_to: aws:ec2/instance:Instance\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(config), 0o600))
+	out, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"monthly": 7.3`)
+	assert.Contains(t, out, "Dynamic provider resource: priced as aws:ec2/instance:Instance")
+	assert.Contains(t, out, "Dynamic provider resource: no custom_types mapping, cannot be priced")
+
+	config = "custom_types:\n  - type: pulumi-nodejs:dynamic:Resource\n    action: map\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(config), 0o600))
+	_, err = run()
+	require.ErrorContains(t, err, "invalid custom_types configuration")
+}
+
+func TestCostProjectedCmd_CostRules(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance",
+		 "inputs": {"instanceType": "t3.micro", "tags": {"env": "test"}}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	rulesPath := filepath.Join(dir, "rules.yaml")
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{
+			"--pulumi-json", planPath, "--spec-dir", specDir, "--offline", "--output", "json",
+		}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	rules := "rules:\n  - name: test-half-price\n    match:\n      tags:\n        env: test\n    multiplier: 0.5\n"
+	require.NoError(t, os.WriteFile(rulesPath, []byte(rules), 0o600))
+	out, err := run("--cost-rules", rulesPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, `"monthly": 3.65`)
+	assert.Contains(t, out, `"costRule": "test-half-price"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte("cost_rules: "+rulesPath+"\n"), 0o600))
+	out, err = run()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"monthly": 3.65`, "the configured rules file is used without the flag")
+
+	rules = "rules:\n  - name: broken\n    match:\n      type: aws:*\n"
+	require.NoError(t, os.WriteFile(rulesPath, []byte(rules), 0o600))
+	_, err = run()
+	require.ErrorContains(t, err, "set exactly one of monthly, multiplier or pricing")
+}
+
+func TestCostProjectedCmd_Budgets(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("NO_COLOR", "1")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::api",
+		 "type": "aws:ec2/instance:Instance",
+		 "inputs": {"instanceType": "t3.micro", "tags": {"Environment": "prod"}}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	run := func(devBudget string, extra ...string) (string, error) {
+		config := "budgets:\n  environments:\n    prod:\n      amount: 100\n    dev:\n      amount: " + devBudget + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(config), 0o600))
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline"}, extra...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+	exitCode := func(err error) int {
+		var coded interface{ ExitCode() int }
+		require.ErrorAs(t, err, &coded)
+		return coded.ExitCode()
+	}
+
+	// Each environment holds one 7.30/month instance.
+	out, err := run("50")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Budgets:")
+	assert.Regexp(t, `dev\s+50.00 USD\s+7.30 USD\s+15%\s+OK`, out)
+	assert.Regexp(t, `prod\s+100.00 USD\s+7.30 USD\s+7%\s+OK`, out)
+
+	out, err = run("8")
+	assert.Equal(t, 3, exitCode(err))
+	assert.Regexp(t, `dev\s+8.00 USD\s+7.30 USD\s+91%\s+WARNING`, out)
+
+	_, err = run("8", "--fail-on-budget", "critical")
+	require.NoError(t, err)
+
+	_, err = run("7")
+	assert.Equal(t, 4, exitCode(err))
+
+	_, err = run("7", "--fail-on-budget", "none")
+	require.NoError(t, err)
+
+	_, err = run("7", "--fail-on-budget", "sometimes")
+	require.ErrorContains(t, err, "invalid --fail-on-budget")
+
+	_, err = run("-1")
+	require.ErrorContains(t, err, "invalid budgets configuration")
+}
+
+func TestCostProjectedCmd_BudgetLimits(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+	run := func(extra ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"--pulumi-json", planPath, "--spec-dir", specDir, "--offline"}, extra...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	// The instance costs 7.30/month.
+	out, err := run("--budget-total", "10", "--budget-provider", "aws=8")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "Budget limits exceeded")
+
+	out, err = run("--budget-total", "5", "--budget-provider", "aws=8")
+	var coded interface{ ExitCode() int }
+	require.ErrorAs(t, err, &coded)
+	assert.Equal(t, 4, coded.ExitCode())
+	assert.Regexp(t, `total\s+5.00 USD\s+7.30 USD\s+2.30 USD`, out)
+	assert.NotContains(t, out, "provider:aws")
+
+	_, err = run("--budget-total", "5", "--budget-currency", "EUR")
+	require.ErrorIs(t, err, engine.ErrBudgetCurrencyMismatch)
+
+	_, err = run("--budget-provider", "aws")
+	require.ErrorContains(t, err, "invalid --budget-provider")
+}
+
+func TestCostProjectedCmd_Anonymize(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	const urn = "urn:pulumi:prod::payments::aws:ec2/instance:Instance::payments-db"
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "` + urn + `",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--pulumi-json", planPath, "--offline", "--output", "json", "--anonymize"})
+	require.NoError(t, cmd.Execute())
+
+	out := buf.String()
+	assert.NotContains(t, out, "payments-db")
+	assert.Contains(t, out, engine.NewAnonymizer("", nil).Pseudonym("aws:ec2/instance:Instance", urn))
+	assert.Contains(t, out, `"resourceType": "aws:ec2/instance:Instance"`)
+}
+
+func TestCostProjectedCmd_FOCUSOutput(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--pulumi-json", planPath, "--offline", "--output", "focus"})
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "BilledCost,EffectiveCost,ListCost,"))
+	assert.Contains(t, lines[1], ",AWS,")
+	assert.Contains(t, lines[1], ",web,aws:ec2/instance:Instance,")
+}
+
+func TestCostProjectedCmd_BlastRadius(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main", "type": "aws:ec2/vpc:Vpc"},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"},
+		 "newState": {"dependencies": ["urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main"]}},
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets", "type": "aws:s3/bucket:Bucket"}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+
+	run := func(target string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostProjectedCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"--pulumi-json", planPath, "--offline", "--output", "ndjson", "--blast-radius", target})
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main")
+	require.NoError(t, err)
+	assert.Contains(t, out, "::web")
+	assert.NotContains(t, out, "::assets")
+
+	_, err = run("urn:pulumi:dev::app::aws:ec2/vpc:Vpc::other")
+	require.ErrorIs(t, err, engine.ErrBlastRadiusTarget)
+}
+
+func TestCostProjectedCmd_ValidateOutput(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	plan := `{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		 "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}
+	]}`
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{
+		"--pulumi-json", planPath, "--offline", "--output", "json", "--json-envelope", "--validate-output",
+	})
+	require.NoError(t, cmd.Execute())
+	require.NoError(t, engine.ValidateEnvelope(buf.Bytes()))
+
+	cmd = cli.NewCostProjectedCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--pulumi-json", planPath, "--offline", "--output", "json", "--validate-output"})
+	require.ErrorContains(t, cmd.Execute(), "--validate-output requires --json-envelope")
+}
@@ -33,6 +33,8 @@ type costRecommendationsParams struct {
 	output   string
 	filter   []string
 	verbose  bool
+	fromStr  string
+	toStr    string
 }
 
 // NewCostRecommendationsCmd creates the "recommendations" subcommand that fetches cost optimization
@@ -43,6 +45,7 @@ type costRecommendationsParams struct {
 //   - --adapter: restrict to a specific adapter plugin
 //   - --output: output format (table, json, ndjson; defaults from configuration)
 //   - --filter: filter expressions for recommendations (e.g., 'action=MIGRATE')
+//   - --from/--to: usage period analyzed for reserved-instance and savings plan purchases
 //
 // The returned *cobra.Command is ready to be added to the CLI command tree.
 func NewCostRecommendationsCmd() *cobra.Command {
@@ -63,6 +66,10 @@ In interactive terminals, launches a TUI with:
   - Detail view by pressing Enter
   - Quit by pressing 'q' or Ctrl+C
 
+With --from, the actual costs of the period are analyzed for resources whose pricing
+specs list reserved rates (reserved1yrHourly, reserved3yrHourly). Resources that ran
+steadily enough for a commitment to pay off get a PURCHASE_COMMITMENT recommendation.
+
 Valid action types for filtering:
   RIGHTSIZE, TERMINATE, PURCHASE_COMMITMENT, ADJUST_REQUESTS, MODIFY,
   DELETE_UNUSED, MIGRATE, CONSOLIDATE, SCHEDULE, REFACTOR, OTHER`,
@@ -85,7 +92,10 @@ Valid action types for filtering:
   finfocus cost recommendations --pulumi-json plan.json --filter "action=RIGHTSIZE,TERMINATE"
 
   # Use a specific adapter plugin
-  finfocus cost recommendations --pulumi-json plan.json --adapter kubecost`,
+  finfocus cost recommendations --pulumi-json plan.json --adapter kubecost
+
+  # Recommend reservations from the last 30 days of usage
+  finfocus cost recommendations --pulumi-json plan.json --from 2026-01-01 --to 2026-01-31`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return executeCostRecommendations(cmd, params)
 		},
@@ -102,6 +112,10 @@ Valid action types for filtering:
 		"Filter expressions (e.g., 'action=MIGRATE,RIGHTSIZE')")
 	cmd.Flags().BoolVar(&params.verbose, "verbose", false,
 		"Show all recommendations with full details (default shows top 5 by savings)")
+	cmd.Flags().StringVar(&params.fromStr, "from", "",
+		"Start of the usage period analyzed for commitment purchases (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&params.toStr, "to", "",
+		"End of the usage period analyzed for commitment purchases (default: now)")
 
 	_ = cmd.MarkFlagRequired("pulumi-json")
 
@@ -124,6 +138,17 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 	log.Debug().Ctx(ctx).Str("operation", "cost_recommendations").Str("plan_path", params.planPath).
 		Msg("starting recommendations fetch")
 
+	// The usage period is only needed for commitment purchases; check it before any work
+	var from, to time.Time
+	if params.fromStr != "" {
+		var rangeErr error
+		if from, to, rangeErr = ParseTimeRange(params.fromStr, defaultToNow(params.toStr)); rangeErr != nil {
+			return rangeErr
+		}
+	} else if params.toStr != "" {
+		return errors.New("--to requires --from")
+	}
+
 	// Setup audit context for logging
 	auditParams := map[string]string{
 		"pulumi_json": params.planPath,
@@ -135,7 +160,8 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 	audit := newAuditContext(ctx, "cost recommendations", auditParams)
 
 	// Load and map resources from Pulumi plan
-	resources, err := loadAndMapResources(ctx, params.planPath, audit)
+	cfg := config.New()
+	resources, err := loadAndMapResources(ctx, params.planPath, audit, engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes))
 	if err != nil {
 		return err
 	}
@@ -147,14 +173,40 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 	}
 	defer cleanup()
 
+	suppressions, err := engine.ParseRecommendationSuppressions(cfg.Recommendations.Suppress)
+	if err != nil {
+		return fmt.Errorf("invalid recommendations.suppress configuration: %w", err)
+	}
+
 	// Fetch recommendations from engine
-	result, err := engine.New(clients, nil).GetRecommendationsForResources(ctx, resources)
+	eng, err := engine.New(clients, newSpecLoader(ctx, cfg, cfg.SpecDir), newEngineOptions(cfg))
+	if err != nil {
+		return err
+	}
+	eng.WithRecommendationSuppressions(suppressions)
+	result, err := eng.GetRecommendationsForResources(ctx, resources)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to fetch recommendations")
 		audit.logFailure(ctx, err)
 		return fmt.Errorf("fetching recommendations: %w", err)
 	}
 
+	// Possibly unused resources are found from the plan's dependency graph, without plugins
+	unused, err := eng.UnusedResourceRecommendations(ctx, resources)
+	if err != nil {
+		log.Warn().Ctx(ctx).Err(err).Msg("failed to check for unused resources")
+	}
+	result.Recommendations = append(result.Recommendations, unused...)
+
+	// Commitment purchases are found from the actual usage of the requested period
+	if !from.IsZero() {
+		commitments, commitErr := eng.CommitmentRecommendations(ctx, resources, from, to)
+		if commitErr != nil {
+			log.Warn().Ctx(ctx).Err(commitErr).Msg("failed to analyze usage for commitment purchases")
+		}
+		result.Recommendations = append(result.Recommendations, commitments...)
+	}
+
 	// Apply action type filters if specified
 	filteredRecommendations := result.Recommendations
 	for _, f := range params.filter {
@@ -173,6 +225,7 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 		Errors:          result.Errors,
 		TotalSavings:    calculateTotalSavings(filteredRecommendations),
 		Currency:        result.Currency,
+		Suppressed:      result.Suppressed,
 	}
 
 	// Render output
@@ -387,6 +440,9 @@ func sortActionTypes(actionTypes []string) {
 func renderRecommendationsTableWithVerbose(w io.Writer, result *engine.RecommendationsResult, verbose bool) error {
 	// Render summary section first
 	renderRecommendationsSummary(w, result.Recommendations)
+	if result.Suppressed > 0 {
+		fmt.Fprintf(w, "%d recommendation(s) suppressed by configuration.\n\n", result.Suppressed)
+	}
 
 	// Handle empty case
 	if len(result.Recommendations) == 0 {
@@ -472,6 +528,7 @@ func renderRecommendationsJSON(w io.Writer, result *engine.RecommendationsResult
 		Recommendations: make([]recommendationJSON, 0, len(result.Recommendations)),
 		TotalSavings:    result.TotalSavings,
 		Currency:        result.Currency,
+		Suppressed:      result.Suppressed,
 		Errors:          result.Errors,
 	}
 
@@ -509,6 +566,7 @@ func renderRecommendationsNDJSON(w io.Writer, result *engine.RecommendationsResu
 		Currency:          jsonSum.Currency,
 		CountByActionType: jsonSum.CountByActionType,
 		SavingsByAction:   jsonSum.SavingsByAction,
+		Suppressed:        result.Suppressed,
 	}
 	if err := encoder.Encode(summary); err != nil {
 		return fmt.Errorf("encoding NDJSON summary: %w", err)
@@ -542,6 +600,7 @@ type recommendationsJSONOutput struct {
 	Recommendations []recommendationJSON         `json:"recommendations"`
 	TotalSavings    float64                      `json:"total_savings"`
 	Currency        string                       `json:"currency"`
+	Suppressed      int                          `json:"suppressed,omitempty"`
 	Errors          []engine.RecommendationError `json:"errors,omitempty"`
 }
 
@@ -562,6 +621,7 @@ type ndjsonSummary struct {
 	Currency          string             `json:"currency"`
 	CountByActionType map[string]int     `json:"count_by_action_type"`
 	SavingsByAction   map[string]float64 `json:"savings_by_action_type"`
+	Suppressed        int                `json:"suppressed,omitempty"`
 }
 
 type recommendationJSON struct {
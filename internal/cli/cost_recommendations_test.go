@@ -275,6 +275,36 @@ func TestCostRecommendationsCmd_UnsupportedOutputFormat(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported output format")
 }
 
+// Test the usage period flags used for commitment purchase recommendations.
+func TestCostRecommendationsCmd_UsagePeriod(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(planPath, []byte(`{"version": 3, "steps": []}`), 0o600))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "to without from", args: []string{"--to", "2026-01-31"}, wantErr: "--to requires --from"},
+		{name: "invalid from", args: []string{"--from", "last month"}, wantErr: "parsing 'from' date"},
+		{name: "to before from", args: []string{"--from", "2026-01-31", "--to", "2026-01-01"},
+			wantErr: "'to' date must be after 'from' date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := cli.NewCostRecommendationsCmd()
+			var outBuf bytes.Buffer
+			cmd.SetOut(&outBuf)
+			cmd.SetErr(&outBuf)
+			cmd.SetArgs(append([]string{"--pulumi-json", planPath}, tt.args...))
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 // T022: Test action type filter parsing in CLI command with valid types.
 func TestCostRecommendationsCmd_ValidActionTypeFilter(t *testing.T) {
 	tests := []struct {
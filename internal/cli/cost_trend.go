@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+// costTrendParams holds the parameters for the cost trend command execution.
+type costTrendParams struct {
+	snapshotDir string
+	specDir     string
+	adapter     string
+	output      string
+	drivers     int
+	launch      pluginLaunchParams
+}
+
+// snapshotTimestamp matches a date, optionally followed by a time, in a snapshot file name,
+// such as 2024-05-01, 20240501 or 2024-05-01T09-30-00.
+var snapshotTimestamp = regexp.MustCompile(`\d{4}-?\d{2}-?\d{2}(?:[T_-]?\d{2}[-:]?\d{2}[-:]?\d{2})?`)
+
+// NewCostTrendCmd creates the "trend" subcommand that shows how projected costs evolved
+// across a directory of captured Pulumi previews.
+func NewCostTrendCmd() *cobra.Command {
+	var params costTrendParams
+
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show how projected costs evolved across preview snapshots",
+		Long: `Calculate projected costs for every Pulumi preview JSON file in a directory and show
+the total over time as a sparkline and a table, with the resources that drove each
+increase. Unlike actual-cost history, this tracks how planned costs change as the
+infrastructure code changes.
+
+Snapshots are ordered by the date or timestamp in their file name, such as
+preview-2024-05-01.json or 20240501T093000.json, falling back to the file
+modification time.`,
+		Example: `  # Capture a snapshot each day, then review the trend
+  pulumi preview --json > snapshots/preview-$(date +%F).json
+  finfocus cost trend --snapshots snapshots/
+
+  # Machine-readable trend with the top 5 drivers per snapshot
+  finfocus cost trend --snapshots snapshots/ --output json --drivers 5`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostTrend(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.snapshotDir, "snapshots", "",
+		"Directory of Pulumi preview JSON snapshots (required)")
+	cmd.Flags().StringVar(&params.specDir, "spec-dir", "", "Directory containing pricing spec files")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", "table", "Output format: table or json")
+	cmd.Flags().IntVar(&params.drivers, "drivers", engine.DefaultTrendDrivers,
+		"Number of resources shown as drivers of each increase")
+	addPluginLaunchFlags(cmd, &params.launch)
+	_ = cmd.MarkFlagRequired("snapshots")
+
+	return cmd
+}
+
+// trendSnapshotFile is a snapshot on disk with the time it was captured.
+type trendSnapshotFile struct {
+	path string
+	time time.Time
+}
+
+// executeCostTrend prices each snapshot with one engine and renders the trend.
+func executeCostTrend(cmd *cobra.Command, params costTrendParams) error {
+	ctx := cmd.Context()
+	format := engine.OutputFormat(params.output)
+	if format != engine.OutputTable && format != engine.OutputJSON {
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+	if params.drivers < 0 {
+		return fmt.Errorf("--drivers must not be negative, got %d", params.drivers)
+	}
+
+	log := logging.FromContext(ctx)
+	audit := newAuditContext(ctx, "cost trend", map[string]string{"snapshots": params.snapshotDir})
+
+	files, err := listTrendSnapshots(params.snapshotDir)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	cfg := config.New()
+	zeroCost := engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes)
+	resourcesBySnapshot := make([][]engine.ResourceDescriptor, len(files))
+	var allResources []engine.ResourceDescriptor
+	for i, f := range files {
+		resources, loadErr := loadAndMapResources(ctx, f.path, audit, zeroCost)
+		if loadErr != nil {
+			return fmt.Errorf("snapshot %s: %w", filepath.Base(f.path), loadErr)
+		}
+		resourcesBySnapshot[i] = resources
+		allResources = append(allResources, resources...)
+	}
+
+	params.launch.resolveOffline(cfg)
+	transforms, err := newTransformChain(cfg)
+	if err != nil {
+		return err
+	}
+	customTypes, err := newCustomTypeRules(cfg)
+	if err != nil {
+		return err
+	}
+	costRules, err := newCostRules(cfg, "")
+	if err != nil {
+		return err
+	}
+	specDir := params.specDir
+	if specDir == "" {
+		specDir = cfg.SpecDir
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit, params.launch.openOptions(allResources))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	eng, err := engine.New(clients, newSpecLoader(ctx, cfg, specDir), params.launch.engineOptions(cfg))
+	if err != nil {
+		return err
+	}
+	eng.WithPricingCache(newPricingCache(cfg)).
+		WithPluginLayers(newPluginLayers(cfg)).
+		WithResultTransforms(transforms).
+		WithCustomTypes(customTypes).
+		WithCostRules(costRules)
+
+	snapshots := make([]engine.TrendSnapshot, 0, len(files))
+	var errorSummaries []string
+	for i, f := range files {
+		resultWithErrors, calcErr := eng.GetProjectedCostWithErrors(ctx, resourcesBySnapshot[i])
+		if calcErr != nil {
+			audit.logFailure(ctx, calcErr)
+			return fmt.Errorf("calculating projected costs for %s: %w", filepath.Base(f.path), calcErr)
+		}
+		if resultWithErrors.HasErrors() {
+			errorSummaries = append(errorSummaries,
+				filepath.Base(f.path)+":\n"+resultWithErrors.ErrorSummary())
+		}
+		snapshots = append(snapshots, engine.TrendSnapshot{
+			Name:    filepath.Base(f.path),
+			Time:    f.time,
+			Results: resultWithErrors.Results,
+		})
+	}
+
+	trend := engine.BuildCostTrend(snapshots, params.drivers)
+	if renderErr := engine.RenderCostTrend(cmd.OutOrStdout(), format, trend); renderErr != nil {
+		return renderErr
+	}
+	// Errors go to stderr so JSON output stays valid.
+	for _, summary := range errorSummaries {
+		fmt.Fprint(cmd.ErrOrStderr(), summary)
+	}
+
+	log.Info().Ctx(ctx).Str("operation", "cost_trend").Int("snapshot_count", len(snapshots)).
+		Msg("cost trend calculated")
+
+	latest := 0.0
+	if len(trend.Points) > 0 {
+		latest = trend.Points[len(trend.Points)-1].Total
+	}
+	audit.logSuccess(ctx, len(snapshots), latest)
+	return nil
+}
+
+// listTrendSnapshots returns the JSON files of dir ordered by capture time, then by name.
+func listTrendSnapshots(dir string) ([]trendSnapshotFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot directory: %w", err)
+	}
+
+	var files []trendSnapshotFile
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".json") {
+			continue
+		}
+		captured, ok := snapshotTimeFromName(e.Name())
+		if !ok {
+			info, infoErr := e.Info()
+			if infoErr != nil {
+				return nil, fmt.Errorf("reading snapshot %s: %w", e.Name(), infoErr)
+			}
+			captured = info.ModTime()
+		}
+		files = append(files, trendSnapshotFile{path: filepath.Join(dir, e.Name()), time: captured})
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no preview JSON snapshots found in %s", dir)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if !files[i].time.Equal(files[j].time) {
+			return files[i].time.Before(files[j].time)
+		}
+		return files[i].path < files[j].path
+	})
+	return files, nil
+}
+
+// snapshotTimeFromName parses the first date or timestamp in a file name, in UTC.
+func snapshotTimeFromName(name string) (time.Time, bool) {
+	match := snapshotTimestamp.FindString(name)
+	if match == "" {
+		return time.Time{}, false
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, match)
+	layout := "20060102"
+	if len(digits) > len(layout) {
+		layout = "20060102150405"
+	}
+	t, err := time.Parse(layout, digits)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
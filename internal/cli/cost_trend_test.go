@@ -0,0 +1,65 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostTrendCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	snapshotDir := filepath.Join(dir, "snapshots")
+	require.NoError(t, os.MkdirAll(snapshotDir, 0o755))
+	step := func(name string) string {
+		return `{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::` + name + `",
+			"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}`
+	}
+	// File names sort differently from their dates, which decide the order.
+	snapshots := map[string][]string{
+		"b-2024-05-01.json": {step("web")},
+		"a-2024-05-03.json": {step("web"), step("api"), step("worker")},
+		"c-2024-05-02.json": {step("web"), step("api")},
+	}
+	for name, steps := range snapshots {
+		plan := `{"steps": [` + strings.Join(steps, ",") + `]}`
+		require.NoError(t, os.WriteFile(filepath.Join(snapshotDir, name), []byte(plan), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotDir, "notes.txt"), []byte("ignored"), 0o600))
+
+	var buf bytes.Buffer
+	cmd := cli.NewCostTrendCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--snapshots", snapshotDir, "--spec-dir", specDir, "--offline"})
+	require.NoError(t, cmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "Projected monthly cost (USD): ▁▄█")
+	first := strings.Index(out, "b-2024-05-01.json")
+	second := strings.Index(out, "c-2024-05-02.json")
+	third := strings.Index(out, "a-2024-05-03.json")
+	require.True(t, first >= 0 && second > first && third > second, out)
+	assert.Regexp(t, `c-2024-05-02.json\s+2024-05-02 00:00:00\s+2\s+14.60\s+\+7.30, \+100.0%\s+api \+7.30`, out)
+
+	buf.Reset()
+	cmd = cli.NewCostTrendCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--snapshots", t.TempDir(), "--offline"})
+	require.ErrorContains(t, cmd.Execute(), "no preview JSON snapshots")
+}
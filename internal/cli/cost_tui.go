@@ -1,14 +1,17 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/tui"
+	"github.com/rshade/finfocus/pkg/version"
 	"github.com/spf13/cobra"
 )
 
@@ -16,30 +19,64 @@ import (
 // based on the detected output mode (Plain, Styled, or Interactive).
 // The context parameter is reserved for future use (e.g., cancellation, tracing)
 // but is currently unused to maintain API compatibility.
+// Optional render options are applied to the plain table; requesting annotation
+// columns or normalization forces plain output since the styled and interactive views
+// have no room for them.
 func RenderCostOutput(
 	_ context.Context,
 	cmd *cobra.Command,
 	outputFormat string,
 	resultWithErrors *engine.CostResultWithErrors,
+	opts ...engine.RenderOptions,
 ) error {
+	var renderOpts engine.RenderOptions
+	if len(opts) > 0 {
+		renderOpts = opts[0]
+	}
+
 	// 1. Determine and validate output format.
 	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
 
+	// GitHub Actions annotations are only offered for projected costs and include
+	// plugin errors, so they bypass both the generic validation and RenderResults.
+	if fmtType == engine.OutputGitHubActions {
+		annotations := engine.BuildGitHubAnnotations(resultWithErrors.Results, resultWithErrors.Errors, renderOpts)
+		return engine.WriteGitHubAnnotations(cmd.OutOrStdout(), annotations)
+	}
+
+	// CSV, FOCUS and Markdown exports write one row per result and need no terminal detection.
+	if fmtType == engine.OutputCSV || fmtType == engine.OutputFOCUS || fmtType == engine.OutputMarkdown {
+		return engine.RenderResultsWithOptions(cmd.OutOrStdout(), fmtType, resultWithErrors.Results, renderOpts)
+	}
+
 	// Validate format is supported before proceeding
 	if !isValidOutputFormat(fmtType) {
 		return fmt.Errorf("unsupported output format: %s", fmtType)
 	}
 
+	if renderOpts.Normalize {
+		sorted := *resultWithErrors
+		sorted.Results = engine.SortByEfficiency(resultWithErrors.Results)
+		resultWithErrors = &sorted
+	}
+
 	// 2. If output format is explicitly structured (JSON/NDJSON), bypass TUI completely.
 	// This satisfies FR-004: Maintain output for --output json/ndjson.
+	if fmtType == engine.OutputJSON && renderOpts.Envelope != nil {
+		envelope := engine.NewOutputEnvelope(resultWithErrors.Results, resultWithErrors.Errors, *renderOpts.Envelope)
+		return renderEnvelope(cmd.OutOrStdout(), envelope, renderOpts.ValidateEnvelope)
+	}
 	if fmtType == engine.OutputJSON || fmtType == engine.OutputNDJSON {
-		return engine.RenderResults(cmd.OutOrStdout(), fmtType, resultWithErrors.Results)
+		return engine.RenderResultsWithOptions(cmd.OutOrStdout(), fmtType, resultWithErrors.Results, renderOpts)
 	}
 
 	// 2. Detect the appropriate output mode for the terminal.
 	// We rely on standard detection (flags passed as false for now, as they aren't global yet).
 	// Future improvement: plumb --no-color / --plain flags if added to CLI.
 	mode := tui.DetectOutputMode(false, false, false)
+	if len(renderOpts.Annotations) > 0 || renderOpts.Normalize {
+		mode = tui.OutputModePlain
+	}
 
 	// 3. Route to specific renderer
 	switch mode {
@@ -47,19 +84,20 @@ func RenderCostOutput(
 		return runInteractiveTUI(resultWithErrors)
 
 	case tui.OutputModeStyled:
-		return renderStyledOutput(cmd.OutOrStdout(), resultWithErrors)
+		return renderStyledOutput(cmd.OutOrStdout(), resultWithErrors, renderOpts.Omitted)
 
 	case tui.OutputModePlain:
-		return renderPlainOutput(cmd.OutOrStdout(), resultWithErrors)
+		return renderPlainOutput(cmd.OutOrStdout(), resultWithErrors, renderOpts)
 
 	default:
-		return renderPlainOutput(cmd.OutOrStdout(), resultWithErrors)
+		return renderPlainOutput(cmd.OutOrStdout(), resultWithErrors, renderOpts)
 	}
 }
 
 // RenderActualCostOutput routes actual cost results to the appropriate rendering function.
 // The context parameter is reserved for future use (e.g., cancellation, tracing)
 // but is currently unused to maintain API compatibility.
+// When render options request an envelope, JSON output is wrapped in an OutputEnvelope.
 func RenderActualCostOutput(
 	_ context.Context,
 	cmd *cobra.Command,
@@ -67,14 +105,27 @@ func RenderActualCostOutput(
 	resultWithErrors *engine.CostResultWithErrors,
 	groupBy string,
 	estimateConfidence bool,
+	opts ...engine.RenderOptions,
 ) error {
 	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
 
+	if fmtType == engine.OutputFOCUS {
+		return engine.RenderFOCUS(cmd.OutOrStdout(), resultWithErrors.Results, time.Now())
+	}
+	if fmtType == engine.OutputMarkdown {
+		return engine.RenderMarkdown(cmd.OutOrStdout(), resultWithErrors.Results)
+	}
+
 	// Validate format is supported before proceeding
 	if !isValidOutputFormat(fmtType) {
 		return fmt.Errorf("unsupported output format: %s", fmtType)
 	}
 
+	if fmtType == engine.OutputJSON && len(opts) > 0 && opts[0].Envelope != nil {
+		envelope := engine.NewOutputEnvelope(resultWithErrors.Results, resultWithErrors.Errors, *opts[0].Envelope)
+		return renderEnvelope(cmd.OutOrStdout(), envelope, opts[0].ValidateEnvelope)
+	}
+
 	if fmtType == engine.OutputJSON || fmtType == engine.OutputNDJSON {
 		// Use existing logic for JSON/NDJSON (handling aggregation inside)
 		return renderActualCostOutput(cmd.OutOrStdout(), fmtType, resultWithErrors.Results, groupBy, estimateConfidence)
@@ -113,8 +164,12 @@ func runInteractiveActualCostTUI(resultWithErrors *engine.CostResultWithErrors,
 }
 
 // renderPlainOutput renders the standard table output (legacy behavior).
-func renderPlainOutput(w io.Writer, resultWithErrors *engine.CostResultWithErrors) error {
-	if err := engine.RenderResults(w, engine.OutputTable, resultWithErrors.Results); err != nil {
+func renderPlainOutput(
+	w io.Writer,
+	resultWithErrors *engine.CostResultWithErrors,
+	opts engine.RenderOptions,
+) error {
+	if err := engine.RenderResultsWithOptions(w, engine.OutputTable, resultWithErrors.Results, opts); err != nil {
 		return err
 	}
 
@@ -127,10 +182,18 @@ func renderPlainOutput(w io.Writer, resultWithErrors *engine.CostResultWithError
 	return nil
 }
 
-// renderStyledOutput renders the styled summary using Lip Gloss (T011).
-func renderStyledOutput(w io.Writer, resultWithErrors *engine.CostResultWithErrors) error {
+// renderStyledOutput renders the styled summary using Lip Gloss (T011), followed by a
+// line for the results left out by --top, if any.
+func renderStyledOutput(
+	w io.Writer,
+	resultWithErrors *engine.CostResultWithErrors,
+	omitted *engine.OmittedResults,
+) error {
 	summary := tui.RenderCostSummary(resultWithErrors.Results, tui.TerminalWidth())
 	fmt.Fprint(w, summary)
+	if omitted != nil {
+		fmt.Fprintln(w, omitted)
+	}
 
 	// Display error summary using plain text format.
 	// Error styling is intentionally kept simple for readability across terminals.
@@ -151,3 +214,40 @@ func isValidOutputFormat(format engine.OutputFormat) bool {
 		return false
 	}
 }
+
+// renderEnvelope writes the envelope, first checking it against the envelope schema when
+// validate is set so that nothing is written when it does not match.
+func renderEnvelope(w io.Writer, envelope *engine.OutputEnvelope, validate bool) error {
+	if !validate {
+		return engine.RenderEnvelope(w, envelope)
+	}
+	var buf bytes.Buffer
+	if err := engine.RenderEnvelope(&buf, envelope); err != nil {
+		return err
+	}
+	if err := engine.ValidateEnvelope(buf.Bytes()); err != nil {
+		return fmt.Errorf("validating output: %w", err)
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// newEnvelopeMeta returns envelope metadata for the given command when enabled, or nil.
+// It rejects the flag for non-JSON formats so that users are not silently ignored.
+func newEnvelopeMeta(
+	enabled bool,
+	outputFormat, command string,
+	resources []engine.ResourceDescriptor,
+) (*engine.EnvelopeMeta, error) {
+	if !enabled {
+		return nil, nil
+	}
+	if engine.OutputFormat(config.GetOutputFormat(outputFormat)) != engine.OutputJSON {
+		return nil, fmt.Errorf("--json-envelope requires --output json, got %s", outputFormat)
+	}
+	return &engine.EnvelopeMeta{
+		Command:     command,
+		Stack:       engine.StackFromResources(resources),
+		ToolVersion: version.GetVersion(),
+	}, nil
+}
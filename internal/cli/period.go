@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// monthsPerQuarter is the length of a fiscal quarter.
+const monthsPerQuarter = 3
+
+// Business-calendar periods accepted by --period.
+const (
+	PeriodThisMonth        = "this-month"
+	PeriodLastMonth        = "last-month"
+	PeriodThisQuarter      = "this-quarter"
+	PeriodLastQuarter      = "last-quarter"
+	PeriodFiscalYTD        = "fiscal-ytd"
+	PeriodLastFiscalYear   = "last-fiscal-year"
+	PeriodThisBillingCycle = "this-billing-cycle"
+	PeriodLastBillingCycle = "last-billing-cycle"
+)
+
+// periodNames lists the --period terms in the order shown in help and errors.
+var periodNames = []string{
+	PeriodThisMonth, PeriodLastMonth, PeriodThisQuarter, PeriodLastQuarter,
+	PeriodFiscalYTD, PeriodLastFiscalYear, PeriodThisBillingCycle, PeriodLastBillingCycle,
+}
+
+// BusinessCalendar describes when fiscal years and billing cycles start.
+type BusinessCalendar struct {
+	// FiscalYearStartMonth is the first month of the fiscal year; quarters are counted
+	// from it. Zero means January.
+	FiscalYearStartMonth time.Month
+	// BillingCycleStartDay is the day of the month a billing cycle starts on (1-28).
+	// Zero means the first.
+	BillingCycleStartDay int
+}
+
+// newBusinessCalendar returns the calendar from the calendar configuration.
+func newBusinessCalendar(cfg *config.Config) BusinessCalendar {
+	return BusinessCalendar{
+		FiscalYearStartMonth: time.Month(cfg.Calendar.FiscalYearStartMonth),
+		BillingCycleStartDay: cfg.Calendar.BillingCycleStartDay,
+	}
+}
+
+// ResolvePeriod turns a business-calendar term such as last-quarter or fiscal-ytd into a
+// concrete range in UTC. Completed periods end at the start of the next one; periods in
+// progress end at now. Quarters follow the fiscal year, so with a fiscal year starting in
+// April, last-quarter on 2025-05-10 is 2025-01-01 to 2025-04-01.
+func ResolvePeriod(period string, now time.Time, cal BusinessCalendar) (time.Time, time.Time, error) {
+	now = now.UTC()
+	fiscalStart := cal.FiscalYearStartMonth
+	if fiscalStart < time.January || fiscalStart > time.December {
+		fiscalStart = time.January
+	}
+	cycleDay := cal.BillingCycleStartDay
+	if cycleDay < 1 || cycleDay > config.MaxBillingCycleStartDay {
+		cycleDay = 1
+	}
+
+	year, month, day := now.Date()
+	monthStart := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	monthsIntoYear := (int(month) - int(fiscalStart) + int(time.December)) % int(time.December)
+	yearStart := monthStart.AddDate(0, -monthsIntoYear, 0)
+	quarterStart := yearStart.AddDate(0, monthsIntoYear/monthsPerQuarter*monthsPerQuarter, 0)
+	cycleStart := time.Date(year, month, cycleDay, 0, 0, 0, 0, time.UTC)
+	if day < cycleDay {
+		cycleStart = cycleStart.AddDate(0, -1, 0)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case PeriodThisMonth:
+		return monthStart, now, nil
+	case PeriodLastMonth:
+		return monthStart.AddDate(0, -1, 0), monthStart, nil
+	case PeriodThisQuarter:
+		return quarterStart, now, nil
+	case PeriodLastQuarter:
+		return quarterStart.AddDate(0, -monthsPerQuarter, 0), quarterStart, nil
+	case PeriodFiscalYTD:
+		return yearStart, now, nil
+	case PeriodLastFiscalYear:
+		return yearStart.AddDate(-1, 0, 0), yearStart, nil
+	case PeriodThisBillingCycle:
+		return cycleStart, now, nil
+	case PeriodLastBillingCycle:
+		return cycleStart.AddDate(0, -1, 0), cycleStart, nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown period %q (use one of: %s)",
+			period, strings.Join(periodNames, ", "))
+	}
+}
+
+// resolvePeriodRange resolves --period against the configured calendar and checks the
+// range like explicit dates.
+func resolvePeriodRange(period string, cfg *config.Config) (time.Time, time.Time, error) {
+	from, to, err := ResolvePeriod(period, time.Now(), newBusinessCalendar(cfg))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("period %s has no elapsed time yet", period)
+	}
+	if rangeErr := ValidateDateRange(from, to); rangeErr != nil {
+		return time.Time{}, time.Time{}, rangeErr
+	}
+	return from, to, nil
+}
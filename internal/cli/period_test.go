@@ -0,0 +1,83 @@
+package cli_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePeriod(t *testing.T) {
+	now := time.Date(2025, time.May, 10, 15, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	calendarYear := cli.BusinessCalendar{}
+	aprilFiscal := cli.BusinessCalendar{FiscalYearStartMonth: time.April, BillingCycleStartDay: 15}
+	octoberFiscal := cli.BusinessCalendar{FiscalYearStartMonth: time.October}
+
+	tests := []struct {
+		period   string
+		calendar cli.BusinessCalendar
+		from, to time.Time
+	}{
+		{cli.PeriodThisMonth, calendarYear, date(2025, time.May, 1), now},
+		{cli.PeriodLastMonth, calendarYear, date(2025, time.April, 1), date(2025, time.May, 1)},
+		{cli.PeriodThisQuarter, calendarYear, date(2025, time.April, 1), now},
+		{cli.PeriodLastQuarter, calendarYear, date(2025, time.January, 1), date(2025, time.April, 1)},
+		{cli.PeriodFiscalYTD, calendarYear, date(2025, time.January, 1), now},
+		{cli.PeriodLastFiscalYear, calendarYear, date(2024, time.January, 1), date(2025, time.January, 1)},
+		{cli.PeriodLastQuarter, aprilFiscal, date(2025, time.January, 1), date(2025, time.April, 1)},
+		{cli.PeriodFiscalYTD, aprilFiscal, date(2025, time.April, 1), now},
+		{cli.PeriodLastFiscalYear, aprilFiscal, date(2024, time.April, 1), date(2025, time.April, 1)},
+		{cli.PeriodThisQuarter, octoberFiscal, date(2025, time.April, 1), now},
+		{cli.PeriodFiscalYTD, octoberFiscal, date(2024, time.October, 1), now},
+		{cli.PeriodLastFiscalYear, octoberFiscal, date(2023, time.October, 1), date(2024, time.October, 1)},
+		{cli.PeriodLastBillingCycle, calendarYear, date(2025, time.April, 1), date(2025, time.May, 1)},
+		{cli.PeriodThisBillingCycle, aprilFiscal, date(2025, time.April, 15), now},
+		{cli.PeriodLastBillingCycle, aprilFiscal, date(2025, time.March, 15), date(2025, time.April, 15)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			from, to, err := cli.ResolvePeriod(tt.period, now, tt.calendar)
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+
+	_, _, err := cli.ResolvePeriod("last-fortnight", now, calendarYear)
+	require.ErrorContains(t, err, "unknown period")
+}
+
+func TestCostActualCmd_Period(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	statePath := filepath.Join("..", "..", "test", "fixtures", "state", "valid-state.json")
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewCostActualCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"--pulumi-state", statePath}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("--period", "last-month")
+	require.NoError(t, err)
+	monthStart := time.Date(time.Now().UTC().Year(), time.Now().UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	assert.Contains(t, out, "Period last-month: "+monthStart.AddDate(0, -1, 0).Format(time.RFC3339)+
+		" to "+monthStart.Format(time.RFC3339))
+
+	_, err = run("--period", "last-month", "--from", "2025-01-01")
+	require.ErrorContains(t, err, "mutually exclusive")
+
+	_, err = run("--period", "someday")
+	require.ErrorContains(t, err, "unknown period")
+}
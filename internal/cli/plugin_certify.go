@@ -160,7 +160,8 @@ func runPluginCertifyCmd(
 	}
 
 	cmd.Println("❌ NOT CERTIFIED - Plugin failed conformance tests")
-	cmd.Printf("   Failed: %d, Errors: %d\n", report.Summary.Failed, report.Summary.Errors)
+	cmd.Printf("   Failed: %d, Errors: %d, Timed out: %d\n", report.Summary.Failed, report.Summary.Errors,
+		report.Summary.TimedOut)
 
 	return &exitError{
 		code:    certificationExitFailure,
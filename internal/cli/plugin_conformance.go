@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rshade/finfocus/internal/conformance"
@@ -17,6 +18,11 @@ const (
 	outputFormatTable = "table"
 	outputFormatJSON  = "json"
 	outputFormatJUnit = "junit"
+
+	// Badge-friendly conformance output formats.
+	outputFormatBadge   = "badge"
+	outputFormatSVG     = "svg"
+	outputFormatSummary = "summary"
 )
 
 // Exit codes for conformance test results.
@@ -28,8 +34,12 @@ const (
 // NewPluginConformanceCmd creates the plugin conformance command for running
 // NewPluginConformanceCmd returns a Cobra command configured to run conformance tests against a plugin binary.
 // The command verifies a plugin's protocol compliance and supports the following flags:
-// --mode (tcp|stdio), --verbosity (quiet|normal|verbose|debug), --output (table|json|junit), --output-file,
-// --timeout, --category (repeatable: protocol, error, performance, context), and --filter (regex for test names).
+// --mode (tcp|stdio), --verbosity (quiet|normal|verbose|debug), --output (table|json|junit|badge|svg|summary),
+// --output-file,
+// --timeout, --category (repeatable: protocol, error, performance, context, recommendations),
+// --filter (regex for test names),
+// --fail-on (error|warning|info), --severity (repeatable Test=severity overrides), --parallel,
+// and --bench-iterations.
 func NewPluginConformanceCmd() *cobra.Command {
 	var (
 		mode       string
@@ -39,6 +49,10 @@ func NewPluginConformanceCmd() *cobra.Command {
 		timeout    string
 		categories []string
 		filter     string
+		failOn     string
+		severities []string
+		parallel   int
+		benchIters int
 	)
 
 	cmd := &cobra.Command{
@@ -61,12 +75,31 @@ and context cancellation.`,
   # JUnit XML for CI
   finfocus plugin conformance --output junit --output-file report.xml ./plugins/aws-cost
 
+  # shields.io endpoint badge for a README
+  finfocus plugin conformance --output badge --output-file conformance.json ./plugins/aws-cost
+
+  # One-line summary for a GitHub status check
+  finfocus plugin conformance --output summary ./plugins/aws-cost
+
   # Use stdio mode
-  finfocus plugin conformance --mode stdio ./plugins/aws-cost`,
+  finfocus plugin conformance --mode stdio ./plugins/aws-cost
+
+  # Strict mode for release CI: warnings also fail
+  finfocus plugin conformance --fail-on warning ./plugins/aws-cost
+
+  # Downgrade a test to advisory
+  finfocus plugin conformance --severity Batch_Handling=info ./plugins/aws-cost
+
+  # Run up to four tests at once
+  finfocus plugin conformance --parallel 4 ./plugins/aws-cost
+
+  # Measure GetProjectedCost latency over 1000 calls and keep the raw timings
+  finfocus plugin conformance --category performance --bench-iterations 1000 --output json ./plugins/aws-cost`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPluginConformanceCmd(
-				cmd, args[0], mode, verbosity, output, outputFile, timeout, categories, filter,
+				cmd, args[0], mode, verbosity, output, outputFile, timeout, categories, filter, failOn, severities,
+				parallel, benchIters,
 			)
 		},
 	}
@@ -74,13 +107,23 @@ and context cancellation.`,
 	cmd.Flags().StringVar(&mode, "mode", "tcp", "Communication mode: tcp, stdio")
 	cmd.Flags().
 		StringVar(&verbosity, "verbosity", "normal", "Output detail: quiet, normal, verbose, debug")
-	cmd.Flags().StringVar(&output, "output", "table", "Output format: table, json, junit")
+	cmd.Flags().StringVar(&output, "output", "table", "Output format: table, json, junit, badge, svg, summary")
 	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write output to file (default: stdout)")
 	cmd.Flags().StringVar(&timeout, "timeout", "5m", "Global suite timeout")
 	cmd.Flags().StringSliceVar(
-		&categories, "category", nil, "Filter by category (repeatable): protocol, error, performance, context",
+		&categories, "category", nil,
+		"Filter by category (repeatable): protocol, error, performance, context, recommendations",
 	)
 	cmd.Flags().StringVar(&filter, "filter", "", "Regex filter for test names")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(conformance.SeverityError),
+		"Lowest failure severity that causes a non-zero exit: error, warning, info")
+	cmd.Flags().StringSliceVar(
+		&severities, "severity", nil, "Override a test's severity (repeatable): <TestName>=error|warning|info",
+	)
+	cmd.Flags().IntVar(&parallel, "parallel", 1,
+		"Maximum number of tests run at once against the plugin; timing tests always run alone")
+	cmd.Flags().IntVar(&benchIters, "bench-iterations", conformance.DefaultBenchIterations,
+		"Number of calls the latency benchmark makes to measure p50/p95/p99")
 
 	return cmd
 }
@@ -89,7 +132,9 @@ func runPluginConformanceCmd(
 	cmd *cobra.Command,
 	pluginPath, mode, verbosity, output, outputFile, timeout string,
 	categories []string,
-	filter string,
+	filter, failOn string,
+	severities []string,
+	parallel, benchIters int,
 ) error {
 	ctx := cmd.Context()
 
@@ -99,9 +144,28 @@ func runPluginConformanceCmd(
 		return err
 	}
 
+	if !conformance.IsValidSeverity(failOn) {
+		return fmt.Errorf("invalid --fail-on %q: must be error, warning, or info", failOn)
+	}
+	cfg.SeverityOverrides, err = parseSeverityOverrides(severities)
+	if err != nil {
+		return err
+	}
+	if parallel < 1 {
+		return fmt.Errorf("invalid --parallel %d: must be at least 1", parallel)
+	}
+	cfg.Parallelism = parallel
+	if benchIters < 1 {
+		return fmt.Errorf("invalid --bench-iterations %d: must be at least 1", benchIters)
+	}
+	cfg.BenchIterations = benchIters
+
 	// Validate output format
-	if output != outputFormatTable && output != outputFormatJSON && output != outputFormatJUnit {
-		return fmt.Errorf("invalid output format %q: must be table, json, or junit", output)
+	switch output {
+	case outputFormatTable, outputFormatJSON, outputFormatJUnit,
+		outputFormatBadge, outputFormatSVG, outputFormatSummary:
+	default:
+		return fmt.Errorf("invalid output format %q: must be table, json, junit, badge, svg, or summary", output)
 	}
 
 	// Create and run suite
@@ -121,7 +185,7 @@ func runPluginConformanceCmd(
 	}
 
 	// Return exit code based on results
-	return checkResults(report)
+	return checkResults(report, conformance.Severity(failOn))
 }
 
 // buildSuiteConfig validates inputs and creates a SuiteConfig.
@@ -176,8 +240,8 @@ func buildSuiteConfig(
 
 // parseCategories validates the provided category strings and converts them to
 // conformance.Category values. It returns a slice of converted categories or an
-// error if any input is not one of: "protocol", "error", "performance", or
-// "context".
+// error if any input is not one of: "protocol", "error", "performance", "context",
+// or "recommendations".
 //
 // The returned slice preserves the order of the input slice.
 func parseCategories(categories []string) ([]conformance.Category, error) {
@@ -185,7 +249,7 @@ func parseCategories(categories []string) ([]conformance.Category, error) {
 	for _, cat := range categories {
 		if !conformance.IsValidCategory(cat) {
 			return nil, fmt.Errorf(
-				"invalid category %q: must be protocol, error, performance, or context",
+				"invalid category %q: must be protocol, error, performance, context, or recommendations",
 				cat,
 			)
 		}
@@ -194,15 +258,32 @@ func parseCategories(categories []string) ([]conformance.Category, error) {
 	return categoryList, nil
 }
 
+// parseSeverityOverrides converts "TestName=severity" pairs into a severity override map.
+func parseSeverityOverrides(pairs []string) (map[string]conformance.Severity, error) {
+	overrides := make(map[string]conformance.Severity, len(pairs))
+	for _, pair := range pairs {
+		name, severity, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --severity %q: expected <TestName>=<severity>", pair)
+		}
+		if !conformance.IsValidSeverity(severity) {
+			return nil, fmt.Errorf("invalid severity %q for %s: must be error, warning, or info", severity, name)
+		}
+		overrides[name] = conformance.Severity(severity)
+	}
+	return overrides, nil
+}
+
 // writeReport writes the conformance SuiteReport to the specified destination using the requested format.
 // It selects an output writer based on outputFile (writes to stdout when empty) and writes the report
-// in the given output format (`"table"`, `"json"`, or `"junit"`). The cmd parameter is used to obtain
-// the command's stdout when no output file is provided.
+// in the given output format (`"table"`, `"json"`, `"junit"`, `"badge"`, `"svg"`, or `"summary"`).
+// The cmd parameter is used to obtain the command's stdout when no output file is provided.
 //
 // Parameters:
 //   - cmd: the Cobra command used to determine standard output when outputFile is empty.
 //   - report: the conformance.SuiteReport to be written.
-//   - output: the desired output format; recognized values are "table", "json", and "junit".
+//   - output: the desired output format; recognized values are "table", "json", "junit", "badge",
+//     "svg", and "summary".
 //   - outputFile: optional path to a file to write the output; if empty, stdout is used.
 //
 // Returns an error if acquiring the output writer fails or if writing the report in the chosen format fails.
@@ -228,6 +309,18 @@ func writeReport(
 		if writeErr := report.WriteJUnit(writer); writeErr != nil {
 			return fmt.Errorf("writing JUnit output: %w", writeErr)
 		}
+	case outputFormatBadge:
+		if writeErr := report.WriteBadgeJSON(writer); writeErr != nil {
+			return fmt.Errorf("writing badge output: %w", writeErr)
+		}
+	case outputFormatSVG:
+		if writeErr := report.WriteBadgeSVG(writer); writeErr != nil {
+			return fmt.Errorf("writing SVG badge: %w", writeErr)
+		}
+	case outputFormatSummary:
+		if writeErr := report.WriteSummary(writer); writeErr != nil {
+			return fmt.Errorf("writing summary: %w", writeErr)
+		}
 	default:
 		if writeErr := report.WriteTable(writer); writeErr != nil {
 			return fmt.Errorf("writing table output: %w", writeErr)
@@ -263,12 +356,16 @@ func getOutputWriter(cmd *cobra.Command, outputFile string) (io.Writer, func(),
 	}, nil
 }
 
-// checkResults returns an error if there are failures or errors for exit code handling.
-func checkResults(report *conformance.SuiteReport) error {
-	if report.Summary.Failed > 0 {
-		return &exitError{code: exitCodeFailures, message: "conformance tests failed"}
+// checkResults returns an error for exit code handling when any failed or errored test has a
+// severity at or above failOn. Failures below the threshold are reported but do not fail the run.
+func checkResults(report *conformance.SuiteReport, failOn conformance.Severity) error {
+	failing := report.FailingResults(failOn)
+	for _, res := range failing {
+		if res.Status == conformance.StatusFail {
+			return &exitError{code: exitCodeFailures, message: "conformance tests failed"}
+		}
 	}
-	if report.Summary.Errors > 0 {
+	if len(failing) > 0 {
 		return &exitError{code: exitCodeErrors, message: "conformance tests encountered errors"}
 	}
 	return nil
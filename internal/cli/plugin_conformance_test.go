@@ -36,6 +36,8 @@ func TestPluginConformanceCmd_Flags(t *testing.T) {
 		"timeout",
 		"category",
 		"filter",
+		"fail-on",
+		"severity",
 	}
 
 	for _, flag := range expectedFlags {
@@ -54,6 +56,8 @@ func TestPluginConformanceCmd_FlagDefaults(t *testing.T) {
 	assert.Equal(t, "", cmd.Flags().Lookup("output-file").DefValue)
 	assert.Equal(t, "5m", cmd.Flags().Lookup("timeout").DefValue)
 	assert.Equal(t, "", cmd.Flags().Lookup("filter").DefValue)
+	assert.Equal(t, "error", cmd.Flags().Lookup("fail-on").DefValue)
+	assert.Equal(t, "100", cmd.Flags().Lookup("bench-iterations").DefValue)
 }
 
 func TestPluginConformanceCmd_RequiresArg(t *testing.T) {
@@ -188,6 +192,38 @@ func TestPluginConformanceCmd_InvalidCategory(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid category")
 }
 
+func TestPluginConformanceCmd_InvalidSeverityFlags(t *testing.T) {
+	// Note: Cannot use t.Parallel() - tests that execute rootCmd modify global logger state
+	tmpDir := t.TempDir()
+	pluginPath := filepath.Join(tmpDir, "test-plugin")
+	require.NoError(t, os.WriteFile(pluginPath, []byte("#!/bin/bash\necho test"), 0755))
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{"invalid fail-on", []string{"--fail-on", "fatal"}, "invalid --fail-on"},
+		{"missing separator", []string{"--severity", "Batch_Handling"}, "expected <TestName>=<severity>"},
+		{"invalid severity", []string{"--severity", "Batch_Handling=low"}, "invalid severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := cli.NewRootCmd("test")
+			var outBuf, errBuf bytes.Buffer
+			rootCmd.SetOut(&outBuf)
+			rootCmd.SetErr(&errBuf)
+			rootCmd.SetArgs(append(append([]string{"plugin", "conformance"}, tt.args...), pluginPath))
+
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
 func TestPluginConformanceCmd_PluginNotFound(t *testing.T) {
 	// Note: Cannot use t.Parallel() - tests that execute rootCmd modify global logger state
 
@@ -15,6 +15,7 @@ import (
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/registry"
 	"github.com/spf13/cobra"
 )
 
@@ -63,7 +64,7 @@ func runPluginInspect(cmd *cobra.Command, args []string) error {
 	}
 
 	// 2. Launch plugin
-	launcher := pluginhost.NewProcessLauncher()
+	launcher := registry.NewLauncher(cfg)
 	client, err := pluginhost.NewClient(ctx, launcher, path)
 	if err != nil {
 		return fmt.Errorf("failed to launch plugin: %w", err)
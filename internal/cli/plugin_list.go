@@ -135,7 +135,7 @@ func runPluginListCmd(cmd *cobra.Command, verbose bool) error {
 
 	var enriched []enrichedPluginInfo
 	ctx := cmd.Context()
-	launcher := pluginhost.NewProcessLauncher()
+	launcher := registry.NewLauncher(cfg)
 
 	for _, p := range plugins {
 		// Launch plugin to get metadata
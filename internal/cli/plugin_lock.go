@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// NewPluginLockCmd returns the command that pins installed plugin versions in a lockfile.
+// Without arguments it rewrites the lockfile from the latest installed version of every
+// plugin; with plugin names it updates only those pins and keeps the rest.
+func NewPluginLockCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "lock [plugin...]",
+		Short: "Pin installed plugin versions in " + registry.LockfileName,
+		Long: `Record the plugin versions currently resolved in a lockfile so every machine and CI run
+prices with the same plugins. Commit the lockfile and pass --locked to the cost commands to
+resolve plugins strictly against it.
+
+Without arguments the lockfile is rewritten from the latest installed version of every
+plugin. With plugin names only those pins are updated.`,
+		Example: `  # Pin every installed plugin
+  finfocus plugin lock
+
+  # Update the pin of one plugin after upgrading it
+  finfocus plugin update kubecost
+  finfocus plugin lock kubecost
+
+  # Price strictly with the pinned versions
+  finfocus cost projected --pulumi-json plan.json --locked`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPluginLock(cmd, file, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", registry.LockfileName, "Path of the lockfile")
+
+	return cmd
+}
+
+func runPluginLock(cmd *cobra.Command, file string, names []string) error {
+	plugins, warnings, err := registry.NewDefault().ListLatestPlugins()
+	if err != nil {
+		return fmt.Errorf("listing plugins: %w", err)
+	}
+	for _, warning := range warnings {
+		cmd.PrintErrf("Warning: %s\n", warning)
+	}
+
+	var lock *registry.Lockfile
+	if len(names) == 0 {
+		lock = registry.NewLockfile(plugins)
+	} else if lock, err = updateLockfile(file, plugins, names); err != nil {
+		return err
+	}
+
+	if writeErr := lock.Write(file); writeErr != nil {
+		return writeErr
+	}
+	for _, p := range lock.Plugins {
+		cmd.Printf("%s %s\n", p.Name, p.Version)
+	}
+	cmd.Printf("\nLocked %d plugin(s) in %s\n", len(lock.Plugins), file)
+	return nil
+}
+
+// updateLockfile loads the lockfile at file, or starts an empty one when it does not
+// exist, and pins each named plugin to its latest installed version.
+func updateLockfile(file string, plugins []registry.PluginInfo, names []string) (*registry.Lockfile, error) {
+	lock, err := registry.LoadLockfile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		lock, err = registry.NewLockfile(nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]registry.PluginInfo, len(plugins))
+	for _, p := range plugins {
+		latest[p.Name] = p
+	}
+	for _, name := range names {
+		p, ok := latest[name]
+		if !ok {
+			return nil, fmt.Errorf("plugin %q is not installed", name)
+		}
+		lock.Set(p.Name, p.Version)
+	}
+	return lock, nil
+}
@@ -0,0 +1,70 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// installFakePlugin creates an executable plugin binary under home's plugin directory.
+func installFakePlugin(t *testing.T, home, name, version string) {
+	t.Helper()
+	dir := filepath.Join(home, "plugins", name, version)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	//nolint:gosec // G306: the fake plugin binary must be executable
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nexit 1\n"), 0o755))
+}
+
+func TestPluginLockCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	installFakePlugin(t, home, "kubecost", "v1.0.0")
+	installFakePlugin(t, home, "kubecost", "v1.1.0")
+	installFakePlugin(t, home, "aws-public", "v0.1.0")
+	lockPath := filepath.Join(t.TempDir(), registry.LockfileName)
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := cli.NewPluginLockCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"--file", lockPath}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, out, "Locked 2 plugin(s)")
+	lock, err := registry.LoadLockfile(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, []registry.LockedPlugin{
+		{Name: "aws-public", Version: "v0.1.0"},
+		{Name: "kubecost", Version: "v1.1.0"},
+	}, lock.Plugins)
+
+	// Updating one plugin keeps the other pins as they are.
+	lock.Set("kubecost", "v1.0.0")
+	lock.Set("aws-public", "v0.0.9")
+	require.NoError(t, lock.Write(lockPath))
+	_, err = run("kubecost")
+	require.NoError(t, err)
+	lock, err = registry.LoadLockfile(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, []registry.LockedPlugin{
+		{Name: "aws-public", Version: "v0.0.9"},
+		{Name: "kubecost", Version: "v1.1.0"},
+	}, lock.Plugins)
+
+	_, err = run("vantage")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `plugin "vantage" is not installed`)
+}
@@ -19,7 +19,7 @@ func isTerminal(f *os.File) bool {
 var logger zerolog.Logger //nolint:gochecknoglobals // Required for zerolog context integration
 
 // NewRootCmd creates the root Cobra command for the finfocus CLI.
-// It wires up logging, tracing, audit logging, and subcommands (cost, plugin, config, analyzer).
+// It wires up logging, tracing, audit logging, and subcommands (cost, plugin, config, spec, analyzer).
 // The command dynamically adjusts its Use and Example strings based on whether it's running
 // as a Pulumi tool plugin (detected via binary name or FINFOCUS_PLUGIN_MODE env var).
 func NewRootCmd(ver string) *cobra.Command {
@@ -74,7 +74,7 @@ func NewRootCmdWithArgs(ver string, args []string, lookupEnv func(string) (strin
 
 	cmd.PersistentFlags().Bool("debug", false, "enable debug logging")
 	cmd.PersistentFlags().Bool("skip-version-check", false, "skip plugin spec version compatibility check")
-	cmd.AddCommand(newCostCmd(), newPluginCmd(), newConfigCmd(), NewAnalyzerCmd())
+	cmd.AddCommand(newCostCmd(), newPluginCmd(), newConfigCmd(), newSpecCmd(), NewAnalyzerCmd())
 
 	return cmd
 }
@@ -122,10 +122,14 @@ const pluginCmdExample = `  # Calculate projected costs from a Pulumi plan
   # Set configuration values
   pulumi plugin run tool cost -- config set output.default_format json`
 
-// newCostCmd creates the cost command group with projected, actual, and recommendations subcommands.
+// newCostCmd creates the cost command group with projected, actual, recommendations, check, graph, trend,
+// import-bill and batch subcommands.
 func newCostCmd() *cobra.Command {
 	cmd := &cobra.Command{Use: "cost", Short: "Cost calculation commands"}
-	cmd.AddCommand(NewCostProjectedCmd(), NewCostActualCmd(), NewCostRecommendationsCmd())
+	cmd.AddCommand(
+		NewCostProjectedCmd(), NewCostActualCmd(), NewCostRecommendationsCmd(), NewCostCheckCmd(), NewCostGraphCmd(),
+		NewCostTrendCmd(), NewCostImportBillCmd(), NewCostBatchCmd(),
+	)
 	return cmd
 }
 
@@ -135,7 +139,17 @@ func newPluginCmd() *cobra.Command {
 	cmd.AddCommand(
 		NewPluginValidateCmd(), NewPluginListCmd(), NewPluginInitCmd(),
 		NewPluginInstallCmd(), NewPluginUpdateCmd(), NewPluginRemoveCmd(),
-		NewPluginConformanceCmd(), NewPluginCertifyCmd(), NewPluginInspectCmd(),
+		NewPluginConformanceCmd(), NewPluginCertifyCmd(), NewPluginInspectCmd(), NewPluginLockCmd(),
+	)
+	return cmd
+}
+
+// newSpecCmd creates the spec command group for working with local pricing specs.
+func newSpecCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "spec", Short: "Pricing spec commands"}
+	cmd.AddCommand(
+		NewSpecTestCmd(), NewSpecSyncCmd(), NewSpecMigrateCmd(), NewSpecValidateCmd(), NewSpecLintCmd(),
+		NewSpecGenerateCmd(),
 	)
 	return cmd
 }
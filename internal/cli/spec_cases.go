@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrSpecTestFailed is returned when one or more spec test cases do not produce the expected cost.
+var ErrSpecTestFailed = errors.New("one or more spec test cases failed")
+
+// defaultSpecCaseTolerance is the absolute tolerance applied when a case file does not set one.
+const defaultSpecCaseTolerance = 0.01
+
+// SpecCaseFile is the on-disk format of a `spec test --cases` file.
+type SpecCaseFile struct {
+	// Tolerance is the default absolute tolerance for every case in the file.
+	Tolerance *float64   `yaml:"tolerance,omitempty"`
+	Cases     []SpecCase `yaml:"cases"`
+}
+
+// SpecCase declares one input resource and the cost it is expected to produce.
+type SpecCase struct {
+	Name      string           `yaml:"name"`
+	Resource  SpecCaseResource `yaml:"resource"`
+	Expected  SpecCaseExpected `yaml:"expected"`
+	Tolerance *float64         `yaml:"tolerance,omitempty"`
+}
+
+// SpecCaseResource is the resource under test. Provider defaults to the prefix of Type.
+type SpecCaseResource struct {
+	Type       string                 `yaml:"type"`
+	Provider   string                 `yaml:"provider,omitempty"`
+	Properties map[string]interface{} `yaml:"properties,omitempty"`
+}
+
+// SpecCaseExpected holds the expected costs. At least one of Monthly or Hourly must be set.
+type SpecCaseExpected struct {
+	Monthly *float64 `yaml:"monthly,omitempty"`
+	Hourly  *float64 `yaml:"hourly,omitempty"`
+}
+
+// SpecCaseResult is the outcome of running a single SpecCase.
+type SpecCaseResult struct {
+	Name     string
+	Passed   bool
+	Monthly  float64
+	Hourly   float64
+	Failures []string
+}
+
+// NewSpecTestCmd creates the "spec test" command that runs golden-file cost cases
+// against local pricing specs.
+func NewSpecTestCmd() *cobra.Command {
+	var casesPath, specDir string
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run cost test cases against local pricing specs",
+		Long: `Run golden-file test cases through the engine's spec path (no plugins) and
+report whether each resource produces the expected monthly/hourly cost.
+The command exits non-zero when any case fails, making it suitable for CI.`,
+		Example: `  # Run cases against the configured spec directory
+  finfocus spec test --cases cases.yaml
+
+  # Run cases against a specific spec directory
+  finfocus spec test --cases cases.yaml --spec-dir ./specs`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSpecTestCmd(cmd, casesPath, specDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&casesPath, "cases", "", "Path to the YAML test case file (required)")
+	cmd.Flags().StringVar(&specDir, "spec-dir", "", "Directory containing pricing spec files")
+	_ = cmd.MarkFlagRequired("cases")
+
+	return cmd
+}
+
+// runSpecTestCmd loads the case file, runs every case and prints a PASS/FAIL line per case.
+func runSpecTestCmd(cmd *cobra.Command, casesPath, specDir string) error {
+	caseFile, err := LoadSpecCases(casesPath)
+	if err != nil {
+		return err
+	}
+
+	if specDir == "" {
+		specDir = config.New().SpecDir
+	}
+
+	eng, err := engine.New(nil, spec.NewCachingLoader(spec.NewLoader(specDir)))
+	if err != nil {
+		return err
+	}
+	results, err := RunSpecCases(cmd.Context(), eng, caseFile)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Passed {
+			cmd.Printf("PASS  %s (monthly=%.4f hourly=%.6f)\n", r.Name, r.Monthly, r.Hourly)
+			continue
+		}
+		failed++
+		cmd.Printf("FAIL  %s\n", r.Name)
+		for _, f := range r.Failures {
+			cmd.Printf("      %s\n", f)
+		}
+	}
+	cmd.Printf("\n%d passed, %d failed, %d total\n", len(results)-failed, failed, len(results))
+
+	if failed > 0 {
+		return ErrSpecTestFailed
+	}
+	return nil
+}
+
+// LoadSpecCases reads and validates a spec test case file.
+func LoadSpecCases(path string) (*SpecCaseFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cases file: %w", err)
+	}
+
+	var caseFile SpecCaseFile
+	if unmarshalErr := yaml.Unmarshal(data, &caseFile); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing cases file: %w", unmarshalErr)
+	}
+
+	if len(caseFile.Cases) == 0 {
+		return nil, fmt.Errorf("cases file %s contains no cases", path)
+	}
+	for i, c := range caseFile.Cases {
+		if c.Name == "" {
+			return nil, fmt.Errorf("case %d: name is required", i)
+		}
+		if c.Resource.Type == "" {
+			return nil, fmt.Errorf("case %q: resource.type is required", c.Name)
+		}
+		if c.Expected.Monthly == nil && c.Expected.Hourly == nil {
+			return nil, fmt.Errorf("case %q: expected.monthly or expected.hourly is required", c.Name)
+		}
+	}
+
+	return &caseFile, nil
+}
+
+// RunSpecCases evaluates every case against the engine and returns one result per case, in order.
+// A case fails if no local spec priced the resource or a cost falls outside its tolerance.
+func RunSpecCases(ctx context.Context, eng *engine.Engine, caseFile *SpecCaseFile) ([]SpecCaseResult, error) {
+	defaultTolerance := defaultSpecCaseTolerance
+	if caseFile.Tolerance != nil {
+		defaultTolerance = *caseFile.Tolerance
+	}
+
+	results := make([]SpecCaseResult, 0, len(caseFile.Cases))
+	for i, c := range caseFile.Cases {
+		tolerance := defaultTolerance
+		if c.Tolerance != nil {
+			tolerance = *c.Tolerance
+		}
+
+		provider := c.Resource.Provider
+		if provider == "" {
+			provider, _, _ = strings.Cut(c.Resource.Type, ":")
+		}
+		resource := engine.ResourceDescriptor{
+			Type:       c.Resource.Type,
+			ID:         fmt.Sprintf("case-%d", i),
+			Provider:   provider,
+			Properties: c.Resource.Properties,
+		}
+
+		costs, err := eng.GetProjectedCost(ctx, []engine.ResourceDescriptor{resource})
+		if err != nil {
+			return nil, fmt.Errorf("case %q: %w", c.Name, err)
+		}
+
+		results = append(results, evaluateSpecCase(c, costs, tolerance))
+	}
+	return results, nil
+}
+
+// evaluateSpecCase compares engine output against the case expectations.
+func evaluateSpecCase(c SpecCase, costs []engine.CostResult, tolerance float64) SpecCaseResult {
+	result := SpecCaseResult{Name: c.Name}
+	if len(costs) == 0 || costs[0].Adapter != "local-spec" {
+		result.Failures = append(result.Failures, "no local spec matched resource "+c.Resource.Type)
+		return result
+	}
+
+	result.Monthly = costs[0].Monthly
+	result.Hourly = costs[0].Hourly
+	if c.Expected.Monthly != nil && math.Abs(result.Monthly-*c.Expected.Monthly) > tolerance {
+		result.Failures = append(result.Failures, fmt.Sprintf(
+			"monthly: expected %.4f ±%g, got %.4f", *c.Expected.Monthly, tolerance, result.Monthly))
+	}
+	if c.Expected.Hourly != nil && math.Abs(result.Hourly-*c.Expected.Hourly) > tolerance {
+		result.Failures = append(result.Failures, fmt.Sprintf(
+			"hourly: expected %.6f ±%g, got %.6f", *c.Expected.Hourly, tolerance, result.Hourly))
+	}
+	result.Passed = len(result.Failures) == 0
+	return result
+}
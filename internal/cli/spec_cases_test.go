@@ -0,0 +1,157 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEC2Spec = `provider: aws
+service: ec2
+sku: t3.micro
+currency: USD
+pricing:
+  onDemandHourly: 0.0104
+`
+
+func writeSpecTestFixtures(t *testing.T, cases string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	specDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"), []byte(testEC2Spec), 0o600))
+	casesPath := filepath.Join(dir, "cases.yaml")
+	require.NoError(t, os.WriteFile(casesPath, []byte(cases), 0o600))
+	return casesPath, specDir
+}
+
+func TestSpecTestCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+
+	tests := []struct {
+		name        string
+		cases       string
+		expectError error
+		contains    []string
+	}{
+		{
+			name: "passing case",
+			cases: `cases:
+  - name: t3.micro
+    resource:
+      type: aws:ec2/instance:Instance
+      properties:
+        instanceType: t3.micro
+    expected:
+      monthly: 7.592
+      hourly: 0.0104
+`,
+			contains: []string{"PASS  t3.micro", "1 passed, 0 failed, 1 total"},
+		},
+		{
+			name: "outside tolerance",
+			cases: `tolerance: 0.001
+cases:
+  - name: wrong-monthly
+    resource:
+      type: aws:ec2/instance:Instance
+      properties:
+        instanceType: t3.micro
+    expected:
+      monthly: 8.00
+`,
+			expectError: cli.ErrSpecTestFailed,
+			contains:    []string{"FAIL  wrong-monthly", "monthly: expected 8.0000"},
+		},
+		{
+			name: "per-case tolerance override",
+			cases: `tolerance: 0.001
+cases:
+  - name: loose
+    tolerance: 1.0
+    resource:
+      type: aws:ec2/instance:Instance
+      properties:
+        instanceType: t3.micro
+    expected:
+      monthly: 8.00
+`,
+			contains: []string{"PASS  loose"},
+		},
+		{
+			name: "no matching spec",
+			cases: `cases:
+  - name: missing
+    resource:
+      type: aws:rds/instance:Instance
+    expected:
+      monthly: 10
+`,
+			expectError: cli.ErrSpecTestFailed,
+			contains:    []string{"no local spec matched resource aws:rds/instance:Instance"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			casesPath, specDir := writeSpecTestFixtures(t, tt.cases)
+
+			var buf bytes.Buffer
+			cmd := cli.NewSpecTestCmd()
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs([]string{"--cases", casesPath, "--spec-dir", specDir})
+
+			err := cmd.Execute()
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func TestLoadSpecCases_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{name: "empty", content: "cases: []\n", errMsg: "contains no cases"},
+		{
+			name:    "missing name",
+			content: "cases:\n  - resource: {type: aws:ec2:Instance}\n    expected: {monthly: 1}\n",
+			errMsg:  "name is required",
+		},
+		{
+			name:    "missing type",
+			content: "cases:\n  - name: a\n    expected: {monthly: 1}\n",
+			errMsg:  "resource.type is required",
+		},
+		{
+			name:    "missing expectation",
+			content: "cases:\n  - name: a\n    resource: {type: aws:ec2:Instance}\n",
+			errMsg:  "expected.monthly or expected.hourly",
+		},
+		{name: "bad yaml", content: "cases: [", errMsg: "parsing cases file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cases.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			_, err := cli.LoadSpecCases(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
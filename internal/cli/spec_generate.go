@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/ingest"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// specFileMode is the permission of generated spec files.
+const specFileMode = 0o600
+
+// specDirMode is the permission of spec directories created for generated specs.
+const specDirMode = 0o750
+
+// specGenerateParams holds the flags of the "spec generate" command.
+type specGenerateParams struct {
+	provider      string
+	template      string
+	service       string
+	sku           string
+	outDir        string
+	force         bool
+	listTemplates bool
+	pulumiJSON    string
+}
+
+// NewSpecGenerateCmd creates the "spec generate" command that writes a pricing spec
+// skeleton from a provider template, ready for the author to fill in prices.
+func NewSpecGenerateCmd() *cobra.Command {
+	var params specGenerateParams
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a pricing spec skeleton from a provider template",
+		Long: `Write a new pricing spec pre-filled from a template with the structure, pricing
+field names and defaults that suit the provider, such as onDemandHourly and the region
+for virtual machines. Prices are left at zero for you to fill in.
+
+Each provider has a default template named after it; --template picks another, such as
+aws-ebs or aws-rds. Templates placed in the templates directory of the spec directory are
+added to the built-in ones and replace any with the same name. Use --list-templates to
+see them all.
+
+The spec is written to provider-service-sku.yaml in the spec directory. An existing file
+is never overwritten unless --force is given.
+
+With --pulumi-json, a skeleton is written for every distinct provider, service and SKU of
+the resources in a Pulumi preview, named as the engine looks its pricing up. Each uses the
+template for its provider and service, or zero hourly pricing when there is none.
+Resources that already have a spec are skipped.`,
+		Example: `  # Generate an Azure virtual machine spec
+  finfocus spec generate --provider azure --sku Standard_D2s_v5
+
+  # Generate an EBS volume spec into a shared spec repository
+  finfocus spec generate --template aws-ebs --sku io2 --out ./specs
+
+  # List the available templates
+  finfocus spec generate --list-templates
+
+  # Generate a spec for every resource type and SKU in a plan
+  finfocus spec generate --pulumi-json plan.json --out ./specs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSpecGenerateCmd(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.provider, "provider", "",
+		"Cloud provider whose default template is used (aws, azure, gcp)")
+	cmd.Flags().StringVar(&params.template, "template", "", "Name of the template to use instead of the default")
+	cmd.Flags().StringVar(&params.service, "service", "", "Service of the spec (default: the template's)")
+	cmd.Flags().StringVar(&params.sku, "sku", "", "SKU of the spec (default: the template's)")
+	cmd.Flags().StringVar(&params.outDir, "out", "", "Directory to write the spec to (default: the spec directory)")
+	cmd.Flags().BoolVar(&params.force, "force", false, "Overwrite an existing spec file")
+	cmd.Flags().BoolVar(&params.listTemplates, "list-templates", false, "List the available templates and exit")
+	cmd.Flags().StringVar(&params.pulumiJSON, "pulumi-json", "",
+		"Path to Pulumi preview JSON output; generates a spec for each resource type and SKU in it")
+	return cmd
+}
+
+// runSpecGenerateCmd resolves the template and writes the generated spec.
+func runSpecGenerateCmd(cmd *cobra.Command, params specGenerateParams) error {
+	cfg := config.New()
+	specDir := cfg.SpecDir
+	templates, err := spec.LoadTemplates(filepath.Join(specDir, spec.TemplateDirName))
+	if err != nil {
+		return err
+	}
+
+	if params.listTemplates {
+		for _, name := range spec.SortedTemplateNames(templates) {
+			t := templates[name]
+			cmd.Printf("%-12s %-6s %s (%s)\n", t.Name, t.Provider, t.Description, t.Source)
+		}
+		return nil
+	}
+
+	outDir := params.outDir
+	if outDir == "" {
+		outDir = specDir
+	}
+	if params.pulumiJSON != "" {
+		zeroCost := engine.NewZeroCostTypes(cfg.Engine.ZeroCostTypes)
+		return runSpecGeneratePlan(cmd, params.pulumiJSON, outDir, templates, zeroCost, params.force)
+	}
+	if params.provider == "" && params.template == "" {
+		return errors.New("either --provider or --template is required")
+	}
+
+	tmpl, err := spec.FindTemplate(templates, params.template, params.provider)
+	if err != nil {
+		return err
+	}
+	if params.provider != "" && params.template != "" && tmpl.Provider != params.provider {
+		return fmt.Errorf("template %q is for provider %s, not %s", tmpl.Name, tmpl.Provider, params.provider)
+	}
+
+	generated := tmpl.NewSpec(params.service, params.sku)
+	if err = spec.ValidateSpec(generated); err != nil {
+		return fmt.Errorf("generated spec is invalid: %w", err)
+	}
+	data, err := tmpl.MarshalSkeleton(generated)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(outDir, fmt.Sprintf("%s-%s-%s.yaml", generated.Provider, generated.Service, generated.SKU))
+	if _, statErr := os.Stat(path); statErr == nil && !params.force {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+	if err = writeSpecFile(outDir, path, data); err != nil {
+		return err
+	}
+	cmd.Printf("Generated %s from the %s template\n", path, tmpl.Name)
+	return nil
+}
+
+// runSpecGeneratePlan writes a skeleton spec to outDir for each distinct provider, service
+// and SKU of the resources in the Pulumi plan at planPath, skipping those that already
+// have a YAML or JSON spec there unless force is set. Zero-cost resources are skipped.
+func runSpecGeneratePlan(
+	cmd *cobra.Command,
+	planPath, outDir string,
+	templates map[string]*spec.Template,
+	zeroCost engine.ZeroCostTypes,
+	force bool,
+) error {
+	ctx := cmd.Context()
+	plan, err := ingest.LoadPulumiPlanWithContext(ctx, planPath)
+	if err != nil {
+		return fmt.Errorf("loading Pulumi plan: %w", err)
+	}
+	resources, err := ingest.MapResources(plan.GetResourcesWithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("mapping resources: %w", err)
+	}
+	resources, _ = zeroCost.Exclude(resources)
+
+	created, skipped := 0, 0
+	seen := make(map[string]bool)
+	for _, resource := range resources {
+		provider, service, sku := engine.SpecKey(resource)
+		name := fmt.Sprintf("%s-%s-%s", provider, service, sku)
+		if provider == "" || service == "" || sku == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		path := filepath.Join(outDir, name+".yaml")
+		if !force && (fileExists(path) || fileExists(filepath.Join(outDir, name+".json"))) {
+			skipped++
+			continue
+		}
+		tmpl := spec.TemplateFor(templates, provider, service)
+		data, marshalErr := tmpl.MarshalSkeleton(tmpl.NewSpec(service, sku))
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if err = writeSpecFile(outDir, path, data); err != nil {
+			return err
+		}
+		cmd.Printf("Generated %s from the %s template (%s)\n", path, tmpl.Name, resource.Type)
+		created++
+	}
+	cmd.Printf("Created %d specs, skipped %d that already exist\n", created, skipped)
+	return nil
+}
+
+// fileExists reports whether a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// writeSpecFile writes a generated spec to path, creating outDir when needed.
+func writeSpecFile(outDir, path string, data []byte) error {
+	if err := os.MkdirAll(outDir, specDirMode); err != nil {
+		return fmt.Errorf("creating spec directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, specFileMode); err != nil {
+		return fmt.Errorf("writing spec: %w", err)
+	}
+	return nil
+}
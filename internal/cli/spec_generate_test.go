@@ -0,0 +1,139 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runSpecGenerate(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	cmd := cli.NewSpecGenerateCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestSpecGenerateCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	out := t.TempDir()
+
+	stdout, err := runSpecGenerate(t, "--provider", "azure", "--sku", "Standard_D2s_v5", "--out", out)
+	require.NoError(t, err)
+	path := filepath.Join(out, "azure-compute-Standard_D2s_v5.yaml")
+	assert.Contains(t, stdout, "Generated "+path+" from the azure template")
+
+	raw, err := spec.NewLoader(out).LoadSpec("azure", "compute", "Standard_D2s_v5")
+	require.NoError(t, err)
+	loaded, ok := raw.(*spec.PricingSpec)
+	require.True(t, ok)
+	assert.Equal(t, "eastus", loaded.Metadata["region"])
+	assert.Contains(t, loaded.Pricing, "onDemandHourly")
+
+	_, err = runSpecGenerate(t, "--provider", "azure", "--sku", "Standard_D2s_v5", "--out", out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	_, err = runSpecGenerate(t, "--provider", "azure", "--sku", "Standard_D2s_v5", "--out", out, "--force")
+	require.NoError(t, err)
+}
+
+func TestSpecGenerateCmd_Errors(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	_, err := runSpecGenerate(t)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--provider or --template")
+
+	_, err = runSpecGenerate(t, "--provider", "oracle", "--out", t.TempDir())
+	require.ErrorIs(t, err, spec.ErrTemplateNotFound)
+
+	_, err = runSpecGenerate(t, "--provider", "gcp", "--template", "aws-ebs", "--out", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is for provider aws")
+}
+
+func TestSpecGenerateCmd_UserTemplates(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	templateDir := filepath.Join(home, "specs", spec.TemplateDirName)
+	require.NoError(t, os.MkdirAll(templateDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "aws-lambda.yaml"), []byte(`name: aws-lambda
+description: Lambda function
+provider: aws
+service: lambda
+sku: arm64
+currency: USD
+pricing:
+  monthlyEstimate: 0
+`), 0o600))
+
+	stdout, err := runSpecGenerate(t, "--list-templates")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "aws-lambda")
+	assert.Contains(t, stdout, "built-in")
+
+	out := t.TempDir()
+	_, err = runSpecGenerate(t, "--template", "aws-lambda", "--out", out)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(out, "aws-lambda-arm64.yaml"))
+}
+
+func TestSpecGenerateCmd_PulumiPlan(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	out := t.TempDir()
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(planPath, []byte(`{"steps": [
+  {"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web-1",
+   "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}},
+  {"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web-2",
+   "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}},
+  {"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::worker",
+   "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "m5.large"}},
+  {"op": "create", "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets",
+   "type": "aws:s3/bucket:Bucket", "inputs": {}},
+  {"op": "create", "urn": "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev",
+   "type": "pulumi:pulumi:Stack", "inputs": {}}
+]}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(out, "aws-ec2-m5.large.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\npricing:\n  onDemandHourly: 0.096\n"), 0o600))
+
+	stdout, err := runSpecGenerate(t, "--pulumi-json", planPath, "--out", out)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Created 2 specs, skipped 1 that already exist")
+
+	entries, err := os.ReadDir(out)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "one spec per distinct type and SKU, without the stack")
+
+	ec2, err := os.ReadFile(filepath.Join(out, "aws-ec2-t3.micro.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(ec2), "# TODO: replace the zero prices")
+	raw, err := spec.NewLoader(out).LoadSpec("aws", "s3", "bucket")
+	require.NoError(t, err)
+	bucket, ok := raw.(*spec.PricingSpec)
+	require.True(t, ok)
+	require.NoError(t, spec.ValidateSpec(bucket))
+	assert.Contains(t, bucket.Pricing, "onDemandHourly")
+
+	existing, err := os.ReadFile(filepath.Join(out, "aws-ec2-m5.large.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(existing), "0.096", "existing specs are left alone")
+
+	stdout, err = runSpecGenerate(t, "--pulumi-json", planPath, "--out", out)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Created 0 specs, skipped 3 that already exist")
+}
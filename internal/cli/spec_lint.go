@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/rshade/finfocus/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ErrSpecLintFailed is returned when one or more specs fail linting.
+var ErrSpecLintFailed = errors.New("one or more specs failed linting")
+
+// Lint statuses of a spec file.
+const (
+	lintPass = "pass"
+	lintWarn = "warn"
+	lintFail = "fail"
+)
+
+// specLintIssue is one problem with a spec file; Field is empty for problems with the
+// file as a whole, such as YAML that does not parse.
+type specLintIssue struct {
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// specLintFile is the lint result for one spec file.
+type specLintFile struct {
+	File     string          `json:"file"`
+	Status   string          `json:"status"`
+	Issues   []specLintIssue `json:"issues,omitempty"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// specLintReport is the JSON output of "spec lint".
+type specLintReport struct {
+	Specs  []specLintFile `json:"specs"`
+	Passed int            `json:"passed"`
+	Failed int            `json:"failed"`
+}
+
+// NewSpecLintCmd creates the "spec lint" command that validates every pricing spec in a
+// directory and reports the results as a table or as JSON.
+func NewSpecLintCmd() *cobra.Command {
+	var (
+		format      string
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint [spec-dir]",
+		Short: "Lint every pricing spec in a directory",
+		Long: `Run the spec validator over each pricing spec in the directory and print a
+pass/fail table naming the field and reason of every problem. Use --format json
+for CI. The command fails if any spec is invalid; warnings do not fail it.
+
+With no argument the configured spec directory is linted.`,
+		Example: `  # Lint the configured spec directory
+  finfocus spec lint
+
+  # Lint specs in CI and keep the report
+  finfocus spec lint ./specs --format json > spec-lint.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specDir := config.New().SpecDir
+			if len(args) > 0 {
+				specDir = args[0]
+			}
+			return runSpecLintCmd(cmd, specDir, format, concurrency)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", spec.DefaultValidateConcurrency,
+		"Maximum number of spec files validated at once")
+	return cmd
+}
+
+// runSpecLintCmd lints specDir and writes the report in format.
+func runSpecLintCmd(cmd *cobra.Command, specDir, format string, concurrency int) error {
+	if format != "table" && format != "json" {
+		return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+	}
+	results, err := spec.ValidateDir(cmd.Context(), specDir, concurrency)
+	if err != nil {
+		return err
+	}
+
+	report := newSpecLintReport(results)
+	if format == "json" {
+		data, marshalErr := json.MarshalIndent(report, "", "  ")
+		if marshalErr != nil {
+			return fmt.Errorf("failed to marshal lint report to JSON: %w", marshalErr)
+		}
+		cmd.Printf("%s\n", data)
+	} else if renderErr := renderSpecLintTable(cmd.OutOrStdout(), report); renderErr != nil {
+		return renderErr
+	}
+
+	if report.Failed > 0 {
+		return ErrSpecLintFailed
+	}
+	return nil
+}
+
+// newSpecLintReport turns validation results into a lint report, one issue per problem.
+func newSpecLintReport(results []spec.ValidationResult) specLintReport {
+	report := specLintReport{Specs: make([]specLintFile, 0, len(results))}
+	for _, r := range results {
+		file := specLintFile{File: filepath.Base(r.Path), Status: lintPass, Warnings: r.Warnings}
+		if len(r.Warnings) > 0 {
+			file.Status = lintWarn
+		}
+		if r.Err != nil {
+			file.Status = lintFail
+			for _, issue := range validationIssues(r.Err) {
+				lintIssue := specLintIssue{Reason: issue.Error()}
+				var fieldErr *spec.FieldError
+				if errors.As(issue, &fieldErr) {
+					lintIssue.Field = fieldErr.Field
+				}
+				file.Issues = append(file.Issues, lintIssue)
+			}
+			report.Failed++
+		} else {
+			report.Passed++
+		}
+		report.Specs = append(report.Specs, file)
+	}
+	return report
+}
+
+// renderSpecLintTable writes one row per problem, or per file without problems, followed by
+// a summary. Statuses are styled when the output is a terminal.
+func renderSpecLintTable(writer io.Writer, report specLintReport) error {
+	statusLabel := strings.ToUpper
+	if tui.DetectOutputMode(false, false, false) != tui.OutputModePlain {
+		styled := map[string]string{lintPass: tui.StatusOK, lintWarn: tui.StatusWarning, lintFail: tui.StatusCritical}
+		statusLabel = func(status string) string { return tui.RenderStatus(styled[status]) }
+	}
+
+	w := tabwriter.NewWriter(writer, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(w, "File\tField\tReason\tStatus")
+	fmt.Fprintln(w, "----\t-----\t------\t------")
+	row := func(file, field, reason, status string) {
+		if field == "" {
+			field = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", file, field, reason, statusLabel(status))
+	}
+	for _, f := range report.Specs {
+		for _, issue := range f.Issues {
+			row(f.File, issue.Field, issue.Reason, lintFail)
+		}
+		for _, warning := range f.Warnings {
+			row(f.File, "", warning, lintWarn)
+		}
+		if len(f.Issues) == 0 && len(f.Warnings) == 0 {
+			row(f.File, "", "", lintPass)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(writer, "\n%d passed, %d failed\n", report.Passed, report.Failed)
+	return nil
+}
@@ -0,0 +1,81 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecLintCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	dir := t.TempDir()
+	files := map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+		"aws-ebs-gp3.yaml": "provider: aws\nservice: ebs\nsku: gp3\ncurrency: USD\n" +
+			"pricing:\n  pricePerGBMonth: 0.08\n  onDemandHourly: 0.01\n",
+		"aws-s3-standard.yaml": "provider: aws\nservice: s3\nsku: standard\ncurrency: DOLLARS\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewSpecLintCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SilenceUsage = true
+		cmd.SetArgs(append([]string{dir}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run()
+	require.ErrorIs(t, err, cli.ErrSpecLintFailed)
+	assert.Regexp(t, `aws-ec2-t3\.micro\.yaml\s+-\s+PASS`, out)
+	assert.Regexp(t, `aws-ebs-gp3\.yaml\s+-\s+pricing sets both onDemandHourly and pricePerGBMonth.*WARN`, out)
+	assert.Regexp(t, `aws-s3-standard\.yaml\s+currency\s+currency is not an ISO 4217 code: "DOLLARS"\s+FAIL`, out)
+	assert.Regexp(t, `aws-s3-standard\.yaml\s+pricing\s+pricing information is required\s+FAIL`, out)
+	assert.Contains(t, out, "2 passed, 1 failed")
+
+	out, err = run("--format", "json")
+	require.ErrorIs(t, err, cli.ErrSpecLintFailed)
+	var report struct {
+		Specs []struct {
+			File   string `json:"file"`
+			Status string `json:"status"`
+			Issues []struct {
+				Field  string `json:"field"`
+				Reason string `json:"reason"`
+			} `json:"issues"`
+		} `json:"specs"`
+		Passed int `json:"passed"`
+		Failed int `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, 2, report.Passed)
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Specs, 3)
+	assert.Equal(t, "warn", report.Specs[0].Status)
+	assert.Equal(t, "pass", report.Specs[1].Status)
+	failed := report.Specs[2]
+	assert.Equal(t, "aws-s3-standard.yaml", failed.File)
+	assert.Equal(t, "fail", failed.Status)
+	require.Len(t, failed.Issues, 2)
+	assert.Equal(t, "currency", failed.Issues[0].Field)
+	assert.Equal(t, "pricing", failed.Issues[1].Field)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "aws-s3-standard.yaml")))
+	_, err = run()
+	require.NoError(t, err, "warnings alone do not fail linting")
+
+	_, err = run("--format", "xml")
+	require.ErrorContains(t, err, "unsupported format")
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// ErrSpecMigrateFailed is returned when one or more specs could not be migrated.
+var ErrSpecMigrateFailed = errors.New("one or more specs could not be migrated")
+
+// NewSpecMigrateCmd creates the "spec migrate" command that upgrades pricing specs to the
+// current format version.
+func NewSpecMigrateCmd() *cobra.Command {
+	var (
+		specDir string
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate [spec-file...]",
+		Short: "Upgrade pricing specs to the current format version",
+		Long: fmt.Sprintf(`Detect the format version of each pricing spec and upgrade older specs to
+version %d, preserving their values. Legacy price keys such as hourly and monthly
+are renamed to the keys the engine reads, flat price fields are moved under
+pricing, and a version field is added. Files are replaced atomically.
+
+With no arguments every spec in the spec directory is migrated.`, spec.CurrentSpecVersion),
+		Example: `  # Preview changes to the configured spec directory
+  finfocus spec migrate --dry-run
+
+  # Migrate specific files
+  finfocus spec migrate ./specs/aws-ec2-t3.micro.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSpecMigrateCmd(cmd, args, specDir, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&specDir, "spec-dir", "", "Directory containing pricing spec files")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report changes without writing files")
+	return cmd
+}
+
+// runSpecMigrateCmd migrates the given files, or every spec in specDir when none are given,
+// and prints what changed per file.
+func runSpecMigrateCmd(cmd *cobra.Command, paths []string, specDir string, dryRun bool) error {
+	var results []spec.MigrationResult
+	if len(paths) > 0 {
+		for _, path := range paths {
+			results = append(results, spec.MigrateFile(path, dryRun))
+		}
+	} else {
+		if specDir == "" {
+			specDir = config.New().SpecDir
+		}
+		if _, err := os.Stat(specDir); err != nil {
+			return fmt.Errorf("reading spec directory: %w", err)
+		}
+		var err error
+		results, err = spec.MigrateDir(specDir, dryRun)
+		if err != nil {
+			return err
+		}
+	}
+
+	var migrated, current, failed int
+	for _, r := range results {
+		name := filepath.Base(r.Path)
+		switch {
+		case r.Err != nil:
+			failed++
+			cmd.Printf("ERROR    %s: %v\n", name, r.Err)
+		case r.Migrated():
+			migrated++
+			cmd.Printf("MIGRATE  %s (v%d -> v%d)\n", name, r.FromVersion, r.ToVersion)
+			for _, change := range r.Changes {
+				cmd.Printf("         %s\n", change)
+			}
+		default:
+			current++
+			cmd.Printf("OK       %s (v%d)\n", name, r.FromVersion)
+		}
+	}
+
+	summary := fmt.Sprintf("\n%d migrated, %d up to date, %d failed", migrated, current, failed)
+	if dryRun {
+		summary += " (dry run: no files written)"
+	}
+	cmd.Println(summary)
+
+	if failed > 0 {
+		return ErrSpecMigrateFailed
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecMigrateCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aws-ec2-t3.micro.yaml")
+	legacy := "provider: aws\nservice: ec2\nsku: t3.micro\npricing:\n  hourly: 0.0104\n  currency: USD\n"
+	require.NoError(t, os.WriteFile(path, []byte(legacy), 0o600))
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewSpecMigrateCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("--spec-dir", dir, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, out, "MIGRATE  aws-ec2-t3.micro.yaml (v1 -> v2)")
+	assert.Contains(t, out, "renamed pricing.hourly to pricing.onDemandHourly")
+	assert.Contains(t, out, "dry run: no files written")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, string(data))
+
+	_, err = run(path)
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "version: 2")
+
+	out, err = run(path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "OK       aws-ec2-t3.micro.yaml (v2)")
+}
+
+func TestSpecMigrateCmd_InvalidSpec(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	path := filepath.Join(t.TempDir(), "bad.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("version: 9\n"), 0o600))
+
+	cmd := cli.NewSpecMigrateCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{path})
+	require.ErrorIs(t, cmd.Execute(), cli.ErrSpecMigrateFailed)
+	assert.Contains(t, buf.String(), "unsupported spec version")
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// NewSpecSyncCmd creates the "spec sync" command that refreshes the local cache of the
+// configured remote spec source.
+func NewSpecSyncCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Refresh pricing specs from the configured remote source",
+		Long: `Fetch pricing specs from the remote source configured under specs.remote
+(a git repository or an HTTP index), validate them, and replace the local cache
+in <spec_dir>/remote. Invalid specs are reported and skipped.`,
+		Example: `  # Configure a shared spec repository, then sync it
+  finfocus config set specs.remote.url https://github.com/acme/pricing-specs.git
+  finfocus spec sync`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSpecSyncCmd(cmd, config.New())
+		},
+	}
+}
+
+// runSpecSyncCmd forces a sync of the remote spec source described by cfg.
+func runSpecSyncCmd(cmd *cobra.Command, cfg *config.Config) error {
+	source := newRemoteSpecSource(cfg)
+	result, err := source.Sync(cmd.Context(), true)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Synced %d spec(s) from %s into %s\n", len(result.Cached), source.URL, source.CacheDir)
+	if len(result.Rejected) > 0 {
+		names := make([]string, 0, len(result.Rejected))
+		for name := range result.Rejected {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		cmd.Printf("Rejected %d invalid spec(s):\n", len(names))
+		for _, name := range names {
+			cmd.Printf("  %s: %s\n", name, result.Rejected[name])
+		}
+	}
+	if len(result.Warnings) > 0 {
+		names := make([]string, 0, len(result.Warnings))
+		for name := range result.Warnings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		cmd.Printf("Warnings for %d synced spec(s):\n", len(names))
+		for _, name := range names {
+			for _, warning := range result.Warnings[name] {
+				cmd.Printf("  %s: %s\n", name, warning)
+			}
+		}
+	}
+	return nil
+}
+
+// newRemoteSpecSource builds the remote spec source from configuration.
+func newRemoteSpecSource(cfg *config.Config) *spec.RemoteSource {
+	return &spec.RemoteSource{
+		URL:      cfg.Specs.Remote.URL,
+		Type:     cfg.Specs.Remote.Type,
+		TTL:      cfg.RemoteSpecTTL(),
+		CacheDir: cfg.RemoteSpecCacheDir(),
+	}
+}
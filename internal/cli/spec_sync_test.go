@@ -0,0 +1,24 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecSyncCmd_NotConfigured(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	cmd := cli.NewSpecSyncCmd()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, spec.ErrRemoteNotConfigured)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// ErrSpecValidateFailed is returned when one or more specs are invalid.
+var ErrSpecValidateFailed = errors.New("one or more specs are invalid")
+
+// NewSpecValidateCmd creates the "spec validate" command that checks every pricing spec in a
+// directory, validating files concurrently.
+func NewSpecValidateCmd() *cobra.Command {
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "validate [spec-dir]",
+		Short: "Validate every pricing spec in a directory",
+		Long: `Check that each pricing spec in the directory parses, has the required fields,
+and is named provider-service-sku.yaml so the engine can find it. Per-GB storage prices
+must belong to a storage or database spec, and specs setting both hourly and storage
+prices are warned about, as only the hourly rate is used. Files are validated
+concurrently; the report lists every problem with each spec, is sorted by filename, and
+the command fails if any spec is invalid.
+
+With no argument the configured spec directory is validated.`,
+		Example: `  # Validate the configured spec directory
+  finfocus spec validate
+
+  # Validate a shared spec repository in CI
+  finfocus spec validate ./specs --concurrency 32`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specDir := config.New().SpecDir
+			if len(args) > 0 {
+				specDir = args[0]
+			}
+			return runSpecValidateCmd(cmd, specDir, concurrency)
+		},
+	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", spec.DefaultValidateConcurrency,
+		"Maximum number of spec files validated at once")
+	return cmd
+}
+
+// runSpecValidateCmd validates specDir and prints one line per file and a summary.
+func runSpecValidateCmd(cmd *cobra.Command, specDir string, concurrency int) error {
+	results, err := spec.ValidateDir(cmd.Context(), specDir, concurrency)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		name := filepath.Base(r.Path)
+		if r.Err != nil {
+			failed++
+			for _, issue := range validationIssues(r.Err) {
+				cmd.Printf("ERROR    %s: %v\n", name, issue)
+			}
+		} else {
+			cmd.Printf("OK       %s\n", name)
+		}
+		for _, warning := range r.Warnings {
+			cmd.Printf("WARN     %s: %s\n", name, warning)
+		}
+	}
+	cmd.Printf("\n%d valid, %d invalid\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return ErrSpecValidateFailed
+	}
+	return nil
+}
+
+// validationIssues splits the joined errors of an invalid spec so each is printed on its
+// own line.
+func validationIssues(err error) []error {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return []error{err}
+	}
+	var issues []error
+	for _, e := range joined.Unwrap() {
+		issues = append(issues, validationIssues(e)...)
+	}
+	return issues
+}
@@ -0,0 +1,56 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecValidateCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"),
+		0o600))
+
+	run := func() (string, error) {
+		var buf bytes.Buffer
+		cmd := cli.NewSpecValidateCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{dir, "--concurrency", "2"})
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, out, "OK       aws-ec2-t3.micro.yaml")
+	assert.Contains(t, out, "1 valid, 0 invalid")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-s3-standard.yaml"),
+		[]byte("provider: aws\nservice: s3\nsku: standard\ncurrency: USD\n"), 0o600))
+	out, err = run()
+	require.ErrorIs(t, err, cli.ErrSpecValidateFailed)
+	assert.Contains(t, out, "ERROR    aws-s3-standard.yaml: pricing information is required")
+	assert.Contains(t, out, "1 valid, 1 invalid")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-s3-standard.yaml"),
+		[]byte("provider: aws\nservice: s3\nsku: standard\ncurrency: DOLLARS\npricing:\n  pricePerGBMonth: 0.023\n"+
+			"  onDemandHourly: 0.01\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-m5.large.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\npricing:\n  pricePerGBMonth: 0.1\n"), 0o600))
+	out, err = run()
+	require.ErrorIs(t, err, cli.ErrSpecValidateFailed)
+	assert.Contains(t, out, `ERROR    aws-s3-standard.yaml: currency is not an ISO 4217 code: "DOLLARS"`)
+	assert.Contains(t, out,
+		"WARN     aws-s3-standard.yaml: pricing sets both onDemandHourly and pricePerGBMonth; only the hourly rate is used")
+	assert.Contains(t, out, "ERROR    aws-ec2-m5.large.yaml: pricing does not fit the resource: "+
+		"pricePerGBMonth is priced per GB but ec2-m5.large is not a storage or database resource")
+	assert.Contains(t, out, "1 valid, 2 invalid")
+}
@@ -3,8 +3,11 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -51,8 +54,25 @@ const (
 	defaultPerResourceTimeout   = 5 * time.Second
 	defaultTotalTimeout         = 60 * time.Second
 	defaultWarnThresholdTimeout = 30 * time.Second
+
+	// defaultRemoteSpecTTL is how long a synced remote spec cache is considered fresh.
+	defaultRemoteSpecTTL = 24 * time.Hour
+
+	// defaultPricingCacheTTL is how long a plugin price carrying an ETag is reused before
+	// the plugin is asked whether it changed.
+	defaultPricingCacheTTL = time.Hour
+
+	// budgetKeyParts is the length of budgets.environments.<env>.<field> after "budgets".
+	budgetKeyParts = 3
+
+	// monthsPerYear bounds calendar.fiscal_year_start_month.
+	monthsPerYear = 12
 )
 
+// MaxBillingCycleStartDay is the latest day a billing cycle can start on, so that every
+// month has it.
+const MaxBillingCycleStartDay = 28
+
 // ErrConfigCorrupted is returned in strict mode when the config file exists but cannot be parsed.
 var ErrConfigCorrupted = errors.New("configuration file appears corrupted")
 
@@ -67,6 +87,36 @@ type Config struct {
 	Plugins  map[string]PluginConfig `yaml:"plugins"  json:"plugins"`
 	Logging  LoggingConfig           `yaml:"logging"  json:"logging"`
 	Analyzer AnalyzerConfig          `yaml:"analyzer" json:"analyzer"`
+	Specs    SpecsConfig             `yaml:"specs"    json:"specs"`
+	Cache    CacheConfig             `yaml:"cache"    json:"cache"`
+
+	Recommendations RecommendationsConfig `yaml:"recommendations,omitempty" json:"recommendations,omitempty"`
+
+	// Transforms post-process every cost result, in order, before it is aggregated.
+	Transforms []TransformConfig `yaml:"transforms,omitempty" json:"transforms,omitempty"`
+
+	// Budgets sets monthly budgets per environment with alerting thresholds.
+	Budgets BudgetsConfig `yaml:"budgets,omitempty" json:"budgets,omitempty"`
+
+	// CustomTypes tells the engine how to price resource types it does not recognize, such
+	// as those created by dynamic or custom providers.
+	CustomTypes []CustomTypeConfig `yaml:"custom_types,omitempty" json:"custom_types,omitempty"`
+
+	// Calendar describes the fiscal year and billing cycle used to resolve --period terms.
+	Calendar CalendarConfig `yaml:"calendar,omitempty" json:"calendar,omitempty"`
+
+	// CostRules is the path of a YAML file of ordered rules that override how matching
+	// resources are priced.
+	CostRules string `yaml:"cost_rules,omitempty" json:"cost_rules,omitempty"`
+
+	// Anomalies configures cost spike detection in cost actual and where spikes are sent.
+	Anomalies AnomaliesConfig `yaml:"anomalies,omitempty" json:"anomalies,omitempty"`
+
+	// Sandbox restricts the plugin processes finfocus launches.
+	Sandbox SandboxConfig `yaml:"sandbox,omitempty" json:"sandbox,omitempty"`
+
+	// Engine tunes how the cost engine spreads work across resources and plugins.
+	Engine EngineConfig `yaml:"engine,omitempty" json:"engine,omitempty"`
 
 	// Internal fields
 	configPath string
@@ -76,6 +126,12 @@ type Config struct {
 type OutputConfig struct {
 	DefaultFormat string `yaml:"default_format" json:"default_format"`
 	Precision     int    `yaml:"precision"      json:"precision"`
+	// AllocationTags are tag keys (e.g. team, cost-center) promoted to top-level fields in
+	// JSON and CSV output for cost allocation tools.
+	AllocationTags []string `yaml:"allocation_tags,omitempty" json:"allocation_tags,omitempty"`
+	// RedactTags are tag keys whose values are replaced with "[redacted]" in --anonymize
+	// output; "*" redacts every tag.
+	RedactTags []string `yaml:"redact_tags,omitempty" json:"redact_tags,omitempty"`
 }
 
 // PluginConfig defines plugin-specific configuration.
@@ -121,6 +177,228 @@ type AnalyzerTimeout struct {
 	WarnThreshold Duration `yaml:"warn_threshold" json:"warn_threshold"` // Warning threshold (default: 30s)
 }
 
+// SpecsConfig defines where pricing specs come from beyond the local spec directory.
+type SpecsConfig struct {
+	Remote RemoteSpecsConfig `yaml:"remote" json:"remote"`
+	// Offline prices resources from local specs only: plugins are never launched and the
+	// remote spec source is not refreshed.
+	Offline bool `yaml:"offline,omitempty" json:"offline,omitempty"`
+}
+
+// RemoteSpecsConfig configures a shared spec source that is cached under <spec_dir>/remote.
+type RemoteSpecsConfig struct {
+	URL  string   `yaml:"url,omitempty"  json:"url,omitempty"`  // Git repository or HTTP index URL
+	Type string   `yaml:"type,omitempty" json:"type,omitempty"` // "git" or "http"; inferred from URL when empty
+	TTL  Duration `yaml:"ttl,omitempty"  json:"ttl,omitempty"`  // Refresh interval (default: 24h)
+}
+
+// CacheConfig defines how plugin responses are cached between runs.
+type CacheConfig struct {
+	// PricingTTL is how long a projected price that a plugin tagged with an ETag is reused
+	// without contacting the plugin (default: 1h). After that the plugin is asked whether
+	// the price changed.
+	PricingTTL Duration `yaml:"pricing_ttl,omitempty" json:"pricing_ttl,omitempty"`
+}
+
+// CalendarConfig defines the business calendar. FiscalYearStartMonth is the month (1-12)
+// the fiscal year starts in and BillingCycleStartDay the day of the month (1-28) a billing
+// cycle starts on; both default to 1.
+type CalendarConfig struct {
+	FiscalYearStartMonth int `yaml:"fiscal_year_start_month,omitempty" json:"fiscal_year_start_month,omitempty"`
+	BillingCycleStartDay int `yaml:"billing_cycle_start_day,omitempty" json:"billing_cycle_start_day,omitempty"`
+}
+
+// AnomaliesConfig defines when a day's cost counts as an anomaly and where anomalies are
+// posted. Threshold is the spike above the trailing daily average that is flagged (0.5 for
+// 50%; zero disables detection). Anomalies at or above MinSeverity (low, medium or high;
+// default low) are posted to WebhookURL as they are detected.
+type AnomaliesConfig struct {
+	Threshold   float64 `yaml:"threshold,omitempty"    json:"threshold,omitempty"`
+	WebhookURL  string  `yaml:"webhook_url,omitempty"  json:"webhook_url,omitempty"`
+	MinSeverity string  `yaml:"min_severity,omitempty" json:"min_severity,omitempty"`
+}
+
+// SandboxConfig defines whether plugins run restricted: with a reduced environment, in a
+// private temporary directory and, on Linux, in their own namespaces. AllowEnv names
+// further environment variables passed to plugins, such as the credentials of their
+// cloud; a trailing * matches a prefix, as in "AWS_*".
+type SandboxConfig struct {
+	Enabled  bool     `yaml:"enabled,omitempty"   json:"enabled,omitempty"`
+	AllowEnv []string `yaml:"allow_env,omitempty" json:"allow_env,omitempty"`
+}
+
+// EngineConfig defines the cost engine's concurrency, caching, retry and conversion
+// settings. Zero values keep the engine defaults: workers scale with the CPU count, all
+// plugins are asked at once, actual-cost calls are not rate limited, plugin responses are
+// reused within a run, transient plugin failures are tried 3 times starting 100ms apart,
+// and a month has 730 hours. ZeroCostTypes lists resource types, besides internal Pulumi
+// types, that are reported as $0; an entry ending in "*" matches by prefix.
+type EngineConfig struct {
+	Workers              int      `yaml:"workers,omitempty"                json:"workers,omitempty"`
+	ActualCostWorkers    int      `yaml:"actual_cost_workers,omitempty"    json:"actual_cost_workers,omitempty"`
+	ActualCostRateLimit  float64  `yaml:"actual_cost_rate_limit,omitempty" json:"actual_cost_rate_limit,omitempty"`
+	PluginConcurrency    int      `yaml:"plugin_concurrency,omitempty"     json:"plugin_concurrency,omitempty"`
+	DisableResponseCache bool     `yaml:"disable_response_cache,omitempty" json:"disable_response_cache,omitempty"`
+	HoursPerMonth        float64  `yaml:"hours_per_month,omitempty"        json:"hours_per_month,omitempty"`
+	RetryAttempts        int      `yaml:"retry_attempts,omitempty"         json:"retry_attempts,omitempty"`
+	RetryBaseDelay       Duration `yaml:"retry_base_delay,omitempty"       json:"retry_base_delay,omitempty"`
+	ZeroCostTypes        []string `yaml:"zero_cost_types,omitempty"        json:"zero_cost_types,omitempty"`
+}
+
+// RecommendationsConfig defines how recommendations are filtered before reporting.
+type RecommendationsConfig struct {
+	// Suppress lists acknowledged recommendations to hide. Each entry is a recommendation
+	// ID, "type:<ACTION>", "resource:<id>", or "type:<ACTION> on <resource>".
+	Suppress []string `yaml:"suppress,omitempty" json:"suppress,omitempty"`
+}
+
+// TransformConfig is one step of the result transform chain. Type is discount, markup,
+// round or currency; the other fields apply to the types that use them.
+type TransformConfig struct {
+	Type     string  `yaml:"type"               json:"type"`
+	Percent  float64 `yaml:"percent,omitempty"  json:"percent,omitempty"`  // discount, markup
+	Decimals int     `yaml:"decimals,omitempty" json:"decimals,omitempty"` // round
+	From     string  `yaml:"from,omitempty"     json:"from,omitempty"`     // currency; empty matches any
+	To       string  `yaml:"to,omitempty"       json:"to,omitempty"`       // currency
+	Rate     float64 `yaml:"rate,omitempty"     json:"rate,omitempty"`     // currency
+}
+
+// CustomTypeConfig maps a resource type, or a type prefix ending in "*", to a pricing
+// behavior. Action is map, fixed or unpriceable; the other fields apply to the actions
+// that use them.
+type CustomTypeConfig struct {
+	Type     string  `yaml:"type"               json:"type"`
+	Action   string  `yaml:"action"             json:"action"`
+	MapTo    string  `yaml:"map_to,omitempty"   json:"map_to,omitempty"`   // map
+	Monthly  float64 `yaml:"monthly,omitempty"  json:"monthly,omitempty"`  // fixed
+	Currency string  `yaml:"currency,omitempty" json:"currency,omitempty"` // fixed
+}
+
+// BudgetsConfig defines monthly budgets per environment. A resource belongs to the
+// environment named by its TagKey tag (default "environment"), or to its stack when the
+// tag is absent.
+type BudgetsConfig struct {
+	TagKey       string                  `yaml:"tag_key,omitempty"      json:"tag_key,omitempty"`
+	Environments map[string]BudgetConfig `yaml:"environments,omitempty" json:"environments,omitempty"`
+}
+
+// BudgetConfig is the monthly budget of one environment. The thresholds are percentages
+// of Amount (defaults: warn 80, critical 100).
+type BudgetConfig struct {
+	Amount          float64 `yaml:"amount"                     json:"amount"`
+	Currency        string  `yaml:"currency,omitempty"         json:"currency,omitempty"` // default USD
+	WarnPercent     float64 `yaml:"warn_percent,omitempty"     json:"warn_percent,omitempty"`
+	CriticalPercent float64 `yaml:"critical_percent,omitempty" json:"critical_percent,omitempty"`
+}
+
+// RemoteSpecCacheDir returns the directory where remote specs are cached.
+func (c *Config) RemoteSpecCacheDir() string {
+	return filepath.Join(c.SpecDir, "remote")
+}
+
+// RemoteSpecTTL returns the configured refresh interval, or the default when unset.
+func (c *Config) RemoteSpecTTL() time.Duration {
+	if c.Specs.Remote.TTL > 0 {
+		return c.Specs.Remote.TTL.Duration()
+	}
+	return defaultRemoteSpecTTL
+}
+
+// PricingCacheDir returns the directory where ETag-tagged plugin prices are cached.
+func (c *Config) PricingCacheDir() string {
+	return filepath.Join(ResolveConfigDir(), "cache", "pricing")
+}
+
+// PricingCacheTTL returns the configured pricing cache TTL, or the default when unset.
+func (c *Config) PricingCacheTTL() time.Duration {
+	if c.Cache.PricingTTL > 0 {
+		return c.Cache.PricingTTL.Duration()
+	}
+	return defaultPricingCacheTTL
+}
+
+// PluginCacheTTLKey is the plugin setting that overrides the pricing cache TTL for that
+// plugin's prices, e.g. plugins.spot.cache_ttl: 5m.
+const PluginCacheTTLKey = "cache_ttl"
+
+// PluginCacheTTLs returns the pricing cache TTL configured for each plugin that sets one.
+func (c *Config) PluginCacheTTLs() (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	for name, plugin := range c.Plugins {
+		raw, ok := plugin.Config[PluginCacheTTLKey]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(fmt.Sprint(raw))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("plugins.%s.%s must be a positive duration: %q", name, PluginCacheTTLKey, raw)
+		}
+		ttls[name] = d
+	}
+	return ttls, nil
+}
+
+// Plugin settings that layer plugins of the same provider, e.g. public prices overlaid
+// with negotiated discounts:
+//
+//	plugins:
+//	  aws-public: {role: base}
+//	  aws-negotiated: {role: adjustment, adjustment: factor, layer_order: 1}
+const (
+	PluginRoleKey       = "role"
+	PluginAdjustmentKey = "adjustment"
+	PluginLayerOrderKey = "layer_order"
+
+	PluginRoleBase       = "base"
+	PluginRoleAdjustment = "adjustment"
+	AdjustmentDelta      = "delta"
+	AdjustmentFactor     = "factor"
+)
+
+// PluginLayer is the role a plugin plays in layered pricing.
+type PluginLayer struct {
+	Name string
+	// Role is PluginRoleBase or PluginRoleAdjustment.
+	Role string
+	// Adjustment is AdjustmentDelta or AdjustmentFactor for adjustment plugins; empty
+	// means delta.
+	Adjustment string
+	Order      int
+}
+
+// PluginLayers returns the layering of every plugin that sets a role, sorted by name.
+func (c *Config) PluginLayers() ([]PluginLayer, error) {
+	var layers []PluginLayer
+	for name, plugin := range c.Plugins {
+		raw, ok := plugin.Config[PluginRoleKey]
+		if !ok {
+			continue
+		}
+		layer := PluginLayer{Name: name, Role: fmt.Sprint(raw)}
+		if layer.Role != PluginRoleBase && layer.Role != PluginRoleAdjustment {
+			return nil, fmt.Errorf("plugins.%s.%s must be base or adjustment: %q", name, PluginRoleKey, raw)
+		}
+		if mode, set := plugin.Config[PluginAdjustmentKey]; set {
+			layer.Adjustment = fmt.Sprint(mode)
+			if layer.Role != PluginRoleAdjustment ||
+				(layer.Adjustment != AdjustmentDelta && layer.Adjustment != AdjustmentFactor) {
+				return nil, fmt.Errorf("plugins.%s.%s must be delta or factor on an adjustment plugin: %q",
+					name, PluginAdjustmentKey, mode)
+			}
+		}
+		if order, set := plugin.Config[PluginLayerOrderKey]; set {
+			n, err := strconv.Atoi(fmt.Sprint(order))
+			if err != nil {
+				return nil, fmt.Errorf("plugins.%s.%s must be an integer: %q", name, PluginLayerOrderKey, order)
+			}
+			layer.Order = n
+		}
+		layers = append(layers, layer)
+	}
+	sort.Slice(layers, func(i, j int) bool { return layers[i].Name < layers[j].Name })
+	return layers, nil
+}
+
 // AnalyzerPlugin defines a cost plugin configuration for the analyzer.
 type AnalyzerPlugin struct {
 	Path    string            `yaml:"path"    json:"path"`    // Path to plugin binary
@@ -337,6 +615,28 @@ func (c *Config) Set(key, value string) error {
 		return c.setPluginValue(parts[1:], value)
 	case "logging":
 		return c.setLoggingValue(parts[1:], value)
+	case "specs":
+		return c.setSpecsValue(parts[1:], value)
+	case "cache":
+		return c.setCacheValue(parts[1:], value)
+	case "recommendations":
+		return c.setRecommendationsValue(parts[1:], value)
+	case "budgets":
+		return c.setBudgetsValue(parts[1:], value)
+	case "calendar":
+		return c.setCalendarValue(parts[1:], value)
+	case "cost_rules":
+		if len(parts) > 1 {
+			return errors.New("cost_rules is a file path and has no nested keys")
+		}
+		c.CostRules = value
+		return nil
+	case "anomalies":
+		return c.setAnomaliesValue(parts[1:], value)
+	case "sandbox":
+		return c.setSandboxValue(parts[1:], value)
+	case "engine":
+		return c.setEngineValue(parts[1:], value)
 	default:
 		return fmt.Errorf("unknown configuration section: %s", parts[0])
 	}
@@ -356,6 +656,37 @@ func (c *Config) Get(key string) (interface{}, error) {
 		return c.getPluginValue(parts[1:])
 	case "logging":
 		return c.getLoggingValue(parts[1:])
+	case "specs":
+		return c.getSpecsValue(parts[1:])
+	case "cache":
+		return c.getCacheValue(parts[1:])
+	case "recommendations":
+		return c.getRecommendationsValue(parts[1:])
+	case "budgets":
+		return c.getBudgetsValue(parts[1:])
+	case "calendar":
+		return c.getCalendarValue(parts[1:])
+	case "transforms":
+		if len(parts) > 1 {
+			return nil, errors.New("transforms can only be read as a whole")
+		}
+		return c.Transforms, nil
+	case "custom_types":
+		if len(parts) > 1 {
+			return nil, errors.New("custom_types can only be read as a whole")
+		}
+		return c.CustomTypes, nil
+	case "cost_rules":
+		if len(parts) > 1 {
+			return nil, errors.New("cost_rules is a file path and has no nested keys")
+		}
+		return c.CostRules, nil
+	case "anomalies":
+		return c.getAnomaliesValue(parts[1:])
+	case "sandbox":
+		return c.getSandboxValue(parts[1:])
+	case "engine":
+		return c.getEngineValue(parts[1:])
 	default:
 		return nil, fmt.Errorf("unknown configuration section: %s", parts[0])
 	}
@@ -368,6 +699,18 @@ func (c *Config) List() map[string]interface{} {
 		"plugins":  c.Plugins,
 		"logging":  c.Logging,
 		"analyzer": c.Analyzer,
+		"specs":    c.Specs,
+		"cache":    c.Cache,
+
+		"recommendations": c.Recommendations,
+		"transforms":      c.Transforms,
+		"budgets":         c.Budgets,
+		"custom_types":    c.CustomTypes,
+		"calendar":        c.Calendar,
+		"cost_rules":      c.CostRules,
+		"anomalies":       c.Anomalies,
+		"sandbox":         c.Sandbox,
+		"engine":          c.Engine,
 	}
 }
 
@@ -405,6 +748,25 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("plugin configuration validation failed: %w", err)
 	}
 
+	if err := c.Calendar.validate(); err != nil {
+		return fmt.Errorf("calendar configuration validation failed: %w", err)
+	}
+
+	if err := c.Anomalies.validate(); err != nil {
+		return fmt.Errorf("anomalies configuration validation failed: %w", err)
+	}
+
+	if err := c.Engine.validate(); err != nil {
+		return fmt.Errorf("engine configuration validation failed: %w", err)
+	}
+
+	// Validate remote spec source
+	switch c.Specs.Remote.Type {
+	case "", "git", "http":
+	default:
+		return fmt.Errorf("invalid specs.remote.type: %s (must be git or http)", c.Specs.Remote.Type)
+	}
+
 	return nil
 }
 
@@ -604,7 +966,11 @@ func (c *Config) validatePluginConfigurations() error {
 		}
 	}
 
-	return nil
+	if _, err := c.PluginCacheTTLs(); err != nil {
+		return err
+	}
+	_, err := c.PluginLayers()
+	return err
 }
 
 // PluginPath returns the path for a specific plugin version (backward compatibility).
@@ -656,6 +1022,44 @@ func (c *Config) applyEnvOverrides() {
 		c.Logging.File = logFile
 	}
 
+	// Offline mode override
+	if offline := os.Getenv("FINFOCUS_OFFLINE"); offline != "" {
+		if b, err := strconv.ParseBool(offline); err == nil {
+			c.Specs.Offline = b
+		}
+	}
+
+	// Plugin sandbox override
+	if sandbox := os.Getenv("FINFOCUS_PLUGIN_SANDBOX"); sandbox != "" {
+		if b, err := strconv.ParseBool(sandbox); err == nil {
+			c.Sandbox.Enabled = b
+		}
+	}
+
+	// Pricing cache TTL override
+	if ttl := os.Getenv("FINFOCUS_PRICING_CACHE_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err == nil && d > 0 {
+			c.Cache.PricingTTL = Duration(d)
+		}
+	}
+
+	// Actual-cost concurrency and rate limit overrides
+	if workers := os.Getenv("FINFOCUS_ACTUAL_COST_CONCURRENCY"); workers != "" {
+		if n, err := strconv.Atoi(workers); err == nil {
+			c.Engine.ActualCostWorkers = n
+		}
+	}
+	if limit := os.Getenv("FINFOCUS_ACTUAL_COST_RATE_LIMIT"); limit != "" {
+		if v, err := strconv.ParseFloat(limit, 64); err == nil {
+			c.Engine.ActualCostRateLimit = v
+		}
+	}
+
+	// Extra zero-cost resource types (comma-separated)
+	if types := os.Getenv("FINFOCUS_ZERO_COST_TYPES"); types != "" {
+		c.Engine.ZeroCostTypes = splitConfigList(types)
+	}
+
 	// Plugin overrides (FINFOCUS_PLUGIN_<NAME>_<KEY>=value)
 	c.scanPluginEnvironmentVars()
 }
@@ -746,6 +1150,17 @@ func (c *Config) scanPluginEnvironmentVars() {
 	}
 }
 
+// splitConfigList parses a comma-separated setting, dropping empty entries.
+func splitConfigList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // Helper methods for setting values.
 func (c *Config) setOutputValue(parts []string, value string) error {
 	if len(parts) != 1 {
@@ -753,6 +1168,10 @@ func (c *Config) setOutputValue(parts []string, value string) error {
 	}
 
 	switch parts[0] {
+	case "allocation_tags":
+		c.Output.AllocationTags = splitConfigList(value)
+	case "redact_tags":
+		c.Output.RedactTags = splitConfigList(value)
 	case "default_format":
 		c.Output.DefaultFormat = value
 	case "precision":
@@ -806,12 +1225,443 @@ func (c *Config) setLoggingValue(parts []string, value string) error {
 }
 
 // Helper methods for getting values.
+func (c *Config) setSpecsValue(parts []string, value string) error {
+	if len(parts) == 1 && parts[0] == "offline" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("offline must be true or false: %w", err)
+		}
+		c.Specs.Offline = b
+		return nil
+	}
+	if len(parts) != 2 || parts[0] != "remote" {
+		return errors.New("specs key must be specs.offline or specs.remote.<url|type|ttl>")
+	}
+
+	switch parts[1] {
+	case "url":
+		c.Specs.Remote.URL = value
+	case "type":
+		c.Specs.Remote.Type = value
+	case "ttl":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("ttl must be a duration: %w", err)
+		}
+		c.Specs.Remote.TTL = Duration(d)
+	default:
+		return fmt.Errorf("unknown specs.remote setting: %s", parts[1])
+	}
+
+	return nil
+}
+
+func (c *Config) getSpecsValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Specs, nil
+	}
+	if len(parts) == 1 && parts[0] == "offline" {
+		return c.Specs.Offline, nil
+	}
+	if parts[0] != "remote" || len(parts) > 2 {
+		return nil, fmt.Errorf("unknown specs setting: %s", strings.Join(parts, "."))
+	}
+	if len(parts) == 1 {
+		return c.Specs.Remote, nil
+	}
+
+	switch parts[1] {
+	case "url":
+		return c.Specs.Remote.URL, nil
+	case "type":
+		return c.Specs.Remote.Type, nil
+	case "ttl":
+		return c.RemoteSpecTTL().String(), nil
+	default:
+		return nil, fmt.Errorf("unknown specs.remote setting: %s", parts[1])
+	}
+}
+
+func (c *Config) setCacheValue(parts []string, value string) error {
+	if len(parts) != 1 || parts[0] != "pricing_ttl" {
+		return errors.New("cache key must be cache.pricing_ttl")
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("pricing_ttl must be a positive duration: %q", value)
+	}
+	c.Cache.PricingTTL = Duration(d)
+	return nil
+}
+
+func (c *Config) getCacheValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Cache, nil
+	}
+	if len(parts) != 1 || parts[0] != "pricing_ttl" {
+		return nil, fmt.Errorf("unknown cache setting: %s", strings.Join(parts, "."))
+	}
+	return c.PricingCacheTTL().String(), nil
+}
+
+// setRecommendationsValue sets recommendations.suppress from a comma-separated list.
+func (c *Config) setRecommendationsValue(parts []string, value string) error {
+	if len(parts) != 1 || parts[0] != "suppress" {
+		return errors.New("recommendations key must be recommendations.suppress")
+	}
+
+	c.Recommendations.Suppress = splitConfigList(value)
+	return nil
+}
+
+func (c *Config) getRecommendationsValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Recommendations, nil
+	}
+	if len(parts) != 1 || parts[0] != "suppress" {
+		return nil, fmt.Errorf("unknown recommendations setting: %s", strings.Join(parts, "."))
+	}
+	return c.Recommendations.Suppress, nil
+}
+
+// setCalendarValue sets calendar.fiscal_year_start_month or calendar.billing_cycle_start_day.
+func (c *Config) setCalendarValue(parts []string, value string) error {
+	if len(parts) != 1 {
+		return errors.New("calendar key must be calendar.fiscal_year_start_month or calendar.billing_cycle_start_day")
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s must be a whole number: %q", parts[0], value)
+	}
+	updated := c.Calendar
+	switch parts[0] {
+	case "fiscal_year_start_month":
+		updated.FiscalYearStartMonth = n
+	case "billing_cycle_start_day":
+		updated.BillingCycleStartDay = n
+	default:
+		return fmt.Errorf("unknown calendar setting: %s", parts[0])
+	}
+	if validateErr := updated.validate(); validateErr != nil {
+		return validateErr
+	}
+	c.Calendar = updated
+	return nil
+}
+
+func (c *Config) getCalendarValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Calendar, nil
+	}
+	if len(parts) == 1 {
+		switch parts[0] {
+		case "fiscal_year_start_month":
+			return c.Calendar.FiscalYearStartMonth, nil
+		case "billing_cycle_start_day":
+			return c.Calendar.BillingCycleStartDay, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown calendar setting: %s", strings.Join(parts, "."))
+}
+
+// validate checks that the fiscal year start month and billing cycle day are in range.
+// Zero means the default.
+func (cal CalendarConfig) validate() error {
+	if cal.FiscalYearStartMonth < 0 || cal.FiscalYearStartMonth > monthsPerYear {
+		return fmt.Errorf("fiscal_year_start_month must be between 1 and %d, got %d",
+			monthsPerYear, cal.FiscalYearStartMonth)
+	}
+	if cal.BillingCycleStartDay < 0 || cal.BillingCycleStartDay > MaxBillingCycleStartDay {
+		return fmt.Errorf("billing_cycle_start_day must be between 1 and %d, got %d",
+			MaxBillingCycleStartDay, cal.BillingCycleStartDay)
+	}
+	return nil
+}
+
+// setAnomaliesValue sets anomalies.threshold, anomalies.webhook_url or anomalies.min_severity.
+func (c *Config) setAnomaliesValue(parts []string, value string) error {
+	if len(parts) != 1 {
+		return errors.New("anomalies key must be anomalies.threshold, anomalies.webhook_url or anomalies.min_severity")
+	}
+	updated := c.Anomalies
+	switch parts[0] {
+	case "threshold":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("threshold must be a number: %q", value)
+		}
+		updated.Threshold = v
+	case "webhook_url":
+		updated.WebhookURL = value
+	case "min_severity":
+		updated.MinSeverity = value
+	default:
+		return fmt.Errorf("unknown anomalies setting: %s", parts[0])
+	}
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	c.Anomalies = updated
+	return nil
+}
+
+func (c *Config) getAnomaliesValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Anomalies, nil
+	}
+	if len(parts) == 1 {
+		switch parts[0] {
+		case "threshold":
+			return c.Anomalies.Threshold, nil
+		case "webhook_url":
+			return c.Anomalies.WebhookURL, nil
+		case "min_severity":
+			return c.Anomalies.MinSeverity, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown anomalies setting: %s", strings.Join(parts, "."))
+}
+
+// setSandboxValue sets sandbox.enabled or sandbox.allow_env, a comma-separated list.
+func (c *Config) setSandboxValue(parts []string, value string) error {
+	if len(parts) != 1 {
+		return errors.New("sandbox key must be sandbox.enabled or sandbox.allow_env")
+	}
+	switch parts[0] {
+	case "enabled":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("enabled must be true or false: %w", err)
+		}
+		c.Sandbox.Enabled = b
+	case "allow_env":
+		var names []string
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		c.Sandbox.AllowEnv = names
+	default:
+		return fmt.Errorf("unknown sandbox setting: %s", parts[0])
+	}
+	return nil
+}
+
+func (c *Config) getSandboxValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Sandbox, nil
+	}
+	if len(parts) == 1 {
+		switch parts[0] {
+		case "enabled":
+			return c.Sandbox.Enabled, nil
+		case "allow_env":
+			return c.Sandbox.AllowEnv, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown sandbox setting: %s", strings.Join(parts, "."))
+}
+
+// setEngineValue sets one engine.* setting.
+func (c *Config) setEngineValue(parts []string, value string) error {
+	if len(parts) != 1 {
+		return errors.New("engine key must name a setting, e.g. engine.workers")
+	}
+	updated := c.Engine
+	switch parts[0] {
+	case "workers", "actual_cost_workers", "plugin_concurrency", "retry_attempts":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number: %q", parts[0], value)
+		}
+		switch parts[0] {
+		case "workers":
+			updated.Workers = n
+		case "actual_cost_workers":
+			updated.ActualCostWorkers = n
+		case "plugin_concurrency":
+			updated.PluginConcurrency = n
+		default:
+			updated.RetryAttempts = n
+		}
+	case "disable_response_cache":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("disable_response_cache must be true or false: %w", err)
+		}
+		updated.DisableResponseCache = b
+	case "hours_per_month", "actual_cost_rate_limit":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a number: %q", parts[0], value)
+		}
+		if parts[0] == "hours_per_month" {
+			updated.HoursPerMonth = v
+		} else {
+			updated.ActualCostRateLimit = v
+		}
+	case "retry_base_delay":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("retry_base_delay must be a duration: %q", value)
+		}
+		updated.RetryBaseDelay = Duration(d)
+	case "zero_cost_types":
+		updated.ZeroCostTypes = splitConfigList(value)
+	default:
+		return fmt.Errorf("unknown engine setting: %s", parts[0])
+	}
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	c.Engine = updated
+	return nil
+}
+
+func (c *Config) getEngineValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Engine, nil
+	}
+	if len(parts) == 1 {
+		switch parts[0] {
+		case "workers":
+			return c.Engine.Workers, nil
+		case "actual_cost_workers":
+			return c.Engine.ActualCostWorkers, nil
+		case "actual_cost_rate_limit":
+			return c.Engine.ActualCostRateLimit, nil
+		case "plugin_concurrency":
+			return c.Engine.PluginConcurrency, nil
+		case "disable_response_cache":
+			return c.Engine.DisableResponseCache, nil
+		case "hours_per_month":
+			return c.Engine.HoursPerMonth, nil
+		case "retry_attempts":
+			return c.Engine.RetryAttempts, nil
+		case "retry_base_delay":
+			return c.Engine.RetryBaseDelay.Duration().String(), nil
+		case "zero_cost_types":
+			return c.Engine.ZeroCostTypes, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown engine setting: %s", strings.Join(parts, "."))
+}
+
+// validate checks that no engine setting is negative and that hours_per_month and
+// actual_cost_rate_limit are finite.
+func (e EngineConfig) validate() error {
+	counts := []struct {
+		name  string
+		value int
+	}{
+		{"workers", e.Workers},
+		{"actual_cost_workers", e.ActualCostWorkers},
+		{"plugin_concurrency", e.PluginConcurrency},
+		{"retry_attempts", e.RetryAttempts},
+	}
+	for _, c := range counts {
+		if c.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", c.name, c.value)
+		}
+	}
+	if e.HoursPerMonth < 0 || math.IsNaN(e.HoursPerMonth) || math.IsInf(e.HoursPerMonth, 0) {
+		return fmt.Errorf("hours_per_month must be a positive number, got %g", e.HoursPerMonth)
+	}
+	if e.ActualCostRateLimit < 0 || math.IsNaN(e.ActualCostRateLimit) || math.IsInf(e.ActualCostRateLimit, 0) {
+		return fmt.Errorf("actual_cost_rate_limit must be a non-negative number, got %g", e.ActualCostRateLimit)
+	}
+	if e.RetryBaseDelay < 0 {
+		return fmt.Errorf("retry_base_delay must not be negative, got %s", e.RetryBaseDelay.Duration())
+	}
+	return nil
+}
+
+// validate checks that the threshold is not negative, the webhook is an http(s) URL and
+// the minimum severity is known.
+func (a AnomaliesConfig) validate() error {
+	if a.Threshold < 0 {
+		return fmt.Errorf("threshold must not be negative, got %g", a.Threshold)
+	}
+	if a.WebhookURL != "" {
+		u, err := url.Parse(a.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook_url must be an http or https URL: %q", a.WebhookURL)
+		}
+	}
+	switch strings.ToLower(a.MinSeverity) {
+	case "", "low", "medium", "high":
+		return nil
+	default:
+		return fmt.Errorf("min_severity must be low, medium or high: %q", a.MinSeverity)
+	}
+}
+
+// setBudgetsValue sets budgets.tag_key or a field of budgets.environments.<env>.
+func (c *Config) setBudgetsValue(parts []string, value string) error {
+	if len(parts) == 1 && parts[0] == "tag_key" {
+		c.Budgets.TagKey = value
+		return nil
+	}
+	if len(parts) != budgetKeyParts || parts[0] != "environments" {
+		return errors.New("budgets key must be budgets.tag_key or budgets.environments.<env>.<field>")
+	}
+
+	env, field := parts[1], parts[2]
+	budget := c.Budgets.Environments[env]
+	if field == "currency" {
+		budget.Currency = value
+	} else {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v < 0 {
+			return fmt.Errorf("%s must be a non-negative number: %q", field, value)
+		}
+		switch field {
+		case "amount":
+			budget.Amount = v
+		case "warn_percent":
+			budget.WarnPercent = v
+		case "critical_percent":
+			budget.CriticalPercent = v
+		default:
+			return fmt.Errorf("unknown budget setting: %s", field)
+		}
+	}
+	if c.Budgets.Environments == nil {
+		c.Budgets.Environments = make(map[string]BudgetConfig)
+	}
+	c.Budgets.Environments[env] = budget
+	return nil
+}
+
+func (c *Config) getBudgetsValue(parts []string) (interface{}, error) {
+	switch {
+	case len(parts) == 0:
+		return c.Budgets, nil
+	case len(parts) == 1 && parts[0] == "tag_key":
+		return c.Budgets.TagKey, nil
+	case parts[0] != "environments" || len(parts) >= budgetKeyParts:
+		return nil, fmt.Errorf("unknown budgets setting: %s", strings.Join(parts, "."))
+	case len(parts) == 1:
+		return c.Budgets.Environments, nil
+	}
+	budget, ok := c.Budgets.Environments[parts[1]]
+	if !ok {
+		return nil, fmt.Errorf("no budget for environment: %s", parts[1])
+	}
+	return budget, nil
+}
+
 func (c *Config) getOutputValue(parts []string) (interface{}, error) {
 	if len(parts) != 1 {
 		return nil, errors.New("invalid output key")
 	}
 
 	switch parts[0] {
+	case "allocation_tags":
+		return c.Output.AllocationTags, nil
+	case "redact_tags":
+		return c.Output.RedactTags, nil
 	case "default_format":
 		return c.Output.DefaultFormat, nil
 	case "precision":
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
 	"github.com/stretchr/testify/assert"
@@ -649,3 +650,354 @@ func TestResolveConfigDir(t *testing.T) {
 		assert.Contains(t, dir, ".finfocus")
 	})
 }
+
+func TestConfig_SpecsRemote(t *testing.T) {
+	stubHome(t)
+	cfg := New()
+
+	assert.Equal(t, 24*time.Hour, cfg.RemoteSpecTTL())
+	assert.Equal(t, filepath.Join(cfg.SpecDir, "remote"), cfg.RemoteSpecCacheDir())
+
+	require.NoError(t, cfg.Set("specs.remote.url", "https://github.com/acme/specs.git"))
+	require.NoError(t, cfg.Set("specs.remote.ttl", "2h"))
+	require.NoError(t, cfg.Set("specs.remote.type", "git"))
+	assert.Equal(t, 2*time.Hour, cfg.RemoteSpecTTL())
+
+	url, err := cfg.Get("specs.remote.url")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/specs.git", url)
+	require.NoError(t, cfg.Validate())
+
+	require.Error(t, cfg.Set("specs.remote.ttl", "soon"))
+	require.Error(t, cfg.Set("specs.local", "x"))
+
+	require.NoError(t, cfg.Set("specs.remote.type", "ftp"))
+	require.Error(t, cfg.Validate())
+}
+
+func TestConfig_OutputAllocationTags(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, cfg.Set("output.allocation_tags", "team, cost-center,,environment"))
+	got, err := cfg.Get("output.allocation_tags")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team", "cost-center", "environment"}, got)
+}
+
+func TestConfig_OutputRedactTags(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, cfg.Set("output.redact_tags", "owner, email"))
+	got, err := cfg.Get("output.redact_tags")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"owner", "email"}, got)
+}
+
+func TestConfig_Transforms(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	data := "transforms:\n  - type: discount\n    percent: 12\n  - type: currency\n    from: USD\n    to: EUR\n" +
+		"    rate: 0.9\n  - type: round\n    decimals: 2\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(data), 0o600))
+
+	cfg := New()
+	require.Len(t, cfg.Transforms, 3)
+	assert.Equal(t, TransformConfig{Type: "discount", Percent: 12}, cfg.Transforms[0])
+	assert.Equal(t, TransformConfig{Type: "currency", From: "USD", To: "EUR", Rate: 0.9}, cfg.Transforms[1])
+
+	got, err := cfg.Get("transforms")
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Transforms, got)
+	_, err = cfg.Get("transforms.0")
+	require.Error(t, err)
+}
+
+func TestConfig_CustomTypes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	data := "custom_types:\n  - type: pulumi-nodejs:dynamic:Resource\n    action: map\n" +
+		"    map_to: aws:ec2/instance:Instance\n  - type: acme:*\n    action: fixed\n    monthly: 12.5\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(data), 0o600))
+
+	cfg := New()
+	require.Len(t, cfg.CustomTypes, 2)
+	assert.Equal(t, CustomTypeConfig{
+		Type: "pulumi-nodejs:dynamic:Resource", Action: "map", MapTo: "aws:ec2/instance:Instance",
+	}, cfg.CustomTypes[0])
+	assert.Equal(t, CustomTypeConfig{Type: "acme:*", Action: "fixed", Monthly: 12.5}, cfg.CustomTypes[1])
+
+	got, err := cfg.Get("custom_types")
+	require.NoError(t, err)
+	assert.Equal(t, cfg.CustomTypes, got)
+	_, err = cfg.Get("custom_types.0")
+	require.Error(t, err)
+}
+
+func TestConfig_CostRules(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	cfg := New()
+	require.NoError(t, cfg.Set("cost_rules", "/etc/finfocus/rules.yaml"))
+	assert.Equal(t, "/etc/finfocus/rules.yaml", cfg.CostRules)
+	got, err := cfg.Get("cost_rules")
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/finfocus/rules.yaml", got)
+	assert.Equal(t, "/etc/finfocus/rules.yaml", cfg.List()["cost_rules"])
+
+	require.Error(t, cfg.Set("cost_rules.path", "x"))
+	_, err = cfg.Get("cost_rules.path")
+	require.Error(t, err)
+}
+
+func TestConfig_Calendar(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	data := "calendar:\n  fiscal_year_start_month: 4\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(data), 0o600))
+
+	cfg := New()
+	assert.Equal(t, CalendarConfig{FiscalYearStartMonth: 4}, cfg.Calendar)
+	require.NoError(t, cfg.Validate())
+
+	require.NoError(t, cfg.Set("calendar.billing_cycle_start_day", "15"))
+	got, err := cfg.Get("calendar.billing_cycle_start_day")
+	require.NoError(t, err)
+	assert.Equal(t, 15, got)
+
+	require.Error(t, cfg.Set("calendar.fiscal_year_start_month", "13"))
+	require.Error(t, cfg.Set("calendar.billing_cycle_start_day", "31"))
+	require.Error(t, cfg.Set("calendar.billing_cycle_start_day", "first"))
+	require.Error(t, cfg.Set("calendar.week_start", "1"))
+	assert.Equal(t, CalendarConfig{FiscalYearStartMonth: 4, BillingCycleStartDay: 15}, cfg.Calendar)
+
+	cfg.Calendar.FiscalYearStartMonth = 13
+	require.Error(t, cfg.Validate())
+}
+
+func TestConfig_Budgets(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	data := "budgets:\n  tag_key: env\n  environments:\n    prod:\n      amount: 5000\n      warn_percent: 75\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(data), 0o600))
+
+	cfg := New()
+	assert.Equal(t, "env", cfg.Budgets.TagKey)
+	assert.Equal(t, BudgetConfig{Amount: 5000, WarnPercent: 75}, cfg.Budgets.Environments["prod"])
+
+	require.NoError(t, cfg.Set("budgets.environments.dev.amount", "200"))
+	require.NoError(t, cfg.Set("budgets.environments.dev.currency", "EUR"))
+	require.NoError(t, cfg.Set("budgets.environments.dev.critical_percent", "120"))
+	got, err := cfg.Get("budgets.environments.dev")
+	require.NoError(t, err)
+	assert.Equal(t, BudgetConfig{Amount: 200, Currency: "EUR", CriticalPercent: 120}, got)
+
+	require.NoError(t, cfg.Set("budgets.tag_key", "stage"))
+	got, err = cfg.Get("budgets.tag_key")
+	require.NoError(t, err)
+	assert.Equal(t, "stage", got)
+
+	require.Error(t, cfg.Set("budgets.environments.dev.amount", "lots"))
+	require.Error(t, cfg.Set("budgets.environments.dev.owner", "me"))
+	require.Error(t, cfg.Set("budgets.amount", "1"))
+	_, err = cfg.Get("budgets.environments.qa")
+	require.Error(t, err)
+}
+
+func TestConfig_SpecsOffline(t *testing.T) {
+	stubHome(t)
+	cfg := New()
+	assert.False(t, cfg.Specs.Offline)
+
+	require.NoError(t, cfg.Set("specs.offline", "true"))
+	got, err := cfg.Get("specs.offline")
+	require.NoError(t, err)
+	assert.Equal(t, true, got)
+	require.Error(t, cfg.Set("specs.offline", "sometimes"))
+
+	t.Setenv("FINFOCUS_OFFLINE", "1")
+	assert.True(t, New().Specs.Offline)
+}
+
+func TestConfig_PluginCacheTTLs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	data := "plugins:\n  spot:\n    cache_ttl: 5m\n  aws-static:\n    cache_ttl: 24h\n    region: us-east-1\n" +
+		"  vantage:\n    token: secret\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(data), 0o600))
+
+	cfg := New()
+	ttls, err := cfg.PluginCacheTTLs()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"spot": 5 * time.Minute, "aws-static": 24 * time.Hour}, ttls)
+	require.NoError(t, cfg.Validate())
+
+	t.Setenv("FINFOCUS_PLUGIN_SPOT_CACHE_TTL", "30s")
+	ttls, err = New().PluginCacheTTLs()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, ttls["spot"])
+
+	require.NoError(t, cfg.Set("plugins.spot.cache_ttl", "soon"))
+	_, err = cfg.PluginCacheTTLs()
+	require.ErrorContains(t, err, "plugins.spot.cache_ttl must be a positive duration")
+	require.Error(t, cfg.Validate())
+}
+
+func TestConfig_PluginLayers(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	data := "plugins:\n  aws-public:\n    role: base\n  aws-negotiated:\n    role: adjustment\n" +
+		"    adjustment: factor\n    layer_order: 2\n  vantage:\n    token: secret\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(data), 0o600))
+
+	cfg := New()
+	layers, err := cfg.PluginLayers()
+	require.NoError(t, err)
+	assert.Equal(t, []PluginLayer{
+		{Name: "aws-negotiated", Role: PluginRoleAdjustment, Adjustment: AdjustmentFactor, Order: 2},
+		{Name: "aws-public", Role: PluginRoleBase},
+	}, layers)
+	require.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		key, value, wantErr string
+	}{
+		{"plugins.vantage.role", "overlay", "plugins.vantage.role must be base or adjustment"},
+		{"plugins.aws-public.adjustment", "delta", "must be delta or factor on an adjustment plugin"},
+		{"plugins.aws-negotiated.layer_order", "first", "plugins.aws-negotiated.layer_order must be an integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			cfg := New()
+			require.NoError(t, cfg.Set(tt.key, tt.value))
+			_, err := cfg.PluginLayers()
+			require.ErrorContains(t, err, tt.wantErr)
+			require.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestConfig_PricingCacheTTL(t *testing.T) {
+	stubHome(t)
+	cfg := New()
+	assert.Equal(t, time.Hour, cfg.PricingCacheTTL())
+
+	require.NoError(t, cfg.Set("cache.pricing_ttl", "6h"))
+	got, err := cfg.Get("cache.pricing_ttl")
+	require.NoError(t, err)
+	assert.Equal(t, "6h0m0s", got)
+	require.Error(t, cfg.Set("cache.pricing_ttl", "0s"))
+	require.Error(t, cfg.Set("cache.other", "1h"))
+
+	t.Setenv("FINFOCUS_PRICING_CACHE_TTL", "15m")
+	assert.Equal(t, 15*time.Minute, New().PricingCacheTTL())
+}
+
+func TestConfig_RecommendationsSuppress(t *testing.T) {
+	cfg := &Config{}
+
+	require.NoError(t, cfg.Set("recommendations.suppress", "rec-1, type:RIGHTSIZE on web ,"))
+	assert.Equal(t, []string{"rec-1", "type:RIGHTSIZE on web"}, cfg.Recommendations.Suppress)
+
+	got, err := cfg.Get("recommendations.suppress")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rec-1", "type:RIGHTSIZE on web"}, got)
+
+	assert.Error(t, cfg.Set("recommendations.other", "x"))
+	_, err = cfg.Get("recommendations.other")
+	assert.Error(t, err)
+}
+
+func TestConfig_Anomalies(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	cfg := New()
+	require.NoError(t, cfg.Set("anomalies.threshold", "0.5"))
+	require.NoError(t, cfg.Set("anomalies.webhook_url", "https://hooks.example.com/finfocus"))
+	require.NoError(t, cfg.Set("anomalies.min_severity", "medium"))
+	assert.Equal(t, AnomaliesConfig{
+		Threshold: 0.5, WebhookURL: "https://hooks.example.com/finfocus", MinSeverity: "medium",
+	}, cfg.Anomalies)
+	got, err := cfg.Get("anomalies.threshold")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, got, 0.0001)
+	assert.Equal(t, cfg.Anomalies, cfg.List()["anomalies"])
+	require.NoError(t, cfg.Validate())
+
+	require.Error(t, cfg.Set("anomalies.threshold", "-1"))
+	require.Error(t, cfg.Set("anomalies.webhook_url", "hooks.example.com"))
+	require.Error(t, cfg.Set("anomalies.min_severity", "urgent"))
+	require.Error(t, cfg.Set("anomalies.window", "7"))
+	_, err = cfg.Get("anomalies.window")
+	require.Error(t, err)
+
+	cfg.Anomalies.MinSeverity = "urgent"
+	require.Error(t, cfg.Validate())
+}
+
+func TestConfig_Sandbox(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	cfg := New()
+	assert.False(t, cfg.Sandbox.Enabled)
+	require.NoError(t, cfg.Set("sandbox.enabled", "true"))
+	require.NoError(t, cfg.Set("sandbox.allow_env", "AWS_*, AZURE_TENANT_ID"))
+	assert.Equal(t, SandboxConfig{Enabled: true, AllowEnv: []string{"AWS_*", "AZURE_TENANT_ID"}}, cfg.Sandbox)
+	got, err := cfg.Get("sandbox.enabled")
+	require.NoError(t, err)
+	assert.Equal(t, true, got)
+	assert.Equal(t, cfg.Sandbox, cfg.List()["sandbox"])
+
+	require.Error(t, cfg.Set("sandbox.enabled", "maybe"))
+	require.Error(t, cfg.Set("sandbox.network", "false"))
+	_, err = cfg.Get("sandbox.network")
+	require.Error(t, err)
+
+	t.Setenv("FINFOCUS_PLUGIN_SANDBOX", "true")
+	assert.True(t, New().Sandbox.Enabled)
+}
+
+func TestConfig_Engine(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	cfg := New()
+	assert.Equal(t, EngineConfig{}, cfg.Engine)
+	require.NoError(t, cfg.Set("engine.workers", "8"))
+	require.NoError(t, cfg.Set("engine.actual_cost_workers", "2"))
+	require.NoError(t, cfg.Set("engine.actual_cost_rate_limit", "2.5"))
+	require.NoError(t, cfg.Set("engine.plugin_concurrency", "1"))
+	require.NoError(t, cfg.Set("engine.disable_response_cache", "true"))
+	require.NoError(t, cfg.Set("engine.hours_per_month", "720"))
+	require.NoError(t, cfg.Set("engine.retry_attempts", "5"))
+	require.NoError(t, cfg.Set("engine.retry_base_delay", "250ms"))
+	require.NoError(t, cfg.Set("engine.zero_cost_types", "my-components:*, acme:index:Wrapper,"))
+	assert.Equal(t, EngineConfig{
+		Workers: 8, ActualCostWorkers: 2, ActualCostRateLimit: 2.5, PluginConcurrency: 1, DisableResponseCache: true, HoursPerMonth: 720,
+		RetryAttempts: 5, RetryBaseDelay: Duration(250 * time.Millisecond),
+		ZeroCostTypes: []string{"my-components:*", "acme:index:Wrapper"},
+	}, cfg.Engine)
+	got, err := cfg.Get("engine.retry_base_delay")
+	require.NoError(t, err)
+	assert.Equal(t, "250ms", got)
+	assert.Equal(t, cfg.Engine, cfg.List()["engine"])
+	require.NoError(t, cfg.Validate())
+
+	require.Error(t, cfg.Set("engine.workers", "-1"))
+	require.Error(t, cfg.Set("engine.hours_per_month", "NaN"))
+	require.Error(t, cfg.Set("engine.retry_base_delay", "soon"))
+	require.Error(t, cfg.Set("engine.turbo", "true"))
+	_, err = cfg.Get("engine.turbo")
+	require.Error(t, err)
+
+	require.Error(t, cfg.Set("engine.actual_cost_rate_limit", "-1"))
+
+	cfg.Engine.HoursPerMonth = -730
+	require.Error(t, cfg.Validate())
+
+	t.Setenv("FINFOCUS_ACTUAL_COST_CONCURRENCY", "4")
+	t.Setenv("FINFOCUS_ACTUAL_COST_RATE_LIMIT", "0.5")
+	t.Setenv("FINFOCUS_ZERO_COST_TYPES", "my-components:*")
+	fromEnv := New().Engine
+	assert.Equal(t, 4, fromEnv.ActualCostWorkers)
+	assert.InDelta(t, 0.5, fromEnv.ActualCostRateLimit, 0.0001)
+	assert.Equal(t, []string{"my-components:*"}, fromEnv.ZeroCostTypes)
+}
@@ -0,0 +1,144 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Badge colors, in shields.io naming.
+const (
+	badgeColorPassing  = "brightgreen"
+	badgeColorAdvisory = "yellow"
+	badgeColorFailing  = "red"
+)
+
+// badgeHexColors maps badge colors to the shields.io palette used in SVG badges.
+var badgeHexColors = map[string]string{
+	badgeColorPassing:  "#4c1",
+	badgeColorAdvisory: "#dfb317",
+	badgeColorFailing:  "#e05d44",
+}
+
+const (
+	// badgeLabel is the left-hand text of the badge.
+	badgeLabel = "conformance"
+
+	// Approximate layout of 11px Verdana text in a flat badge: each text is inset from
+	// the left edge of its half by badgeTextInset and padded by the same on the right.
+	badgeCharWidth = 7
+	badgeTextInset = 5
+	badgePadding   = 2 * badgeTextInset
+)
+
+// Badge summarizes a conformance run for a README badge or a status check.
+type Badge struct {
+	// Passed is the number of tests that passed.
+	Passed int
+	// Counted is the number of tests that ran; skipped tests are not counted.
+	Counted int
+	// ProtocolVersion is the protocol version the plugin was tested against.
+	ProtocolVersion string
+	// Color is brightgreen when every test passed, yellow when only tests below error
+	// severity failed, and red otherwise.
+	Color string
+}
+
+// Badge returns the badge for the report.
+func (r *SuiteReport) Badge() Badge {
+	badge := Badge{
+		Passed:          r.Summary.Passed,
+		Counted:         r.Summary.Total - r.Summary.Skipped,
+		ProtocolVersion: r.Plugin.ProtocolVersion,
+		Color:           badgeColorPassing,
+	}
+	switch {
+	case len(r.FailingResults(SeverityError)) > 0:
+		badge.Color = badgeColorFailing
+	case badge.Passed < badge.Counted:
+		badge.Color = badgeColorAdvisory
+	}
+	return badge
+}
+
+// Message is the right-hand text of the badge, e.g. "47/50 passing (protocol v1.0)".
+func (b Badge) Message() string {
+	message := fmt.Sprintf("%d/%d passing", b.Passed, b.Counted)
+	if b.ProtocolVersion != "" {
+		message += " (protocol v" + b.ProtocolVersion + ")"
+	}
+	return message
+}
+
+// WriteBadgeJSON writes the report as a shields.io endpoint badge, for use with
+// https://img.shields.io/endpoint?url=<url of the file>.
+func (r *SuiteReport) WriteBadgeJSON(w io.Writer) error {
+	badge := r.Badge()
+	endpoint := struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		Label         string `json:"label"`
+		Message       string `json:"message"`
+		Color         string `json:"color"`
+	}{
+		SchemaVersion: 1,
+		Label:         badgeLabel,
+		Message:       badge.Message(),
+		Color:         badge.Color,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(endpoint)
+}
+
+// WriteBadgeSVG writes the report as a flat SVG badge that can be committed next to a
+// README.
+func (r *SuiteReport) WriteBadgeSVG(w io.Writer) error {
+	badge := r.Badge()
+	message := badge.Message()
+	labelWidth := len(badgeLabel)*badgeCharWidth + badgePadding
+	messageWidth := len(message)*badgeCharWidth + badgePadding
+	width := labelWidth + messageWidth
+	title := html.EscapeString(badgeLabel + ": " + message)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s">`,
+		width, title)
+	fmt.Fprintf(&b, "\n  <title>%s</title>", title)
+	fmt.Fprintf(&b, "\n  <rect width=\"%d\" height=\"20\" rx=\"3\" fill=\"#555\"/>", width)
+	fmt.Fprintf(&b, "\n  <rect x=\"%d\" width=\"%d\" height=\"20\" rx=\"3\" fill=\"%s\"/>",
+		labelWidth, messageWidth, badgeHexColors[badge.Color])
+	b.WriteString("\n  <g fill=\"#fff\" font-family=\"Verdana,Geneva,sans-serif\" font-size=\"11\">")
+	fmt.Fprintf(&b, "\n    <text x=\"%d\" y=\"14\">%s</text>", badgeTextInset, badgeLabel)
+	fmt.Fprintf(&b, "\n    <text x=\"%d\" y=\"14\">%s</text>", labelWidth+badgeTextInset, html.EscapeString(message))
+	b.WriteString("\n  </g>\n</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SummaryLine returns a one-line summary of the run suitable for a status check, e.g.
+// "conformance: 47/50 passing (protocol v1.0), 2 failed, 1 errored, 3 skipped - aws-cost v1.2.0".
+// Timed-out tests are added to the counts when there are any.
+func (r *SuiteReport) SummaryLine() string {
+	line := badgeLabel + ": " + r.Badge().Message()
+	line += fmt.Sprintf(", %d failed, %d errored, %d skipped",
+		r.Summary.Failed, r.Summary.Errors, r.Summary.Skipped)
+	if r.Summary.TimedOut > 0 {
+		line += fmt.Sprintf(", %d timed out", r.Summary.TimedOut)
+	}
+	if r.Plugin.Name != "" {
+		line += " - " + r.Plugin.Name
+		if r.Plugin.Version != "" {
+			line += " v" + r.Plugin.Version
+		}
+	}
+	return line
+}
+
+// WriteSummary writes SummaryLine followed by a newline.
+func (r *SuiteReport) WriteSummary(w io.Writer) error {
+	_, err := fmt.Fprintln(w, r.SummaryLine())
+	return err
+}
@@ -0,0 +1,68 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport_Badge(t *testing.T) {
+	t.Parallel()
+
+	report := createTestReport()
+	badge := report.Badge()
+	assert.Equal(t, 2, badge.Passed)
+	assert.Equal(t, 3, badge.Counted, "skipped tests are not counted")
+	assert.Equal(t, "2/3 passing (protocol v1.0)", badge.Message())
+	assert.Equal(t, badgeColorFailing, badge.Color)
+
+	report.Results[2].Severity = SeverityWarning
+	assert.Equal(t, badgeColorAdvisory, report.Badge().Color, "only advisory failures")
+
+	report.Results[2].Status = StatusPass
+	report.Summary.Passed, report.Summary.Failed = 3, 0
+	assert.Equal(t, badgeColorPassing, report.Badge().Color)
+}
+
+func TestReport_WriteBadgeJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, createTestReport().WriteBadgeJSON(&buf))
+
+	var endpoint map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &endpoint))
+	assert.Equal(t, map[string]interface{}{
+		"schemaVersion": float64(1),
+		"label":         "conformance",
+		"message":       "2/3 passing (protocol v1.0)",
+		"color":         "red",
+	}, endpoint)
+}
+
+func TestReport_WriteBadgeSVG(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, createTestReport().WriteBadgeSVG(&buf))
+
+	svg := buf.String()
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Contains(t, svg, "<title>conformance: 2/3 passing (protocol v1.0)</title>")
+	assert.Contains(t, svg, badgeHexColors[badgeColorFailing])
+}
+
+func TestReport_SummaryLine(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"conformance: 2/3 passing (protocol v1.0), 1 failed, 0 errored, 1 skipped - aws-cost v1.2.0",
+		createTestReport().SummaryLine())
+
+	var buf bytes.Buffer
+	require.NoError(t, createTestReport().WriteSummary(&buf))
+	assert.Equal(t, createTestReport().SummaryLine()+"\n", buf.String())
+}
@@ -0,0 +1,93 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+)
+
+// Latency percentiles reported by benchmark tests.
+const (
+	percentile50 = 50
+	percentile95 = 95
+	percentile99 = 99
+)
+
+// testGetProjectedCostLatency calls GetProjectedCost ctx.BenchIterations times in a row and
+// reports the p50, p95 and p99 latency of the calls along with every sample. It returns
+// StatusFail if any call fails and StatusError if the plugin client has an unexpected type.
+func testGetProjectedCostLatency(ctx *TestContext) *TestResult {
+	client, ok := ctx.PluginClient.(pbc.CostSourceServiceClient)
+	if !ok {
+		return &TestResult{Status: StatusError, Error: "invalid plugin client type"}
+	}
+
+	iterations := ctx.BenchIterations
+	if iterations <= 0 {
+		iterations = DefaultBenchIterations
+	}
+	req := &pbc.GetProjectedCostRequest{
+		Resource: &pbc.ResourceDescriptor{
+			Provider:     "aws",
+			ResourceType: "aws:ec2/instance:Instance",
+			Sku:          "t3.micro",
+			Region:       "us-east-1",
+		},
+	}
+
+	samples := make([]time.Duration, 0, iterations)
+	for i := range iterations {
+		rpcCtx, cancel := context.WithTimeout(context.Background(), ctx.Timeout)
+		start := time.Now()
+		_, err := client.GetProjectedCost(rpcCtx, req)
+		elapsed := time.Since(start)
+		cancel()
+		if err != nil {
+			return &TestResult{
+				Status: StatusFail,
+				Error:  fmt.Sprintf("GetProjectedCost() call %d/%d failed: %v", i+1, iterations, err),
+			}
+		}
+		samples = append(samples, elapsed)
+	}
+
+	stats := newLatencyStats("GetProjectedCost", samples)
+	return &TestResult{
+		Status:  StatusPass,
+		Details: fmt.Sprintf("%d calls: %s", iterations, stats),
+		Latency: stats,
+	}
+}
+
+// newLatencyStats computes the latency percentiles of samples for rpc.
+func newLatencyStats(rpc string, samples []time.Duration) *LatencyStats {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	return &LatencyStats{
+		RPC:     rpc,
+		P50:     latencyPercentile(sorted, percentile50),
+		P95:     latencyPercentile(sorted, percentile95),
+		P99:     latencyPercentile(sorted, percentile99),
+		Samples: samples,
+	}
+}
+
+// latencyPercentile returns the p-th percentile of sorted by the nearest-rank method, or
+// zero when there are no samples.
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted)))) //nolint:mnd // Percent to fraction.
+	return sorted[max(rank, 1)-1]
+}
+
+// String formats the percentiles, e.g. "p50 1.2ms | p95 3.4ms | p99 5ms".
+func (s *LatencyStats) String() string {
+	return fmt.Sprintf("p50 %v | p95 %v | p99 %v", s.P50.Round(time.Microsecond),
+		s.P95.Round(time.Microsecond), s.P99.Round(time.Microsecond))
+}
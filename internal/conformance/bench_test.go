@@ -0,0 +1,75 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeCostClient answers GetProjectedCost, failing from the failAt-th call on when
+// failAt is positive.
+type fakeCostClient struct {
+	pbc.CostSourceServiceClient
+
+	calls  int
+	failAt int
+}
+
+func (f *fakeCostClient) GetProjectedCost(
+	_ context.Context,
+	_ *pbc.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*pbc.GetProjectedCostResponse, error) {
+	f.calls++
+	if f.failAt > 0 && f.calls >= f.failAt {
+		return nil, errors.New("boom")
+	}
+	return &pbc.GetProjectedCostResponse{Currency: "USD"}, nil
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	t.Parallel()
+
+	samples := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := newLatencyStats("GetProjectedCost", samples)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 95*time.Millisecond, stats.P95)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+	assert.Equal(t, 100*time.Millisecond, stats.Samples[0], "samples keep the order of the calls")
+	assert.Equal(t, "p50 50ms | p95 95ms | p99 99ms", stats.String())
+
+	single := newLatencyStats("Name", []time.Duration{time.Millisecond})
+	assert.Equal(t, time.Millisecond, single.P50)
+	assert.Equal(t, time.Millisecond, single.P99)
+	assert.Zero(t, latencyPercentile(nil, percentile50))
+}
+
+func TestGetProjectedCostLatency(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeCostClient{}
+	result := testGetProjectedCostLatency(&TestContext{PluginClient: client, Timeout: time.Second, BenchIterations: 25})
+	assert.Equal(t, StatusPass, result.Status)
+	require.NotNil(t, result.Latency)
+	assert.Equal(t, "GetProjectedCost", result.Latency.RPC)
+	assert.Len(t, result.Latency.Samples, 25)
+	assert.Equal(t, 25, client.calls)
+	assert.LessOrEqual(t, result.Latency.P50, result.Latency.P99)
+
+	result = testGetProjectedCostLatency(&TestContext{
+		PluginClient: &fakeCostClient{failAt: 3}, Timeout: time.Second, BenchIterations: 10,
+	})
+	assert.Equal(t, StatusFail, result.Status)
+	assert.Contains(t, result.Error, "call 3/10 failed")
+	assert.Nil(t, result.Latency)
+}
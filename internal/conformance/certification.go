@@ -19,7 +19,8 @@ type CertificationReport struct {
 // Certify evaluates a SuiteReport and returns a CertificationReport for the specified plugin name and version.
 // It sets CertifiedAt to the current time and copies the suite summary from suiteReport.
 // If the suite summary indicates any failures, Certified is set to false and Issues is populated with entries
-// formatted as "<TestName>: <Error>" for each result whose status is StatusFail or StatusError.
+// formatted as "<TestName>: <Error>" for each result whose status is StatusFail, StatusError or
+// StatusTimeout.
 // If there are no failures, Certified is set to true.
 // The returned *CertificationReport contains the plugin identification, certification status, timestamp,
 // any collected issues, and the suite summary.
@@ -31,11 +32,12 @@ func Certify(suiteReport *SuiteReport, name, version string) *CertificationRepor
 		SuiteSummary:  suiteReport.Summary,
 	}
 
-	// Include both failures and errors in certification check
-	if suiteReport.Summary.Failed > 0 || suiteReport.Summary.Errors > 0 {
+	// Include failures, errors and timeouts in certification check
+	summary := suiteReport.Summary
+	if summary.Failed > 0 || summary.Errors > 0 || summary.TimedOut > 0 {
 		report.Certified = false
 		for _, res := range suiteReport.Results {
-			if res.Status == StatusFail || res.Status == StatusError {
+			if res.Status.failed() {
 				report.Issues = append(report.Issues, fmt.Sprintf("%s: %s", res.TestName, res.Error))
 			}
 		}
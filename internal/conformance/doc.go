@@ -47,6 +47,8 @@
 //   - error: Error handling and gRPC status codes
 //   - performance: Timeout behavior and batch handling
 //   - context: Context cancellation and deadline propagation
+//   - recommendations: GetRecommendations response shape, pagination and empty
+//     results; skipped for plugins that do not implement the RPC
 //
 // # Output Formats
 //
@@ -0,0 +1,172 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recommendationsPageSize is the page size requested by the recommendations tests. One
+// recommendation per page makes any plugin with two or more recommendations paginate.
+const recommendationsPageSize = 1
+
+// recommendationsTarget is the resource the recommendations tests ask about, the same
+// t3.micro instance the projected cost tests price.
+func recommendationsTarget() *pbc.ResourceDescriptor {
+	return &pbc.ResourceDescriptor{
+		Provider:     "aws",
+		ResourceType: "aws:ec2/instance:Instance",
+		Sku:          "t3.micro",
+		Region:       "us-east-1",
+	}
+}
+
+// getRecommendations calls GetRecommendations with the test's timeout. It returns a skip
+// result when the plugin does not implement the RPC, since recommendations are optional,
+// and a failure result for any other error.
+func getRecommendations(
+	ctx *TestContext,
+	req *pbc.GetRecommendationsRequest,
+) (*pbc.GetRecommendationsResponse, *TestResult) {
+	client, ok := ctx.PluginClient.(pbc.CostSourceServiceClient)
+	if !ok {
+		return nil, &TestResult{Status: StatusError, Error: "invalid plugin client type"}
+	}
+
+	rpcCtx, cancel := context.WithTimeout(context.Background(), ctx.Timeout)
+	defer cancel()
+
+	resp, err := client.GetRecommendations(rpcCtx, req)
+	if status.Code(err) == codes.Unimplemented {
+		return nil, &TestResult{Status: StatusSkip, Error: "GetRecommendations not implemented"}
+	}
+	if err != nil {
+		return nil, &TestResult{
+			Status: StatusFail,
+			Error:  fmt.Sprintf("GetRecommendations() RPC failed: %v", err),
+		}
+	}
+	return resp, nil
+}
+
+// checkRecommendations returns a description of the first malformed recommendation in
+// recs, or "" when every one has a unique ID and non-negative estimated savings in a
+// named currency.
+func checkRecommendations(recs []*pbc.Recommendation) string {
+	seen := make(map[string]bool, len(recs))
+	for i, rec := range recs {
+		switch {
+		case rec.GetId() == "":
+			return fmt.Sprintf("recommendation %d has no ID", i)
+		case seen[rec.GetId()]:
+			return fmt.Sprintf("recommendation ID %q appears more than once", rec.GetId())
+		case rec.GetImpact().GetEstimatedSavings() < 0:
+			return fmt.Sprintf("recommendation %q has negative estimated savings", rec.GetId())
+		case rec.GetImpact() != nil && rec.GetImpact().GetCurrency() == "":
+			return fmt.Sprintf("recommendation %q impact is missing currency", rec.GetId())
+		}
+		seen[rec.GetId()] = true
+	}
+	return ""
+}
+
+// testGetRecommendationsResponseShape asks for recommendations for a target resource, one
+// per page, and verifies the page holds no more than that and every recommendation is
+// well formed. It returns StatusSkip if the plugin does not implement the RPC.
+func testGetRecommendationsResponseShape(ctx *TestContext) *TestResult {
+	resp, result := getRecommendations(ctx, &pbc.GetRecommendationsRequest{
+		TargetResources: []*pbc.ResourceDescriptor{recommendationsTarget()},
+		PageSize:        recommendationsPageSize,
+	})
+	if result != nil {
+		return result
+	}
+
+	recs := resp.GetRecommendations()
+	if len(recs) > recommendationsPageSize {
+		return &TestResult{
+			Status: StatusFail,
+			Error:  fmt.Sprintf("page size %d ignored: got %d recommendations", recommendationsPageSize, len(recs)),
+		}
+	}
+	if problem := checkRecommendations(recs); problem != "" {
+		return &TestResult{Status: StatusFail, Error: problem}
+	}
+
+	return &TestResult{
+		Status:  StatusPass,
+		Details: fmt.Sprintf("Received %d recommendations", len(recs)),
+	}
+}
+
+// testGetRecommendationsPagination fetches two pages of recommendations, passing the first
+// page's NextPageToken back for the second, and verifies the second page succeeds without
+// repeating recommendations from the first. A plugin whose recommendations fit on one page
+// passes without the second request. It returns StatusSkip if the plugin does not
+// implement the RPC.
+func testGetRecommendationsPagination(ctx *TestContext) *TestResult {
+	first, result := getRecommendations(ctx, &pbc.GetRecommendationsRequest{
+		PageSize: recommendationsPageSize,
+	})
+	if result != nil {
+		return result
+	}
+	if first.GetNextPageToken() == "" {
+		return &TestResult{Status: StatusPass, Details: "All recommendations fit on one page"}
+	}
+
+	second, result := getRecommendations(ctx, &pbc.GetRecommendationsRequest{
+		PageSize:  recommendationsPageSize,
+		PageToken: first.GetNextPageToken(),
+	})
+	if result != nil {
+		result.Error = "second page: " + result.Error
+		return result
+	}
+	if len(second.GetRecommendations()) == 0 {
+		return &TestResult{Status: StatusFail, Error: "NextPageToken was set but the next page is empty"}
+	}
+	if second.GetNextPageToken() == first.GetNextPageToken() {
+		return &TestResult{Status: StatusFail, Error: "second page returned the same NextPageToken as the first"}
+	}
+	if problem := checkRecommendations(
+		slices.Concat(first.GetRecommendations(), second.GetRecommendations()),
+	); problem != "" {
+		return &TestResult{Status: StatusFail, Error: "across pages: " + problem}
+	}
+
+	return &TestResult{Status: StatusPass, Details: "NextPageToken returned the next page"}
+}
+
+// testGetRecommendationsEmpty asks for recommendations for a resource type no plugin
+// supports and verifies the plugin answers with an empty list and no next page rather
+// than an error.
+func testGetRecommendationsEmpty(ctx *TestContext) *TestResult {
+	resp, result := getRecommendations(ctx, &pbc.GetRecommendationsRequest{
+		TargetResources: []*pbc.ResourceDescriptor{{
+			Provider:     "conformance",
+			ResourceType: "conformance:none/none:None",
+			Region:       "none",
+		}},
+		PageSize: recommendationsPageSize,
+	})
+	if result != nil {
+		return result
+	}
+
+	if n := len(resp.GetRecommendations()); n > 0 {
+		return &TestResult{
+			Status: StatusFail,
+			Error:  fmt.Sprintf("expected no recommendations for an unknown resource, got %d", n),
+		}
+	}
+	if resp.GetNextPageToken() != "" {
+		return &TestResult{Status: StatusFail, Error: "empty result has a NextPageToken"}
+	}
+
+	return &TestResult{Status: StatusPass, Details: "Empty result returned"}
+}
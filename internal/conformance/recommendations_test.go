@@ -0,0 +1,121 @@
+package conformance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRecommendationsClient serves recs one page at a time, with page tokens holding the
+// index of the page's first recommendation. Only GetRecommendations is implemented.
+type fakeRecommendationsClient struct {
+	pbc.CostSourceServiceClient
+
+	recs []*pbc.Recommendation
+	err  error
+	// ignorePageToken serves the first page for every request.
+	ignorePageToken bool
+}
+
+func (f *fakeRecommendationsClient) GetRecommendations(
+	_ context.Context,
+	req *pbc.GetRecommendationsRequest,
+	_ ...grpc.CallOption,
+) (*pbc.GetRecommendationsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(req.GetTargetResources()) > 0 && req.GetTargetResources()[0].GetProvider() != "aws" {
+		return &pbc.GetRecommendationsResponse{}, nil
+	}
+	start, _ := strconv.Atoi(req.GetPageToken())
+	if f.ignorePageToken {
+		start = 0
+	}
+	end := min(start+int(req.GetPageSize()), len(f.recs))
+	resp := &pbc.GetRecommendationsResponse{Recommendations: f.recs[start:end]}
+	if end < len(f.recs) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func testRecommendation(id string) *pbc.Recommendation {
+	return &pbc.Recommendation{
+		Id:     id,
+		Impact: &pbc.RecommendationImpact{EstimatedSavings: 10, Currency: "USD"},
+	}
+}
+
+func TestRecommendationsTests(t *testing.T) {
+	t.Parallel()
+
+	twoPages := []*pbc.Recommendation{testRecommendation("rec-1"), testRecommendation("rec-2")}
+	tests := []struct {
+		name       string
+		client     *fakeRecommendationsClient
+		shape      Status
+		pagination Status
+		empty      Status
+	}{
+		{
+			name:       "paginated",
+			client:     &fakeRecommendationsClient{recs: twoPages},
+			shape:      StatusPass,
+			pagination: StatusPass,
+			empty:      StatusPass,
+		},
+		{
+			name:       "no recommendations",
+			client:     &fakeRecommendationsClient{},
+			shape:      StatusPass,
+			pagination: StatusPass,
+			empty:      StatusPass,
+		},
+		{
+			name:       "page token ignored",
+			client:     &fakeRecommendationsClient{recs: twoPages, ignorePageToken: true},
+			shape:      StatusPass,
+			pagination: StatusFail,
+			empty:      StatusPass,
+		},
+		{
+			name:       "missing ID",
+			client:     &fakeRecommendationsClient{recs: []*pbc.Recommendation{testRecommendation("")}},
+			shape:      StatusFail,
+			pagination: StatusPass,
+			empty:      StatusPass,
+		},
+		{
+			name:       "unimplemented",
+			client:     &fakeRecommendationsClient{err: status.Error(codes.Unimplemented, "not implemented")},
+			shape:      StatusSkip,
+			pagination: StatusSkip,
+			empty:      StatusSkip,
+		},
+		{
+			name:       "internal error",
+			client:     &fakeRecommendationsClient{err: status.Error(codes.Internal, "boom")},
+			shape:      StatusFail,
+			pagination: StatusFail,
+			empty:      StatusFail,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := &TestContext{PluginClient: tc.client, Timeout: time.Second}
+			assert.Equal(t, tc.shape, testGetRecommendationsResponseShape(ctx).Status, "response shape")
+			assert.Equal(t, tc.pagination, testGetRecommendationsPagination(ctx).Status, "pagination")
+			assert.Equal(t, tc.empty, testGetRecommendationsEmpty(ctx).Status, "empty result")
+		})
+	}
+}
@@ -51,10 +51,17 @@ func (r *SuiteReport) WriteTable(w io.Writer) error {
 		statusIcon := getStatusIcon(result.Status)
 		duration := formatDuration(result.Duration)
 
-		fprintf("%s %-45s [%7s]\n", statusIcon, result.TestName, duration)
+		if result.Status.failed() {
+			fprintf("%s %-45s [%7s] %s\n", statusIcon, result.TestName, duration,
+				strings.ToUpper(string(result.severity())))
+		} else {
+			fprintf("%s %-45s [%7s]\n", statusIcon, result.TestName, duration)
+		}
 
-		// Show error message for failed/error tests
-		if result.Error != "" && (result.Status == StatusFail || result.Status == StatusError) {
+		// Show error message for failed/error tests, and timeouts apart from protocol failures
+		if result.Error != "" && result.Status == StatusTimeout {
+			fprintf("  Timeout: %s\n", result.Error)
+		} else if result.Error != "" && result.Status.failed() {
 			fprintf("  Error: %s\n", result.Error)
 		}
 
@@ -62,6 +69,11 @@ func (r *SuiteReport) WriteTable(w io.Writer) error {
 		if result.Status == StatusSkip && result.Error != "" {
 			fprintf("  (%s)\n", result.Error)
 		}
+
+		// Show benchmark latency
+		if result.Latency != nil {
+			fprintf("  Latency: %s (%d %s calls)\n", result.Latency, len(result.Latency.Samples), result.Latency.RPC)
+		}
 	}
 
 	fprintln()
@@ -77,6 +89,17 @@ func (r *SuiteReport) WriteTable(w io.Writer) error {
 		fprintf("Errors: %d\n", r.Summary.Errors)
 	}
 
+	if r.Summary.TimedOut > 0 {
+		fprintf("Timed out: %d (raise the test timeout if the plugin is only slow)\n", r.Summary.TimedOut)
+	}
+
+	if len(r.Summary.FailuresBySeverity) > 0 {
+		fprintf("By severity: error %d | warning %d | info %d\n",
+			r.Summary.FailuresBySeverity[SeverityError],
+			r.Summary.FailuresBySeverity[SeverityWarning],
+			r.Summary.FailuresBySeverity[SeverityInfo])
+	}
+
 	return writeErr
 }
 
@@ -84,13 +107,23 @@ func (r *SuiteReport) WriteTable(w io.Writer) error {
 // This implements FR-016: Machine-readable JSON format for programmatic access.
 func (r *SuiteReport) WriteJSON(w io.Writer) error {
 	// Create a custom struct for JSON output with proper duration formatting
+	type jsonLatency struct {
+		RPC       string    `json:"rpc"`
+		P50MS     float64   `json:"p50_ms"`
+		P95MS     float64   `json:"p95_ms"`
+		P99MS     float64   `json:"p99_ms"`
+		SamplesMS []float64 `json:"samples_ms"`
+	}
+
 	type jsonResult struct {
-		Name       string   `json:"name"`
-		Category   Category `json:"category"`
-		Status     Status   `json:"status"`
-		DurationMS int64    `json:"duration_ms"`
-		Error      string   `json:"error,omitempty"`
-		Details    string   `json:"details,omitempty"`
+		Name       string       `json:"name"`
+		Category   Category     `json:"category"`
+		Status     Status       `json:"status"`
+		Severity   Severity     `json:"severity"`
+		DurationMS int64        `json:"duration_ms"`
+		Error      string       `json:"error,omitempty"`
+		Details    string       `json:"details,omitempty"`
+		Latency    *jsonLatency `json:"latency,omitempty"`
 	}
 
 	type jsonReport struct {
@@ -108,10 +141,25 @@ func (r *SuiteReport) WriteJSON(w io.Writer) error {
 			Name:       res.TestName,
 			Category:   res.Category,
 			Status:     res.Status,
+			Severity:   res.severity(),
 			DurationMS: res.Duration.Milliseconds(),
 			Error:      res.Error,
 			Details:    res.Details,
 		}
+		// Latencies are fractional milliseconds, keeping the precision of fast RPCs.
+		if lat := res.Latency; lat != nil {
+			samples := make([]float64, len(lat.Samples))
+			for j, sample := range lat.Samples {
+				samples[j] = durationMS(sample)
+			}
+			results[i].Latency = &jsonLatency{
+				RPC:       lat.RPC,
+				P50MS:     durationMS(lat.P50),
+				P95MS:     durationMS(lat.P95),
+				P99MS:     durationMS(lat.P99),
+				SamplesMS: samples,
+			}
+		}
 	}
 
 	report := jsonReport{
@@ -148,6 +196,13 @@ type (
 		Content string   `xml:",chardata"`
 	}
 
+	junitError struct {
+		XMLName xml.Name `xml:"error"`
+		Message string   `xml:"message,attr"`
+		Type    string   `xml:"type,attr"`
+		Content string   `xml:",chardata"`
+	}
+
 	junitSkipped struct {
 		XMLName xml.Name `xml:"skipped"`
 		Message string   `xml:"message,attr,omitempty"`
@@ -159,7 +214,9 @@ type (
 		Classname string        `xml:"classname,attr"`
 		Time      string        `xml:"time,attr"`
 		Failure   *junitFailure `xml:"failure,omitempty"`
+		Error     *junitError   `xml:"error,omitempty"`
 		Skipped   *junitSkipped `xml:"skipped,omitempty"`
+		SystemOut string        `xml:"system-out,omitempty"`
 	}
 
 	junitTestsuite struct {
@@ -167,6 +224,7 @@ type (
 		Name       string          `xml:"name,attr"`
 		Tests      int             `xml:"tests,attr"`
 		Failures   int             `xml:"failures,attr"`
+		Errors     int             `xml:"errors,attr"`
 		Skipped    int             `xml:"skipped,attr"`
 		Time       string          `xml:"time,attr"`
 		Timestamp  string          `xml:"timestamp,attr"`
@@ -179,6 +237,7 @@ type (
 		Name      string           `xml:"name,attr"`
 		Tests     int              `xml:"tests,attr"`
 		Failures  int              `xml:"failures,attr"`
+		Errors    int              `xml:"errors,attr"`
 		Skipped   int              `xml:"skipped,attr"`
 		Time      string           `xml:"time,attr"`
 		Testsuite []junitTestsuite `xml:"testsuite"`
@@ -209,6 +268,10 @@ func (r *SuiteReport) buildJUnitTestcases() []junitTestcase {
 			Classname: string(res.Category),
 			Time:      fmt.Sprintf("%.2f", res.Duration.Seconds()),
 		}
+		if res.Latency != nil {
+			tc.SystemOut = fmt.Sprintf("%s latency over %d calls: %s",
+				res.Latency.RPC, len(res.Latency.Samples), res.Latency)
+		}
 
 		switch res.Status {
 		case StatusFail, StatusError:
@@ -217,6 +280,14 @@ func (r *SuiteReport) buildJUnitTestcases() []junitTestcase {
 				Type:    "AssertionError",
 				Content: res.Error,
 			}
+		case StatusTimeout:
+			// JUnit errors are unexpected problems rather than failed assertions, which
+			// keeps slow plugins apart from protocol violations in CI reports.
+			tc.Error = &junitError{
+				Message: res.Error,
+				Type:    "Timeout",
+				Content: res.Error,
+			}
 		case StatusSkip:
 			tc.Skipped = &junitSkipped{
 				Message: res.Error,
@@ -236,6 +307,7 @@ func (r *SuiteReport) buildJUnitOutput(testcases []junitTestcase) junitTestsuite
 		Name:     "finfocus-conformance",
 		Tests:    r.Summary.Total,
 		Failures: r.Summary.Failed + r.Summary.Errors,
+		Errors:   r.Summary.TimedOut,
 		Skipped:  r.Summary.Skipped,
 		Time:     fmt.Sprintf("%.1f", r.TotalTime.Seconds()),
 		Testsuite: []junitTestsuite{
@@ -243,6 +315,7 @@ func (r *SuiteReport) buildJUnitOutput(testcases []junitTestcase) junitTestsuite
 				Name:      r.SuiteName,
 				Tests:     r.Summary.Total,
 				Failures:  r.Summary.Failed + r.Summary.Errors,
+				Errors:    r.Summary.TimedOut,
 				Skipped:   r.Summary.Skipped,
 				Time:      fmt.Sprintf("%.1f", r.TotalTime.Seconds()),
 				Timestamp: r.Timestamp.Format(time.RFC3339),
@@ -270,6 +343,8 @@ func getStatusIcon(status Status) string {
 		return "⊘"
 	case StatusError:
 		return "!"
+	case StatusTimeout:
+		return "⏱"
 	default:
 		return "?"
 	}
@@ -286,6 +361,11 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%5dms", d.Milliseconds())
 }
 
+// durationMS converts d to fractional milliseconds.
+func durationMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 // formatTotalDuration formats the total suite duration.
 func formatTotalDuration(d time.Duration) string {
 	if d >= time.Minute {
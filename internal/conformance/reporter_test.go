@@ -348,3 +348,97 @@ func TestReport_WriteTable_AllStatusTypes(t *testing.T) {
 	assert.Contains(t, output, "⊘") // Skip
 	assert.Contains(t, output, "!") // Error
 }
+
+func TestReport_WriteTable_Severities(t *testing.T) {
+	t.Parallel()
+
+	results := []TestResult{
+		{TestName: "ProtocolTest", Status: StatusFail, Severity: SeverityError, Error: "bad"},
+		{TestName: "PerfTest", Status: StatusFail, Severity: SeverityWarning, Error: "slow"},
+		{TestName: "PassTest", Status: StatusPass, Severity: SeverityError},
+	}
+	report := &SuiteReport{
+		SuiteName: "conformance",
+		Results:   results,
+		Summary:   calculateSummary(results),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteTable(&buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "WARNING")
+	assert.Contains(t, output, "By severity: error 1 | warning 1 | info 0")
+
+	buf.Reset()
+	require.NoError(t, report.WriteJSON(&buf))
+	assert.Contains(t, buf.String(), `"severity": "warning"`)
+}
+
+func TestReport_Timeouts(t *testing.T) {
+	t.Parallel()
+
+	results := []TestResult{
+		{TestName: "SlowTest", Status: StatusTimeout, Category: CategoryPerformance, Error: "timeout after 10s"},
+		{TestName: "FailTest", Status: StatusFail, Category: CategoryProtocol, Error: "bad"},
+		{TestName: "PassTest", Status: StatusPass, Category: CategoryProtocol},
+	}
+	report := &SuiteReport{SuiteName: "conformance", Results: results, Summary: calculateSummary(results)}
+	assert.Equal(t, 1, report.Summary.TimedOut)
+	assert.Equal(t, 1, report.Summary.Failed)
+	assert.Len(t, report.FailingResults(SeverityError), 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteTable(&buf))
+	assert.Contains(t, buf.String(), "Timeout: timeout after 10s")
+	assert.Contains(t, buf.String(), "Timed out: 1")
+
+	buf.Reset()
+	require.NoError(t, report.WriteJSON(&buf))
+	assert.Contains(t, buf.String(), `"status": "timeout"`)
+	assert.Contains(t, buf.String(), `"timed_out": 1`)
+
+	buf.Reset()
+	require.NoError(t, report.WriteJUnit(&buf))
+	assert.Contains(t, buf.String(), `<error message="timeout after 10s" type="Timeout">`)
+	assert.Contains(t, buf.String(), `failures="1" errors="1"`)
+}
+
+func TestReport_Latency(t *testing.T) {
+	t.Parallel()
+
+	stats := newLatencyStats("GetProjectedCost", []time.Duration{
+		1500 * time.Microsecond, 2 * time.Millisecond, 4 * time.Millisecond,
+	})
+	report := &SuiteReport{
+		SuiteName: "conformance",
+		Results: []TestResult{
+			{TestName: "GetProjectedCost_Latency", Status: StatusPass, Category: CategoryPerformance, Latency: stats},
+		},
+		Summary: Summary{Total: 1, Passed: 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteTable(&buf))
+	assert.Contains(t, buf.String(), "Latency: p50 2ms | p95 4ms | p99 4ms (3 GetProjectedCost calls)")
+
+	buf.Reset()
+	require.NoError(t, report.WriteJSON(&buf))
+	var parsed struct {
+		Results []struct {
+			Latency struct {
+				P50MS     float64   `json:"p50_ms"`
+				SamplesMS []float64 `json:"samples_ms"`
+			} `json:"latency"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	require.Len(t, parsed.Results, 1)
+	assert.InDelta(t, 2.0, parsed.Results[0].Latency.P50MS, 1e-9)
+	assert.Equal(t, []float64{1.5, 2, 4}, parsed.Results[0].Latency.SamplesMS)
+
+	buf.Reset()
+	require.NoError(t, report.WriteJUnit(&buf))
+	assert.Contains(t, buf.String(),
+		"<system-out>GetProjectedCost latency over 3 calls: p50 2ms | p95 4ms | p99 4ms</system-out>")
+}
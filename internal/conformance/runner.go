@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
 )
 
 // Runner executes individual conformance test cases.
 type Runner struct {
 	logger    zerolog.Logger
 	verbosity Verbosity
+	// benchIterations is the number of calls latency benchmarks make; DefaultBenchIterations
+	// when not positive.
+	benchIterations int
 }
 
 // NewRunner creates a new test runner with the given logger and verbosity.
@@ -24,11 +29,14 @@ func NewRunner(logger zerolog.Logger, verbosity Verbosity) *Runner {
 }
 
 // RunTest executes a single test case and returns the result.
-// It handles timeout, panic recovery, and result recording.
+// It handles timeout, panic recovery, and result recording. A test that runs past its
+// timeout, or whose RPC fails because its deadline passed, gets StatusTimeout rather than
+// a failure, with the configured timeout in the message.
 func (r *Runner) RunTest(ctx context.Context, tc TestCase, client interface{}) *TestResult {
 	result := &TestResult{
 		TestName:  tc.Name,
 		Category:  tc.Category,
+		Severity:  tc.severity(),
 		Timestamp: time.Now(),
 	}
 
@@ -48,12 +56,18 @@ func (r *Runner) RunTest(ctx context.Context, tc TestCase, client interface{}) *
 	testCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	benchIterations := r.benchIterations
+	if benchIterations <= 0 {
+		benchIterations = DefaultBenchIterations
+	}
+
 	// Create test context
 	tctx := &TestContext{
-		PluginClient: client,
-		Logger:       r.logger,
-		Verbosity:    r.verbosity,
-		Timeout:      timeout,
+		PluginClient:    client,
+		Logger:          r.logger,
+		Verbosity:       r.verbosity,
+		Timeout:         timeout,
+		BenchIterations: benchIterations,
 	}
 
 	// Record start time
@@ -87,6 +101,10 @@ func (r *Runner) RunTest(ctx context.Context, tc TestCase, client interface{}) *
 	case testResult := <-resultChan:
 		if testResult != nil {
 			result = testResult
+			if result.Status.failed() && isDeadlineExceeded(result.Error) {
+				result.Status = StatusTimeout
+				result.Error = timeoutMessage(timeout, result.Error)
+			}
 		} else {
 			result.Status = StatusError
 			result.Error = "test returned nil result"
@@ -98,13 +116,14 @@ func (r *Runner) RunTest(ctx context.Context, tc TestCase, client interface{}) *
 			result.Error = "context cancelled"
 		} else {
 			// Test timeout
-			result.Status = StatusError
-			result.Error = fmt.Sprintf("timeout after %v", timeout)
+			result.Status = StatusTimeout
+			result.Error = timeoutMessage(timeout, "")
 		}
 	}
 
-	// Record duration
+	// Record duration and severity (the test function may have returned a new result)
 	result.Duration = time.Since(startTime)
+	result.Severity = tc.severity()
 	result.Timestamp = time.Now()
 
 	// Log result based on verbosity
@@ -124,6 +143,8 @@ func (r *Runner) logResult(_ TestCase, result *TestResult) {
 		event = r.logger.Warn()
 	case StatusError:
 		event = r.logger.Error()
+	case StatusTimeout:
+		event = r.logger.Warn()
 	case StatusSkip:
 		event = r.logger.Info()
 	}
@@ -160,6 +181,7 @@ func (r *Runner) RunTests(ctx context.Context, tests []TestCase, connectFn Conne
 			results = append(results, TestResult{
 				TestName:  tc.Name,
 				Category:  tc.Category,
+				Severity:  tc.severity(),
 				Status:    StatusError,
 				Error:     fmt.Sprintf("failed to connect to plugin: %v", err),
 				Timestamp: time.Now(),
@@ -182,6 +204,7 @@ func (r *Runner) RunTests(ctx context.Context, tests []TestCase, connectFn Conne
 				results = append(results, TestResult{
 					TestName:  tests[i].Name,
 					Category:  tests[i].Category,
+					Severity:  tests[i].severity(),
 					Status:    StatusSkip,
 					Error:     "context cancelled",
 					Timestamp: time.Now(),
@@ -212,6 +235,7 @@ func (r *Runner) RunTests(ctx context.Context, tests []TestCase, connectFn Conne
 					results = append(results, TestResult{
 						TestName:  tests[i].Name,
 						Category:  tests[i].Category,
+						Severity:  tests[i].severity(),
 						Status:    StatusError,
 						Error:     fmt.Sprintf("plugin crashed and failed to restart: %v", err),
 						Timestamp: time.Now(),
@@ -225,6 +249,106 @@ func (r *Runner) RunTests(ctx context.Context, tests []TestCase, connectFn Conne
 	return results
 }
 
+// RunTestsParallel executes test cases like RunTests, but runs up to parallelism test
+// cases at once against a single plugin connection. Tests marked Serial run afterwards,
+// one at a time on a fresh connection with RunTests' restart handling, so a plugin that
+// crashed while tests ran concurrently is restarted for them. Results are returned in
+// the order of tests whatever order they finish in. A parallelism of 1 or less runs every
+// test with RunTests.
+func (r *Runner) RunTestsParallel(
+	ctx context.Context,
+	tests []TestCase,
+	connectFn ConnectFunc,
+	parallelism int,
+) []TestResult {
+	if parallelism <= 1 {
+		return r.RunTests(ctx, tests, connectFn)
+	}
+
+	var concurrent, serial []int
+	for i, tc := range tests {
+		if tc.Serial {
+			serial = append(serial, i)
+		} else {
+			concurrent = append(concurrent, i)
+		}
+	}
+
+	results := make([]TestResult, len(tests))
+	if len(concurrent) > 0 {
+		r.runConcurrently(ctx, tests, concurrent, connectFn, parallelism, results)
+	}
+
+	serialTests := make([]TestCase, len(serial))
+	for j, i := range serial {
+		serialTests[j] = tests[i]
+	}
+	for j, result := range r.RunTests(ctx, serialTests, connectFn) {
+		results[serial[j]] = result
+	}
+	return results
+}
+
+// runConcurrently runs the tests at indexes on one plugin connection with up to
+// parallelism workers, storing each result at its test's index in results. Tests that
+// have not started when ctx is cancelled are skipped.
+func (r *Runner) runConcurrently(
+	ctx context.Context,
+	tests []TestCase,
+	indexes []int,
+	connectFn ConnectFunc,
+	parallelism int,
+	results []TestResult,
+) {
+	client, closeFn, err := connectFn(ctx)
+	if err != nil {
+		for _, i := range indexes {
+			results[i] = TestResult{
+				TestName:  tests[i].Name,
+				Category:  tests[i].Category,
+				Severity:  tests[i].severity(),
+				Status:    StatusError,
+				Error:     fmt.Sprintf("failed to connect to plugin: %v", err),
+				Timestamp: time.Now(),
+			}
+		}
+		return
+	}
+	defer func() {
+		if closeFn != nil {
+			_ = closeFn()
+		}
+	}()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parallelism, len(indexes)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					results[i] = TestResult{
+						TestName:  tests[i].Name,
+						Category:  tests[i].Category,
+						Severity:  tests[i].severity(),
+						Status:    StatusSkip,
+						Error:     "context cancelled",
+						Timestamp: time.Now(),
+					}
+					continue
+				}
+				results[i] = *r.RunTest(ctx, tests[i], client)
+			}
+		}()
+	}
+	for _, i := range indexes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
 // isCrash determines if a test result indicates a plugin crash.
 // It uses string-based heuristics because the original gRPC error is converted
 // to a string in TestResult.Error, and status.FromError cannot recover
@@ -258,3 +382,30 @@ func (r *Runner) isCrash(result *TestResult) bool {
 
 	return false
 }
+
+// isDeadlineExceeded reports whether a test's error message comes from a deadline that
+// passed, either a context error or a gRPC DeadlineExceeded status. Like isCrash it
+// matches strings because results carry the error as text.
+func isDeadlineExceeded(errorStr string) bool {
+	return strings.Contains(errorStr, context.DeadlineExceeded.Error()) ||
+		strings.Contains(errorStr, "code = "+codes.DeadlineExceeded.String())
+}
+
+// timeoutMessage describes a test that exceeded timeout, keeping the error it returned,
+// if any, so that a slow plugin is told apart from a broken one.
+func timeoutMessage(timeout time.Duration, cause string) string {
+	msg := fmt.Sprintf("timeout after %v; the plugin may be too slow rather than non-conformant, "+
+		"consider raising the timeout", timeout)
+	if cause != "" {
+		msg += ": " + cause
+	}
+	return msg
+}
+
+// severity returns the test's severity, defaulting to SeverityError.
+func (tc TestCase) severity() Severity {
+	if tc.Severity == "" {
+		return SeverityError
+	}
+	return tc.Severity
+}
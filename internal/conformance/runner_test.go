@@ -2,6 +2,9 @@ package conformance
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -137,9 +140,32 @@ func TestRunner_RunTest_Timeout(t *testing.T) {
 
 	require.NotNil(t, result)
 	assert.Equal(t, "TestTimeout", result.TestName)
-	// The test should return with error status due to timeout
-	assert.Equal(t, StatusError, result.Status)
-	assert.Contains(t, result.Error, "timeout")
+	// The test should return with timeout status, naming the configured timeout
+	assert.Equal(t, StatusTimeout, result.Status)
+	assert.Contains(t, result.Error, "timeout after 50ms")
+}
+
+func TestRunner_RunTest_DeadlineExceededRPC(t *testing.T) {
+	t.Parallel()
+
+	runner := NewRunner(zerolog.Nop(), VerbosityNormal)
+	run := func(errMsg string) *TestResult {
+		return runner.RunTest(context.Background(), TestCase{
+			Name:    "TestSlowRPC",
+			Timeout: time.Second,
+			TestFunc: func(_ *TestContext) *TestResult {
+				return &TestResult{Status: StatusFail, Error: errMsg}
+			},
+		}, nil)
+	}
+
+	result := run("Name() RPC failed: rpc error: code = DeadlineExceeded desc = context deadline exceeded")
+	assert.Equal(t, StatusTimeout, result.Status)
+	assert.Contains(t, result.Error, "timeout after 1s")
+	assert.Contains(t, result.Error, "code = DeadlineExceeded")
+
+	result = run("expected status DeadlineExceeded")
+	assert.Equal(t, StatusFail, result.Status, "assertions about deadlines are protocol failures")
 }
 
 func TestRunner_RunTest_Panic(t *testing.T) {
@@ -245,3 +271,73 @@ func TestRunner_RunTest_NilTestFunc(t *testing.T) {
 	assert.Equal(t, StatusError, result.Status)
 	assert.Contains(t, result.Error, "test function is nil")
 }
+
+func TestRunner_RunTestsParallel(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning, serialOverlaps atomic.Int32
+	track := func(serial bool) TestFunc {
+		return func(_ *TestContext) *TestResult {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			if serial && n > 1 {
+				serialOverlaps.Add(1)
+			}
+			time.Sleep(20 * time.Millisecond)
+			return &TestResult{Status: StatusPass}
+		}
+	}
+
+	var tests []TestCase
+	for i := range 8 {
+		tests = append(tests, TestCase{Name: fmt.Sprintf("Test%d", i), TestFunc: track(false)})
+	}
+	tests = append(tests, TestCase{Name: "SerialTest", Serial: true, TestFunc: track(true)})
+	tests[2], tests[8] = tests[8], tests[2]
+
+	var connects atomic.Int32
+	connectFn := func(context.Context) (interface{}, func() error, error) {
+		connects.Add(1)
+		return nil, func() error { return nil }, nil
+	}
+
+	runner := NewRunner(zerolog.Nop(), VerbosityNormal)
+	results := runner.RunTestsParallel(context.Background(), tests, connectFn, 4)
+
+	require.Len(t, results, len(tests))
+	for i, result := range results {
+		assert.Equal(t, tests[i].Name, result.TestName, "results keep the order of the tests")
+		assert.Equal(t, StatusPass, result.Status)
+	}
+	assert.Equal(t, int32(4), maxRunning.Load())
+	assert.Zero(t, serialOverlaps.Load(), "serial tests run alone")
+	assert.Equal(t, int32(2), connects.Load(), "one connection for concurrent tests, one for serial tests")
+}
+
+func TestRunner_RunTestsParallel_ConnectFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []TestCase{
+		{Name: "A", TestFunc: func(_ *TestContext) *TestResult { return &TestResult{Status: StatusPass} }},
+		{Name: "B", Serial: true, TestFunc: func(_ *TestContext) *TestResult { return &TestResult{Status: StatusPass} }},
+	}
+	connectFn := func(context.Context) (interface{}, func() error, error) {
+		return nil, nil, errors.New("no plugin")
+	}
+
+	runner := NewRunner(zerolog.Nop(), VerbosityNormal)
+	results := runner.RunTestsParallel(context.Background(), tests, connectFn, 2)
+
+	require.Len(t, results, 2)
+	for i, result := range results {
+		assert.Equal(t, tests[i].Name, result.TestName)
+		assert.Equal(t, StatusError, result.Status)
+		assert.Contains(t, result.Error, "no plugin")
+	}
+}
@@ -45,6 +45,14 @@ func NewSuite(cfg SuiteConfig) (*Suite, error) {
 		cfg.Timeout = DefaultSuiteTimeout
 	}
 
+	if cfg.Parallelism <= 0 {
+		cfg.Parallelism = 1
+	}
+
+	if cfg.BenchIterations <= 0 {
+		cfg.BenchIterations = DefaultBenchIterations
+	}
+
 	// Compile test filter regex if provided
 	var filter *regexp.Regexp
 	if cfg.TestFilter != "" {
@@ -73,9 +81,28 @@ func NewSuite(cfg SuiteConfig) (*Suite, error) {
 	// Register default test cases
 	suite.registerDefaultTests()
 
+	if err := suite.applySeverityOverrides(cfg.SeverityOverrides); err != nil {
+		return nil, err
+	}
+
 	return suite, nil
 }
 
+// applySeverityOverrides replaces the severity of the named test cases.
+func (s *Suite) applySeverityOverrides(overrides map[string]Severity) error {
+	for name, severity := range overrides {
+		if !IsValidSeverity(string(severity)) {
+			return fmt.Errorf("invalid severity %q for test %s", severity, name)
+		}
+		idx := slices.IndexFunc(s.testCases, func(tc TestCase) bool { return tc.Name == name })
+		if idx < 0 {
+			return fmt.Errorf("unknown test in severity override: %s", name)
+		}
+		s.testCases[idx].Severity = severity
+	}
+	return nil
+}
+
 // registerDefaultTests registers all built-in conformance test cases.
 func (s *Suite) registerDefaultTests() {
 	s.testCases = []TestCase{
@@ -85,6 +112,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryProtocol,
 			Description:     "Verifies plugin returns its identifier via Name RPC",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"Name"},
 			TestFunc:        testNameReturnsIdentifier,
 		},
@@ -93,6 +121,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryProtocol,
 			Description:     "Verifies plugin returns protocol version via Name RPC",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"Name"},
 			TestFunc:        testNameReturnsProtocolVersion,
 		},
@@ -101,6 +130,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryProtocol,
 			Description:     "Verifies GetProjectedCost returns cost for valid resource",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"GetProjectedCost"},
 			TestFunc:        testGetProjectedCostValid,
 		},
@@ -109,6 +139,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryError,
 			Description:     "Verifies GetProjectedCost returns NotFound for unsupported resource",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"GetProjectedCost"},
 			TestFunc:        testGetProjectedCostInvalid,
 		},
@@ -117,6 +148,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryError,
 			Description:     "Verifies GetProjectedCost returns PermissionDenied for forbidden resource",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"GetProjectedCost"},
 			TestFunc:        testGetProjectedCostPermissionDenied,
 		},
@@ -125,6 +157,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryError,
 			Description:     "Verifies GetProjectedCost returns Internal for internal errors",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"GetProjectedCost"},
 			TestFunc:        testGetProjectedCostInternal,
 		},
@@ -133,6 +166,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryError,
 			Description:     "Verifies GetProjectedCost returns Unavailable when service is down",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"GetProjectedCost"},
 			TestFunc:        testGetProjectedCostUnavailable,
 		},
@@ -142,6 +176,7 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryContext,
 			Description:     "Verifies plugin respects context cancellation",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
 			RequiredMethods: []string{"GetProjectedCost"},
 			TestFunc:        testContextCancellation,
 		},
@@ -151,7 +186,9 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryPerformance,
 			Description:     "Verifies plugin responds within timeout limits",
 			Timeout:         DefaultTimeout,
+			Severity:        SeverityWarning,
 			RequiredMethods: []string{"Name"},
+			Serial:          true,
 			TestFunc:        testTimeoutRespected,
 		},
 		{
@@ -159,9 +196,49 @@ func (s *Suite) registerDefaultTests() {
 			Category:        CategoryPerformance,
 			Description:     "Verifies plugin handles multiple sequential requests",
 			Timeout:         DefaultTimeout * batchTestTimeoutMultiplier,
+			Severity:        SeverityWarning,
 			RequiredMethods: []string{"GetProjectedCost"},
+			Serial:          true,
 			TestFunc:        testBatchHandling,
 		},
+		{
+			Name:            "GetProjectedCost_Latency",
+			Category:        CategoryPerformance,
+			Description:     "Measures p50/p95/p99 latency of repeated GetProjectedCost calls",
+			Timeout:         DefaultTimeout * batchTestTimeoutMultiplier,
+			Severity:        SeverityWarning,
+			RequiredMethods: []string{"GetProjectedCost"},
+			Serial:          true,
+			TestFunc:        testGetProjectedCostLatency,
+		},
+		// Recommendations tests (skipped for plugins without the RPC)
+		{
+			Name:            "GetRecommendations_ResponseShape",
+			Category:        CategoryRecommendations,
+			Description:     "Verifies GetRecommendations returns well-formed recommendations for target resources",
+			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
+			RequiredMethods: []string{"GetRecommendations"},
+			TestFunc:        testGetRecommendationsResponseShape,
+		},
+		{
+			Name:            "GetRecommendations_Pagination",
+			Category:        CategoryRecommendations,
+			Description:     "Verifies GetRecommendations NextPageToken returns the next page",
+			Timeout:         DefaultTimeout * batchTestTimeoutMultiplier,
+			Severity:        SeverityError,
+			RequiredMethods: []string{"GetRecommendations"},
+			TestFunc:        testGetRecommendationsPagination,
+		},
+		{
+			Name:            "GetRecommendations_EmptyResult",
+			Category:        CategoryRecommendations,
+			Description:     "Verifies GetRecommendations returns an empty list for unknown resources",
+			Timeout:         DefaultTimeout,
+			Severity:        SeverityError,
+			RequiredMethods: []string{"GetRecommendations"},
+			TestFunc:        testGetRecommendationsEmpty,
+		},
 	}
 }
 
@@ -244,9 +321,10 @@ func (s *Suite) Run(ctx context.Context) (*SuiteReport, error) {
 
 	// Create runner
 	runner := NewRunner(s.logger, s.config.Verbosity)
+	runner.benchIterations = s.config.BenchIterations
 
-	// Run tests with restart support
-	results := runner.RunTests(suiteCtx, testCases, connectFn)
+	// Run tests with restart support, running independent tests concurrently if configured
+	results := runner.RunTestsParallel(suiteCtx, testCases, connectFn, s.config.Parallelism)
 
 	endTime := time.Now()
 
@@ -274,6 +352,7 @@ func (s *Suite) Run(ctx context.Context) (*SuiteReport, error) {
 		Int("failed", report.Summary.Failed).
 		Int("skipped", report.Summary.Skipped).
 		Int("errors", report.Summary.Errors).
+		Int("timed_out", report.Summary.TimedOut).
 		Dur("duration", report.TotalTime).
 		Msg("conformance suite completed")
 
@@ -296,10 +375,19 @@ func calculateSummary(results []TestResult) Summary {
 			summary.Skipped++
 		case StatusError:
 			summary.Errors++
+		case StatusTimeout:
+			summary.TimedOut++
 		default:
 			// Unknown status - count as error to surface the issue
 			summary.Errors++
 		}
+
+		if r.Status != StatusPass && r.Status != StatusSkip {
+			if summary.FailuresBySeverity == nil {
+				summary.FailuresBySeverity = make(map[Severity]int)
+			}
+			summary.FailuresBySeverity[r.severity()]++
+		}
 	}
 
 	return summary
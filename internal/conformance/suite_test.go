@@ -44,6 +44,7 @@ func TestNewSuite_DefaultValues(t *testing.T) {
 	assert.Equal(t, CommModeTCP, suite.config.CommMode)
 	assert.Equal(t, VerbosityNormal, suite.config.Verbosity)
 	assert.Equal(t, DefaultSuiteTimeout, suite.config.Timeout)
+	assert.Equal(t, 1, suite.config.Parallelism)
 }
 
 func TestNewSuite_EmptyPluginPath(t *testing.T) {
@@ -237,3 +238,65 @@ func TestSuite_GetTestCases_FilterByRegex(t *testing.T) {
 			"test case %s should match filter regex", tc.Name)
 	}
 }
+
+func TestNewSuite_DefaultSeverities(t *testing.T) {
+	t.Parallel()
+
+	suite, err := NewSuite(SuiteConfig{PluginPath: "/path/to/plugin"})
+	require.NoError(t, err)
+
+	for _, tc := range suite.GetTestCases() {
+		if tc.Category == CategoryPerformance {
+			assert.Equal(t, SeverityWarning, tc.Severity, tc.Name)
+		} else {
+			assert.Equal(t, SeverityError, tc.Severity, tc.Name)
+		}
+	}
+}
+
+func TestNewSuite_SeverityOverrides(t *testing.T) {
+	t.Parallel()
+
+	suite, err := NewSuite(SuiteConfig{
+		PluginPath:        "/path/to/plugin",
+		SeverityOverrides: map[string]Severity{"Batch_Handling": SeverityInfo},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range suite.GetTestCases() {
+		if tc.Name == "Batch_Handling" {
+			assert.Equal(t, SeverityInfo, tc.Severity)
+		}
+	}
+
+	_, err = NewSuite(SuiteConfig{
+		PluginPath:        "/path/to/plugin",
+		SeverityOverrides: map[string]Severity{"No_Such_Test": SeverityInfo},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown test")
+}
+
+func TestCalculateSummary_FailuresBySeverity(t *testing.T) {
+	t.Parallel()
+
+	summary := calculateSummary([]TestResult{
+		{Status: StatusFail, Severity: SeverityError},
+		{Status: StatusError, Severity: SeverityWarning},
+		{Status: StatusFail, Severity: SeverityWarning},
+		{Status: StatusPass, Severity: SeverityError},
+		{Status: StatusSkip, Severity: SeverityInfo},
+	})
+
+	assert.Equal(t, 1, summary.FailuresBySeverity[SeverityError])
+	assert.Equal(t, 2, summary.FailuresBySeverity[SeverityWarning])
+	assert.Zero(t, summary.FailuresBySeverity[SeverityInfo])
+}
+
+func TestNewSuite_DefaultBenchIterations(t *testing.T) {
+	t.Parallel()
+
+	suite, err := NewSuite(SuiteConfig{PluginPath: "/path/to/plugin"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBenchIterations, suite.config.BenchIterations)
+}
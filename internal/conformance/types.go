@@ -17,10 +17,65 @@ const (
 	StatusFail Status = "fail"
 	// StatusSkip indicates the test was skipped (precondition not met).
 	StatusSkip Status = "skip"
-	// StatusError indicates an infrastructure error (plugin crash, timeout, connection lost).
+	// StatusError indicates an infrastructure error (plugin crash, connection lost).
 	StatusError Status = "error"
+	// StatusTimeout indicates the test did not finish within its timeout. The plugin may
+	// only be slow, so the timeout is reported apart from protocol violations.
+	StatusTimeout Status = "timeout"
 )
 
+// failed reports whether s counts against the run: a failure, an error or a timeout.
+func (s Status) failed() bool {
+	return s == StatusFail || s == StatusError || s == StatusTimeout
+}
+
+// Severity ranks how serious a failing test is. It decides whether a failure
+// affects the exit status under the configured fail-on threshold.
+type Severity string
+
+const (
+	// SeverityError marks hard protocol violations. Errors fail the run by default.
+	SeverityError Severity = "error"
+	// SeverityWarning marks recommendations, such as performance expectations.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks purely advisory checks.
+	SeverityInfo Severity = "info"
+)
+
+// IsValidSeverity checks if a severity string is valid.
+func IsValidSeverity(s string) bool {
+	switch Severity(s) {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AtLeast reports whether s is as severe as threshold or more. An empty severity is
+// treated as SeverityError.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() >= threshold.rank()
+}
+
+// Severity ranks used for threshold comparison.
+const (
+	severityRankInfo = iota
+	severityRankWarning
+	severityRankError
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return severityRankInfo
+	case SeverityWarning:
+		return severityRankWarning
+	default:
+		return severityRankError
+	}
+}
+
 // Category represents a test grouping for filtering.
 type Category string
 
@@ -33,6 +88,8 @@ const (
 	CategoryError Category = "error"
 	// CategoryContext tests context cancellation and deadline propagation.
 	CategoryContext Category = "context"
+	// CategoryRecommendations tests the optional GetRecommendations RPC.
+	CategoryRecommendations Category = "recommendations"
 )
 
 // AllCategories returns all available test categories.
@@ -42,13 +99,14 @@ func AllCategories() []Category {
 		CategoryPerformance,
 		CategoryError,
 		CategoryContext,
+		CategoryRecommendations,
 	}
 }
 
 // IsValidCategory checks if a category string is valid.
 func IsValidCategory(cat string) bool {
 	switch Category(cat) {
-	case CategoryProtocol, CategoryPerformance, CategoryError, CategoryContext:
+	case CategoryProtocol, CategoryPerformance, CategoryError, CategoryContext, CategoryRecommendations:
 		return true
 	default:
 		return false
@@ -103,14 +161,19 @@ func IsValidCommMode(mode string) bool {
 type TestCase struct {
 	// Name is the unique test identifier (e.g., "Name_ReturnsPluginIdentifier").
 	Name string
-	// Category is the test category: protocol, performance, error, context.
+	// Category is the test category: protocol, performance, error, context, recommendations.
 	Category Category
 	// Description is a human-readable test description.
 	Description string
 	// Timeout is the maximum time for this test (default: 10s).
 	Timeout time.Duration
+	// Severity is how serious a failure of this test is (default: error).
+	Severity Severity
 	// RequiredMethods lists gRPC methods this test validates.
 	RequiredMethods []string
+	// Serial marks tests that must not run alongside others when the suite runs tests in
+	// parallel, such as timing checks or tests that stop or restart the plugin.
+	Serial bool
 	// TestFunc is the function that executes the test.
 	TestFunc TestFunc
 }
@@ -133,6 +196,8 @@ type TestContext struct {
 	Verbosity Verbosity
 	// Timeout is the timeout for this specific test.
 	Timeout time.Duration
+	// BenchIterations is the number of calls latency benchmarks make.
+	BenchIterations int
 }
 
 // TestResult represents the outcome of running a single test.
@@ -141,18 +206,43 @@ type TestResult struct {
 	TestName string `json:"name"`
 	// Category is the test category.
 	Category Category `json:"category"`
-	// Status is the test outcome: pass, fail, skip, error.
+	// Status is the test outcome: pass, fail, skip, error, timeout.
 	Status Status `json:"status"`
+	// Severity is the severity of the test case: error, warning, info.
+	Severity Severity `json:"severity"`
 	// Duration is the actual execution time (serialized as nanoseconds).
 	Duration time.Duration `json:"duration_ns"`
 	// Error is the error message if Status != pass.
 	Error string `json:"error,omitempty"`
 	// Details provides additional context (request/response logs).
 	Details string `json:"details,omitempty"`
+	// Latency holds the latency percentiles measured by a benchmark test.
+	Latency *LatencyStats `json:"latency,omitempty"`
 	// Timestamp is when the test completed.
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// LatencyStats summarizes the latency of repeated calls to an RPC.
+type LatencyStats struct {
+	// RPC is the method that was called.
+	RPC string `json:"rpc"`
+	// P50, P95 and P99 are the latency percentiles (serialized as nanoseconds).
+	P50 time.Duration `json:"p50_ns"`
+	P95 time.Duration `json:"p95_ns"`
+	P99 time.Duration `json:"p99_ns"`
+	// Samples holds the latency of each call in the order they were made (serialized as
+	// nanoseconds).
+	Samples []time.Duration `json:"samples_ns"`
+}
+
+// severity returns the result's severity, treating results without one as SeverityError.
+func (r TestResult) severity() Severity {
+	if r.Severity == "" {
+		return SeverityError
+	}
+	return r.Severity
+}
+
 // PluginUnderTest represents the plugin binary being validated.
 type PluginUnderTest struct {
 	// Path is the absolute path to plugin binary.
@@ -181,10 +271,17 @@ type SuiteConfig struct {
 	OutputPath string
 	// Timeout is the global timeout for entire suite (default: 5m).
 	Timeout time.Duration
+	// Parallelism is the maximum number of test cases run at once against the plugin
+	// (default: 1, one at a time). Tests marked Serial always run one at a time.
+	Parallelism int
+	// BenchIterations is the number of calls latency benchmarks make (default: 100).
+	BenchIterations int
 	// Categories filters to specific test categories.
 	Categories []Category
 	// TestFilter is a regex filter for test names.
 	TestFilter string
+	// SeverityOverrides maps test names to a severity that replaces the built-in one.
+	SeverityOverrides map[string]Severity
 	// Logger is the custom logger (optional).
 	Logger zerolog.Logger
 }
@@ -201,6 +298,10 @@ type Summary struct {
 	Skipped int `json:"skipped"`
 	// Errors is the count of tests with error status.
 	Errors int `json:"errors"`
+	// TimedOut is the count of tests with timeout status.
+	TimedOut int `json:"timed_out"`
+	// FailuresBySeverity counts failed, errored and timed-out tests per severity.
+	FailuresBySeverity map[Severity]int `json:"failures_by_severity,omitempty"`
 }
 
 // SuiteReport is the aggregate report for a conformance suite run.
@@ -223,12 +324,30 @@ type SuiteReport struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// FailingResults returns the failed, errored and timed-out results whose severity is at
+// least threshold. Results below the threshold are advisory and do not fail the run.
+func (r *SuiteReport) FailingResults(threshold Severity) []TestResult {
+	var failing []TestResult
+	for _, res := range r.Results {
+		if !res.Status.failed() {
+			continue
+		}
+		if res.severity().AtLeast(threshold) {
+			failing = append(failing, res)
+		}
+	}
+	return failing
+}
+
 // DefaultTimeout is the default timeout for individual tests (10 seconds).
 const DefaultTimeout = 10 * time.Second
 
 // DefaultSuiteTimeout is the default timeout for the entire suite (5 minutes).
 const DefaultSuiteTimeout = 5 * time.Minute
 
+// DefaultBenchIterations is the default number of calls latency benchmarks make.
+const DefaultBenchIterations = 100
+
 // batchTestTimeoutMultiplier is the multiplier for batch tests.
 const batchTestTimeoutMultiplier = 2
 
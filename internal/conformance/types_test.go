@@ -57,11 +57,12 @@ func TestAllCategories(t *testing.T) {
 
 	categories := AllCategories()
 
-	require.Len(t, categories, 4)
+	require.Len(t, categories, 5)
 	assert.Contains(t, categories, CategoryProtocol)
 	assert.Contains(t, categories, CategoryPerformance)
 	assert.Contains(t, categories, CategoryError)
 	assert.Contains(t, categories, CategoryContext)
+	assert.Contains(t, categories, CategoryRecommendations)
 }
 
 func TestIsValidCategory(t *testing.T) {
@@ -76,6 +77,7 @@ func TestIsValidCategory(t *testing.T) {
 		{"valid performance", "performance", true},
 		{"valid error", "error", true},
 		{"valid context", "context", true},
+		{"valid recommendations", "recommendations", true},
 		{"invalid empty", "", false},
 		{"invalid unknown", "unknown", false},
 		{"invalid case", "Protocol", false},
@@ -373,3 +375,61 @@ func TestConstants(t *testing.T) {
 	assert.Equal(t, 1000, MaxBatchSize)
 	assert.Equal(t, "1.0", ProtocolVersion)
 }
+
+func TestSeverity_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		severity  Severity
+		threshold Severity
+		want      bool
+	}{
+		{SeverityError, SeverityError, true},
+		{SeverityWarning, SeverityError, false},
+		{SeverityWarning, SeverityWarning, true},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityInfo, SeverityInfo, true},
+		{"", SeverityError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.severity)+">="+string(tt.threshold), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.severity.AtLeast(tt.threshold))
+		})
+	}
+}
+
+func TestIsValidSeverity(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsValidSeverity("error"))
+	assert.True(t, IsValidSeverity("warning"))
+	assert.True(t, IsValidSeverity("info"))
+	assert.False(t, IsValidSeverity(""))
+	assert.False(t, IsValidSeverity("fatal"))
+}
+
+func TestSuiteReport_FailingResults(t *testing.T) {
+	t.Parallel()
+
+	report := &SuiteReport{Results: []TestResult{
+		{TestName: "Protocol", Status: StatusFail, Severity: SeverityError},
+		{TestName: "Perf", Status: StatusFail, Severity: SeverityWarning},
+		{TestName: "Advisory", Status: StatusError, Severity: SeverityInfo},
+		{TestName: "Passing", Status: StatusPass, Severity: SeverityError},
+		{TestName: "Legacy", Status: StatusFail},
+	}}
+
+	names := func(results []TestResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.TestName)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"Protocol", "Legacy"}, names(report.FailingResults(SeverityError)))
+	assert.Equal(t, []string{"Protocol", "Perf", "Legacy"}, names(report.FailingResults(SeverityWarning)))
+	assert.Len(t, report.FailingResults(SeverityInfo), 4)
+}
@@ -336,6 +336,68 @@ func TestCreateCrossProviderAggregation_SortingOrder(t *testing.T) {
 	assert.Equal(t, "2024-12-31", aggregations[2].Period)
 }
 
+// TestCreateCrossProviderAggregation_QuarterlyAndYearly tests that daily costs spanning
+// quarter and year boundaries land in the calendar period of each day.
+func TestCreateCrossProviderAggregation_QuarterlyAndYearly(t *testing.T) {
+	mar30 := time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)
+	dec31 := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	results := []CostResult{
+		{
+			ResourceType: "aws:ec2:Instance",
+			Currency:     "USD",
+			StartDate:    mar30,
+			EndDate:      mar30.AddDate(0, 0, 4),
+			DailyCosts:   []float64{1, 2, 4, 8}, // Mar 30, Mar 31, Apr 1, Apr 2
+		},
+		{
+			ResourceType: "gcp:compute:Instance",
+			Currency:     "USD",
+			StartDate:    dec31,
+			EndDate:      dec31.AddDate(0, 0, 2),
+			DailyCosts:   []float64{16, 32}, // Dec 31, Jan 1
+		},
+		{
+			ResourceType: "azure:compute:VirtualMachine",
+			Monthly:      100,
+			Currency:     "USD",
+			StartDate:    time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:      time.Date(2024, 11, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	quarters, err := CreateCrossProviderAggregation(results, GroupByQuarterly)
+	require.NoError(t, err)
+	require.Len(t, quarters, 4)
+	assert.Equal(t, "2024-Q1", quarters[0].Period)
+	assert.InDelta(t, 3.0, quarters[0].Total, 1e-9)
+	assert.Equal(t, "2024-Q2", quarters[1].Period)
+	assert.InDelta(t, 12.0, quarters[1].Total, 1e-9)
+	assert.Equal(t, "2024-Q4", quarters[2].Period)
+	assert.InDelta(t, 16.0, quarters[2].Providers["gcp"], 1e-9)
+	assert.InDelta(t, 300.0, quarters[2].Providers["azure"], 1e-9, "a monthly projection spans the quarter")
+	assert.Equal(t, "2025-Q1", quarters[3].Period)
+	assert.InDelta(t, 32.0, quarters[3].Total, 1e-9)
+
+	years, err := CreateCrossProviderAggregation(results, GroupByYearly)
+	require.NoError(t, err)
+	require.Len(t, years, 2)
+	assert.Equal(t, "2024", years[0].Period)
+	assert.InDelta(t, 1+2+4+8+16+1200.0, years[0].Total, 1e-9)
+	assert.Equal(t, "2025", years[1].Period)
+	assert.InDelta(t, 32.0, years[1].Total, 1e-9)
+}
+
+func TestFormatPeriodForGrouping_Quarters(t *testing.T) {
+	for month, want := range map[time.Month]string{
+		time.January: "2024-Q1", time.March: "2024-Q1", time.April: "2024-Q2",
+		time.June: "2024-Q2", time.July: "2024-Q3", time.October: "2024-Q4", time.December: "2024-Q4",
+	} {
+		assert.Equal(t, want, formatPeriodForGrouping(time.Date(2024, month, 15, 0, 0, 0, 0, time.UTC), GroupByQuarterly))
+	}
+	assert.Equal(t, "2024", formatPeriodForGrouping(time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC), GroupByYearly))
+}
+
 // TestAggregateResults_SingleResource tests aggregation with one resource.
 func TestAggregateResults_SingleResource(t *testing.T) {
 	results := []CostResult{
@@ -547,7 +609,8 @@ func TestEdgeCase_NilPropertiesNoNilPointerPanic(t *testing.T) {
 
 	// FilterResources should handle nil Properties
 	resources := []ResourceDescriptor{resource}
-	filtered := FilterResources(resources, "provider=aws")
+	filtered, err := FilterResources(resources, "provider=aws")
+	assert.NoError(t, err)
 	assert.NotNil(t, filtered, "FilterResources should not panic on nil Properties")
 }
 
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OutputCSV renders one row per resource with allocation tags as columns.
+const OutputCSV OutputFormat = "csv"
+
+// costResultFields returns the JSON field names of CostResult, which allocation tag keys
+// may not shadow.
+var costResultFields = sync.OnceValue(func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(CostResult{})
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+})
+
+// ValidateAllocationTagKeys rejects empty keys and keys that would collide with the
+// standard CostResult fields once promoted to top-level output fields.
+func ValidateAllocationTagKeys(keys []string) error {
+	for _, key := range keys {
+		if key == "" {
+			return errors.New("allocation tag key must not be empty")
+		}
+		if costResultFields()[key] {
+			return fmt.Errorf("allocation tag key %q conflicts with a built-in output field", key)
+		}
+	}
+	return nil
+}
+
+// allocateResults copies the resource's allocation tags onto every result produced for it.
+// Like annotations, the map is shared because it is read-only after ingest.
+func allocateResults(results []CostResult, tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+	for i := range results {
+		results[i].AllocationTags = tags
+	}
+}
+
+// costResultJSON has CostResult's fields without its MarshalJSON method.
+type costResultJSON CostResult
+
+// MarshalJSON encodes the result with each allocation tag promoted to a top-level field
+// after the standard fields, in key order.
+func (r CostResult) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(costResultJSON(r))
+	if err != nil || len(r.AllocationTags) == 0 {
+		return base, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(base, []byte("}")))
+	for _, key := range sortedTagKeys(r.AllocationTags) {
+		if costResultFields()[key] {
+			continue
+		}
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(r.AllocationTags[key])
+		buf.WriteByte(',')
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// renderCSV writes one row per result. Allocation tag columns follow the cost columns in
+// the order given; when none are given, every tag key found on the results is used.
+func renderCSV(writer io.Writer, results []CostResult, allocationTags []string) error {
+	if len(allocationTags) == 0 {
+		seen := make(map[string]string)
+		for _, r := range results {
+			for k := range r.AllocationTags {
+				seen[k] = ""
+			}
+		}
+		allocationTags = sortedTagKeys(seen)
+	}
+
+	w := csv.NewWriter(writer)
+	header := []string{"resource_type", "resource_id", "adapter", "currency", "monthly", "hourly"}
+	header = append(header, allocationTags...)
+	if err := w.Write(append(header, "notes")); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.ResourceType,
+			r.ResourceID,
+			r.Adapter,
+			r.Currency,
+			strconv.FormatFloat(r.Monthly, 'f', -1, 64),
+			strconv.FormatFloat(r.Hourly, 'f', -1, 64),
+		}
+		for _, key := range allocationTags {
+			row = append(row, r.AllocationTags[key])
+		}
+		if err := w.Write(append(row, r.Notes)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
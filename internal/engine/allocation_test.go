@@ -0,0 +1,65 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allocationTestResults() []engine.CostResult {
+	return []engine.CostResult{
+		{
+			ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Adapter: "aws", Currency: "USD",
+			Monthly: 7.3, Hourly: 0.01, Notes: "on-demand",
+			AllocationTags: map[string]string{"team": "platform", "cost-center": "cc-42"},
+		},
+		{
+			ResourceType: "aws:s3/bucket:Bucket", ResourceID: "assets", Adapter: "aws", Currency: "USD",
+			Monthly: 1.5, AllocationTags: map[string]string{"team": "", "cost-center": ""},
+		},
+	}
+}
+
+func TestCostResult_MarshalJSON_PromotesAllocationTags(t *testing.T) {
+	data, err := json.Marshal(allocationTestResults()[0])
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "platform", fields["team"])
+	assert.Equal(t, "cc-42", fields["cost-center"])
+	assert.Equal(t, "web", fields["resourceId"])
+	assert.NotContains(t, fields, "AllocationTags")
+
+	empty, err := json.Marshal(allocationTestResults()[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(empty), `"team":""`, "missing tags are emitted as empty values")
+
+	plain, err := json.Marshal(engine.CostResult{ResourceID: "db"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(plain), "team")
+}
+
+func TestRenderResults_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := engine.RenderResultsWithOptions(&buf, engine.OutputCSV, allocationTestResults(),
+		engine.RenderOptions{AllocationTags: []string{"team", "cost-center"}})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "resource_type,resource_id,adapter,currency,monthly,hourly,team,cost-center,notes", lines[0])
+	assert.Equal(t, "aws:ec2/instance:Instance,web,aws,USD,7.3,0.01,platform,cc-42,on-demand", lines[1])
+	assert.Equal(t, "aws:s3/bucket:Bucket,assets,aws,USD,1.5,0,,,", lines[2])
+}
+
+func TestValidateAllocationTagKeys(t *testing.T) {
+	require.NoError(t, engine.ValidateAllocationTagKeys([]string{"team", "cost-center"}))
+	require.ErrorContains(t, engine.ValidateAllocationTagKeys([]string{"currency"}), "conflicts")
+	require.Error(t, engine.ValidateAllocationTagKeys([]string{""}))
+}
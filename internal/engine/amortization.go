@@ -0,0 +1,57 @@
+package engine
+
+import "fmt"
+
+const (
+	// upfrontPricingKey is a one-time fee paid for a term, such as a reserved instance or
+	// savings plan purchased with an upfront payment. It is spread evenly over the months
+	// of termMonthsPricingKey and added to the spec's recurring cost:
+	//
+	//	pricing:
+	//	  onDemandHourly: 0.0062  # recurring part of a partial-upfront reservation
+	//	  upfront: 54
+	//	  term_months: 12
+	upfrontPricingKey    = "upfront"
+	termMonthsPricingKey = "term_months"
+
+	breakdownAmortizedUpfront = "amortized_upfront"
+	breakdownRecurring        = "recurring"
+)
+
+// amortizedUpfront returns the monthly share of a spec's upfront fee, or false when the
+// spec has no upfront fee or no positive term to spread it over.
+func amortizedUpfront(pricing map[string]interface{}) (float64, bool) {
+	upfront, ok := parseFloatValue(pricing[upfrontPricingKey])
+	if !ok || upfront <= 0 {
+		return 0, false
+	}
+	term, ok := parseFloatValue(pricing[termMonthsPricingKey])
+	if !ok || term <= 0 {
+		return 0, false
+	}
+	return upfront / term, true
+}
+
+// isAmortizationKey reports whether key describes an upfront fee rather than a rate.
+func isAmortizationKey(key string) bool {
+	return key == upfrontPricingKey || key == termMonthsPricingKey
+}
+
+// applyAmortizedUpfront adds the monthly share of the spec's upfront fee to a spec-based
+// result and splits its breakdown into the amortized fee and the recurring cost. The fee
+// is owed whether or not the resource runs, so it is added after any run schedule. A
+// month has hours hours. Results of specs without an upfront fee are left unchanged.
+func applyAmortizedUpfront(result *CostResult, pricing map[string]interface{}, hours float64) {
+	monthlyUpfront, ok := amortizedUpfront(pricing)
+	if !ok {
+		return
+	}
+	term, _ := parseFloatValue(pricing[termMonthsPricingKey])
+	result.Breakdown = map[string]float64{
+		breakdownAmortizedUpfront: monthlyUpfront,
+		breakdownRecurring:        result.Monthly,
+	}
+	result.Monthly += monthlyUpfront
+	result.Hourly += monthlyUpfront / hours
+	result.Notes += fmt.Sprintf("; upfront fee amortized over %g months", term)
+}
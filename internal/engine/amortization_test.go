@@ -0,0 +1,73 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProjectedCost_AmortizedUpfront(t *testing.T) {
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.1\n",
+		"aws-ec2-m5.large.yaml": "provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.05\n  upfront: 120\n  term_months: 12\n",
+		"aws-ec2-c5.large.yaml": "provider: aws\nservice: ec2\nsku: c5.large\ncurrency: USD\n" +
+			"pricing:\n  upfront: 360.0\n  term_months: 36\n",
+	})
+	eng := newTestEngine(t, nil, loader)
+
+	tests := []struct {
+		name          string
+		properties    map[string]interface{}
+		wantMonthly   float64
+		wantBreakdown map[string]float64
+	}{
+		{
+			name:          "spec without upfront fee",
+			properties:    map[string]interface{}{"instanceType": "t3.micro"},
+			wantMonthly:   73,
+			wantBreakdown: map[string]float64{"base_cost": 73},
+		},
+		{
+			name:          "partial upfront",
+			properties:    map[string]interface{}{"instanceType": "m5.large"},
+			wantMonthly:   36.5 + 10,
+			wantBreakdown: map[string]float64{"amortized_upfront": 10, "recurring": 36.5},
+		},
+		{
+			name:          "all upfront",
+			properties:    map[string]interface{}{"instanceType": "c5.large"},
+			wantMonthly:   10,
+			wantBreakdown: map[string]float64{"amortized_upfront": 10, "recurring": 0},
+		},
+		{
+			name: "upfront fee is owed outside the run schedule",
+			properties: map[string]interface{}{
+				"instanceType": "m5.large",
+				"tags":         map[string]interface{}{"finfocus:schedule": "business-hours"},
+			},
+			wantMonthly:   50*0.05*730/168 + 10,
+			wantBreakdown: map[string]float64{"amortized_upfront": 10, "recurring": 50 * 0.05 * 730 / 168},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
+				{Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws", Properties: tt.properties},
+			})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			assert.InDelta(t, tt.wantMonthly, results[0].Monthly, 0.0001)
+			assert.InDelta(t, tt.wantMonthly/730, results[0].Hourly, 0.0001)
+			require.Len(t, results[0].Breakdown, len(tt.wantBreakdown))
+			for key, want := range tt.wantBreakdown {
+				assert.InDelta(t, want, results[0].Breakdown[key], 0.0001, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package engine
+
+// annotateResults copies the resource annotations onto every result produced
+// for that resource. The map is shared rather than cloned because annotations
+// are treated as read-only once ingest has extracted them.
+func annotateResults(results []CostResult, annotations map[string]string) {
+	if len(annotations) == 0 {
+		return
+	}
+	for i := range results {
+		results[i].Annotations = annotations
+	}
+}
@@ -0,0 +1,81 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationsPassthrough(t *testing.T) {
+	eng := newTestEngine(t, nil, nil)
+	resources := []engine.ResourceDescriptor{
+		{
+			Type:        "aws:ec2:Instance",
+			ID:          "web",
+			Provider:    "aws",
+			Annotations: map[string]string{"owner": "team-a", "ticket": "OPS-1"},
+		},
+		{Type: "aws:s3:Bucket", ID: "logs", Provider: "aws"},
+	}
+
+	t.Run("projected", func(t *testing.T) {
+		result, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "team-a", result.Results[0].Annotations["owner"])
+		assert.Equal(t, "OPS-1", result.Results[0].Annotations["ticket"])
+		assert.Nil(t, result.Results[1].Annotations)
+	})
+
+	t.Run("actual", func(t *testing.T) {
+		result, err := eng.GetActualCostWithOptionsAndErrors(context.Background(), engine.ActualCostRequest{
+			Resources: resources,
+			From:      time.Now().Add(-24 * time.Hour),
+			To:        time.Now(),
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "team-a", result.Results[0].Annotations["owner"])
+		assert.Nil(t, result.Results[1].Annotations)
+	})
+}
+
+func TestRenderResultsWithOptions_AnnotationColumns(t *testing.T) {
+	results := []engine.CostResult{
+		{
+			ResourceType: "aws:ec2:Instance",
+			ResourceID:   "web",
+			Adapter:      "aws-plugin",
+			Currency:     "USD",
+			Monthly:      10,
+			Annotations:  map[string]string{"owner": "team-a"},
+		},
+		{ResourceType: "aws:s3:Bucket", ResourceID: "logs", Adapter: "aws-plugin", Currency: "USD"},
+	}
+
+	var buf bytes.Buffer
+	err := engine.RenderResultsWithOptions(&buf, engine.OutputTable, results, engine.RenderOptions{
+		Annotations: []string{"owner", "ticket"},
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Regexp(t, `Currency\s+owner\s+ticket\s+Notes`, out)
+	assert.Contains(t, out, "team-a")
+	assert.Regexp(t, `aws:s3:Bucket/logs.*USD\s+-\s+-`, out)
+}
+
+func TestRenderResults_JSONIncludesAnnotations(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", Currency: "USD", Annotations: map[string]string{"owner": "team-a"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderResults(&buf, engine.OutputNDJSON, results))
+	assert.Contains(t, buf.String(), `"annotations":{"owner":"team-a"}`)
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// AnomalySeverity ranks how far a day's cost spiked above its trailing average.
+type AnomalySeverity string
+
+// Anomaly severities, by spike above the trailing average.
+const (
+	AnomalySeverityLow    AnomalySeverity = "low"    // below 100%
+	AnomalySeverityMedium AnomalySeverity = "medium" // 100% to 200%
+	AnomalySeverityHigh   AnomalySeverity = "high"   // 200% or more
+)
+
+const (
+	// minAnomalyDays is the fewest days of data a resource needs before its costs are
+	// checked for anomalies; shorter series are too noisy.
+	minAnomalyDays = 3
+
+	// anomalyTrailingDays is how many preceding days the expected cost is averaged over.
+	anomalyTrailingDays = 7
+
+	anomalyMediumSpikePercent = 100
+	anomalyHighSpikePercent   = 200
+)
+
+// anomalySeverityRank orders severities for threshold comparisons.
+var anomalySeverityRank = map[AnomalySeverity]int{
+	AnomalySeverityLow:    1,
+	AnomalySeverityMedium: 2,
+	AnomalySeverityHigh:   3,
+}
+
+// ParseAnomalySeverity parses low, medium or high; an empty string means low.
+func ParseAnomalySeverity(s string) (AnomalySeverity, error) {
+	severity := AnomalySeverity(strings.ToLower(strings.TrimSpace(s)))
+	if severity == "" {
+		return AnomalySeverityLow, nil
+	}
+	if _, ok := anomalySeverityRank[severity]; !ok {
+		return "", fmt.Errorf("invalid anomaly severity %q: must be low, medium or high", s)
+	}
+	return severity, nil
+}
+
+// AtLeast reports whether s is as severe as minimum.
+func (s AnomalySeverity) AtLeast(minimum AnomalySeverity) bool {
+	return anomalySeverityRank[s] >= anomalySeverityRank[minimum]
+}
+
+// Anomaly is a day on which a resource cost markedly more than on the days before it.
+type Anomaly struct {
+	ResourceType string `json:"resourceType"`
+	ResourceID   string `json:"resourceId"`
+	// Date is the day of the spike; it is zero when the result has no start date.
+	Date     time.Time `json:"date,omitempty"`
+	Currency string    `json:"currency"`
+	// Expected is the average daily cost over the preceding days and Actual the day's cost.
+	Expected     float64         `json:"expected"`
+	Actual       float64         `json:"actual"`
+	SpikePercent float64         `json:"spikePercent"`
+	Severity     AnomalySeverity `json:"severity"`
+}
+
+// DetectAnomalies walks each result's DailyCosts and flags the days that cost more than
+// threshold (0.5 for 50%) above the average of up to anomalyTrailingDays preceding days.
+// Results with fewer than minAnomalyDays days of data are skipped.
+func DetectAnomalies(results []CostResult, threshold float64) []Anomaly {
+	var anomalies []Anomaly
+	for _, r := range results {
+		if len(r.DailyCosts) < minAnomalyDays {
+			continue
+		}
+		for day := minAnomalyDays - 1; day < len(r.DailyCosts); day++ {
+			window := r.DailyCosts[max(0, day-anomalyTrailingDays):day]
+			var sum float64
+			for _, cost := range window {
+				sum += cost
+			}
+			expected := sum / float64(len(window))
+			actual := r.DailyCosts[day]
+			if expected <= 0 || actual <= expected*(1+threshold) {
+				continue
+			}
+			spike := (actual - expected) / expected * maxPercent
+			anomaly := Anomaly{
+				ResourceType: r.ResourceType,
+				ResourceID:   r.ResourceID,
+				Currency:     r.Currency,
+				Expected:     expected,
+				Actual:       actual,
+				SpikePercent: spike,
+				Severity:     anomalySeverity(spike),
+			}
+			if !r.StartDate.IsZero() {
+				anomaly.Date = r.StartDate.AddDate(0, 0, day)
+			}
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+	return anomalies
+}
+
+func anomalySeverity(spikePercent float64) AnomalySeverity {
+	switch {
+	case spikePercent >= anomalyHighSpikePercent:
+		return AnomalySeverityHigh
+	case spikePercent >= anomalyMediumSpikePercent:
+		return AnomalySeverityMedium
+	default:
+		return AnomalySeverityLow
+	}
+}
+
+// RenderAnomalies writes the anomalies as a table.
+func RenderAnomalies(writer io.Writer, anomalies []Anomaly) error {
+	if len(anomalies) == 0 {
+		fmt.Fprintln(writer, "No cost anomalies detected.")
+		return nil
+	}
+	fmt.Fprintln(writer, "Cost anomalies:")
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Resource\tDate\tExpected\tActual\tSpike\tSeverity")
+	fmt.Fprintln(w, "--------\t----\t--------\t------\t-----\t--------")
+	for _, a := range anomalies {
+		resource := fmt.Sprintf("%s/%s", a.ResourceType, a.ResourceID)
+		if len(resource) > maxResourceDisplayLen {
+			resource = resource[:maxResourceDisplayLen-len(truncationEllipsis)] + truncationEllipsis
+		}
+		date := "-"
+		if !a.Date.IsZero() {
+			date = a.Date.Format(time.DateOnly)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.2f %s\t%.2f %s\t+%.0f%%\t%s\n",
+			resource, date, a.Expected, a.Currency, a.Actual, a.Currency, a.SpikePercent, a.Severity)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,70 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func anomalyResults() []engine.CostResult {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	return []engine.CostResult{
+		{
+			ResourceType: ec2InstanceType, ResourceID: "web", Currency: "USD", StartDate: start,
+			DailyCosts: []float64{10, 10, 10, 16, 10, 40},
+		},
+		{
+			ResourceType: "aws:s3/bucket:Bucket", ResourceID: "logs", Currency: "USD", StartDate: start,
+			DailyCosts: []float64{1, 9},
+		},
+		{
+			ResourceType: "aws:rds/instance:Instance", ResourceID: "db", Currency: "USD", StartDate: start,
+			DailyCosts: []float64{0, 0, 5},
+		},
+	}
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	anomalies := engine.DetectAnomalies(anomalyResults(), 0.5)
+	require.Len(t, anomalies, 2, "short series and spikes from zero are ignored")
+
+	assert.Equal(t, "web", anomalies[0].ResourceID)
+	assert.Equal(t, time.Date(2026, 9, 4, 0, 0, 0, 0, time.UTC), anomalies[0].Date)
+	assert.InDelta(t, 10.0, anomalies[0].Expected, 0.001)
+	assert.InDelta(t, 16.0, anomalies[0].Actual, 0.001)
+	assert.InDelta(t, 60.0, anomalies[0].SpikePercent, 0.001)
+	assert.Equal(t, engine.AnomalySeverityLow, anomalies[0].Severity)
+
+	assert.InDelta(t, 11.2, anomalies[1].Expected, 0.001, "the trailing average includes earlier spikes")
+	assert.Equal(t, engine.AnomalySeverityHigh, anomalies[1].Severity)
+
+	assert.Len(t, engine.DetectAnomalies(anomalyResults(), 1), 1)
+}
+
+func TestParseAnomalySeverity(t *testing.T) {
+	severity, err := engine.ParseAnomalySeverity("")
+	require.NoError(t, err)
+	assert.Equal(t, engine.AnomalySeverityLow, severity)
+
+	severity, err = engine.ParseAnomalySeverity("High")
+	require.NoError(t, err)
+	assert.True(t, severity.AtLeast(engine.AnomalySeverityMedium))
+	assert.False(t, engine.AnomalySeverityLow.AtLeast(severity))
+
+	_, err = engine.ParseAnomalySeverity("urgent")
+	require.Error(t, err)
+}
+
+func TestRenderAnomalies(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderAnomalies(&buf, engine.DetectAnomalies(anomalyResults(), 0.5)))
+	assert.Regexp(t, `web\s+2026-09-04\s+10\.00 USD\s+16\.00 USD\s+\+60%\s+low`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, engine.RenderAnomalies(&buf, nil))
+	assert.Equal(t, "No cost anomalies detected.\n", buf.String())
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// anomalyWebhookBatchSize is the most events sent in one webhook request, so a run
+	// with many anomalies posts a handful of requests rather than one per event.
+	anomalyWebhookBatchSize = 25
+
+	// anomalyWebhookTimeout bounds each webhook request.
+	anomalyWebhookTimeout = 10 * time.Second
+)
+
+// AnomalyNotifier delivers detected anomalies to an alerting integration.
+type AnomalyNotifier interface {
+	Notify(ctx context.Context, anomalies []Anomaly) error
+}
+
+// AnomalyWebhook posts anomalies as JSON events to a webhook, such as a Slack workflow or
+// PagerDuty events endpoint fronted by a relay. Each request carries up to
+// anomalyWebhookBatchSize events:
+//
+//	{"source": "finfocus", "events": [{"resourceId": ..., "severity": "high", ...}]}
+type AnomalyWebhook struct {
+	URL string
+	// MinSeverity drops less severe anomalies; empty sends all of them.
+	MinSeverity AnomalySeverity
+	HTTPClient  *http.Client
+}
+
+// anomalyWebhookPayload is the body of a webhook request.
+type anomalyWebhookPayload struct {
+	Source string    `json:"source"`
+	Events []Anomaly `json:"events"`
+}
+
+// Notify posts the anomalies at or above MinSeverity in batches. It attempts every batch
+// and returns the joined errors of those that failed.
+func (w *AnomalyWebhook) Notify(ctx context.Context, anomalies []Anomaly) error {
+	minSeverity := w.MinSeverity
+	if minSeverity == "" {
+		minSeverity = AnomalySeverityLow
+	}
+	var events []Anomaly
+	for _, a := range anomalies {
+		if a.Severity.AtLeast(minSeverity) {
+			events = append(events, a)
+		}
+	}
+
+	var errs []error
+	for start := 0; start < len(events); start += anomalyWebhookBatchSize {
+		batch := events[start:min(start+anomalyWebhookBatchSize, len(events))]
+		if err := w.post(ctx, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *AnomalyWebhook) post(ctx context.Context, events []Anomaly) error {
+	body, err := json.Marshal(anomalyWebhookPayload{Source: "finfocus", Events: events})
+	if err != nil {
+		return fmt.Errorf("encoding anomaly events: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, anomalyWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating anomaly webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting anomaly events: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("posting anomaly events: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnomalyWebhook_Notify(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]engine.Anomaly
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload struct {
+			Source string           `json:"source"`
+			Events []engine.Anomaly `json:"events"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "finfocus", payload.Source)
+		mu.Lock()
+		batches = append(batches, payload.Events)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	anomalies := make([]engine.Anomaly, 0, 60)
+	for range 30 {
+		anomalies = append(anomalies,
+			engine.Anomaly{ResourceID: "web", Severity: engine.AnomalySeverityHigh},
+			engine.Anomaly{ResourceID: "db", Severity: engine.AnomalySeverityLow})
+	}
+
+	webhook := &engine.AnomalyWebhook{URL: server.URL, MinSeverity: engine.AnomalySeverityMedium}
+	require.NoError(t, webhook.Notify(context.Background(), anomalies))
+	require.Len(t, batches, 2, "events are batched")
+	assert.Len(t, batches[0], 25)
+	assert.Len(t, batches[1], 5)
+	for _, batch := range batches {
+		for _, event := range batch {
+			assert.Equal(t, "web", event.ResourceID, "anomalies below the minimum severity are not sent")
+		}
+	}
+}
+
+func TestAnomalyWebhook_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := &engine.AnomalyWebhook{URL: server.URL}
+	err := webhook.Notify(context.Background(), []engine.Anomaly{{Severity: engine.AnomalySeverityLow}})
+	require.ErrorContains(t, err, "unexpected status 500")
+
+	require.NoError(t, webhook.Notify(context.Background(), nil), "nothing is posted without anomalies")
+}
@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"strings"
+)
+
+const (
+	// pseudonymHashLen is the number of hex digits in a pseudonym's hash suffix.
+	pseudonymHashLen = 10
+
+	// redactedValue replaces the values of redacted tags.
+	redactedValue = "[redacted]"
+
+	// redactAllTags in the redaction list redacts every tag and annotation value.
+	redactAllTags = "*"
+
+	adapterAggregated = "aggregated"
+)
+
+// Anonymizer replaces resource IDs and URNs with pseudonyms and redacts sensitive tag
+// values so reports can be shared outside the organization. Pseudonyms are derived from a
+// hash of the ID and an optional salt, so a resource gets the same pseudonym wherever it
+// appears in a report, and across reports made with the same salt. Types and costs are
+// left as they are.
+type Anonymizer struct {
+	salt       string
+	redactTags map[string]bool
+}
+
+// NewAnonymizer returns an anonymizer that salts pseudonym hashes with salt and redacts the
+// values of the given tag keys, matched case-insensitively. A key of "*" redacts all tags.
+func NewAnonymizer(salt string, redactTags []string) *Anonymizer {
+	a := &Anonymizer{salt: salt, redactTags: make(map[string]bool, len(redactTags))}
+	for _, key := range redactTags {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			a.redactTags[key] = true
+		}
+	}
+	return a
+}
+
+// Pseudonym returns the stable replacement for a resource ID or URN, prefixed with a short
+// name derived from the resource type (e.g. "instance-3fa2c1d9e0").
+func (a *Anonymizer) Pseudonym(resourceType, id string) string {
+	if id == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(a.salt + "\x00" + id))
+	hash := hex.EncodeToString(sum[:])[:pseudonymHashLen]
+
+	kind := resourceType
+	if i := strings.LastIndexAny(kind, ":/"); i >= 0 {
+		kind = kind[i+1:]
+	}
+	kind = strings.ToLower(kind)
+	if kind == "" {
+		kind = "resource"
+	}
+	return kind + "-" + hash
+}
+
+// AnonymizeResults returns copies of results with pseudonymous resource IDs and
+// identities, IDs in notes and recommendations replaced, and redacted tag values.
+// Aggregated group rows keep their synthetic IDs.
+func (a *Anonymizer) AnonymizeResults(results []CostResult) []CostResult {
+	out := make([]CostResult, len(results))
+	for i, r := range results {
+		if r.Adapter != adapterAggregated && r.ResourceID != "" {
+			pseudonym := a.Pseudonym(r.ResourceType, r.ResourceID)
+			r.Notes = strings.ReplaceAll(r.Notes, r.ResourceID, pseudonym)
+			r.ResourceID = pseudonym
+		}
+		if len(r.Recommendations) > 0 {
+			recs := make([]Recommendation, len(r.Recommendations))
+			for j, rec := range r.Recommendations {
+				if rec.ResourceID != "" {
+					rec.ResourceID = a.Pseudonym(r.ResourceType, rec.ResourceID)
+				}
+				recs[j] = rec
+			}
+			r.Recommendations = recs
+		}
+		if r.Identity != "" {
+			// Not derived from the type, so the identity still matches across type changes.
+			r.Identity = a.Pseudonym("identity", r.Identity)
+		}
+		r.Annotations = a.redact(r.Annotations)
+		r.AllocationTags = a.redact(r.AllocationTags)
+		out[i] = r
+	}
+	return out
+}
+
+// AnonymizeErrors returns copies of errs with pseudonymous resource IDs, also replacing
+// the IDs in error messages.
+func (a *Anonymizer) AnonymizeErrors(errs []ErrorDetail) []ErrorDetail {
+	out := make([]ErrorDetail, len(errs))
+	for i, e := range errs {
+		if e.ResourceID != "" {
+			pseudonym := a.Pseudonym(e.ResourceType, e.ResourceID)
+			if e.Error != nil {
+				e.Error = errors.New(strings.ReplaceAll(e.Error.Error(), e.ResourceID, pseudonym))
+			}
+			e.ResourceID = pseudonym
+		}
+		out[i] = e
+	}
+	return out
+}
+
+// Anonymize returns an anonymized copy of a result set.
+func (a *Anonymizer) Anonymize(results *CostResultWithErrors) *CostResultWithErrors {
+	return &CostResultWithErrors{
+		Results: a.AnonymizeResults(results.Results),
+		Errors:  a.AnonymizeErrors(results.Errors),
+	}
+}
+
+// AnonymizeChanges returns a copy of a cost change explanation with pseudonymous IDs.
+func (a *Anonymizer) AnonymizeChanges(explanation *CostChangeExplanation) *CostChangeExplanation {
+	out := *explanation
+	out.Changes = make([]CostChange, len(explanation.Changes))
+	for i, c := range explanation.Changes {
+		c.ResourceID = a.Pseudonym(c.ResourceType, c.ResourceID)
+		out.Changes[i] = c
+	}
+	return &out
+}
+
+// AnonymizePluginDiffs returns a copy of plugin breakdown comparisons with pseudonymous IDs.
+func (a *Anonymizer) AnonymizePluginDiffs(diffs []PluginDiff) []PluginDiff {
+	out := make([]PluginDiff, len(diffs))
+	for i, d := range diffs {
+		d.ResourceID = a.Pseudonym(d.ResourceType, d.ResourceID)
+		out[i] = d
+	}
+	return out
+}
+
+// AnonymizeAnomalies returns a copy of anomalies with pseudonymous IDs.
+func (a *Anonymizer) AnonymizeAnomalies(anomalies []Anomaly) []Anomaly {
+	out := make([]Anomaly, len(anomalies))
+	for i, anomaly := range anomalies {
+		anomaly.ResourceID = a.Pseudonym(anomaly.ResourceType, anomaly.ResourceID)
+		out[i] = anomaly
+	}
+	return out
+}
+
+// redact returns a copy of tags with the values of redacted keys replaced.
+func (a *Anonymizer) redact(tags map[string]string) map[string]string {
+	if len(tags) == 0 || len(a.redactTags) == 0 {
+		return tags
+	}
+	out := maps.Clone(tags)
+	for k := range out {
+		if a.redactTags[redactAllTags] || a.redactTags[strings.ToLower(k)] {
+			out[k] = redactedValue
+		}
+	}
+	return out
+}
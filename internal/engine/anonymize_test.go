@@ -0,0 +1,76 @@
+package engine_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymizer_Pseudonym(t *testing.T) {
+	a := engine.NewAnonymizer("", nil)
+	const urn = "urn:pulumi:prod::payments::aws:ec2/instance:Instance::payments-db-primary"
+
+	p := a.Pseudonym("aws:ec2/instance:Instance", urn)
+	assert.Regexp(t, `^instance-[0-9a-f]{10}$`, p)
+	assert.Equal(t, p, a.Pseudonym("aws:ec2/instance:Instance", urn), "pseudonyms are deterministic")
+	assert.NotEqual(t, p, a.Pseudonym("aws:ec2/instance:Instance", urn+"-2"))
+	assert.NotEqual(t, p, engine.NewAnonymizer("secret", nil).Pseudonym("aws:ec2/instance:Instance", urn),
+		"the salt changes pseudonyms")
+	assert.Regexp(t, `^resource-[0-9a-f]{10}$`, a.Pseudonym("", "x"))
+	assert.Empty(t, a.Pseudonym("aws:s3/bucket:Bucket", ""))
+}
+
+func TestAnonymizer_Anonymize(t *testing.T) {
+	a := engine.NewAnonymizer("", []string{"Owner"})
+	original := &engine.CostResultWithErrors{
+		Results: []engine.CostResult{
+			{
+				ResourceType:    "aws:ec2/instance:Instance",
+				ResourceID:      "payments-db",
+				Adapter:         "aws-plugin",
+				Monthly:         42,
+				Notes:           "payments-db runs 24/7",
+				Annotations:     map[string]string{"owner": "alice@example.com", "env": "prod"},
+				Recommendations: []engine.Recommendation{{ResourceID: "payments-db", Type: "RIGHTSIZE"}},
+			},
+			{ResourceType: "aws", ResourceID: "aggregated-2-resources", Adapter: "aggregated"},
+		},
+		Errors: []engine.ErrorDetail{{
+			ResourceType: "aws:ec2/instance:Instance",
+			ResourceID:   "payments-db",
+			Error:        errors.New("pricing payments-db: timeout"),
+		}},
+	}
+
+	anonymized := a.Anonymize(original)
+	require.Len(t, anonymized.Results, 2)
+	r := anonymized.Results[0]
+	pseudonym := a.Pseudonym("aws:ec2/instance:Instance", "payments-db")
+	assert.Equal(t, pseudonym, r.ResourceID)
+	assert.Equal(t, "aws:ec2/instance:Instance", r.ResourceType)
+	assert.InDelta(t, 42.0, r.Monthly, 0.001)
+	assert.Equal(t, pseudonym+" runs 24/7", r.Notes)
+	assert.Equal(t, map[string]string{"owner": "[redacted]", "env": "prod"}, r.Annotations)
+	assert.Equal(t, pseudonym, r.Recommendations[0].ResourceID)
+	assert.Equal(t, "aggregated-2-resources", anonymized.Results[1].ResourceID)
+
+	require.Len(t, anonymized.Errors, 1)
+	assert.Equal(t, pseudonym, anonymized.Errors[0].ResourceID, "the same resource gets the same pseudonym")
+	assert.EqualError(t, anonymized.Errors[0].Error, "pricing "+pseudonym+": timeout")
+
+	assert.Equal(t, "payments-db", original.Results[0].ResourceID, "the input is not modified")
+	assert.Equal(t, "alice@example.com", original.Results[0].Annotations["owner"])
+	assert.Equal(t, "payments-db", original.Results[0].Recommendations[0].ResourceID)
+}
+
+func TestAnonymizer_RedactAllTags(t *testing.T) {
+	a := engine.NewAnonymizer("", []string{"*"})
+	results := a.AnonymizeResults([]engine.CostResult{{
+		ResourceID:     "web",
+		AllocationTags: map[string]string{"team": "payments", "cost-center": "cc-42"},
+	}})
+	assert.Equal(t, map[string]string{"team": "[redacted]", "cost-center": "[redacted]"}, results[0].AllocationTags)
+}
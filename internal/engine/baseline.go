@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+// Baseline drift statuses.
+const (
+	DriftIncreased = "increased"
+	DriftDecreased = "decreased"
+	DriftAdded     = "added"
+	DriftRemoved   = "removed"
+)
+
+// costEpsilon ignores floating-point noise when comparing monthly costs.
+const costEpsilon = 0.005
+
+// ErrBaselineExceeded is returned by cost check when projected costs exceed the baseline.
+var ErrBaselineExceeded = errors.New("projected cost exceeds the committed baseline")
+
+// BaselineCheckOptions sets how far costs may rise above the baseline before the check fails.
+// Tolerances are percentages of the baselined cost.
+type BaselineCheckOptions struct {
+	TotalTolerancePercent    float64
+	ResourceTolerancePercent float64
+	// IdentityMatch decides which baseline and current resources are the same resource,
+	// as accepted by ParseIdentityMatch. Empty matches by URN.
+	IdentityMatch string `json:",omitempty"`
+}
+
+// BaselineDrift is a resource whose projected monthly cost differs from the baseline.
+type BaselineDrift struct {
+	ResourceType string  `json:"resourceType"`
+	ResourceID   string  `json:"resourceId"`
+	Baseline     float64 `json:"baseline"`
+	Current      float64 `json:"current"`
+	Delta        float64 `json:"delta"`
+	Status       string  `json:"status"`
+	// BaselineResourceID is the resource's ID in the baseline when it was matched to a
+	// resource with a different ID, such as after a rename.
+	BaselineResourceID string `json:"baselineResourceId,omitempty"`
+	// Exceeded is true when the increase is beyond the resource tolerance. Resources absent
+	// from the baseline exceed it whenever they have a cost.
+	Exceeded bool `json:"exceeded"`
+}
+
+// BaselineCheck is the result of comparing projected costs with a committed baseline.
+type BaselineCheck struct {
+	BaselineTotal float64              `json:"baselineTotal"`
+	CurrentTotal  float64              `json:"currentTotal"`
+	Delta         float64              `json:"delta"`
+	Currency      string               `json:"currency"`
+	TotalExceeded bool                 `json:"totalExceeded"`
+	Options       BaselineCheckOptions `json:"options"`
+	Drifts        []BaselineDrift      `json:"drifts"`
+}
+
+// Passed reports whether neither the total nor any resource exceeded its tolerance.
+func (c *BaselineCheck) Passed() bool {
+	if c.TotalExceeded {
+		return false
+	}
+	for _, d := range c.Drifts {
+		if d.Exceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadBaseline reads a baseline committed from finfocus JSON output: the default
+// "cost projected --output json" document, a --json-envelope document, or a plain array
+// of results.
+func LoadBaseline(path string) ([]CostResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var results []CostResult
+		if unmarshalErr := json.Unmarshal(data, &results); unmarshalErr != nil {
+			return nil, fmt.Errorf("parsing baseline: %w", unmarshalErr)
+		}
+		return results, nil
+	}
+
+	var doc struct {
+		FinFocus *AggregatedResults `json:"finfocus"`
+		Results  []CostResult       `json:"results"`
+	}
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing baseline: %w", unmarshalErr)
+	}
+	switch {
+	case doc.FinFocus != nil:
+		return doc.FinFocus.Resources, nil
+	case doc.Results != nil:
+		return doc.Results, nil
+	default:
+		return nil, errors.New("parsing baseline: no resources found (expected finfocus JSON output)")
+	}
+}
+
+// CompareToBaseline compares current projected costs with baseline costs per resource and
+// in total. Results for the same resource are summed, and resources are matched by
+// opts.IdentityMatch, by type and ID unless set. Resources left unmatched are reported as
+// added or removed.
+func CompareToBaseline(baseline, current []CostResult, opts BaselineCheckOptions) *BaselineCheck {
+	matcher, err := ParseIdentityMatch(opts.IdentityMatch)
+	if err != nil {
+		// Callers validate the match first; an invalid one falls back to URNs.
+		matcher = urnIdentity
+	}
+
+	check := &BaselineCheck{Options: opts, Currency: defaultCurrency, Drifts: []BaselineDrift{}}
+	for _, r := range baseline {
+		check.BaselineTotal += r.Monthly
+	}
+	for _, r := range current {
+		check.CurrentTotal += r.Monthly
+		if r.Currency != "" {
+			check.Currency = r.Currency
+		}
+	}
+	check.Delta = check.CurrentTotal - check.BaselineTotal
+	check.TotalExceeded = exceedsTolerance(check.BaselineTotal, check.CurrentTotal, opts.TotalTolerancePercent)
+
+	for _, p := range matchResults(baseline, current, matcher) {
+		if !p.changed() {
+			continue
+		}
+		drift := BaselineDrift{
+			ResourceType: p.resourceType,
+			ResourceID:   p.resourceID,
+			Baseline:     p.before.monthly,
+			Current:      p.after.monthly,
+			Delta:        p.delta(),
+		}
+		if p.before.present && p.after.present && p.beforeID != p.resourceID {
+			drift.BaselineResourceID = p.beforeID
+		}
+		switch {
+		case !p.before.present:
+			drift.Status = DriftAdded
+			drift.Exceeded = p.after.monthly >= costEpsilon
+		case !p.after.present:
+			drift.Status = DriftRemoved
+		case drift.Delta > 0:
+			drift.Status = DriftIncreased
+			drift.Exceeded = exceedsTolerance(p.before.monthly, p.after.monthly, opts.ResourceTolerancePercent)
+		default:
+			drift.Status = DriftDecreased
+		}
+		check.Drifts = append(check.Drifts, drift)
+	}
+	return check
+}
+
+// exceedsTolerance reports whether current is more than tolerancePercent above baseline.
+func exceedsTolerance(baseline, current, tolerancePercent float64) bool {
+	return current-baseline*(1+tolerancePercent/maxPercent) >= costEpsilon
+}
+
+// RenderBaselineCheck writes the check as JSON, or as a summary line and a drift table.
+func RenderBaselineCheck(writer io.Writer, format OutputFormat, check *BaselineCheck) error {
+	if format == OutputJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(check)
+	}
+	if format != OutputTable {
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	status := "PASS"
+	if !check.Passed() {
+		status = "FAIL"
+	}
+	fmt.Fprintf(writer, "%s  baseline %.2f %s, current %.2f %s (%s, tolerance %g%%)\n",
+		status, check.BaselineTotal, check.Currency, check.CurrentTotal, check.Currency,
+		formatDeltaWithPercent(check.Delta, check.BaselineTotal), check.Options.TotalTolerancePercent)
+	if len(check.Drifts) == 0 {
+		fmt.Fprintln(writer, "No resources drifted from the baseline.")
+		return nil
+	}
+
+	fmt.Fprintln(writer)
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Resource\tBaseline\tCurrent\tChange\tStatus")
+	fmt.Fprintln(w, "--------\t--------\t-------\t------\t------")
+	for _, d := range check.Drifts {
+		status := d.Status
+		if d.Exceeded {
+			status += " (exceeds baseline)"
+		}
+		resource := fmt.Sprintf("%s/%s", d.ResourceType, d.ResourceID)
+		if d.BaselineResourceID != "" {
+			resource += " (was " + urnResourceName(d.BaselineResourceID) + ")"
+		}
+		if len(resource) > maxResourceDisplayLen {
+			resource = resource[:maxResourceDisplayLen-len(truncationEllipsis)] + truncationEllipsis
+		}
+		fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%s\t%s\n",
+			resource, d.Baseline, d.Current, formatDeltaWithPercent(d.Delta, d.Baseline), status)
+	}
+	return w.Flush()
+}
+
+func formatDeltaWithPercent(delta, baseline float64) string {
+	if baseline == 0 {
+		return fmt.Sprintf("%+.2f", delta)
+	}
+	return fmt.Sprintf("%+.2f, %+.1f%%", delta, delta/baseline*maxPercent)
+}
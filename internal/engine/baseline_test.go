@@ -0,0 +1,101 @@
+package engine_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func baselineResult(id string, monthly float64) engine.CostResult {
+	return engine.CostResult{ResourceType: "aws:ec2/instance:Instance", ResourceID: id, Currency: "USD", Monthly: monthly}
+}
+
+func TestCompareToBaseline(t *testing.T) {
+	baseline := []engine.CostResult{baselineResult("web", 100), baselineResult("db", 50), baselineResult("old", 10)}
+	current := []engine.CostResult{baselineResult("web", 104), baselineResult("db", 40), baselineResult("new", 5)}
+
+	check := engine.CompareToBaseline(baseline, current, engine.BaselineCheckOptions{
+		TotalTolerancePercent: 5, ResourceTolerancePercent: 5,
+	})
+	assert.InDelta(t, 160.0, check.BaselineTotal, 0.0001)
+	assert.InDelta(t, 149.0, check.CurrentTotal, 0.0001)
+	assert.False(t, check.TotalExceeded)
+
+	require.Len(t, check.Drifts, 4)
+	byID := make(map[string]engine.BaselineDrift)
+	for _, d := range check.Drifts {
+		byID[d.ResourceID] = d
+	}
+	assert.Equal(t, engine.DriftIncreased, byID["web"].Status)
+	assert.False(t, byID["web"].Exceeded, "4% increase is within the 5% resource tolerance")
+	assert.Equal(t, engine.DriftDecreased, byID["db"].Status)
+	assert.Equal(t, engine.DriftRemoved, byID["old"].Status)
+	assert.Equal(t, engine.DriftAdded, byID["new"].Status)
+	assert.True(t, byID["new"].Exceeded, "new resources have no approved cost")
+	assert.Equal(t, "db", check.Drifts[0].ResourceID, "largest change first")
+	assert.False(t, check.Passed())
+}
+
+func TestCompareToBaseline_TotalTolerance(t *testing.T) {
+	baseline := []engine.CostResult{baselineResult("web", 100)}
+
+	within := engine.CompareToBaseline(baseline, []engine.CostResult{baselineResult("web", 110)},
+		engine.BaselineCheckOptions{TotalTolerancePercent: 10, ResourceTolerancePercent: 10})
+	assert.True(t, within.Passed())
+
+	over := engine.CompareToBaseline(baseline, []engine.CostResult{baselineResult("web", 111)},
+		engine.BaselineCheckOptions{TotalTolerancePercent: 10, ResourceTolerancePercent: 50})
+	assert.True(t, over.TotalExceeded)
+	assert.False(t, over.Passed())
+
+	unchanged := engine.CompareToBaseline(baseline, baseline, engine.BaselineCheckOptions{})
+	assert.Empty(t, unchanged.Drifts)
+	assert.True(t, unchanged.Passed())
+}
+
+func TestLoadBaseline_Formats(t *testing.T) {
+	dir := t.TempDir()
+	results := []engine.CostResult{baselineResult("web", 12.5)}
+
+	var projected bytes.Buffer
+	require.NoError(t, engine.RenderResults(&projected, engine.OutputJSON, results))
+	var envelope bytes.Buffer
+	require.NoError(t, engine.RenderEnvelope(&envelope,
+		engine.NewOutputEnvelope(results, nil, engine.EnvelopeMeta{Command: "cost projected"})))
+
+	for name, content := range map[string]string{
+		"projected.json": projected.String(),
+		"envelope.json":  envelope.String(),
+		"array.json":     `[{"resourceType":"aws:ec2/instance:Instance","resourceId":"web","monthly":12.5}]`,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		loaded, err := engine.LoadBaseline(path)
+		require.NoError(t, err, name)
+		require.Len(t, loaded, 1, name)
+		assert.Equal(t, "web", loaded[0].ResourceID, name)
+		assert.InDelta(t, 12.5, loaded[0].Monthly, 0.0001, name)
+	}
+
+	path := filepath.Join(dir, "other.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"hello":"world"}`), 0o600))
+	_, err := engine.LoadBaseline(path)
+	require.ErrorContains(t, err, "no resources found")
+}
+
+func TestRenderBaselineCheck_Table(t *testing.T) {
+	check := engine.CompareToBaseline(
+		[]engine.CostResult{baselineResult("web", 100)},
+		[]engine.CostResult{baselineResult("web", 150)},
+		engine.BaselineCheckOptions{TotalTolerancePercent: 10},
+	)
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderBaselineCheck(&buf, engine.OutputTable, check))
+	assert.Contains(t, buf.String(), "FAIL  baseline 100.00 USD, current 150.00 USD (+50.00, +50.0%, tolerance 10%)")
+	assert.Contains(t, buf.String(), "increased (exceeds baseline)")
+}
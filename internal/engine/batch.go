@@ -0,0 +1,410 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultBatchConcurrency is how many stacks are estimated at once when the manifest
+	// and command line leave it unset.
+	DefaultBatchConcurrency = 4
+
+	// batchWebhookTimeout bounds the request posting a batch report.
+	batchWebhookTimeout = 30 * time.Second
+
+	// Permissions of the report directory and files written by WriteBatchReports.
+	batchReportDirPerm  = 0o750
+	batchReportFilePerm = 0o600
+)
+
+// Batch stack statuses.
+const (
+	BatchStackOK     = "ok"
+	BatchStackFailed = "failed"
+)
+
+// ErrBatchStacksFailed is returned when one or more stacks of a batch could not be estimated.
+var ErrBatchStacksFailed = errors.New("one or more stacks failed")
+
+// BatchStack is one stack of a batch manifest. Its resources come either from a saved
+// Pulumi preview JSON file or from a command that prints one on stdout, such as
+// "pulumi preview --json --stack prod". Metadata such as owner and environment is carried
+// into the reports and rolled up across the portfolio.
+type BatchStack struct {
+	Name     string            `yaml:"name" json:"name"`
+	Preview  string            `yaml:"preview,omitempty" json:"preview,omitempty"`
+	Command  string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Metadata map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+// BatchManifest lists the stacks estimated by a batch run.
+//
+//	concurrency: 4
+//	stacks:
+//	  - name: payments-prod
+//	    preview: previews/payments-prod.json
+//	    metadata: {owner: payments, environment: prod}
+//	  - name: web-staging
+//	    command: pulumi preview --json --stack staging --cwd web
+//	    metadata: {owner: web, environment: staging}
+type BatchManifest struct {
+	Concurrency int          `yaml:"concurrency,omitempty"`
+	Stacks      []BatchStack `yaml:"stacks"`
+	// Dir is the directory of the manifest file. Preview paths and commands are relative
+	// to it.
+	Dir string `yaml:"-"`
+}
+
+// LoadBatchManifest reads and validates a batch manifest. Each stack needs a unique name
+// and exactly one of preview or command.
+func LoadBatchManifest(path string) (*BatchManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading batch manifest: %w", err)
+	}
+	var manifest BatchManifest
+	if unmarshalErr := yaml.Unmarshal(data, &manifest); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing batch manifest: %w", unmarshalErr)
+	}
+	if len(manifest.Stacks) == 0 {
+		return nil, errors.New("batch manifest lists no stacks")
+	}
+	if manifest.Concurrency < 0 {
+		return nil, errors.New("batch manifest concurrency must not be negative")
+	}
+	seen := make(map[string]bool, len(manifest.Stacks))
+	for i, s := range manifest.Stacks {
+		switch {
+		case s.Name == "":
+			return nil, fmt.Errorf("stack %d: name is required", i+1)
+		case seen[s.Name]:
+			return nil, fmt.Errorf("stack %d: duplicate name %q", i+1, s.Name)
+		case (s.Preview == "") == (s.Command == ""):
+			return nil, fmt.Errorf("stack %q: exactly one of preview or command is required", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	manifest.Dir = filepath.Dir(path)
+	return &manifest, nil
+}
+
+// PreviewPath returns the stack's preview file, resolved against the manifest directory.
+func (m *BatchManifest) PreviewPath(s BatchStack) string {
+	if s.Preview == "" || filepath.IsAbs(s.Preview) {
+		return s.Preview
+	}
+	return filepath.Join(m.Dir, s.Preview)
+}
+
+// BatchEstimator computes the cost of one stack of a batch.
+type BatchEstimator func(ctx context.Context, stack BatchStack) (*CostResultWithErrors, error)
+
+// BatchStackReport is the outcome of estimating one stack. A failed stack carries the
+// error instead of results.
+type BatchStackReport struct {
+	Name          string            `json:"name"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	Status        string            `json:"status"`
+	Error         string            `json:"error,omitempty"`
+	Currency      string            `json:"currency,omitempty"`
+	TotalMonthly  float64           `json:"totalMonthly"`
+	ResourceCount int               `json:"resourceCount"`
+	// PricingErrors counts the resources that could not be priced in an otherwise
+	// successful stack.
+	PricingErrors int          `json:"pricingErrors,omitempty"`
+	Results       []CostResult `json:"results,omitempty"`
+	DurationMs    int64        `json:"durationMs"`
+}
+
+// BatchRollup is the monthly cost of the stacks that share a metadata value, in one
+// currency, such as every stack with environment=prod priced in USD.
+type BatchRollup struct {
+	Key          string  `json:"key"`
+	Value        string  `json:"value"`
+	Currency     string  `json:"currency"`
+	TotalMonthly float64 `json:"totalMonthly"`
+	Stacks       int     `json:"stacks"`
+}
+
+// BatchPortfolio rolls the successful stacks of a batch up into portfolio totals.
+type BatchPortfolio struct {
+	// ByCurrency is the total monthly cost per currency; amounts in different currencies
+	// are never added together.
+	ByCurrency   map[string]float64 `json:"byCurrency"`
+	ByMetadata   []BatchRollup      `json:"byMetadata,omitempty"`
+	StackCount   int                `json:"stackCount"`
+	FailedStacks int                `json:"failedStacks"`
+}
+
+// BatchReport is the result of a batch run: one report per stack, in manifest order,
+// and the portfolio rollup.
+type BatchReport struct {
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Stacks      []BatchStackReport `json:"stacks"`
+	Portfolio   BatchPortfolio     `json:"portfolio"`
+}
+
+// Failed reports whether any stack of the batch failed.
+func (r *BatchReport) Failed() bool {
+	return r.Portfolio.FailedStacks > 0
+}
+
+// RunBatch estimates every stack with estimate, running up to concurrency stacks at
+// once. A stack that fails is recorded in its report and does not stop the others.
+// Stacks not yet started when ctx is cancelled are reported as failed.
+func RunBatch(ctx context.Context, stacks []BatchStack, concurrency int, estimate BatchEstimator) *BatchReport {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	reports := make([]BatchStackReport, len(stacks))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(stacks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i] = runBatchStack(ctx, stacks[i], estimate)
+			}
+		}()
+	}
+	for i, s := range stacks {
+		if err := ctx.Err(); err != nil {
+			reports[i] = failedBatchStack(s, err, 0)
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return &BatchReport{
+		GeneratedAt: time.Now().UTC(),
+		Stacks:      reports,
+		Portfolio:   rollUpBatch(reports),
+	}
+}
+
+// runBatchStack estimates one stack, turning an error or panic into a failed report.
+func runBatchStack(ctx context.Context, stack BatchStack, estimate BatchEstimator) (report BatchStackReport) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			report = failedBatchStack(stack, fmt.Errorf("panic: %v", r), time.Since(start))
+		}
+	}()
+
+	result, err := estimate(ctx, stack)
+	if err != nil {
+		return failedBatchStack(stack, err, time.Since(start))
+	}
+	report = BatchStackReport{
+		Name:          stack.Name,
+		Metadata:      stack.Metadata,
+		Status:        BatchStackOK,
+		Currency:      defaultCurrency,
+		ResourceCount: len(result.Results),
+		PricingErrors: len(result.Errors),
+		Results:       result.Results,
+		DurationMs:    time.Since(start).Milliseconds(),
+	}
+	for i, r := range result.Results {
+		if i == 0 {
+			report.Currency = resultCurrency(r)
+		}
+		report.TotalMonthly += r.Monthly
+	}
+	return report
+}
+
+func failedBatchStack(stack BatchStack, err error, elapsed time.Duration) BatchStackReport {
+	return BatchStackReport{
+		Name:       stack.Name,
+		Metadata:   stack.Metadata,
+		Status:     BatchStackFailed,
+		Error:      err.Error(),
+		DurationMs: elapsed.Milliseconds(),
+	}
+}
+
+// rollUpBatch totals the successful stacks per currency and per metadata value.
+func rollUpBatch(reports []BatchStackReport) BatchPortfolio {
+	portfolio := BatchPortfolio{ByCurrency: make(map[string]float64), StackCount: len(reports)}
+	rollups := make(map[[3]string]*BatchRollup)
+	for _, s := range reports {
+		if s.Status != BatchStackOK {
+			portfolio.FailedStacks++
+			continue
+		}
+		sums := sumByCurrency(s.Results)
+		for currency, t := range sums {
+			portfolio.ByCurrency[currency] += t.monthly
+			for key, value := range s.Metadata {
+				id := [3]string{key, value, currency}
+				if rollups[id] == nil {
+					rollups[id] = &BatchRollup{Key: key, Value: value, Currency: currency}
+				}
+				rollups[id].TotalMonthly += t.monthly
+				rollups[id].Stacks++
+			}
+		}
+	}
+	for _, r := range rollups {
+		portfolio.ByMetadata = append(portfolio.ByMetadata, *r)
+	}
+	sort.Slice(portfolio.ByMetadata, func(i, j int) bool {
+		a, b := portfolio.ByMetadata[i], portfolio.ByMetadata[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.Currency < b.Currency
+	})
+	return portfolio
+}
+
+// RenderBatchReport writes a batch report as a table of stacks followed by the portfolio
+// rollup, or as JSON. Per-resource results are only included in JSON.
+func RenderBatchReport(w io.Writer, format OutputFormat, report *BatchReport) error {
+	if format == OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(tw, "STACK\tSTATUS\tRESOURCES\tMONTHLY\tMETADATA")
+	for _, s := range report.Stacks {
+		if s.Status != BatchStackOK {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t%s\n", s.Name, s.Status, formatBatchMetadata(s.Metadata))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f %s\t%s\n", s.Name, s.Status, s.ResourceCount,
+			s.TotalMonthly, s.Currency, formatBatchMetadata(s.Metadata))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	p := report.Portfolio
+	fmt.Fprintf(w, "\nPORTFOLIO (%d stacks, %d failed)\n", p.StackCount, p.FailedStacks)
+	currencies := make([]string, 0, len(p.ByCurrency))
+	for currency := range p.ByCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		fmt.Fprintf(w, "  Total: %.2f %s/month\n", p.ByCurrency[currency], currency)
+	}
+	for _, r := range p.ByMetadata {
+		fmt.Fprintf(w, "  %s=%s: %.2f %s/month (stacks: %d)\n", r.Key, r.Value, r.TotalMonthly, r.Currency, r.Stacks)
+	}
+	for _, s := range report.Stacks {
+		if s.Status != BatchStackOK {
+			fmt.Fprintf(w, "\nFAILED %s: %s", s.Name, s.Error)
+		}
+	}
+	if p.FailedStacks > 0 {
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// formatBatchMetadata renders metadata as sorted key=value pairs.
+func formatBatchMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for k, v := range metadata {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// WriteBatchReports writes the report of each stack to <dir>/<stack>.json and the
+// portfolio rollup, without per-resource results, to <dir>/portfolio.json.
+func WriteBatchReports(dir string, report *BatchReport) error {
+	if err := os.MkdirAll(dir, batchReportDirPerm); err != nil {
+		return fmt.Errorf("creating report directory: %w", err)
+	}
+	for _, s := range report.Stacks {
+		if err := writeJSONFile(filepath.Join(dir, batchReportFileName(s.Name)), s); err != nil {
+			return err
+		}
+	}
+	summary := struct {
+		GeneratedAt time.Time          `json:"generatedAt"`
+		Stacks      []BatchStackReport `json:"stacks"`
+		Portfolio   BatchPortfolio     `json:"portfolio"`
+	}{GeneratedAt: report.GeneratedAt, Portfolio: report.Portfolio}
+	for _, s := range report.Stacks {
+		s.Results = nil
+		summary.Stacks = append(summary.Stacks, s)
+	}
+	return writeJSONFile(filepath.Join(dir, "portfolio.json"), summary)
+}
+
+// batchReportFileName turns a stack name, which may contain slashes as in
+// "org/project/stack", into a file name.
+func batchReportFileName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(name) + ".json"
+}
+
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", filepath.Base(path), err)
+	}
+	if err = os.WriteFile(path, append(data, '\n'), batchReportFilePerm); err != nil {
+		return fmt.Errorf("writing %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// PostBatchReport posts the batch report as JSON to a webhook.
+func PostBatchReport(ctx context.Context, url string, client *http.Client, report *BatchReport) error {
+	body, err := json.Marshal(struct {
+		Source string       `json:"source"`
+		Report *BatchReport `json:"report"`
+	}{Source: "finfocus", Report: report})
+	if err != nil {
+		return fmt.Errorf("encoding batch report: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, batchWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating batch webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting batch report: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("posting batch report: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
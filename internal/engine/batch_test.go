@@ -0,0 +1,195 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBatchManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "stacks.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	manifest, err := engine.LoadBatchManifest(write(`concurrency: 2
+stacks:
+  - name: prod
+    preview: previews/prod.json
+    metadata: {owner: payments, environment: prod}
+  - name: staging
+    command: cat staging.json
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, manifest.Concurrency)
+	require.Len(t, manifest.Stacks, 2)
+	assert.Equal(t, "payments", manifest.Stacks[0].Metadata["owner"])
+	assert.Equal(t, filepath.Join(dir, "previews", "prod.json"), manifest.PreviewPath(manifest.Stacks[0]))
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no stacks", content: "stacks: []\n", wantErr: "lists no stacks"},
+		{name: "no name", content: "stacks:\n  - preview: a.json\n", wantErr: "stack 1: name is required"},
+		{
+			name:    "duplicate",
+			content: "stacks:\n  - {name: a, preview: a.json}\n  - {name: a, preview: b.json}\n",
+			wantErr: `duplicate name "a"`,
+		},
+		{
+			name:    "both sources",
+			content: "stacks:\n  - {name: a, preview: a.json, command: cat a.json}\n",
+			wantErr: "exactly one of preview or command",
+		},
+		{name: "no source", content: "stacks:\n  - {name: a}\n", wantErr: "exactly one of preview or command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, loadErr := engine.LoadBatchManifest(write(tt.content))
+			require.Error(t, loadErr)
+			assert.Contains(t, loadErr.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunBatch_IsolatesFailuresAndRollsUp(t *testing.T) {
+	stacks := []engine.BatchStack{
+		{Name: "payments-prod", Metadata: map[string]string{"environment": "prod", "owner": "payments"}},
+		{Name: "broken", Metadata: map[string]string{"environment": "prod"}},
+		{Name: "web-prod", Metadata: map[string]string{"environment": "prod", "owner": "web"}},
+		{Name: "web-eu", Metadata: map[string]string{"environment": "prod", "owner": "web"}},
+		{Name: "panics"},
+	}
+	var running, peak atomic.Int32
+	estimate := func(_ context.Context, s engine.BatchStack) (*engine.CostResultWithErrors, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		switch s.Name {
+		case "broken":
+			return nil, errors.New("preview failed")
+		case "panics":
+			panic("boom")
+		case "web-eu":
+			return &engine.CostResultWithErrors{Results: []engine.CostResult{{Monthly: 20, Currency: "EUR"}}}, nil
+		case "web-prod":
+			return &engine.CostResultWithErrors{Results: []engine.CostResult{{Monthly: 30}, {Monthly: 5}}}, nil
+		}
+		return &engine.CostResultWithErrors{Results: []engine.CostResult{{Monthly: 100, Currency: "USD"}}}, nil
+	}
+
+	report := engine.RunBatch(context.Background(), stacks, 2, estimate)
+
+	require.Len(t, report.Stacks, len(stacks))
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.True(t, report.Failed())
+	assert.Equal(t, engine.BatchStackOK, report.Stacks[0].Status)
+	assert.Equal(t, engine.BatchStackFailed, report.Stacks[1].Status)
+	assert.Equal(t, "preview failed", report.Stacks[1].Error)
+	assert.Contains(t, report.Stacks[4].Error, "panic: boom")
+	assert.InDelta(t, 35.0, report.Stacks[2].TotalMonthly, 1e-9)
+	assert.Equal(t, 2, report.Stacks[2].ResourceCount)
+
+	p := report.Portfolio
+	assert.Equal(t, 5, p.StackCount)
+	assert.Equal(t, 2, p.FailedStacks)
+	assert.InDelta(t, 135.0, p.ByCurrency["USD"], 1e-9)
+	assert.InDelta(t, 20.0, p.ByCurrency["EUR"], 1e-9)
+	assert.Equal(t, []engine.BatchRollup{
+		{Key: "environment", Value: "prod", Currency: "EUR", TotalMonthly: 20, Stacks: 1},
+		{Key: "environment", Value: "prod", Currency: "USD", TotalMonthly: 135, Stacks: 2},
+		{Key: "owner", Value: "payments", Currency: "USD", TotalMonthly: 100, Stacks: 1},
+		{Key: "owner", Value: "web", Currency: "EUR", TotalMonthly: 20, Stacks: 1},
+		{Key: "owner", Value: "web", Currency: "USD", TotalMonthly: 35, Stacks: 1},
+	}, p.ByMetadata)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderBatchReport(&buf, engine.OutputTable, report))
+	out := buf.String()
+	assert.Contains(t, out, "PORTFOLIO (5 stacks, 2 failed)")
+	assert.Contains(t, out, "Total: 135.00 USD/month")
+	assert.Contains(t, out, "environment=prod: 135.00 USD/month (stacks: 2)")
+	assert.Contains(t, out, "FAILED broken: preview failed")
+}
+
+func TestRunBatch_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	report := engine.RunBatch(ctx, []engine.BatchStack{{Name: "a"}}, 1,
+		func(context.Context, engine.BatchStack) (*engine.CostResultWithErrors, error) {
+			called = true
+			return &engine.CostResultWithErrors{}, nil
+		})
+	assert.False(t, called)
+	assert.Equal(t, engine.BatchStackFailed, report.Stacks[0].Status)
+	assert.Contains(t, report.Stacks[0].Error, "context canceled")
+}
+
+func TestWriteBatchReports(t *testing.T) {
+	report := engine.RunBatch(context.Background(), []engine.BatchStack{{Name: "org/app/prod"}}, 1,
+		func(context.Context, engine.BatchStack) (*engine.CostResultWithErrors, error) {
+			return &engine.CostResultWithErrors{Results: []engine.CostResult{{ResourceID: "web", Monthly: 10}}}, nil
+		})
+
+	dir := filepath.Join(t.TempDir(), "reports")
+	require.NoError(t, engine.WriteBatchReports(dir, report))
+
+	data, err := os.ReadFile(filepath.Join(dir, "org_app_prod.json"))
+	require.NoError(t, err)
+	var stack engine.BatchStackReport
+	require.NoError(t, json.Unmarshal(data, &stack))
+	require.Len(t, stack.Results, 1)
+	assert.Equal(t, "web", stack.Results[0].ResourceID)
+
+	data, err = os.ReadFile(filepath.Join(dir, "portfolio.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"results"`)
+	assert.Contains(t, string(data), `"byCurrency"`)
+}
+
+func TestPostBatchReport(t *testing.T) {
+	var received struct {
+		Source string             `json:"source"`
+		Report engine.BatchReport `json:"report"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	report := &engine.BatchReport{Stacks: []engine.BatchStackReport{{Name: "prod", Status: engine.BatchStackOK}}}
+	require.NoError(t, engine.PostBatchReport(context.Background(), server.URL, server.Client(), report))
+	assert.Equal(t, "finfocus", received.Source)
+	assert.Equal(t, "prod", received.Report.Stacks[0].Name)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	err := engine.PostBatchReport(context.Background(), failing.URL, failing.Client(), report)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 502")
+}
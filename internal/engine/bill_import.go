@@ -0,0 +1,347 @@
+package engine
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// billImportAdapter is the adapter name of results read from a bill export.
+	billImportAdapter = "bill-import"
+
+	// utf8BOM starts the header of CSV files saved by spreadsheet applications.
+	utf8BOM = "\ufeff"
+)
+
+// Fields of a bill row that a column can be mapped to with BillColumnMap.
+const (
+	BillFieldResourceID = "resource_id"
+	BillFieldCost       = "cost"
+	BillFieldDate       = "date"
+	BillFieldType       = "type"
+	BillFieldProvider   = "provider"
+	BillFieldCurrency   = "currency"
+)
+
+var billFields = []string{
+	BillFieldResourceID, BillFieldCost, BillFieldDate, BillFieldType, BillFieldProvider, BillFieldCurrency,
+}
+
+// billDateLayouts are the date formats recognized in bill exports, tried in order.
+// Slash-separated dates are read month first, as in US billing exports; other orders
+// need BillImportOptions.DateLayout.
+var billDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05Z0700",
+	"2006/01/02",
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/2006 15:04:05",
+	"1/2/2006 15:04",
+	"02.01.2006",
+	"20060102",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+	"02-Jan-2006",
+}
+
+// billCurrencySymbols maps the currency symbols found in formatted amounts to ISO codes.
+var billCurrencySymbols = map[string]string{"$": "USD", "€": "EUR", "£": "GBP", "¥": "JPY", "₹": "INR"}
+
+// BillColumnMap maps bill fields (BillFieldCost, BillFieldDate, ...) to the names of the
+// CSV columns holding them.
+type BillColumnMap map[string]string
+
+// ParseBillColumnMap parses a mapping such as
+// "resource_id=ResourceId,cost=Cost,date=UsageDate,type=ServiceName". The cost and date
+// fields are required, along with a resource ID or a type to attribute costs to.
+func ParseBillColumnMap(mapping string) (BillColumnMap, error) {
+	columns := make(BillColumnMap)
+	for _, pair := range strings.Split(mapping, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid column mapping %q: expected field=Column", pair)
+		}
+		if !isBillField(field) {
+			return nil, fmt.Errorf("unknown bill field %q (fields are %s)", field, strings.Join(billFields, ", "))
+		}
+		columns[field] = column
+	}
+	for _, required := range []string{BillFieldCost, BillFieldDate} {
+		if columns[required] == "" {
+			return nil, fmt.Errorf("column mapping must include %s", required)
+		}
+	}
+	if columns[BillFieldResourceID] == "" && columns[BillFieldType] == "" {
+		return nil, fmt.Errorf("column mapping must include %s or %s", BillFieldResourceID, BillFieldType)
+	}
+	return columns, nil
+}
+
+func isBillField(field string) bool {
+	for _, f := range billFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// BillImportOptions control how bill rows are read.
+type BillImportOptions struct {
+	// Currency is used for rows without a currency column or symbol; USD when empty.
+	Currency string
+	// DateLayout is a Go time layout tried before the recognized formats, for exports
+	// whose dates are ambiguous, such as "02/01/2006" for day-first dates.
+	DateLayout string
+}
+
+// billRow is one parsed line item of a bill.
+type billRow struct {
+	resourceID   string
+	resourceType string
+	currency     string
+	day          time.Time
+	cost         float64
+}
+
+// LoadBill reads a bill export from path; see ImportBill.
+func LoadBill(path string, columns BillColumnMap, opts BillImportOptions) ([]CostResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening bill: %w", err)
+	}
+	defer f.Close()
+	return ImportBill(f, columns, opts)
+}
+
+// ImportBill turns the line items of a CSV bill export into actual cost results, so
+// exported bills can be grouped, aggregated and checked for anomalies without a plugin.
+// Line items are summed per resource and currency into a result covering the whole
+// billing period, from the first to the last day billed, with a daily series of the
+// costs incurred each day. Items without a resource ID are attributed to their type.
+// Amounts may carry currency symbols, thousands separators, or parentheses for credits.
+func ImportBill(r io.Reader, columns BillColumnMap, opts BillImportOptions) ([]CostResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing bill: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("parsing bill: empty CSV")
+	}
+
+	index := make(map[string]int, len(columns))
+	for field, column := range columns {
+		i := billColumnIndex(records[0], column)
+		if i < 0 {
+			return nil, fmt.Errorf("parsing bill: no %q column for %s", column, field)
+		}
+		index[field] = i
+	}
+
+	rows := make([]billRow, 0, len(records)-1)
+	for line, record := range records[1:] {
+		row, skip, rowErr := parseBillRow(record, index, opts)
+		if rowErr != nil {
+			return nil, fmt.Errorf("parsing bill: line %d: %w", line+csvFirstDataLine, rowErr)
+		}
+		if !skip {
+			rows = append(rows, row)
+		}
+	}
+	return billResults(rows), nil
+}
+
+// billColumnIndex finds column in the header, ignoring case, surrounding spaces and a
+// byte order mark.
+func billColumnIndex(header []string, column string) int {
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, utf8BOM)), column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseBillRow parses one line item, reporting skip for blank lines.
+func parseBillRow(record []string, index map[string]int, opts BillImportOptions) (billRow, bool, error) {
+	field := func(name string) string {
+		if i, ok := index[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	if field(BillFieldCost) == "" && field(BillFieldDate) == "" {
+		return billRow{}, true, nil
+	}
+
+	cost, symbolCurrency, err := parseBillAmount(field(BillFieldCost))
+	if err != nil {
+		return billRow{}, false, fmt.Errorf("%s: %w", BillFieldCost, err)
+	}
+	day, err := parseBillDate(field(BillFieldDate), opts.DateLayout)
+	if err != nil {
+		return billRow{}, false, fmt.Errorf("%s: %w", BillFieldDate, err)
+	}
+
+	row := billRow{
+		resourceID:   field(BillFieldResourceID),
+		resourceType: field(BillFieldType),
+		currency:     strings.ToUpper(field(BillFieldCurrency)),
+		day:          day,
+		cost:         cost,
+	}
+	if provider := field(BillFieldProvider); provider != "" && !strings.Contains(row.resourceType, ":") {
+		row.resourceType = strings.ToLower(provider) + ":" + row.resourceType
+	}
+	if row.resourceID == "" {
+		row.resourceID = row.resourceType
+	}
+	if row.resourceID == "" {
+		return billRow{}, false, errors.New("no resource ID or type")
+	}
+	if row.currency == "" {
+		row.currency = symbolCurrency
+	}
+	if row.currency == "" {
+		row.currency = opts.Currency
+	}
+	if row.currency == "" {
+		row.currency = defaultCurrency
+	}
+	return row, false, nil
+}
+
+// parseBillAmount parses amounts such as "12.5", "$1,234.56", "12.00 EUR" or "(3.20)",
+// returning the currency named by a symbol or code in the amount, if any.
+func parseBillAmount(s string) (float64, string, error) {
+	amount := s
+	negative := strings.HasPrefix(amount, "(") && strings.HasSuffix(amount, ")")
+	if negative {
+		amount = amount[1 : len(amount)-1]
+	}
+	var currency string
+	for symbol, code := range billCurrencySymbols {
+		if strings.Contains(amount, symbol) {
+			amount = strings.ReplaceAll(amount, symbol, "")
+			currency = code
+		}
+	}
+	if fields := strings.Fields(amount); len(fields) == 2 { //nolint:mnd // amount and currency code.
+		if code := strings.ToUpper(fields[1]); isCurrencyCode(code) {
+			amount, currency = fields[0], code
+		} else if code = strings.ToUpper(fields[0]); isCurrencyCode(code) {
+			amount, currency = fields[1], code
+		}
+	}
+	amount = strings.ReplaceAll(strings.TrimSpace(amount), ",", "")
+	if amount == "" {
+		return 0, currency, nil
+	}
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		value = -value
+	}
+	return value, currency, nil
+}
+
+// isCurrencyCode reports whether s looks like an ISO 4217 code.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 { //nolint:mnd // ISO 4217 codes have three letters.
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBillDate parses s with layout, when set, or one of billDateLayouts, and returns
+// the UTC day it falls on.
+func parseBillDate(s, layout string) (time.Time, error) {
+	layouts := billDateLayouts
+	if layout != "" {
+		layouts = append([]string{layout}, billDateLayouts...)
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// billResults sums rows per resource and currency over the billing period.
+func billResults(rows []billRow) []CostResult {
+	if len(rows) == 0 {
+		return []CostResult{}
+	}
+	from, to := rows[0].day, rows[0].day
+	for _, row := range rows {
+		if row.day.Before(from) {
+			from = row.day
+		}
+		if row.day.After(to) {
+			to = row.day
+		}
+	}
+	to = to.AddDate(0, 0, 1)
+	days := int(to.Sub(from).Hours() / hoursPerDay)
+
+	byKey := make(map[string]*CostResult)
+	var keys []string
+	for _, row := range rows {
+		key := row.resourceID + "\x00" + row.currency
+		result, ok := byKey[key]
+		if !ok {
+			result = &CostResult{
+				ResourceType: row.resourceType,
+				ResourceID:   row.resourceID,
+				Adapter:      billImportAdapter,
+				Currency:     row.currency,
+				DailyCosts:   make([]float64, days),
+				Notes: fmt.Sprintf("Imported bill from %s to %s",
+					from.Format("2006-01-02"), to.Format("2006-01-02")),
+				StartDate:  from,
+				EndDate:    to,
+				CostPeriod: FormatPeriod(from, to),
+			}
+			byKey[key] = result
+			keys = append(keys, key)
+		}
+		result.TotalCost += row.cost
+		result.DailyCosts[int(row.day.Sub(from).Hours()/hoursPerDay)] += row.cost
+	}
+
+	sort.Strings(keys)
+	results := make([]CostResult, 0, len(keys))
+	for _, key := range keys {
+		result := byKey[key]
+		result.Monthly = result.TotalCost * avgDaysPerMonth / float64(days)
+		result.Hourly = result.TotalCost / (float64(days) * hoursPerDay)
+		results = append(results, *result)
+	}
+	return results
+}
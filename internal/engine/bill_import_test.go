@@ -0,0 +1,115 @@
+package engine_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBillColumnMap(t *testing.T) {
+	columns, err := engine.ParseBillColumnMap("resource_id=ResourceId, cost=Cost,date=UsageDate,type=ServiceName")
+	require.NoError(t, err)
+	assert.Equal(t, engine.BillColumnMap{
+		"resource_id": "ResourceId", "cost": "Cost", "date": "UsageDate", "type": "ServiceName",
+	}, columns)
+
+	tests := []struct {
+		name    string
+		mapping string
+		wantErr string
+	}{
+		{name: "missing cost", mapping: "resource_id=Id,date=Date", wantErr: "must include cost"},
+		{name: "missing attribution", mapping: "cost=Cost,date=Date", wantErr: "resource_id or type"},
+		{name: "unknown field", mapping: "cost=Cost,date=Date,type=T,region=R", wantErr: `unknown bill field "region"`},
+		{name: "no column", mapping: "cost,date=Date,type=T", wantErr: "expected field=Column"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.ParseBillColumnMap(tt.mapping)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestImportBill(t *testing.T) {
+	bill := "\ufeffResourceId,ServiceName,UsageDate,Cost\n" +
+		"i-1,AmazonEC2,2025-01-01,10.00\n" +
+		"i-1,AmazonEC2,01/03/2025,\"$1,000.50\"\n" +
+		"bucket,AmazonS3,2025-01-02T08:00:00Z,2.5\n" +
+		"bucket,AmazonS3,2025-01-02,(0.50)\n" +
+		",,,\n" +
+		",AmazonCloudWatch,2025-01-02,1.25 EUR\n"
+	columns, err := engine.ParseBillColumnMap("resource_id=ResourceId,cost=Cost,date=UsageDate,type=ServiceName")
+	require.NoError(t, err)
+
+	results, err := engine.ImportBill(strings.NewReader(bill), columns, engine.BillImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+	byID := make(map[string]engine.CostResult, len(results))
+	for _, r := range results {
+		assert.Equal(t, from, r.StartDate)
+		assert.Equal(t, to, r.EndDate)
+		assert.Equal(t, "bill-import", r.Adapter)
+		byID[r.ResourceID] = r
+	}
+
+	ec2 := byID["i-1"]
+	assert.Equal(t, "AmazonEC2", ec2.ResourceType)
+	assert.Equal(t, "USD", ec2.Currency)
+	assert.InDelta(t, 1010.5, ec2.TotalCost, 0.0001)
+	assert.InDeltaSlice(t, []float64{10, 0, 1000.5}, ec2.DailyCosts, 0.0001)
+	assert.InDelta(t, 1010.5/3*30.44, ec2.Monthly, 0.0001)
+
+	// Credits in parentheses reduce the cost.
+	assert.InDeltaSlice(t, []float64{0, 2, 0}, byID["bucket"].DailyCosts, 0.0001)
+
+	// Items without a resource ID are attributed to their type, in their own currency.
+	assert.Equal(t, "EUR", byID["AmazonCloudWatch"].Currency)
+}
+
+func TestImportBill_Options(t *testing.T) {
+	bill := "Provider,Service,Day,Amount,Currency\n" +
+		"AWS,ec2,02/01/2025,4,\n" +
+		"AWS,ec2,03/01/2025,6,gbp\n"
+	columns, err := engine.ParseBillColumnMap("provider=Provider,type=Service,date=Day,cost=Amount,currency=Currency")
+	require.NoError(t, err)
+
+	results, err := engine.ImportBill(strings.NewReader(bill), columns,
+		engine.BillImportOptions{Currency: "EUR", DateLayout: "02/01/2006"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "aws:ec2", results[0].ResourceType)
+	assert.Equal(t, "EUR", results[0].Currency)
+	assert.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), results[0].StartDate)
+	assert.Equal(t, "GBP", results[1].Currency)
+	assert.InDeltaSlice(t, []float64{0, 6}, results[1].DailyCosts, 0.0001)
+}
+
+func TestImportBill_Errors(t *testing.T) {
+	columns, err := engine.ParseBillColumnMap("resource_id=Id,cost=Cost,date=Date")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		bill    string
+		wantErr string
+	}{
+		{name: "empty", bill: "", wantErr: "empty CSV"},
+		{name: "missing column", bill: "Id,Cost\ni-1,1\n", wantErr: `no "Date" column for date`},
+		{name: "bad amount", bill: "Id,Cost,Date\ni-1,ten,2025-01-01\n", wantErr: `line 2: cost: invalid amount "ten"`},
+		{name: "bad date", bill: "Id,Cost,Date\ni-1,1,yesterday\n", wantErr: `line 2: date: unrecognized date`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.ImportBill(strings.NewReader(tt.bill), columns, engine.BillImportOptions{})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// ErrBlastRadiusTarget is returned when the blast radius target is not among the resources.
+var ErrBlastRadiusTarget = errors.New("blast radius target not found")
+
+// BlastRadius returns the target resource and every resource that depends on it, directly
+// or through other resources, in their original order. Dependencies include parents, so the
+// blast radius of a component covers its children. Cycles are followed once, and
+// dependencies on URNs that are not among descriptors are ignored.
+func BlastRadius(descriptors []ResourceDescriptor, targetURN string) ([]ResourceDescriptor, error) {
+	dependents := make(map[string][]string)
+	found := false
+	for _, d := range descriptors {
+		found = found || d.ID == targetURN
+		for _, dep := range d.Dependencies {
+			dependents[dep] = append(dependents[dep], d.ID)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrBlastRadiusTarget, targetURN)
+	}
+
+	affected := map[string]bool{targetURN: true}
+	queue := []string{targetURN}
+	for len(queue) > 0 {
+		urn := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[urn] {
+			if !affected[dependent] {
+				affected[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	radius := make([]ResourceDescriptor, 0, len(affected))
+	for _, d := range descriptors {
+		if affected[d.ID] {
+			radius = append(radius, d)
+		}
+	}
+	return radius, nil
+}
+
+// BlastRadiusCost prices the blast radius of targetURN and summarizes it, so the full cost
+// footprint of a shared component is known before it is changed. When no resource carries
+// dependency data the summary covers only the target, and a warning is logged.
+func (e *Engine) BlastRadiusCost(
+	ctx context.Context,
+	descriptors []ResourceDescriptor,
+	targetURN string,
+) (*CostSummary, error) {
+	radius, err := BlastRadius(descriptors, targetURN)
+	if err != nil {
+		return nil, err
+	}
+	if !hasDependencyData(descriptors) {
+		logging.FromContext(ctx).Warn().
+			Ctx(ctx).
+			Str("component", "engine").
+			Str("target", targetURN).
+			Msg("resources carry no dependency data; blast radius covers only the target")
+	}
+
+	results, err := e.GetProjectedCostWithErrors(ctx, radius)
+	if err != nil {
+		return nil, err
+	}
+	summary := AggregateResults(results.Results).Summary
+	return &summary, nil
+}
+
+func hasDependencyData(descriptors []ResourceDescriptor) bool {
+	for _, d := range descriptors {
+		if len(d.Dependencies) > 0 {
+			return true
+		}
+	}
+	return false
+}
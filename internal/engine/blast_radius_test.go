@@ -0,0 +1,65 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blastRadiusResources is a VPC shared by a subnet, whose instance and a second instance
+// depend on it, plus an unrelated bucket. The two instances depend on each other.
+func blastRadiusResources() []engine.ResourceDescriptor {
+	return []engine.ResourceDescriptor{
+		{ID: "vpc", Type: "aws:ec2/vpc:Vpc"},
+		{ID: "subnet", Type: "aws:ec2/subnet:Subnet", Dependencies: []string{"vpc"}},
+		{
+			ID: "web", Type: "aws:ec2/instance:Instance", Dependencies: []string{"subnet", "worker"},
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		},
+		{
+			ID: "worker", Type: "aws:ec2/instance:Instance", Dependencies: []string{"web", "urn:missing"},
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		},
+		{ID: "bucket", Type: "aws:s3/bucket:Bucket"},
+	}
+}
+
+func TestBlastRadius(t *testing.T) {
+	ids := func(descriptors []engine.ResourceDescriptor) []string {
+		var out []string
+		for _, d := range descriptors {
+			out = append(out, d.ID)
+		}
+		return out
+	}
+
+	radius, err := engine.BlastRadius(blastRadiusResources(), "vpc")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc", "subnet", "web", "worker"}, ids(radius))
+
+	radius, err = engine.BlastRadius(blastRadiusResources(), "worker")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web", "worker"}, ids(radius), "cycles are followed once")
+
+	radius, err = engine.BlastRadius(blastRadiusResources(), "bucket")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bucket"}, ids(radius))
+
+	_, err = engine.BlastRadius(blastRadiusResources(), "urn:missing")
+	require.ErrorIs(t, err, engine.ErrBlastRadiusTarget)
+}
+
+func TestBlastRadiusCost(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	unit := unitMonthly(t, eng, engine.ResourceDescriptor{
+		ID: "unit", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	})
+
+	summary, err := eng.BlastRadiusCost(context.Background(), blastRadiusResources(), "subnet")
+	require.NoError(t, err)
+	assert.InDelta(t, 2*unit, summary.TotalMonthly, 0.001)
+	assert.Len(t, summary.Resources, 3)
+}
@@ -0,0 +1,273 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Default budget thresholds, as a percentage of the budget amount.
+const (
+	DefaultBudgetWarnPercent     = 80
+	DefaultBudgetCriticalPercent = 100
+)
+
+// DefaultBudgetTagKey is the resource tag that names a resource's environment.
+const DefaultBudgetTagKey = "environment"
+
+// BudgetStatus is the highest threshold an environment's projected cost has crossed.
+type BudgetStatus string
+
+// Budget statuses, from least to most severe.
+const (
+	BudgetStatusOK       BudgetStatus = "ok"
+	BudgetStatusWarning  BudgetStatus = "warning"
+	BudgetStatusCritical BudgetStatus = "critical"
+)
+
+// budgetStatusRank orders statuses by severity.
+var budgetStatusRank = map[BudgetStatus]int{
+	BudgetStatusOK:       0,
+	BudgetStatusWarning:  1,
+	BudgetStatusCritical: 2,
+}
+
+// AtLeast reports whether s is as severe as other or more.
+func (s BudgetStatus) AtLeast(other BudgetStatus) bool {
+	return budgetStatusRank[s] >= budgetStatusRank[other]
+}
+
+// EnvironmentBudget is the monthly budget of one environment with its alerting thresholds.
+// Zero thresholds take the defaults; an empty Currency means USD.
+type EnvironmentBudget struct {
+	Environment     string
+	Amount          float64
+	Currency        string
+	WarnPercent     float64
+	CriticalPercent float64
+}
+
+// BudgetEvaluation reports an environment's projected monthly cost against its budget.
+// Excluded counts results in another currency, which are not added to Total.
+type BudgetEvaluation struct {
+	Environment string       `json:"environment"`
+	Budget      float64      `json:"budget"`
+	Total       float64      `json:"total"`
+	Currency    string       `json:"currency"`
+	PercentUsed float64      `json:"percentUsed"`
+	Status      BudgetStatus `json:"status"`
+	Excluded    int          `json:"excluded,omitempty"`
+}
+
+// BudgetPolicy holds validated budgets keyed by environment.
+type BudgetPolicy struct {
+	budgets map[string]EnvironmentBudget
+}
+
+// NewBudgetPolicy validates budgets and fills in default thresholds and currency.
+// Environment names are matched case-insensitively.
+func NewBudgetPolicy(budgets []EnvironmentBudget) (*BudgetPolicy, error) {
+	policy := &BudgetPolicy{budgets: make(map[string]EnvironmentBudget, len(budgets))}
+	for _, b := range budgets {
+		b.Environment = strings.ToLower(strings.TrimSpace(b.Environment))
+		if b.Environment == "" {
+			return nil, errors.New("budget environment must not be empty")
+		}
+		if b.Amount <= 0 {
+			return nil, fmt.Errorf("budget %s: amount must be positive, got %g", b.Environment, b.Amount)
+		}
+		if b.WarnPercent == 0 {
+			b.WarnPercent = DefaultBudgetWarnPercent
+		}
+		if b.CriticalPercent == 0 {
+			b.CriticalPercent = DefaultBudgetCriticalPercent
+		}
+		if b.WarnPercent < 0 || b.WarnPercent > b.CriticalPercent {
+			return nil, fmt.Errorf("budget %s: warn threshold %g%% must be between 0 and the critical threshold %g%%",
+				b.Environment, b.WarnPercent, b.CriticalPercent)
+		}
+		if b.Currency == "" {
+			b.Currency = defaultCurrency
+		}
+		b.Currency = strings.ToUpper(b.Currency)
+		policy.budgets[b.Environment] = b
+	}
+	return policy, nil
+}
+
+// Evaluate totals the monthly cost of each environment that has a budget, using
+// environmentOf to map result resource IDs to environments, and reports the threshold each
+// total crosses. Environments without resources in results are not reported. Results are
+// sorted by environment.
+func (p *BudgetPolicy) Evaluate(results []CostResult, environmentOf map[string]string) []BudgetEvaluation {
+	byEnv := make(map[string]*BudgetEvaluation)
+	for _, r := range results {
+		env := strings.ToLower(environmentOf[r.ResourceID])
+		budget, ok := p.budgets[env]
+		if !ok {
+			continue
+		}
+		eval, seen := byEnv[env]
+		if !seen {
+			eval = &BudgetEvaluation{Environment: env, Budget: budget.Amount, Currency: budget.Currency}
+			byEnv[env] = eval
+		}
+		currency := r.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		if !strings.EqualFold(currency, budget.Currency) {
+			eval.Excluded++
+			continue
+		}
+		eval.Total += r.Monthly
+	}
+
+	evaluations := make([]BudgetEvaluation, 0, len(byEnv))
+	for env, eval := range byEnv {
+		budget := p.budgets[env]
+		eval.PercentUsed = eval.Total / budget.Amount * maxPercent
+		switch {
+		case eval.PercentUsed >= budget.CriticalPercent:
+			eval.Status = BudgetStatusCritical
+		case eval.PercentUsed >= budget.WarnPercent:
+			eval.Status = BudgetStatusWarning
+		default:
+			eval.Status = BudgetStatusOK
+		}
+		evaluations = append(evaluations, *eval)
+	}
+	sort.Slice(evaluations, func(i, j int) bool {
+		return evaluations[i].Environment < evaluations[j].Environment
+	})
+	return evaluations
+}
+
+// BudgetDimensionTotal is the dimension of a violation of BudgetLimits.TotalMonthly.
+// Provider caps are reported as "provider:<name>".
+const BudgetDimensionTotal = "total"
+
+// ErrBudgetCurrencyMismatch is returned when budget limits and costs are in different
+// currencies, which cannot be compared.
+var ErrBudgetCurrencyMismatch = errors.New("budget currency does not match the cost currency")
+
+// BudgetLimits caps the projected monthly cost of a whole stack and of each provider.
+// A zero TotalMonthly and providers missing from ByProvider are not capped; an empty
+// Currency means USD.
+type BudgetLimits struct {
+	Currency     string
+	TotalMonthly float64
+	ByProvider   map[string]float64
+}
+
+// BudgetViolation is a budget limit that the projected monthly cost exceeds by Overage.
+type BudgetViolation struct {
+	Dimension string  `json:"dimension"`
+	Limit     float64 `json:"limit"`
+	Actual    float64 `json:"actual"`
+	Overage   float64 `json:"overage"`
+	Currency  string  `json:"currency"`
+}
+
+// EvaluateBudget checks the totals of summary against limits and returns the limits that
+// are exceeded: the total first, then providers by name. Provider names are matched
+// case-insensitively. It returns ErrBudgetCurrencyMismatch when the costs are not all in
+// the limits' currency, and an error when a limit is negative.
+func EvaluateBudget(summary *AggregatedResults, limits BudgetLimits) ([]BudgetViolation, error) {
+	if limits.TotalMonthly < 0 {
+		return nil, fmt.Errorf("budget total limit must not be negative, got %g", limits.TotalMonthly)
+	}
+	providers := make([]string, 0, len(limits.ByProvider))
+	for provider, limit := range limits.ByProvider {
+		if limit < 0 {
+			return nil, fmt.Errorf("budget limit of provider %s must not be negative, got %g", provider, limit)
+		}
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	currency := strings.ToUpper(limits.Currency)
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	if summary.Summary.ByCurrency != nil {
+		return nil, fmt.Errorf("%w: limits are in %s, costs are in %s", ErrBudgetCurrencyMismatch,
+			currency, strings.Join(SortedCurrencies(summary.Summary.ByCurrency), ", "))
+	}
+	costCurrency := strings.ToUpper(summary.Summary.Currency)
+	if costCurrency == "" {
+		costCurrency = defaultCurrency
+	}
+	if costCurrency != currency {
+		return nil, fmt.Errorf("%w: limits are in %s, costs are in %s", ErrBudgetCurrencyMismatch,
+			currency, costCurrency)
+	}
+
+	var violations []BudgetViolation
+	check := func(dimension string, limit, actual float64) {
+		if actual > limit+costEpsilon {
+			violations = append(violations, BudgetViolation{
+				Dimension: dimension, Limit: limit, Actual: actual, Overage: actual - limit, Currency: currency,
+			})
+		}
+	}
+	if limits.TotalMonthly > 0 {
+		check(BudgetDimensionTotal, limits.TotalMonthly, summary.Summary.TotalMonthly)
+	}
+
+	for _, provider := range providers {
+		var actual float64
+		for name, monthly := range summary.Summary.ByProvider {
+			if strings.EqualFold(name, provider) {
+				actual += monthly
+			}
+		}
+		check("provider:"+strings.ToLower(provider), limits.ByProvider[provider], actual)
+	}
+	return violations, nil
+}
+
+// RenderBudgetViolations writes each violation as a row of a table of exceeded limits.
+func RenderBudgetViolations(writer io.Writer, violations []BudgetViolation) error {
+	fmt.Fprintln(writer, "Budget limits exceeded:")
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Dimension\tLimit\tProjected\tOverage")
+	fmt.Fprintln(w, "---------\t-----\t---------\t-------")
+	for _, v := range violations {
+		fmt.Fprintf(w, "%s\t%.2f %s\t%.2f %s\t%.2f %s\n",
+			v.Dimension, v.Limit, v.Currency, v.Actual, v.Currency, v.Overage, v.Currency)
+	}
+	return w.Flush()
+}
+
+// WorstBudgetStatus returns the most severe status among evaluations, or
+// BudgetStatusOK when there are none.
+func WorstBudgetStatus(evaluations []BudgetEvaluation) BudgetStatus {
+	worst := BudgetStatusOK
+	for _, e := range evaluations {
+		if !worst.AtLeast(e.Status) {
+			worst = e.Status
+		}
+	}
+	return worst
+}
+
+// RenderBudgets writes each evaluation as a row of a budget table. statusLabel formats the
+// status column, for example with color; when nil the status is printed in upper case.
+func RenderBudgets(writer io.Writer, evaluations []BudgetEvaluation, statusLabel func(BudgetStatus) string) error {
+	if statusLabel == nil {
+		statusLabel = func(s BudgetStatus) string { return strings.ToUpper(string(s)) }
+	}
+	fmt.Fprintln(writer, "Budgets:")
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Environment\tBudget\tProjected\tUsed\tStatus")
+	fmt.Fprintln(w, "-----------\t------\t---------\t----\t------")
+	for _, e := range evaluations {
+		fmt.Fprintf(w, "%s\t%.2f %s\t%.2f %s\t%.0f%%\t%s\n",
+			e.Environment, e.Budget, e.Currency, e.Total, e.Currency, e.PercentUsed, statusLabel(e.Status))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,130 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetPolicy_Evaluate(t *testing.T) {
+	policy, err := engine.NewBudgetPolicy([]engine.EnvironmentBudget{
+		{Environment: "Prod", Amount: 1000},
+		{Environment: "staging", Amount: 100, WarnPercent: 50, CriticalPercent: 90},
+		{Environment: "dev", Amount: 100},
+		{Environment: "sandbox", Amount: 100},
+	})
+	require.NoError(t, err)
+
+	results := []engine.CostResult{
+		{ResourceID: "web", Currency: "USD", Monthly: 600},
+		{ResourceID: "db", Currency: "USD", Monthly: 250},
+		{ResourceID: "cache", Currency: "EUR", Monthly: 500},
+		{ResourceID: "queue", Currency: "USD", Monthly: 95},
+		{ResourceID: "box", Monthly: 10},
+		{ResourceID: "untagged", Currency: "USD", Monthly: 1e6},
+	}
+	envs := map[string]string{
+		"web": "prod", "db": "PROD", "cache": "prod", "queue": "staging", "box": "dev",
+	}
+
+	evaluations := policy.Evaluate(results, envs)
+	require.Len(t, evaluations, 3, "environments without resources are not reported")
+
+	assert.Equal(t, "dev", evaluations[0].Environment)
+	assert.InDelta(t, 10.0, evaluations[0].Total, 1e-9)
+	assert.Equal(t, engine.BudgetStatusOK, evaluations[0].Status)
+
+	prod := evaluations[1]
+	assert.Equal(t, "prod", prod.Environment)
+	assert.InDelta(t, 850.0, prod.Total, 1e-9)
+	assert.InDelta(t, 85.0, prod.PercentUsed, 1e-9)
+	assert.Equal(t, "USD", prod.Currency)
+	assert.Equal(t, 1, prod.Excluded)
+	assert.Equal(t, engine.BudgetStatusWarning, prod.Status)
+
+	assert.Equal(t, "staging", evaluations[2].Environment)
+	assert.Equal(t, engine.BudgetStatusCritical, evaluations[2].Status)
+
+	assert.Equal(t, engine.BudgetStatusCritical, engine.WorstBudgetStatus(evaluations))
+	assert.Equal(t, engine.BudgetStatusOK, engine.WorstBudgetStatus(nil))
+}
+
+func TestNewBudgetPolicy_Invalid(t *testing.T) {
+	for name, budget := range map[string]engine.EnvironmentBudget{
+		"no environment":      {Amount: 100},
+		"zero amount":         {Environment: "prod"},
+		"warn above critical": {Environment: "prod", Amount: 100, WarnPercent: 120},
+		"negative warn":       {Environment: "prod", Amount: 100, WarnPercent: -5},
+		"critical below warn": {Environment: "prod", Amount: 100, WarnPercent: 90, CriticalPercent: 50},
+	} {
+		_, err := engine.NewBudgetPolicy([]engine.EnvironmentBudget{budget})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRenderBudgets(t *testing.T) {
+	var buf bytes.Buffer
+	evaluations := []engine.BudgetEvaluation{
+		{Environment: "prod", Budget: 1000, Total: 850, Currency: "USD", PercentUsed: 85,
+			Status: engine.BudgetStatusWarning},
+	}
+	require.NoError(t, engine.RenderBudgets(&buf, evaluations, nil))
+	assert.Contains(t, buf.String(), "Budgets:")
+	assert.Regexp(t, `prod\s+1000.00 USD\s+850.00 USD\s+85%\s+WARNING`, buf.String())
+
+	buf.Reset()
+	label := func(s engine.BudgetStatus) string { return "<" + string(s) + ">" }
+	require.NoError(t, engine.RenderBudgets(&buf, evaluations, label))
+	assert.Contains(t, buf.String(), "<warning>")
+}
+
+func TestEvaluateBudget(t *testing.T) {
+	summary := engine.AggregateResults([]engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 600},
+		{ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 250},
+		{ResourceType: "gcp:compute/instance:Instance", Currency: "USD", Monthly: 100},
+	})
+
+	violations, err := engine.EvaluateBudget(summary, engine.BudgetLimits{
+		TotalMonthly: 900,
+		ByProvider:   map[string]float64{"AWS": 800, "gcp": 100, "azure": 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, violations, 2, "limits that are met exactly or unused are not violations")
+	assert.Equal(t, engine.BudgetViolation{
+		Dimension: engine.BudgetDimensionTotal, Limit: 900, Actual: 950, Overage: 50, Currency: "USD",
+	}, violations[0])
+	assert.Equal(t, "provider:aws", violations[1].Dimension)
+	assert.InDelta(t, 50.0, violations[1].Overage, 1e-9)
+
+	violations, err = engine.EvaluateBudget(summary, engine.BudgetLimits{Currency: "usd", TotalMonthly: 1000})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	_, err = engine.EvaluateBudget(summary, engine.BudgetLimits{Currency: "EUR", TotalMonthly: 1000})
+	require.ErrorIs(t, err, engine.ErrBudgetCurrencyMismatch)
+	assert.ErrorContains(t, err, "limits are in EUR, costs are in USD")
+
+	mixed := engine.AggregateResults([]engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 600},
+		{ResourceType: "aws:ec2/instance:Instance", Currency: "EUR", Monthly: 500},
+	})
+	_, err = engine.EvaluateBudget(mixed, engine.BudgetLimits{TotalMonthly: 1000})
+	require.ErrorIs(t, err, engine.ErrBudgetCurrencyMismatch)
+	assert.ErrorContains(t, err, "costs are in EUR, USD")
+
+	_, err = engine.EvaluateBudget(summary, engine.BudgetLimits{ByProvider: map[string]float64{"aws": -1}})
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestRenderBudgetViolations(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderBudgetViolations(&buf, []engine.BudgetViolation{
+		{Dimension: "provider:aws", Limit: 800, Actual: 850, Overage: 50, Currency: "USD"},
+	}))
+	assert.Contains(t, buf.String(), "Budget limits exceeded:")
+	assert.Regexp(t, `provider:aws\s+800.00 USD\s+850.00 USD\s+50.00 USD`, buf.String())
+}
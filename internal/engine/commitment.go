@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxPercent = 100
+
+	// commitmentWildcardService applies a coverage row to every service of a provider.
+	commitmentWildcardService = "*"
+
+	// csvFirstDataLine is the 1-based line number of the first CSV record after the header.
+	csvFirstDataLine = 2
+)
+
+// CommitmentCoverage is one row of a commitment-utilization report: the share of a
+// service's usage covered by savings plans or reservations, and the discount those
+// commitments give relative to on-demand. Service "*" covers the whole provider.
+type CommitmentCoverage struct {
+	Provider        string  `json:"provider"`
+	Service         string  `json:"service"`
+	CoveragePercent float64 `json:"coveragePercent"`
+	DiscountPercent float64 `json:"discountPercent"`
+}
+
+// CommitmentAdjustment records how a result's on-demand cost was blended with committed rates.
+type CommitmentAdjustment struct {
+	CoveragePercent float64 `json:"coveragePercent"`
+	DiscountPercent float64 `json:"discountPercent"`
+	OnDemandMonthly float64 `json:"onDemandMonthly"`
+}
+
+// CommitmentReport indexes coverage rows by provider and service.
+type CommitmentReport struct {
+	coverage map[string]CommitmentCoverage
+}
+
+// NewCommitmentReport validates rows and builds a report. Later rows for the same
+// provider/service replace earlier ones.
+func NewCommitmentReport(rows []CommitmentCoverage) (*CommitmentReport, error) {
+	report := &CommitmentReport{coverage: make(map[string]CommitmentCoverage, len(rows))}
+	for i, row := range rows {
+		row.Provider = strings.ToLower(strings.TrimSpace(row.Provider))
+		row.Service = strings.ToLower(strings.TrimSpace(row.Service))
+		if row.Provider == "" {
+			return nil, fmt.Errorf("commitment row %d: provider is required", i+1)
+		}
+		if row.Service == "" {
+			row.Service = commitmentWildcardService
+		}
+		if row.CoveragePercent < 0 || row.CoveragePercent > maxPercent {
+			return nil, fmt.Errorf("commitment row %d: coverage %.2f%% is outside 0-100", i+1, row.CoveragePercent)
+		}
+		if row.DiscountPercent < 0 || row.DiscountPercent > maxPercent {
+			return nil, fmt.Errorf("commitment row %d: discount %.2f%% is outside 0-100", i+1, row.DiscountPercent)
+		}
+		report.coverage[commitmentKey(row.Provider, row.Service)] = row
+	}
+	return report, nil
+}
+
+// LoadCommitmentReport reads a commitment-utilization export. Files ending in .json hold an
+// array of CommitmentCoverage objects; anything else is read as CSV with a header row
+// naming the provider, service, coverage_percent and discount_percent columns.
+func LoadCommitmentReport(path string) (*CommitmentReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening commitment report: %w", err)
+	}
+	defer f.Close()
+
+	var rows []CommitmentCoverage
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if decodeErr := json.NewDecoder(f).Decode(&rows); decodeErr != nil {
+			return nil, fmt.Errorf("parsing commitment report: %w", decodeErr)
+		}
+	} else {
+		rows, err = readCommitmentCSV(f)
+		if err != nil {
+			return nil, fmt.Errorf("parsing commitment report: %w", err)
+		}
+	}
+	return NewCommitmentReport(rows)
+}
+
+func readCommitmentCSV(r io.Reader) ([]CommitmentCoverage, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty CSV")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"provider", "coverage_percent", "discount_percent"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	rows := make([]CommitmentCoverage, 0, len(records)-1)
+	for line, record := range records[1:] {
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		coverage, covErr := parsePercent(field("coverage_percent"))
+		if covErr != nil {
+			return nil, fmt.Errorf("line %d: coverage_percent: %w", line+csvFirstDataLine, covErr)
+		}
+		discount, discErr := parsePercent(field("discount_percent"))
+		if discErr != nil {
+			return nil, fmt.Errorf("line %d: discount_percent: %w", line+csvFirstDataLine, discErr)
+		}
+		rows = append(rows, CommitmentCoverage{
+			Provider:        field("provider"),
+			Service:         field("service"),
+			CoveragePercent: coverage,
+			DiscountPercent: discount,
+		})
+	}
+	return rows, nil
+}
+
+// parsePercent accepts "70" or "70%".
+func parsePercent(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+}
+
+// Lookup returns the coverage for a service, falling back to the provider-wide row.
+// It reports false when the report has no data, in which case on-demand rates apply.
+func (r *CommitmentReport) Lookup(provider, service string) (CommitmentCoverage, bool) {
+	if r == nil {
+		return CommitmentCoverage{}, false
+	}
+	provider, service = strings.ToLower(provider), strings.ToLower(service)
+	if row, ok := r.coverage[commitmentKey(provider, service)]; ok {
+		return row, true
+	}
+	row, ok := r.coverage[commitmentKey(provider, commitmentWildcardService)]
+	return row, ok
+}
+
+func commitmentKey(provider, service string) string {
+	return provider + "/" + service
+}
+
+// WithCommitmentCoverage sets the commitment report used to blend projected on-demand
+// costs with committed rates and returns the engine for chaining.
+func (e *Engine) WithCommitmentCoverage(report *CommitmentReport) *Engine {
+	e.commitments = report
+	return e
+}
+
+// applyCommitmentCoverage blends each result's on-demand cost with the committed rate:
+// the covered share is charged at (1 - discount) and the rest at on-demand.
+func (e *Engine) applyCommitmentCoverage(results []CostResult, resource ResourceDescriptor) {
+	provider := resource.Provider
+	if provider == "" {
+		provider = extractProviderFromType(resource.Type)
+	}
+	row, ok := e.commitments.Lookup(provider, extractService(resource.Type))
+	if !ok || row.CoveragePercent == 0 || row.DiscountPercent == 0 {
+		return
+	}
+
+	factor := 1 - (row.CoveragePercent/maxPercent)*(row.DiscountPercent/maxPercent)
+	for i := range results {
+		r := &results[i]
+		if r.Monthly <= 0 && r.Hourly <= 0 {
+			continue
+		}
+		r.Commitment = &CommitmentAdjustment{
+			CoveragePercent: row.CoveragePercent,
+			DiscountPercent: row.DiscountPercent,
+			OnDemandMonthly: r.Monthly,
+		}
+		r.Monthly *= factor
+		r.Hourly *= factor
+		for k, v := range r.Breakdown {
+			r.Breakdown[k] = v * factor
+		}
+		note := fmt.Sprintf("Blended rate: %.0f%% commitment coverage at %.0f%% discount (on-demand %.2f %s/month)",
+			row.CoveragePercent, row.DiscountPercent, r.Commitment.OnDemandMonthly, r.Currency)
+		if r.Notes != "" {
+			note = r.Notes + "; " + note
+		}
+		r.Notes = note
+	}
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// recommendationTypePurchaseCommitment is the action type of reservation and savings
+	// plan purchases, the short name plugins use for the same action.
+	recommendationTypePurchaseCommitment = "PURCHASE_COMMITMENT"
+
+	// commitmentRecommendationPrefix precedes the resource ID in the ID of commitment
+	// recommendations, so they can be suppressed like plugin recommendations.
+	commitmentRecommendationPrefix = "finfocus-commitment:"
+
+	// minCommitmentDays is the shortest usage history that commitments are recommended
+	// from; a few days say little about a resource running for a year or more.
+	minCommitmentDays = 7
+)
+
+// commitmentTerm is a reservation term and the spec pricing key of its effective hourly
+// rate, upfront payments included:
+//
+//	pricing:
+//	  onDemandHourly: 0.0104
+//	  reserved1yrHourly: 0.0065
+//	  reserved3yrHourly: 0.0045
+type commitmentTerm struct {
+	name string
+	key  string
+}
+
+var commitmentTerms = []commitmentTerm{
+	{name: "1-year", key: "reserved1yrHourly"},
+	{name: "3-year", key: "reserved3yrHourly"},
+}
+
+// commitmentRates are the on-demand and reserved hourly rates of a resource's spec.
+type commitmentRates struct {
+	sku      string
+	currency string
+	onDemand float64
+	reserved map[string]float64 // by term name
+	hours    float64            // in a month, from Engine.hoursPerMonth
+}
+
+// CommitmentRecommendations fetches the actual costs of the resources whose specs carry
+// reserved rates over [from, to) and returns RecommendCommitments for them, leaving out
+// suppressed recommendations.
+func (e *Engine) CommitmentRecommendations(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+	from, to time.Time,
+) ([]Recommendation, error) {
+	if to.Sub(from) < minCommitmentDays*hoursPerDay*time.Hour {
+		return nil, nil
+	}
+	var candidates []ResourceDescriptor
+	for _, r := range resources {
+		if _, ok := e.commitmentRates(ctx, r); ok {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	actual, err := e.GetActualCost(ctx, candidates, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var recommendations []Recommendation
+	for _, rec := range e.RecommendCommitments(ctx, candidates, actual, from, to) {
+		if !isSuppressed(rec, e.suppressions) {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	return recommendations, nil
+}
+
+// RecommendCommitments recommends reserving the resources that ran steadily enough over
+// [from, to) for a commitment to pay off. A resource's utilization is its actual cost
+// divided by what running it on demand for the whole period would have cost. A
+// commitment is paid for every hour, so it breaks even at the utilization given by the
+// ratio of the reserved to the on-demand rate; sporadically used resources below it are
+// skipped. The term saving the most per month is recommended, with its savings over the
+// on-demand cost of the observed usage. Periods shorter than minCommitmentDays and
+// resources whose specs have no reserved rates yield nothing.
+func (e *Engine) RecommendCommitments(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+	actual []CostResult,
+	from, to time.Time,
+) []Recommendation {
+	periodHours := to.Sub(from).Hours()
+	if periodHours < minCommitmentDays*hoursPerDay {
+		return nil
+	}
+	totals := make(map[string]float64, len(actual))
+	for _, r := range actual {
+		totals[r.ResourceID] = max(totals[r.ResourceID], r.TotalCost)
+	}
+
+	var recommendations []Recommendation
+	for _, resource := range resources {
+		rates, ok := e.commitmentRates(ctx, resource)
+		total := totals[resource.ID]
+		if !ok || total <= 0 {
+			continue
+		}
+		utilization := min(1, total/(rates.onDemand*periodHours))
+		onDemandMonthly := utilization * rates.onDemand * rates.hours
+
+		var best commitmentTerm
+		var bestSavings float64
+		for _, term := range commitmentTerms {
+			reserved, found := rates.reserved[term.name]
+			if savings := onDemandMonthly - reserved*rates.hours; found && savings > bestSavings {
+				best, bestSavings = term, savings
+			}
+		}
+		if bestSavings <= 0 {
+			continue
+		}
+		breakEven := rates.reserved[best.name] / rates.onDemand
+		recommendations = append(recommendations, Recommendation{
+			ID:         commitmentRecommendationPrefix + resource.ID,
+			ResourceID: resource.ID,
+			Type:       recommendationTypePurchaseCommitment,
+			Description: fmt.Sprintf("Reserve %s for %s: ran %.0f%% of the time over %.0f days, "+
+				"above the %.0f%% break-even", rates.sku, best.name, utilization*maxPercent,
+				periodHours/hoursPerDay, breakEven*maxPercent),
+			EstimatedSavings: bestSavings,
+			Currency:         rates.currency,
+		})
+	}
+	return recommendations
+}
+
+// commitmentRates reads the on-demand and reserved hourly rates from the resource's spec,
+// reporting false when it has no on-demand rate or no reserved rate.
+func (e *Engine) commitmentRates(ctx context.Context, resource ResourceDescriptor) (commitmentRates, bool) {
+	if e.loader == nil {
+		return commitmentRates{}, false
+	}
+	spec := e.loadSpecWithFallback(ctx, resource.Provider, extractService(resource.Type),
+		extractSKU(resource), fallbackSKUs(resource)...)
+	if spec == nil {
+		return commitmentRates{}, false
+	}
+	hours := e.hoursPerMonth(spec)
+	_, onDemand, hourly := tryHourlyRates(spec.Pricing, hours)
+	if !hourly || onDemand <= 0 {
+		return commitmentRates{}, false
+	}
+	rates := commitmentRates{
+		sku:      spec.SKU,
+		currency: spec.Currency,
+		onDemand: onDemand,
+		reserved: make(map[string]float64, len(commitmentTerms)),
+		hours:    hours,
+	}
+	for _, term := range commitmentTerms {
+		if rate, found := getFloatFromPricing(spec.Pricing, term.key); found && rate > 0 {
+			rates.reserved[term.name] = rate
+		}
+	}
+	return rates, len(rates.reserved) > 0
+}
@@ -0,0 +1,146 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fixedActualAPI reports the same actual cost for every resource.
+type fixedActualAPI struct {
+	proto.CostSourceClient
+
+	total float64
+}
+
+func (a *fixedActualAPI) GetActualCost(
+	_ context.Context,
+	_ *proto.GetActualCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	return &proto.GetActualCostResponse{
+		Results: []*proto.ActualCostResult{{Currency: "USD", TotalCost: a.total}},
+	}, nil
+}
+
+func newCommitmentSpecLoader(t *testing.T) *spec.Loader {
+	t.Helper()
+	return writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.1\n  reserved1yrHourly: 0.065\n  reserved3yrHourly: 0.045\n",
+		"aws-ec2-m5.large.yaml": "provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.1\n",
+	})
+}
+
+func TestRecommendCommitments(t *testing.T) {
+	const days = 30
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, days)
+	periodHours := float64(days * 24)
+
+	tests := []struct {
+		name         string
+		instanceType string
+		utilization  float64
+		to           time.Time
+		wantSavings  float64
+		wantContains string
+	}{
+		{
+			name:         "steady usage reserves for three years",
+			instanceType: "t3.micro",
+			utilization:  1,
+			wantSavings:  (0.1 - 0.045) * 730,
+			wantContains: "Reserve t3.micro for 3-year: ran 100% of the time over 30 days, above the 45% break-even",
+		},
+		{
+			name:         "partial usage above the three-year break-even",
+			instanceType: "t3.micro",
+			utilization:  0.6,
+			wantSavings:  (0.06 - 0.045) * 730,
+			wantContains: "ran 60% of the time",
+		},
+		{
+			name:         "sporadic usage is not worth reserving",
+			instanceType: "t3.micro",
+			utilization:  0.3,
+		},
+		{
+			name:         "spec without reserved rates",
+			instanceType: "m5.large",
+			utilization:  1,
+		},
+		{
+			name:         "too little history",
+			instanceType: "t3.micro",
+			utilization:  1,
+			to:           from.AddDate(0, 0, 3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := to
+			if !tt.to.IsZero() {
+				end = tt.to
+			}
+			resources := []engine.ResourceDescriptor{{
+				Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+				Properties: map[string]interface{}{"instanceType": tt.instanceType},
+			}}
+			actual := []engine.CostResult{{ResourceID: "web", TotalCost: tt.utilization * 0.1 * periodHours}}
+
+			recs := newTestEngine(t, nil, newCommitmentSpecLoader(t)).
+				RecommendCommitments(context.Background(), resources, actual, from, end)
+
+			if tt.wantContains == "" {
+				assert.Empty(t, recs)
+				return
+			}
+			require.Len(t, recs, 1)
+			assert.Equal(t, "finfocus-commitment:web", recs[0].ID)
+			assert.Equal(t, "web", recs[0].ResourceID)
+			assert.Equal(t, "PURCHASE_COMMITMENT", recs[0].Type)
+			assert.Contains(t, recs[0].Description, tt.wantContains)
+			assert.InDelta(t, tt.wantSavings, recs[0].EstimatedSavings, 0.0001)
+			assert.Equal(t, "USD", recs[0].Currency)
+		})
+	}
+}
+
+func TestCommitmentRecommendations(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+	clients := []*pluginhost.Client{{Name: "aws-billing", API: &fixedActualAPI{total: 0.1 * 240}}}
+	resources := []engine.ResourceDescriptor{
+		{
+			Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		},
+		{
+			Type: "aws:ec2/instance:Instance", ID: "batch", Provider: "aws",
+			Properties: map[string]interface{}{"instanceType": "m5.large"},
+		},
+	}
+
+	recs, err := newTestEngine(t, clients, newCommitmentSpecLoader(t)).
+		CommitmentRecommendations(context.Background(), resources, from, to)
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	assert.Equal(t, "web", recs[0].ResourceID)
+	assert.InDelta(t, (0.1-0.045)*730, recs[0].EstimatedSavings, 0.0001)
+
+	recs, err = newTestEngine(t, clients, newCommitmentSpecLoader(t)).
+		WithRecommendationSuppressions([]engine.RecommendationSuppression{{Type: "purchase_commitment"}}).
+		CommitmentRecommendations(context.Background(), resources, from, to)
+	require.NoError(t, err)
+	assert.Empty(t, recs)
+}
@@ -0,0 +1,88 @@
+package engine_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCommitmentReport_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.csv")
+	content := "provider,service,coverage_percent,discount_percent\n" +
+		"aws,ec2,70%,30\n" +
+		"aws,,50,20\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	report, err := engine.LoadCommitmentReport(path)
+	require.NoError(t, err)
+
+	row, ok := report.Lookup("aws", "ec2")
+	require.True(t, ok)
+	assert.InDelta(t, 70.0, row.CoveragePercent, 0.0001)
+	assert.InDelta(t, 30.0, row.DiscountPercent, 0.0001)
+
+	row, ok = report.Lookup("AWS", "rds")
+	require.True(t, ok, "provider-wide row applies to other services")
+	assert.InDelta(t, 50.0, row.CoveragePercent, 0.0001)
+
+	_, ok = report.Lookup("gcp", "compute")
+	assert.False(t, ok)
+}
+
+func TestLoadCommitmentReport_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.json")
+	content := `[{"provider":"gcp","service":"compute","coveragePercent":40,"discountPercent":25}]`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	report, err := engine.LoadCommitmentReport(path)
+	require.NoError(t, err)
+	row, ok := report.Lookup("gcp", "compute")
+	require.True(t, ok)
+	assert.InDelta(t, 25.0, row.DiscountPercent, 0.0001)
+}
+
+func TestNewCommitmentReport_Validation(t *testing.T) {
+	_, err := engine.NewCommitmentReport([]engine.CommitmentCoverage{{Service: "ec2", CoveragePercent: 10}})
+	require.ErrorContains(t, err, "provider is required")
+
+	_, err = engine.NewCommitmentReport([]engine.CommitmentCoverage{{Provider: "aws", CoveragePercent: 120}})
+	require.ErrorContains(t, err, "outside 0-100")
+}
+
+func TestGetProjectedCost_CommitmentCoverage(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	resource := engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}
+	onDemand := unitMonthly(t, eng, resource)
+
+	report, err := engine.NewCommitmentReport([]engine.CommitmentCoverage{
+		{Provider: "aws", Service: "ec2", CoveragePercent: 50, DiscountPercent: 40},
+	})
+	require.NoError(t, err)
+
+	results, err := eng.WithCommitmentCoverage(report).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	r := results[0]
+	assert.InDelta(t, onDemand*0.8, r.Monthly, 0.0001)
+	require.NotNil(t, r.Commitment)
+	assert.InDelta(t, onDemand, r.Commitment.OnDemandMonthly, 0.0001)
+	assert.Contains(t, r.Notes, "50% commitment coverage")
+
+	gcpResults, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type: "gcp:compute/instance:Instance", ID: "vm", Provider: "gcp",
+		Properties: map[string]interface{}{"machineType": "e2-medium"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, gcpResults, 1)
+	assert.Nil(t, gcpResults[0].Commitment, "services without coverage data stay on-demand")
+}
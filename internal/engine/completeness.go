@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Completeness scores at or above these thresholds map to high and medium confidence.
+const (
+	completenessHighThreshold   = 1.0
+	completenessMediumThreshold = 0.5
+)
+
+// EstimateCompleteness records how many of a resource's pricing-relevant properties were
+// resolvable. Score is the resolved fraction, from 0 to 1; Missing names the properties
+// that were absent, so pricing fell back to defaults for them.
+type EstimateCompleteness struct {
+	Score   float64  `json:"score"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+// pricingProperty is a property that changes the price of a resource, together with the
+// resource property names it can be read from.
+type pricingProperty struct {
+	name string
+	keys []string
+}
+
+// Pricing-relevant properties of cloud resources. The SKU and region keys mirror what the
+// plugin adapter extracts before calling a plugin.
+var (
+	skuPricingProperty = pricingProperty{name: "sku", keys: []string{
+		"instanceType", "instanceClass", "dbInstanceClass", "machineType", "vmSize", "nodeType",
+		"sku", "size", "tier", "type",
+	}}
+	regionPricingProperty = pricingProperty{name: "region", keys: []string{
+		"region", "location", "availabilityZone", "zone",
+	}}
+	storagePricingProperty = pricingProperty{name: "storageSize", keys: []string{
+		"size", "sizeGb", "diskSizeGb", "volumeSize", "allocatedStorage",
+	}}
+)
+
+// completenessProviders are the providers whose resources are priced from SKU and region.
+// Other resources, such as Kubernetes workloads, are priced from different inputs and are
+// not scored.
+var completenessProviders = map[string]bool{
+	"aws":           true,
+	"azure":         true,
+	"azure-native":  true,
+	"gcp":           true,
+	"google-native": true,
+}
+
+// pricingPropertiesFor returns the pricing-relevant properties of a resource type, or nil
+// when the type is not scored. Storage size matters only for disks, volumes and databases.
+func pricingPropertiesFor(resourceType string) []pricingProperty {
+	provider := strings.ToLower(extractProviderFromType(resourceType))
+	if !completenessProviders[provider] {
+		return nil
+	}
+	props := []pricingProperty{skuPricingProperty, regionPricingProperty}
+	lower := strings.ToLower(resourceType)
+	if strings.Contains(lower, "volume") || strings.Contains(lower, "disk") ||
+		strings.Contains(lower, "rds/instance") {
+		props = append(props, storagePricingProperty)
+	}
+	return props
+}
+
+// ScoreCompleteness scores how many pricing-relevant properties of resource are set. It
+// returns nil for resources that are not scored. An AWS resource without a region property
+// counts its region as resolved when AWS_REGION or AWS_DEFAULT_REGION is set, as the plugin
+// adapter falls back to them.
+func ScoreCompleteness(resource ResourceDescriptor) *EstimateCompleteness {
+	props := pricingPropertiesFor(resource.Type)
+	if len(props) == 0 {
+		return nil
+	}
+
+	var missing []string
+	for _, p := range props {
+		if hasPricingProperty(resource, p) {
+			continue
+		}
+		missing = append(missing, p.name)
+	}
+	return &EstimateCompleteness{
+		Score:   float64(len(props)-len(missing)) / float64(len(props)),
+		Missing: missing,
+	}
+}
+
+func hasPricingProperty(resource ResourceDescriptor, p pricingProperty) bool {
+	for _, key := range p.keys {
+		if v, ok := resource.Properties[key]; ok && v != nil && fmt.Sprint(v) != "" {
+			return true
+		}
+	}
+	isAWS := strings.EqualFold(extractProviderFromType(resource.Type), "aws")
+	if p.name == regionPricingProperty.name && isAWS {
+		return os.Getenv("AWS_REGION") != "" || os.Getenv("AWS_DEFAULT_REGION") != ""
+	}
+	return false
+}
+
+// ConfidenceFromCompleteness maps a completeness score to a confidence level: every
+// property resolved is high, at least half is medium, and fewer is low.
+func ConfidenceFromCompleteness(c *EstimateCompleteness) Confidence {
+	switch {
+	case c == nil:
+		return ConfidenceUnknown
+	case c.Score >= completenessHighThreshold:
+		return ConfidenceHigh
+	case c.Score >= completenessMediumThreshold:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+// confidenceRank orders confidence levels from least to most reliable.
+var confidenceRank = map[Confidence]int{
+	ConfidenceUnknown: 0,
+	ConfidenceLow:     1,
+	ConfidenceMedium:  2,
+	ConfidenceHigh:    3,
+}
+
+// scoreResults sets Completeness on priced results and lowers their Confidence to what the
+// score supports. Results with no pricing data are left unscored.
+func scoreResults(results []CostResult, resource ResourceDescriptor) {
+	completeness := ScoreCompleteness(resource)
+	if completeness == nil {
+		return
+	}
+	supported := ConfidenceFromCompleteness(completeness)
+	for i := range results {
+		if results[i].Adapter == "none" {
+			continue
+		}
+		results[i].Completeness = completeness
+		if results[i].Confidence == ConfidenceUnknown ||
+			confidenceRank[supported] < confidenceRank[results[i].Confidence] {
+			results[i].Confidence = supported
+		}
+	}
+}
+
+// RenderCompleteness writes the confidence, completeness score and missing properties of
+// each scored result as a table.
+func RenderCompleteness(writer io.Writer, results []CostResult) error {
+	fmt.Fprintln(writer, "Estimate confidence:")
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Resource\tConfidence\tCompleteness\tMissing")
+	fmt.Fprintln(w, "--------\t----------\t------------\t-------")
+	for _, r := range results {
+		if r.Completeness == nil {
+			continue
+		}
+		resource := fmt.Sprintf("%s/%s", r.ResourceType, r.ResourceID)
+		if len(resource) > maxResourceDisplayLen {
+			resource = resource[:maxResourceDisplayLen-len(truncationEllipsis)] + truncationEllipsis
+		}
+		missing := strings.Join(r.Completeness.Missing, ", ")
+		if missing == "" {
+			missing = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.0f%%\t%s\n",
+			resource, r.Confidence.DisplayLabel(), r.Completeness.Score*maxPercent, missing)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,101 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreCompleteness(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	tests := []struct {
+		name       string
+		resource   engine.ResourceDescriptor
+		score      float64
+		missing    []string
+		confidence engine.Confidence
+	}{
+		{
+			name: "all properties set",
+			resource: engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+				"instanceType": "t3.micro", "availabilityZone": "us-east-1a",
+			}},
+			score:      1,
+			confidence: engine.ConfidenceHigh,
+		},
+		{
+			name: "region missing",
+			resource: engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+				"instanceType": "t3.micro",
+			}},
+			score:      0.5,
+			missing:    []string{"region"},
+			confidence: engine.ConfidenceMedium,
+		},
+		{
+			name: "volume without size",
+			resource: engine.ResourceDescriptor{Type: "aws:ebs/volume:Volume", Properties: map[string]interface{}{
+				"availabilityZone": "us-east-1a",
+			}},
+			score:      1.0 / 3,
+			missing:    []string{"sku", "storageSize"},
+			confidence: engine.ConfidenceLow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := engine.ScoreCompleteness(tt.resource)
+			require.NotNil(t, c)
+			assert.InDelta(t, tt.score, c.Score, 1e-9)
+			assert.Equal(t, tt.missing, c.Missing)
+			assert.Equal(t, tt.confidence, engine.ConfidenceFromCompleteness(c))
+		})
+	}
+}
+
+func TestScoreCompleteness_AWSRegionFromEnvironment(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	c := engine.ScoreCompleteness(engine.ResourceDescriptor{
+		Type:       "aws:ec2/instance:Instance",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	})
+	require.NotNil(t, c)
+	assert.InDelta(t, 1.0, c.Score, 1e-9)
+}
+
+func TestScoreCompleteness_UnscoredProvider(t *testing.T) {
+	assert.Nil(t, engine.ScoreCompleteness(engine.ResourceDescriptor{Type: "kubernetes:apps/v1:Deployment"}))
+}
+
+func TestGetProjectedCost_Completeness(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	eng := newScalingGroupTestEngine(t)
+
+	result, err := eng.GetProjectedCostWithErrors(context.Background(), []engine.ResourceDescriptor{{
+		Type:       "aws:ec2/instance:Instance",
+		ID:         "web",
+		Provider:   "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+
+	r := result.Results[0]
+	require.NotNil(t, r.Completeness)
+	assert.Equal(t, []string{"region"}, r.Completeness.Missing)
+	assert.Equal(t, engine.ConfidenceMedium, r.Confidence)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderCompleteness(&buf, result.Results))
+	assert.Contains(t, buf.String(), "aws:ec2/instance:Instance/web")
+	assert.Contains(t, buf.String(), "MEDIUM")
+	assert.Contains(t, buf.String(), "50%")
+}
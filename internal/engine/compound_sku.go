@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+)
+
+// compoundSKUProperties lists, for resource types priced on more than one attribute, the
+// properties whose values are joined in order into the SKU used to look up their spec.
+// Keys are "provider:service:type" in lower case, so "aws:rds/instance:Instance" and
+// "aws:rds:Instance" share an entry. Supporting another resource type is a new entry here.
+var compoundSKUProperties = map[string][]string{
+	"aws:rds:instance":          {"instanceClass", "engine"},
+	"aws:rds:cluster":           {"engine", "engineMode"},
+	"aws:elasticache:cluster":   {"nodeType", "engine"},
+	"aws:docdb:clusterinstance": {"instanceClass", "engine"},
+}
+
+// compoundSKUKey returns the compoundSKUProperties key of a resource type.
+func compoundSKUKey(resourceType string) string {
+	parts := strings.Split(resourceType, ":")
+	if len(parts) < minProviderServiceTypeParts {
+		return ""
+	}
+	return strings.ToLower(parts[0] + ":" + extractService(resourceType) + ":" + parts[2])
+}
+
+// compoundSKUParts returns the values of the resource's compound SKU properties, in order,
+// up to the first that is missing. It returns nil for types without compound SKUs.
+func compoundSKUParts(resource ResourceDescriptor) []string {
+	keys := compoundSKUProperties[compoundSKUKey(resource.Type)]
+	if len(keys) == 0 || resource.Properties == nil {
+		return nil
+	}
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, found := getStringProperty(resource.Properties, key)
+		if !found || value == "" {
+			break
+		}
+		parts = append(parts, value)
+	}
+	return parts
+}
+
+// extractSKUCandidates returns the SKUs to look a resource's spec up by, most specific
+// first: its compound SKU ("db.t3.micro-mysql"), each shorter prefix of it
+// ("db.t3.micro"), and then the single-attribute SKU from its properties or type.
+func extractSKUCandidates(resource ResourceDescriptor) []string {
+	parts := compoundSKUParts(resource)
+	candidates := make([]string, 0, len(parts)+1)
+	for n := len(parts); n > 0; n-- {
+		candidates = append(candidates, strings.Join(parts[:n], "-"))
+	}
+	if sku := extractSingleSKU(resource); sku != "" && !slices.Contains(candidates, sku) {
+		candidates = append(candidates, sku)
+	}
+	return candidates
+}
+
+// fallbackSKUs returns the SKUs to try, in order, when no spec matches extractSKU.
+func fallbackSKUs(resource ResourceDescriptor) []string {
+	if candidates := extractSKUCandidates(resource); len(candidates) > 1 {
+		return candidates[1:]
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProjectedCost_CompoundSKU(t *testing.T) {
+	loader := writeSpecs(t, map[string]string{
+		"aws-rds-db.t3.micro-mysql.yaml": "provider: aws\nservice: rds\nsku: db.t3.micro-mysql\ncurrency: USD\n" +
+			"pricing:\n  monthlyEstimate: 12.5\n",
+		"aws-rds-db.t3.micro.yaml": "provider: aws\nservice: rds\nsku: db.t3.micro\ncurrency: USD\n" +
+			"pricing:\n  monthlyEstimate: 20.5\n",
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  monthlyEstimate: 7.5\n",
+	})
+	eng := newTestEngine(t, nil, loader)
+
+	rds := func(properties map[string]interface{}) engine.ResourceDescriptor {
+		return engine.ResourceDescriptor{
+			Type: "aws:rds/instance:Instance", ID: "db", Provider: "aws", Properties: properties,
+		}
+	}
+	tests := []struct {
+		name     string
+		resource engine.ResourceDescriptor
+		monthly  float64
+	}{
+		{
+			name:     "compound spec",
+			resource: rds(map[string]interface{}{"instanceClass": "db.t3.micro", "engine": "mysql"}),
+			monthly:  12.5,
+		},
+		{
+			name:     "falls back to instance class",
+			resource: rds(map[string]interface{}{"instanceClass": "db.t3.micro", "engine": "postgres"}),
+			monthly:  20.5,
+		},
+		{
+			name:     "missing engine",
+			resource: rds(map[string]interface{}{"instanceClass": "db.t3.micro"}),
+			monthly:  20.5,
+		},
+		{
+			name: "single attribute type",
+			resource: engine.ResourceDescriptor{
+				Type: "aws:ec2:Instance", ID: "i-1", Provider: "aws",
+				Properties: map[string]interface{}{"instanceType": "t3.micro"},
+			},
+			monthly: 7.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{tt.resource})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, "local-spec", results[0].Adapter)
+			assert.InDelta(t, tt.monthly, results[0].Monthly, 1e-9)
+		})
+	}
+}
@@ -71,7 +71,7 @@ func TestEngineConcurrency(t *testing.T) {
 	defer client.Close()
 
 	// Create engine
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng := newTestEngine(t, []*pluginhost.Client{client}, nil)
 
 	// Concurrency parameters
 	concurrency := 50
@@ -174,7 +174,7 @@ func TestEngineConcurrency_SharedState(t *testing.T) {
 	require.NoError(t, err)
 	defer client.Close()
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng := newTestEngine(t, []*pluginhost.Client{client}, nil)
 
 	// Simulate concurrent read/write to plugin configuration while engine is querying
 	// This tests the thread safety of the mock plugin itself as well as the engine's handling
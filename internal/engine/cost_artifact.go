@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// costArtifactVersion is the format version of the cost artifact, bumped on incompatible
+// changes.
+const costArtifactVersion = 1
+
+// costArtifactFilePerm is the permission of written cost artifacts.
+const costArtifactFilePerm = 0o600
+
+// ResourceCost is the cost of one resource in a cost artifact.
+type ResourceCost struct {
+	Type     string  `json:"type"`
+	Monthly  float64 `json:"monthly"`
+	Hourly   float64 `json:"hourly"`
+	Currency string  `json:"currency"`
+}
+
+// CostArtifact is per-resource and total cost keyed by URN, for Pulumi automation and CI
+// steps to read. A program can load it and export it as stack outputs:
+//
+//	const costs = JSON.parse(fs.readFileSync("cost.json", "utf8"));
+//	export const monthlyCost = costs.totalMonthly;
+type CostArtifact struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Currency and TotalMonthly are set when every resource is priced in one currency;
+	// otherwise totals are only in ByCurrency.
+	Currency     string                  `json:"currency,omitempty"`
+	TotalMonthly float64                 `json:"totalMonthly,omitempty"`
+	ByCurrency   map[string]float64      `json:"byCurrency"`
+	Resources    map[string]ResourceCost `json:"resources"`
+}
+
+// NewCostArtifact keys results by resource URN, summing the results of resources priced
+// more than once. Aggregated group rows, which have no URN, are skipped.
+func NewCostArtifact(results []CostResult) *CostArtifact {
+	artifact := &CostArtifact{
+		Version:     costArtifactVersion,
+		GeneratedAt: time.Now().UTC(),
+		ByCurrency:  make(map[string]float64),
+		Resources:   make(map[string]ResourceCost),
+	}
+	for _, r := range results {
+		if r.Adapter == adapterAggregated || r.ResourceID == "" {
+			continue
+		}
+		currency := resultCurrency(r)
+		cost, ok := artifact.Resources[r.ResourceID]
+		if !ok {
+			cost = ResourceCost{Type: r.ResourceType, Currency: currency}
+		}
+		cost.Monthly += r.Monthly
+		cost.Hourly += r.Hourly
+		artifact.Resources[r.ResourceID] = cost
+		artifact.ByCurrency[currency] += r.Monthly
+	}
+	if len(artifact.ByCurrency) == 1 {
+		for currency, total := range artifact.ByCurrency {
+			artifact.Currency, artifact.TotalMonthly = currency, total
+		}
+	}
+	return artifact
+}
+
+// WriteCostArtifact writes the cost artifact of results to path as indented JSON.
+func WriteCostArtifact(path string, results []CostResult) error {
+	data, err := json.MarshalIndent(NewCostArtifact(results), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cost artifact: %w", err)
+	}
+	if err = os.WriteFile(path, append(data, '\n'), costArtifactFilePerm); err != nil {
+		return fmt.Errorf("writing cost artifact: %w", err)
+	}
+	return nil
+}
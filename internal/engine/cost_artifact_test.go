@@ -0,0 +1,55 @@
+package engine_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCostArtifact(t *testing.T) {
+	const web = "urn:pulumi:dev::app::aws:ec2/instance:Instance::web"
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: web, Monthly: 7.3, Hourly: 0.01},
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: web, Monthly: 2.7, Hourly: 0.004, Currency: "USD"},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets"},
+		{ResourceType: "group", ResourceID: "aggregated-2-resources", Adapter: "aggregated", Monthly: 10},
+	}
+
+	artifact := engine.NewCostArtifact(results)
+	assert.Equal(t, 1, artifact.Version)
+	assert.Equal(t, "USD", artifact.Currency)
+	assert.InDelta(t, 10.0, artifact.TotalMonthly, 0.0001)
+	require.Len(t, artifact.Resources, 2)
+	assert.InDelta(t, 10.0, artifact.Resources[web].Monthly, 0.0001)
+	assert.InDelta(t, 0.014, artifact.Resources[web].Hourly, 0.0001)
+	assert.Equal(t, "USD", artifact.Resources[web].Currency)
+}
+
+func TestNewCostArtifact_MixedCurrencies(t *testing.T) {
+	artifact := engine.NewCostArtifact([]engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "a", Monthly: 5, Currency: "USD"},
+		{ResourceType: "azure:compute:VirtualMachine", ResourceID: "b", Monthly: 4, Currency: "EUR"},
+	})
+	assert.Empty(t, artifact.Currency, "totals in different currencies are not added")
+	assert.Zero(t, artifact.TotalMonthly)
+	assert.Equal(t, map[string]float64{"USD": 5, "EUR": 4}, artifact.ByCurrency)
+}
+
+func TestWriteCostArtifact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cost.json")
+	require.NoError(t, engine.WriteCostArtifact(path, []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "urn:a", Monthly: 5},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Contains(t, doc, "resources")
+	assert.InDelta(t, 5.0, doc["totalMonthly"], 0.0001)
+}
@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"math"
+	"sort"
+)
+
+// Kinds of change between two projected-cost runs.
+const (
+	DeltaAdded   = DriftAdded
+	DeltaRemoved = DriftRemoved
+	DeltaChanged = "changed"
+)
+
+// CostDelta is the change in one resource's projected monthly cost between two runs.
+type CostDelta struct {
+	ResourceType string  `json:"resourceType"`
+	ResourceID   string  `json:"resourceId"`
+	Currency     string  `json:"currency"`
+	OldMonthly   float64 `json:"oldMonthly"`
+	NewMonthly   float64 `json:"newMonthly"`
+	Delta        float64 `json:"delta"`
+	// DeltaPercent is Delta as a percentage of OldMonthly, and zero when OldMonthly is zero.
+	DeltaPercent float64 `json:"deltaPercent"`
+	// Kind is DeltaAdded for resources only in the later run, DeltaRemoved for those only
+	// in the earlier one, and DeltaChanged for those whose cost changed.
+	Kind string `json:"kind"`
+}
+
+// DiffResults compares two projected-cost runs, such as a base branch plan and a pull
+// request plan, matching results by resource type and ID and summing the results of a
+// resource priced more than once. It returns the added and removed resources and those
+// whose monthly cost changed, largest change first; unchanged resources are left out.
+func DiffResults(before, after []CostResult) []CostDelta {
+	pairs := matchResults(before, after, urnIdentity)
+	deltas := make([]CostDelta, 0, len(pairs))
+	for _, p := range pairs {
+		if !p.changed() {
+			continue
+		}
+		d := CostDelta{
+			ResourceType: p.resourceType,
+			ResourceID:   p.resourceID,
+			Currency:     p.currency,
+			OldMonthly:   p.before.monthly,
+			NewMonthly:   p.after.monthly,
+			Delta:        p.delta(),
+			Kind:         DeltaChanged,
+		}
+		switch {
+		case !p.before.present:
+			d.Kind = DeltaAdded
+		case !p.after.present:
+			d.Kind = DeltaRemoved
+		}
+		if d.OldMonthly != 0 {
+			d.DeltaPercent = d.Delta / d.OldMonthly * maxPercent
+		}
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// urnIdentity matches results by resource type and ID.
+var urnIdentity = &IdentityMatcher{mode: IdentityByURN}
+
+// resultSide is one resource's results in one of the runs compared by matchResults.
+type resultSide struct {
+	monthly    float64
+	present    bool
+	provenance *PricingProvenance // the first recorded
+}
+
+// resultPair is one resource matched across two runs by matchResults.
+type resultPair struct {
+	// resourceType and resourceID come from the later run when the resource is in it.
+	resourceType, resourceID string
+	// beforeID is the resource's ID in the earlier run, which differs from resourceID
+	// when the identity matches a renamed resource.
+	beforeID      string
+	currency      string
+	before, after resultSide
+}
+
+// delta returns the change in monthly cost from the earlier run to the later one.
+func (p resultPair) delta() float64 {
+	return p.after.monthly - p.before.monthly
+}
+
+// changed reports whether the resource was added, removed or changed in cost.
+func (p resultPair) changed() bool {
+	return p.before.present != p.after.present || math.Abs(p.delta()) >= costEpsilon
+}
+
+// matchResults pairs the results of two runs by identity, summing the results of a
+// resource priced more than once. Pairs are ordered by the size of their change, largest
+// first, and otherwise in the order their resources first appear.
+func matchResults(before, after []CostResult, identity *IdentityMatcher) []resultPair {
+	index := make(map[string]int)
+	var pairs []resultPair
+	add := func(r CostResult, side func(*resultPair) *resultSide) *resultPair {
+		key := identity.Key(r)
+		i, ok := index[key]
+		if !ok {
+			i = len(pairs)
+			index[key] = i
+			pairs = append(pairs, resultPair{resourceType: r.ResourceType, resourceID: r.ResourceID})
+		}
+		p := &pairs[i]
+		p.currency = resultCurrency(r)
+		s := side(p)
+		s.monthly += r.Monthly
+		s.present = true
+		if s.provenance == nil {
+			s.provenance = r.Provenance
+		}
+		return p
+	}
+	for _, r := range before {
+		add(r, func(p *resultPair) *resultSide { return &p.before }).beforeID = r.ResourceID
+	}
+	for _, r := range after {
+		p := add(r, func(p *resultPair) *resultSide { return &p.after })
+		p.resourceType, p.resourceID = r.ResourceType, r.ResourceID
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return math.Abs(pairs[i].delta()) > math.Abs(pairs[j].delta())
+	})
+	return pairs
+}
@@ -0,0 +1,54 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResults(t *testing.T) {
+	before := []engine.CostResult{
+		baselineResult("web", 10),
+		baselineResult("db", 100),
+		baselineResult("cache", 20),
+		baselineResult("logs", 5),
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "logs", Adapter: "extra", Monthly: 1},
+	}
+	after := []engine.CostResult{
+		baselineResult("web", 15),
+		baselineResult("db", 80),
+		baselineResult("logs", 6),
+		baselineResult("queue", 40),
+	}
+
+	deltas := engine.DiffResults(before, after)
+	require.Len(t, deltas, 4, "unchanged resources are left out")
+
+	assert.Equal(t, "queue", deltas[0].ResourceID, "largest change first")
+	assert.Equal(t, engine.DeltaAdded, deltas[0].Kind)
+	assert.InDelta(t, 40.0, deltas[0].Delta, 0.001)
+	assert.Zero(t, deltas[0].DeltaPercent)
+
+	assert.Equal(t, "db", deltas[1].ResourceID)
+	assert.Equal(t, engine.DeltaChanged, deltas[1].Kind)
+	assert.InDelta(t, -20.0, deltas[1].Delta, 0.001)
+	assert.InDelta(t, -20.0, deltas[1].DeltaPercent, 0.001)
+
+	assert.Equal(t, "cache", deltas[2].ResourceID)
+	assert.Equal(t, engine.DeltaRemoved, deltas[2].Kind)
+	assert.InDelta(t, 20.0, deltas[2].OldMonthly, 0.001)
+	assert.Zero(t, deltas[2].NewMonthly)
+
+	assert.Equal(t, "web", deltas[3].ResourceID)
+	assert.InDelta(t, 50.0, deltas[3].DeltaPercent, 0.001)
+	assert.Equal(t, "USD", deltas[3].Currency)
+}
+
+func TestDiffResults_SumsResultsPerResource(t *testing.T) {
+	before := []engine.CostResult{baselineResult("logs", 5), baselineResult("logs", 1)}
+	after := []engine.CostResult{baselineResult("logs", 6)}
+	assert.Empty(t, engine.DiffResults(before, after))
+	assert.Empty(t, engine.DiffResults(nil, nil))
+}
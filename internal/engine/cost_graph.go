@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// OutputDOT renders the resource dependency graph as Graphviz DOT.
+const OutputDOT OutputFormat = "dot"
+
+// costGraphColors fill nodes by their share of the most expensive node's cost, from the
+// cheapest quartile to the most expensive. Nodes without cost are grey.
+var costGraphColors = []string{"#c7e9c0", "#fff3b0", "#fdae6b", "#fb6a4a"}
+
+const costGraphZeroColor = "#eeeeee"
+
+// CostGraphOptions controls how the cost graph is drawn.
+type CostGraphOptions struct {
+	// CollapseComponents draws each top-level component as one node carrying the cost of
+	// every resource beneath it, which keeps large stacks readable.
+	CollapseComponents bool
+}
+
+// costGraphNode is one node of the rendered graph.
+type costGraphNode struct {
+	id           string
+	resourceType string
+	monthly      float64
+	members      int
+}
+
+// RenderCostGraphDOT writes the dependency graph of descriptors as Graphviz DOT. Each node
+// is labeled with its name, type and monthly cost from results, and filled by cost relative
+// to the most expensive node. Solid edges point from a resource to the resources it
+// depends on; dashed edges point from a component to its children. Pulumi internal
+// resources such as the stack and providers are omitted.
+func RenderCostGraphDOT(
+	writer io.Writer,
+	descriptors []ResourceDescriptor,
+	results []CostResult,
+	opts CostGraphOptions,
+) error {
+	monthly := make(map[string]float64, len(results))
+	currency := defaultCurrency
+	for _, r := range results {
+		monthly[r.ResourceID] += r.Monthly
+		if r.Currency != "" {
+			currency = r.Currency
+		}
+	}
+
+	nodeOf := costGraphNodeIDs(descriptors, opts.CollapseComponents)
+	nodes := make(map[string]*costGraphNode)
+	var order []string
+	for _, d := range descriptors {
+		id, ok := nodeOf[d.ID]
+		if !ok {
+			continue
+		}
+		n, exists := nodes[id]
+		if !exists {
+			n = &costGraphNode{id: id}
+			nodes[id] = n
+			order = append(order, id)
+		}
+		if id == d.ID {
+			n.resourceType = d.Type
+		}
+		n.monthly += monthly[d.ID]
+		n.members++
+	}
+
+	maxMonthly := 0.0
+	for _, n := range nodes {
+		maxMonthly = max(maxMonthly, n.monthly)
+	}
+
+	fmt.Fprintln(writer, "digraph costs {")
+	fmt.Fprintln(writer, "  rankdir=LR;")
+	fmt.Fprintln(writer, `  node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+	for _, id := range order {
+		n := nodes[id]
+		fmt.Fprintf(writer, "  %s [label=%s, fillcolor=%s];\n",
+			dotQuote(id), dotQuote(costGraphLabel(n, currency)),
+			dotQuote(costGraphColor(n.monthly, maxMonthly)))
+	}
+	for _, e := range costGraphEdges(descriptors, nodeOf) {
+		style := ""
+		if e.parent {
+			style = " [style=dashed]"
+		}
+		fmt.Fprintf(writer, "  %s -> %s%s;\n", dotQuote(e.from), dotQuote(e.to), style)
+	}
+	_, err := fmt.Fprintln(writer, "}")
+	return err
+}
+
+// costGraphNodeIDs maps each drawn resource to the node it belongs to: itself, or its
+// top-level component when collapsing. Internal Pulumi resources are left out.
+func costGraphNodeIDs(descriptors []ResourceDescriptor, collapse bool) map[string]string {
+	byID := make(map[string]ResourceDescriptor, len(descriptors))
+	for _, d := range descriptors {
+		byID[d.ID] = d
+	}
+
+	nodeOf := make(map[string]string, len(descriptors))
+	for _, d := range descriptors {
+		if strings.HasPrefix(d.Type, internalTypePrefix) {
+			continue
+		}
+		if !collapse {
+			nodeOf[d.ID] = d.ID
+			continue
+		}
+		// Walk up to the highest ancestor that is not an internal resource, guarding
+		// against malformed parent cycles.
+		top := d
+		seen := map[string]bool{d.ID: true}
+		for {
+			parent, ok := byID[top.Parent]
+			if !ok || seen[parent.ID] || strings.HasPrefix(parent.Type, internalTypePrefix) {
+				break
+			}
+			seen[parent.ID] = true
+			top = parent
+		}
+		nodeOf[d.ID] = top.ID
+	}
+	return nodeOf
+}
+
+type costGraphEdge struct {
+	from, to string
+	parent   bool
+}
+
+// costGraphEdges returns the deduplicated edges between drawn nodes, sorted for stable
+// output. Edges inside a collapsed component are dropped.
+func costGraphEdges(descriptors []ResourceDescriptor, nodeOf map[string]string) []costGraphEdge {
+	seen := make(map[costGraphEdge]bool)
+	var edges []costGraphEdge
+	add := func(e costGraphEdge) {
+		if e.from == e.to || seen[e] {
+			return
+		}
+		seen[e] = true
+		edges = append(edges, e)
+	}
+
+	for _, d := range descriptors {
+		self, ok := nodeOf[d.ID]
+		if !ok {
+			continue
+		}
+		for _, dep := range d.Dependencies {
+			target, drawn := nodeOf[dep]
+			if !drawn {
+				continue
+			}
+			if dep == d.Parent {
+				add(costGraphEdge{from: target, to: self, parent: true})
+				continue
+			}
+			add(costGraphEdge{from: self, to: target})
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].from != edges[j].from {
+			return edges[i].from < edges[j].from
+		}
+		if edges[i].to != edges[j].to {
+			return edges[i].to < edges[j].to
+		}
+		return !edges[i].parent && edges[j].parent
+	})
+	return edges
+}
+
+func costGraphLabel(n *costGraphNode, currency string) string {
+	lines := []string{urnResourceName(n.id)}
+	if n.resourceType != "" {
+		lines = append(lines, n.resourceType)
+	}
+	if n.members > 1 {
+		lines = append(lines, fmt.Sprintf("%d resources", n.members))
+	}
+	lines = append(lines, fmt.Sprintf("%.2f %s/mo", n.monthly, currency))
+	return strings.Join(lines, "\n")
+}
+
+func costGraphColor(monthly, maxMonthly float64) string {
+	if monthly <= 0 || maxMonthly <= 0 {
+		return costGraphZeroColor
+	}
+	bucket := int(monthly / maxMonthly * float64(len(costGraphColors)))
+	return costGraphColors[min(bucket, len(costGraphColors)-1)]
+}
+
+// dotQuote returns s as a quoted DOT string, with newlines as DOT line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,72 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	graphStack  = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+	graphNet    = "urn:pulumi:dev::app::my:network:Network::core"
+	graphVPC    = "urn:pulumi:dev::app::my:network:Network$aws:ec2/vpc:Vpc::main"
+	graphNAT    = "urn:pulumi:dev::app::my:network:Network$aws:ec2/natGateway:NatGateway::nat"
+	graphServer = "urn:pulumi:dev::app::aws:ec2/instance:Instance::web"
+)
+
+func costGraphFixture() ([]engine.ResourceDescriptor, []engine.CostResult) {
+	descriptors := []engine.ResourceDescriptor{
+		{ID: graphStack, Type: "pulumi:pulumi:Stack"},
+		{ID: graphNet, Type: "my:network:Network", Parent: graphStack, Dependencies: []string{graphStack}},
+		{ID: graphVPC, Type: "aws:ec2/vpc:Vpc", Parent: graphNet, Dependencies: []string{graphNet}},
+		{
+			ID: graphNAT, Type: "aws:ec2/natGateway:NatGateway", Parent: graphNet,
+			Dependencies: []string{graphNet, graphVPC},
+		},
+		{
+			ID: graphServer, Type: "aws:ec2/instance:Instance", Parent: graphStack,
+			Dependencies: []string{graphStack, graphVPC},
+		},
+	}
+	results := []engine.CostResult{
+		{ResourceID: graphNAT, Currency: "USD", Monthly: 32.85},
+		{ResourceID: graphServer, Currency: "USD", Monthly: 7.3},
+	}
+	return descriptors, results
+}
+
+func TestRenderCostGraphDOT(t *testing.T) {
+	descriptors, results := costGraphFixture()
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderCostGraphDOT(&buf, descriptors, results, engine.CostGraphOptions{}))
+	out := buf.String()
+
+	assert.Contains(t, out, "digraph costs {")
+	assert.NotContains(t, out, "pulumi:pulumi:Stack", "internal resources are omitted")
+	assert.Contains(t, out,
+		`"`+graphNAT+`" [label="nat\naws:ec2/natGateway:NatGateway\n32.85 USD/mo", fillcolor="#fb6a4a"];`)
+	assert.Contains(t, out,
+		`"`+graphServer+`" [label="web\naws:ec2/instance:Instance\n7.30 USD/mo", fillcolor="#c7e9c0"];`)
+	assert.Contains(t, out, `"`+graphVPC+`" [label="main\naws:ec2/vpc:Vpc\n0.00 USD/mo", fillcolor="#eeeeee"];`)
+	assert.Contains(t, out, `"`+graphNAT+`" -> "`+graphVPC+`";`)
+	assert.Contains(t, out, `"`+graphNet+`" -> "`+graphNAT+`" [style=dashed];`)
+	assert.Contains(t, out, `"`+graphServer+`" -> "`+graphVPC+`";`)
+}
+
+func TestRenderCostGraphDOT_CollapseComponents(t *testing.T) {
+	descriptors, results := costGraphFixture()
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderCostGraphDOT(&buf, descriptors, results,
+		engine.CostGraphOptions{CollapseComponents: true}))
+	out := buf.String()
+
+	assert.Contains(t, out, `"`+graphNet+`" [label="core\nmy:network:Network\n3 resources\n32.85 USD/mo"`)
+	assert.NotContains(t, out, `"`+graphVPC+`"`, "component members are folded into the component")
+	assert.Contains(t, out, `"`+graphServer+`" -> "`+graphNet+`";`)
+	assert.NotContains(t, out, "dashed", "parent edges inside a component disappear")
+}
@@ -0,0 +1,157 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	// minHistorySamples is how many past estimates a resource type, or failing that its
+	// provider and service, needs before projected costs get a confidence interval.
+	minHistorySamples = 5
+
+	// historyIntervalLevel is the confidence level, in percent, of intervals derived from
+	// history; they span the 5th to 95th percentile of how actuals compared to projections.
+	historyIntervalLevel = 90
+	historyQuantileLow   = 0.05
+	historyQuantileHigh  = 0.95
+)
+
+// CostHistoryRecord pairs a past projected monthly cost with the actual cost billed for the
+// same resource over the month it was projected for.
+type CostHistoryRecord struct {
+	ResourceType string  `json:"resourceType"`
+	ResourceID   string  `json:"resourceId,omitempty"`
+	Period       string  `json:"period,omitempty"`
+	Projected    float64 `json:"projected"`
+	Actual       float64 `json:"actual"`
+}
+
+// ConfidenceInterval bounds a projected monthly cost by how far actual costs of similar
+// resources historically landed from their projections.
+type ConfidenceInterval struct {
+	// Level is the confidence level in percent.
+	Level float64 `json:"level"`
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	// Samples is the number of past estimates the interval is based on.
+	Samples int `json:"samples"`
+	// Basis is the resource type, or provider/service, whose history was used.
+	Basis string `json:"basis"`
+}
+
+// CostHistory indexes the ratio of actual to projected cost of past estimates by resource
+// type and by provider/service.
+type CostHistory struct {
+	byType    map[string][]float64
+	byService map[string][]float64
+}
+
+// NewCostHistory validates records and builds the history. Records projected at zero say
+// nothing about estimation error and are skipped.
+func NewCostHistory(records []CostHistoryRecord) (*CostHistory, error) {
+	h := &CostHistory{byType: map[string][]float64{}, byService: map[string][]float64{}}
+	for i, rec := range records {
+		if strings.TrimSpace(rec.ResourceType) == "" {
+			return nil, fmt.Errorf("cost history record %d: resourceType is required", i+1)
+		}
+		if rec.Projected < 0 || rec.Actual < 0 {
+			return nil, fmt.Errorf("cost history record %d: costs must not be negative", i+1)
+		}
+		if rec.Projected == 0 {
+			continue
+		}
+		ratio := rec.Actual / rec.Projected
+		h.byType[rec.ResourceType] = append(h.byType[rec.ResourceType], ratio)
+		service := historyServiceKey(rec.ResourceType)
+		h.byService[service] = append(h.byService[service], ratio)
+	}
+	for _, ratios := range h.byType {
+		sort.Float64s(ratios)
+	}
+	for _, ratios := range h.byService {
+		sort.Float64s(ratios)
+	}
+	return h, nil
+}
+
+// LoadCostHistory reads a JSON array of CostHistoryRecord objects.
+func LoadCostHistory(path string) (*CostHistory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cost history: %w", err)
+	}
+	var records []CostHistoryRecord
+	if unmarshalErr := json.Unmarshal(data, &records); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing cost history: %w", unmarshalErr)
+	}
+	return NewCostHistory(records)
+}
+
+// Interval returns the confidence interval for a projected monthly cost of resourceType.
+// The resource type's own history is used when it has enough samples, then that of its
+// provider and service. It reports false when neither has, as for a new kind of resource.
+func (h *CostHistory) Interval(resourceType string, monthly float64) (ConfidenceInterval, bool) {
+	if h == nil || monthly <= 0 {
+		return ConfidenceInterval{}, false
+	}
+	basis, ratios := resourceType, h.byType[resourceType]
+	if len(ratios) < minHistorySamples {
+		basis = historyServiceKey(resourceType)
+		ratios = h.byService[basis]
+	}
+	if len(ratios) < minHistorySamples {
+		return ConfidenceInterval{}, false
+	}
+	return ConfidenceInterval{
+		Level:   historyIntervalLevel,
+		Low:     monthly * quantile(ratios, historyQuantileLow),
+		High:    monthly * quantile(ratios, historyQuantileHigh),
+		Samples: len(ratios),
+		Basis:   basis,
+	}, true
+}
+
+// historyServiceKey groups resource types by provider and service, e.g. aws/ec2.
+func historyServiceKey(resourceType string) string {
+	return strings.ToLower(extractProviderFromType(resourceType) + "/" + extractService(resourceType))
+}
+
+// quantile interpolates the q-th quantile of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(pos)
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower] + frac*(sorted[lower+1]-sorted[lower])
+}
+
+// WithCostHistory sets the past estimates used to attach confidence intervals to projected
+// costs and returns the engine for chaining.
+func (e *Engine) WithCostHistory(history *CostHistory) *Engine {
+	e.costHistory = history
+	return e
+}
+
+// applyCostHistory attaches a confidence interval to each priced result.
+func (e *Engine) applyCostHistory(results []CostResult) {
+	for i := range results {
+		r := &results[i]
+		interval, ok := e.costHistory.Interval(r.ResourceType, r.Monthly)
+		if !ok {
+			continue
+		}
+		r.Interval = &interval
+		note := fmt.Sprintf("%.0f%% interval %.2f-%.2f %s/month from %d past estimates",
+			interval.Level, interval.Low, interval.High, r.Currency, interval.Samples)
+		if r.Notes != "" {
+			note = r.Notes + "; " + note
+		}
+		r.Notes = note
+	}
+}
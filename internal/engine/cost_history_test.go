@@ -0,0 +1,100 @@
+package engine_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ec2InstanceType = "aws:ec2/instance:Instance"
+
+func historyRecords(resourceType string, ratios ...float64) []engine.CostHistoryRecord {
+	records := make([]engine.CostHistoryRecord, 0, len(ratios))
+	for _, ratio := range ratios {
+		records = append(records, engine.CostHistoryRecord{
+			ResourceType: resourceType, Projected: 10, Actual: 10 * ratio,
+		})
+	}
+	return records
+}
+
+func TestCostHistory_Interval(t *testing.T) {
+	records := historyRecords(ec2InstanceType, 1.2, 0.8, 1.0, 1.1, 0.9)
+	records = append(records, historyRecords("aws:ec2/eip:Eip", 1.0, 1.0)...)
+	records = append(records, engine.CostHistoryRecord{ResourceType: ec2InstanceType, Projected: 0, Actual: 4})
+	history, err := engine.NewCostHistory(records)
+	require.NoError(t, err)
+
+	interval, ok := history.Interval(ec2InstanceType, 100)
+	require.True(t, ok)
+	assert.InDelta(t, 82.0, interval.Low, 0.0001)
+	assert.InDelta(t, 118.0, interval.High, 0.0001)
+	assert.InDelta(t, 90.0, interval.Level, 0.0001)
+	assert.Equal(t, 5, interval.Samples, "records projected at zero are skipped")
+	assert.Equal(t, ec2InstanceType, interval.Basis)
+
+	interval, ok = history.Interval("aws:ec2/volume:Volume", 100)
+	require.True(t, ok, "types without enough history use their provider and service")
+	assert.Equal(t, "aws/ec2", interval.Basis)
+	assert.Equal(t, 7, interval.Samples)
+
+	_, ok = history.Interval("aws:rds/instance:Instance", 100)
+	assert.False(t, ok, "no interval without history")
+	_, ok = history.Interval(ec2InstanceType, 0)
+	assert.False(t, ok, "no interval for unpriced resources")
+
+	var empty *engine.CostHistory
+	_, ok = empty.Interval(ec2InstanceType, 100)
+	assert.False(t, ok)
+}
+
+func TestLoadCostHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	require.NoError(t, os.WriteFile(path,
+		[]byte(`[{"resourceType":"aws:ec2/instance:Instance","period":"2026-09","projected":10,"actual":12}]`),
+		0o600))
+	_, err := engine.LoadCostHistory(path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"projected":10,"actual":12}]`), 0o600))
+	_, err = engine.LoadCostHistory(path)
+	require.ErrorContains(t, err, "resourceType is required")
+
+	_, err = engine.NewCostHistory([]engine.CostHistoryRecord{
+		{ResourceType: ec2InstanceType, Projected: 10, Actual: -1},
+	})
+	require.ErrorContains(t, err, "must not be negative")
+}
+
+func TestGetProjectedCost_CostHistoryInterval(t *testing.T) {
+	loader := &MockSpecLoader{specs: map[string]*engine.PricingSpec{
+		"aws-ec2-t3.micro": {
+			Provider: "aws", Service: "ec2", SKU: "t3.micro", Currency: "USD",
+			Pricing: map[string]interface{}{"onDemandHourly": 0.01},
+		},
+	}}
+	history, err := engine.NewCostHistory(historyRecords(ec2InstanceType, 1.2, 0.8, 1.0, 1.1, 0.9))
+	require.NoError(t, err)
+	resources := []engine.ResourceDescriptor{
+		{Type: ec2InstanceType, ID: "web", Provider: "aws", Properties: map[string]interface{}{
+			"instanceType": "t3.micro",
+		}},
+	}
+
+	results, err := newTestEngine(t, nil, loader).WithCostHistory(history).GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NotNil(t, results[0].Interval)
+	assert.InDelta(t, 7.3*0.82, results[0].Interval.Low, 0.0001)
+	assert.InDelta(t, 7.3*1.18, results[0].Interval.High, 0.0001)
+	assert.Contains(t, results[0].Notes, "90% interval 5.99-8.61 USD/month from 5 past estimates")
+
+	results, err = newTestEngine(t, nil, loader).GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Nil(t, results[0].Interval, "no interval without history")
+}
@@ -0,0 +1,240 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// adapterCostRule is the adapter reported for results priced by a cost rule.
+const adapterCostRule = "cost-rule"
+
+// ErrInvalidCostRule is returned for a cost rule that cannot be applied.
+var ErrInvalidCostRule = errors.New("invalid cost rule")
+
+// CostRuleMatch selects the resources a cost rule applies to. Every set field must match.
+// Values match exactly, or by prefix when they end in "*".
+type CostRuleMatch struct {
+	// Type is the resource type, e.g. aws:ec2/instance:Instance or aws:*.
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+	// Tags are matched case-insensitively by key against the resource's tags and labels.
+	Tags map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	// Properties are matched against the resource's top-level input properties.
+	Properties map[string]string `yaml:"properties,omitempty" json:"properties,omitempty"`
+}
+
+// CostRule overrides how matching resources are priced. Exactly one of Monthly,
+// Multiplier and Pricing is set:
+//
+//	monthly     reports a fixed monthly cost in Currency (USD when empty)
+//	multiplier  scales the cost found by plugins or specs, e.g. 0.5 for test environments
+//	pricing     prices the resource from these rates, using the keys of a pricing spec
+//	            such as onDemandHourly, monthlyEstimate or pricePerGBMonth
+type CostRule struct {
+	Name       string                 `yaml:"name"                 json:"name"`
+	Match      CostRuleMatch          `yaml:"match"                json:"match"`
+	Monthly    *float64               `yaml:"monthly,omitempty"    json:"monthly,omitempty"`
+	Currency   string                 `yaml:"currency,omitempty"   json:"currency,omitempty"`
+	Multiplier *float64               `yaml:"multiplier,omitempty" json:"multiplier,omitempty"`
+	Pricing    map[string]interface{} `yaml:"pricing,omitempty"    json:"pricing,omitempty"`
+}
+
+// CostRules are evaluated in order before plugins and specs are asked; the first rule
+// matching a resource applies and later rules are ignored for it.
+type CostRules []CostRule
+
+// costRulesFile is the on-disk format read by LoadCostRules.
+type costRulesFile struct {
+	Rules []CostRule `yaml:"rules"`
+}
+
+// LoadCostRules reads and validates a YAML file with a top-level "rules" list.
+func LoadCostRules(path string) (CostRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cost rules: %w", err)
+	}
+	var file costRulesFile
+	if unmarshalErr := yaml.Unmarshal(data, &file); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing cost rules: %w", unmarshalErr)
+	}
+	return NewCostRules(file.Rules)
+}
+
+// NewCostRules validates rules and returns them with default names and currencies filled
+// in and pricing rates converted to numbers.
+func NewCostRules(rules []CostRule) (CostRules, error) {
+	out := make(CostRules, 0, len(rules))
+	for i, rule := range rules {
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			rule.Name = "rule " + strconv.Itoa(i+1)
+		}
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("cost rule %q: %w", rule.Name, err)
+		}
+		if rule.Monthly != nil && rule.Currency == "" {
+			rule.Currency = defaultCurrency
+		}
+		if rule.Pricing != nil {
+			pricing := make(map[string]interface{}, len(rule.Pricing))
+			for key, value := range rule.Pricing {
+				rate, _ := parseFloatValue(value)
+				pricing[key] = rate
+			}
+			rule.Pricing = pricing
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+// validate checks that the rule matches something and sets exactly one valid action.
+func (r CostRule) validate() error {
+	if r.Match.Type == "" && len(r.Match.Tags) == 0 && len(r.Match.Properties) == 0 {
+		return fmt.Errorf("%w: match needs a type, tags or properties", ErrInvalidCostRule)
+	}
+	actions := 0
+	if r.Monthly != nil {
+		actions++
+		if *r.Monthly < 0 {
+			return fmt.Errorf("%w: monthly must not be negative", ErrInvalidCostRule)
+		}
+	}
+	if r.Multiplier != nil {
+		actions++
+		if *r.Multiplier < 0 {
+			return fmt.Errorf("%w: multiplier must not be negative", ErrInvalidCostRule)
+		}
+	}
+	if r.Pricing != nil {
+		actions++
+		if len(r.Pricing) == 0 {
+			return fmt.Errorf("%w: pricing needs at least one rate", ErrInvalidCostRule)
+		}
+		for key, value := range r.Pricing {
+			if rate, ok := parseFloatValue(value); !ok || rate < 0 {
+				return fmt.Errorf("%w: pricing rate %s must be a non-negative number", ErrInvalidCostRule, key)
+			}
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("%w: set exactly one of monthly, multiplier or pricing", ErrInvalidCostRule)
+	}
+	return nil
+}
+
+// WithCostRules sets the rules that override how matching resources are priced and
+// returns the engine for chaining.
+func (e *Engine) WithCostRules(rules CostRules) *Engine {
+	e.costRules = rules
+	return e
+}
+
+// match returns the first rule for resource, or nil.
+func (r CostRules) match(resource ResourceDescriptor) *CostRule {
+	for i := range r {
+		if r[i].matches(resource) {
+			return &r[i]
+		}
+	}
+	return nil
+}
+
+// matches reports whether every criterion of the rule holds for resource.
+func (r *CostRule) matches(resource ResourceDescriptor) bool {
+	if r.Match.Type != "" && !matchRuleValue(r.Match.Type, resource.Type) {
+		return false
+	}
+	for key, want := range r.Match.Tags {
+		value, ok := resourceTag(resource, key)
+		if !ok || !matchRuleValue(want, value) {
+			return false
+		}
+	}
+	for key, want := range r.Match.Properties {
+		value, ok := resource.Properties[key]
+		if !ok || !matchRuleValue(want, fmt.Sprint(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRuleValue compares value exactly, or by prefix when pattern ends in "*".
+func matchRuleValue(pattern, value string) bool {
+	if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix {
+		return strings.HasPrefix(value, prefix)
+	}
+	return value == pattern
+}
+
+// resourceTag looks key up case-insensitively in the resource's tags and labels.
+func resourceTag(resource ResourceDescriptor, key string) (string, bool) {
+	for _, mapKey := range []string{"tags", "labels"} {
+		m, _ := resource.Properties[mapKey].(map[string]interface{})
+		for k, v := range m {
+			if strings.EqualFold(k, key) {
+				return fmt.Sprint(v), true
+			}
+		}
+	}
+	return "", false
+}
+
+// resolve returns the result to report instead of asking plugins and specs, or nil when
+// the rule only adjusts the cost they find. A pricing rule whose rates do not apply to
+// the resource, such as a per-GB rate for a resource without a size, also returns nil.
+// hours is the number of hours in a month.
+func (r *CostRule) resolve(resource ResourceDescriptor, hours float64) *CostResult {
+	if r == nil {
+		return nil
+	}
+	var monthly, hourly float64
+	currency := defaultCurrency
+	switch {
+	case r.Monthly != nil:
+		monthly, hourly, currency = *r.Monthly, *r.Monthly/hours, r.Currency
+	case r.Pricing != nil:
+		var found bool
+		if monthly, hourly, found = tryExtractCostsFromPricing(r.Pricing, resource, hours); !found {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &CostResult{
+		ResourceType: resource.Type,
+		ResourceID:   resource.ID,
+		Adapter:      adapterCostRule,
+		Currency:     currency,
+		Monthly:      monthly,
+		Hourly:       hourly,
+	}
+}
+
+// apply scales results by the rule's multiplier and records the rule on them.
+func (r *CostRule) apply(results []CostResult) {
+	if r == nil {
+		return
+	}
+	note := "cost rule " + r.Name
+	if r.Multiplier != nil {
+		note += " (x" + strconv.FormatFloat(*r.Multiplier, 'g', -1, 64) + ")"
+	}
+	for i := range results {
+		if r.Multiplier != nil {
+			scaleCosts(&results[i], *r.Multiplier)
+		}
+		results[i].CostRule = r.Name
+		if results[i].Notes == "" {
+			results[i].Notes = note
+		} else {
+			results[i].Notes = note + "; " + results[i].Notes
+		}
+	}
+}
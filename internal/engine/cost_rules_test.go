@@ -0,0 +1,136 @@
+package engine_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCostRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`rules:
+  - name: test-half-price
+    match:
+      tags:
+        env: test
+    multiplier: 0.5
+  - match:
+      type: "aws:ec2/*"
+      properties:
+        availabilityZone: "us-gov-*"
+    pricing:
+      onDemandHourly: 1
+`), 0o600))
+
+	rules, err := engine.LoadCostRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "test-half-price", rules[0].Name)
+	assert.Equal(t, "rule 2", rules[1].Name)
+	assert.InDelta(t, 1.0, rules[1].Pricing["onDemandHourly"], 0.001, "integer rates are converted to numbers")
+
+	_, err = engine.LoadCostRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestNewCostRules_Invalid(t *testing.T) {
+	half, negative := 0.5, -1.0
+	tests := []struct {
+		name string
+		rule engine.CostRule
+	}{
+		{"no match criteria", engine.CostRule{Multiplier: &half}},
+		{"no action", engine.CostRule{Match: engine.CostRuleMatch{Type: "aws:*"}}},
+		{"two actions", engine.CostRule{Match: engine.CostRuleMatch{Type: "aws:*"}, Multiplier: &half, Monthly: &half}},
+		{"negative monthly", engine.CostRule{Match: engine.CostRuleMatch{Type: "aws:*"}, Monthly: &negative}},
+		{"negative multiplier", engine.CostRule{Match: engine.CostRuleMatch{Type: "aws:*"}, Multiplier: &negative}},
+		{"empty pricing", engine.CostRule{
+			Match: engine.CostRuleMatch{Type: "aws:*"}, Pricing: map[string]interface{}{},
+		}},
+		{"non-numeric rate", engine.CostRule{
+			Match: engine.CostRuleMatch{Type: "aws:*"}, Pricing: map[string]interface{}{"onDemandHourly": "cheap"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.NewCostRules([]engine.CostRule{tt.rule})
+			require.ErrorIs(t, err, engine.ErrInvalidCostRule)
+		})
+	}
+}
+
+func TestGetProjectedCost_CostRules(t *testing.T) {
+	loader := &MockSpecLoader{
+		specs: map[string]*engine.PricingSpec{
+			"aws-ec2-t3.micro": {
+				Provider: "aws",
+				Service:  "ec2",
+				SKU:      "t3.micro",
+				Currency: "USD",
+				Pricing:  map[string]interface{}{"onDemandHourly": 0.01},
+			},
+		},
+	}
+	half, contract := 0.5, 42.0
+	rules, err := engine.NewCostRules([]engine.CostRule{
+		{Name: "test-half-price", Match: engine.CostRuleMatch{Tags: map[string]string{"env": "test"}}, Multiplier: &half},
+		{
+			Name: "govcloud",
+			Match: engine.CostRuleMatch{
+				Type:       "aws:ec2/instance:Instance",
+				Properties: map[string]string{"availabilityZone": "us-gov-*"},
+			},
+			Pricing: map[string]interface{}{"onDemandHourly": 0.02},
+		},
+		{Name: "contract", Match: engine.CostRuleMatch{Type: "aws:rds/*"}, Monthly: &contract},
+	})
+	require.NoError(t, err)
+
+	instance := func(id string, props map[string]interface{}) engine.ResourceDescriptor {
+		props["instanceType"] = "t3.micro"
+		return engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: id, Provider: "aws", Properties: props}
+	}
+	resources := []engine.ResourceDescriptor{
+		instance("test", map[string]interface{}{
+			"tags":             map[string]interface{}{"Env": "test"},
+			"availabilityZone": "us-gov-west-1a",
+		}),
+		instance("gov", map[string]interface{}{"availabilityZone": "us-gov-west-1a"}),
+		{Type: "aws:rds/instance:Instance", ID: "db", Provider: "aws"},
+		instance("plain", map[string]interface{}{}),
+	}
+	eng := newTestEngine(t, nil, loader).WithCostRules(rules)
+
+	check := func(t *testing.T, results []engine.CostResult) {
+		t.Helper()
+		require.Len(t, results, 4)
+
+		assert.InDelta(t, 3.65, results[0].Monthly, 0.001, "first matching rule wins")
+		assert.Equal(t, "test-half-price", results[0].CostRule)
+		assert.Contains(t, results[0].Notes, "cost rule test-half-price (x0.5)")
+
+		assert.InDelta(t, 14.6, results[1].Monthly, 0.001)
+		assert.Equal(t, "cost-rule", results[1].Adapter)
+		assert.Equal(t, "govcloud", results[1].CostRule)
+
+		assert.InDelta(t, 42.0, results[2].Monthly, 0.001)
+		assert.Equal(t, "USD", results[2].Currency)
+		assert.Equal(t, "contract", results[2].CostRule)
+
+		assert.InDelta(t, 7.3, results[3].Monthly, 0.001)
+		assert.Empty(t, results[3].CostRule)
+	}
+
+	results, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	check(t, results)
+
+	withErrors, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	check(t, withErrors.Results)
+}
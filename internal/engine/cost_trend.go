@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// DefaultTrendDrivers is how many resources are named as drivers of each increase.
+const DefaultTrendDrivers = 3
+
+// sparklineBlocks draw a sparkline from the lowest to the highest value.
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// TrendSnapshot is the projected cost of one captured preview.
+type TrendSnapshot struct {
+	Name    string
+	Time    time.Time
+	Results []CostResult
+}
+
+// TrendDriver is a resource whose monthly cost rose since the previous snapshot.
+type TrendDriver struct {
+	ResourceType string  `json:"resourceType"`
+	ResourceID   string  `json:"resourceId"`
+	Previous     float64 `json:"previous"`
+	Current      float64 `json:"current"`
+	Delta        float64 `json:"delta"`
+}
+
+// CostTrendPoint is the projected total of one snapshot and its change from the previous one.
+type CostTrendPoint struct {
+	Snapshot      string        `json:"snapshot"`
+	Time          time.Time     `json:"time"`
+	Total         float64       `json:"total"`
+	Delta         float64       `json:"delta"`
+	ResourceCount int           `json:"resourceCount"`
+	Drivers       []TrendDriver `json:"drivers,omitempty"`
+}
+
+// CostTrend is the evolution of projected monthly cost across preview snapshots.
+type CostTrend struct {
+	Currency string           `json:"currency"`
+	Points   []CostTrendPoint `json:"points"`
+}
+
+// BuildCostTrend totals each snapshot, in the order given, and names up to maxDrivers
+// resources with the largest increases since the previous snapshot. Resources are matched
+// by type and ID; a new resource counts as an increase from zero.
+func BuildCostTrend(snapshots []TrendSnapshot, maxDrivers int) *CostTrend {
+	trend := &CostTrend{Currency: defaultCurrency, Points: make([]CostTrendPoint, 0, len(snapshots))}
+	currencySet := false
+	var previous map[string]TrendDriver
+	for i, snap := range snapshots {
+		current := make(map[string]TrendDriver, len(snap.Results))
+		point := CostTrendPoint{Snapshot: snap.Name, Time: snap.Time}
+		for _, r := range snap.Results {
+			if !currencySet && r.Currency != "" {
+				trend.Currency = r.Currency
+				currencySet = true
+			}
+			key := r.ResourceType + "/" + r.ResourceID
+			entry := current[key]
+			entry.ResourceType, entry.ResourceID = r.ResourceType, r.ResourceID
+			entry.Current += r.Monthly
+			current[key] = entry
+			point.Total += r.Monthly
+		}
+		point.ResourceCount = len(current)
+		if i > 0 {
+			point.Delta = point.Total - trend.Points[i-1].Total
+			point.Drivers = trendDrivers(previous, current, maxDrivers)
+		}
+		trend.Points = append(trend.Points, point)
+		previous = current
+	}
+	return trend
+}
+
+// trendDrivers returns the resources whose cost rose from previous to current, largest
+// increase first.
+func trendDrivers(previous, current map[string]TrendDriver, maxDrivers int) []TrendDriver {
+	var drivers []TrendDriver
+	for key, c := range current {
+		c.Previous = previous[key].Current
+		c.Delta = c.Current - c.Previous
+		if c.Delta > 0 {
+			drivers = append(drivers, c)
+		}
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		if drivers[i].Delta != drivers[j].Delta {
+			return drivers[i].Delta > drivers[j].Delta
+		}
+		return drivers[i].ResourceID < drivers[j].ResourceID
+	})
+	if maxDrivers >= 0 && len(drivers) > maxDrivers {
+		drivers = drivers[:maxDrivers]
+	}
+	return drivers
+}
+
+// Totals returns the total of each point, in order.
+func (t *CostTrend) Totals() []float64 {
+	totals := make([]float64, len(t.Points))
+	for i, p := range t.Points {
+		totals[i] = p.Total
+	}
+	return totals
+}
+
+// Sparkline draws values as a line of block characters scaled between their minimum and
+// maximum. Equal values draw as the lowest block.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var b strings.Builder
+	top := len(sparklineBlocks) - 1
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(top))
+		}
+		b.WriteRune(sparklineBlocks[level])
+	}
+	return b.String()
+}
+
+// RenderCostTrend writes the trend as JSON or as a sparkline followed by a table with one
+// row per snapshot.
+func RenderCostTrend(writer io.Writer, format OutputFormat, trend *CostTrend) error {
+	if format == OutputJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(trend)
+	}
+	if format != OutputTable {
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	fmt.Fprintf(writer, "Projected monthly cost (%s): %s\n\n", trend.Currency, Sparkline(trend.Totals()))
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Snapshot\tTime\tResources\tTotal\tChange\tDrivers")
+	fmt.Fprintln(w, "--------\t----\t---------\t-----\t------\t-------")
+	for i, p := range trend.Points {
+		change := "-"
+		if i > 0 {
+			change = formatDeltaWithPercent(p.Delta, trend.Points[i-1].Total)
+		}
+		drivers := make([]string, 0, len(p.Drivers))
+		for _, d := range p.Drivers {
+			drivers = append(drivers, fmt.Sprintf("%s %+.2f", urnResourceName(d.ResourceID), d.Delta))
+		}
+		driverList := strings.Join(drivers, ", ")
+		if driverList == "" {
+			driverList = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.2f\t%s\t%s\n",
+			p.Snapshot, p.Time.Format(time.DateTime), p.ResourceCount, p.Total, change, driverList)
+	}
+	return w.Flush()
+}
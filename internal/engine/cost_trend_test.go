@@ -0,0 +1,85 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCostTrend(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	ec2 := "aws:ec2/instance:Instance"
+	snapshots := []engine.TrendSnapshot{
+		{Name: "a.json", Time: day(1), Results: []engine.CostResult{
+			{ResourceType: ec2, ResourceID: "web", Currency: "EUR", Monthly: 10},
+			{ResourceType: ec2, ResourceID: "db", Currency: "EUR", Monthly: 50},
+		}},
+		{Name: "b.json", Time: day(2), Results: []engine.CostResult{
+			{ResourceType: ec2, ResourceID: "web", Currency: "EUR", Monthly: 30},
+			{ResourceType: ec2, ResourceID: "db", Currency: "EUR", Monthly: 40},
+			{ResourceType: ec2, ResourceID: "cache", Currency: "EUR", Monthly: 5},
+		}},
+		{Name: "c.json", Time: day(3), Results: []engine.CostResult{
+			{ResourceType: ec2, ResourceID: "web", Currency: "EUR", Monthly: 30},
+		}},
+	}
+
+	trend := engine.BuildCostTrend(snapshots, 1)
+	assert.Equal(t, "EUR", trend.Currency)
+	require.Len(t, trend.Points, 3)
+	assert.Equal(t, []float64{60, 75, 30}, trend.Totals())
+
+	assert.Empty(t, trend.Points[0].Drivers)
+	assert.InDelta(t, 15.0, trend.Points[1].Delta, 1e-9)
+	assert.Equal(t, 3, trend.Points[1].ResourceCount)
+	require.Len(t, trend.Points[1].Drivers, 1, "limited to the largest increase")
+	assert.Equal(t, engine.TrendDriver{
+		ResourceType: ec2, ResourceID: "web", Previous: 10, Current: 30, Delta: 20,
+	}, trend.Points[1].Drivers[0])
+
+	assert.InDelta(t, -45.0, trend.Points[2].Delta, 1e-9)
+	assert.Empty(t, trend.Points[2].Drivers)
+
+	all := engine.BuildCostTrend(snapshots, 5)
+	require.Len(t, all.Points[1].Drivers, 2)
+	assert.Equal(t, "cache", all.Points[1].Drivers[1].ResourceID)
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█", engine.Sparkline([]float64{10, 15, 20}))
+	assert.Equal(t, "▁▁", engine.Sparkline([]float64{7, 7}))
+	assert.Empty(t, engine.Sparkline(nil))
+}
+
+func TestRenderCostTrend(t *testing.T) {
+	trend := engine.BuildCostTrend([]engine.TrendSnapshot{
+		{Name: "day1.json", Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Results: []engine.CostResult{
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+				Monthly: 100},
+		}},
+		{Name: "day2.json", Time: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), Results: []engine.CostResult{
+			{ResourceType: "aws:ec2/instance:Instance", ResourceID: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+				Monthly: 150},
+		}},
+	}, engine.DefaultTrendDrivers)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderCostTrend(&buf, engine.OutputTable, trend))
+	out := buf.String()
+	assert.Contains(t, out, "Projected monthly cost (USD): ▁█")
+	assert.Regexp(t, `day1.json\s+2024-05-01 00:00:00\s+1\s+100.00\s+-\s+-`, out)
+	assert.Regexp(t, `day2.json\s+2024-05-02 00:00:00\s+1\s+150.00\s+\+50.00, \+50.0%\s+web \+50.00`, out)
+
+	buf.Reset()
+	require.NoError(t, engine.RenderCostTrend(&buf, engine.OutputJSON, trend))
+	var decoded engine.CostTrend
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, trend.Totals(), decoded.Totals())
+
+	require.Error(t, engine.RenderCostTrend(&buf, engine.OutputCSV, trend))
+}
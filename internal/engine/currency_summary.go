@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CurrencySubtotal summarizes the results priced in one currency of a mixed-currency
+// result set. Amounts in different currencies are never added together; each subtotal
+// stands on its own.
+type CurrencySubtotal struct {
+	TotalMonthly  float64            `json:"totalMonthly"`
+	TotalHourly   float64            `json:"totalHourly"`
+	TotalCost     float64            `json:"totalCost,omitempty"`
+	ByProvider    map[string]float64 `json:"byProvider"`
+	ByService     map[string]float64 `json:"byService"`
+	ByAdapter     map[string]float64 `json:"byAdapter"`
+	ResourceCount int                `json:"resourceCount"`
+}
+
+// resultCurrency returns the currency of r, treating an empty currency as the default.
+func resultCurrency(r CostResult) string {
+	if r.Currency == "" {
+		return defaultCurrency
+	}
+	return r.Currency
+}
+
+// GroupByCurrency partitions results by currency, an empty currency counting as USD.
+// Results without any cost join a group only when their currency also carries costs:
+// unpriced resources are reported in USD, and they must not turn a stack priced
+// entirely in EUR into a mixed-currency one.
+func GroupByCurrency(results []CostResult) map[string][]CostResult {
+	groups := make(map[string][]CostResult)
+	var zero []CostResult
+	for _, r := range results {
+		if r.Monthly == 0 && r.Hourly == 0 && r.TotalCost == 0 {
+			zero = append(zero, r)
+			continue
+		}
+		currency := resultCurrency(r)
+		groups[currency] = append(groups[currency], r)
+	}
+	for _, r := range zero {
+		if currency := resultCurrency(r); groups[currency] != nil {
+			groups[currency] = append(groups[currency], r)
+		}
+	}
+	return groups
+}
+
+// SortedCurrencies returns the currencies of a ByCurrency map in alphabetical order.
+func SortedCurrencies(byCurrency map[string]CurrencySubtotal) []string {
+	currencies := make([]string, 0, len(byCurrency))
+	for currency := range byCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// summarizeByCurrency returns a subtotal per currency of results, or nil when the
+// results are priced in a single currency.
+func summarizeByCurrency(results []CostResult) map[string]CurrencySubtotal {
+	groups := GroupByCurrency(results)
+	if len(groups) <= 1 {
+		return nil
+	}
+	byCurrency := make(map[string]CurrencySubtotal, len(groups))
+	for currency, group := range groups {
+		summary := AggregateResults(group).Summary
+		subtotal := CurrencySubtotal{
+			TotalMonthly:  summary.TotalMonthly,
+			TotalHourly:   summary.TotalHourly,
+			ByProvider:    summary.ByProvider,
+			ByService:     summary.ByService,
+			ByAdapter:     summary.ByAdapter,
+			ResourceCount: len(group),
+		}
+		for _, r := range group {
+			subtotal.TotalCost += r.TotalCost
+		}
+		byCurrency[currency] = subtotal
+	}
+	return byCurrency
+}
+
+// formatCurrencyTotals lists amounts per currency, e.g. "120.00 EUR, 50.00 USD", with
+// amount picking the figure to show from each subtotal.
+func formatCurrencyTotals(byCurrency map[string]CurrencySubtotal, amount func(CurrencySubtotal) float64) string {
+	parts := make([]string, 0, len(byCurrency))
+	for _, currency := range SortedCurrencies(byCurrency) {
+		parts = append(parts, fmt.Sprintf("%.2f %s", amount(byCurrency[currency]), currency))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// aggregateGroup aggregates the results of one group into a single result, or into one
+// result per currency, in currency order, when the group spans currencies. Results
+// without any cost in a currency the group has no costs in join the first currency.
+func aggregateGroup(results []CostResult, groupName string) []CostResult {
+	groups := GroupByCurrency(results)
+	if len(groups) <= 1 {
+		return []CostResult{AggregateResultsInternal(results, groupName)}
+	}
+
+	currencies := make([]string, 0, len(groups))
+	placed := 0
+	for currency, group := range groups {
+		currencies = append(currencies, currency)
+		placed += len(group)
+	}
+	sort.Strings(currencies)
+	if placed < len(results) {
+		for _, r := range results {
+			if groups[resultCurrency(r)] == nil {
+				groups[currencies[0]] = append(groups[currencies[0]], r)
+			}
+		}
+	}
+
+	aggregated := make([]CostResult, 0, len(currencies))
+	for _, currency := range currencies {
+		result := AggregateResultsInternal(groups[currency], groupName)
+		result.Currency = currency
+		aggregated = append(aggregated, result)
+	}
+	return aggregated
+}
@@ -0,0 +1,95 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mixedCurrencyResults() []engine.CostResult {
+	return []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", ResourceID: "web", Adapter: "aws", Currency: "USD", Monthly: 50, Hourly: 0.07},
+		{ResourceType: "azure:compute:VM", ResourceID: "vm", Adapter: "azure", Currency: "EUR", Monthly: 80, Hourly: 0.11},
+		{ResourceType: "azure:storage:Account", ResourceID: "st", Adapter: "azure", Currency: "EUR", Monthly: 40},
+		{ResourceType: "aws:s3:Bucket", ResourceID: "logs", Adapter: "none", Notes: "No pricing information available"},
+	}
+}
+
+func TestGroupByCurrency(t *testing.T) {
+	groups := engine.GroupByCurrency(mixedCurrencyResults())
+
+	require.Len(t, groups, 2)
+	assert.Len(t, groups["EUR"], 2)
+	// The unpriced bucket counts as USD.
+	assert.Len(t, groups["USD"], 2)
+
+	// Unpriced results do not make a single-currency stack mixed.
+	groups = engine.GroupByCurrency([]engine.CostResult{
+		{ResourceType: "azure:compute:VM", Currency: "EUR", Monthly: 80},
+		{ResourceType: "aws:s3:Bucket", Currency: "USD"},
+	})
+	require.Len(t, groups, 1)
+	assert.Len(t, groups["EUR"], 1)
+}
+
+func TestAggregateResults_MixedCurrencies(t *testing.T) {
+	summary := engine.AggregateResults(mixedCurrencyResults()).Summary
+
+	assert.Empty(t, summary.Currency)
+	assert.Zero(t, summary.TotalMonthly)
+	assert.Empty(t, summary.ByProvider)
+	assert.Equal(t, []string{"EUR", "USD"}, engine.SortedCurrencies(summary.ByCurrency))
+
+	eur := summary.ByCurrency["EUR"]
+	assert.InDelta(t, 120.0, eur.TotalMonthly, 0.0001)
+	assert.InDelta(t, 0.11, eur.TotalHourly, 0.0001)
+	assert.Equal(t, 2, eur.ResourceCount)
+	assert.InDelta(t, 120.0, eur.ByProvider["azure"], 0.0001)
+	assert.InDelta(t, 40.0, eur.ByService["storage"], 0.0001)
+
+	usd := summary.ByCurrency["USD"]
+	assert.InDelta(t, 50.0, usd.TotalMonthly, 0.0001)
+	assert.Equal(t, 2, usd.ResourceCount)
+
+	// A single currency keeps the combined totals.
+	single := engine.AggregateResults(mixedCurrencyResults()[1:3]).Summary
+	assert.Nil(t, single.ByCurrency)
+	assert.Equal(t, "EUR", single.Currency)
+	assert.InDelta(t, 120.0, single.TotalMonthly, 0.0001)
+}
+
+func TestRenderResults_MixedCurrencyTable(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderResults(&buf, engine.OutputTable, mixedCurrencyResults()))
+	out := buf.String()
+
+	assert.Contains(t, out, "EUR, USD (not converted, no combined total)")
+	assert.Contains(t, out, "COST SUMMARY (EUR)")
+	assert.Regexp(t, `Total Monthly Cost:\s+120.00 EUR`, out)
+	assert.Contains(t, out, "COST SUMMARY (USD)")
+	assert.Regexp(t, `Total Monthly Cost:\s+50.00 USD`, out)
+	assert.Contains(t, out, "BY PROVIDER (EUR)")
+	assert.Regexp(t, `azure:\s+120.00 EUR`, out)
+	assert.NotContains(t, out, "170.00")
+}
+
+func TestNewOutputEnvelope_MixedCurrencies(t *testing.T) {
+	env := engine.NewOutputEnvelope(mixedCurrencyResults(), nil, engine.EnvelopeMeta{})
+
+	assert.Zero(t, env.Summary.TotalMonthly)
+	assert.Empty(t, env.Meta.Currency)
+	require.Len(t, env.Summary.ByCurrency, 2)
+	assert.InDelta(t, 120.0, env.Summary.ByCurrency["EUR"].TotalMonthly, 0.0001)
+	assert.Equal(t, 4, env.Summary.ResourceCount)
+	require.NoError(t, engine.ValidateEnvelope(renderTestEnvelope(t, env)))
+}
+
+func TestBuildGitHubAnnotations_MixedCurrencies(t *testing.T) {
+	annotations := engine.BuildGitHubAnnotations(mixedCurrencyResults(), nil, engine.RenderOptions{})
+
+	require.NotEmpty(t, annotations)
+	assert.Equal(t, "Monthly cost by currency 120.00 EUR, 50.00 USD across 4 resources", annotations[0].Message)
+}
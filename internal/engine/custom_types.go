@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Custom type actions accepted by NewCustomTypeRules.
+const (
+	CustomTypeMap         = "map"
+	CustomTypeFixed       = "fixed"
+	CustomTypeUnpriceable = "unpriceable"
+)
+
+const (
+	// dynamicTypeModule is the module of types created by Pulumi dynamic providers, such
+	// as pulumi-nodejs:dynamic:Resource and pulumi-python:dynamic:Resource.
+	dynamicTypeModule = "dynamic"
+
+	// dynamicResourceNote marks results for resources created by a dynamic provider.
+	dynamicResourceNote = "Dynamic provider resource"
+
+	// adapterCustomType is the adapter reported for fixed and unpriceable custom types.
+	adapterCustomType = "custom-type"
+)
+
+// ErrUnknownCustomTypeAction is returned for a custom type action that does not exist.
+var ErrUnknownCustomTypeAction = errors.New("unknown custom type action")
+
+// CustomTypeRule tells the engine how to price a resource type it cannot recognize, such
+// as one created by a dynamic or custom provider. Type matches exactly, or by prefix when
+// it ends in "*". Only the fields of its Action are used:
+//
+//	map          MapTo              prices the resource as if it were of type MapTo
+//	fixed        Monthly, Currency  reports a fixed monthly cost (Currency defaults to USD)
+//	unpriceable                     reports no cost and says the type cannot be priced
+type CustomTypeRule struct {
+	Type     string
+	Action   string
+	MapTo    string
+	Monthly  float64
+	Currency string
+}
+
+// CustomTypeRules are consulted in order; the first rule whose Type matches applies.
+type CustomTypeRules []CustomTypeRule
+
+// NewCustomTypeRules validates rules and returns them with normalized actions.
+func NewCustomTypeRules(rules []CustomTypeRule) (CustomTypeRules, error) {
+	out := make(CustomTypeRules, 0, len(rules))
+	for i, rule := range rules {
+		rule.Type = strings.TrimSpace(rule.Type)
+		rule.Action = strings.ToLower(strings.TrimSpace(rule.Action))
+		if rule.Type == "" {
+			return nil, fmt.Errorf("custom type %d: type is required", i+1)
+		}
+		switch rule.Action {
+		case CustomTypeMap:
+			if strings.TrimSpace(rule.MapTo) == "" {
+				return nil, fmt.Errorf("custom type %s: map_to is required", rule.Type)
+			}
+		case CustomTypeFixed:
+			if rule.Monthly < 0 {
+				return nil, fmt.Errorf("custom type %s: monthly must not be negative", rule.Type)
+			}
+			if rule.Currency == "" {
+				rule.Currency = defaultCurrency
+			}
+		case CustomTypeUnpriceable:
+		default:
+			return nil, fmt.Errorf("custom type %s: %w %q", rule.Type, ErrUnknownCustomTypeAction, rule.Action)
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+// WithCustomTypes sets the rules used to price unrecognized resource types and returns the
+// engine for chaining.
+func (e *Engine) WithCustomTypes(rules CustomTypeRules) *Engine {
+	e.customTypes = rules
+	return e
+}
+
+// IsDynamicProviderType reports whether resourceType was created by a Pulumi dynamic
+// provider, such as pulumi-nodejs:dynamic:Resource.
+func IsDynamicProviderType(resourceType string) bool {
+	parts := strings.Split(resourceType, ":")
+	return len(parts) == minProviderServiceTypeParts &&
+		strings.HasPrefix(parts[0], "pulumi-") && parts[1] == dynamicTypeModule
+}
+
+// match returns the first rule for resourceType, or nil.
+func (r CustomTypeRules) match(resourceType string) *CustomTypeRule {
+	for i, rule := range r {
+		if prefix, isPrefix := strings.CutSuffix(rule.Type, "*"); isPrefix {
+			if strings.HasPrefix(resourceType, prefix) {
+				return &r[i]
+			}
+		} else if resourceType == rule.Type {
+			return &r[i]
+		}
+	}
+	return nil
+}
+
+// resolve returns the resource to price and, when no plugin or spec should be asked, the
+// result to report instead. Mapped resources are priced as their target type. Dynamic
+// provider resources without a rule are unpriceable rather than given a generic default.
+// hours is the number of hours in a month.
+func (r CustomTypeRules) resolve(resource ResourceDescriptor, hours float64) (ResourceDescriptor, *CostResult) {
+	rule := r.match(resource.Type)
+	if rule == nil {
+		if IsDynamicProviderType(resource.Type) {
+			return resource, unpriceableResult(resource,
+				"no custom_types mapping, cannot be priced")
+		}
+		return resource, nil
+	}
+	switch rule.Action {
+	case CustomTypeMap:
+		mapped := resource
+		mapped.Type = rule.MapTo
+		mapped.Provider = extractProviderFromType(rule.MapTo)
+		return mapped, nil
+	case CustomTypeFixed:
+		return resource, &CostResult{
+			ResourceType: resource.Type,
+			ResourceID:   resource.ID,
+			Adapter:      adapterCustomType,
+			Currency:     rule.Currency,
+			Monthly:      rule.Monthly,
+			Hourly:       rule.Monthly / hours,
+			Notes:        customTypeNote(resource.Type, "fixed cost from custom_types"),
+		}
+	default:
+		return resource, unpriceableResult(resource, "custom type, unpriceable")
+	}
+}
+
+// unpriceableResult is the $0 result reported for a custom type that cannot be priced.
+func unpriceableResult(resource ResourceDescriptor, reason string) *CostResult {
+	return &CostResult{
+		ResourceType: resource.Type,
+		ResourceID:   resource.ID,
+		Adapter:      adapterCustomType,
+		Currency:     defaultCurrency,
+		Notes:        customTypeNote(resource.Type, reason),
+	}
+}
+
+// customTypeNote prefixes note with the dynamic provider marker for dynamic types.
+func customTypeNote(resourceType, note string) string {
+	if IsDynamicProviderType(resourceType) {
+		return dynamicResourceNote + ": " + note
+	}
+	return note
+}
+
+// labelCustomTypeResults restores the original type on results of a resource priced as
+// another type and notes the mapping.
+func labelCustomTypeResults(results []CostResult, original, priced ResourceDescriptor) {
+	if original.Type == priced.Type {
+		return
+	}
+	mapping := customTypeNote(original.Type, "priced as "+priced.Type)
+	for i := range results {
+		results[i].ResourceType = original.Type
+		if results[i].Notes == "" {
+			results[i].Notes = mapping
+		} else {
+			results[i].Notes = mapping + "; " + results[i].Notes
+		}
+	}
+}
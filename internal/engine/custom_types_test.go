@@ -0,0 +1,100 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDynamicProviderType(t *testing.T) {
+	assert.True(t, engine.IsDynamicProviderType("pulumi-nodejs:dynamic:Resource"))
+	assert.True(t, engine.IsDynamicProviderType("pulumi-python:dynamic:Resource"))
+	assert.False(t, engine.IsDynamicProviderType("aws:ec2/instance:Instance"))
+	assert.False(t, engine.IsDynamicProviderType("pulumi:providers:aws"))
+	assert.False(t, engine.IsDynamicProviderType("mycloud:index:Widget"))
+}
+
+func TestNewCustomTypeRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule engine.CustomTypeRule
+	}{
+		{"missing type", engine.CustomTypeRule{Action: engine.CustomTypeUnpriceable}},
+		{"map without target", engine.CustomTypeRule{Type: "x:y:Z", Action: engine.CustomTypeMap}},
+		{"negative fixed cost", engine.CustomTypeRule{Type: "x:y:Z", Action: engine.CustomTypeFixed, Monthly: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.NewCustomTypeRules([]engine.CustomTypeRule{tt.rule})
+			require.Error(t, err)
+		})
+	}
+
+	_, err := engine.NewCustomTypeRules([]engine.CustomTypeRule{{Type: "x:y:Z", Action: "guess"}})
+	require.ErrorIs(t, err, engine.ErrUnknownCustomTypeAction)
+}
+
+func TestGetProjectedCost_CustomTypes(t *testing.T) {
+	loader := &MockSpecLoader{
+		specs: map[string]*engine.PricingSpec{
+			"aws-ec2-t3.micro": {
+				Provider: "aws",
+				Service:  "ec2",
+				SKU:      "t3.micro",
+				Currency: "USD",
+				Pricing:  map[string]interface{}{"onDemandHourly": 0.01},
+			},
+		},
+	}
+	rules, err := engine.NewCustomTypeRules([]engine.CustomTypeRule{
+		{Type: "pulumi-nodejs:dynamic:Resource", Action: "MAP", MapTo: "aws:ec2/instance:Instance"},
+		{Type: "acme:dns:*", Action: engine.CustomTypeFixed, Monthly: 5},
+		{Type: "acme:legacy:Box", Action: engine.CustomTypeUnpriceable},
+	})
+	require.NoError(t, err)
+
+	resources := []engine.ResourceDescriptor{
+		{
+			Type:       "pulumi-nodejs:dynamic:Resource",
+			ID:         "mapped",
+			Provider:   "pulumi-nodejs",
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		},
+		{Type: "acme:dns:Record", ID: "fixed", Provider: "acme"},
+		{Type: "acme:legacy:Box", ID: "unpriceable", Provider: "acme"},
+		{Type: "pulumi-python:dynamic:Resource", ID: "unmapped", Provider: "pulumi-python"},
+	}
+	eng := newTestEngine(t, nil, loader).WithCustomTypes(rules)
+
+	check := func(t *testing.T, results []engine.CostResult) {
+		t.Helper()
+		require.Len(t, results, 4)
+
+		assert.Equal(t, "pulumi-nodejs:dynamic:Resource", results[0].ResourceType)
+		assert.Equal(t, "local-spec", results[0].Adapter)
+		assert.InDelta(t, 7.3, results[0].Monthly, 0.001)
+		assert.Contains(t, results[0].Notes, "Dynamic provider resource: priced as aws:ec2/instance:Instance")
+
+		assert.Equal(t, "custom-type", results[1].Adapter)
+		assert.InDelta(t, 5.0, results[1].Monthly, 0.001)
+		assert.Equal(t, "USD", results[1].Currency)
+
+		assert.Zero(t, results[2].Monthly)
+		assert.Contains(t, results[2].Notes, "unpriceable")
+
+		assert.Zero(t, results[3].Monthly, "dynamic resources must not get a default price")
+		assert.Contains(t, results[3].Notes, "Dynamic provider resource")
+	}
+
+	results, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	check(t, results)
+
+	withErrors, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	assert.False(t, withErrors.HasErrors())
+	check(t, withErrors.Results)
+}
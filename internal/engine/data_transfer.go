@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Tags that declare a resource's expected outbound transfer without a manifest.
+	transferTagDestination = "finfocus:transfer-to"
+	transferTagGBPerMonth  = "finfocus:transfer-gb-month"
+
+	// dataTransferPricingKey is the spec pricing block holding transfer rates:
+	//
+	//	pricing:
+	//	  data_transfer:
+	//	    egress_per_gb: 0.09
+	//	    inter_az_per_gb: 0.01
+	//	    inter_region:
+	//	      "us-east-1->eu-west-1": 0.02
+	dataTransferPricingKey = "data_transfer"
+	dataTransferService    = "data-transfer"
+
+	breakdownDataTransfer = "data_transfer"
+	regionPairSeparator   = "->"
+)
+
+// zonePattern matches AWS (us-east-1a) and GCP (us-central1-a) zone names, capturing the region.
+var zonePattern = regexp.MustCompile(`^(.*\d)-?[a-z]$`)
+
+// TransferEstimate is the expected monthly data transfer out of a resource. Destination
+// is a region or availability zone; an empty destination means transfer to the internet.
+type TransferEstimate struct {
+	ResourceID  string  `yaml:"resource" json:"resource"`
+	Destination string  `yaml:"to" json:"to"`
+	GBPerMonth  float64 `yaml:"gbPerMonth" json:"gbPerMonth"`
+}
+
+// transferManifest is the on-disk format read by LoadTransferManifest.
+type transferManifest struct {
+	Transfers []TransferEstimate `yaml:"transfers"`
+}
+
+// LoadTransferManifest reads a YAML file with a top-level "transfers" list.
+func LoadTransferManifest(path string) ([]TransferEstimate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading transfer manifest: %w", err)
+	}
+	var manifest transferManifest
+	if unmarshalErr := yaml.Unmarshal(data, &manifest); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing transfer manifest: %w", unmarshalErr)
+	}
+	for i, t := range manifest.Transfers {
+		if t.ResourceID == "" {
+			return nil, fmt.Errorf("transfer %d: resource is required", i+1)
+		}
+		if t.GBPerMonth < 0 {
+			return nil, fmt.Errorf("transfer %d: gbPerMonth must not be negative", i+1)
+		}
+	}
+	return manifest.Transfers, nil
+}
+
+// WithTransferEstimates sets manifest transfer estimates, keyed by resource ID, and returns
+// the engine for chaining. Estimates declared through tags are always considered.
+func (e *Engine) WithTransferEstimates(estimates []TransferEstimate) *Engine {
+	e.transfers = make(map[string][]TransferEstimate, len(estimates))
+	for _, t := range estimates {
+		e.transfers[t.ResourceID] = append(e.transfers[t.ResourceID], t)
+	}
+	return e
+}
+
+// placement is a resolved region and optional zone.
+type placement struct {
+	region string
+	zone   string
+}
+
+// parsePlacement resolves a region or zone name, deriving the region from zone names.
+func parsePlacement(location string) placement {
+	location = strings.TrimSpace(location)
+	if m := zonePattern.FindStringSubmatch(location); m != nil {
+		return placement{region: m[1], zone: location}
+	}
+	return placement{region: location}
+}
+
+// resourcePlacement reads a resource's zone or region from its properties.
+func resourcePlacement(props map[string]interface{}) placement {
+	for _, key := range []string{"availabilityZone", "zone"} {
+		if zone, ok := getStringProperty(props, key); ok {
+			return parsePlacement(zone)
+		}
+	}
+	for _, key := range []string{"region", "location"} {
+		if region, ok := getStringProperty(props, key); ok {
+			return placement{region: region}
+		}
+	}
+	return placement{}
+}
+
+// dataTransferRates are the per-GB rates from a spec's data_transfer block.
+type dataTransferRates struct {
+	egress, interAZ       float64
+	hasEgress, hasInterAZ bool
+	pairs                 map[string]float64
+}
+
+func parseDataTransferRates(pricing map[string]interface{}) (dataTransferRates, bool) {
+	block, ok := pricing[dataTransferPricingKey].(map[string]interface{})
+	if !ok {
+		return dataTransferRates{}, false
+	}
+	rates := dataTransferRates{pairs: make(map[string]float64)}
+	rates.egress, rates.hasEgress = parseFloatValue(block["egress_per_gb"])
+	rates.interAZ, rates.hasInterAZ = parseFloatValue(block["inter_az_per_gb"])
+	if pairs, isMap := block["inter_region"].(map[string]interface{}); isMap {
+		for pair, v := range pairs {
+			if rate, isNum := parseFloatValue(v); isNum {
+				rates.pairs[strings.ReplaceAll(pair, " ", "")] = rate
+			}
+		}
+	}
+	return rates, true
+}
+
+// rate returns the per-GB rate from src to dst and a description of how it was chosen.
+// Missing inter-AZ or region-pair rates fall back to the generic egress rate.
+func (r dataTransferRates) rate(src, dst placement) (float64, string, bool) {
+	switch {
+	case dst.region == "":
+		return r.egress, "internet egress", r.hasEgress
+	case src.region == dst.region && (src.zone == "" || dst.zone == "" || src.zone == dst.zone):
+		return 0, "same zone", true
+	case src.region == dst.region:
+		if r.hasInterAZ {
+			return r.interAZ, "inter-AZ", true
+		}
+		return r.egress, "inter-AZ (generic egress rate)", r.hasEgress
+	}
+	if rate, ok := r.pairs[src.region+regionPairSeparator+dst.region]; ok {
+		return rate, "inter-region", true
+	}
+	return r.egress, "inter-region (generic egress rate)", r.hasEgress
+}
+
+// transferEstimatesFor combines manifest estimates with those declared in resource tags.
+func (e *Engine) transferEstimatesFor(resource ResourceDescriptor) []TransferEstimate {
+	estimates := append([]TransferEstimate(nil), e.transfers[resource.ID]...)
+	tags, _ := resource.Properties["tags"].(map[string]interface{})
+	if gb, ok := parseFloatValue(tags[transferTagGBPerMonth]); ok && gb > 0 {
+		dest, _ := tags[transferTagDestination].(string)
+		estimates = append(estimates, TransferEstimate{ResourceID: resource.ID, Destination: dest, GBPerMonth: gb})
+	}
+	return estimates
+}
+
+// applyDataTransfer adds the monthly cost of a resource's expected transfer to each priced
+// result. Rates come from the data_transfer block of the resource's spec, or of the
+// provider's data-transfer spec when the resource spec has none.
+func (e *Engine) applyDataTransfer(ctx context.Context, results []CostResult, resource ResourceDescriptor) {
+	estimates := e.transferEstimatesFor(resource)
+	if len(estimates) == 0 || e.loader == nil {
+		return
+	}
+
+	provider := resource.Provider
+	if provider == "" {
+		provider = extractProviderFromType(resource.Type)
+	}
+	rates, found := dataTransferRates{}, false
+	service := extractService(resource.Type)
+	serviceSpec := e.loadSpecWithFallback(ctx, provider, service, extractSKU(resource), fallbackSKUs(resource)...)
+	if serviceSpec != nil {
+		rates, found = parseDataTransferRates(serviceSpec.Pricing)
+	}
+	if !found {
+		if spec := e.loadSpec(ctx, provider, dataTransferService, defaultServiceName); spec != nil {
+			rates, found = parseDataTransferRates(spec.Pricing)
+		}
+	}
+
+	src := resourcePlacement(resource.Properties)
+	var (
+		monthly float64
+		notes   []string
+	)
+	for _, t := range estimates {
+		dst := parsePlacement(t.Destination)
+		destination := t.Destination
+		if destination == "" {
+			destination = "internet"
+		}
+		rate, kind, ok := rates.rate(src, dst)
+		if !found || !ok {
+			notes = append(notes, fmt.Sprintf("%.0f GB/month to %s not priced: no data_transfer rate",
+				t.GBPerMonth, destination))
+			continue
+		}
+		monthly += t.GBPerMonth * rate
+		notes = append(notes, fmt.Sprintf("%.0f GB/month to %s at %g/GB (%s)", t.GBPerMonth, destination, rate, kind))
+	}
+
+	note := "Data transfer: " + strings.Join(notes, ", ")
+	hours := e.hoursPerMonth(serviceSpec)
+	for i := range results {
+		r := &results[i]
+		if r.Adapter == "none" {
+			continue
+		}
+		r.Monthly += monthly
+		r.Hourly += monthly / hours
+		if monthly > 0 {
+			if r.Breakdown == nil {
+				r.Breakdown = make(map[string]float64)
+			}
+			r.Breakdown[breakdownDataTransfer] += monthly
+		}
+		if r.Notes != "" {
+			r.Notes += "; " + note
+		} else {
+			r.Notes = note
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package engine_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDataTransferTestEngine(t *testing.T) *engine.Engine {
+	t.Helper()
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+		"aws-data-transfer-default.yaml": "provider: aws\nservice: data-transfer\nsku: default\ncurrency: USD\n" +
+			"pricing:\n  data_transfer:\n    egress_per_gb: 0.09\n    inter_az_per_gb: 0.01\n" +
+			"    inter_region:\n      \"us-east-1->eu-west-1\": 0.02\n",
+	})
+	return newTestEngine(t, nil, loader)
+}
+
+func TestGetProjectedCost_DataTransfer(t *testing.T) {
+	base := engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro", "availabilityZone": "us-east-1a"},
+	}
+	compute := unitMonthly(t, newDataTransferTestEngine(t), base)
+
+	tests := []struct {
+		name        string
+		destination string
+		rate        float64
+		kind        string
+	}{
+		{name: "same zone", destination: "us-east-1a", rate: 0, kind: "same zone"},
+		{name: "inter-AZ", destination: "us-east-1b", rate: 0.01, kind: "inter-AZ"},
+		{name: "region pair", destination: "eu-west-1", rate: 0.02, kind: "inter-region"},
+		{name: "unlisted region", destination: "ap-south-1", rate: 0.09, kind: "generic egress rate"},
+		{name: "internet", destination: "", rate: 0.09, kind: "internet egress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eng := newDataTransferTestEngine(t).WithTransferEstimates([]engine.TransferEstimate{
+				{ResourceID: "web", Destination: tt.destination, GBPerMonth: 100},
+			})
+			results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{base})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			assert.InDelta(t, compute+100*tt.rate, results[0].Monthly, 0.0001)
+			assert.Contains(t, results[0].Notes, tt.kind)
+			if tt.rate > 0 {
+				assert.InDelta(t, 100*tt.rate, results[0].Breakdown["data_transfer"], 0.0001)
+			}
+		})
+	}
+}
+
+func TestGetProjectedCost_DataTransferFromTags(t *testing.T) {
+	eng := newDataTransferTestEngine(t)
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		Properties: map[string]interface{}{
+			"instanceType": "t3.micro",
+			"region":       "us-east-1",
+			"tags": map[string]interface{}{
+				"finfocus:transfer-to":       "eu-west-1",
+				"finfocus:transfer-gb-month": "500",
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.InDelta(t, 10.0, results[0].Breakdown["data_transfer"], 0.0001)
+	assert.Contains(t, results[0].Notes, "500 GB/month to eu-west-1")
+}
+
+func TestGetProjectedCost_DataTransferWithoutRates(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	resource := engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}
+	compute := unitMonthly(t, eng, resource)
+
+	results, err := eng.WithTransferEstimates([]engine.TransferEstimate{{ResourceID: "web", GBPerMonth: 50}}).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.InDelta(t, compute, results[0].Monthly, 0.0001)
+	assert.Contains(t, results[0].Notes, "not priced")
+}
+
+func TestLoadTransferManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transfers.yaml")
+	content := "transfers:\n  - resource: web\n    to: eu-west-1\n    gbPerMonth: 250\n  - resource: db\n    gbPerMonth: 10\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	transfers, err := engine.LoadTransferManifest(path)
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	assert.Equal(t, "eu-west-1", transfers[0].Destination)
+	assert.InDelta(t, 250.0, transfers[0].GBPerMonth, 0.0001)
+
+	require.NoError(t, os.WriteFile(path, []byte("transfers:\n  - to: eu-west-1\n    gbPerMonth: 1\n"), 0o600))
+	_, err = engine.LoadTransferManifest(path)
+	require.ErrorContains(t, err, "resource is required")
+}
@@ -0,0 +1,81 @@
+package engine
+
+import "strings"
+
+const (
+	// defaultVPCNote explains the cost of a default VPC, which is free but shapes the cost
+	// of what runs in it.
+	defaultVPCNote = "Default VPC: free itself, but instances in its subnets get public IPv4 addresses " +
+		"billed hourly, and it has no NAT gateway"
+
+	// defaultSubnetPublicIPNote explains the public IPv4 charge of instances launched into
+	// default subnets, which assign public addresses unless told otherwise.
+	defaultSubnetPublicIPNote = "Launches into a default VPC subnet, which assigns a public IPv4 address " +
+		"billed at about $0.005/hour unless associatePublicIpAddress is false"
+)
+
+// defaultResourceNotes explains the cost of provider default resources, keyed by Pulumi
+// type. Default resources exist in every account and are adopted by Pulumi rather than
+// created. None is charged for itself, but some shape the cost of resources placed in
+// them. Unlike internal Pulumi types they are real cloud resources, so plugins still
+// price them.
+var defaultResourceNotes = map[string]string{
+	"aws:ec2/defaultVpc:DefaultVpc": defaultVPCNote,
+	"aws:ec2/defaultSubnet:DefaultSubnet": "Default subnet: free itself, but assigns public IPv4 addresses, " +
+		"billed hourly, to instances launched in it",
+	"aws:ec2/defaultSecurityGroup:DefaultSecurityGroup":   "Default security group: no cost",
+	"aws:ec2/defaultNetworkAcl:DefaultNetworkAcl":         "Default network ACL: no cost",
+	"aws:ec2/defaultRouteTable:DefaultRouteTable":         "Default route table: no cost",
+	"aws:ec2/defaultVpcDhcpOptions:DefaultVpcDhcpOptions": "Default DHCP options: no cost",
+	"awsx:ec2:DefaultVpc":                                 defaultVPCNote,
+}
+
+// applyDefaultResourceNotes notes the cost of a default resource, or of an instance
+// implicitly launched into the default VPC, on the results priced for it.
+func (e *Engine) applyDefaultResourceNotes(results []CostResult, resource ResourceDescriptor) {
+	if !e.options.DefaultResourceNotes {
+		return
+	}
+	if note, ok := defaultResourceNotes[resource.Type]; ok {
+		for i := range results {
+			if results[i].Adapter == "none" {
+				results[i].Adapter = ""
+				results[i].Notes = note
+				continue
+			}
+			results[i].Notes = appendDefaultNote(results[i].Notes, note)
+		}
+		return
+	}
+	if resource.Type == awsInstanceType && launchesIntoDefaultSubnet(resource.Properties) {
+		for i := range results {
+			results[i].Notes = appendDefaultNote(results[i].Notes, defaultSubnetPublicIPNote)
+		}
+	}
+}
+
+// launchesIntoDefaultSubnet reports whether an instance without an explicit subnet or
+// network interface would be placed in a default subnet with a public address.
+func launchesIntoDefaultSubnet(props map[string]interface{}) bool {
+	for _, key := range []string{"subnetId", "networkInterfaces", "launchTemplate"} {
+		if v, ok := props[key]; ok && v != nil {
+			return false
+		}
+	}
+	if public, ok := props["associatePublicIpAddress"].(bool); ok && !public {
+		return false
+	}
+	return true
+}
+
+// appendDefaultNote adds note to a result's notes unless it is already there.
+func appendDefaultNote(notes, note string) string {
+	switch {
+	case notes == "":
+		return note
+	case strings.Contains(notes, note):
+		return notes
+	default:
+		return notes + "; " + note
+	}
+}
@@ -0,0 +1,48 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultResourceNotes(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{Type: "aws:ec2/defaultVpc:DefaultVpc", ID: "default-vpc"},
+		{Type: "aws:ec2/defaultSecurityGroup:DefaultSecurityGroup", ID: "default-sg"},
+		{Type: "aws:ec2/instance:Instance", ID: "implicit", Properties: map[string]interface{}{"instanceType": "t3.micro"}},
+		{Type: "aws:ec2/instance:Instance", ID: "private", Properties: map[string]interface{}{
+			"instanceType": "t3.micro", "associatePublicIpAddress": false,
+		}},
+		{Type: "aws:ec2/instance:Instance", ID: "placed", Properties: map[string]interface{}{
+			"instanceType": "t3.micro", "subnetId": "subnet-123",
+		}},
+		{Type: "pulumi:providers:aws", ID: "provider"},
+	}
+
+	result, err := newTestEngine(t, nil, nil, engine.EngineOptions{DefaultResourceNotes: true}).
+		GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	byID := make(map[string]engine.CostResult, len(result.Results))
+	for _, r := range result.Results {
+		byID[r.ResourceID] = r
+	}
+
+	vpc := byID["default-vpc"]
+	assert.Empty(t, vpc.Adapter, "unpriced default resources are free, not unpriced")
+	assert.Contains(t, vpc.Notes, "Default VPC: free itself")
+	assert.Equal(t, "Default security group: no cost", byID["default-sg"].Notes)
+
+	assert.Equal(t, "none", byID["implicit"].Adapter, "instances are still priced normally")
+	assert.Contains(t, byID["implicit"].Notes, "No pricing information available; Launches into a default VPC subnet")
+	assert.NotContains(t, byID["private"].Notes, "default VPC")
+	assert.NotContains(t, byID["placed"].Notes, "default VPC")
+	assert.Equal(t, "Internal Pulumi resource (no cloud cost)", byID["provider"].Notes)
+
+	result, err = newTestEngine(t, nil, nil).GetProjectedCostWithErrors(context.Background(), resources[:1])
+	require.NoError(t, err)
+	assert.Equal(t, "none", result.Results[0].Adapter, "notes are opt-in")
+}
@@ -26,6 +26,9 @@
 //   - json: Structured JSON for programmatic use
 //   - ndjson: Newline-delimited JSON for streaming
 //
+// StreamResults writes NDJSON as results arrive on a channel, flushing each line, so
+// large result sets can be piped into log processors without being buffered.
+//
 // # Timeouts
 //
 // Operations are protected by context timeouts:
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -48,8 +49,8 @@ const (
 	ContextKeyUtilization ContextKey = "utilization"
 
 	// Timeout constants for engine operations.
-	defaultQueryTimeout = 60 * time.Second // Overall query timeout.
-	perResourceTimeout  = 5 * time.Second  // Per-resource calculation timeout.
+	defaultQueryTimeout       = 60 * time.Second // Base overall query timeout, scaled by resource count.
+	defaultPerResourceTimeout = 5 * time.Second  // Per-resource calculation timeout.
 )
 
 var (
@@ -72,16 +73,48 @@ type SpecLoader interface {
 
 // Engine orchestrates cost calculations between plugins and local pricing specifications.
 type Engine struct {
-	clients []*pluginhost.Client
-	loader  SpecLoader
+	clients      []*pluginhost.Client
+	loader       SpecLoader
+	suppressions []RecommendationSuppression
+	commitments  *CommitmentReport
+	pluginSlots  chan struct{}
+	transfers    map[string][]TransferEstimate
+	pricingCache *PricingCache
+	transforms   TransformChain
+	customTypes  CustomTypeRules
+	costRules    CostRules
+	costHistory  *CostHistory
+	pluginLayers []PluginLayer
+
+	options       EngineOptions
+	zeroCostTypes ZeroCostTypes
 }
 
-// New creates a new Engine with the given plugin clients and spec loader.
-func New(clients []*pluginhost.Client, loader SpecLoader) *Engine {
-	return &Engine{
+// New creates a new Engine with the given plugin clients and spec loader, configured by
+// the optional EngineOptions. It returns an error when the options do not validate.
+func New(clients []*pluginhost.Client, loader SpecLoader, opts ...EngineOptions) (*Engine, error) {
+	e := &Engine{
 		clients: clients,
 		loader:  loader,
 	}
+	if len(opts) > 0 {
+		if err := opts[0].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid engine options: %w", err)
+		}
+		e.options = opts[0]
+	}
+	e.zeroCostTypes = NewZeroCostTypes(e.options.ZeroCostTypes)
+	if e.options.MaxConcurrentPluginCalls > 0 {
+		e.pluginSlots = make(chan struct{}, e.options.MaxConcurrentPluginCalls)
+	}
+	return e, nil
+}
+
+// WithRecommendationSuppressions sets the rules used to hide acknowledged recommendations
+// and returns the engine for chaining.
+func (e *Engine) WithRecommendationSuppressions(rules []RecommendationSuppression) *Engine {
+	e.suppressions = rules
+	return e
 }
 
 func (e *Engine) getConcurrencyMultiplier() int {
@@ -97,6 +130,9 @@ func (e *Engine) getWorkerCount(jobCount int) int {
 	if jobCount == 0 {
 		return 0
 	}
+	if e.options.Workers > 0 {
+		return min(e.options.Workers, jobCount)
+	}
 	numWorkers := runtime.NumCPU() * e.getConcurrencyMultiplier()
 	if jobCount < numWorkers {
 		numWorkers = jobCount
@@ -105,8 +141,8 @@ func (e *Engine) getWorkerCount(jobCount int) int {
 }
 
 // GetProjectedCost calculates projected costs for the given resources using plugins or specs.
-//
-//nolint:gocognit,funlen // Comprehensive cost calculation requires multiple nested conditions and fallbacks.
+// Plugin failures are logged and the resource falls back to the next pricing source; use
+// GetProjectedCostWithErrors to also report them.
 func (e *Engine) GetProjectedCost(
 	ctx context.Context,
 	resources []ResourceDescriptor,
@@ -114,12 +150,9 @@ func (e *Engine) GetProjectedCost(
 	log := logging.FromContext(ctx)
 	start := time.Now()
 
-	// Apply overall query timeout if not already set
-	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, defaultQueryTimeout)
-		defer cancel()
-	}
+	// Apply overall query timeout (scaled by resource count) if not already set
+	ctx, cancel := withQueryTimeout(ctx, len(resources))
+	defer cancel()
 
 	log.Debug().
 		Ctx(ctx).
@@ -136,180 +169,50 @@ func (e *Engine) GetProjectedCost(
 		}
 	}
 
-	type job struct {
-		index    int
-		resource ResourceDescriptor
-	}
-
-	type workerResult struct {
-		index   int
-		results []CostResult
-	}
-
-	numWorkers := e.getWorkerCount(len(resources))
-	if numWorkers == 0 {
-		return []CostResult{}, nil
-	}
-
-	jobs := make(chan job, len(resources))
-	resultsChan := make(chan workerResult, len(resources))
-	var wg sync.WaitGroup
-
-	// Worker function
-	worker := func() {
-		defer wg.Done()
-		for j := range jobs {
-			// Check context cancellation
-			if ctx.Err() != nil {
-				return
-			}
-
-			resource := j.resource
-			var resourceResults []CostResult
-
-			for _, client := range e.clients {
-				log.Debug().
-					Ctx(ctx).
-					Str("component", "engine").
-					Str("resource_type", resource.Type).
-					Str("resource_id", resource.ID).
-					Str("plugin", client.Name).
-					Msg("querying plugin for projected cost")
-
-				// Apply per-resource timeout for plugin calls
-				resourceCtx, resourceCancel := context.WithTimeout(ctx, perResourceTimeout)
-				result, err := e.getProjectedCostFromPlugin(resourceCtx, client, resource)
-				resourceCancel()
-				if err != nil {
-					log.Debug().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("resource_type", resource.Type).
-						Str("plugin", client.Name).
-						Err(err).
-						Msg("plugin did not return cost data")
-					continue
-				}
-				if result != nil {
-					log.Debug().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("resource_type", resource.Type).
-						Str("plugin", client.Name).
-						Float64("monthly_cost", result.Monthly).
-						Msg("plugin returned cost data")
-					resourceResults = append(resourceResults, *result)
-				}
-			}
-
-			if len(resourceResults) == 0 {
-				// Single spec fallback per resource
-				if e.loader != nil {
-					log.Debug().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("resource_type", resource.Type).
-						Str("resource_id", resource.ID).
-						Msg("no plugin data, trying spec fallback")
-
-					if specRes := e.getProjectedCostFromSpec(ctx, resource); specRes != nil {
-						log.Debug().
-							Ctx(ctx).
-							Str("component", "engine").
-							Str("resource_type", resource.Type).
-							Float64("monthly_cost", specRes.Monthly).
-							Msg("spec fallback provided cost data")
-						resourceResults = append(resourceResults, *specRes)
-					}
-				}
-
-				if len(resourceResults) == 0 {
-					// Final fallback: no cost data available
-					log.Warn().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("resource_type", resource.Type).
-						Str("resource_id", resource.ID).
-						Msg("no pricing data available from plugins or specs")
-
-					resourceResults = append(resourceResults, CostResult{
-						ResourceType: resource.Type,
-						ResourceID:   resource.ID,
-						Adapter:      "none",
-						Currency:     defaultCurrency,
-						Monthly:      0,
-						Hourly:       0,
-						Notes:        "No pricing information available",
-					})
-				}
-			}
-
-			resultsChan <- workerResult{index: j.index, results: resourceResults}
-		}
-	}
-
-	// Start workers
-	for range numWorkers {
-		wg.Add(1)
-		go worker()
-	}
-
-	// Submit jobs
-	for i, resource := range resources {
-		jobs <- job{index: i, resource: resource}
-	}
-	close(jobs)
-
-	// Wait for workers in a goroutine to close resultsChan
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-
-	// Collect results
-	var collectedResults []workerResult
-	for res := range resultsChan {
-		collectedResults = append(collectedResults, res)
-	}
-
+	result, err := e.projectCosts(ctx, resources)
 	if ctx.Err() != nil {
 		log.Warn().
 			Ctx(ctx).
 			Str("component", "engine").
-			Int("processed", len(collectedResults)).
+			Int("processed", len(result.Results)).
 			Int("total", len(resources)).
 			Msg("query timeout reached, returning partial results")
+		return result.Results, fmt.Errorf("projected cost calculation cancelled: %w", ctx.Err())
 	}
-
-	// Sort by index to preserve order
-	sort.Slice(collectedResults, func(i, j int) bool {
-		return collectedResults[i].index < collectedResults[j].index
-	})
-
-	var results []CostResult
-	for _, cr := range collectedResults {
-		results = append(results, cr.results...)
-	}
-
-	if ctx.Err() != nil {
-		return results, fmt.Errorf("projected cost calculation cancelled: %w", ctx.Err())
+	if err != nil {
+		return nil, err
 	}
 
 	log.Info().
 		Ctx(ctx).
 		Str("component", "engine").
 		Str("operation", "get_projected_cost").
-		Int("result_count", len(results)).
+		Int("result_count", len(result.Results)).
 		Dur("duration_ms", time.Since(start)).
 		Msg("projected cost calculation complete")
 
-	return results, nil
+	return result.Results, nil
 }
 
 // GetProjectedCostWithErrors calculates projected costs with comprehensive error tracking.
+func (e *Engine) GetProjectedCostWithErrors(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+) (*CostResultWithErrors, error) {
+	result, err := e.projectCosts(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// projectCosts is the projected-cost pipeline behind GetProjectedCost and
+// GetProjectedCostWithErrors. Plugins failing validation are skipped, then each resource is
+// priced by the plugins, a Kubernetes estimate or its spec, in that order. If ctx ends
+// early it returns the results of the resources already priced along with ctx's error.
 //
 //nolint:funlen,gocognit // Parallel implementation requires worker setup
-func (e *Engine) GetProjectedCostWithErrors(
+func (e *Engine) projectCosts(
 	ctx context.Context,
 	resources []ResourceDescriptor,
 ) (*CostResultWithErrors, error) {
@@ -326,8 +229,11 @@ func (e *Engine) GetProjectedCostWithErrors(
 
 	numWorkers := e.getWorkerCount(len(resources))
 	if numWorkers == 0 {
-		return &CostResultWithErrors{}, nil
+		return &CostResultWithErrors{Results: []CostResult{}}, nil
 	}
+	ctx = withPriceMemo(ctx)
+	pluginErrors := e.excludeUnreadyPlugins(ctx)
+	log := logging.FromContext(ctx)
 
 	jobs := make(chan job, len(resources))
 	resultsChan := make(chan workerResult, len(resources))
@@ -340,22 +246,43 @@ func (e *Engine) GetProjectedCostWithErrors(
 				return
 			}
 
-			resource := j.resource
+			if e.zeroCostTypes.Match(j.resource.Type) {
+				zeroCost := []CostResult{ZeroCostResult(j.resource.Type, j.resource.ID)}
+				e.finalizeResults(zeroCost, j.resource)
+				resultsChan <- workerResult{index: j.index, results: zeroCost}
+				continue
+			}
+
+			rule := e.costRules.match(j.resource)
+			hours := e.hoursPerMonth(nil)
+			resource, customResult := e.customTypes.resolve(j.resource, hours)
+			if ruleResult := rule.resolve(j.resource, hours); ruleResult != nil {
+				resource, customResult = j.resource, ruleResult
+			}
+			priced := resource
+			group, isGroup := detectScalingGroup(resource)
+			if isGroup {
+				resource = group.unit
+			}
 			var resourceResults []CostResult
 			var resourceErrors []ErrorDetail
+			clients := e.clients
+			if customResult != nil {
+				resourceResults = append(resourceResults, *customResult)
+				clients = nil
+			}
 
-			// Try each plugin client
-			for _, client := range e.clients {
-				pluginResult, err := e.getProjectedCostFromPlugin(ctx, client, resource)
+			// Apply per-resource timeout for plugin calls
+			for _, call := range e.queryProjectedPlugins(ctx, clients, resource, e.perResourceTimeout()) {
+				client, pluginResult, err := call.client, call.result, call.err
 				if err != nil {
-					// Log error with structured fields using context-based logger
-					log := logging.FromContext(ctx)
 					log.Warn().
 						Ctx(ctx).
 						Str("component", "engine").
 						Str("resource_type", resource.Type).
 						Str("resource_id", resource.ID).
 						Str("plugin", client.Name).
+						Int("attempts", pluginCallAttempts(err)).
 						Err(err).
 						Msg("plugin call failed for projected cost")
 
@@ -370,35 +297,80 @@ func (e *Engine) GetProjectedCostWithErrors(
 					continue
 				}
 				if pluginResult != nil {
-					engineResult := *pluginResult
-					resourceResults = append(resourceResults, engineResult)
+					log.Debug().
+						Ctx(ctx).
+						Str("component", "engine").
+						Str("resource_type", resource.Type).
+						Str("plugin", client.Name).
+						Float64("monthly_cost", pluginResult.Monthly).
+						Msg("plugin returned cost data")
+					resourceResults = append(resourceResults, *pluginResult)
 				}
 			}
 
-			// If no results from plugins, try spec fallback
+			resourceResults = e.layerPluginResults(resourceResults)
+
+			// Kubernetes workloads without plugin pricing are estimated from their requests
 			if len(resourceResults) == 0 {
-				fallbackUsed := false
-				if e.loader != nil {
-					if specRes := e.getProjectedCostFromSpec(ctx, resource); specRes != nil {
-						resourceResults = append(resourceResults, *specRes)
-						fallbackUsed = true
-					}
+				if k8sRes := estimateKubernetesWorkloadCost(resource, hours); k8sRes != nil {
+					resourceResults = append(resourceResults, *k8sRes)
 				}
+			}
 
-				if !fallbackUsed {
-					// Final fallback: no cost data available
-					resourceResults = append(resourceResults, CostResult{
-						ResourceType: resource.Type,
-						ResourceID:   resource.ID,
-						Adapter:      "none",
-						Currency:     defaultCurrency,
-						Monthly:      0,
-						Hourly:       0,
-						Notes:        "No pricing information available",
-					})
+			// If no results from plugins, try spec fallback
+			if len(resourceResults) == 0 && e.loader != nil {
+				log.Debug().
+					Ctx(ctx).
+					Str("component", "engine").
+					Str("resource_type", resource.Type).
+					Str("resource_id", resource.ID).
+					Msg("no plugin data, trying spec fallback")
+
+				if specRes := e.getProjectedCostFromSpec(ctx, resource); specRes != nil {
+					log.Debug().
+						Ctx(ctx).
+						Str("component", "engine").
+						Str("resource_type", resource.Type).
+						Float64("monthly_cost", specRes.Monthly).
+						Msg("spec fallback provided cost data")
+					resourceResults = append(resourceResults, *specRes)
 				}
 			}
 
+			if len(resourceResults) == 0 {
+				// Final fallback: no cost data available
+				log.Warn().
+					Ctx(ctx).
+					Str("component", "engine").
+					Str("resource_type", resource.Type).
+					Str("resource_id", resource.ID).
+					Msg("no pricing data available from plugins or specs")
+
+				resourceResults = append(resourceResults, CostResult{
+					ResourceType: resource.Type,
+					ResourceID:   resource.ID,
+					Adapter:      "none",
+					Currency:     defaultCurrency,
+					Monthly:      0,
+					Hourly:       0,
+					Notes:        "No pricing information available",
+				})
+			}
+
+			if isGroup {
+				group.apply(resourceResults)
+			}
+			labelCustomTypeResults(resourceResults, j.resource, priced)
+			rule.apply(resourceResults)
+			e.applyCommitmentCoverage(resourceResults, j.resource)
+			e.applyDataTransfer(ctx, resourceResults, j.resource)
+			e.applyDefaultResourceNotes(resourceResults, j.resource)
+			normalizeResults(resourceResults, j.resource.Properties)
+			e.applyCostHistory(resourceResults)
+			scoreResults(resourceResults, resource)
+			allocateResults(resourceResults, j.resource.AllocationTags)
+			identifyResults(resourceResults, j.resource)
+			e.finalizeResults(resourceResults, j.resource)
 			resultsChan <- workerResult{
 				index:   j.index,
 				results: resourceResults,
@@ -427,24 +399,26 @@ func (e *Engine) GetProjectedCostWithErrors(
 		collectedResults = append(collectedResults, res)
 	}
 
-	if ctx.Err() != nil {
-		return nil, ctx.Err()
-	}
-
+	// Sort by index to preserve order
 	sort.Slice(collectedResults, func(i, j int) bool {
 		return collectedResults[i].index < collectedResults[j].index
 	})
 
 	finalResult := &CostResultWithErrors{
 		Results: []CostResult{},
-		Errors:  []ErrorDetail{},
+		Errors:  append([]ErrorDetail{}, pluginErrors...),
 	}
-
 	for _, cr := range collectedResults {
 		finalResult.Results = append(finalResult.Results, cr.results...)
 		finalResult.Errors = append(finalResult.Errors, cr.errors...)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return finalResult, err
+	}
+	if verifyErr := e.verifySummary(finalResult.Results); verifyErr != nil {
+		return nil, verifyErr
+	}
 	return finalResult, nil
 }
 
@@ -462,8 +436,8 @@ func (e *Engine) GetActualCost(
 }
 
 // GetActualCostWithOptions retrieves actual costs with advanced filtering, grouping, and time range options.
-//
-//nolint:funlen,gocognit // Comprehensive logging and cost calculation pipeline requires additional complexity.
+// Plugin failures are logged and the resource falls back to state-based and spec
+// estimates; use GetActualCostWithOptionsAndErrors to also report them.
 func (e *Engine) GetActualCostWithOptions(
 	ctx context.Context,
 	request ActualCostRequest,
@@ -471,12 +445,9 @@ func (e *Engine) GetActualCostWithOptions(
 	log := logging.FromContext(ctx)
 	start := time.Now()
 
-	// Apply overall query timeout if not already set
-	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, defaultQueryTimeout)
-		defer cancel()
-	}
+	// Apply overall query timeout (scaled by resource count) if not already set
+	ctx, cancel := withQueryTimeout(ctx, len(request.Resources))
+	defer cancel()
 
 	log.Debug().
 		Ctx(ctx).
@@ -495,191 +466,25 @@ func (e *Engine) GetActualCostWithOptions(
 		}
 	}
 
-	type job struct {
-		index    int
-		resource ResourceDescriptor
-	}
-
-	type workerResult struct {
-		index        int
-		result       *CostResult
-		partialError error
-	}
-
-	numWorkers := e.getWorkerCount(len(request.Resources))
-	if numWorkers == 0 {
-		return []CostResult{}, nil
-	}
-
-	jobs := make(chan job, len(request.Resources))
-	resultsChan := make(chan workerResult, len(request.Resources))
-	var wg sync.WaitGroup
-
-	worker := func() {
-		defer wg.Done()
-		for j := range jobs {
-			if ctx.Err() != nil {
-				return
-			}
-
-			resource := j.resource
-
-			// Filter by tags if specified
-			if len(request.Tags) > 0 && !MatchesTags(resource, request.Tags) {
-				log.Debug().
-					Ctx(ctx).
-					Str("component", "engine").
-					Str("resource_type", resource.Type).
-					Str("resource_id", resource.ID).
-					Msg("resource filtered out by tags")
-				resultsChan <- workerResult{index: j.index, result: nil}
-				continue
-			}
-
-			var resourceResult *CostResult
-			var partialErr error
-
-			for _, client := range e.clients {
-				if request.Adapter != "" && client.Name != request.Adapter {
-					continue
-				}
-
-				log.Debug().
-					Ctx(ctx).
-					Str("component", "engine").
-					Str("resource_type", resource.Type).
-					Str("resource_id", resource.ID).
-					Str("plugin", client.Name).
-					Msg("querying plugin for actual cost")
-
-				// Apply per-resource timeout for plugin calls
-				resourceCtx, resourceCancel := context.WithTimeout(ctx, perResourceTimeout)
-				result, err := e.getActualCostFromPlugin(
-					resourceCtx,
-					client,
-					resource,
-					request.From,
-					request.To,
-				)
-				resourceCancel()
-				if err != nil {
-					log.Debug().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("resource_type", resource.Type).
-						Str("plugin", client.Name).
-						Err(err).
-						Msg("plugin did not return actual cost data")
-					newErr := fmt.Errorf("plugin %s: %w", client.Name, err)
-					if partialErr == nil {
-						partialErr = newErr
-					} else {
-						partialErr = errors.Join(partialErr, newErr)
-					}
-					continue
-				}
-				if result != nil {
-					log.Debug().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("resource_type", resource.Type).
-						Str("plugin", client.Name).
-						Float64("total_cost", result.TotalCost).
-						Msg("plugin returned actual cost data")
-					resourceResult = result
-					break
-				}
-			}
-
-			// If no plugin provided data, create a placeholder result
-			if resourceResult == nil {
-				log.Warn().
-					Ctx(ctx).
-					Str("component", "engine").
-					Str("resource_type", resource.Type).
-					Str("resource_id", resource.ID).
-					Msg("no actual cost data available from plugins")
-
-				resourceResult = &CostResult{
-					ResourceType: resource.Type,
-					ResourceID:   resource.ID,
-					Adapter:      "none",
-					Currency:     defaultCurrency,
-					TotalCost:    0,
-					Notes:        "No actual cost data available",
-					StartDate:    request.From,
-					EndDate:      request.To,
-					CostPeriod:   FormatPeriod(request.From, request.To),
-				}
-			}
-
-			resultsChan <- workerResult{index: j.index, result: resourceResult, partialError: partialErr}
-		}
-	}
-
-	for range numWorkers {
-		wg.Add(1)
-		go worker()
-	}
-
-	for i, resource := range request.Resources {
-		jobs <- job{index: i, resource: resource}
-	}
-	close(jobs)
-
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-
-	var collectedResults []workerResult
-	var partialErrors []error
-
-	for res := range resultsChan {
-		collectedResults = append(collectedResults, res)
-		if res.partialError != nil {
-			partialErrors = append(partialErrors, res.partialError)
-		}
-	}
-
+	result, err := e.actualCosts(ctx, request)
 	if ctx.Err() != nil {
 		log.Warn().
 			Ctx(ctx).
 			Str("component", "engine").
-			Int("processed", len(collectedResults)).
 			Int("total", len(request.Resources)).
-			Msg("query timeout reached, returning partial results")
+			Msg("query timeout reached, returning no results")
 		return nil, fmt.Errorf("actual cost calculation cancelled: %w", ctx.Err())
 	}
-
-	sort.Slice(collectedResults, func(i, j int) bool {
-		return collectedResults[i].index < collectedResults[j].index
-	})
-
-	var results []CostResult
-	for _, cr := range collectedResults {
-		if cr.result != nil {
-			results = append(results, *cr.result)
-		}
-	}
-
-	// Group results if requested
-	if request.GroupBy != "" {
-		log.Debug().
-			Ctx(ctx).
-			Str("component", "engine").
-			Str("group_by", request.GroupBy).
-			Int("pre_group_count", len(results)).
-			Msg("grouping results")
-		results = e.GroupResults(results, GroupBy(request.GroupBy))
+	if err != nil {
+		return nil, err
 	}
 
 	// Log partial errors
-	if len(partialErrors) > 0 {
+	if len(result.Errors) > 0 {
 		log.Warn().
 			Ctx(ctx).
 			Str("component", "engine").
-			Int("error_count", len(partialErrors)).
+			Int("error_count", len(result.Errors)).
 			Msg("some plugins returned errors during actual cost calculation")
 	}
 
@@ -687,11 +492,11 @@ func (e *Engine) GetActualCostWithOptions(
 		Ctx(ctx).
 		Str("component", "engine").
 		Str("operation", "get_actual_cost").
-		Int("result_count", len(results)).
+		Int("result_count", len(result.Results)).
 		Dur("duration_ms", time.Since(start)).
 		Msg("actual cost calculation complete")
 
-	return results, nil
+	return result.Results, nil
 }
 
 // GetActualCostWithOptionsAndErrors retrieves actual costs with comprehensive error tracking.
@@ -699,6 +504,23 @@ func (e *Engine) GetActualCostWithOptions(
 func (e *Engine) GetActualCostWithOptionsAndErrors(
 	ctx context.Context,
 	request ActualCostRequest,
+) (*CostResultWithErrors, error) {
+	result, err := e.actualCosts(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// actualCosts is the actual-cost pipeline behind GetActualCostWithOptions and
+// GetActualCostWithOptionsAndErrors. Plugins failing validation are skipped, then each
+// resource matching the request's tags is costed by getActualCostForResource and the
+// results are grouped as requested. If ctx ends early it returns ctx's error.
+//
+//nolint:funlen // Parallel implementation requires worker setup
+func (e *Engine) actualCosts(
+	ctx context.Context,
+	request ActualCostRequest,
 ) (*CostResultWithErrors, error) {
 	type job struct {
 		index    int
@@ -711,10 +533,18 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 		errors []ErrorDetail
 	}
 
-	numWorkers := e.getWorkerCount(len(request.Resources))
+	if err := validateActualGrouping(request); err != nil {
+		return nil, err
+	}
+
+	numWorkers := e.getActualCostWorkerCount(len(request.Resources))
 	if numWorkers == 0 {
-		return &CostResultWithErrors{}, nil
+		return &CostResultWithErrors{Results: []CostResult{}}, nil
 	}
+	ctx = withPriceMemo(ctx)
+	pluginErrors := e.excludeUnreadyPlugins(ctx)
+	limiter := newRateLimiter(e.options.ActualCostRateLimit)
+	log := logging.FromContext(ctx)
 
 	jobs := make(chan job, len(request.Resources))
 	resultsChan := make(chan workerResult, len(request.Resources))
@@ -731,12 +561,20 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 
 			// Filter by tags if specified
 			if len(request.Tags) > 0 && !MatchesTags(resource, request.Tags) {
+				log.Debug().
+					Ctx(ctx).
+					Str("component", "engine").
+					Str("resource_type", resource.Type).
+					Str("resource_id", resource.ID).
+					Msg("resource filtered out by tags")
 				resultsChan <- workerResult{index: j.index, result: nil, errors: nil}
 				continue
 			}
 
-			resourceResult, errors := e.getActualCostForResource(ctx, resource, request)
-			resultsChan <- workerResult{index: j.index, result: &resourceResult, errors: errors}
+			resourceResult, errors := e.getActualCostForResource(ctx, resource, request, limiter)
+			finalized := []CostResult{resourceResult}
+			e.finalizeResults(finalized, resource)
+			resultsChan <- workerResult{index: j.index, result: &finalized[0], errors: errors}
 		}
 	}
 
@@ -770,7 +608,7 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 
 	result := &CostResultWithErrors{
 		Results: []CostResult{},
-		Errors:  []ErrorDetail{},
+		Errors:  append([]ErrorDetail{}, pluginErrors...),
 	}
 
 	for _, cr := range collectedResults {
@@ -782,18 +620,39 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 
 	// Group results if requested
 	if request.GroupBy != "" {
-		result.Results = e.GroupResults(result.Results, GroupBy(request.GroupBy))
+		log.Debug().
+			Ctx(ctx).
+			Str("component", "engine").
+			Str("group_by", request.GroupBy).
+			Int("pre_group_count", len(result.Results)).
+			Msg("grouping results")
+		stop := TimingsFromContext(ctx).Track(StageAggregation)
+		grouped, groupErr := e.groupActualResults(result.Results, request)
+		stop()
+		if groupErr != nil {
+			return nil, groupErr
+		}
+		result.Results = grouped
+	} else if verifyErr := e.verifySummary(result.Results); verifyErr != nil {
+		return nil, verifyErr
 	}
 
 	return result, nil
 }
 
 // getActualCostForResource processes a single resource for actual cost with error tracking.
+// The first plugin to return a cost wins; without one, the cost is estimated from the
+// resource's state and then from its pricing spec. Each plugin call waits on limiter
+// first, a nil limiter imposing no rate limit, and is bounded by the per-resource timeout.
+//
+//nolint:funlen // Plugin loop, fallbacks and placeholder in one place.
 func (e *Engine) getActualCostForResource(
 	ctx context.Context,
 	resource ResourceDescriptor,
 	request ActualCostRequest,
+	limiter *rateLimiter,
 ) (CostResult, []ErrorDetail) {
+	log := logging.FromContext(ctx)
 	var errors []ErrorDetail
 	var resourceResult *CostResult
 
@@ -802,22 +661,37 @@ func (e *Engine) getActualCostForResource(
 			continue
 		}
 
+		log.Debug().
+			Ctx(ctx).
+			Str("component", "engine").
+			Str("resource_type", resource.Type).
+			Str("resource_id", resource.ID).
+			Str("plugin", client.Name).
+			Msg("querying plugin for actual cost")
+
+		// Respect the billing API rate limit shared by all workers
+		if waitErr := limiter.Wait(ctx); waitErr != nil {
+			break
+		}
+
+		// Apply per-resource timeout for plugin calls
+		resourceCtx, resourceCancel := context.WithTimeout(ctx, e.perResourceTimeout())
 		costResult, err := e.getActualCostFromPlugin(
-			ctx,
+			resourceCtx,
 			client,
 			resource,
 			request.From,
 			request.To,
 		)
+		resourceCancel()
 		if err != nil {
-			// Log error with structured fields using context-based logger
-			log := logging.FromContext(ctx)
 			log.Warn().
 				Ctx(ctx).
 				Str("component", "engine").
 				Str("resource_type", resource.Type).
 				Str("resource_id", resource.ID).
 				Str("plugin", client.Name).
+				Int("attempts", pluginCallAttempts(err)).
 				Err(err).
 				Msg("plugin call failed for actual cost")
 
@@ -831,6 +705,13 @@ func (e *Engine) getActualCostForResource(
 			continue
 		}
 		if costResult != nil {
+			log.Debug().
+				Ctx(ctx).
+				Str("component", "engine").
+				Str("resource_type", resource.Type).
+				Str("plugin", client.Name).
+				Float64("total_cost", costResult.TotalCost).
+				Msg("plugin returned actual cost data")
 			engineResult := *costResult
 			// Only allocate sustainability map if source has values
 			if len(costResult.Sustainability) > 0 {
@@ -856,10 +737,22 @@ func (e *Engine) getActualCostForResource(
 		return *stateResult, errors
 	}
 
+	// Then estimate from the local pricing spec
+	if specResult := e.tryActualCostFromSpec(ctx, resource, request); specResult != nil {
+		return *specResult, errors
+	}
+
+	log.Warn().
+		Ctx(ctx).
+		Str("component", "engine").
+		Str("resource_type", resource.Type).
+		Str("resource_id", resource.ID).
+		Msg("no actual cost data available from plugins")
+
 	// Create placeholder result
 	notes := "No actual cost data available"
 	if len(errors) > 0 {
-		notes = "ERROR: plugin call failed"
+		notes += fmt.Sprintf(" (%d plugin call(s) failed)", len(errors))
 	}
 
 	return CostResult{
@@ -961,29 +854,79 @@ func (e *Engine) getHourlyRateForResource(
 	return 0, errors.New("no plugin returned projected cost")
 }
 
+// getProjectedCostFromPlugin prices resource with client, reusing the price of an
+// identically configured resource already priced by client in the same run.
 func (e *Engine) getProjectedCostFromPlugin(
 	ctx context.Context,
 	client *pluginhost.Client,
 	resource ResourceDescriptor,
 ) (*CostResult, error) {
+	memo := e.priceMemo(ctx)
+	if memo == nil {
+		return e.callProjectedCostPlugin(ctx, client, resource)
+	}
+	return memo.price(ctx, pricingFingerprint(client, resource), resource, func() (*CostResult, error) {
+		return e.callProjectedCostPlugin(ctx, client, resource)
+	})
+}
+
+func (e *Engine) callProjectedCostPlugin(
+	ctx context.Context,
+	client *pluginhost.Client,
+	resource ResourceDescriptor,
+) (*CostResult, error) {
+	defer TimingsFromContext(ctx).TrackPlugin(client.Name)()
+
+	var (
+		cacheKey string
+		cached   pricingCacheEntry
+		isCached bool
+	)
+	if e.pricingCache != nil {
+		var fresh bool
+		cacheKey = pricingFingerprint(client, resource)
+		cached, fresh, isCached = e.pricingCache.lookup(client.Name, cacheKey)
+		if fresh {
+			return e.withPluginProvenance(cachedResult(cached, resource), client, resource,
+				cached.ETag, cached.PricingDate), nil
+		}
+	}
+
 	// Try to get pricing from plugin first
-	req := &proto.GetProjectedCostRequest{
-		Resources: []*proto.ResourceDescriptor{
-			{
-				ID:         resource.ID,
-				Type:       resource.Type,
-				Provider:   resource.Provider,
-				Properties: convertToProto(resource.Properties),
-			},
-		},
+	descriptor := &proto.ResourceDescriptor{
+		ID:         resource.ID,
+		Type:       resource.Type,
+		Provider:   resource.Provider,
+		Properties: convertToProto(resource.Properties),
 	}
+	if isCached {
+		descriptor.IfNoneMatch = cached.ETag
+	}
+	req := &proto.GetProjectedCostRequest{Resources: []*proto.ResourceDescriptor{descriptor}}
 
 	// Note: Utilization from ctx (ContextKeyUtilization) is available for future use
 	// when adapter supports passing it via gRPC metadata.
 
-	resp, err := client.API.GetProjectedCost(ctx, req)
-	if err == nil && len(resp.Results) > 0 {
+	resp, err := retryPlugin(ctx, e, client, func() (*proto.GetProjectedCostResponse, error) {
+		release := e.acquirePluginSlot(ctx)
+		defer release()
+		return client.API.GetProjectedCost(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) > 0 {
 		result := resp.Results[0]
+		if result.NotModified && isCached {
+			ttl := result.CacheTTL
+			if ttl == 0 {
+				ttl = cached.TTL
+			}
+			e.storePricingCache(ctx, cacheKey,
+				pricingCacheMeta{etag: result.ETag, pricingDate: cached.PricingDate, ttl: ttl}, cached.Result)
+			return e.withPluginProvenance(cachedResult(cached, resource), client, resource,
+				result.ETag, cached.PricingDate), nil
+		}
 		engineResult := &CostResult{
 			ResourceType:   resource.Type,
 			ResourceID:     resource.ID,
@@ -1002,48 +945,84 @@ func (e *Engine) getProjectedCostFromPlugin(
 				Unit:  v.Unit,
 			}
 		}
-		return engineResult, nil
+		if e.pricingCache != nil && result.ETag != "" {
+			e.storePricingCache(ctx, cacheKey,
+				pricingCacheMeta{etag: result.ETag, pricingDate: result.PricingDate, ttl: result.CacheTTL}, *engineResult)
+		}
+		return e.withPluginProvenance(engineResult, client, resource, result.ETag, result.PricingDate), nil
 	}
 
 	return nil, ErrNoCostData
 }
 
+// storePricingCache saves a plugin price, logging rather than failing when the cache
+// cannot be written.
+func (e *Engine) storePricingCache(ctx context.Context, key string, meta pricingCacheMeta, result CostResult) {
+	if err := e.pricingCache.store(key, meta, result); err != nil {
+		logging.FromContext(ctx).Warn().Ctx(ctx).Str("component", "engine").
+			Err(err).Msg("failed to update pricing cache")
+	}
+}
+
+// withPluginProvenance records where a plugin price came from when provenance is enabled.
+func (e *Engine) withPluginProvenance(
+	result *CostResult,
+	client *pluginhost.Client,
+	resource ResourceDescriptor,
+	etag, pricingDate string,
+) *CostResult {
+	if e.options.PricingProvenance {
+		result.Provenance = pluginProvenance(client, resource, etag, pricingDate)
+	}
+	return result
+}
+
 func (e *Engine) getProjectedCostFromSpec(
 	ctx context.Context,
 	resource ResourceDescriptor,
 ) *CostResult {
 	service := extractService(resource.Type)
-	sku := extractSKU(resource)
 
-	spec := e.loadSpecWithFallback(ctx, resource.Provider, service, sku)
+	spec := e.loadSpecWithFallback(ctx, resource.Provider, service, extractSKU(resource), fallbackSKUs(resource)...)
 	if spec == nil {
 		return nil
 	}
 
-	monthly, hourly := calculateCostsFromSpec(spec, resource)
-	return e.createSpecBasedResult(resource, spec, monthly, hourly)
+	hours := e.hoursPerMonth(spec)
+	monthly, hourly := calculateCostsFromSpec(spec, resource, hours)
+	monthly, hourly, scheduleNote := applyRunSchedule(spec.Pricing, resource, monthly, hourly, hours)
+	result := e.createSpecBasedResult(resource, spec, monthly, hourly)
+	if scheduleNote != "" {
+		result.Notes += "; " + scheduleNote
+	}
+	applyAmortizedUpfront(result, spec.Pricing, hours)
+	return result
 }
 
 func (e *Engine) loadSpecWithFallback(
 	ctx context.Context,
 	provider, service, sku string,
+	fallbackSKUs ...string,
 ) *PricingSpec {
-	// Try provider-service-sku pattern first
-	if sku != "" {
-		if spec := e.tryLoadSpec(ctx, provider, service, sku); spec != nil {
+	// Try provider-service-sku pattern first, then any less specific SKUs in order
+	for _, candidate := range append([]string{sku}, fallbackSKUs...) {
+		if candidate == "" {
+			continue
+		}
+		if spec := e.loadSpec(ctx, provider, service, candidate); spec != nil {
 			return spec
 		}
 	}
 
 	// Fallback to provider-service-default pattern
-	if spec := e.tryLoadSpec(ctx, provider, service, defaultServiceName); spec != nil {
+	if spec := e.loadSpec(ctx, provider, service, defaultServiceName); spec != nil {
 		return spec
 	}
 
 	// Last resort: try common resource patterns
 	commonSKUs := []string{"standard", "basic"}
 	for _, commonSKU := range commonSKUs {
-		if spec := e.tryLoadSpec(ctx, provider, service, commonSKU); spec != nil {
+		if spec := e.loadSpec(ctx, provider, service, commonSKU); spec != nil {
 			return spec
 		}
 	}
@@ -1051,11 +1030,23 @@ func (e *Engine) loadSpecWithFallback(
 	return nil
 }
 
-func (e *Engine) tryLoadSpec(ctx context.Context, provider, service, sku string) *PricingSpec {
+// loadSpec asks the loader for a spec, returning nil when it is missing or unusable. The
+// fallback ladder repeats lookups for every resource, so callers that price many
+// resources should pass a caching loader such as spec.CachingLoader.
+func (e *Engine) loadSpec(ctx context.Context, provider, service, sku string) *PricingSpec {
+	defer TimingsFromContext(ctx).Track(StageSpecLoad)()
+
 	if loader, ok := e.loader.(interface {
 		LoadSpecWithContext(ctx context.Context, provider, service, sku string) (interface{}, error)
 	}); ok {
 		specData, err := loader.LoadSpecWithContext(ctx, provider, service, sku)
+		if errors.Is(err, errSpecInheritance) {
+			logging.FromContext(ctx).Warn().
+				Ctx(ctx).
+				Str("component", "engine").
+				Err(err).
+				Msg("ignoring pricing spec whose extends chain cannot be resolved")
+		}
 		if err != nil {
 			return nil
 		}
@@ -1080,10 +1071,10 @@ func (e *Engine) createSpecBasedResult(
 	spec *PricingSpec,
 	monthly, hourly float64,
 ) *CostResult {
-	return &CostResult{
+	result := &CostResult{
 		ResourceType: resource.Type,
 		ResourceID:   resource.ID,
-		Adapter:      "local-spec",
+		Adapter:      adapterLocalSpec,
 		Currency:     spec.Currency,
 		Monthly:      monthly,
 		Hourly:       hourly,
@@ -1097,21 +1088,49 @@ func (e *Engine) createSpecBasedResult(
 			"base_cost": monthly,
 		},
 	}
+	if e.options.PricingProvenance {
+		result.Provenance = specProvenance(spec, resource)
+	}
+	return result
 }
 
+// getActualCostFromPlugin fetches the actual cost of resource from client, reusing the
+// response for the same resource and time range already fetched in the same run.
 func (e *Engine) getActualCostFromPlugin(
 	ctx context.Context,
 	client *pluginhost.Client,
 	resource ResourceDescriptor,
 	from, to time.Time,
 ) (*CostResult, error) {
+	memo := e.priceMemo(ctx)
+	if memo == nil {
+		return e.callActualCostPlugin(ctx, client, resource, from, to)
+	}
+	return memo.price(ctx, actualCostFingerprint(client, resource, from, to), resource,
+		func() (*CostResult, error) {
+			return e.callActualCostPlugin(ctx, client, resource, from, to)
+		})
+}
+
+func (e *Engine) callActualCostPlugin(
+	ctx context.Context,
+	client *pluginhost.Client,
+	resource ResourceDescriptor,
+	from, to time.Time,
+) (*CostResult, error) {
+	defer TimingsFromContext(ctx).TrackPlugin(client.Name)()
+
 	req := &proto.GetActualCostRequest{
 		ResourceIDs: []string{resource.ID},
 		StartTime:   from.Unix(),
 		EndTime:     to.Unix(),
 	}
 
-	resp, err := client.API.GetActualCost(ctx, req)
+	resp, err := retryPlugin(ctx, e, client, func() (*proto.GetActualCostResponse, error) {
+		release := e.acquirePluginSlot(ctx)
+		defer release()
+		return client.API.GetActualCost(ctx, req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1141,7 +1160,7 @@ func (e *Engine) getActualCostFromPlugin(
 		monthlyRate = result.TotalCost * avgDaysPerMonth / float64(totalDays)
 	} else if totalHours > 0 {
 		// If less than a day, project based on hourly rate
-		monthlyRate = (result.TotalCost / totalHours) * hoursPerMonth
+		monthlyRate = (result.TotalCost / totalHours) * e.hoursPerMonth(nil)
 	}
 
 	if totalHours > 0 {
@@ -1191,7 +1210,28 @@ func extractService(resourceType string) string {
 	return defaultServiceName
 }
 
+// SpecKey returns the provider, service and SKU of the spec the engine looks a resource's
+// pricing up by first, i.e. the provider-service-sku name of its spec file.
+func SpecKey(resource ResourceDescriptor) (string, string, string) {
+	provider := resource.Provider
+	if provider == "" {
+		provider = extractProviderFromType(resource.Type)
+	}
+	return provider, extractService(resource.Type), extractSKU(resource)
+}
+
+// extractSKU returns the most specific SKU of a resource: its compound SKU when its type
+// has one (see compoundSKUProperties), else its single-attribute SKU.
 func extractSKU(resource ResourceDescriptor) string {
+	if candidates := extractSKUCandidates(resource); len(candidates) > 0 {
+		return candidates[0]
+	}
+	return ""
+}
+
+// extractSingleSKU returns the SKU taken from a single resource property, or from the
+// resource type when no SKU property is set.
+func extractSingleSKU(resource ResourceDescriptor) string {
 	// Try to extract SKU from resource properties first
 	if sku := extractSKUFromProperties(resource.Properties); sku != "" {
 		return sku
@@ -1232,47 +1272,56 @@ func getStringProperty(properties map[string]interface{}, key string) (string, b
 	return "", false
 }
 
-func calculateCostsFromSpec(spec *PricingSpec, resource ResourceDescriptor) (float64, float64) {
+// calculateCostsFromSpec derives monthly and hourly costs from spec, converting between
+// them with hours per month. These are the recurring costs; an upfront fee in the spec is
+// amortized on top of them by applyAmortizedUpfront.
+func calculateCostsFromSpec(spec *PricingSpec, resource ResourceDescriptor, hours float64) (float64, float64) {
 	// Try to extract cost information from spec pricing
 	if spec.Pricing != nil {
-		if monthlyRate, hourlyRate, found := tryExtractCostsFromPricing(spec.Pricing, resource); found {
+		if monthlyRate, hourlyRate, found := tryExtractCostsFromPricing(spec.Pricing, resource, hours); found {
 			return monthlyRate, hourlyRate
 		}
 	}
 
+	// An upfront fee with no rate beside it is the whole cost; it is added by the caller.
+	if _, ok := amortizedUpfront(spec.Pricing); ok {
+		return 0, 0
+	}
+
 	// Ultimate fallback - conservative estimate based on resource type
 	monthly := getDefaultMonthlyByType(resource.Type)
-	hourly := monthly / hoursPerMonth
+	hourly := monthly / hours
 	return monthly, hourly
 }
 
 func tryExtractCostsFromPricing(
 	pricing map[string]interface{},
 	resource ResourceDescriptor,
+	hours float64,
 ) (float64, float64, bool) {
 	// Try direct monthly estimate first
-	if monthly, hourly, found := tryMonthlyEstimate(pricing); found {
+	if monthly, hourly, found := tryMonthlyEstimate(pricing, hours); found {
 		return monthly, hourly, true
 	}
 
 	// Try hourly rates
-	if monthly, hourly, found := tryHourlyRates(pricing); found {
+	if monthly, hourly, found := tryHourlyRates(pricing, hours); found {
 		return monthly, hourly, true
 	}
 
 	// Try storage-based pricing
-	if monthly, hourly, found := tryStoragePricing(pricing, resource); found {
+	if monthly, hourly, found := tryStoragePricing(pricing, resource, hours); found {
 		return monthly, hourly, true
 	}
 
 	// Fallback to any numeric value
-	return tryFallbackNumericValue(pricing)
+	return tryFallbackNumericValue(pricing, hours)
 }
 
-func tryMonthlyEstimate(pricing map[string]interface{}) (float64, float64, bool) {
+func tryMonthlyEstimate(pricing map[string]interface{}, hours float64) (float64, float64, bool) {
 	if monthlyFloat, ok := getFloatFromPricing(pricing, "monthlyEstimate"); ok {
 		monthly := monthlyFloat
-		hourly := monthly / hoursPerMonth
+		hourly := monthly / hours
 		return monthly, hourly, true
 	}
 	return 0, 0, false
@@ -1282,20 +1331,22 @@ func tryMonthlyEstimate(pricing map[string]interface{}) (float64, float64, bool)
 // and derives a monthly estimate from it.
 //
 // The function looks for known hourly keys ("onDemandHourly", "hourlyRate") and,
-// if found, returns the computed monthly cost (hourly * hoursPerMonth), the
+// if found, returns the computed monthly cost (hourly * hours), the
 // hourly rate, and true. If no hourly rate is present, it returns 0, 0, false.
 //
 // pricing: a map of pricing fields keyed by string; values are expected to contain
 // numeric float64 entries for hourly rates.
 //
+// hours: the hours in a month, hoursPerMonth unless configured otherwise.
+//
 // Returns the monthly estimate, the hourly rate, and a boolean indicating whether
 // a valid hourly rate was found.
-func tryHourlyRates(pricing map[string]interface{}) (float64, float64, bool) {
+func tryHourlyRates(pricing map[string]interface{}, hours float64) (float64, float64, bool) {
 	hourlyKeys := []string{"onDemandHourly", "hourlyRate"}
 	for _, key := range hourlyKeys {
 		if hourlyFloat, ok := getFloatFromPricing(pricing, key); ok {
 			hourly := hourlyFloat
-			monthly := hourly * hoursPerMonth
+			monthly := hourly * hours
 			return monthly, hourly, true
 		}
 	}
@@ -1306,13 +1357,14 @@ func tryHourlyRates(pricing map[string]interface{}) (float64, float64, bool) {
 // pricing contains a per-GB-per-month value.
 //
 // It returns the computed monthly cost (sizeGB * pricePerGBMonth), the hourly
-// equivalent (monthly divided by hoursPerMonth), and `true` if both a storage size
+// equivalent (monthly divided by hours), and `true` if both a storage size
 // and a `pricePerGBMonth` numeric value were available. If the resource lacks a
 // size or the pricing does not contain `pricePerGBMonth` as a float64, it returns
 // zeros and `false`.
 func tryStoragePricing(
 	pricing map[string]interface{},
 	resource ResourceDescriptor,
+	hours float64,
 ) (float64, float64, bool) {
 	sizeGB, hasSize := getStorageSize(resource)
 	if !hasSize {
@@ -1321,16 +1373,19 @@ func tryStoragePricing(
 
 	if priceFloat, ok := getFloatFromPricing(pricing, "pricePerGBMonth"); ok {
 		monthly := sizeGB * priceFloat
-		hourly := monthly / hoursPerMonth
+		hourly := monthly / hours
 		return monthly, hourly, true
 	}
 	return 0, 0, false
 }
 
-func tryFallbackNumericValue(pricing map[string]interface{}) (float64, float64, bool) {
-	for _, value := range pricing {
+func tryFallbackNumericValue(pricing map[string]interface{}, hours float64) (float64, float64, bool) {
+	for key, value := range pricing {
+		if isAmortizationKey(key) {
+			continue
+		}
 		if floatValue, ok := value.(float64); ok && floatValue > 0 {
-			monthly := floatValue * hoursPerMonth // Assume it's hourly
+			monthly := floatValue * hours // Assume it's hourly
 			hourly := floatValue
 			return monthly, hourly, true
 		}
@@ -1400,24 +1455,20 @@ func parseFloatValue(value interface{}) (float64, bool) {
 	return 0, false
 }
 
-// AggregateResults aggregates a slice of CostResult into an AggregatedResults summary.
+// AggregateResults summarizes results into an AggregatedResults. Its Resources are a
+// copy of results, so the caller's slice is left untouched, with each result's
+// PercentOfTotal set to its share of the total monthly cost.
 //
-// AggregateResults computes total monthly and hourly costs, and builds maps of monthly
-// costs grouped by provider, service, and adapter. The returned AggregatedResults
-// contains the original Resources slice and a CostSummary populated with:
-//   - Currency: taken from the first result (or defaultCurrency when input is empty).
-//   - TotalMonthly and TotalHourly: summed across all results.
-//   - ByProvider, ByService, ByAdapter: maps of monthly totals keyed by provider, service,
-//     and adapter respectively.
+// For results priced in one currency, the summary holds that currency, the monthly and
+// hourly totals, monthly totals by provider, service and adapter, and the min, max, mean
+// and median monthly cost of the priced results. Results in more than one currency are
+// never added together: ByCurrency holds a subtotal per currency instead, Currency is
+// empty, the combined totals, breakdowns and statistics stay zero, and each percentage
+// is of its own currency's total.
 //
-// If the input slice is empty, AggregateResults returns an AggregatedResults with an
-// AggregateResults aggregates a slice of CostResult into an AggregatedResults summary.
-//
-// If results is empty, it returns an AggregatedResults with zero totals, empty maps,
-// an empty Resources slice, and Currency set to defaultCurrency. For a non-empty
-// input, totals (TotalMonthly, TotalHourly) are summed across results, ByProvider,
-// ByService, and ByAdapter maps accumulate monthly totals, Currency is taken from the
-// first result, and Resources contains the original input slice.
+// SupportedCount, UnsupportedCount and UnsupportedTypes count the priced and placeholder
+// results whatever the currencies; internal Pulumi resources count as neither. An empty
+// input yields zero totals, empty maps and defaultCurrency.
 func AggregateResults(results []CostResult) *AggregatedResults {
 	if len(results) == 0 {
 		return &AggregatedResults{
@@ -1432,6 +1483,8 @@ func AggregateResults(results []CostResult) *AggregatedResults {
 		}
 	}
 
+	// Copy so that setting PercentOfTotal leaves the caller's results untouched.
+	results = slices.Clone(results)
 	summary := CostSummary{
 		Currency:   results[0].Currency, // Use currency from first result
 		ByProvider: make(map[string]float64),
@@ -1439,6 +1492,18 @@ func AggregateResults(results []CostResult) *AggregatedResults {
 		ByAdapter:  make(map[string]float64),
 		Resources:  results,
 	}
+	summary.SupportedCount, summary.UnsupportedCount, summary.UnsupportedTypes = resourceCoverage(results)
+
+	// Mixed currencies are summarized per currency rather than summed into one total.
+	if byCurrency := summarizeByCurrency(results); byCurrency != nil {
+		summary.Currency = ""
+		summary.ByCurrency = byCurrency
+		for i := range results {
+			subtotal := byCurrency[resultCurrency(results[i])].TotalMonthly
+			results[i].PercentOfTotal = percentOfTotal(results[i].Monthly, subtotal)
+		}
+		return &AggregatedResults{Summary: summary, Resources: results}
+	}
 
 	for _, result := range results {
 		// Aggregate totals
@@ -1456,6 +1521,10 @@ func AggregateResults(results []CostResult) *AggregatedResults {
 		// Aggregate by adapter
 		summary.ByAdapter[result.Adapter] += result.Monthly
 	}
+	summary.MinMonthly, summary.MaxMonthly, summary.MeanMonthly, summary.MedianMonthly = monthlyCostStats(results)
+	for i := range results {
+		results[i].PercentOfTotal = percentOfTotal(results[i].Monthly, summary.TotalMonthly)
+	}
 
 	return &AggregatedResults{
 		Summary:   summary,
@@ -1472,47 +1541,9 @@ func extractProviderFromType(resourceType string) string {
 	return "unknown"
 }
 
-// FilterResources selects resources that match the provided filter expression.
-// The filter is a single key=value expression (for example "provider=aws" or "tag:env=prod").
-// An empty filter returns the input slice unchanged.
-// The returned slice contains only the resources that satisfy the filter.
-func FilterResources(resources []ResourceDescriptor, filter string) []ResourceDescriptor {
-	if filter == "" {
-		return resources
-	}
-
-	var filtered []ResourceDescriptor
-	for _, resource := range resources {
-		if matchesFilter(resource, filter) {
-			filtered = append(filtered, resource)
-		}
-	}
-	return filtered
-}
-
-// ValidateFilter validates the syntax of a filter expression.
-// It returns an error if the filter is malformed: missing '=' separator,
-// or if either the key or value is empty (for example: `type=aws:ec2/instance` or `tag:env=prod`).
-func ValidateFilter(filter string) error {
-	if filter == "" {
-		return nil
-	}
-	parts := strings.SplitN(filter, "=", filterKeyValueParts)
-	if len(parts) != filterKeyValueParts {
-		return errors.New(
-			"invalid filter syntax: expected 'key=value' (e.g., 'type=aws:ec2/instance' or 'tag:env=prod')")
-	}
-	if strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
-		return errors.New("filter key and value must be non-empty")
-	}
-	return nil
-}
-
-// matchesFilter reports whether the given resource satisfies the simple filter expression.
-// The filter must be in the form "key=value". If the filter is empty or malformed, the
-// function returns true (resource is included).
+// matchesFilter reports whether the given resource has a value under key that satisfies
+// match.
 //
-// Matching is case-insensitive and uses substring matching (contains).
 // Supported builtin keys:
 //   - "type": matches against ResourceDescriptor.Type
 //   - "provider": matches the provider extracted from the type
@@ -1520,42 +1551,30 @@ func ValidateFilter(filter string) error {
 //   - "id": matches ResourceDescriptor.ID
 //
 // Any other key is evaluated against the resource's properties via matchesProperties.
-func matchesFilter(resource ResourceDescriptor, filter string) bool {
-	// Parse filter format like "type=aws:ec2/instance" or "provider=aws"
-	parts := strings.SplitN(filter, "=", filterKeyValueParts)
-	if len(parts) != filterKeyValueParts {
-		return true // Invalid filter, include all
-	}
-
-	key := strings.ToLower(strings.TrimSpace(parts[0]))
-	value := strings.ToLower(strings.TrimSpace(parts[1]))
-
+func matchesFilter(resource ResourceDescriptor, key string, match func(string) bool) bool {
 	switch key {
 	case "type":
-		return strings.Contains(strings.ToLower(resource.Type), value)
+		return match(resource.Type)
 	case "provider":
-		provider := extractProviderFromType(resource.Type)
-		return strings.Contains(strings.ToLower(provider), value)
+		return match(extractProviderFromType(resource.Type))
 	case "service":
-		service := extractService(resource.Type)
-		return strings.Contains(strings.ToLower(service), value)
+		return match(extractService(resource.Type))
 	case "id":
-		return strings.Contains(strings.ToLower(resource.ID), value)
+		return match(resource.ID)
 	default:
-		return matchesProperties(resource, key, value)
+		return matchesProperties(resource, key, match)
 	}
 }
 
-// matchesProperties reports whether the properties of resource match the provided key and value.
-// It performs case-insensitive comparisons. If key has the prefix "tag:", the prefix is removed
-// and the function searches within "tags" or "labels" maps for a matching entry. The value is
-// matched as a case-insensitive substring of the property's string representation or of a map
-// entry's string value.
+// matchesProperties reports whether the properties of resource match the provided key.
+// Keys are compared case-insensitively. If key has the prefix "tag:", the prefix is removed
+// and the function searches within "tags" or "labels" maps for a matching entry. match is
+// applied to the property's string representation or to a map entry's string value.
 // resource: the resource whose Properties map is inspected.
-// key: the property key to match; may be prefixed with "tag:" to force searching tags/labels.
-// value: the substring to search for within the property's value.
+// key: the lowercased property key; may be prefixed with "tag:" to force searching tags/labels.
+// match: reports whether a property value satisfies the filter.
 // Returns true if a matching property or tag/label entry is found, false otherwise.
-func matchesProperties(resource ResourceDescriptor, key, value string) bool {
+func matchesProperties(resource ResourceDescriptor, key string, match func(string) bool) bool {
 	if resource.Properties == nil {
 		return false
 	}
@@ -1567,7 +1586,7 @@ func matchesProperties(resource ResourceDescriptor, key, value string) bool {
 
 	for k, v := range resource.Properties {
 		if strings.ToLower(k) == propKey {
-			if strings.Contains(strings.ToLower(fmt.Sprintf("%v", v)), value) {
+			if match(fmt.Sprintf("%v", v)) {
 				return true
 			}
 		}
@@ -1575,7 +1594,7 @@ func matchesProperties(resource ResourceDescriptor, key, value string) bool {
 		kl := strings.ToLower(k)
 		if kl == "tags" || kl == "labels" {
 			if m, ok := v.(map[string]interface{}); ok {
-				if matchInMap(m, propKey, value) {
+				if matchInMap(m, propKey, match) {
 					return true
 				}
 			}
@@ -1585,15 +1604,11 @@ func matchesProperties(resource ResourceDescriptor, key, value string) bool {
 }
 
 // matchInMap reports whether m contains an entry whose key equals propKey (case-insensitive)
-// and whose value, when formatted to a string, contains value as a substring (case-insensitive).
-//
-// propKey and value are matched against the map contents in a case-insensitive manner; if a
-// matching key is found and its stringified value contains the provided value, the function
-// returns true, otherwise it returns false.
-func matchInMap(m map[string]interface{}, propKey, value string) bool {
+// and whose value, when formatted to a string, satisfies match.
+func matchInMap(m map[string]interface{}, propKey string, match func(string) bool) bool {
 	for mk, mv := range m {
 		if strings.ToLower(mk) == propKey {
-			if strings.Contains(strings.ToLower(fmt.Sprintf("%v", mv)), value) {
+			if match(fmt.Sprintf("%v", mv)) {
 				return true
 			}
 		}
@@ -1635,41 +1650,28 @@ func FormatPeriod(from, to time.Time) string {
 	return fmt.Sprintf("%d months", months)
 }
 
-// GroupResults groups cost results by the specified grouping strategy.
+// GroupResults groups cost results by the specified grouping strategy. A group spanning
+// currencies yields one result per currency, since their amounts cannot be added.
+// GroupByTag needs a tag key and the resources' tags, so GroupResults returns results
+// as-is for it; use GroupResultsByTag instead. A comma-separated list of dimensions, such
+// as "provider,service", groups hierarchically as GroupResultsByDimensions does, leaving
+// the results as-is when a dimension is invalid.
 func (e *Engine) GroupResults(results []CostResult, groupBy GroupBy) []CostResult {
-	if groupBy == GroupByNone {
+	if IsMultiDimensionGroupBy(string(groupBy)) {
+		grouped, err := e.GroupResultsByDimensions(results, string(groupBy), nil)
+		if err != nil {
+			return results
+		}
+		return grouped
+	}
+	if groupBy == GroupByNone || groupBy == GroupByTag {
 		return results
 	}
 
 	groups := make(map[string][]CostResult)
 
 	for _, result := range results {
-		var key string
-		switch groupBy {
-		case GroupByNone:
-			// Should not reach here since we return early if GroupByNone
-			key = defaultServiceName
-		case GroupByResource:
-			key = fmt.Sprintf("%s/%s", result.ResourceType, result.ResourceID)
-		case GroupByType:
-			key = result.ResourceType
-		case GroupByProvider:
-			// Extract provider from resource type (e.g., "aws:ec2/instance:Instance" -> "aws")
-			if parts := strings.Split(result.ResourceType, ":"); len(parts) > 0 {
-				key = parts[0]
-			} else {
-				key = "unknown"
-			}
-		case GroupByDate:
-			key = result.StartDate.Format("2006-01-02")
-		case GroupByDaily:
-			key = result.StartDate.Format("2006-01-02")
-		case GroupByMonthly:
-			key = result.StartDate.Format("2006-01")
-		default:
-			key = defaultServiceName
-		}
-
+		key := builtinGroupKey(result, groupBy)
 		groups[key] = append(groups[key], result)
 	}
 
@@ -1678,15 +1680,44 @@ func (e *Engine) GroupResults(results []CostResult, groupBy GroupBy) []CostResul
 		if len(groupResults) == 1 {
 			grouped = append(grouped, groupResults[0])
 		} else {
-			// Aggregate multiple results into one
-			aggregated := AggregateResultsInternal(groupResults, groupKey)
-			grouped = append(grouped, aggregated)
+			// Aggregate multiple results into one per currency
+			grouped = append(grouped, aggregateGroup(groupResults, groupKey)...)
 		}
 	}
 
 	return grouped
 }
 
+// builtinGroupKey returns the group of result under a built-in grouping.
+func builtinGroupKey(result CostResult, groupBy GroupBy) string {
+	switch groupBy {
+	case GroupByNone:
+		// Should not reach here since GroupResults returns early if GroupByNone
+		return defaultServiceName
+	case GroupByResource:
+		return fmt.Sprintf("%s/%s", result.ResourceType, result.ResourceID)
+	case GroupByType:
+		return result.ResourceType
+	case GroupByProvider:
+		// Extract provider from resource type (e.g., "aws:ec2/instance:Instance" -> "aws")
+		if parts := strings.Split(result.ResourceType, ":"); len(parts) > 0 {
+			return parts[0]
+		}
+		return "unknown"
+	case GroupByTag:
+		// Should not reach here since GroupResults returns early if GroupByTag
+		return untaggedGroupKey
+	case GroupByDate:
+		return result.StartDate.Format("2006-01-02")
+	case GroupByDaily:
+		return result.StartDate.Format("2006-01-02")
+	case GroupByMonthly, GroupByQuarterly, GroupByYearly:
+		return formatPeriodForGrouping(result.StartDate, groupBy)
+	default:
+		return defaultServiceName
+	}
+}
+
 // AggregateResultsInternal aggregates multiple CostResult entries into a single CostResult.
 // AggregateResultsInternal sums numeric totals (Monthly, Hourly, TotalCost), merges breakdown maps
 // by summing values for matching keys, and combines daily cost series by aligning indices and summing
@@ -2037,13 +2068,14 @@ func validateCurrencyConsistency(results []CostResult) error {
 //	// }
 //
 // distributeDailyCosts adds the entries from result.DailyCosts into the periods map for the specified provider,
-// grouping each daily cost into either a daily ("YYYY-MM-DD") or monthly ("YYYY-MM") period based on groupBy.
+// grouping each daily cost into the daily, monthly, quarterly or yearly period of its day based on groupBy,
+// so a range spanning two quarters or years is split between them.
 // The function mutates the provided periods map and creates nested maps as needed.
 // Parameters:
 //   - periods: map keyed by period string to a map of provider -> accumulated cost.
 //   - result: CostResult whose StartDate and DailyCosts define the per-day values to distribute.
 //   - provider: provider identifier used as the key within each period's nested map.
-//   - groupBy: determines whether costs are grouped by day (GroupByDaily) or by month (GroupByMonthly).
+//   - groupBy: the period granularity each day is grouped into (see formatPeriodForGrouping).
 func distributeDailyCosts(
 	periods map[string]map[string]float64,
 	result CostResult,
@@ -2052,12 +2084,7 @@ func distributeDailyCosts(
 ) {
 	for i, dc := range result.DailyCosts {
 		day := result.StartDate.Add(time.Duration(i) * 24 * time.Hour)
-		var p string
-		if groupBy == GroupByDaily {
-			p = day.Format("2006-01-02")
-		} else { // GroupByMonthly
-			p = day.Format("2006-01")
-		}
+		p := formatPeriodForGrouping(day, groupBy)
 		if periods[p] == nil {
 			periods[p] = make(map[string]float64)
 		}
@@ -2158,13 +2185,21 @@ func groupResultsByPeriod(
 //
 // formatPeriodForGrouping returns a period string for the given date suitable for grouping.
 // formatPeriodForGrouping returns a period string for the given date based on groupBy.
-// For GroupByDaily it returns "YYYY-MM-DD"; for other time-based groupings it returns "YYYY-MM".
+// For GroupByDaily it returns "YYYY-MM-DD", for GroupByQuarterly the calendar quarter as
+// "YYYY-QN", for GroupByYearly "YYYY", and for other time-based groupings "YYYY-MM". Every
+// format sorts chronologically as a string.
 // date is the time to format and groupBy selects the time resolution used for formatting.
 func formatPeriodForGrouping(date time.Time, groupBy GroupBy) string {
-	if groupBy == GroupByDaily {
+	switch groupBy { //nolint:exhaustive // Other groupings use the monthly format.
+	case GroupByDaily:
 		return date.Format("2006-01-02")
+	case GroupByQuarterly:
+		return fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())-1)/monthsPerQuarter+1)
+	case GroupByYearly:
+		return date.Format("2006")
+	default:
+		return date.Format("2006-01")
 	}
-	return date.Format("2006-01")
 }
 
 // calculateCostForPeriod calculates the appropriate cost for a given period.
@@ -2209,7 +2244,8 @@ func formatPeriodForGrouping(date time.Time, groupBy GroupBy) string {
 // If TotalCost is zero and Monthly is available it uses Monthly, converting Monthly to a daily estimate when groupBy is GroupByDaily.
 // calculateCostForPeriod computes the cost for the specified grouping period using a CostResult.
 // It prefers per-day entries when present, falls back to TotalCost, and otherwise derives a period
-// estimate from Monthly (converting to a daily value when groupBy is GroupByDaily).
+// estimate from Monthly (converting to a daily value when groupBy is GroupByDaily, and
+// scaling it to the quarter or year for GroupByQuarterly and GroupByYearly).
 //
 // Parameters:
 //   - result: the CostResult containing potential DailyCosts, TotalCost, and Monthly values.
@@ -2231,10 +2267,15 @@ func calculateCostForPeriod(result CostResult, groupBy GroupBy) float64 {
 	// Fallback to TotalCost if available, otherwise use Monthly projection
 	cost := result.TotalCost
 	if cost == 0 && result.Monthly > 0 {
-		if groupBy == GroupByDaily {
+		switch groupBy { //nolint:exhaustive // Other groupings take the monthly projection as is.
+		case GroupByDaily:
 			// Convert monthly to daily estimate
 			cost = result.Monthly / avgDaysPerMonth
-		} else {
+		case GroupByQuarterly:
+			cost = result.Monthly * monthsPerQuarter
+		case GroupByYearly:
+			cost = result.Monthly * monthsPerYear
+		default:
 			cost = result.Monthly
 		}
 	}
@@ -2334,6 +2375,7 @@ func createSortedAggregations(
 // The plugin is expected to populate ResourceID from the Id field sent in ResourceDescriptor.
 func convertProtoRecommendation(rec *proto.Recommendation) Recommendation {
 	engineRec := Recommendation{
+		ID:          rec.ID,
 		ResourceID:  rec.ResourceID,
 		Type:        rec.ActionType,
 		Description: rec.Description,
@@ -2384,11 +2426,14 @@ func (e *Engine) GetRecommendationsForResources(
 		}
 
 		req := &proto.GetRecommendationsRequest{
-			TargetResources:  targetResources,
-			ProjectionPeriod: "monthly",
+			TargetResources:           targetResources,
+			ProjectionPeriod:          "monthly",
+			ExcludedRecommendationIDs: suppressedRecommendationIDs(e.suppressions),
 		}
 
+		release := e.acquirePluginSlot(ctx)
 		resp, err := client.API.GetRecommendations(ctx, req)
+		release()
 		if err != nil {
 			log.Warn().
 				Ctx(ctx).
@@ -2413,6 +2458,10 @@ func (e *Engine) GetRecommendationsForResources(
 
 		for _, rec := range resp.Recommendations {
 			engineRec := convertProtoRecommendation(rec)
+			if isSuppressed(engineRec, e.suppressions) {
+				result.Suppressed++
+				continue
+			}
 			if result.Currency == defaultCurrency && engineRec.Currency != "" {
 				result.Currency = engineRec.Currency
 			}
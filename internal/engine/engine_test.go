@@ -3,14 +3,48 @@ package engine_test
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/spec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newTestEngine creates an engine, failing the test when the options do not validate.
+func newTestEngine(
+	t testing.TB,
+	clients []*pluginhost.Client,
+	loader engine.SpecLoader,
+	opts ...engine.EngineOptions,
+) *engine.Engine {
+	t.Helper()
+	eng, err := engine.New(clients, loader, opts...)
+	require.NoError(t, err)
+	return eng
+}
+
+// writeSpecs writes specs, keyed by file name, to a temporary directory and returns a
+// loader for it.
+func writeSpecs(t testing.TB, specs map[string]string) *spec.Loader {
+	t.Helper()
+	return spec.NewLoader(writeSpecDir(t, specs))
+}
+
+// writeSpecDir writes specs, keyed by file name, to a temporary directory and returns it.
+func writeSpecDir(t testing.TB, specs map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range specs {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
 func TestAggregateResults(t *testing.T) {
 	results := []engine.CostResult{
 		{
@@ -120,16 +154,45 @@ func TestFilterResources(t *testing.T) {
 			filter:   "invalid",
 			expected: 3, // Should include all on invalid filter
 		},
+		{
+			name:     "regex type",
+			filter:   "type=regex:^aws:(ec2|rds):",
+			expected: 2,
+		},
+		{
+			name:     "regex is case-insensitive",
+			filter:   "type=regex:virtualmachine$",
+			expected: 1,
+		},
+		{
+			name:     "regex anchors at the start of the value",
+			filter:   "id=regex:^123",
+			expected: 0,
+		},
+		{
+			name:     "regex property",
+			filter:   "instanceType=regex:^t3\\.(micro|small)$",
+			expected: 1,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filtered := engine.FilterResources(resources, tt.filter)
+			filtered, err := engine.FilterResources(resources, tt.filter)
+			require.NoError(t, err)
 			assert.Len(t, filtered, tt.expected)
 		})
 	}
 }
 
+func TestFilterResources_InvalidRegex(t *testing.T) {
+	resources := []engine.ResourceDescriptor{{Type: "aws:ec2:Instance", ID: "i-123"}}
+	filtered, err := engine.FilterResources(resources, "type=regex:(ec2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid filter regex")
+	assert.Nil(t, filtered)
+}
+
 func TestValidateFilter(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -152,6 +215,17 @@ func TestValidateFilter(t *testing.T) {
 			filter:    "tag:env=prod",
 			wantError: false,
 		},
+		{
+			name:      "valid regex filter",
+			filter:    "type=regex:^aws:ec2/",
+			wantError: false,
+		},
+		{
+			name:      "invalid regex filter",
+			filter:    "type=regex:[ec2",
+			wantError: true,
+			errorMsg:  "invalid filter regex",
+		},
 		{
 			name:      "missing equals sign",
 			filter:    "type",
@@ -199,7 +273,7 @@ func TestValidateFilter(t *testing.T) {
 
 func TestGetProjectedCostEmpty(t *testing.T) {
 	// Test with no clients and no loader
-	eng := engine.New(nil, nil)
+	eng := newTestEngine(t, nil, nil)
 
 	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
 		{
@@ -249,7 +323,7 @@ func TestGetProjectedCostWithSpecLoader(t *testing.T) {
 		},
 	}
 
-	eng := engine.New(nil, loader)
+	eng := newTestEngine(t, nil, loader)
 
 	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
 		{
@@ -276,7 +350,7 @@ func TestGetProjectedCostWithSpecLoader(t *testing.T) {
 }
 
 func TestGetProjectedCost_PropagatesContextError(t *testing.T) {
-	eng := engine.New(nil, nil)
+	eng := newTestEngine(t, nil, nil)
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -705,7 +779,7 @@ func TestCreateCrossProviderAggregation(t *testing.T) {
 }
 
 func TestGetActualCostWithOptions(t *testing.T) {
-	eng := engine.New(nil, nil) // No plugins for this test
+	eng := newTestEngine(t, nil, nil) // No plugins for this test
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -785,7 +859,7 @@ func TestGetActualCostWithOptions(t *testing.T) {
 }
 
 func TestGetActualCostWithOptions_PropagatesContextError(t *testing.T) {
-	eng := engine.New(nil, nil)
+	eng := newTestEngine(t, nil, nil)
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -816,6 +890,8 @@ func TestGroupByValidation(t *testing.T) {
 		{"date grouping", engine.GroupByDate, true, false},
 		{"daily grouping", engine.GroupByDaily, true, true},
 		{"monthly grouping", engine.GroupByMonthly, true, true},
+		{"quarterly grouping", engine.GroupByQuarterly, true, true},
+		{"yearly grouping", engine.GroupByYearly, true, true},
 		{"none grouping", engine.GroupByNone, true, false},
 		{"invalid grouping", engine.GroupBy("invalid"), false, false},
 	}
@@ -859,7 +935,7 @@ func TestCurrencySymbolMapping(t *testing.T) {
 
 func TestGetProjectedCostWithErrorsReturnType(t *testing.T) {
 	// Test that GetProjectedCostWithErrors returns *CostResultWithErrors
-	eng := engine.New(nil, nil)
+	eng := newTestEngine(t, nil, nil)
 
 	result, err := eng.GetProjectedCostWithErrors(context.Background(), []engine.ResourceDescriptor{
 		{
@@ -889,7 +965,7 @@ func TestGetProjectedCostWithErrorsReturnType(t *testing.T) {
 
 func TestGetProjectedCostWithErrorsMultipleResources(t *testing.T) {
 	// Test with multiple resources to ensure all are processed
-	eng := engine.New(nil, nil)
+	eng := newTestEngine(t, nil, nil)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2:Instance", ID: "i-123"},
@@ -913,7 +989,7 @@ func TestGetProjectedCostWithErrorsMultipleResources(t *testing.T) {
 
 func TestGetActualCostWithOptionsAndErrors(t *testing.T) {
 	t.Run("returns results for resources without plugins", func(t *testing.T) {
-		eng := engine.New(nil, nil)
+		eng := newTestEngine(t, nil, nil)
 		request := engine.ActualCostRequest{
 			Resources: []engine.ResourceDescriptor{
 				{Type: "aws:ec2:Instance", ID: "i-123"},
@@ -932,7 +1008,7 @@ func TestGetActualCostWithOptionsAndErrors(t *testing.T) {
 	})
 
 	t.Run("filters resources by tags", func(t *testing.T) {
-		eng := engine.New(nil, nil)
+		eng := newTestEngine(t, nil, nil)
 		request := engine.ActualCostRequest{
 			Resources: []engine.ResourceDescriptor{
 				{
@@ -964,7 +1040,7 @@ func TestGetActualCostWithOptionsAndErrors(t *testing.T) {
 	})
 
 	t.Run("empty resources returns empty results", func(t *testing.T) {
-		eng := engine.New(nil, nil)
+		eng := newTestEngine(t, nil, nil)
 		request := engine.ActualCostRequest{
 			Resources: []engine.ResourceDescriptor{},
 			From:      time.Now().Add(-24 * time.Hour),
@@ -980,7 +1056,7 @@ func TestGetActualCostWithOptionsAndErrors(t *testing.T) {
 	})
 
 	t.Run("groups results when groupBy is specified", func(t *testing.T) {
-		eng := engine.New(nil, nil)
+		eng := newTestEngine(t, nil, nil)
 		request := engine.ActualCostRequest{
 			Resources: []engine.ResourceDescriptor{
 				{Type: "aws:ec2:Instance", ID: "i-123"},
@@ -1002,7 +1078,7 @@ func TestGetActualCostWithOptionsAndErrors(t *testing.T) {
 
 // T050: Test Engine.GetRecommendationsForResources method returns empty results when no plugins available.
 func TestGetRecommendationsForResources_NoPlugins(t *testing.T) {
-	eng := engine.New(nil, nil) // No plugins
+	eng := newTestEngine(t, nil, nil) // No plugins
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -1022,7 +1098,7 @@ func TestGetRecommendationsForResources_NoPlugins(t *testing.T) {
 
 // T050: Test Engine.GetRecommendationsForResources with empty resource list.
 func TestGetRecommendationsForResources_EmptyResources(t *testing.T) {
-	eng := engine.New(nil, nil)
+	eng := newTestEngine(t, nil, nil)
 
 	result, err := eng.GetRecommendationsForResources(context.Background(), []engine.ResourceDescriptor{})
 
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// EnvelopeVersion identifies the schema of OutputEnvelope. Consumers should check it
+// before parsing; it changes whenever a field is removed or its meaning changes.
+const EnvelopeVersion = "1"
+
+// urnStackParts is the minimum number of "::"-separated URN segments needed to read the stack.
+const urnStackParts = 2
+
+// OutputEnvelope is the self-describing JSON document emitted with --json-envelope.
+// It is shared by the projected and actual cost commands so that consumers can rely on
+// one contract instead of re-aggregating flat result arrays.
+type OutputEnvelope struct {
+	Version string          `json:"version"`
+	Summary EnvelopeSummary `json:"summary"`
+	Results []CostResult    `json:"results"`
+	Errors  []EnvelopeError `json:"errors"`
+	Meta    EnvelopeMeta    `json:"meta"`
+}
+
+// EnvelopeSummary mirrors CostSummary without repeating the per-resource results,
+// and adds the actual-cost total and resource count.
+type EnvelopeSummary struct {
+	TotalMonthly  float64            `json:"totalMonthly"`
+	TotalHourly   float64            `json:"totalHourly"`
+	TotalCost     float64            `json:"totalCost"`
+	Currency      string             `json:"currency"`
+	ByProvider    map[string]float64 `json:"byProvider"`
+	ByService     map[string]float64 `json:"byService"`
+	ByAdapter     map[string]float64 `json:"byAdapter"`
+	ResourceCount int                `json:"resourceCount"`
+
+	// ByCurrency holds per-currency subtotals when the results span currencies, in
+	// which case the totals above stay zero and Currency is empty.
+	ByCurrency map[string]CurrencySubtotal `json:"byCurrency,omitempty"`
+}
+
+// EnvelopeError is the JSON form of ErrorDetail.
+type EnvelopeError struct {
+	ResourceType string    `json:"resourceType"`
+	ResourceID   string    `json:"resourceId"`
+	PluginName   string    `json:"pluginName"`
+	Message      string    `json:"message"`
+	Code         string    `json:"code,omitempty"`
+	Guidance     string    `json:"guidance,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// EnvelopeMeta records the provenance of an envelope.
+type EnvelopeMeta struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Command     string    `json:"command,omitempty"`
+	Stack       string    `json:"stack,omitempty"`
+	Currency    string    `json:"currency"`
+	ToolVersion string    `json:"tool_version"`
+}
+
+// NewOutputEnvelope builds an envelope from results and errors. Meta.GeneratedAt defaults
+// to now and Meta.Currency to the summary currency when left empty.
+func NewOutputEnvelope(results []CostResult, errs []ErrorDetail, meta EnvelopeMeta) *OutputEnvelope {
+	if results == nil {
+		results = []CostResult{}
+	}
+	aggregated := AggregateResults(results)
+
+	summary := EnvelopeSummary{
+		TotalMonthly:  aggregated.Summary.TotalMonthly,
+		TotalHourly:   aggregated.Summary.TotalHourly,
+		Currency:      aggregated.Summary.Currency,
+		ByProvider:    aggregated.Summary.ByProvider,
+		ByService:     aggregated.Summary.ByService,
+		ByAdapter:     aggregated.Summary.ByAdapter,
+		ResourceCount: len(results),
+		ByCurrency:    aggregated.Summary.ByCurrency,
+	}
+	if summary.ByCurrency == nil {
+		for _, r := range results {
+			summary.TotalCost += r.TotalCost
+		}
+	}
+
+	envErrors := make([]EnvelopeError, 0, len(errs))
+	for _, e := range errs {
+		msg := ""
+		if e.Error != nil {
+			msg = e.Error.Error()
+		}
+		envErrors = append(envErrors, EnvelopeError{
+			ResourceType: e.ResourceType,
+			ResourceID:   e.ResourceID,
+			PluginName:   e.PluginName,
+			Message:      msg,
+			Code:         proto.PluginErrorCode(e.Error),
+			Guidance:     PluginErrorGuidance(e),
+			Timestamp:    e.Timestamp,
+		})
+	}
+
+	if meta.GeneratedAt.IsZero() {
+		meta.GeneratedAt = time.Now().UTC()
+	}
+	if meta.Currency == "" {
+		meta.Currency = summary.Currency
+	}
+
+	return &OutputEnvelope{
+		Version: EnvelopeVersion,
+		Summary: summary,
+		Results: results,
+		Errors:  envErrors,
+		Meta:    meta,
+	}
+}
+
+// RenderEnvelope writes the envelope as indented JSON.
+func RenderEnvelope(writer io.Writer, envelope *OutputEnvelope) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(envelope)
+}
+
+// StackFromResources returns the Pulumi stack name encoded in the first resource URN
+// (urn:pulumi:<stack>::<project>::...), or "" when no URN is present.
+func StackFromResources(resources []ResourceDescriptor) string {
+	for _, r := range resources {
+		rest, ok := strings.CutPrefix(r.ID, "urn:pulumi:")
+		if !ok {
+			continue
+		}
+		if parts := strings.SplitN(rest, "::", urnStackParts); len(parts) == urnStackParts {
+			return parts[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const (
+	// envelopeSchemaID is the $id of the envelope schema; the version pins it to
+	// EnvelopeVersion so consumers can tell schemas of incompatible envelopes apart.
+	envelopeSchemaID = "https://github.com/rshade/finfocus/schemas/output-envelope-v" + EnvelopeVersion + ".json"
+
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	schemaDefsRef   = "#/$defs/"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaExtraProperties are the schemas of the fields a type adds outside its struct
+// fields. CostResult promotes its allocation tags to top-level string fields (see
+// CostResult.MarshalJSON); every other object rejects unknown fields.
+var schemaExtraProperties = map[reflect.Type]any{
+	reflect.TypeOf(CostResult{}): map[string]any{"type": "string"},
+}
+
+var (
+	envelopeSchemaOnce     sync.Once
+	envelopeSchema         []byte
+	compiledEnvelopeSchema *jsonschema.Schema
+	envelopeSchemaErr      error
+)
+
+// EnvelopeSchema returns the JSON Schema (draft 2020-12) of the OutputEnvelope document
+// emitted with --json-envelope. It is generated from the Go types that are serialized,
+// so it always describes the output of this build: integrators can validate against
+// it, and a field that is renamed or removed changes it.
+func EnvelopeSchema() []byte {
+	loadEnvelopeSchema()
+	return bytes.Clone(envelopeSchema)
+}
+
+// ValidateEnvelope checks an encoded OutputEnvelope against EnvelopeSchema.
+func ValidateEnvelope(data []byte) error {
+	loadEnvelopeSchema()
+	if envelopeSchemaErr != nil {
+		return envelopeSchemaErr
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("decoding envelope: %w", err)
+	}
+	if err = compiledEnvelopeSchema.Validate(doc); err != nil {
+		return fmt.Errorf("envelope does not match schema v%s: %w", EnvelopeVersion, err)
+	}
+	return nil
+}
+
+func loadEnvelopeSchema() {
+	envelopeSchemaOnce.Do(func() {
+		gen := schemaGenerator{defs: make(map[string]any)}
+		root := map[string]any{
+			"$schema": jsonSchemaDraft,
+			"$id":     envelopeSchemaID,
+			"title":   "finfocus output envelope",
+		}
+		for key, value := range gen.structSchema(reflect.TypeOf(OutputEnvelope{})) {
+			root[key] = value
+		}
+		root["$defs"] = gen.defs
+
+		envelopeSchema, envelopeSchemaErr = json.MarshalIndent(root, "", "  ")
+		if envelopeSchemaErr != nil {
+			return
+		}
+		compiler := jsonschema.NewCompiler()
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(envelopeSchema))
+		if err == nil {
+			err = compiler.AddResource(envelopeSchemaID, doc)
+		}
+		if err == nil {
+			compiledEnvelopeSchema, err = compiler.Compile(envelopeSchemaID)
+		}
+		if err != nil {
+			envelopeSchemaErr = fmt.Errorf("compiling envelope schema: %w", err)
+		}
+	})
+}
+
+// schemaGenerator derives JSON Schemas from Go types following encoding/json's rules.
+// Named structs are emitted once under $defs and referenced from their uses.
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+// typeSchema returns the schema of a value of type t.
+func (g schemaGenerator) typeSchema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() { //nolint:exhaustive // channels, funcs and complex numbers are not serialized.
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, seen := g.defs[t.Name()]; !seen {
+			g.defs[t.Name()] = nil // placeholder for recursive types
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": schemaDefsRef + t.Name()}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the object schema of struct type t. Fields that encoding/json
+// always writes are required; nil maps, slices and pointers among them may be null.
+func (g schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	g.addFields(t, properties, &required)
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if extra, ok := schemaExtraProperties[t]; ok {
+		schema["additionalProperties"] = extra
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g schemaGenerator) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := g.typeSchema(field.Type)
+		optional := strings.Contains(opts, "omitzero") ||
+			(strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Struct)
+		if !optional {
+			*required = append(*required, name)
+			switch field.Type.Kind() { //nolint:exhaustive // only nil-able kinds encode as null.
+			case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+				schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+			}
+		}
+		properties[name] = schema
+	}
+}
@@ -0,0 +1,116 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishedEnvelopeSchema is the copy of the schema documented for integrators.
+var publishedEnvelopeSchema = filepath.Join("..", "..", "docs", "reference", "output-envelope.schema.json")
+
+func renderTestEnvelope(t *testing.T, env *engine.OutputEnvelope) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderEnvelope(&buf, env))
+	return buf.Bytes()
+}
+
+func TestValidateEnvelope_FullyPopulated(t *testing.T) {
+	generated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	results := []engine.CostResult{
+		{
+			ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Adapter: "aws-public",
+			Currency: "USD", Monthly: 73, Hourly: 0.1, Notes: "on-demand",
+			Breakdown:      map[string]float64{"compute": 73},
+			Sustainability: map[string]engine.SustainabilityMetric{"carbon": {Value: 1.5, Unit: "kgCO2e"}},
+			Recommendations: []engine.Recommendation{
+				{ResourceID: "web", Type: "RIGHTSIZE", Description: "Use t3.small", EstimatedSavings: 20, Currency: "USD"},
+			},
+			TotalCost: 12, DailyCosts: []float64{1, 2}, CostPeriod: "daily",
+			StartDate: generated, EndDate: generated, Delta: -3,
+			Confidence:   engine.ConfidenceHigh,
+			Completeness: &engine.EstimateCompleteness{Score: 0.5, Missing: []string{"region"}},
+			Annotations:  map[string]string{"owner": "team-a"},
+			Capacity:     &engine.ScalingCapacity{InstanceType: "t3.micro", Desired: 2, Min: 1, Max: 3, Instances: 2},
+			Efficiency:   &engine.CostEfficiency{VCPUs: 2, MemoryGB: 1, PerVCPU: 36.5, PerGB: 73},
+			Provenance:   &engine.PricingProvenance{Source: "aws-public", InputFingerprint: "abc"},
+			Commitment:   &engine.CommitmentAdjustment{CoveragePercent: 50, DiscountPercent: 30, OnDemandMonthly: 85},
+			CostRule:     "discount",
+			Interval:     &engine.ConfidenceInterval{Level: 0.9, Low: 60, High: 80, Samples: 10, Basis: "history"},
+
+			AllocationTags: map[string]string{"cost-center": "42"},
+		},
+	}
+	errs := []engine.ErrorDetail{
+		{ResourceType: "aws:rds:Instance", ResourceID: "db", PluginName: "aws", Error: errors.New("timeout")},
+	}
+	env := engine.NewOutputEnvelope(results, errs, engine.EnvelopeMeta{
+		GeneratedAt: generated, Command: "cost projected", Stack: "dev", ToolVersion: "v1.2.3",
+	})
+
+	require.NoError(t, engine.ValidateEnvelope(renderTestEnvelope(t, env)))
+}
+
+func TestValidateEnvelope_Minimal(t *testing.T) {
+	env := engine.NewOutputEnvelope([]engine.CostResult{{ResourceType: "aws:s3/bucket:Bucket"}}, nil,
+		engine.EnvelopeMeta{})
+
+	require.NoError(t, engine.ValidateEnvelope(renderTestEnvelope(t, env)))
+}
+
+func TestValidateEnvelope_Rejects(t *testing.T) {
+	valid := renderTestEnvelope(t, engine.NewOutputEnvelope(
+		[]engine.CostResult{{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "b", Monthly: 1}}, nil,
+		engine.EnvelopeMeta{}))
+
+	tests := []struct {
+		name   string
+		mutate func(doc map[string]any)
+	}{
+		{name: "missing version", mutate: func(doc map[string]any) { delete(doc, "version") }},
+		{name: "unknown top-level field", mutate: func(doc map[string]any) { doc["totals"] = 1 }},
+		{name: "wrong result type", mutate: func(doc map[string]any) {
+			doc["results"].([]any)[0].(map[string]any)["monthly"] = "1.00"
+		}},
+		{name: "non-string extra result field", mutate: func(doc map[string]any) {
+			doc["results"].([]any)[0].(map[string]any)["team"] = 7
+		}},
+		{name: "unknown meta field", mutate: func(doc map[string]any) {
+			doc["meta"].(map[string]any)["host"] = "ci"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc map[string]any
+			require.NoError(t, json.Unmarshal(valid, &doc))
+			tt.mutate(doc)
+			data, err := json.Marshal(doc)
+			require.NoError(t, err)
+
+			err = engine.ValidateEnvelope(data)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "envelope does not match schema v"+engine.EnvelopeVersion)
+		})
+	}
+
+	require.Error(t, engine.ValidateEnvelope([]byte("not json")))
+}
+
+func TestEnvelopeSchema_MatchesPublishedCopy(t *testing.T) {
+	published, err := os.ReadFile(publishedEnvelopeSchema)
+	require.NoError(t, err)
+
+	// A mismatch means the output format changed: regenerate the published copy from
+	// engine.EnvelopeSchema() and bump EnvelopeVersion if the change is incompatible.
+	assert.Equal(t, strings.TrimSpace(string(engine.EnvelopeSchema())), strings.TrimSpace(string(published)))
+}
@@ -0,0 +1,91 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutputEnvelope(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", ResourceID: "i-1", Adapter: "aws", Currency: "USD", Monthly: 10, Hourly: 0.01},
+		{ResourceType: "aws:s3:Bucket", ResourceID: "b-1", Adapter: "aws", Currency: "USD", Monthly: 5, TotalCost: 2},
+	}
+	errs := []engine.ErrorDetail{
+		{ResourceType: "aws:rds:Instance", ResourceID: "db-1", PluginName: "aws", Error: errors.New("timeout")},
+	}
+	generated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	env := engine.NewOutputEnvelope(results, errs, engine.EnvelopeMeta{
+		GeneratedAt: generated,
+		Stack:       "dev",
+		ToolVersion: "v1.2.3",
+	})
+
+	assert.Equal(t, engine.EnvelopeVersion, env.Version)
+	assert.InDelta(t, 15.0, env.Summary.TotalMonthly, 0.001)
+	assert.InDelta(t, 2.0, env.Summary.TotalCost, 0.001)
+	assert.Equal(t, 2, env.Summary.ResourceCount)
+	assert.Equal(t, "USD", env.Meta.Currency)
+	assert.Equal(t, generated, env.Meta.GeneratedAt)
+	require.Len(t, env.Errors, 1)
+	assert.Equal(t, "timeout", env.Errors[0].Message)
+}
+
+func TestNewOutputEnvelope_Empty(t *testing.T) {
+	env := engine.NewOutputEnvelope(nil, nil, engine.EnvelopeMeta{})
+
+	assert.NotNil(t, env.Results)
+	assert.NotNil(t, env.Errors)
+	assert.False(t, env.Meta.GeneratedAt.IsZero())
+}
+
+func TestRenderEnvelope(t *testing.T) {
+	env := engine.NewOutputEnvelope(
+		[]engine.CostResult{{ResourceType: "aws:ec2:Instance", Currency: "USD", Monthly: 1}},
+		nil,
+		engine.EnvelopeMeta{Stack: "prod", ToolVersion: "v0.1.0"},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderEnvelope(&buf, env))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	for _, key := range []string{"version", "summary", "results", "errors", "meta"} {
+		assert.Contains(t, decoded, key)
+	}
+	meta, ok := decoded["meta"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "prod", meta["stack"])
+	assert.Equal(t, "USD", meta["currency"])
+	assert.Equal(t, "v0.1.0", meta["tool_version"])
+	assert.Contains(t, meta, "generated_at")
+}
+
+func TestStackFromResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []engine.ResourceDescriptor
+		want      string
+	}{
+		{"no resources", nil, ""},
+		{"plain IDs", []engine.ResourceDescriptor{{ID: "i-123"}}, ""},
+		{
+			"pulumi URN",
+			[]engine.ResourceDescriptor{{ID: "i-1"}, {ID: "urn:pulumi:staging::app::aws:ec2/instance:Instance::web"}},
+			"staging",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, engine.StackFromResources(tt.resources))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// PluginErrorGuidance returns advice for fixing a failed plugin call, based on the
+// structured error code the plugin reported. It returns "" for errors without a known
+// code, whose raw message is the best information available.
+func PluginErrorGuidance(detail ErrorDetail) string {
+	plugin := detail.PluginName
+	if plugin == "" {
+		plugin = "the plugin"
+	}
+	switch proto.PluginErrorCode(detail.Error) {
+	case proto.ErrorCodeAuthFailed:
+		return fmt.Sprintf("%s could not authenticate: %s",
+			plugin, providerCredentialHint(extractProviderFromType(detail.ResourceType)))
+	case proto.ErrorCodeRegionUnsupported:
+		return fmt.Sprintf("this region isn't supported by plugin %s; use a plugin that covers it "+
+			"or a local pricing spec", plugin)
+	case proto.ErrorCodeRateLimited:
+		return fmt.Sprintf("%s is rate limited; reduce --max-plugins or wait and retry", plugin)
+	case proto.ErrorCodeResourceNotFound:
+		return fmt.Sprintf("%s could not find the resource; check that it exists in the account "+
+			"and region the plugin is configured for", plugin)
+	default:
+		return ""
+	}
+}
+
+// providerCredentialHint tells users how to supply credentials for each cloud provider.
+func providerCredentialHint(provider string) string {
+	switch provider {
+	case "aws":
+		return "set AWS_PROFILE, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, and check they are not expired"
+	case "azure", "azure-native":
+		return "run 'az login', or set AZURE_CLIENT_ID, AZURE_CLIENT_SECRET and AZURE_TENANT_ID"
+	case "gcp", "google-native":
+		return "set GOOGLE_APPLICATION_CREDENTIALS or run 'gcloud auth application-default login'"
+	default:
+		return "check the credentials the plugin uses for " + provider
+	}
+}
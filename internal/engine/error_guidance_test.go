@@ -0,0 +1,73 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// failingAPI fails every projected-cost call with err.
+type failingAPI struct {
+	proto.CostSourceClient
+
+	err error
+}
+
+func (a *failingAPI) GetProjectedCost(
+	_ context.Context,
+	_ *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	return nil, a.err
+}
+
+func TestPluginErrorGuidance(t *testing.T) {
+	detail := func(resourceType, code string) engine.ErrorDetail {
+		return engine.ErrorDetail{
+			ResourceType: resourceType,
+			PluginName:   "cloud-pricing",
+			Error:        fmt.Errorf("plugin call failed: %w", &proto.PluginError{Code: code, Message: "denied"}),
+		}
+	}
+
+	assert.Contains(t, engine.PluginErrorGuidance(detail("aws:ec2/instance:Instance", proto.ErrorCodeAuthFailed)),
+		"AWS_PROFILE")
+	assert.Contains(t, engine.PluginErrorGuidance(detail("gcp:compute:Instance", proto.ErrorCodeAuthFailed)),
+		"GOOGLE_APPLICATION_CREDENTIALS")
+	assert.Contains(t, engine.PluginErrorGuidance(detail("aws:ec2/instance:Instance", proto.ErrorCodeRegionUnsupported)),
+		"this region isn't supported by plugin cloud-pricing")
+	assert.Contains(t, engine.PluginErrorGuidance(detail("aws:ec2/instance:Instance", proto.ErrorCodeRateLimited)),
+		"--max-plugins")
+	assert.Contains(t, engine.PluginErrorGuidance(detail("aws:ec2/instance:Instance", proto.ErrorCodeResourceNotFound)),
+		"could not find the resource")
+	assert.Empty(t, engine.PluginErrorGuidance(detail("aws:ec2/instance:Instance", "quota-exceeded")))
+	assert.Empty(t, engine.PluginErrorGuidance(engine.ErrorDetail{Error: errors.New("connection refused")}))
+}
+
+func TestGetProjectedCostWithErrors_PluginErrorGuidance(t *testing.T) {
+	clients := []*pluginhost.Client{{
+		Name: "cloud-pricing",
+		API:  &failingAPI{err: &proto.PluginError{Code: proto.ErrorCodeAuthFailed, Message: "token expired"}},
+	}}
+	result, err := newTestEngine(t, clients, nil).GetProjectedCostWithErrors(context.Background(),
+		[]engine.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws"}})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+
+	summary := result.ErrorSummary()
+	assert.Contains(t, summary, "token expired (auth-failed)")
+	assert.Contains(t, summary, "hint: cloud-pricing could not authenticate: set AWS_PROFILE")
+
+	envelope := engine.NewOutputEnvelope(result.Results, result.Errors, engine.EnvelopeMeta{})
+	require.Len(t, envelope.Errors, 1)
+	assert.Equal(t, proto.ErrorCodeAuthFailed, envelope.Errors[0].Code)
+	assert.Contains(t, envelope.Errors[0].Guidance, "AWS_PROFILE")
+}
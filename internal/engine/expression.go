@@ -0,0 +1,423 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidExpression is returned when an expression cannot be parsed.
+	ErrInvalidExpression = errors.New("invalid expression")
+	// ErrUnknownField is returned when an expression refers to a field its environment lacks.
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// ExpressionEnv resolves the names an expression refers to.
+type ExpressionEnv interface {
+	// Field returns a named resource field such as type or provider.
+	Field(name string) (string, bool)
+	// Tag returns a resource tag, or "" when the resource does not have it.
+	Tag(key string) string
+}
+
+// Expression is a parsed string expression over resource fields and tags, e.g.
+//
+//	split(type, ':')[0] + '/' + default(tag:environment, 'none')
+//
+// Operands are quoted string literals, field names, tag:<key> references and function
+// calls; "+" concatenates strings and [n] indexes a list. Functions are split(s, sep),
+// join(list, sep), lower(s), upper(s) and default(s, fallback), which returns fallback
+// when s is empty.
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpression parses src, reporting syntax errors with their position.
+func ParseExpression(src string) (*Expression, error) {
+	p := &exprParser{src: src}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return &Expression{source: src, root: root}, nil
+}
+
+// String returns the expression source.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression to a string.
+func (e *Expression) Eval(env ExpressionEnv) (string, error) {
+	v, err := e.root.eval(env)
+	if err != nil {
+		return "", err
+	}
+	if v.isList {
+		return "", errors.New("expression evaluates to a list; index it or use join()")
+	}
+	return v.str, nil
+}
+
+// exprValue is a string or a list of strings.
+type exprValue struct {
+	str    string
+	list   []string
+	isList bool
+}
+
+func (v exprValue) asString(context string) (string, error) {
+	if v.isList {
+		return "", fmt.Errorf("%s: expected a string, got a list", context)
+	}
+	return v.str, nil
+}
+
+type exprNode interface {
+	eval(env ExpressionEnv) (exprValue, error)
+}
+
+type literalNode struct{ value string }
+
+func (n literalNode) eval(ExpressionEnv) (exprValue, error) {
+	return exprValue{str: n.value}, nil
+}
+
+type fieldNode struct{ name string }
+
+func (n fieldNode) eval(env ExpressionEnv) (exprValue, error) {
+	v, ok := env.Field(n.name)
+	if !ok {
+		return exprValue{}, fmt.Errorf("%w %q", ErrUnknownField, n.name)
+	}
+	return exprValue{str: v}, nil
+}
+
+type tagNode struct{ key string }
+
+func (n tagNode) eval(env ExpressionEnv) (exprValue, error) {
+	return exprValue{str: env.Tag(n.key)}, nil
+}
+
+type concatNode struct{ parts []exprNode }
+
+func (n concatNode) eval(env ExpressionEnv) (exprValue, error) {
+	var b strings.Builder
+	for _, part := range n.parts {
+		v, err := part.eval(env)
+		if err != nil {
+			return exprValue{}, err
+		}
+		s, err := v.asString("+")
+		if err != nil {
+			return exprValue{}, err
+		}
+		b.WriteString(s)
+	}
+	return exprValue{str: b.String()}, nil
+}
+
+type indexNode struct {
+	target exprNode
+	index  int
+}
+
+func (n indexNode) eval(env ExpressionEnv) (exprValue, error) {
+	v, err := n.target.eval(env)
+	if err != nil {
+		return exprValue{}, err
+	}
+	if !v.isList {
+		return exprValue{}, errors.New("index applied to a string")
+	}
+	if n.index >= len(v.list) {
+		return exprValue{}, fmt.Errorf("index %d out of range (length %d)", n.index, len(v.list))
+	}
+	return exprValue{str: v.list[n.index]}, nil
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+// exprFunctions maps function names to their arity and implementation.
+var exprFunctions = map[string]struct {
+	arity int
+	fn    func(args []exprValue) (exprValue, error)
+}{
+	"split": {2, func(args []exprValue) (exprValue, error) {
+		s, sep, err := twoStrings("split", args)
+		if err != nil {
+			return exprValue{}, err
+		}
+		return exprValue{list: strings.Split(s, sep), isList: true}, nil
+	}},
+	"join": {2, func(args []exprValue) (exprValue, error) {
+		if !args[0].isList {
+			return exprValue{}, errors.New("join: expected a list")
+		}
+		sep, err := args[1].asString("join")
+		if err != nil {
+			return exprValue{}, err
+		}
+		return exprValue{str: strings.Join(args[0].list, sep)}, nil
+	}},
+	"lower": {1, func(args []exprValue) (exprValue, error) {
+		s, err := args[0].asString("lower")
+		return exprValue{str: strings.ToLower(s)}, err
+	}},
+	"upper": {1, func(args []exprValue) (exprValue, error) {
+		s, err := args[0].asString("upper")
+		return exprValue{str: strings.ToUpper(s)}, err
+	}},
+	"default": {2, func(args []exprValue) (exprValue, error) {
+		s, fallback, err := twoStrings("default", args)
+		if s == "" {
+			s = fallback
+		}
+		return exprValue{str: s}, err
+	}},
+}
+
+func twoStrings(name string, args []exprValue) (string, string, error) {
+	a, err := args[0].asString(name)
+	if err != nil {
+		return "", "", err
+	}
+	b, err := args[1].asString(name)
+	return a, b, err
+}
+
+func (n callNode) eval(env ExpressionEnv) (exprValue, error) {
+	args := make([]exprValue, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return exprValue{}, err
+		}
+		args[i] = v
+	}
+	return exprFunctions[n.name].fn(args)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokTag
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type exprParser struct {
+	src    string
+	tokens []exprToken
+	next   int
+}
+
+func (p *exprParser) errorf(tok exprToken, format string, args ...interface{}) error {
+	return fmt.Errorf("%w at position %d: %s", ErrInvalidExpression, tok.pos+1, fmt.Sprintf(format, args...))
+}
+
+// isTagKeyChar reports whether r may appear in an unquoted tag key.
+func isTagKeyChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./:", r)
+}
+
+func (p *exprParser) tokenize() error {
+	src := []rune(p.src)
+	for i := 0; i < len(src); {
+		r := src[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+()[],", r):
+			p.tokens = append(p.tokens, exprToken{kind: tokPunct, text: string(r), pos: i})
+			i++
+		case r == '\'' || r == '"':
+			start := i
+			var b strings.Builder
+			for i++; i < len(src) && src[i] != r; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				b.WriteRune(src[i])
+			}
+			if i >= len(src) {
+				return p.errorf(exprToken{pos: start}, "unterminated string")
+			}
+			i++
+			p.tokens = append(p.tokens, exprToken{kind: tokString, text: b.String(), pos: start})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(src) && unicode.IsDigit(src[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokNumber, text: string(src[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(src[i]) || unicode.IsDigit(src[i]) || src[i] == '_') {
+				i++
+			}
+			word := string(src[start:i])
+			if word == "tag" && i < len(src) && src[i] == ':' {
+				keyStart := i + 1
+				i = keyStart
+				for i < len(src) && isTagKeyChar(src[i]) {
+					i++
+				}
+				if i == keyStart {
+					return p.errorf(exprToken{pos: start}, "tag: needs a key")
+				}
+				p.tokens = append(p.tokens, exprToken{kind: tokTag, text: string(src[keyStart:i]), pos: start})
+				continue
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokIdent, text: word, pos: start})
+		default:
+			return p.errorf(exprToken{pos: i}, "unexpected character %q", r)
+		}
+	}
+	p.tokens = append(p.tokens, exprToken{kind: tokEOF, pos: len(src)})
+	return nil
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.next]
+}
+
+func (p *exprParser) take() exprToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *exprParser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokPunct && tok.text == text
+}
+
+func (p *exprParser) expect(text string) error {
+	if tok := p.take(); tok.kind != tokPunct || tok.text != text {
+		return p.errorf(tok, "expected %q", text)
+	}
+	return nil
+}
+
+// parseConcat parses operand ('+' operand)*.
+func (p *exprParser) parseConcat() (exprNode, error) {
+	first, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	parts := []exprNode{first}
+	for p.isPunct("+") {
+		p.take()
+		next, nextErr := p.parsePostfix()
+		if nextErr != nil {
+			return nil, nextErr
+		}
+		parts = append(parts, next)
+	}
+	if len(parts) == 1 {
+		return first, nil
+	}
+	return concatNode{parts: parts}, nil
+}
+
+// parsePostfix parses an operand followed by any number of [n] indexes.
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	node, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("[") {
+		p.take()
+		tok := p.take()
+		if tok.kind != tokNumber {
+			return nil, p.errorf(tok, "expected a list index")
+		}
+		index, convErr := strconv.Atoi(tok.text)
+		if convErr != nil {
+			return nil, p.errorf(tok, "invalid index %q", tok.text)
+		}
+		if expectErr := p.expect("]"); expectErr != nil {
+			return nil, expectErr
+		}
+		node = indexNode{target: node, index: index}
+	}
+	return node, nil
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	tok := p.take()
+	switch tok.kind {
+	case tokString:
+		return literalNode{value: tok.text}, nil
+	case tokTag:
+		return tagNode{key: tok.text}, nil
+	case tokIdent:
+		if !p.isPunct("(") {
+			return fieldNode{name: tok.text}, nil
+		}
+		return p.parseCall(tok)
+	case tokPunct:
+		if tok.text == "(" {
+			node, err := p.parseConcat()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+	case tokEOF:
+		return nil, p.errorf(tok, "unexpected end of expression")
+	case tokNumber:
+	}
+	return nil, p.errorf(tok, "unexpected %q", tok.text)
+}
+
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	fn, ok := exprFunctions[name.text]
+	if !ok {
+		return nil, p.errorf(name, "unknown function %q", name.text)
+	}
+	p.take() // (
+	var args []exprNode
+	for !p.isPunct(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.take() // )
+	if len(args) != fn.arity {
+		return nil, p.errorf(name, "%s takes %d argument(s), got %d", name.text, fn.arity, len(args))
+	}
+	return callNode{name: name.text, args: args}, nil
+}
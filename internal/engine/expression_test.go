@@ -0,0 +1,84 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapEnv resolves fields and tags from maps.
+type mapEnv struct {
+	fields map[string]string
+	tags   map[string]string
+}
+
+func (m mapEnv) Field(name string) (string, bool) {
+	v, ok := m.fields[name]
+	return v, ok
+}
+
+func (m mapEnv) Tag(key string) string {
+	return m.tags[key]
+}
+
+func TestExpression_Eval(t *testing.T) {
+	env := mapEnv{
+		fields: map[string]string{"type": "aws:ec2/instance:Instance", "id": "web"},
+		tags:   map[string]string{"environment": "prod", "cost-center": "cc-42"},
+	}
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`split(type, ':')[0] + '/' + tag:environment`, "aws/prod"},
+		{`upper(tag:cost-center)`, "CC-42"},
+		{`default(tag:team, "unowned")`, "unowned"},
+		{`join(split(type, ':'), '.')`, "aws.ec2/instance.Instance"},
+		{`lower(split(split(type, ':')[1], '/')[0]) + '-' + id`, "ec2-web"},
+		{`('a' + 'b')`, "ab"},
+		{`'it\'s'`, "it's"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := engine.ParseExpression(tt.expr)
+			require.NoError(t, err)
+			got, err := expr.Eval(env)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpression_EvalErrors(t *testing.T) {
+	env := mapEnv{fields: map[string]string{"type": "aws:s3"}}
+	for _, src := range []string{
+		`split(type, ':')[5]`,
+		`split(type, ':')`,
+		`type[0]`,
+		`owner`,
+	} {
+		expr, err := engine.ParseExpression(src)
+		require.NoError(t, err, src)
+		_, err = expr.Eval(env)
+		assert.Error(t, err, src)
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`'unterminated`,
+		`split(type)`,
+		`nope(type)`,
+		`type +`,
+		`tag:`,
+		`type $ id`,
+		`split(type, ':')[x]`,
+	} {
+		_, err := engine.ParseExpression(src)
+		require.ErrorIs(t, err, engine.ErrInvalidExpression, src)
+	}
+}
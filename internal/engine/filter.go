@@ -0,0 +1,300 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// filterRegexPrefix marks a filter value as a regular expression, as in
+// "type=regex:^aws:ec2".
+const filterRegexPrefix = "regex:"
+
+// Boolean operators of filter expressions, matched case-insensitively.
+const (
+	filterAnd = "AND"
+	filterOr  = "OR"
+)
+
+// Filter is a parsed resource filter expression. The zero value matches every resource.
+type Filter struct {
+	root filterNode
+	expr string
+}
+
+// filterNode is a term or boolean combination in a filter expression.
+type filterNode interface {
+	match(resource ResourceDescriptor) bool
+}
+
+// ParseFilter parses a filter expression of key=value terms combined with AND and OR,
+// where AND binds tighter than OR and parentheses group, for example
+// "provider=aws AND (service=ec2 OR service=rds)". Values match as case-insensitive
+// substrings, or as case-insensitive regular expressions when prefixed with "regex:"
+// (for example "type=regex:^aws:ec2/"). Words up to the next operator belong to one
+// value, so "tag:owner=Jane Doe" is a single term. An empty expression matches every
+// resource.
+func ParseFilter(expr string) (Filter, error) {
+	tokens := tokenizeFilter(expr)
+	if len(tokens) == 0 {
+		return Filter{expr: expr}, nil
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return Filter{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Filter{}, fmt.Errorf("invalid filter syntax: unexpected %q", p.tokens[p.pos].text)
+	}
+	return Filter{root: root, expr: expr}, nil
+}
+
+// Match reports whether resource satisfies the filter.
+func (f Filter) Match(resource ResourceDescriptor) bool {
+	return f.root == nil || f.root.match(resource)
+}
+
+// String returns the expression the filter was parsed from.
+func (f Filter) String() string {
+	return f.expr
+}
+
+// FilterResources selects resources that match the provided filter expression, as accepted
+// by ParseFilter (for example "provider=aws" or "tag:env=prod AND type=ec2"). An empty
+// filter returns the input slice unchanged, and so, for compatibility, does a filter with
+// no '=' at all. Other malformed expressions and invalid regular expressions return an
+// error.
+// The returned slice contains only the resources that satisfy the filter.
+func FilterResources(resources []ResourceDescriptor, filter string) ([]ResourceDescriptor, error) {
+	if !strings.Contains(filter, "=") {
+		return resources, nil
+	}
+	f, err := ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []ResourceDescriptor
+	for _, resource := range resources {
+		if f.Match(resource) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered, nil
+}
+
+// ValidateFilter validates the syntax of a filter expression.
+// It returns an error if a term is malformed: missing '=' separator,
+// if either the key or value is empty (for example: `type=aws:ec2/instance` or `tag:env=prod`),
+// or if a "regex:" value is not a valid regular expression; or if AND, OR and parentheses
+// do not combine terms correctly.
+func ValidateFilter(filter string) error {
+	_, err := ParseFilter(filter)
+	return err
+}
+
+// filterTerm is a key=value comparison.
+type filterTerm struct {
+	key   string
+	value func(string) bool
+}
+
+func (t filterTerm) match(resource ResourceDescriptor) bool {
+	return matchesFilter(resource, t.key, t.value)
+}
+
+// filterAll matches resources satisfying every node.
+type filterAll []filterNode
+
+func (a filterAll) match(resource ResourceDescriptor) bool {
+	for _, n := range a {
+		if !n.match(resource) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterAny matches resources satisfying at least one node.
+type filterAny []filterNode
+
+func (a filterAny) match(resource ResourceDescriptor) bool {
+	for _, n := range a {
+		if n.match(resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFilterTerm parses a "key=value" term into its lowercased key and a function
+// matching values against it.
+func parseFilterTerm(term string) (filterTerm, error) {
+	parts := strings.SplitN(term, "=", filterKeyValueParts)
+	if len(parts) != filterKeyValueParts {
+		return filterTerm{}, fmt.Errorf(
+			"invalid filter syntax: expected 'key=value' (e.g., 'type=aws:ec2/instance' or 'tag:env=prod'), got %q",
+			term)
+	}
+	key := strings.ToLower(strings.TrimSpace(parts[0]))
+	value := strings.TrimSpace(parts[1])
+	if key == "" || value == "" {
+		return filterTerm{}, errors.New("filter key and value must be non-empty")
+	}
+
+	if pattern, isRegex := strings.CutPrefix(value, filterRegexPrefix); isRegex {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return filterTerm{}, fmt.Errorf("invalid filter regex %q: %w", pattern, err)
+		}
+		return filterTerm{key: key, value: re.MatchString}, nil
+	}
+	value = strings.ToLower(value)
+	return filterTerm{key: key, value: func(s string) bool {
+		return strings.Contains(strings.ToLower(s), value)
+	}}, nil
+}
+
+// filterToken is a term, operator or parenthesis of a filter expression.
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenTerm filterTokenKind = iota
+	filterTokenAnd
+	filterTokenOr
+	filterTokenOpen
+	filterTokenClose
+)
+
+// tokenizeFilter splits expr into terms, operators and parentheses. Parentheses inside a
+// word, as in "type=regex:^aws:(ec2|rds)", belong to the term as long as they balance, and
+// consecutive words that are not operators are joined into one term.
+func tokenizeFilter(expr string) []filterToken {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenOpen, text: "("})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenClose, text: ")"})
+			i++
+			continue
+		}
+
+		start, depth := i, 0
+		for ; i < len(runes) && !unicode.IsSpace(runes[i]); i++ {
+			if runes[i] == '(' {
+				depth++
+			} else if runes[i] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		word := string(runes[start:i])
+		switch strings.ToUpper(word) {
+		case filterAnd:
+			tokens = append(tokens, filterToken{kind: filterTokenAnd, text: word})
+		case filterOr:
+			tokens = append(tokens, filterToken{kind: filterTokenOr, text: word})
+		default:
+			if n := len(tokens); n > 0 && tokens[n-1].kind == filterTokenTerm {
+				tokens[n-1].text += " " + word
+			} else {
+				tokens = append(tokens, filterToken{kind: filterTokenTerm, text: word})
+			}
+		}
+	}
+	return tokens
+}
+
+// filterParser is a recursive-descent parser over filter tokens.
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek(kind filterTokenKind) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind
+}
+
+// parseOr parses terms joined by OR.
+func (p *filterParser) parseOr() (filterNode, error) {
+	return p.parseJoined(filterTokenOr, p.parseAnd, func(nodes []filterNode) filterNode {
+		return filterAny(nodes)
+	})
+}
+
+// parseAnd parses terms joined by AND.
+func (p *filterParser) parseAnd() (filterNode, error) {
+	return p.parseJoined(filterTokenAnd, p.parsePrimary, func(nodes []filterNode) filterNode {
+		return filterAll(nodes)
+	})
+}
+
+// parseJoined parses operands separated by the operator op, combining two or more with
+// join.
+func (p *filterParser) parseJoined(
+	op filterTokenKind,
+	operand func() (filterNode, error),
+	join func([]filterNode) filterNode,
+) (filterNode, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []filterNode{first}
+	for p.peek(op) {
+		p.pos++
+		next, nextErr := operand()
+		if nextErr != nil {
+			return nil, nextErr
+		}
+		nodes = append(nodes, next)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return join(nodes), nil
+}
+
+// parsePrimary parses a term or a parenthesized expression.
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("invalid filter syntax: expression ends where a filter term was expected")
+	}
+	token := p.tokens[p.pos]
+	switch token.kind { //nolint:exhaustive // Operators and ')' cannot start an operand.
+	case filterTokenTerm:
+		p.pos++
+		return parseFilterTerm(token.text)
+	case filterTokenOpen:
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(filterTokenClose) {
+			return nil, errors.New("invalid filter syntax: missing ')'")
+		}
+		p.pos++
+		return node, nil
+	default:
+		return nil, fmt.Errorf("invalid filter syntax: unexpected %q where a filter term was expected", token.text)
+	}
+}
@@ -0,0 +1,91 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filterTestResources() []engine.ResourceDescriptor {
+	return []engine.ResourceDescriptor{
+		{
+			Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"env": "prod", "owner": "Jane Doe"}},
+		},
+		{
+			Type: "aws:ec2/instance:Instance", ID: "batch", Provider: "aws",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"env": "test"}},
+		},
+		{Type: "aws:rds/instance:Instance", ID: "db", Provider: "aws"},
+		{Type: "aws:dynamodb/table:Table", ID: "sessions", Provider: "aws"},
+		{Type: "gcp:compute/instance:Instance", ID: "vm", Provider: "gcp"},
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"web", "batch", "db", "sessions", "vm"}},
+		{"provider=aws AND service=ec2", []string{"web", "batch"}},
+		{"type=rds OR type=dynamodb", []string{"db", "sessions"}},
+		{"type=rds or type=dynamodb", []string{"db", "sessions"}},
+		// AND binds tighter than OR.
+		{"provider=gcp OR service=ec2 AND tag:env=prod", []string{"web", "vm"}},
+		{"(provider=gcp OR service=ec2) AND tag:env=prod", []string{"web"}},
+		{"((id=web))", []string{"web"}},
+		{"type=regex:^aws:(rds|dynamodb)/ OR id=vm", []string{"db", "sessions", "vm"}},
+		{"(type=regex:^aws:(rds|dynamodb)/)", []string{"db", "sessions"}},
+		{"tag:owner=Jane Doe AND service=ec2", []string{"web"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := engine.ParseFilter(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expr, f.String())
+
+			var got []string
+			for _, r := range filterTestResources() {
+				if f.Match(r) {
+					got = append(got, r.ID)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	tests := map[string]string{
+		"provider=aws AND":               "expression ends where a filter term was expected",
+		"OR provider=aws":                `unexpected "OR"`,
+		"provider=aws AND AND type=ec2":  `unexpected "AND"`,
+		"(provider=aws":                  "missing ')'",
+		"provider=aws)":                  `unexpected ")"`,
+		"()":                             `unexpected ")"`,
+		"provider=aws AND service":       `expected 'key=value'`,
+		"provider=aws OR type=":          "filter key and value must be non-empty",
+		"provider=aws OR type=regex:(ec": "invalid filter regex",
+	}
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := engine.ParseFilter(expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), want)
+			assert.Error(t, engine.ValidateFilter(expr))
+		})
+	}
+}
+
+func TestFilterResources_Compound(t *testing.T) {
+	filtered, err := engine.FilterResources(filterTestResources(), "service=ec2 AND (tag:env=prod OR id=vm)")
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "web", filtered[0].ID)
+
+	_, err = engine.FilterResources(filterTestResources(), "service=ec2 AND (tag:env=prod")
+	require.Error(t, err)
+}
@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OutputFOCUS renders results as CSV in the FinOps Open Cost and Usage Specification
+// (FOCUS) 1.0 column layout.
+const OutputFOCUS OutputFormat = "focus"
+
+// FOCUS column values used by the exporter.
+const (
+	focusChargeCategoryUsage  = "Usage"
+	focusChargeFrequencyUsage = "Usage-Based"
+	focusPricingStandard      = "Standard"
+	focusPricingCommitted     = "Committed"
+	focusChargeBasisActual    = "actual"
+	focusChargeBasisProjected = "projected"
+)
+
+// focusColumns is the FOCUS header, in order. Columns prefixed with x_ are FOCUS custom
+// columns carrying data with no FOCUS equivalent.
+//
+// Field mapping from CostResult:
+//
+//	BilledCost           TotalCost for actual costs, Monthly for projected costs
+//	EffectiveCost        same as BilledCost (commitment blending is already applied)
+//	ListCost             Commitment.OnDemandMonthly when set, otherwise BilledCost
+//	ContractedCost       same as EffectiveCost
+//	BillingCurrency      Currency
+//	BillingPeriodStart   blank: billing periods are not known
+//	BillingPeriodEnd     blank
+//	ChargePeriodStart    StartDate, or the export day for projected costs
+//	ChargePeriodEnd      EndDate, or 730 hours after ChargePeriodStart for projected costs
+//	ChargeCategory       always "Usage"
+//	ChargeClass          blank: corrections are not tracked
+//	ChargeDescription    Notes
+//	ChargeFrequency      always "Usage-Based"
+//	ProviderName         provider of ResourceType, e.g. "AWS" for aws:ec2/instance:Instance
+//	PublisherName        blank: marketplace publishers are not known
+//	InvoiceIssuerName    blank: resellers are not known
+//	PricingCategory      "Committed" when Commitment is set, otherwise "Standard"
+//	RegionId             blank: results do not carry a region
+//	ResourceId           ResourceID
+//	ResourceName         last segment of a Pulumi URN, otherwise ResourceID
+//	ResourceType         ResourceType
+//	ServiceCategory      blank: no FOCUS service category mapping exists yet
+//	ServiceName          service segment of ResourceType, e.g. "ec2"
+//	SkuId                blank: SKUs are not carried on results
+//	Tags                 AllocationTags as a JSON object
+//	x_Adapter            Adapter
+//	x_ChargeBasis        "actual" or "projected"
+var focusColumns = []string{
+	"BilledCost", "EffectiveCost", "ListCost", "ContractedCost", "BillingCurrency",
+	"BillingPeriodStart", "BillingPeriodEnd", "ChargePeriodStart", "ChargePeriodEnd",
+	"ChargeCategory", "ChargeClass", "ChargeDescription", "ChargeFrequency",
+	"ProviderName", "PublisherName", "InvoiceIssuerName", "PricingCategory", "RegionId",
+	"ResourceId", "ResourceName", "ResourceType", "ServiceCategory", "ServiceName", "SkuId",
+	"Tags", "x_Adapter", "x_ChargeBasis",
+}
+
+// focusProviderNames maps Pulumi provider prefixes to FOCUS provider names.
+var focusProviderNames = map[string]string{
+	"aws":           "AWS",
+	"azure":         "Microsoft",
+	"azure-native":  "Microsoft",
+	"gcp":           "Google Cloud",
+	"google-native": "Google Cloud",
+	"kubernetes":    "Kubernetes",
+}
+
+// RenderFOCUS writes results as FOCUS CSV. Results with a StartDate are treated as actual
+// costs for that period; other results are projected monthly costs whose charge period
+// starts on the UTC day of now. Columns with no equivalent in the results are left blank.
+func RenderFOCUS(writer io.Writer, results []CostResult, now time.Time) error {
+	projectedStart := now.UTC().Truncate(hoursPerDay * time.Hour)
+	projectedEnd := projectedStart.Add(hoursPerMonth * time.Hour)
+
+	w := csv.NewWriter(writer)
+	if err := w.Write(focusColumns); err != nil {
+		return err
+	}
+	for _, r := range results {
+		cost, start, end, basis := r.Monthly, projectedStart, projectedEnd, focusChargeBasisProjected
+		if !r.StartDate.IsZero() {
+			cost, start, end, basis = r.TotalCost, r.StartDate, r.EndDate, focusChargeBasisActual
+		}
+		listCost, pricing := cost, focusPricingStandard
+		if r.Commitment != nil {
+			listCost, pricing = r.Commitment.OnDemandMonthly, focusPricingCommitted
+		}
+
+		row := []string{
+			formatFOCUSCost(cost),
+			formatFOCUSCost(cost),
+			formatFOCUSCost(listCost),
+			formatFOCUSCost(cost),
+			r.Currency,
+			"",
+			"",
+			formatFOCUSTime(start),
+			formatFOCUSTime(end),
+			focusChargeCategoryUsage,
+			"",
+			r.Notes,
+			focusChargeFrequencyUsage,
+			focusProviderName(r.ResourceType),
+			"",
+			"",
+			pricing,
+			"",
+			r.ResourceID,
+			urnResourceName(r.ResourceID),
+			r.ResourceType,
+			"",
+			extractService(r.ResourceType),
+			"",
+			focusTags(r.AllocationTags),
+			r.Adapter,
+			basis,
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatFOCUSCost(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatFOCUSTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func focusProviderName(resourceType string) string {
+	provider := extractProviderFromType(resourceType)
+	if name, ok := focusProviderNames[strings.ToLower(provider)]; ok {
+		return name
+	}
+	return provider
+}
+
+// urnResourceName returns the resource name from a Pulumi URN, which follows the last
+// "::" separator.
+func urnResourceName(id string) string {
+	if i := strings.LastIndex(id, "::"); i >= 0 && strings.HasPrefix(id, "urn:pulumi:") {
+		return id[i+len("::"):]
+	}
+	return id
+}
+
+func focusTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
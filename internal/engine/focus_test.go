@@ -0,0 +1,92 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// focusRows parses FOCUS CSV into one column-name-to-value map per row.
+func focusRows(t *testing.T, data []byte) []map[string]string {
+	t.Helper()
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(record))
+		for i, value := range record {
+			row[records[0][i]] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestRenderFOCUS_Projected(t *testing.T) {
+	results := []engine.CostResult{{
+		ResourceType:   "aws:ec2/instance:Instance",
+		ResourceID:     "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		Adapter:        "local-spec",
+		Currency:       "USD",
+		Monthly:        70,
+		Notes:          "t3.micro on-demand",
+		Commitment:     &engine.CommitmentAdjustment{OnDemandMonthly: 100},
+		AllocationTags: map[string]string{"team": "platform"},
+	}}
+	now := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderFOCUS(&buf, results, now))
+	rows := focusRows(t, buf.Bytes())
+	require.Len(t, rows, 1)
+	row := rows[0]
+
+	assert.Equal(t, "70", row["BilledCost"])
+	assert.Equal(t, "70", row["EffectiveCost"])
+	assert.Equal(t, "100", row["ListCost"])
+	assert.Equal(t, "USD", row["BillingCurrency"])
+	assert.Equal(t, "2026-03-14T00:00:00Z", row["ChargePeriodStart"])
+	assert.Equal(t, "2026-04-13T10:00:00Z", row["ChargePeriodEnd"])
+	assert.Equal(t, "Usage", row["ChargeCategory"])
+	assert.Equal(t, "AWS", row["ProviderName"])
+	assert.Equal(t, "ec2", row["ServiceName"])
+	assert.Equal(t, "web", row["ResourceName"])
+	assert.Equal(t, "Committed", row["PricingCategory"])
+	assert.Equal(t, `{"team":"platform"}`, row["Tags"])
+	assert.Equal(t, "projected", row["x_ChargeBasis"])
+	assert.Empty(t, row["RegionId"], "unmappable columns are blank")
+}
+
+func TestRenderFOCUS_Actual(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []engine.CostResult{{
+		ResourceType: "gcp:compute/instance:Instance",
+		ResourceID:   "vm-1",
+		Currency:     "EUR",
+		TotalCost:    12.5,
+		StartDate:    start,
+		EndDate:      start.AddDate(0, 1, 0),
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderResults(&buf, engine.OutputFOCUS, results))
+	rows := focusRows(t, buf.Bytes())
+	require.Len(t, rows, 1)
+	row := rows[0]
+
+	assert.Equal(t, "12.5", row["BilledCost"])
+	assert.Equal(t, "12.5", row["ListCost"])
+	assert.Equal(t, "2026-01-01T00:00:00Z", row["ChargePeriodStart"])
+	assert.Equal(t, "2026-02-01T00:00:00Z", row["ChargePeriodEnd"])
+	assert.Equal(t, "Google Cloud", row["ProviderName"])
+	assert.Equal(t, "vm-1", row["ResourceName"])
+	assert.Equal(t, "Standard", row["PricingCategory"])
+	assert.Equal(t, "actual", row["x_ChargeBasis"])
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+const (
+	// adapterForecast marks results projected forward from daily cost history.
+	adapterForecast = "forecast"
+
+	// minForecastDays is the fewest days of history a trend line can be fitted to.
+	minForecastDays = 2
+
+	// Goodness of fit (R²) from which a forecast has high or medium confidence.
+	forecastHighConfidenceFit   = 0.8
+	forecastMediumConfidenceFit = 0.5
+)
+
+// ErrInvalidForecastHorizon is returned by ForecastCost for a horizon that is not positive.
+var ErrInvalidForecastHorizon = errors.New("forecast horizon must be at least one day")
+
+// ForecastCost fits a least-squares line to each result's DailyCosts and extends it
+// horizonDays past the end of the history. Each forecast has the forecast adapter, the
+// projected daily costs in DailyCosts and their sum in TotalCost, and a Monthly run rate.
+// Its confidence follows how well the line fits the history (R²) and is explained in
+// Notes. Projected days never cost less than zero. Results with fewer than two days of
+// history are skipped with a warning.
+func ForecastCost(ctx context.Context, results []CostResult, horizonDays int) ([]CostResult, error) {
+	if horizonDays <= 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidForecastHorizon, horizonDays)
+	}
+
+	log := logging.FromContext(ctx)
+	forecasts := make([]CostResult, 0, len(results))
+	for _, r := range results {
+		if len(r.DailyCosts) < minForecastDays {
+			log.Warn().Ctx(ctx).Str("component", "engine").
+				Str("resource_type", r.ResourceType).Str("resource_id", r.ResourceID).
+				Int("days", len(r.DailyCosts)).
+				Msg("skipping forecast for resource without enough daily cost history")
+			continue
+		}
+		forecasts = append(forecasts, forecastResult(r, horizonDays))
+	}
+	return forecasts, nil
+}
+
+// forecastResult extends the trend line of r's daily costs over horizonDays.
+func forecastResult(r CostResult, horizonDays int) CostResult {
+	intercept, slope, fit := fitLinearTrend(r.DailyCosts)
+	history := len(r.DailyCosts)
+
+	daily := make([]float64, horizonDays)
+	var total float64
+	for i := range daily {
+		daily[i] = max(0, intercept+slope*float64(history+i))
+		total += daily[i]
+	}
+
+	confidence := ConfidenceLow
+	switch {
+	case fit >= forecastHighConfidenceFit:
+		confidence = ConfidenceHigh
+	case fit >= forecastMediumConfidenceFit:
+		confidence = ConfidenceMedium
+	}
+
+	// The forecast starts the day after the history; results without dates stay undated.
+	var start, end time.Time
+	if !r.StartDate.IsZero() {
+		start = r.StartDate.AddDate(0, 0, history)
+		end = start.AddDate(0, 0, horizonDays)
+	}
+	return CostResult{
+		ResourceType: r.ResourceType,
+		ResourceID:   r.ResourceID,
+		Adapter:      adapterForecast,
+		Currency:     resultCurrency(r),
+		Monthly:      total / float64(horizonDays) * avgDaysPerMonth,
+		TotalCost:    total,
+		DailyCosts:   daily,
+		Confidence:   confidence,
+		Notes: fmt.Sprintf("Linear forecast from %d days of history: %+.2f/day trend, R² %.2f (%s confidence)",
+			history, slope, fit, confidence),
+		StartDate:  start,
+		EndDate:    end,
+		CostPeriod: FormatPeriod(time.Time{}, time.Time{}.AddDate(0, 0, horizonDays)),
+	}
+}
+
+// fitLinearTrend fits y = intercept + slope*x by least squares to values at x = 0, 1, ...
+// and returns the line with its coefficient of determination (R²). A flat series fits
+// perfectly.
+func fitLinearTrend(values []float64) (float64, float64, float64) {
+	n := float64(len(values))
+	var sumX, sumY float64
+	for i, y := range values {
+		sumX += float64(i)
+		sumY += y
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var covXY, varX float64
+	for i, y := range values {
+		dx := float64(i) - meanX
+		covXY += dx * (y - meanY)
+		varX += dx * dx
+	}
+	slope := covXY / varX
+	intercept := meanY - slope*meanX
+
+	var residual, total float64
+	for i, y := range values {
+		predicted := intercept + slope*float64(i)
+		residual += (y - predicted) * (y - predicted)
+		total += (y - meanY) * (y - meanY)
+	}
+	if total == 0 {
+		return intercept, slope, 1
+	}
+	return intercept, slope, 1 - residual/total
+}
@@ -0,0 +1,70 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastCost(t *testing.T) {
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []engine.CostResult{
+		{
+			ResourceType: "aws:ec2/instance:Instance", ResourceID: "growing", Adapter: "aws",
+			Currency: "USD", StartDate: jan1, DailyCosts: []float64{10, 12, 14, 16},
+		},
+		{
+			ResourceType: "aws:s3/bucket:Bucket", ResourceID: "noisy", Adapter: "aws",
+			Currency: "EUR", DailyCosts: []float64{5, 1, 6, 2, 5},
+		},
+		{
+			ResourceType: "aws:lambda/function:Function", ResourceID: "shrinking", Adapter: "aws",
+			DailyCosts: []float64{3, 2, 1},
+		},
+		{ResourceType: "aws:sqs/queue:Queue", ResourceID: "no-history", Adapter: "aws", TotalCost: 9},
+		{ResourceType: "aws:sns/topic:Topic", ResourceID: "one-day", Adapter: "aws", DailyCosts: []float64{4}},
+	}
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).WithContext(context.Background())
+	forecasts, err := engine.ForecastCost(ctx, results, 3)
+	require.NoError(t, err)
+	require.Len(t, forecasts, 3)
+
+	growing := forecasts[0]
+	assert.Equal(t, "growing", growing.ResourceID)
+	assert.Equal(t, "forecast", growing.Adapter)
+	assert.Equal(t, []float64{18, 20, 22}, growing.DailyCosts)
+	assert.InDelta(t, 60.0, growing.TotalCost, 1e-9)
+	assert.InDelta(t, 20*30.44, growing.Monthly, 0.01)
+	assert.Equal(t, engine.ConfidenceHigh, growing.Confidence)
+	assert.Contains(t, growing.Notes, "4 days of history: +2.00/day trend, R² 1.00 (high confidence)")
+	assert.Equal(t, jan1.AddDate(0, 0, 4), growing.StartDate)
+	assert.Equal(t, jan1.AddDate(0, 0, 7), growing.EndDate)
+
+	noisy := forecasts[1]
+	assert.Equal(t, "EUR", noisy.Currency)
+	assert.Equal(t, engine.ConfidenceLow, noisy.Confidence)
+	assert.True(t, noisy.StartDate.IsZero(), "results without dates stay undated")
+
+	shrinking := forecasts[2]
+	assert.Equal(t, "USD", shrinking.Currency)
+	assert.Equal(t, []float64{0, 0, 0}, shrinking.DailyCosts, "projected costs never drop below zero")
+
+	assert.Contains(t, logs.String(), "no-history")
+	assert.Contains(t, logs.String(), "one-day")
+	assert.Contains(t, logs.String(), "skipping forecast")
+}
+
+func TestForecastCost_InvalidHorizon(t *testing.T) {
+	for _, horizon := range []int{0, -7} {
+		_, err := engine.ForecastCost(context.Background(), nil, horizon)
+		require.ErrorIs(t, err, engine.ErrInvalidForecastHorizon)
+	}
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// GitHubAnnotationLevel is the severity of a GitHub Actions workflow annotation.
+type GitHubAnnotationLevel string
+
+const (
+	// GitHubNotice is informational and does not fail the check.
+	GitHubNotice GitHubAnnotationLevel = "notice"
+	// GitHubWarning highlights something reviewers should look at.
+	GitHubWarning GitHubAnnotationLevel = "warning"
+	// GitHubError marks a problem such as a failed plugin call.
+	GitHubError GitHubAnnotationLevel = "error"
+)
+
+// envGitHubActions is set to "true" by the GitHub Actions runner.
+const envGitHubActions = "GITHUB_ACTIONS"
+
+// GitHubAnnotation is a single `::level file=...,title=...::message` workflow command.
+type GitHubAnnotation struct {
+	Level   GitHubAnnotationLevel
+	File    string
+	Title   string
+	Message string
+}
+
+// String formats the annotation as a GitHub Actions workflow command, escaping
+// property and message values as required by the runner.
+func (a GitHubAnnotation) String() string {
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeGitHubProperty(a.File))
+	}
+	if a.Title != "" {
+		props = append(props, "title="+escapeGitHubProperty(a.Title))
+	}
+
+	cmd := "::" + string(a.Level)
+	if len(props) > 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	return cmd + "::" + escapeGitHubData(a.Message)
+}
+
+// IsGitHubActions reports whether the process is running inside a GitHub Actions job.
+func IsGitHubActions() bool {
+	return os.Getenv(envGitHubActions) == "true"
+}
+
+// BuildGitHubAnnotations converts cost results and plugin errors into workflow annotations:
+// a notice with the stack total (per currency when results span currencies), a warning per
+// unpriced resource, a warning per resource whose monthly cost exceeds
+// opts.CostWarningThreshold (when set), and an error per plugin failure.
+func BuildGitHubAnnotations(results []CostResult, errs []ErrorDetail, opts RenderOptions) []GitHubAnnotation {
+	summary := AggregateResults(results).Summary
+	total := fmt.Sprintf("Total monthly cost %.2f %s", summary.TotalMonthly, summary.Currency)
+	if summary.ByCurrency != nil {
+		total = "Monthly cost by currency " +
+			formatCurrencyTotals(summary.ByCurrency, func(s CurrencySubtotal) float64 { return s.TotalMonthly })
+	}
+	annotations := []GitHubAnnotation{{
+		Level:   GitHubNotice,
+		File:    opts.GitHubFile,
+		Title:   "Projected cost",
+		Message: fmt.Sprintf("%s across %d resources", total, len(results)),
+	}}
+
+	for _, r := range results {
+		name := r.ResourceType + "/" + r.ResourceID
+		switch {
+		case r.Adapter == "none":
+			annotations = append(annotations, GitHubAnnotation{
+				Level:   GitHubWarning,
+				File:    opts.GitHubFile,
+				Title:   "Unpriced resource",
+				Message: fmt.Sprintf("%s has no pricing data: %s", name, r.Notes),
+			})
+		case opts.CostWarningThreshold > 0 && r.Monthly > opts.CostWarningThreshold:
+			annotations = append(annotations, GitHubAnnotation{
+				Level: GitHubWarning,
+				File:  opts.GitHubFile,
+				Title: "Expensive resource",
+				Message: fmt.Sprintf("%s costs %.2f %s/month (threshold %.2f)",
+					name, r.Monthly, r.Currency, opts.CostWarningThreshold),
+			})
+		}
+	}
+
+	for _, e := range errs {
+		annotations = append(annotations, GitHubAnnotation{
+			Level:   GitHubError,
+			File:    opts.GitHubFile,
+			Title:   "Plugin error",
+			Message: fmt.Sprintf("%s/%s (plugin %s): %v", e.ResourceType, e.ResourceID, e.PluginName, e.Error),
+		})
+	}
+
+	return annotations
+}
+
+// WriteGitHubAnnotations writes one workflow command per line.
+func WriteGitHubAnnotations(writer io.Writer, annotations []GitHubAnnotation) error {
+	for _, a := range annotations {
+		if _, err := fmt.Fprintln(writer, a.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeGitHubData escapes a workflow command message.
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGitHubProperty escapes a workflow command property value, which additionally
+// cannot contain the ':' and ',' separators.
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
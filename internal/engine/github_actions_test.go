@@ -0,0 +1,112 @@
+package engine_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAnnotation_String(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation engine.GitHubAnnotation
+		want       string
+	}{
+		{
+			name:       "message only",
+			annotation: engine.GitHubAnnotation{Level: engine.GitHubNotice, Message: "total 10.00 USD"},
+			want:       "::notice::total 10.00 USD",
+		},
+		{
+			name: "file and title",
+			annotation: engine.GitHubAnnotation{
+				Level:   engine.GitHubWarning,
+				File:    "Pulumi.yaml",
+				Title:   "Unpriced resource",
+				Message: "aws:s3:Bucket/logs",
+			},
+			want: "::warning file=Pulumi.yaml,title=Unpriced resource::aws:s3:Bucket/logs",
+		},
+		{
+			name: "escaping",
+			annotation: engine.GitHubAnnotation{
+				Level:   engine.GitHubError,
+				Title:   "a:b,c",
+				Message: "100%\nfailed",
+			},
+			want: "::error title=a%3Ab%2Cc::100%25%0Afailed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.annotation.String())
+		})
+	}
+}
+
+func TestBuildGitHubAnnotations(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", ResourceID: "big", Adapter: "aws", Currency: "USD", Monthly: 900},
+		{ResourceType: "aws:ec2:Instance", ResourceID: "small", Adapter: "aws", Currency: "USD", Monthly: 10},
+		{
+			ResourceType: "aws:s3:Bucket",
+			ResourceID:   "logs",
+			Adapter:      "none",
+			Currency:     "USD",
+			Notes:        "No pricing information available",
+		},
+	}
+	errs := []engine.ErrorDetail{
+		{ResourceType: "aws:ec2:Instance", ResourceID: "big", PluginName: "aws", Error: errors.New("timeout")},
+	}
+
+	annotations := engine.BuildGitHubAnnotations(results, errs, engine.RenderOptions{
+		GitHubFile:           "Pulumi.yaml",
+		CostWarningThreshold: 500,
+	})
+	require.Len(t, annotations, 4)
+
+	assert.Equal(t, engine.GitHubNotice, annotations[0].Level)
+	assert.Contains(t, annotations[0].Message, "910.00 USD across 3 resources")
+
+	assert.Equal(t, engine.GitHubWarning, annotations[1].Level)
+	assert.Equal(t, "Expensive resource", annotations[1].Title)
+	assert.Contains(t, annotations[1].Message, "aws:ec2:Instance/big")
+
+	assert.Equal(t, engine.GitHubWarning, annotations[2].Level)
+	assert.Equal(t, "Unpriced resource", annotations[2].Title)
+
+	assert.Equal(t, engine.GitHubError, annotations[3].Level)
+	assert.Contains(t, annotations[3].Message, "timeout")
+
+	for _, a := range annotations {
+		assert.Equal(t, "Pulumi.yaml", a.File)
+	}
+}
+
+func TestRenderResults_GitHubActions(t *testing.T) {
+	var buf bytes.Buffer
+	err := engine.RenderResults(&buf, engine.OutputGitHubActions, []engine.CostResult{
+		{ResourceType: "aws:s3:Bucket", ResourceID: "logs", Adapter: "none", Currency: "USD"},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "::notice"))
+	assert.True(t, strings.HasPrefix(lines[1], "::warning"))
+}
+
+func TestIsGitHubActions(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	assert.True(t, engine.IsGitHubActions())
+
+	t.Setenv("GITHUB_ACTIONS", "")
+	assert.False(t, engine.IsGitHubActions())
+}
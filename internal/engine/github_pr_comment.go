@@ -0,0 +1,265 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// PRCommentMarker is a hidden marker that identifies the finfocus cost comment on a
+	// pull request, so later runs update it instead of adding another comment.
+	PRCommentMarker = "<!-- finfocus-cost-comment -->"
+
+	// prCommentTopChanges is the most increases and decreases listed in the comment.
+	prCommentTopChanges = 5
+
+	// githubAPITimeout bounds each GitHub API request.
+	githubAPITimeout = 15 * time.Second
+
+	// githubCommentsPerPage is the page size used when searching existing comments.
+	githubCommentsPerPage = 100
+
+	// defaultGitHubAPIURL is used when GITHUB_API_URL is not set.
+	defaultGitHubAPIURL = "https://api.github.com"
+)
+
+// ErrNoPullRequest is returned when the pull request to comment on cannot be determined.
+var ErrNoPullRequest = errors.New("no pull request to comment on: set --pr-number or run on a pull_request event")
+
+// RenderBaselineCheckMarkdown summarizes a baseline check as a Markdown pull request
+// comment: the total change, the largest increases and decreases, and the resources
+// added or removed. The comment starts with PRCommentMarker.
+func RenderBaselineCheckMarkdown(check *BaselineCheck) string {
+	var b strings.Builder
+	b.WriteString(PRCommentMarker + "\n")
+	b.WriteString("## Cost impact\n\n")
+
+	status := ":white_check_mark: Within the baseline"
+	if !check.Passed() {
+		status = ":x: Exceeds the baseline"
+	}
+	fmt.Fprintf(&b, "%s: **%.2f %s/month** (baseline %.2f %s, %s)\n",
+		status, check.CurrentTotal, check.Currency, check.BaselineTotal, check.Currency,
+		formatDeltaWithPercent(check.Delta, check.BaselineTotal))
+
+	var increases, decreases, added, removed []BaselineDrift
+	for _, d := range check.Drifts {
+		switch d.Status {
+		case DriftIncreased:
+			increases = append(increases, d)
+		case DriftDecreased:
+			decreases = append(decreases, d)
+		case DriftAdded:
+			added = append(added, d)
+		case DriftRemoved:
+			removed = append(removed, d)
+		}
+	}
+	if len(check.Drifts) == 0 {
+		b.WriteString("\nNo resources changed cost.\n")
+		return b.String()
+	}
+
+	writeDriftTable(&b, "Top increases", increases, prCommentTopChanges)
+	writeDriftTable(&b, "Top decreases", decreases, prCommentTopChanges)
+	writeDriftTable(&b, "New resources", added, 0)
+	writeDriftTable(&b, "Removed resources", removed, 0)
+	return b.String()
+}
+
+// writeDriftTable writes a Markdown table of drifts under a heading, listing at most
+// limit rows when limit is positive. Drifts are already ordered by size of change.
+func writeDriftTable(b *strings.Builder, title string, drifts []BaselineDrift, limit int) {
+	if len(drifts) == 0 {
+		return
+	}
+	shown := drifts
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	b.WriteString("| Resource | Baseline | Current | Change |\n")
+	b.WriteString("| --- | ---: | ---: | ---: |\n")
+	for _, d := range shown {
+		renamed := ""
+		if d.BaselineResourceID != "" {
+			renamed = " (was `" + escapeMarkdownCell(urnResourceName(d.BaselineResourceID)) + "`)"
+		}
+		fmt.Fprintf(b, "| `%s/%s`%s | %.2f | %.2f | %s |\n",
+			escapeMarkdownCell(d.ResourceType), escapeMarkdownCell(d.ResourceID), renamed,
+			d.Baseline, d.Current, formatDeltaWithPercent(d.Delta, d.Baseline))
+	}
+	if hidden := len(drifts) - len(shown); hidden > 0 {
+		fmt.Fprintf(b, "\n_and %d more_\n", hidden)
+	}
+}
+
+// escapeMarkdownCell keeps a value from breaking out of a Markdown table cell.
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", "\\|"), "`", "'")
+}
+
+// GitHubPRCommenter maintains a single sticky comment on a pull request through the
+// GitHub REST API.
+type GitHubPRCommenter struct {
+	// APIURL is the API root, https://api.github.com unless on GitHub Enterprise.
+	APIURL string
+	// Repository is "owner/name".
+	Repository  string
+	PullRequest int
+	Token       string
+	HTTPClient  *http.Client
+}
+
+// githubComment is the part of an issue comment the commenter reads.
+type githubComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// NewGitHubPRCommenterFromEnv configures a commenter from the GitHub Actions environment:
+// GITHUB_TOKEN, GITHUB_REPOSITORY, GITHUB_API_URL, and the pull request of the event in
+// GITHUB_EVENT_PATH unless pullRequest is positive.
+func NewGitHubPRCommenterFromEnv(pullRequest int) (*GitHubPRCommenter, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, errors.New("GITHUB_TOKEN must be set to comment on pull requests")
+	}
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return nil, errors.New("GITHUB_REPOSITORY must be set to comment on pull requests")
+	}
+	if pullRequest <= 0 {
+		pullRequest = pullRequestFromEvent(os.Getenv("GITHUB_EVENT_PATH"))
+	}
+	if pullRequest <= 0 {
+		return nil, ErrNoPullRequest
+	}
+	apiURL := os.Getenv("GITHUB_API_URL")
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+	return &GitHubPRCommenter{
+		APIURL:      apiURL,
+		Repository:  repository,
+		PullRequest: pullRequest,
+		Token:       token,
+	}, nil
+}
+
+// pullRequestFromEvent reads the pull request number from a GitHub Actions event payload,
+// returning 0 when the event is not about a pull request.
+func pullRequestFromEvent(path string) int {
+	if path == "" {
+		return 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	var event struct {
+		PullRequest struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+	}
+	if json.Unmarshal(data, &event) != nil {
+		return 0
+	}
+	return event.PullRequest.Number
+}
+
+// Upsert updates the comment carrying PRCommentMarker, or creates it when the pull
+// request has none yet. body should start with the marker.
+func (c *GitHubPRCommenter) Upsert(ctx context.Context, body string) error {
+	existing, err := c.findComment(ctx)
+	if err != nil {
+		return err
+	}
+	payload := map[string]string{"body": body}
+	if existing != 0 {
+		url := fmt.Sprintf("%s/repos/%s/issues/comments/%d", c.apiURL(), c.Repository, existing)
+		return c.do(ctx, http.MethodPatch, url, payload, nil)
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/%d/comments", c.apiURL(), c.Repository, c.PullRequest)
+	return c.do(ctx, http.MethodPost, url, payload, nil)
+}
+
+// findComment returns the ID of the first comment carrying PRCommentMarker, or 0.
+func (c *GitHubPRCommenter) findComment(ctx context.Context) (int64, error) {
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/issues/%d/comments?per_page=%d&page=%d",
+			c.apiURL(), c.Repository, c.PullRequest, githubCommentsPerPage, page)
+		var comments []githubComment
+		if err := c.do(ctx, http.MethodGet, url, nil, &comments); err != nil {
+			return 0, err
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, PRCommentMarker) {
+				return comment.ID, nil
+			}
+		}
+		if len(comments) < githubCommentsPerPage {
+			return 0, nil
+		}
+	}
+}
+
+func (c *GitHubPRCommenter) apiURL() string {
+	if c.APIURL == "" {
+		return defaultGitHubAPIURL
+	}
+	return strings.TrimSuffix(c.APIURL, "/")
+}
+
+// do sends a GitHub API request with an optional JSON body, decoding the response into
+// out when it is not nil.
+func (c *GitHubPRCommenter) do(ctx context.Context, method, url string, in, out any) error {
+	var body io.Reader = http.NoBody
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encoding GitHub request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, githubAPITimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("creating GitHub request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("calling GitHub API: %s %s: unexpected status %d", method, req.URL.Path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding GitHub response: %w", err)
+	}
+	return nil
+}
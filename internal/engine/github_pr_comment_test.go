@@ -0,0 +1,145 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderBaselineCheckMarkdown(t *testing.T) {
+	baseline := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Monthly: 100},
+		{ResourceType: "aws:rds/instance:Instance", ResourceID: "db", Monthly: 200},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "old", Monthly: 5},
+	}
+	current := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Monthly: 150, Currency: "USD"},
+		{ResourceType: "aws:rds/instance:Instance", ResourceID: "db", Monthly: 180, Currency: "USD"},
+		{ResourceType: "aws:sqs/queue:Queue", ResourceID: "jobs|new", Monthly: 1, Currency: "USD"},
+	}
+	check := engine.CompareToBaseline(baseline, current, engine.BaselineCheckOptions{TotalTolerancePercent: 50})
+
+	comment := engine.RenderBaselineCheckMarkdown(check)
+	assert.True(t, strings.HasPrefix(comment, engine.PRCommentMarker))
+	assert.Contains(t, comment, "**331.00 USD/month** (baseline 305.00 USD, +26.00, +8.5%)")
+	assert.Contains(t, comment, "### Top increases\n\n| Resource | Baseline | Current | Change |")
+	assert.Contains(t, comment, "| `aws:ec2/instance:Instance/web` | 100.00 | 150.00 | +50.00, +50.0% |")
+	assert.Contains(t, comment, "### Top decreases")
+	assert.Contains(t, comment, "### New resources")
+	assert.Contains(t, comment, "`aws:sqs/queue:Queue/jobs\\|new`")
+	assert.Contains(t, comment, "### Removed resources")
+	assert.Contains(t, comment, ":x: Exceeds the baseline", "new resources with a cost exceed the baseline")
+
+	unchanged := engine.CompareToBaseline(baseline, baseline, engine.BaselineCheckOptions{})
+	assert.Contains(t, engine.RenderBaselineCheckMarkdown(unchanged), "No resources changed cost.")
+}
+
+// fakeGitHub serves the issue comment endpoints of one pull request.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	comments map[int64]string
+	nextID   int64
+	requests []string
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var payload struct {
+		Body string `json:"body"`
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/infra/issues/7/comments":
+		comments := []map[string]any{{"id": 1, "body": "looks good"}}
+		for id, body := range f.comments {
+			comments = append(comments, map[string]any{"id": id, "body": body})
+		}
+		_ = json.NewEncoder(w).Encode(comments)
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/infra/issues/7/comments":
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		f.nextID++
+		f.comments[f.nextID] = payload.Body
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id": %d}`, f.nextID)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/acme/infra/issues/comments/"):
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		var id int64
+		_, _ = fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/repos/acme/infra/issues/comments/"), "%d", &id)
+		if _, ok := f.comments[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.comments[id] = payload.Body
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGitHubPRCommenter_Upsert(t *testing.T) {
+	github := &fakeGitHub{comments: make(map[int64]string), nextID: 100}
+	server := httptest.NewServer(github)
+	defer server.Close()
+
+	commenter := &engine.GitHubPRCommenter{
+		APIURL: server.URL, Repository: "acme/infra", PullRequest: 7, Token: "token",
+	}
+	ctx := context.Background()
+	require.NoError(t, commenter.Upsert(ctx, engine.PRCommentMarker+"\nfirst"))
+	require.NoError(t, commenter.Upsert(ctx, engine.PRCommentMarker+"\nsecond"))
+
+	assert.Equal(t, map[int64]string{101: engine.PRCommentMarker + "\nsecond"}, github.comments,
+		"the second run updates the comment instead of adding one")
+	assert.Equal(t, []string{
+		"GET /repos/acme/infra/issues/7/comments",
+		"POST /repos/acme/infra/issues/7/comments",
+		"GET /repos/acme/infra/issues/7/comments",
+		"PATCH /repos/acme/infra/issues/comments/101",
+	}, github.requests)
+
+	commenter.Token = "wrong"
+	require.ErrorContains(t, commenter.Upsert(ctx, "x"), "unexpected status 401")
+}
+
+func TestNewGitHubPRCommenterFromEnv(t *testing.T) {
+	event := filepath.Join(t.TempDir(), "event.json")
+	require.NoError(t, os.WriteFile(event, []byte(`{"pull_request": {"number": 42}}`), 0o600))
+	t.Setenv("GITHUB_TOKEN", "token")
+	t.Setenv("GITHUB_REPOSITORY", "acme/infra")
+	t.Setenv("GITHUB_API_URL", "")
+	t.Setenv("GITHUB_EVENT_PATH", event)
+
+	commenter, err := engine.NewGitHubPRCommenterFromEnv(0)
+	require.NoError(t, err)
+	assert.Equal(t, 42, commenter.PullRequest)
+	assert.Equal(t, "https://api.github.com", commenter.APIURL)
+
+	commenter, err = engine.NewGitHubPRCommenterFromEnv(9)
+	require.NoError(t, err)
+	assert.Equal(t, 9, commenter.PullRequest)
+
+	t.Setenv("GITHUB_EVENT_PATH", "")
+	_, err = engine.NewGitHubPRCommenterFromEnv(0)
+	require.ErrorIs(t, err, engine.ErrNoPullRequest)
+
+	t.Setenv("GITHUB_TOKEN", "")
+	_, err = engine.NewGitHubPRCommenterFromEnv(9)
+	require.ErrorContains(t, err, "GITHUB_TOKEN")
+}
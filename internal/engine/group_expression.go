@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// unknownGroupKey collects results whose group expression fails to evaluate or is empty.
+const unknownGroupKey = "unknown"
+
+// untaggedGroupKey collects results whose resource lacks the GroupByTag key.
+const untaggedGroupKey = "untagged"
+
+// ErrMissingGroupByKey is returned when GroupByTag is requested without a tag key.
+var ErrMissingGroupByKey = errors.New("grouping by tag requires a tag key")
+
+// IsGroupExpression reports whether groupBy is a computed expression rather than one of
+// the built-in GroupBy modes.
+func IsGroupExpression(groupBy string) bool {
+	return groupBy != "" && !GroupBy(groupBy).IsValid()
+}
+
+// groupEnv exposes a result, and the resource it was priced for when known, to a group
+// expression. Fields are type, id, provider, service, adapter and currency.
+type groupEnv struct {
+	result   CostResult
+	resource *ResourceDescriptor
+}
+
+func (g groupEnv) Field(name string) (string, bool) {
+	switch name {
+	case "type":
+		return g.result.ResourceType, true
+	case "id":
+		return g.result.ResourceID, true
+	case "provider":
+		if g.resource != nil && g.resource.Provider != "" {
+			return g.resource.Provider, true
+		}
+		return extractProviderFromType(g.result.ResourceType), true
+	case "service":
+		return extractService(g.result.ResourceType), true
+	case "adapter":
+		return g.result.Adapter, true
+	case "currency":
+		return g.result.Currency, true
+	default:
+		return "", false
+	}
+}
+
+// Tag looks the key up case-insensitively in the resource's tags and labels, then in the
+// result's allocation tags and annotations.
+func (g groupEnv) Tag(key string) string {
+	if g.resource != nil {
+		for _, mapKey := range []string{"tags", "labels"} {
+			m, _ := g.resource.Properties[mapKey].(map[string]interface{})
+			for k, v := range m {
+				if strings.EqualFold(k, key) {
+					return fmt.Sprint(v)
+				}
+			}
+		}
+	}
+	for _, m := range []map[string]string{g.result.AllocationTags, g.result.Annotations} {
+		for k, v := range m {
+			if strings.EqualFold(k, key) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// GroupResultsByExpression aggregates results by the value of expr. Resources are matched
+// to results by ID to resolve tags; results whose expression fails or yields an empty key
+// are grouped under "unknown". Groups keep the order in which they first appear, and a
+// group spanning currencies yields one result per currency.
+func (e *Engine) GroupResultsByExpression(
+	results []CostResult,
+	expr *Expression,
+	resources []ResourceDescriptor,
+) []CostResult {
+	byID := make(map[string]*ResourceDescriptor, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+
+	groups := make(map[string][]CostResult)
+	var order []string
+	for _, result := range results {
+		key, err := expr.Eval(groupEnv{result: result, resource: byID[result.ResourceID]})
+		if err != nil || key == "" {
+			key = unknownGroupKey
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], result)
+	}
+
+	grouped := make([]CostResult, 0, len(order))
+	for _, key := range order {
+		grouped = append(grouped, aggregateGroup(groups[key], key)...)
+	}
+	return grouped
+}
+
+// GroupResultsByTag aggregates results by the value of the tag key, looked up
+// case-insensitively in the tags and labels of the resource each result was priced for,
+// matched by ID, and in the result's allocation tags and annotations. Results without
+// the tag are grouped under "untagged". Groups keep the order in which they first
+// appear, and a group spanning currencies yields one result per currency.
+func (e *Engine) GroupResultsByTag(results []CostResult, key string, resources []ResourceDescriptor) []CostResult {
+	byID := make(map[string]*ResourceDescriptor, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+
+	groups := make(map[string][]CostResult)
+	var order []string
+	for _, result := range results {
+		value := groupEnv{result: result, resource: byID[result.ResourceID]}.Tag(key)
+		if value == "" {
+			value = untaggedGroupKey
+		}
+		if _, ok := groups[value]; !ok {
+			order = append(order, value)
+		}
+		groups[value] = append(groups[value], result)
+	}
+
+	grouped := make([]CostResult, 0, len(order))
+	for _, value := range order {
+		grouped = append(grouped, aggregateGroup(groups[value], value)...)
+	}
+	return grouped
+}
+
+// ValidateGroupByKey checks that a tag key accompanies GroupByTag.
+func ValidateGroupByKey(groupBy, key string) error {
+	if GroupBy(groupBy) == GroupByTag && strings.TrimSpace(key) == "" {
+		return ErrMissingGroupByKey
+	}
+	return nil
+}
+
+// validateActualGrouping validates the request's grouping before any plugin is called.
+func validateActualGrouping(request ActualCostRequest) error {
+	if err := ValidateGroupBy(request.GroupBy); err != nil {
+		return err
+	}
+	return ValidateGroupByKey(request.GroupBy, request.GroupByKey)
+}
+
+// ValidateGroupBy rejects group-by values that are neither a built-in mode nor a valid
+// expression over known fields, nor a comma-separated list of such dimensions that can be
+// nested (see GroupResultsByDimensions).
+func ValidateGroupBy(groupBy string) error {
+	if IsMultiDimensionGroupBy(groupBy) {
+		_, err := parseGroupDimensions(groupBy)
+		return err
+	}
+	if !IsGroupExpression(groupBy) {
+		return nil
+	}
+	expr, err := ParseExpression(groupBy)
+	if err != nil {
+		return fmt.Errorf("invalid group-by %q: %w", groupBy, err)
+	}
+	// Other evaluation errors depend on the resource and fall back to the unknown group.
+	if _, evalErr := expr.Eval(groupEnv{}); errors.Is(evalErr, ErrUnknownField) {
+		return fmt.Errorf("invalid group-by %q: %w (fields are type, id, provider, service, adapter, currency)",
+			groupBy, evalErr)
+	}
+	return nil
+}
+
+// groupActualResults applies the request's built-in grouping, tag grouping, group
+// expression or multi-dimension grouping, and verifies the grouped totals when enabled.
+func (e *Engine) groupActualResults(results []CostResult, request ActualCostRequest) ([]CostResult, error) {
+	var grouped []CostResult
+	if GroupBy(request.GroupBy) == GroupByTag {
+		grouped = e.GroupResultsByTag(results, request.GroupByKey, request.Resources)
+	} else if IsMultiDimensionGroupBy(request.GroupBy) {
+		var err error
+		if grouped, err = e.GroupResultsByDimensions(results, request.GroupBy, request.Resources); err != nil {
+			return nil, err
+		}
+	} else if !IsGroupExpression(request.GroupBy) {
+		grouped = e.GroupResults(results, GroupBy(request.GroupBy))
+	} else if expr, parseErr := ParseExpression(request.GroupBy); parseErr == nil {
+		grouped = e.GroupResultsByExpression(results, expr, request.Resources)
+	} else {
+		// Unreachable: GetActualCost validates the expression before pricing.
+		grouped = results
+	}
+	if err := e.verifyGrouping(results, grouped); err != nil {
+		return nil, err
+	}
+	return grouped, nil
+}
@@ -0,0 +1,110 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupResultsByExpression(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"Environment": "prod"},
+		}},
+		{ID: "db", Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"environment": "prod"},
+		}},
+		{ID: "vm", Type: "gcp:compute/instance:Instance", Properties: map[string]interface{}{
+			"labels": map[string]interface{}{"environment": "dev"},
+		}},
+		{ID: "bucket", Type: "aws:s3/bucket:Bucket"},
+	}
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "USD", TotalCost: 10},
+		{ResourceType: "aws:rds/instance:Instance", ResourceID: "db", Currency: "USD", TotalCost: 20},
+		{ResourceType: "gcp:compute/instance:Instance", ResourceID: "vm", Currency: "USD", TotalCost: 5},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "bucket", Currency: "USD", TotalCost: 1},
+	}
+
+	expr, err := engine.ParseExpression(`provider + '/' + tag:environment`)
+	require.NoError(t, err)
+	grouped := newTestEngine(t, nil, nil).GroupResultsByExpression(results, expr, resources)
+
+	totals := make(map[string]float64)
+	for _, r := range grouped {
+		totals[r.ResourceType] = r.TotalCost
+	}
+	assert.Equal(t, map[string]float64{"aws/prod": 30, "gcp/dev": 5, "aws/": 1}, totals)
+	assert.Equal(t, "aws/prod", grouped[0].ResourceType, "groups keep first-seen order")
+}
+
+func TestGroupResultsByExpression_UnknownBucket(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", TotalCost: 10},
+		{ResourceType: "custom", ResourceID: "thing", TotalCost: 2},
+	}
+	expr, err := engine.ParseExpression(`split(type, '/')[1]`)
+	require.NoError(t, err)
+
+	grouped := newTestEngine(t, nil, nil).GroupResultsByExpression(results, expr, nil)
+	require.Len(t, grouped, 2)
+	assert.Equal(t, "instance:Instance", grouped[0].ResourceType)
+	assert.Equal(t, "unknown", grouped[1].ResourceType)
+	assert.InDelta(t, 2.0, grouped[1].TotalCost, 0.001)
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	require.NoError(t, engine.ValidateGroupBy(""))
+	require.NoError(t, engine.ValidateGroupBy("monthly"))
+	require.NoError(t, engine.ValidateGroupBy(`split(type, ':')[3] + tag:env`))
+	require.ErrorIs(t, engine.ValidateGroupBy(`owner + '/'`), engine.ErrUnknownField)
+	require.ErrorIs(t, engine.ValidateGroupBy(`split(type`), engine.ErrInvalidExpression)
+}
+
+func TestGroupResultsByTag(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"CostCenter": "retail"},
+		}},
+		{ID: "db", Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"costCenter": "retail"},
+		}},
+		{ID: "vm", Type: "gcp:compute/instance:Instance", Properties: map[string]interface{}{
+			"labels": map[string]interface{}{"costcenter": "platform"},
+		}},
+		{ID: "bucket", Type: "aws:s3/bucket:Bucket"},
+	}
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "EUR", TotalCost: 10, Monthly: 12},
+		{ResourceType: "aws:rds/instance:Instance", ResourceID: "db", Currency: "EUR", TotalCost: 20, Monthly: 24},
+		{ResourceType: "gcp:compute/instance:Instance", ResourceID: "vm", Currency: "EUR", TotalCost: 5, Monthly: 6},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "bucket", Currency: "EUR", TotalCost: 1, Monthly: 1},
+	}
+
+	grouped := newTestEngine(t, nil, nil).GroupResultsByTag(results, "costCenter", resources)
+	require.Len(t, grouped, 3)
+	for i, want := range []struct {
+		group          string
+		total, monthly float64
+	}{{"retail", 30, 36}, {"platform", 5, 6}, {"untagged", 1, 1}} {
+		assert.Equal(t, want.group, grouped[i].ResourceType)
+		assert.Equal(t, "EUR", grouped[i].Currency)
+		assert.InDelta(t, want.total, grouped[i].TotalCost, 0.001)
+		assert.InDelta(t, want.monthly, grouped[i].Monthly, 0.001)
+	}
+}
+
+func TestValidateGroupByKey(t *testing.T) {
+	require.NoError(t, engine.ValidateGroupBy("tag"))
+	require.ErrorIs(t, engine.ValidateGroupByKey("tag", ""), engine.ErrMissingGroupByKey)
+	require.ErrorIs(t, engine.ValidateGroupByKey("tag", "  "), engine.ErrMissingGroupByKey)
+	require.NoError(t, engine.ValidateGroupByKey("tag", "costCenter"))
+	require.NoError(t, engine.ValidateGroupByKey("type", ""))
+
+	_, err := newTestEngine(t, nil, nil).GetActualCostWithOptions(context.Background(),
+		engine.ActualCostRequest{GroupBy: "tag"})
+	require.ErrorIs(t, err, engine.ErrMissingGroupByKey)
+}
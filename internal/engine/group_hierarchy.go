@@ -0,0 +1,235 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// groupPathSeparator joins the keys of a multi-dimension group into its result's type.
+const groupPathSeparator = "/"
+
+// groupDimension is one level of a multi-dimension grouping: a built-in grouping, or a
+// group expression when expr is set.
+type groupDimension struct {
+	mode GroupBy
+	expr *Expression
+}
+
+// key returns the group of result at this dimension.
+func (d groupDimension) key(result CostResult, resource *ResourceDescriptor) string {
+	if d.expr == nil {
+		return builtinGroupKey(result, d.mode)
+	}
+	key, err := d.expr.Eval(groupEnv{result: result, resource: resource})
+	if err != nil || key == "" {
+		return unknownGroupKey
+	}
+	return key
+}
+
+// SplitGroupBy splits a group-by into its dimensions at the commas outside quotes,
+// parentheses and brackets, so "provider,service" has two dimensions while
+// "split(type, ':')[0]" has one.
+func SplitGroupBy(groupBy string) []string {
+	var dims []string
+	depth, start := 0, 0
+	var quote rune
+	for i, r := range groupBy {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			dims = append(dims, strings.TrimSpace(groupBy[start:i]))
+			start = i + 1
+		}
+	}
+	return append(dims, strings.TrimSpace(groupBy[start:]))
+}
+
+// IsMultiDimensionGroupBy reports whether groupBy lists more than one dimension.
+func IsMultiDimensionGroupBy(groupBy string) bool {
+	return len(SplitGroupBy(groupBy)) > 1
+}
+
+// parseGroupDimensions parses each dimension of groupBy. Dimensions are the resource, type
+// and provider groupings and group expressions; time-based groupings, which aggregate a
+// period rather than a set of resources, cannot be nested, and tags are grouped by with
+// tag:<key> expressions.
+func parseGroupDimensions(groupBy string) ([]groupDimension, error) {
+	parts := SplitGroupBy(groupBy)
+	dims := make([]groupDimension, 0, len(parts))
+	for _, part := range parts {
+		mode := GroupBy(part)
+		switch {
+		case part == "":
+			return nil, fmt.Errorf("invalid group-by %q: empty dimension", groupBy)
+		case mode.IsTimeBasedGrouping() || mode == GroupByDate:
+			return nil, fmt.Errorf("invalid group-by %q: %s grouping cannot be combined with other dimensions",
+				groupBy, part)
+		case mode == GroupByTag:
+			return nil, fmt.Errorf("invalid group-by %q: use tag:<key> to group by a tag alongside other dimensions",
+				groupBy)
+		case mode.IsValid():
+			dims = append(dims, groupDimension{mode: mode})
+		default:
+			if err := ValidateGroupBy(part); err != nil {
+				return nil, err
+			}
+			expr, err := ParseExpression(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid group-by %q: %w", part, err)
+			}
+			dims = append(dims, groupDimension{expr: expr})
+		}
+	}
+	return dims, nil
+}
+
+// GroupResultsByDimensions groups results hierarchically by each comma-separated dimension
+// of groupBy in turn, e.g. "provider,service" groups by service within each provider.
+// It returns one aggregated result per innermost group, and per currency when the group
+// spans currencies, with GroupPath holding the group's key at each dimension and
+// ResourceType those keys joined by "/". Groups keep the order in which they first
+// appear; NestGroupedResults turns them into a tree. Resources are matched to results by
+// ID to resolve tags in expressions.
+func (e *Engine) GroupResultsByDimensions(
+	results []CostResult,
+	groupBy string,
+	resources []ResourceDescriptor,
+) ([]CostResult, error) {
+	dims, err := parseGroupDimensions(groupBy)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*ResourceDescriptor, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+
+	groups := make(map[string][]CostResult)
+	paths := make(map[string][]string)
+	var order []string
+	for _, result := range results {
+		path := make([]string, len(dims))
+		for i, dim := range dims {
+			path[i] = dim.key(result, byID[result.ResourceID])
+		}
+		// The keys are joined with a NUL byte, which keys do not contain, to identify the group.
+		id := strings.Join(path, "\x00")
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+			paths[id] = path
+		}
+		groups[id] = append(groups[id], result)
+	}
+
+	grouped := make([]CostResult, 0, len(order))
+	for _, id := range order {
+		path := paths[id]
+		for _, result := range aggregateGroup(groups[id], strings.Join(path, groupPathSeparator)) {
+			result.GroupPath = path
+			grouped = append(grouped, result)
+		}
+	}
+	return grouped, nil
+}
+
+// CostGroup is one group of a multi-dimension grouping with its totals and, unless it is
+// at the innermost dimension, the groups of the next dimension within it. Groups in
+// different currencies are kept apart, so a key can appear once per currency.
+type CostGroup struct {
+	Key       string      `json:"key"`
+	Currency  string      `json:"currency"`
+	Monthly   float64     `json:"monthly"`
+	Hourly    float64     `json:"hourly"`
+	TotalCost float64     `json:"totalCost,omitempty"`
+	Groups    []CostGroup `json:"groups,omitempty"`
+}
+
+// NestGroupedResults arranges results of GroupResultsByDimensions into a tree of groups,
+// summing each group's totals from the results within it. Results without a GroupPath
+// become top-level groups keyed by their resource type.
+func NestGroupedResults(results []CostResult) []CostGroup {
+	var groups []CostGroup
+	for _, result := range results {
+		path := result.GroupPath
+		if len(path) == 0 {
+			path = []string{result.ResourceType}
+		}
+		groups = addToCostGroup(groups, path, result)
+	}
+	return groups
+}
+
+// addToCostGroup adds result to the group at path within groups, creating the groups
+// along the path as needed.
+func addToCostGroup(groups []CostGroup, path []string, result CostResult) []CostGroup {
+	i := 0
+	for i < len(groups) && (groups[i].Key != path[0] || groups[i].Currency != result.Currency) {
+		i++
+	}
+	if i == len(groups) {
+		groups = append(groups, CostGroup{Key: path[0], Currency: result.Currency})
+	}
+	groups[i].Monthly += result.Monthly
+	groups[i].Hourly += result.Hourly
+	groups[i].TotalCost += result.TotalCost
+	if len(path) > 1 {
+		groups[i].Groups = addToCostGroup(groups[i].Groups, path[1:], result)
+	}
+	return groups
+}
+
+// isNestedGrouping reports whether results come from a multi-dimension grouping.
+func isNestedGrouping(results []CostResult) bool {
+	for _, r := range results {
+		if len(r.GroupPath) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// renderCostGroups writes results of a multi-dimension grouping as nested JSON groups for
+// OutputJSON, or otherwise as a table indenting each dimension under the one before it.
+func renderCostGroups(writer io.Writer, format OutputFormat, results []CostResult) error {
+	groups := NestGroupedResults(results)
+	if format == OutputJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(groups)
+	}
+
+	actual := hasActualCosts(results)
+	amountLabel := "Monthly"
+	if actual {
+		amountLabel = "Total Cost"
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintf(w, "Group\t%s\tCurrency\n", amountLabel)
+	fmt.Fprintf(w, "-----\t%s\t--------\n", strings.Repeat("-", len(amountLabel)))
+	var writeGroups func(level []CostGroup, depth int)
+	writeGroups = func(level []CostGroup, depth int) {
+		for _, g := range level {
+			amount := g.Monthly
+			if actual {
+				amount = g.TotalCost
+			}
+			fmt.Fprintf(w, "%s%s\t%.2f\t%s\n", strings.Repeat("  ", depth), g.Key, amount, g.Currency)
+			writeGroups(g.Groups, depth+1)
+		}
+	}
+	writeGroups(groups, 0)
+	return w.Flush()
+}
@@ -0,0 +1,104 @@
+package engine_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitGroupBy(t *testing.T) {
+	assert.Equal(t, []string{"provider"}, engine.SplitGroupBy("provider"))
+	assert.Equal(t, []string{"provider", "service"}, engine.SplitGroupBy("provider, service"))
+	assert.Equal(t, []string{"split(type, ':')[0]", "tag:env"}, engine.SplitGroupBy("split(type, ':')[0],tag:env"))
+	assert.Equal(t, []string{"default(tag:team, 'a,b')"}, engine.SplitGroupBy("default(tag:team, 'a,b')"))
+	assert.False(t, engine.IsMultiDimensionGroupBy("split(type, ':')[0]"))
+	assert.True(t, engine.IsMultiDimensionGroupBy("provider,service"))
+}
+
+func TestGroupResultsByDimensions(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "USD", Monthly: 10},
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "api", Currency: "USD", Monthly: 5},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "logs", Currency: "USD", Monthly: 2},
+		{ResourceType: "gcp:compute/instance:Instance", ResourceID: "vm", Currency: "USD", Monthly: 8},
+	}
+	eng := newTestEngine(t, nil, nil)
+
+	grouped, err := eng.GroupResultsByDimensions(results, "provider,service", nil)
+	require.NoError(t, err)
+	require.Len(t, grouped, 3)
+	assert.Equal(t, []string{"aws", "ec2"}, grouped[0].GroupPath)
+	assert.Equal(t, "aws/ec2", grouped[0].ResourceType)
+	assert.InDelta(t, 15.0, grouped[0].Monthly, 1e-9)
+	assert.Equal(t, []string{"aws", "s3"}, grouped[1].GroupPath)
+	assert.Equal(t, []string{"gcp", "compute"}, grouped[2].GroupPath)
+
+	assert.Equal(t, grouped, eng.GroupResults(results, "provider,service"),
+		"GroupResults accepts a comma-separated list of dimensions")
+	single := eng.GroupResults(results, engine.GroupByProvider)
+	require.Len(t, single, 2, "single-dimension grouping is unchanged")
+	for _, r := range single {
+		assert.Empty(t, r.GroupPath)
+	}
+
+	groups := engine.NestGroupedResults(grouped)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "aws", groups[0].Key)
+	assert.InDelta(t, 17.0, groups[0].Monthly, 1e-9)
+	require.Len(t, groups[0].Groups, 2)
+	assert.Equal(t, "ec2", groups[0].Groups[0].Key)
+	assert.InDelta(t, 15.0, groups[0].Groups[0].Monthly, 1e-9)
+	assert.Empty(t, groups[0].Groups[0].Groups)
+	assert.Equal(t, "gcp", groups[1].Key)
+}
+
+func TestGroupResultsByDimensions_Currencies(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "us", Currency: "USD", Monthly: 10},
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "eu", Currency: "EUR", Monthly: 7},
+	}
+	grouped, err := newTestEngine(t, nil, nil).GroupResultsByDimensions(results, "provider,service", nil)
+	require.NoError(t, err)
+	require.Len(t, grouped, 2, "one result per currency")
+
+	groups := engine.NestGroupedResults(grouped)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "EUR", groups[0].Currency)
+	assert.InDelta(t, 7.0, groups[0].Monthly, 1e-9)
+	assert.Equal(t, "USD", groups[1].Currency)
+	assert.InDelta(t, 10.0, groups[1].Monthly, 1e-9)
+}
+
+func TestValidateGroupBy_MultiDimension(t *testing.T) {
+	require.NoError(t, engine.ValidateGroupBy("provider,service"))
+	require.NoError(t, engine.ValidateGroupBy("type, tag:env"))
+
+	for _, groupBy := range []string{"provider,daily", "provider,tag", "provider,", "provider,bogus"} {
+		assert.Error(t, engine.ValidateGroupBy(groupBy), groupBy)
+	}
+}
+
+func TestRenderActualCostResults_NestedGroups(t *testing.T) {
+	grouped, err := newTestEngine(t, nil, nil).GroupResultsByDimensions([]engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Currency: "USD", TotalCost: 30},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "logs", Currency: "USD", TotalCost: 5},
+	}, "provider,service", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderActualCostResults(&buf, engine.OutputJSON, grouped, false))
+	var groups []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &groups))
+	require.Len(t, groups, 1)
+	assert.Equal(t, "aws", groups[0]["key"])
+	assert.Len(t, groups[0]["groups"], 2)
+
+	buf.Reset()
+	require.NoError(t, engine.RenderActualCostResults(&buf, engine.OutputTable, grouped, false))
+	assert.Regexp(t, `Group\s+Total Cost\s+Currency`, buf.String())
+	assert.Regexp(t, `(?m)^  ec2\s+30\.00\s+USD$`, buf.String())
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// IdentityTagKey is the resource tag holding a stable identity that survives renames.
+const IdentityTagKey = "finfocus:id"
+
+// Built-in ways of matching the same resource across two sets of results.
+const (
+	// IdentityByURN matches resources by type and ID, which is the Pulumi URN.
+	IdentityByURN = "urn"
+	// IdentityByTag matches resources by their finfocus:id tag, and resources without
+	// the tag by URN.
+	IdentityByTag = "tag"
+	// IdentityByName matches resources by type and the name at the end of the URN, so the
+	// same resource in another stack or project still matches.
+	IdentityByName = "name"
+)
+
+// IdentityMatcher computes the key under which a result is matched with its counterpart
+// in another set of results.
+type IdentityMatcher struct {
+	mode string
+	expr *Expression
+}
+
+// ParseIdentityMatch returns the matcher for urn (the default when empty), tag, name, or
+// an expression over the fields type, id, name, provider and service and tag:<key>
+// references, such as "type + '/' + tag:Name".
+func ParseIdentityMatch(match string) (*IdentityMatcher, error) {
+	switch match {
+	case "", IdentityByURN:
+		return &IdentityMatcher{mode: IdentityByURN}, nil
+	case IdentityByTag, IdentityByName:
+		return &IdentityMatcher{mode: match}, nil
+	}
+	expr, err := ParseExpression(match)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity match %q: %w", match, err)
+	}
+	// Other evaluation errors depend on the resource and fall back to the URN.
+	if _, evalErr := expr.Eval(identityEnv{}); errors.Is(evalErr, ErrUnknownField) {
+		return nil, fmt.Errorf("invalid identity match %q: %w (fields are type, id, name, provider, service)",
+			match, evalErr)
+	}
+	return &IdentityMatcher{expr: expr}, nil
+}
+
+// String returns the match mode or expression.
+func (m *IdentityMatcher) String() string {
+	if m.expr != nil {
+		return m.expr.String()
+	}
+	return m.mode
+}
+
+// Key returns the identity of r. A result that lacks what the matcher needs, such as the
+// finfocus:id tag or a non-empty expression value, is identified by its URN.
+func (m *IdentityMatcher) Key(r CostResult) string {
+	urnKey := r.ResourceType + "/" + r.ResourceID
+	switch {
+	case m.expr != nil:
+		if key, err := m.expr.Eval(identityEnv{result: r}); err == nil && key != "" {
+			return "expr:" + key
+		}
+	case m.mode == IdentityByTag:
+		if r.Identity != "" {
+			return "tag:" + r.Identity
+		}
+	case m.mode == IdentityByName:
+		return r.ResourceType + "/" + urnResourceName(r.ResourceID)
+	}
+	return urnKey
+}
+
+// identityEnv exposes a result to an identity expression. Tags are the finfocus:id
+// identity and the result's annotations and allocation tags, since results do not carry
+// the resource's full tag set.
+type identityEnv struct {
+	result CostResult
+}
+
+func (i identityEnv) Field(name string) (string, bool) {
+	switch name {
+	case "type":
+		return i.result.ResourceType, true
+	case "id":
+		return i.result.ResourceID, true
+	case "name":
+		return urnResourceName(i.result.ResourceID), true
+	case "provider":
+		return extractProviderFromType(i.result.ResourceType), true
+	case "service":
+		return extractService(i.result.ResourceType), true
+	default:
+		return "", false
+	}
+}
+
+func (i identityEnv) Tag(key string) string {
+	if strings.EqualFold(key, IdentityTagKey) {
+		return i.result.Identity
+	}
+	for _, m := range []map[string]string{i.result.AllocationTags, i.result.Annotations} {
+		for k, v := range m {
+			if strings.EqualFold(k, key) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// identifyResults records the resource's finfocus:id tag on every result produced for it.
+func identifyResults(results []CostResult, resource ResourceDescriptor) {
+	identity, ok := resourceTag(resource, IdentityTagKey)
+	if !ok || identity == "" {
+		return
+	}
+	for i := range results {
+		results[i].Identity = identity
+	}
+}
@@ -0,0 +1,114 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const identityTestType = "aws:ec2/instance:Instance"
+
+func identityResult(stack, name, identity string, monthly float64) engine.CostResult {
+	return engine.CostResult{
+		ResourceType: identityTestType,
+		ResourceID:   "urn:pulumi:" + stack + "::app::" + identityTestType + "::" + name,
+		Identity:     identity,
+		Monthly:      monthly,
+		Annotations:  map[string]string{"component": name + "-component"},
+	}
+}
+
+func TestParseIdentityMatch(t *testing.T) {
+	for _, match := range []string{"", "urn", "tag", "name", "type + '/' + tag:component"} {
+		_, err := engine.ParseIdentityMatch(match)
+		require.NoError(t, err, match)
+	}
+
+	_, err := engine.ParseIdentityMatch("region + name")
+	require.ErrorIs(t, err, engine.ErrUnknownField)
+	_, err = engine.ParseIdentityMatch("type + ")
+	require.ErrorIs(t, err, engine.ErrInvalidExpression)
+}
+
+func TestIdentityMatcher_Key(t *testing.T) {
+	prod := identityResult("prod", "web", "web-server", 0)
+	staging := identityResult("staging", "web", "", 0)
+	renamed := identityResult("prod", "frontend", "web-server", 0)
+
+	key := func(match string, r engine.CostResult) string {
+		m, err := engine.ParseIdentityMatch(match)
+		require.NoError(t, err)
+		return m.Key(r)
+	}
+
+	assert.NotEqual(t, key("urn", prod), key("urn", staging))
+	assert.Equal(t, key("name", prod), key("name", staging))
+	assert.Equal(t, key("tag", prod), key("tag", renamed))
+	assert.Equal(t, key("urn", staging), key("tag", staging), "untagged resources fall back to the URN")
+	assert.Equal(t, key("tag:finfocus:id", prod), key("tag:finfocus:id", renamed))
+	assert.NotEqual(t, key("tag:component", prod), key("tag:component", renamed))
+	assert.Equal(t, key("urn", staging), key("tag:owner", staging), "empty expressions fall back to the URN")
+}
+
+func TestCompareToBaseline_IdentityMatch(t *testing.T) {
+	baseline := []engine.CostResult{
+		identityResult("prod", "web", "web-server", 100),
+		identityResult("prod", "worker", "", 20),
+	}
+	current := []engine.CostResult{
+		identityResult("prod", "frontend", "web-server", 110),
+		identityResult("prod", "worker", "", 20),
+	}
+
+	byURN := engine.CompareToBaseline(baseline, current, engine.BaselineCheckOptions{})
+	statuses := make(map[string]int)
+	for _, d := range byURN.Drifts {
+		statuses[d.Status]++
+	}
+	assert.Equal(t, map[string]int{engine.DriftAdded: 1, engine.DriftRemoved: 1}, statuses)
+
+	byTag := engine.CompareToBaseline(baseline, current, engine.BaselineCheckOptions{
+		TotalTolerancePercent: 20, ResourceTolerancePercent: 20, IdentityMatch: engine.IdentityByTag,
+	})
+	require.Len(t, byTag.Drifts, 1)
+	d := byTag.Drifts[0]
+	assert.Equal(t, engine.DriftIncreased, d.Status)
+	assert.InDelta(t, 10.0, d.Delta, 0.0001)
+	assert.Equal(t, current[0].ResourceID, d.ResourceID)
+	assert.Equal(t, baseline[0].ResourceID, d.BaselineResourceID)
+	assert.True(t, byTag.Passed())
+}
+
+func TestCompareToBaseline_MatchByNameAcrossStacks(t *testing.T) {
+	baseline := []engine.CostResult{identityResult("prod", "web", "", 100), identityResult("prod", "cache", "", 30)}
+	current := []engine.CostResult{identityResult("staging", "web", "", 50)}
+
+	check := engine.CompareToBaseline(baseline, current, engine.BaselineCheckOptions{IdentityMatch: engine.IdentityByName})
+	require.Len(t, check.Drifts, 2)
+	assert.Equal(t, engine.DriftDecreased, check.Drifts[0].Status)
+	assert.Contains(t, check.Drifts[0].BaselineResourceID, "urn:pulumi:prod::")
+	assert.Equal(t, engine.DriftRemoved, check.Drifts[1].Status)
+	assert.Empty(t, check.Drifts[1].BaselineResourceID)
+}
+
+func TestGetProjectedCost_RecordsIdentityTag(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{
+			Type: identityTestType, ID: "urn:pulumi:prod::app::" + identityTestType + "::web",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"FinFocus:Id": "web-server"}},
+		},
+		{Type: identityTestType, ID: "urn:pulumi:prod::app::" + identityTestType + "::worker"},
+	}
+	result, err := newTestEngine(t, nil, nil).GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, result.Results, 2)
+	assert.Equal(t, "web-server", result.Results[0].Identity)
+	assert.Empty(t, result.Results[1].Identity)
+
+	anonymized := engine.NewAnonymizer("salt", nil).AnonymizeResults(result.Results)
+	assert.NotEqual(t, "web-server", anonymized[0].Identity)
+	assert.Equal(t, anonymized[0].Identity, engine.NewAnonymizer("salt", nil).AnonymizeResults(result.Results)[0].Identity)
+}
@@ -41,7 +41,7 @@ metadata:
 
 	// Create loader and engine
 	loader := spec.NewLoader(tempDir)
-	eng := engine.New(nil, loader) // No plugin clients for this test
+	eng := newTestEngine(t, nil, loader) // No plugin clients for this test
 
 	// Test resources
 	resources := []engine.ResourceDescriptor{
@@ -143,7 +143,8 @@ func TestFilteringIntegration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filtered := engine.FilterResources(resources, tt.filter)
+			filtered, err := engine.FilterResources(resources, tt.filter)
+			require.NoError(t, err)
 
 			var actualIDs []string
 			for _, r := range filtered {
@@ -212,7 +213,10 @@ func TestAggregationIntegration(t *testing.T) {
 
 	// Test resource preservation
 	assert.Len(t, aggregated.Resources, 4)
-	assert.Equal(t, results, aggregated.Resources)
+	for i, r := range aggregated.Resources {
+		r.PercentOfTotal = 0
+		assert.Equal(t, results[i], r)
+	}
 }
 
 func TestSpecFallbackIntegration(t *testing.T) {
@@ -244,7 +248,7 @@ pricing:
 	}
 
 	loader := spec.NewLoader(tempDir)
-	eng := engine.New(nil, loader)
+	eng := newTestEngine(t, nil, loader)
 
 	// Test resource that should find default spec
 	resources := []engine.ResourceDescriptor{
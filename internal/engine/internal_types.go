@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"strings"
+)
+
+const (
+	// internalTypePrefix identifies Pulumi's own resources (stacks, providers) that have no cost.
+	internalTypePrefix = "pulumi:"
+
+	// internalResourceNote is attached to results for internal resources.
+	internalResourceNote = "Internal Pulumi resource (no cloud cost)"
+
+	// adapterInternal marks the $0 results of internal resources, which are neither
+	// priced nor unsupported.
+	adapterInternal = "internal"
+)
+
+// IsInternalPulumiType reports whether resourceType is an internal Pulumi resource such as
+// pulumi:pulumi:Stack or pulumi:providers:aws. Such resources never incur cloud cost and
+// are not sent to plugins.
+func IsInternalPulumiType(resourceType string) bool {
+	return strings.HasPrefix(resourceType, internalTypePrefix)
+}
+
+// ZeroCostTypes matches the resource types that never incur cloud cost: internal Pulumi
+// types and the extra types configured with EngineOptions.ZeroCostTypes, such as
+// component resources. The zero value matches internal Pulumi types only.
+type ZeroCostTypes struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+// NewZeroCostTypes parses extra zero-cost type patterns. A pattern ending in "*" matches
+// by prefix (e.g. "my-components:*"); otherwise the type must match exactly. Blank
+// patterns are ignored.
+func NewZeroCostTypes(patterns []string) ZeroCostTypes {
+	var z ZeroCostTypes
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix {
+			z.prefixes = append(z.prefixes, prefix)
+			continue
+		}
+		if z.exact == nil {
+			z.exact = make(map[string]bool)
+		}
+		z.exact[pattern] = true
+	}
+	return z
+}
+
+// Match reports whether resourceType is an internal Pulumi type or one of the extra
+// zero-cost types.
+func (z ZeroCostTypes) Match(resourceType string) bool {
+	if IsInternalPulumiType(resourceType) {
+		return true
+	}
+	if resourceType == "" {
+		return false
+	}
+	if z.exact[resourceType] {
+		return true
+	}
+	for _, prefix := range z.prefixes {
+		if strings.HasPrefix(resourceType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Exclude returns resources without zero-cost types, along with the number removed, so
+// that reports are not cluttered with $0 rows.
+func (z ZeroCostTypes) Exclude(resources []ResourceDescriptor) ([]ResourceDescriptor, int) {
+	kept := make([]ResourceDescriptor, 0, len(resources))
+	for _, r := range resources {
+		if !z.Match(r.Type) {
+			kept = append(kept, r)
+		}
+	}
+	return kept, len(resources) - len(kept)
+}
+
+// ZeroCostResult returns the $0 result reported for an internal resource.
+func ZeroCostResult(resourceType, resourceID string) CostResult {
+	return CostResult{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Adapter:      adapterInternal,
+		Currency:     defaultCurrency,
+		Monthly:      0,
+		Hourly:       0,
+		Notes:        internalResourceNote,
+	}
+}
+
+// ExcludeInternalResources returns resources without internal Pulumi types, along with
+// the number removed. Use ZeroCostTypes.Exclude to also drop configured zero-cost types.
+func ExcludeInternalResources(resources []ResourceDescriptor) ([]ResourceDescriptor, int) {
+	return ZeroCostTypes{}.Exclude(resources)
+}
@@ -0,0 +1,120 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsInternalPulumiType(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		want         bool
+	}{
+		{
+			name:         "pulumi stack resource",
+			resourceType: "pulumi:pulumi:Stack",
+			want:         true,
+		},
+		{
+			name:         "pulumi AWS provider",
+			resourceType: "pulumi:providers:aws",
+			want:         true,
+		},
+		{
+			name:         "pulumi Azure provider",
+			resourceType: "pulumi:providers:azure",
+			want:         true,
+		},
+		{
+			name:         "AWS EC2 instance",
+			resourceType: "aws:ec2/instance:Instance",
+			want:         false,
+		},
+		{
+			name:         "Azure VM",
+			resourceType: "azure:compute/virtualMachine:VirtualMachine",
+			want:         false,
+		},
+		{
+			name:         "GCP compute instance",
+			resourceType: "gcp:compute/instance:Instance",
+			want:         false,
+		},
+		{
+			name:         "empty type",
+			resourceType: "",
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.IsInternalPulumiType(tt.resourceType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZeroCostResult(t *testing.T) {
+	result := engine.ZeroCostResult("pulumi:pulumi:Stack", "my-stack")
+
+	assert.Equal(t, "pulumi:pulumi:Stack", result.ResourceType)
+	assert.Equal(t, "my-stack", result.ResourceID)
+	assert.Equal(t, "internal", result.Adapter)
+	assert.Equal(t, "USD", result.Currency)
+	assert.Equal(t, float64(0), result.Monthly)
+	assert.Equal(t, float64(0), result.Hourly)
+	assert.Equal(t, "Internal Pulumi resource (no cloud cost)", result.Notes)
+}
+
+func TestZeroCostTypes_Match(t *testing.T) {
+	types := engine.NewZeroCostTypes([]string{"my-components:*", " acme:index:Wrapper", ""})
+
+	assert.True(t, types.Match("pulumi:pulumi:Stack"))
+	assert.True(t, types.Match("my-components:index:Vpc"))
+	assert.True(t, types.Match("acme:index:Wrapper"))
+	assert.False(t, types.Match("acme:index:WrapperBucket"))
+	assert.False(t, types.Match("aws:s3/bucket:Bucket"))
+	assert.False(t, types.Match(""))
+
+	assert.True(t, engine.ZeroCostTypes{}.Match("pulumi:providers:aws"))
+	assert.False(t, engine.ZeroCostTypes{}.Match("my-components:index:Vpc"))
+}
+
+func TestExcludeInternalResources(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{Type: "pulumi:pulumi:Stack", ID: "dev"},
+		{Type: "aws:s3/bucket:Bucket", ID: "bucket"},
+		{Type: "pulumi:providers:aws", ID: "default"},
+	}
+
+	kept, excluded := engine.ExcludeInternalResources(resources)
+	assert.Equal(t, 2, excluded)
+	assert.Equal(t, []engine.ResourceDescriptor{{Type: "aws:s3/bucket:Bucket", ID: "bucket"}}, kept)
+}
+
+func TestGetProjectedCost_InternalResourcesAreZeroCost(t *testing.T) {
+	results, err := newTestEngine(t, nil, nil).GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
+		{Type: "pulumi:providers:aws", ID: "default"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Zero(t, results[0].Monthly)
+	assert.Equal(t, "Internal Pulumi resource (no cloud cost)", results[0].Notes)
+}
+
+func TestGetProjectedCost_ConfiguredZeroCostTypes(t *testing.T) {
+	eng := newTestEngine(t, nil, nil, engine.EngineOptions{ZeroCostTypes: []string{"my-components:*"}})
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
+		{Type: "my-components:index:Vpc", ID: "network"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Zero(t, results[0].Monthly)
+	assert.Equal(t, "Internal Pulumi resource (no cloud cost)", results[0].Notes)
+}
@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// kubernetesAdapter identifies results estimated from Kubernetes resource requests.
+	kubernetesAdapter = "k8s-requests"
+
+	// envK8sCPUHourly overrides the cost of one requested vCPU per hour.
+	envK8sCPUHourly = "FINFOCUS_K8S_CPU_HOURLY"
+	// envK8sMemoryGBHourly overrides the cost of one requested GiB of memory per hour.
+	envK8sMemoryGBHourly = "FINFOCUS_K8S_MEMORY_GB_HOURLY"
+
+	// Default rates match Kubecost's default on-demand pricing model.
+	defaultK8sCPUHourly      = 0.031611
+	defaultK8sMemoryGBHourly = 0.004237
+
+	bytesPerGiB = 1 << 30
+	milliUnits  = 1000
+)
+
+// kubernetesWorkloadKinds maps "<group>:<Kind>" to whether the workload has a replica count.
+// DaemonSets run one pod per node, which cannot be known from the manifest.
+var kubernetesWorkloadKinds = map[string]bool{
+	"apps:Deployment":  true,
+	"apps:StatefulSet": true,
+	"apps:ReplicaSet":  true,
+	"apps:DaemonSet":   false,
+	"core:Pod":         false,
+}
+
+// memoryQuantitySuffixes lists Kubernetes memory suffixes and their byte multipliers.
+// Two-letter binary suffixes come first so that "Mi" is not read as "M".
+var memoryQuantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// kubernetesWorkload is the per-pod request total and replica count read from a manifest.
+type kubernetesWorkload struct {
+	replicas        int
+	cpuCores        float64
+	memoryGiB       float64
+	containers      int
+	missingRequests int
+	replicasAssumed bool
+}
+
+// kubernetesWorkloadKey normalizes a Pulumi Kubernetes type token to "<group>:<Kind>",
+// accepting both versioned ("apps/v1") and unversioned ("apps") modules.
+func kubernetesWorkloadKey(resourceType string) (string, bool) {
+	parts := strings.Split(resourceType, ":")
+	if len(parts) != minProviderServiceTypeParts || parts[0] != "kubernetes" {
+		return "", false
+	}
+	group, _, _ := strings.Cut(parts[1], "/")
+	key := group + ":" + parts[2]
+	_, ok := kubernetesWorkloadKinds[key]
+	return key, ok
+}
+
+// estimateKubernetesWorkloadCost prices a workload as replicas × requested resources at the
+// configured per-vCPU-hour and per-GiB-hour rates over a month of hours. It returns nil
+// for non-workload types.
+func estimateKubernetesWorkloadCost(resource ResourceDescriptor, hours float64) *CostResult {
+	key, ok := kubernetesWorkloadKey(resource.Type)
+	if !ok {
+		return nil
+	}
+	workload := readKubernetesWorkload(resource.Properties, key)
+	cpuRate, memRate := kubernetesRates()
+
+	replicas := float64(workload.replicas)
+	cpuHourly := workload.cpuCores * replicas * cpuRate
+	memHourly := workload.memoryGiB * replicas * memRate
+	hourly := cpuHourly + memHourly
+
+	notes := []string{fmt.Sprintf(
+		"Estimated from requests: %d replica(s) × %.3f vCPU, %.2f GiB at %g/vCPU-hour, %g/GiB-hour",
+		workload.replicas, workload.cpuCores, workload.memoryGiB, cpuRate, memRate)}
+	confidence := ConfidenceMedium
+	if workload.containers == 0 {
+		notes = append(notes, "no containers found in pod spec; estimate is unreliable")
+		confidence = ConfidenceLow
+	}
+	if workload.missingRequests > 0 {
+		notes = append(notes, fmt.Sprintf("%d of %d container(s) have no resource requests; estimate is unreliable",
+			workload.missingRequests, workload.containers))
+		confidence = ConfidenceLow
+	}
+	if workload.replicasAssumed {
+		notes = append(notes, "replica count unknown; priced as a single pod")
+		confidence = ConfidenceLow
+	}
+
+	return &CostResult{
+		ResourceType: resource.Type,
+		ResourceID:   resource.ID,
+		Adapter:      kubernetesAdapter,
+		Currency:     defaultCurrency,
+		Monthly:      hourly * hours,
+		Hourly:       hourly,
+		Notes:        strings.Join(notes, "; "),
+		Breakdown: map[string]float64{
+			"cpu":    cpuHourly * hours,
+			"memory": memHourly * hours,
+		},
+		Confidence: confidence,
+		Efficiency: newCostEfficiency(hourly*hours, workload.cpuCores*replicas, workload.memoryGiB*replicas),
+	}
+}
+
+// readKubernetesWorkload sums container requests from the pod spec (spec.containers for
+// Pods, spec.template.spec.containers otherwise) and reads spec.replicas.
+func readKubernetesWorkload(props map[string]interface{}, key string) kubernetesWorkload {
+	workload := kubernetesWorkload{replicas: 1}
+	spec, _ := props["spec"].(map[string]interface{})
+
+	podSpec := spec
+	if key != "core:Pod" {
+		template, _ := spec["template"].(map[string]interface{})
+		podSpec, _ = template["spec"].(map[string]interface{})
+	}
+
+	if kubernetesWorkloadKinds[key] {
+		if v, found := spec["replicas"]; found {
+			if n, isNum := parseFloatValue(v); isNum && n >= 0 {
+				workload.replicas = int(n)
+			}
+		}
+	} else if key != "core:Pod" {
+		workload.replicasAssumed = true
+	}
+
+	containers, _ := podSpec["containers"].([]interface{})
+	for _, c := range containers {
+		container, isMap := c.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		workload.containers++
+		resources, _ := container["resources"].(map[string]interface{})
+		requests, _ := resources["requests"].(map[string]interface{})
+
+		cpu, hasCPU := parseCPUQuantity(requests["cpu"])
+		mem, hasMem := parseMemoryQuantity(requests["memory"])
+		if !hasCPU && !hasMem {
+			workload.missingRequests++
+		}
+		workload.cpuCores += cpu
+		workload.memoryGiB += mem / bytesPerGiB
+	}
+	return workload
+}
+
+// kubernetesRates returns the configured per-vCPU-hour and per-GiB-hour rates.
+func kubernetesRates() (float64, float64) {
+	return rateFromEnv(envK8sCPUHourly, defaultK8sCPUHourly),
+		rateFromEnv(envK8sMemoryGBHourly, defaultK8sMemoryGBHourly)
+}
+
+// rateFromEnv returns the non-negative float in the named variable, or def.
+func rateFromEnv(name string, def float64) float64 {
+	if val := os.Getenv(name); val != "" {
+		if f, err := strconv.ParseFloat(val, 64); err == nil && f >= 0 {
+			return f
+		}
+	}
+	return def
+}
+
+// parseCPUQuantity converts a Kubernetes CPU quantity ("250m", "0.5", 2) to cores.
+func parseCPUQuantity(value interface{}) (float64, bool) {
+	if s, ok := value.(string); ok {
+		if milli, found := strings.CutSuffix(s, "m"); found {
+			f, err := strconv.ParseFloat(milli, 64)
+			if err != nil {
+				return 0, false
+			}
+			return f / milliUnits, true
+		}
+	}
+	if value == nil {
+		return 0, false
+	}
+	return parseFloatValue(value)
+}
+
+// parseMemoryQuantity converts a Kubernetes memory quantity ("512Mi", "1G", 1048576) to bytes.
+func parseMemoryQuantity(value interface{}) (float64, bool) {
+	s, ok := value.(string)
+	if !ok {
+		if value == nil {
+			return 0, false
+		}
+		return parseFloatValue(value)
+	}
+	for _, unit := range memoryQuantitySuffixes {
+		if number, found := strings.CutSuffix(s, unit.suffix); found {
+			f, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, false
+			}
+			return f * unit.multiplier, true
+		}
+	}
+	return parseFloatValue(s)
+}
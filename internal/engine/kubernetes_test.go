@@ -0,0 +1,114 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func k8sContainer(cpu, memory interface{}) map[string]interface{} {
+	requests := map[string]interface{}{}
+	if cpu != nil {
+		requests["cpu"] = cpu
+	}
+	if memory != nil {
+		requests["memory"] = memory
+	}
+	return map[string]interface{}{
+		"name":      "app",
+		"resources": map[string]interface{}{"requests": requests},
+	}
+}
+
+func k8sDeployment(replicas interface{}, containers ...interface{}) engine.ResourceDescriptor {
+	spec := map[string]interface{}{
+		"template": map[string]interface{}{
+			"spec": map[string]interface{}{"containers": containers},
+		},
+	}
+	if replicas != nil {
+		spec["replicas"] = replicas
+	}
+	return engine.ResourceDescriptor{
+		Type:       "kubernetes:apps/v1:Deployment",
+		ID:         "web",
+		Provider:   "kubernetes",
+		Properties: map[string]interface{}{"spec": spec},
+	}
+}
+
+func projectSingle(t *testing.T, resource engine.ResourceDescriptor) engine.CostResult {
+	t.Helper()
+	results, err := newTestEngine(t, nil, nil).GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	return results[0]
+}
+
+func TestKubernetesWorkloadCost_Deployment(t *testing.T) {
+	t.Setenv("FINFOCUS_K8S_CPU_HOURLY", "0.04")
+	t.Setenv("FINFOCUS_K8S_MEMORY_GB_HOURLY", "0.005")
+
+	result := projectSingle(t, k8sDeployment(3.0,
+		k8sContainer("500m", "1Gi"),
+		k8sContainer(0.25, "512Mi"),
+	))
+
+	// Per pod: 0.75 vCPU and 1.5 GiB; three replicas.
+	wantHourly := 3 * (0.75*0.04 + 1.5*0.005)
+	assert.Equal(t, "k8s-requests", result.Adapter)
+	assert.InDelta(t, wantHourly, result.Hourly, 1e-9)
+	assert.InDelta(t, wantHourly*730, result.Monthly, 1e-6)
+	assert.InDelta(t, 3*0.75*0.04*730, result.Breakdown["cpu"], 1e-6)
+	assert.Equal(t, engine.ConfidenceMedium, result.Confidence)
+	assert.Contains(t, result.Notes, "3 replica(s)")
+}
+
+func TestKubernetesWorkloadCost_UnversionedTypeAndDefaults(t *testing.T) {
+	resource := k8sDeployment(nil, k8sContainer("1", "1G"))
+	resource.Type = "kubernetes:apps:Deployment"
+
+	result := projectSingle(t, resource)
+
+	assert.Equal(t, "k8s-requests", result.Adapter)
+	assert.Contains(t, result.Notes, "1 replica(s)")
+	assert.Greater(t, result.Monthly, 0.0)
+}
+
+func TestKubernetesWorkloadCost_MissingRequests(t *testing.T) {
+	result := projectSingle(t, k8sDeployment(2.0,
+		k8sContainer("100m", "128Mi"),
+		map[string]interface{}{"name": "sidecar"},
+	))
+
+	assert.Equal(t, engine.ConfidenceLow, result.Confidence)
+	assert.Contains(t, result.Notes, "1 of 2 container(s) have no resource requests")
+}
+
+func TestKubernetesWorkloadCost_DaemonSetAndPod(t *testing.T) {
+	daemonSet := k8sDeployment(nil, k8sContainer("200m", "256Mi"))
+	daemonSet.Type = "kubernetes:apps/v1:DaemonSet"
+	ds := projectSingle(t, daemonSet)
+	assert.Equal(t, engine.ConfidenceLow, ds.Confidence)
+	assert.Contains(t, ds.Notes, "replica count unknown")
+
+	pod := engine.ResourceDescriptor{
+		Type: "kubernetes:core/v1:Pod",
+		ID:   "pod",
+		Properties: map[string]interface{}{
+			"spec": map[string]interface{}{"containers": []interface{}{k8sContainer("200m", "256Mi")}},
+		},
+	}
+	p := projectSingle(t, pod)
+	assert.Equal(t, "k8s-requests", p.Adapter)
+	assert.Equal(t, engine.ConfidenceMedium, p.Confidence)
+	assert.InDelta(t, ds.Monthly, p.Monthly, 1e-9)
+}
+
+func TestKubernetesWorkloadCost_NonWorkloadUnaffected(t *testing.T) {
+	result := projectSingle(t, engine.ResourceDescriptor{Type: "kubernetes:core/v1:ConfigMap", ID: "cfg"})
+	assert.Equal(t, "none", result.Adapter)
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OutputMarkdown renders results as a GitHub-flavored Markdown table, for pull request
+// comments.
+const OutputMarkdown OutputFormat = "markdown"
+
+// RenderMarkdown writes one table row per result, with the amount columns right-aligned,
+// followed by a bold line with the stack total. Results that carry actual costs get a
+// Total Cost and Period column; projected results get Monthly and Hourly columns. Totals
+// in different currencies are listed side by side rather than converted.
+func RenderMarkdown(writer io.Writer, results []CostResult) error {
+	return renderMarkdown(writer, results, nil)
+}
+
+// renderMarkdown writes results as RenderMarkdown does, noting the omitted results, when
+// there are any, after the table.
+func renderMarkdown(writer io.Writer, results []CostResult, omitted *OmittedResults) error {
+	aggregated := AggregateResults(results)
+	actual := hasActualCosts(results)
+
+	var b strings.Builder
+	if actual {
+		b.WriteString("| Resource | Type | Adapter | Total Cost | Period |\n")
+		b.WriteString("| --- | --- | --- | ---: | --- |\n")
+	} else {
+		b.WriteString("| Resource | Type | Adapter | Monthly | Hourly |\n")
+		b.WriteString("| --- | --- | --- | ---: | ---: |\n")
+	}
+	for _, r := range aggregated.Resources {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | ", escapeMarkdownCell(r.ResourceID),
+			escapeMarkdownCell(r.ResourceType), escapeMarkdownCell(r.Adapter))
+		if actual {
+			fmt.Fprintf(&b, "%s | %s |\n", formatMarkdownAmount(r.TotalCost, r.Currency, "%.2f"),
+				escapeMarkdownCell(formatPeriodDisplay(r)))
+		} else {
+			fmt.Fprintf(&b, "%s | %s |\n", formatMarkdownAmount(r.Monthly, r.Currency, "%.2f"),
+				formatMarkdownAmount(r.Hourly, r.Currency, "%.4f"))
+		}
+	}
+
+	b.WriteString("\n")
+	if omitted != nil {
+		fmt.Fprintf(&b, "%s\n\n", strings.ReplaceAll(omitted.String(), "$", `\$`))
+	}
+	b.WriteString(markdownTotalLine(aggregated.Summary, len(aggregated.Resources), actual))
+	b.WriteString("\n")
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+// hasActualCosts reports whether any result carries an actual cost rather than only a
+// projection.
+func hasActualCosts(results []CostResult) bool {
+	for _, r := range results {
+		if r.TotalCost > 0 || r.CostPeriod != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownTotalLine summarizes the total of resources results, per currency when they span
+// several.
+func markdownTotalLine(summary CostSummary, resources int, actual bool) string {
+	label, unit := "Total", "/month"
+	if actual {
+		label, unit = "Total cost", ""
+	}
+	var totals []string
+	if len(summary.ByCurrency) > 0 {
+		for _, currency := range SortedCurrencies(summary.ByCurrency) {
+			subtotal := summary.ByCurrency[currency]
+			amount := subtotal.TotalMonthly
+			if actual {
+				amount = subtotal.TotalCost
+			}
+			totals = append(totals, formatMarkdownAmount(amount, currency, "%.2f")+unit)
+		}
+	} else {
+		amount := summary.TotalMonthly
+		if actual {
+			amount = sumTotalCost(summary.Resources)
+		}
+		totals = append(totals, formatMarkdownAmount(amount, summary.Currency, "%.2f")+unit)
+	}
+	noun := "resources"
+	if resources == 1 {
+		noun = "resource"
+	}
+	return fmt.Sprintf("**%s: %s** across %d %s", label, strings.Join(totals, " + "), resources, noun)
+}
+
+// sumTotalCost adds up the actual costs of results.
+func sumTotalCost(results []CostResult) float64 {
+	var total float64
+	for _, r := range results {
+		total += r.TotalCost
+	}
+	return total
+}
+
+// formatMarkdownAmount formats amount with its currency symbol, or with its ISO code after
+// it when the currency has no symbol. A dollar sign is escaped so that GitHub does not
+// read two amounts on a line as inline math.
+func formatMarkdownAmount(amount float64, currency, numberFormat string) string {
+	value := fmt.Sprintf(numberFormat, amount)
+	symbol := getCurrencySymbol(currency)
+	if symbol == currency {
+		return strings.TrimSpace(value + " " + currency)
+	}
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", value[1:]
+	}
+	return sign + strings.ReplaceAll(symbol, "$", `\$`) + value
+}
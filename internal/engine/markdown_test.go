@@ -0,0 +1,79 @@
+package engine_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Adapter: "local-spec",
+			Currency: "USD", Monthly: 7.5, Hourly: 0.0103},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "a|b", Adapter: "local-spec",
+			Currency: "USD", Monthly: 0.5, Hourly: 0.0007},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderResults(&buf, engine.OutputMarkdown, results))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"| Resource | Type | Adapter | Monthly | Hourly |",
+		"| --- | --- | --- | ---: | ---: |",
+		"| `web` | aws:ec2/instance:Instance | local-spec | \\$7.50 | \\$0.0103 |",
+		"| `a\\|b` | aws:s3/bucket:Bucket | local-spec | \\$0.50 | \\$0.0007 |",
+		"",
+		"**Total: \\$8.00/month** across 2 resources",
+	}, lines)
+}
+
+func TestRenderMarkdown_Currencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []engine.CostResult
+		total   string
+		row     string
+	}{
+		{
+			name:    "symbol",
+			results: []engine.CostResult{{ResourceID: "vm", Currency: "EUR", Monthly: 12, Hourly: 0.0164}},
+			total:   "**Total: €12.00/month** across 1 resource",
+			row:     "| €12.00 | €0.0164 |",
+		},
+		{
+			name:    "code without symbol",
+			results: []engine.CostResult{{ResourceID: "vm", Currency: "SEK", Monthly: 100}},
+			total:   "**Total: 100.00 SEK/month** across 1 resource",
+			row:     "| 100.00 SEK | 0.0000 SEK |",
+		},
+		{
+			name: "mixed currencies",
+			results: []engine.CostResult{
+				{ResourceID: "a", Currency: "USD", Monthly: 5},
+				{ResourceID: "b", Currency: "GBP", Monthly: 3},
+			},
+			total: "**Total: £3.00/month + \\$5.00/month** across 2 resources",
+			row:   "| \\$5.00 |",
+		},
+		{
+			name: "actual costs",
+			results: []engine.CostResult{
+				{ResourceID: "a", Currency: "USD", TotalCost: 42.5, CostPeriod: "2025-01"},
+			},
+			total: "**Total cost: \\$42.50** across 1 resource",
+			row:   "| \\$42.50 | 2025-01 |",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, engine.RenderMarkdown(&buf, tt.results))
+			assert.Contains(t, buf.String(), tt.row)
+			assert.True(t, strings.HasSuffix(buf.String(), tt.total+"\n"), buf.String())
+		})
+	}
+}
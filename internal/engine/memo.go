@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// contextKeyPriceMemo carries the *priceMemo of the current run.
+const contextKeyPriceMemo ContextKey = "priceMemo"
+
+// priceMemo remembers the plugin prices of one run by plugin and resource fingerprint, so
+// identically configured resources, such as a hundred t3.micro instances in one region,
+// cost one plugin call each instead of one per resource. The fingerprint covers every
+// property, so resources that differ in size, count or any other input are priced on
+// their own. Actual costs are remembered by resource ID and time range, so a resource
+// listed twice is fetched once. Unlike PricingCache it lives only as long as the run and
+// needs no ETags. EngineOptions.DisableResponseCache turns it off.
+type priceMemo struct {
+	mu      sync.Mutex
+	entries map[string]*priceMemoEntry
+}
+
+// priceMemoEntry is one plugin call; done is closed once result and err are set.
+type priceMemoEntry struct {
+	done   chan struct{}
+	result *CostResult
+	err    error
+}
+
+// withPriceMemo attaches a new memo to ctx unless the run already has one.
+func withPriceMemo(ctx context.Context) context.Context {
+	if priceMemoFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKeyPriceMemo, &priceMemo{entries: make(map[string]*priceMemoEntry)})
+}
+
+// actualCostFingerprint keys the actual cost of resource from client over a time range.
+func actualCostFingerprint(client *pluginhost.Client, resource ResourceDescriptor, from, to time.Time) string {
+	return hashParts("actual", client.Name, resource.ID, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+}
+
+// priceMemo returns the run's memo of plugin responses, or nil when there is none or the
+// response cache is disabled.
+func (e *Engine) priceMemo(ctx context.Context) *priceMemo {
+	if e.options.DisableResponseCache {
+		return nil
+	}
+	return priceMemoFromContext(ctx)
+}
+
+func priceMemoFromContext(ctx context.Context) *priceMemo {
+	m, _ := ctx.Value(contextKeyPriceMemo).(*priceMemo)
+	return m
+}
+
+// price returns the memoized price of resource under key, calling price when nothing was
+// priced under key in this run. Concurrent callers
+// with the same fingerprint wait for the first call instead of repeating it. Failures
+// caused by a cancelled or timed-out call, locally or in the plugin, are not remembered,
+// since they say nothing about the resource.
+func (m *priceMemo) price(
+	ctx context.Context,
+	key string,
+	resource ResourceDescriptor,
+	price func() (*CostResult, error),
+) (*CostResult, error) {
+	for {
+		m.mu.Lock()
+		entry, found := m.entries[key]
+		if !found {
+			entry = &priceMemoEntry{done: make(chan struct{})}
+			m.entries[key] = entry
+		}
+		m.mu.Unlock()
+
+		if !found {
+			entry.result, entry.err = price()
+			if isContextError(entry.err) {
+				m.mu.Lock()
+				delete(m.entries, key)
+				m.mu.Unlock()
+			}
+			close(entry.done)
+			return memoizedResult(entry.result, resource), entry.err
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if !isContextError(entry.err) {
+			return memoizedResult(entry.result, resource), entry.err
+		}
+	}
+}
+
+// isContextError reports whether err comes from a cancelled or timed-out call, either a
+// Go context error or a gRPC Canceled or DeadlineExceeded status from the plugin.
+func isContextError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) { //nolint:exhaustive // Only cancellation codes matter here.
+	case codes.Canceled, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// memoizedResult copies a remembered price onto the resource being priced. Maps and
+// provenance are copied because later adjustments modify results in place.
+func memoizedResult(result *CostResult, resource ResourceDescriptor) *CostResult {
+	if result == nil {
+		return nil
+	}
+	copied := *result
+	copied.ResourceType = resource.Type
+	copied.ResourceID = resource.ID
+	copied.Breakdown = maps.Clone(result.Breakdown)
+	copied.Sustainability = maps.Clone(result.Sustainability)
+	copied.DailyCosts = slices.Clone(result.DailyCosts)
+	if result.Provenance != nil {
+		provenance := *result.Provenance
+		copied.Provenance = &provenance
+	}
+	return &copied
+}
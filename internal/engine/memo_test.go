@@ -0,0 +1,209 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sizePricedAPI prices resources at one dollar per GB of their size property and counts
+// projected cost calls.
+type sizePricedAPI struct {
+	proto.CostSourceClient
+
+	calls atomic.Int32
+}
+
+func (a *sizePricedAPI) GetProjectedCost(
+	_ context.Context,
+	req *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	a.calls.Add(1)
+	var size float64
+	if _, err := fmt.Sscan(req.Resources[0].Properties["size"], &size); err != nil {
+		size = 1
+	}
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{
+			Currency:      "USD",
+			MonthlyCost:   size,
+			CostBreakdown: map[string]float64{"storage": size},
+		}},
+	}, nil
+}
+
+func TestGetProjectedCost_MemoizesIdenticalResources(t *testing.T) {
+	const identical = 100
+	resources := make([]engine.ResourceDescriptor, 0, identical+2)
+	for i := range identical {
+		resources = append(resources, engine.ResourceDescriptor{
+			Type:       "aws:ebs/volume:Volume",
+			ID:         fmt.Sprintf("data-%d", i),
+			Provider:   "aws",
+			Properties: map[string]interface{}{"size": 10, "region": "us-east-1"},
+		})
+	}
+	resources = append(resources,
+		engine.ResourceDescriptor{
+			Type: "aws:ebs/volume:Volume", ID: "large", Provider: "aws",
+			Properties: map[string]interface{}{"size": 500, "region": "us-east-1"},
+		},
+		engine.ResourceDescriptor{
+			Type: "aws:ebs/volume:Volume", ID: "west", Provider: "aws",
+			Properties: map[string]interface{}{"size": 10, "region": "us-west-2"},
+		},
+	)
+
+	for _, withErrors := range []bool{false, true} {
+		api := &sizePricedAPI{}
+		eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-public", API: api}}, nil)
+		var results []engine.CostResult
+		if withErrors {
+			res, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+			require.NoError(t, err)
+			results = res.Results
+		} else {
+			var err error
+			results, err = eng.GetProjectedCost(context.Background(), resources)
+			require.NoError(t, err)
+		}
+
+		// One call per distinct configuration; sizes and regions that differ are priced apart.
+		assert.Equal(t, int32(3), api.calls.Load())
+		require.Len(t, results, len(resources))
+		byID := make(map[string]engine.CostResult, len(results))
+		for _, r := range results {
+			byID[r.ResourceID] = r
+		}
+		assert.InDelta(t, 10.0, byID["data-0"].Monthly, 0.0001)
+		assert.InDelta(t, 10.0, byID["data-99"].Monthly, 0.0001)
+		assert.InDelta(t, 500.0, byID["large"].Monthly, 0.0001)
+		assert.InDelta(t, 10.0, byID["west"].Monthly, 0.0001)
+
+		// Memoized results do not share maps, so adjusting one leaves the others intact.
+		byID["data-0"].Breakdown["storage"] = 0
+		assert.InDelta(t, 10.0, byID["data-1"].Breakdown["storage"], 0.0001)
+	}
+}
+
+func TestGetProjectedCost_MemoIsPerRun(t *testing.T) {
+	api := &sizePricedAPI{}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-public", API: api}}, nil)
+	resources := []engine.ResourceDescriptor{{
+		Type: "aws:ebs/volume:Volume", ID: "data", Provider: "aws",
+		Properties: map[string]interface{}{"size": 10},
+	}}
+
+	for range 2 {
+		_, err := eng.GetProjectedCost(context.Background(), resources)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), api.calls.Load())
+}
+
+func TestGetProjectedCost_DisableResponseCache(t *testing.T) {
+	api := &sizePricedAPI{}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-public", API: api}}, nil,
+		engine.EngineOptions{DisableResponseCache: true})
+	resources := []engine.ResourceDescriptor{
+		{Type: "aws:ebs/volume:Volume", ID: "a", Provider: "aws", Properties: map[string]interface{}{"size": 10}},
+		{Type: "aws:ebs/volume:Volume", ID: "b", Provider: "aws", Properties: map[string]interface{}{"size": 10}},
+	}
+
+	_, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), api.calls.Load())
+}
+
+// deadlineOnceAPI fails its first projected cost call with a gRPC DeadlineExceeded status,
+// as a plugin does when its own billing API call times out, then prices like
+// sizePricedAPI.
+type deadlineOnceAPI struct {
+	sizePricedAPI
+}
+
+func (a *deadlineOnceAPI) GetProjectedCost(
+	ctx context.Context,
+	req *proto.GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	if a.calls.Load() == 0 {
+		a.calls.Add(1)
+		return nil, status.Error(codes.DeadlineExceeded, "pricing API timed out")
+	}
+	return a.sizePricedAPI.GetProjectedCost(ctx, req, opts...)
+}
+
+func TestGetProjectedCost_MemoForgetsGRPCDeadlines(t *testing.T) {
+	api := &deadlineOnceAPI{}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-public", API: api}}, nil,
+		engine.EngineOptions{Workers: 1, RetryAttempts: 1})
+	resources := []engine.ResourceDescriptor{
+		{Type: "aws:ebs/volume:Volume", ID: "a", Provider: "aws", Properties: map[string]interface{}{"size": 10}},
+		{Type: "aws:ebs/volume:Volume", ID: "b", Provider: "aws", Properties: map[string]interface{}{"size": 10}},
+	}
+
+	results, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int32(2), api.calls.Load(), "a timed-out call is not remembered")
+	assert.Equal(t, "none", results[0].Adapter)
+	assert.InDelta(t, 10.0, results[1].Monthly, 1e-9)
+}
+
+// actualCountingAPI returns a fixed actual cost and counts actual cost calls.
+type actualCountingAPI struct {
+	proto.CostSourceClient
+
+	calls atomic.Int32
+}
+
+func (a *actualCountingAPI) GetActualCost(
+	_ context.Context,
+	_ *proto.GetActualCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	a.calls.Add(1)
+	return &proto.GetActualCostResponse{
+		Results: []*proto.ActualCostResult{{Currency: "USD", TotalCost: 30}},
+	}, nil
+}
+
+func TestGetActualCost_MemoizesRepeatedResources(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+	resources := []engine.ResourceDescriptor{
+		{Type: "aws:ec2/instance:Instance", ID: "i-1"},
+		{Type: "aws:ec2/instance:Instance", ID: "i-1"},
+		{Type: "aws:ec2/instance:Instance", ID: "i-2"},
+	}
+
+	for _, disabled := range []bool{false, true} {
+		api := &actualCountingAPI{}
+		eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-ce", API: api}}, nil,
+			engine.EngineOptions{DisableResponseCache: disabled})
+		results, err := eng.GetActualCost(context.Background(), resources, from, to)
+		require.NoError(t, err)
+		require.Len(t, results, len(resources))
+		if disabled {
+			assert.Equal(t, int32(3), api.calls.Load())
+		} else {
+			assert.Equal(t, int32(2), api.calls.Load(), "a resource listed twice is fetched once")
+		}
+		for _, r := range results {
+			assert.InDelta(t, 30.0, r.TotalCost, 0.0001)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const mibPerGiB = 1024
+
+// CostEfficiency expresses a resource's monthly cost per unit of compute capacity.
+// PerVCPU or PerGB is zero when the corresponding size is unknown.
+type CostEfficiency struct {
+	VCPUs    float64 `json:"vcpus,omitempty"`
+	MemoryGB float64 `json:"memoryGb,omitempty"`
+	PerVCPU  float64 `json:"costPerVcpuMonth,omitempty"`
+	PerGB    float64 `json:"costPerGbMonth,omitempty"`
+}
+
+// Property names that carry a resource's size, checked in order.
+var (
+	vcpuPropertyKeys      = []string{"vcpus", "vcpu", "vCpus", "cpus", "cpuCount", "cpuCores", "cores"}
+	memoryGBPropertyKeys  = []string{"memoryGb", "memoryGB", "memoryGiB", "memorySizeGb", "ramGb"}
+	memoryMiBPropertyKeys = []string{"memoryMb", "memoryMB", "memoryMiB", "memorySize"}
+)
+
+// newCostEfficiency divides monthly by the given sizes. It returns nil when neither size is
+// known or there is no cost to normalize.
+func newCostEfficiency(monthly, vcpus, memoryGB float64) *CostEfficiency {
+	if monthly <= 0 || (vcpus <= 0 && memoryGB <= 0) {
+		return nil
+	}
+	eff := &CostEfficiency{}
+	if vcpus > 0 {
+		eff.VCPUs = vcpus
+		eff.PerVCPU = monthly / vcpus
+	}
+	if memoryGB > 0 {
+		eff.MemoryGB = memoryGB
+		eff.PerGB = monthly / memoryGB
+	}
+	return eff
+}
+
+// normalizeResults sets Efficiency on results that lack it, using the vCPU and memory
+// properties of the resource they were priced from.
+func normalizeResults(results []CostResult, props map[string]interface{}) {
+	vcpus := firstNumericProperty(props, vcpuPropertyKeys)
+	memoryGB := resourceMemoryGB(props)
+	for i := range results {
+		if results[i].Efficiency == nil {
+			results[i].Efficiency = newCostEfficiency(results[i].Monthly, vcpus, memoryGB)
+		}
+	}
+}
+
+// resourceMemoryGB reads memory from a GiB property, a MiB property, or a Kubernetes-style
+// "memory" quantity such as "16Gi".
+func resourceMemoryGB(props map[string]interface{}) float64 {
+	if gb := firstNumericProperty(props, memoryGBPropertyKeys); gb > 0 {
+		return gb
+	}
+	if mib := firstNumericProperty(props, memoryMiBPropertyKeys); mib > 0 {
+		return mib / mibPerGiB
+	}
+	if quantity, ok := props["memory"].(string); ok {
+		if bytes, parsed := parseMemoryQuantity(quantity); parsed && bytes > 0 {
+			return bytes / bytesPerGiB
+		}
+	}
+	return 0
+}
+
+func firstNumericProperty(props map[string]interface{}, keys []string) float64 {
+	for _, key := range keys {
+		if v, ok := props[key]; ok {
+			if f, isNum := parseFloatValue(v); isNum && f > 0 {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+// SortByEfficiency returns a copy of results ordered from least to most cost-efficient:
+// highest cost per vCPU first, then highest cost per GB. Results without efficiency data
+// keep their relative order at the end.
+func SortByEfficiency(results []CostResult) []CostResult {
+	sorted := make([]CostResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Efficiency, sorted[j].Efficiency
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case a.PerVCPU != b.PerVCPU:
+			return a.PerVCPU > b.PerVCPU
+		default:
+			return a.PerGB > b.PerGB
+		}
+	})
+	return sorted
+}
+
+// renderEfficiency writes the COST EFFICIENCY section used by --normalize: normalized
+// resources sorted by SortByEfficiency, followed by a count of those that were skipped.
+func renderEfficiency(w io.Writer, results []CostResult) {
+	fmt.Fprintf(w, "COST EFFICIENCY\n")
+	fmt.Fprintf(w, "===============\n")
+	fmt.Fprintln(w, "Resource\tvCPUs\tMemory (GB)\tPer vCPU/month\tPer GB/month\tCurrency")
+	fmt.Fprintln(w, "--------\t-----\t-----------\t--------------\t------------\t--------")
+
+	skipped := 0
+	for _, result := range SortByEfficiency(results) {
+		eff := result.Efficiency
+		if eff == nil {
+			skipped++
+			continue
+		}
+		resource := fmt.Sprintf("%s/%s", result.ResourceType, result.ResourceID)
+		if len(resource) > maxResourceDisplayLen {
+			resource = resource[:maxResourceDisplayLen-len(truncationEllipsis)] + truncationEllipsis
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			resource,
+			formatEfficiencyValue(eff.VCPUs, "%g"),
+			formatEfficiencyValue(eff.MemoryGB, "%g"),
+			formatEfficiencyValue(eff.PerVCPU, "%.2f"),
+			formatEfficiencyValue(eff.PerGB, "%.2f"),
+			result.Currency,
+		)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(w, "%d resource(s) not normalized: no vCPU or memory data available\n", skipped)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func formatEfficiencyValue(v float64, format string) string {
+	if v <= 0 {
+		return "-"
+	}
+	return strings.TrimSpace(fmt.Sprintf(format, v))
+}
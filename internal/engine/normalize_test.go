@@ -0,0 +1,98 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize_FromResourceProperties(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
+		{
+			Type: "aws:ec2/instance:Instance", ID: "sized", Provider: "aws",
+			Properties: map[string]interface{}{"instanceType": "t3.micro", "vcpus": 2.0, "memoryMiB": 1024.0},
+		},
+		{
+			Type: "aws:ec2/instance:Instance", ID: "unsized", Provider: "aws",
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	sized := results[0]
+	require.NotNil(t, sized.Efficiency)
+	assert.InDelta(t, 2.0, sized.Efficiency.VCPUs, 0.0001)
+	assert.InDelta(t, 1.0, sized.Efficiency.MemoryGB, 0.0001)
+	assert.InDelta(t, sized.Monthly/2, sized.Efficiency.PerVCPU, 0.0001)
+	assert.InDelta(t, sized.Monthly, sized.Efficiency.PerGB, 0.0001)
+
+	assert.Nil(t, results[1].Efficiency, "resources without size data are not normalized")
+}
+
+func TestNormalize_KubernetesWorkload(t *testing.T) {
+	results, err := newTestEngine(t, nil, nil).GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type: "kubernetes:apps/v1:Deployment", ID: "api", Provider: "kubernetes",
+		Properties: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": 2.0,
+				"template": map[string]interface{}{"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{
+						"resources": map[string]interface{}{"requests": map[string]interface{}{
+							"cpu": "500m", "memory": "1Gi",
+						}},
+					}},
+				}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NotNil(t, results[0].Efficiency)
+	assert.InDelta(t, 1.0, results[0].Efficiency.VCPUs, 0.0001)
+	assert.InDelta(t, 2.0, results[0].Efficiency.MemoryGB, 0.0001)
+}
+
+func TestSortByEfficiency(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "none"},
+		{ResourceID: "cheap", Efficiency: &engine.CostEfficiency{PerVCPU: 10}},
+		{ResourceID: "memory-only", Efficiency: &engine.CostEfficiency{PerGB: 50}},
+		{ResourceID: "pricey", Efficiency: &engine.CostEfficiency{PerVCPU: 40}},
+	}
+
+	sorted := engine.SortByEfficiency(results)
+	ids := make([]string, len(sorted))
+	for i, r := range sorted {
+		ids[i] = r.ResourceID
+	}
+	assert.Equal(t, []string{"pricey", "cheap", "memory-only", "none"}, ids)
+	assert.Equal(t, "none", results[0].ResourceID, "input must not be reordered")
+}
+
+func TestRenderResultsWithOptions_Normalize(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "small", Monthly: 10, Currency: "USD",
+			Efficiency: &engine.CostEfficiency{VCPUs: 2, PerVCPU: 5}},
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "large", Monthly: 80, Currency: "USD",
+			Efficiency: &engine.CostEfficiency{VCPUs: 8, PerVCPU: 10}},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "bucket", Monthly: 1, Currency: "USD"},
+	}
+
+	var buf bytes.Buffer
+	err := engine.RenderResultsWithOptions(&buf, engine.OutputTable, results, engine.RenderOptions{Normalize: true})
+	require.NoError(t, err)
+
+	out := buf.String()
+	require.Contains(t, out, "COST EFFICIENCY")
+	assert.Contains(t, out, "1 resource(s) not normalized")
+	assert.Less(t, strings.Index(out, "/large"), strings.Index(out, "/small"),
+		"least efficient resource should be listed first")
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrInvalidHoursPerMonth is returned for an hours-per-month setting that is not a positive
+// number.
+var ErrInvalidHoursPerMonth = errors.New("hours per month must be a positive number")
+
+// ErrInvalidEngineOption is returned for any other EngineOptions setting that is out of
+// range.
+var ErrInvalidEngineOption = errors.New("invalid engine option")
+
+// EngineOptions holds the settings of an Engine and is passed to New, which validates it.
+// The zero value keeps the defaults. Data the engine prices with, such as cost rules,
+// commitment reports and caches, is attached with the With* methods instead.
+type EngineOptions struct {
+	// Workers is how many resources are priced at once. Zero uses the number of CPUs
+	// times FINFOCUS_CONCURRENCY_MULTIPLIER.
+	Workers int
+	// ActualCostWorkers is how many resources are fetched at once for actual costs, which
+	// billing APIs often throttle harder than pricing APIs. Zero uses Workers.
+	ActualCostWorkers int
+	// ActualCostRateLimit caps actual-cost plugin calls per second across all workers.
+	// Zero is unlimited.
+	ActualCostRateLimit float64
+	// PluginConcurrency is how many plugins are asked to price one resource at once, so a
+	// slow plugin does not hold up the others. Zero asks all of them at once and 1 asks
+	// them one after another. MaxConcurrentPluginCalls still caps calls across all
+	// resources.
+	PluginConcurrency int
+	// MaxConcurrentPluginCalls caps the plugin RPCs in flight at once across all plugins.
+	// Zero removes the cap.
+	MaxConcurrentPluginCalls int
+	// PerResourceTimeout is how long each resource's calculation may take. Zero uses 5s.
+	PerResourceTimeout time.Duration
+	// DisableResponseCache asks plugins again for every resource instead of reusing the
+	// response for an identically configured resource priced earlier in the same run.
+	DisableResponseCache bool
+	// HoursPerMonth converts hourly spec rates to monthly costs and back. Zero uses 730;
+	// a spec's own hoursPerMonth takes precedence.
+	HoursPerMonth float64
+	// RetryAttempts is how many times a plugin call failing with a transient gRPC code,
+	// Unavailable or DeadlineExceeded, is attempted in all. Zero uses 3 and 1 disables
+	// retries; other codes always fail fast.
+	RetryAttempts int
+	// RetryBaseDelay is the wait before the first retry, doubling for each later one up
+	// to 2s. Zero uses 100ms.
+	RetryBaseDelay time.Duration
+	// ZeroCostTypes lists resource types that, like internal Pulumi types, never incur
+	// cloud cost and are reported as $0 without asking plugins. An entry ending in "*"
+	// matches by prefix (e.g. "my-components:*"); otherwise the type must match exactly.
+	ZeroCostTypes []string
+	// ValidatePlugins health-checks every plugin before any resource is priced. Plugins
+	// that report they are not ready are left out of the run and reported once, rather
+	// than failing for every resource.
+	ValidatePlugins bool
+	// VerifyTotals checks, after grouping and aggregation, that grouped results and
+	// summary breakdowns add up to the raw per-resource costs, failing the run with
+	// ErrTotalsMismatch when they do not.
+	VerifyTotals bool
+	// PricingProvenance records PricingProvenance on plugin and spec results.
+	PricingProvenance bool
+	// DefaultResourceNotes explains the cost of provider default resources, such as the
+	// default VPC, and of resources placed in them. Default resources that no plugin or
+	// spec prices are reported as free rather than unpriced.
+	DefaultResourceNotes bool
+}
+
+// Validate reports options that cannot be used, such as a negative HoursPerMonth or
+// worker count.
+func (o EngineOptions) Validate() error {
+	if o.HoursPerMonth < 0 || math.IsNaN(o.HoursPerMonth) || math.IsInf(o.HoursPerMonth, 0) {
+		return fmt.Errorf("%w: %v", ErrInvalidHoursPerMonth, o.HoursPerMonth)
+	}
+	counts := []struct {
+		name  string
+		value int
+	}{
+		{"workers", o.Workers},
+		{"actual cost workers", o.ActualCostWorkers},
+		{"plugin concurrency", o.PluginConcurrency},
+		{"max concurrent plugin calls", o.MaxConcurrentPluginCalls},
+		{"retry attempts", o.RetryAttempts},
+	}
+	for _, c := range counts {
+		if c.value < 0 {
+			return fmt.Errorf("%w: %s must not be negative: %d", ErrInvalidEngineOption, c.name, c.value)
+		}
+	}
+	if o.ActualCostRateLimit < 0 || math.IsNaN(o.ActualCostRateLimit) || math.IsInf(o.ActualCostRateLimit, 0) {
+		return fmt.Errorf("%w: actual cost rate limit must be a non-negative number: %v", ErrInvalidEngineOption,
+			o.ActualCostRateLimit)
+	}
+	if o.PerResourceTimeout < 0 {
+		return fmt.Errorf("%w: per-resource timeout must not be negative: %s", ErrInvalidEngineOption,
+			o.PerResourceTimeout)
+	}
+	if o.RetryBaseDelay < 0 {
+		return fmt.Errorf("%w: retry base delay must not be negative: %s", ErrInvalidEngineOption, o.RetryBaseDelay)
+	}
+	return nil
+}
+
+// hoursPerMonth returns the hours in a month used to price spec: the spec's own setting,
+// else the engine's, else hoursPerMonth. A nil spec, for costs not read from a spec,
+// uses the engine's setting.
+func (e *Engine) hoursPerMonth(spec *PricingSpec) float64 {
+	var specHours float64
+	if spec != nil {
+		specHours = spec.HoursPerMonth
+	}
+	for _, hours := range []float64{specHours, e.options.HoursPerMonth} {
+		if hours > 0 && !math.IsInf(hours, 0) {
+			return hours
+		}
+	}
+	return hoursPerMonth
+}
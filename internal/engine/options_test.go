@@ -0,0 +1,116 @@
+package engine_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProjectedCost_HoursPerMonth(t *testing.T) {
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+		"aws-ec2-t3.small.yaml": "provider: aws\nservice: ec2\nsku: t3.small\ncurrency: USD\nhoursPerMonth: 720\n" +
+			"pricing:\n  onDemandHourly: 0.02\n",
+		"aws-ebs-gp3.yaml": "provider: aws\nservice: ebs\nsku: gp3\ncurrency: USD\n" +
+			"pricing:\n  pricePerGBMonth: 0.08\n",
+	})
+	resources := []engine.ResourceDescriptor{
+		ec2Instance("micro", "t3.micro"),
+		ec2Instance("small", "t3.small"),
+		{
+			Type: "aws:ebs/volume:Volume", ID: "data", Provider: "aws",
+			Properties: map[string]interface{}{"type": "gp3", "size": 100},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		opts        engine.EngineOptions
+		microMonth  float64
+		smallMonth  float64
+		volumeHours float64
+	}{
+		{name: "default", microMonth: 7.3, smallMonth: 14.4, volumeHours: 730},
+		{
+			name:        "engine option",
+			opts:        engine.EngineOptions{HoursPerMonth: 730.485},
+			microMonth:  7.30485,
+			smallMonth:  14.4, // the spec's own setting wins
+			volumeHours: 730.485,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := newTestEngine(t, nil, loader, tt.opts).GetProjectedCost(context.Background(), resources)
+			require.NoError(t, err)
+			byID := map[string]engine.CostResult{}
+			for _, r := range results {
+				byID[r.ResourceID] = r
+			}
+			assert.InDelta(t, tt.microMonth, byID["micro"].Monthly, 0.00001)
+			assert.InDelta(t, 0.01, byID["micro"].Hourly, 0.00001)
+			assert.InDelta(t, tt.smallMonth, byID["small"].Monthly, 0.00001)
+			assert.InDelta(t, 8.0, byID["data"].Monthly, 0.00001)
+			assert.InDelta(t, 8.0/tt.volumeHours, byID["data"].Hourly, 0.0000001)
+		})
+	}
+}
+
+func TestGetProjectedCost_HoursPerMonthWithoutSpec(t *testing.T) {
+	t.Setenv("FINFOCUS_K8S_CPU_HOURLY", "0.04")
+	t.Setenv("FINFOCUS_K8S_MEMORY_GB_HOURLY", "0.005")
+	contract := 36.0
+	rules, err := engine.NewCostRules([]engine.CostRule{
+		{Name: "contract", Match: engine.CostRuleMatch{Type: "aws:rds/*"}, Monthly: &contract},
+	})
+	require.NoError(t, err)
+	resources := []engine.ResourceDescriptor{
+		{Type: "aws:rds/instance:Instance", ID: "db", Provider: "aws"},
+		k8sDeployment(1.0, k8sContainer(1.0, "2Gi")),
+	}
+
+	eng := newTestEngine(t, nil, nil, engine.EngineOptions{HoursPerMonth: 720}).WithCostRules(rules)
+	results, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	byID := map[string]engine.CostResult{}
+	for _, r := range results {
+		byID[r.ResourceID] = r
+	}
+
+	assert.InDelta(t, 36.0, byID["db"].Monthly, 1e-9)
+	assert.InDelta(t, 36.0/720, byID["db"].Hourly, 1e-9)
+	wantHourly := 0.04 + 2*0.005
+	assert.InDelta(t, wantHourly, byID["web"].Hourly, 1e-9)
+	assert.InDelta(t, wantHourly*720, byID["web"].Monthly, 1e-9)
+	assert.InDelta(t, 0.04*720, byID["web"].Breakdown["cpu"], 1e-9)
+}
+
+func TestEngineOptions_Validate(t *testing.T) {
+	require.NoError(t, engine.EngineOptions{}.Validate())
+	require.NoError(t, engine.EngineOptions{HoursPerMonth: 720}.Validate())
+	for _, hours := range []float64{-1, math.NaN(), math.Inf(1)} {
+		assert.ErrorIs(t, engine.EngineOptions{HoursPerMonth: hours}.Validate(), engine.ErrInvalidHoursPerMonth)
+	}
+	for _, opts := range []engine.EngineOptions{
+		{Workers: -1},
+		{PluginConcurrency: -1},
+		{MaxConcurrentPluginCalls: -1},
+		{RetryAttempts: -1},
+		{RetryBaseDelay: -time.Millisecond},
+		{PerResourceTimeout: -time.Second},
+	} {
+		assert.ErrorIs(t, opts.Validate(), engine.ErrInvalidEngineOption, "%+v", opts)
+	}
+}
+
+func TestNew_RejectsInvalidOptions(t *testing.T) {
+	eng, err := engine.New(nil, nil, engine.EngineOptions{HoursPerMonth: -730})
+	require.ErrorIs(t, err, engine.ErrInvalidHoursPerMonth)
+	assert.Nil(t, eng)
+}
@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// PluginDiffAll selects every resource priced by more than one plugin in ExplainPluginDiff.
+const PluginDiffAll = "all"
+
+// urnNameSeparator precedes the resource name at the end of a Pulumi URN.
+const urnNameSeparator = "::"
+
+// PluginDiffComponent is one cost breakdown component as priced by each plugin.
+type PluginDiffComponent struct {
+	Name string `json:"name"`
+	// Costs holds the monthly cost of the component by plugin; plugins that do not
+	// break it out are absent.
+	Costs map[string]float64 `json:"costs"`
+	// Differs is set when the component is missing from some plugins or their costs differ.
+	Differs bool `json:"differs"`
+}
+
+// PluginDiff sets the breakdowns of plugins that priced the same resource side by side.
+type PluginDiff struct {
+	ResourceType string   `json:"resourceType"`
+	ResourceID   string   `json:"resourceId"`
+	Adapters     []string `json:"adapters"`
+	// Monthly and Currency hold each plugin's total.
+	Monthly    map[string]float64    `json:"monthly"`
+	Currency   map[string]string     `json:"currency"`
+	Components []PluginDiffComponent `json:"components"`
+	// SharedComponents is false when no component is broken out by every plugin, so only
+	// the totals can be compared.
+	SharedComponents bool `json:"sharedComponents"`
+}
+
+// ExplainPluginDiff aligns the breakdowns of resources priced by more than one plugin.
+// resource selects a resource by ID or by the name at the end of its URN; PluginDiffAll
+// selects every such resource.
+func ExplainPluginDiff(results []CostResult, resource string) []PluginDiff {
+	var order []string
+	byResource := make(map[string][]CostResult)
+	for _, r := range results {
+		if resource != PluginDiffAll && !matchesResourceRef(r.ResourceID, resource) {
+			continue
+		}
+		key := r.ResourceType + "/" + r.ResourceID
+		if _, seen := byResource[key]; !seen {
+			order = append(order, key)
+		}
+		byResource[key] = append(byResource[key], r)
+	}
+
+	var diffs []PluginDiff
+	for _, key := range order {
+		if group := byResource[key]; len(group) > 1 {
+			diffs = append(diffs, buildPluginDiff(group))
+		}
+	}
+	return diffs
+}
+
+// matchesResourceRef reports whether id is ref or a URN ending in the resource name ref.
+func matchesResourceRef(id, ref string) bool {
+	return id == ref || strings.HasSuffix(id, urnNameSeparator+ref)
+}
+
+func buildPluginDiff(group []CostResult) PluginDiff {
+	diff := PluginDiff{
+		ResourceType: group[0].ResourceType,
+		ResourceID:   group[0].ResourceID,
+		Monthly:      make(map[string]float64, len(group)),
+		Currency:     make(map[string]string, len(group)),
+	}
+	components := make(map[string]map[string]float64)
+	for _, r := range group {
+		diff.Adapters = append(diff.Adapters, r.Adapter)
+		diff.Monthly[r.Adapter] = r.Monthly
+		diff.Currency[r.Adapter] = r.Currency
+		for name, cost := range r.Breakdown {
+			if components[name] == nil {
+				components[name] = make(map[string]float64, len(group))
+			}
+			components[name][r.Adapter] = cost
+		}
+	}
+
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		costs := components[name]
+		low, high := costRange(costs)
+		differs := len(costs) < len(group) || high-low > costEpsilon
+		diff.SharedComponents = diff.SharedComponents || len(costs) == len(group)
+		diff.Components = append(diff.Components, PluginDiffComponent{Name: name, Costs: costs, Differs: differs})
+	}
+	return diff
+}
+
+func costRange(costs map[string]float64) (float64, float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, cost := range costs {
+		low, high = math.Min(low, cost), math.Max(high, cost)
+	}
+	return low, high
+}
+
+// RenderPluginDiff writes a table per resource with a column per plugin and a row per
+// breakdown component. The last column says how differing components differ: the plugins
+// that alone price a component, or the ratio of the highest to the lowest cost.
+func RenderPluginDiff(writer io.Writer, diffs []PluginDiff) error {
+	if len(diffs) == 0 {
+		fmt.Fprintln(writer, "No resource was priced by more than one plugin.")
+		return nil
+	}
+	for i, diff := range diffs {
+		if i > 0 {
+			fmt.Fprintln(writer)
+		}
+		fmt.Fprintf(writer, "Plugin pricing for %s/%s:\n", diff.ResourceType, diff.ResourceID)
+		w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+		fmt.Fprintf(w, "Component\t%s\tDifference\n", strings.Join(diff.Adapters, "\t"))
+		fmt.Fprintf(w, "---------\t%s\t----------\n", strings.Join(underlines(diff.Adapters), "\t"))
+		for _, c := range diff.Components {
+			cells := make([]string, 0, len(diff.Adapters))
+			var pricedBy []string
+			for _, adapter := range diff.Adapters {
+				cost, ok := c.Costs[adapter]
+				if !ok {
+					cells = append(cells, "-")
+					continue
+				}
+				cells = append(cells, fmt.Sprintf("%.2f", cost))
+				pricedBy = append(pricedBy, adapter)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n",
+				c.Name, strings.Join(cells, "\t"), componentDifference(c, pricedBy, diff.Adapters))
+		}
+		totals := make([]string, 0, len(diff.Adapters))
+		for _, adapter := range diff.Adapters {
+			totals = append(totals, fmt.Sprintf("%.2f %s", diff.Monthly[adapter], diff.Currency[adapter]))
+		}
+		low, high := costRange(diff.Monthly)
+		fmt.Fprintf(w, "Total\t%s\t%s\n", strings.Join(totals, "\t"), ratioLabel(low, high))
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if !diff.SharedComponents {
+			fmt.Fprintln(writer, "The plugins share no breakdown components; only their totals are comparable.")
+		}
+	}
+	return nil
+}
+
+// componentDifference describes how a component differs across plugins, or "" when it
+// does not.
+func componentDifference(c PluginDiffComponent, pricedBy, adapters []string) string {
+	if !c.Differs {
+		return ""
+	}
+	if len(pricedBy) < len(adapters) {
+		return "only " + strings.Join(pricedBy, ", ")
+	}
+	low, high := costRange(c.Costs)
+	return ratioLabel(low, high)
+}
+
+// ratioLabel formats high/low as "x1.10", or "" when the costs agree.
+func ratioLabel(low, high float64) string {
+	switch {
+	case high-low <= costEpsilon:
+		return ""
+	case low <= 0:
+		return "differs"
+	default:
+		return fmt.Sprintf("x%.2f", high/low)
+	}
+}
+
+func underlines(names []string) []string {
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = strings.Repeat("-", len(name))
+	}
+	return out
+}
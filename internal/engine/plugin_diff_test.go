@@ -0,0 +1,76 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webURN = "urn:pulumi:dev::app::aws:ec2/instance:Instance::web"
+
+func pluginDiffResults() []engine.CostResult {
+	return []engine.CostResult{
+		{
+			ResourceType: ec2InstanceType, ResourceID: webURN, Adapter: "aws-public", Currency: "USD", Monthly: 10.3,
+			Breakdown: map[string]float64{"compute": 7.3, "ebs": 3.0},
+		},
+		{
+			ResourceType: ec2InstanceType, ResourceID: webURN, Adapter: "vantage", Currency: "USD", Monthly: 8.03,
+			Breakdown: map[string]float64{"compute": 8.03},
+		},
+		{
+			ResourceType: "aws:s3/bucket:Bucket", ResourceID: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+			Adapter: "aws-public", Currency: "USD", Monthly: 1,
+		},
+		{
+			ResourceType: "aws:rds/instance:Instance", ResourceID: "urn:pulumi:dev::app::aws:rds/instance:Instance::db",
+			Adapter: "aws-public", Currency: "USD", Monthly: 50, Breakdown: map[string]float64{"instance": 50},
+		},
+		{
+			ResourceType: "aws:rds/instance:Instance", ResourceID: "urn:pulumi:dev::app::aws:rds/instance:Instance::db",
+			Adapter: "vantage", Currency: "USD", Monthly: 50, Breakdown: map[string]float64{"compute": 40, "storage": 10},
+		},
+	}
+}
+
+func TestExplainPluginDiff(t *testing.T) {
+	diffs := engine.ExplainPluginDiff(pluginDiffResults(), "web")
+	require.Len(t, diffs, 1, "resources are selected by URN name")
+	diff := diffs[0]
+	assert.Equal(t, webURN, diff.ResourceID)
+	assert.Equal(t, []string{"aws-public", "vantage"}, diff.Adapters)
+	assert.True(t, diff.SharedComponents)
+	require.Len(t, diff.Components, 2)
+	assert.Equal(t, engine.PluginDiffComponent{
+		Name: "compute", Costs: map[string]float64{"aws-public": 7.3, "vantage": 8.03}, Differs: true,
+	}, diff.Components[0])
+	assert.Equal(t, engine.PluginDiffComponent{
+		Name: "ebs", Costs: map[string]float64{"aws-public": 3.0}, Differs: true,
+	}, diff.Components[1])
+
+	diffs = engine.ExplainPluginDiff(pluginDiffResults(), engine.PluginDiffAll)
+	require.Len(t, diffs, 2, "resources priced by a single plugin are left out")
+	assert.False(t, diffs[1].SharedComponents, "the plugins break the database down differently")
+
+	assert.Empty(t, engine.ExplainPluginDiff(pluginDiffResults(), "logs"))
+}
+
+func TestRenderPluginDiff(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderPluginDiff(&buf, engine.ExplainPluginDiff(pluginDiffResults(), engine.PluginDiffAll)))
+	out := buf.String()
+
+	assert.Contains(t, out, "Plugin pricing for "+ec2InstanceType+"/"+webURN+":")
+	assert.Regexp(t, `compute\s+7\.30\s+8\.03\s+x1\.10`, out)
+	assert.Regexp(t, `ebs\s+3\.00\s+-\s+only aws-public`, out)
+	assert.Regexp(t, `Total\s+10\.30 USD\s+8\.03 USD\s+x1\.28`, out)
+	assert.Regexp(t, `Total\s+50\.00 USD\s+50\.00 USD\s*\n`, out, "equal totals show no difference")
+	assert.Contains(t, out, "The plugins share no breakdown components; only their totals are comparable.")
+
+	buf.Reset()
+	require.NoError(t, engine.RenderPluginDiff(&buf, nil))
+	assert.Equal(t, "No resource was priced by more than one plugin.\n", buf.String())
+}
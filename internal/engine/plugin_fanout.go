@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// pluginCall is the outcome of asking one plugin to price a resource.
+type pluginCall struct {
+	client *pluginhost.Client
+	result *CostResult
+	err    error
+}
+
+// queryProjectedPlugins asks each client to price resource, running up to
+// EngineOptions.PluginConcurrency calls at once, and returns the outcomes in client order
+// so results do not depend on which plugin answered first. A positive timeout bounds each
+// call.
+func (e *Engine) queryProjectedPlugins(
+	ctx context.Context,
+	clients []*pluginhost.Client,
+	resource ResourceDescriptor,
+	timeout time.Duration,
+) []pluginCall {
+	calls := make([]pluginCall, len(clients))
+	query := func(i int) {
+		logging.FromContext(ctx).Debug().
+			Ctx(ctx).
+			Str("component", "engine").
+			Str("resource_type", resource.Type).
+			Str("resource_id", resource.ID).
+			Str("plugin", clients[i].Name).
+			Msg("querying plugin for projected cost")
+
+		callCtx := ctx
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		result, err := e.getProjectedCostFromPlugin(callCtx, clients[i], resource)
+		calls[i] = pluginCall{client: clients[i], result: result, err: err}
+	}
+
+	limit := e.options.PluginConcurrency
+	if limit <= 0 || limit > len(clients) {
+		limit = len(clients)
+	}
+	if limit <= 1 {
+		for i := range clients {
+			query(i)
+		}
+		return calls
+	}
+
+	slots := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			query(i)
+		}()
+	}
+	wg.Wait()
+	return calls
+}
@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// barrierAPI answers only once every plugin sharing the barrier has a call in flight, so
+// it fails when plugins are queried one after another.
+type barrierAPI struct {
+	proto.CostSourceClient
+
+	barrier *callBarrier
+	delay   time.Duration
+	monthly float64
+}
+
+type callBarrier struct {
+	mu      sync.Mutex
+	arrived int
+	want    int
+	ready   chan struct{}
+}
+
+func newCallBarrier(want int) *callBarrier {
+	return &callBarrier{want: want, ready: make(chan struct{})}
+}
+
+func (b *callBarrier) wait(timeout time.Duration) error {
+	b.mu.Lock()
+	b.arrived++
+	if b.arrived == b.want {
+		close(b.ready)
+	}
+	b.mu.Unlock()
+	select {
+	case <-b.ready:
+		return nil
+	case <-time.After(timeout):
+		return errors.New("other plugins were not queried concurrently")
+	}
+}
+
+func (a *barrierAPI) GetProjectedCost(
+	_ context.Context,
+	_ *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	if a.barrier != nil {
+		if err := a.barrier.wait(2 * time.Second); err != nil {
+			return nil, err
+		}
+	}
+	time.Sleep(a.delay)
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{Currency: "USD", MonthlyCost: a.monthly}},
+	}, nil
+}
+
+func TestGetProjectedCost_SlowPluginDoesNotSerializeOthers(t *testing.T) {
+	barrier := newCallBarrier(3)
+	clients := []*pluginhost.Client{
+		{Name: "slow", API: &barrierAPI{barrier: barrier, delay: 50 * time.Millisecond, monthly: 1}},
+		{Name: "fast", API: &barrierAPI{barrier: barrier, monthly: 2}},
+		{Name: "faster", API: &barrierAPI{barrier: barrier, monthly: 3}},
+	}
+	resource := engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: "web"}
+
+	result, err := newTestEngine(t, clients, nil).GetProjectedCostWithErrors(context.Background(),
+		[]engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Results, 3)
+	for i, want := range []string{"slow", "fast", "faster"} {
+		assert.Equal(t, want, result.Results[i].Adapter, "results follow plugin order, not completion order")
+	}
+}
+
+func TestGetProjectedCost_OrderIsStable(t *testing.T) {
+	clients := []*pluginhost.Client{
+		{Name: "slow", API: &barrierAPI{delay: 5 * time.Millisecond, monthly: 1}},
+		{Name: "fast", API: &barrierAPI{monthly: 2}},
+	}
+	resources := make([]engine.ResourceDescriptor, 20)
+	for i := range resources {
+		resources[i] = engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: fmt.Sprintf("i-%02d", i)}
+	}
+
+	for _, opts := range []engine.EngineOptions{{}, {Workers: 3, PluginConcurrency: 2}, {PluginConcurrency: 1}} {
+		results, err := newTestEngine(t, clients, nil, opts).GetProjectedCost(context.Background(), resources)
+		require.NoError(t, err)
+		require.Len(t, results, len(resources)*len(clients))
+		for i, r := range results {
+			assert.Equal(t, resources[i/len(clients)].ID, r.ResourceID, "%+v: result %d", opts, i)
+			assert.Equal(t, clients[i%len(clients)].Name, r.Adapter, "%+v: result %d", opts, i)
+		}
+	}
+}
+
+func TestGetProjectedCost_PluginConcurrencyOne(t *testing.T) {
+	api := &peakTrackingAPI{}
+	clients := []*pluginhost.Client{{Name: "first", API: api}, {Name: "second", API: api}}
+	resources := []engine.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web"}}
+
+	_, err := newTestEngine(t, clients, nil, engine.EngineOptions{PluginConcurrency: 1}).
+		GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Equal(t, 1, api.peak, "plugins must be queried one after another")
+
+	api.peak = 0
+	_, err = newTestEngine(t, clients, nil).GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Equal(t, 2, api.peak, "plugins are queried at once by default")
+}
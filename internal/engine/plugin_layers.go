@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PluginRole is the part a plugin plays when its prices are layered with other plugins'.
+type PluginRole string
+
+// Plugin roles. Plugins without a role are independent pricing sources whose results are
+// reported side by side.
+const (
+	// PluginRoleBase plugins price resources, e.g. from public list prices.
+	PluginRoleBase PluginRole = "base"
+	// PluginRoleAdjustment plugins adjust the base price, e.g. by negotiated discounts.
+	PluginRoleAdjustment PluginRole = "adjustment"
+)
+
+// AdjustmentMode is how an adjustment plugin's cost is read.
+type AdjustmentMode string
+
+// Adjustment modes.
+const (
+	// AdjustmentDelta adds the plugin's monthly cost to the base, so discounts are negative
+	// and surcharges positive.
+	AdjustmentDelta AdjustmentMode = "delta"
+	// AdjustmentFactor multiplies the base by the plugin's monthly cost, so 0.85 is a 15%
+	// discount.
+	AdjustmentFactor AdjustmentMode = "factor"
+)
+
+// breakdownAdjustmentPrefix precedes the plugin name of adjustment breakdown entries.
+const breakdownAdjustmentPrefix = "adjustment:"
+
+// PluginLayer assigns a plugin a role. Adjustments apply in ascending Order, then by name.
+type PluginLayer struct {
+	Plugin string
+	Role   PluginRole
+	// Mode applies to adjustment plugins; empty means AdjustmentDelta.
+	Mode  AdjustmentMode
+	Order int
+}
+
+// WithPluginLayers sets the plugin roles used to compose base and adjustment plugin
+// prices into one result per resource, and returns the engine for chaining.
+func (e *Engine) WithPluginLayers(layers []PluginLayer) *Engine {
+	sorted := append([]PluginLayer(nil), layers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Order != sorted[j].Order {
+			return sorted[i].Order < sorted[j].Order
+		}
+		return sorted[i].Plugin < sorted[j].Plugin
+	})
+	e.pluginLayers = sorted
+	return e
+}
+
+// layerPluginResults folds the results of a resource's adjustment plugins into the result
+// of its first base plugin. Other base results are dropped so layered plugins yield one
+// result, and adjustments without a base result are dropped since they are not costs on
+// their own. Results of plugins without a role are kept as they are.
+func (e *Engine) layerPluginResults(results []CostResult) []CostResult {
+	if len(e.pluginLayers) == 0 || len(results) == 0 {
+		return results
+	}
+	byPlugin := make(map[string]int, len(results))
+	for i, r := range results {
+		byPlugin[r.Adapter] = i
+	}
+
+	base := -1
+	for _, layer := range e.pluginLayers {
+		if i, ok := byPlugin[layer.Plugin]; ok && layer.Role == PluginRoleBase {
+			base = i
+			break
+		}
+	}
+	var notes []string
+	if base >= 0 {
+		for _, layer := range e.pluginLayers {
+			if i, ok := byPlugin[layer.Plugin]; ok && layer.Role == PluginRoleAdjustment {
+				notes = append(notes, applyAdjustment(&results[base], results[i], layer, e.hoursPerMonth(nil)))
+			}
+		}
+		if len(notes) > 0 {
+			note := "Layered: " + strings.Join(notes, ", ")
+			if results[base].Notes != "" {
+				note = results[base].Notes + "; " + note
+			}
+			results[base].Notes = note
+		}
+	}
+
+	layered := make([]CostResult, 0, len(results))
+	for i, r := range results {
+		if i == base || e.pluginRole(r.Adapter) == "" {
+			layered = append(layered, r)
+		}
+	}
+	return layered
+}
+
+// pluginRole returns the role of a plugin, or "" when it has none.
+func (e *Engine) pluginRole(plugin string) PluginRole {
+	for _, layer := range e.pluginLayers {
+		if layer.Plugin == plugin {
+			return layer.Role
+		}
+	}
+	return ""
+}
+
+// applyAdjustment applies one adjustment result to the base result and describes it.
+// hours is the number of hours in a month.
+func applyAdjustment(base *CostResult, adjustment CostResult, layer PluginLayer, hours float64) string {
+	if adjustment.Currency != "" && base.Currency != "" && adjustment.Currency != base.Currency {
+		return fmt.Sprintf("%s skipped (%s adjustment to a %s price)",
+			layer.Plugin, adjustment.Currency, base.Currency)
+	}
+	var delta float64
+	var note string
+	if layer.Mode == AdjustmentFactor {
+		delta = base.Monthly * (adjustment.Monthly - 1)
+		note = fmt.Sprintf("%s x%g", layer.Plugin, adjustment.Monthly)
+	} else {
+		delta = adjustment.Monthly
+		note = fmt.Sprintf("%s %+.2f/month", layer.Plugin, delta)
+	}
+	base.Monthly += delta
+	base.Hourly += delta / hours
+	if base.Breakdown == nil {
+		base.Breakdown = make(map[string]float64)
+	}
+	base.Breakdown[breakdownAdjustmentPrefix+layer.Plugin] += delta
+	return note
+}
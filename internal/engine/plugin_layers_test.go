@@ -0,0 +1,110 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fixedCostAPI prices every resource at the same monthly cost.
+type fixedCostAPI struct {
+	proto.CostSourceClient
+
+	monthly  float64
+	currency string
+}
+
+func (a *fixedCostAPI) GetProjectedCost(
+	_ context.Context,
+	_ *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	currency := a.currency
+	if currency == "" {
+		currency = "USD"
+	}
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{Currency: currency, MonthlyCost: a.monthly, HourlyCost: a.monthly / 730}},
+	}, nil
+}
+
+func TestGetProjectedCost_PluginLayers(t *testing.T) {
+	clients := []*pluginhost.Client{
+		{Name: "aws-public", API: &fixedCostAPI{monthly: 100}},
+		{Name: "aws-negotiated", API: &fixedCostAPI{monthly: 0.8}},
+		{Name: "support-fee", API: &fixedCostAPI{monthly: 5}},
+		{Name: "kubecost", API: &fixedCostAPI{monthly: 90}},
+	}
+	layers := []engine.PluginLayer{
+		{Plugin: "support-fee", Role: engine.PluginRoleAdjustment, Order: 2},
+		{Plugin: "aws-negotiated", Role: engine.PluginRoleAdjustment, Mode: engine.AdjustmentFactor, Order: 1},
+		{Plugin: "aws-public", Role: engine.PluginRoleBase},
+	}
+	resource := engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: "web"}
+
+	for _, withErrors := range []bool{false, true} {
+		eng := newTestEngine(t, clients, nil).WithPluginLayers(layers)
+		var results []engine.CostResult
+		if withErrors {
+			res, err := eng.GetProjectedCostWithErrors(context.Background(), []engine.ResourceDescriptor{resource})
+			require.NoError(t, err)
+			results = res.Results
+		} else {
+			var err error
+			results, err = eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+			require.NoError(t, err)
+		}
+
+		// The discount applies before the surcharge; plugins without a role stay separate.
+		require.Len(t, results, 2)
+		assert.Equal(t, "aws-public", results[0].Adapter)
+		assert.InDelta(t, 85.0, results[0].Monthly, 0.0001)
+		assert.InDelta(t, 85.0/730, results[0].Hourly, 0.0001)
+		assert.InDelta(t, -20.0, results[0].Breakdown["adjustment:aws-negotiated"], 0.0001)
+		assert.InDelta(t, 5.0, results[0].Breakdown["adjustment:support-fee"], 0.0001)
+		assert.Contains(t, results[0].Notes, "Layered: aws-negotiated x0.8, support-fee +5.00/month")
+		assert.Equal(t, "kubecost", results[1].Adapter)
+		assert.InDelta(t, 90.0, results[1].Monthly, 0.0001)
+	}
+}
+
+func TestGetProjectedCost_PluginLayersWithoutBase(t *testing.T) {
+	clients := []*pluginhost.Client{
+		{Name: "aws-negotiated", API: &fixedCostAPI{monthly: -10}},
+	}
+	results, err := newTestEngine(t, clients, nil).
+		WithPluginLayers([]engine.PluginLayer{
+			{Plugin: "aws-public", Role: engine.PluginRoleBase},
+			{Plugin: "aws-negotiated", Role: engine.PluginRoleAdjustment},
+		}).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web"}})
+	require.NoError(t, err)
+
+	// An adjustment alone is not a cost, so the resource is reported as unpriced.
+	require.Len(t, results, 1)
+	assert.Equal(t, "none", results[0].Adapter)
+}
+
+func TestGetProjectedCost_PluginLayersCurrencyMismatch(t *testing.T) {
+	clients := []*pluginhost.Client{
+		{Name: "aws-public", API: &fixedCostAPI{monthly: 100}},
+		{Name: "aws-negotiated", API: &fixedCostAPI{monthly: -10, currency: "EUR"}},
+	}
+	results, err := newTestEngine(t, clients, nil).
+		WithPluginLayers([]engine.PluginLayer{
+			{Plugin: "aws-public", Role: engine.PluginRoleBase},
+			{Plugin: "aws-negotiated", Role: engine.PluginRoleAdjustment},
+		}).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web"}})
+	require.NoError(t, err)
+
+	require.Len(t, results, 1)
+	assert.InDelta(t, 100.0, results[0].Monthly, 0.0001)
+	assert.Contains(t, results[0].Notes, "aws-negotiated skipped (EUR adjustment to a USD price)")
+}
@@ -0,0 +1,19 @@
+package engine
+
+import "context"
+
+// acquirePluginSlot blocks, when EngineOptions.MaxConcurrentPluginCalls is set, until a
+// plugin call may start and returns the function that
+// frees the slot. When ctx is cancelled first it returns immediately, leaving the call
+// itself to fail with the context error.
+func (e *Engine) acquirePluginSlot(ctx context.Context) func() {
+	if e.pluginSlots == nil {
+		return func() {}
+	}
+	select {
+	case e.pluginSlots <- struct{}{}:
+		return func() { <-e.pluginSlots }
+	case <-ctx.Done():
+		return func() {}
+	}
+}
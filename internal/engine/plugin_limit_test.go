@@ -0,0 +1,64 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// peakTrackingAPI records the highest number of GetProjectedCost calls in flight.
+type peakTrackingAPI struct {
+	proto.CostSourceClient
+
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (a *peakTrackingAPI) GetProjectedCost(
+	_ context.Context,
+	_ *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	a.mu.Lock()
+	a.running++
+	a.peak = max(a.peak, a.running)
+	a.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	a.mu.Lock()
+	a.running--
+	a.mu.Unlock()
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{Currency: "USD", MonthlyCost: 1}},
+	}, nil
+}
+
+func TestMaxConcurrentPluginCalls(t *testing.T) {
+	api := &peakTrackingAPI{}
+	clients := []*pluginhost.Client{
+		{Name: "first", API: api},
+		{Name: "second", API: api},
+	}
+
+	resources := make([]engine.ResourceDescriptor, 8)
+	for i := range resources {
+		resources[i] = engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: fmt.Sprintf("i-%d", i)}
+	}
+
+	results, err := newTestEngine(t, clients, nil, engine.EngineOptions{MaxConcurrentPluginCalls: 1}).
+		GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Len(t, results, len(resources)*len(clients))
+	assert.Equal(t, 1, api.peak, "plugin calls must not overlap with a cap of one")
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// pluginErrorResourceType is the ResourceType of errors that concern a whole plugin rather
+// than one resource; their ResourceID is the plugin name.
+const pluginErrorResourceType = "plugin"
+
+// PluginReadiness is the outcome of validating one plugin before a run.
+type PluginReadiness struct {
+	Plugin string
+	Ready  bool
+	Error  error
+}
+
+// ValidatePlugins health-checks all plugins concurrently and returns their readiness in
+// client order. Plugins without a health check RPC are reported ready.
+func (e *Engine) ValidatePlugins(ctx context.Context) []PluginReadiness {
+	readiness := make([]PluginReadiness, len(e.clients))
+	var wg sync.WaitGroup
+	for i, client := range e.clients {
+		wg.Add(1)
+		go func(i int, client *pluginhost.Client) {
+			defer wg.Done()
+			err := client.Validate(ctx)
+			readiness[i] = PluginReadiness{Plugin: client.Name, Ready: err == nil, Error: err}
+		}(i, client)
+	}
+	wg.Wait()
+	return readiness
+}
+
+// excludeUnreadyPlugins runs the validation phase when enabled, drops plugins that are not
+// ready from the engine and returns one error per dropped plugin.
+func (e *Engine) excludeUnreadyPlugins(ctx context.Context) []ErrorDetail {
+	if !e.options.ValidatePlugins || len(e.clients) == 0 {
+		return nil
+	}
+
+	var errs []ErrorDetail
+	ready := make([]*pluginhost.Client, 0, len(e.clients))
+	for i, r := range e.ValidatePlugins(ctx) {
+		if r.Ready {
+			ready = append(ready, e.clients[i])
+			continue
+		}
+		logging.FromContext(ctx).Warn().
+			Ctx(ctx).
+			Str("component", "engine").
+			Str("plugin", r.Plugin).
+			Err(r.Error).
+			Msg("plugin failed validation, skipping it for this run")
+		errs = append(errs, ErrorDetail{
+			ResourceType: pluginErrorResourceType,
+			ResourceID:   r.Plugin,
+			PluginName:   r.Plugin,
+			Error:        r.Error,
+			Timestamp:    time.Now(),
+		})
+	}
+	e.clients = ready
+	return errs
+}
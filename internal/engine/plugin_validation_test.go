@@ -0,0 +1,101 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// healthAPI reports a fixed health status and counts projected cost calls.
+type healthAPI struct {
+	proto.CostSourceClient
+
+	status pbc.HealthCheckResponse_Status
+	calls  atomic.Int32
+}
+
+func (a *healthAPI) HealthCheck(context.Context, ...grpc.CallOption) (*pbc.HealthCheckResponse, error) {
+	return &pbc.HealthCheckResponse{Status: a.status, Message: "credentials expired"}, nil
+}
+
+func (a *healthAPI) GetProjectedCost(
+	context.Context,
+	*proto.GetProjectedCostRequest,
+	...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	a.calls.Add(1)
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{Currency: "USD", MonthlyCost: 1}},
+	}, nil
+}
+
+func TestValidatePlugins(t *testing.T) {
+	clients := []*pluginhost.Client{
+		{Name: "healthy", API: &healthAPI{status: pbc.HealthCheckResponse_STATUS_SERVING}},
+		{Name: "broken", API: &healthAPI{status: pbc.HealthCheckResponse_STATUS_NOT_SERVING}},
+		{Name: "legacy", API: &peakTrackingAPI{}},
+	}
+
+	readiness := newTestEngine(t, clients, nil).ValidatePlugins(context.Background())
+	require.Len(t, readiness, 3)
+	assert.True(t, readiness[0].Ready)
+	assert.False(t, readiness[1].Ready)
+	require.ErrorIs(t, readiness[1].Error, pluginhost.ErrPluginNotReady)
+	assert.True(t, readiness[2].Ready, "plugins without a health check are assumed ready")
+}
+
+func TestValidatePlugins_SkipsUnreadyPlugin(t *testing.T) {
+	broken := &healthAPI{status: pbc.HealthCheckResponse_STATUS_NOT_SERVING}
+	clients := []*pluginhost.Client{{Name: "aws-public", API: broken}}
+
+	resources := make([]engine.ResourceDescriptor, 5)
+	for i := range resources {
+		resources[i] = engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: fmt.Sprintf("i-%d", i)}
+	}
+
+	result, err := newTestEngine(t, clients, nil, engine.EngineOptions{ValidatePlugins: true}).
+		GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+
+	assert.Zero(t, broken.calls.Load(), "an unready plugin is not queried")
+	require.Len(t, result.Errors, 1, "an unready plugin is reported once, not per resource")
+	assert.Equal(t, "aws-public", result.Errors[0].PluginName)
+	assert.EqualError(t, result.Errors[0].Error, "plugin not ready: credentials expired")
+	assert.Len(t, result.Results, len(resources))
+	assert.Equal(t, "none", result.Results[0].Adapter)
+}
+
+func TestValidatePlugins_GetProjectedCostSkipsUnreadyPlugin(t *testing.T) {
+	broken := &healthAPI{status: pbc.HealthCheckResponse_STATUS_NOT_SERVING}
+	clients := []*pluginhost.Client{{Name: "aws-public", API: broken}}
+
+	results, err := newTestEngine(t, clients, nil, engine.EngineOptions{ValidatePlugins: true}).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{
+			{Type: "aws:ec2/instance:Instance", ID: "i-0"},
+		})
+	require.NoError(t, err)
+
+	assert.Zero(t, broken.calls.Load(), "an unready plugin is not queried")
+	require.Len(t, results, 1)
+	assert.Equal(t, "none", results[0].Adapter)
+}
+
+func TestValidatePlugins_Disabled(t *testing.T) {
+	broken := &healthAPI{status: pbc.HealthCheckResponse_STATUS_NOT_SERVING}
+	clients := []*pluginhost.Client{{Name: "aws-public", API: broken}}
+
+	result, err := newTestEngine(t, clients, nil).GetProjectedCostWithErrors(context.Background(),
+		[]engine.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web"}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), broken.calls.Load())
+	assert.Empty(t, result.Errors)
+}
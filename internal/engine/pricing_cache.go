@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+const pricingCacheDirPerm = 0o750
+
+// PricingCache keeps projected prices that plugins tagged with an ETag, one JSON file per
+// fingerprint, so later runs can skip the plugin call. Entries younger than the TTL are
+// reused as-is; older ones are revalidated by sending the ETag back to the plugin, which
+// can answer "not modified" instead of re-pricing. Prices without an ETag are never cached.
+//
+// The TTL of an entry is, in order of precedence, the one configured for its plugin, the
+// one the plugin advertised with the price, or the cache default. This lets stable sources
+// such as static specs be reused for days while volatile spot prices expire in minutes.
+// Entries are keyed by the plugin's pricing version as well as the resource, so they lapse
+// as soon as a plugin update changes its pricing data.
+type PricingCache struct {
+	dir        string
+	ttl        time.Duration
+	pluginTTLs map[string]time.Duration
+
+	mu      sync.Mutex
+	entries map[string]pricingCacheEntry
+}
+
+// pricingCacheMeta is what a plugin said about a price besides its cost.
+type pricingCacheMeta struct {
+	etag        string
+	pricingDate string
+	ttl         time.Duration
+}
+
+// pricingCacheEntry is the on-disk form of a cached price.
+type pricingCacheEntry struct {
+	ETag        string        `json:"etag"`
+	PricingDate string        `json:"pricingDate,omitempty"`
+	StoredAt    time.Time     `json:"storedAt"`
+	TTL         time.Duration `json:"ttl,omitempty"` // advertised by the plugin, if any
+	Result      CostResult    `json:"result"`
+}
+
+// NewPricingCache returns a cache stored under dir whose entries are fresh for ttl.
+func NewPricingCache(dir string, ttl time.Duration) *PricingCache {
+	return &PricingCache{
+		dir:     dir,
+		ttl:     ttl,
+		entries: make(map[string]pricingCacheEntry),
+	}
+}
+
+// WithPluginTTLs sets TTLs for the prices of individual plugins, overriding both the
+// default and what the plugins advertise, and returns the cache for chaining.
+func (c *PricingCache) WithPluginTTLs(ttls map[string]time.Duration) *PricingCache {
+	c.pluginTTLs = maps.Clone(ttls)
+	return c
+}
+
+// ttlFor returns how long a price from plugin stays fresh, given the TTL the plugin
+// advertised with it.
+func (c *PricingCache) ttlFor(plugin string, advertised time.Duration) time.Duration {
+	if ttl, ok := c.pluginTTLs[plugin]; ok {
+		return ttl
+	}
+	if advertised > 0 {
+		return advertised
+	}
+	return c.ttl
+}
+
+// WithPricingCache sets the cache used for ETag-tagged plugin prices and returns the engine
+// for chaining.
+func (e *Engine) WithPricingCache(cache *PricingCache) *Engine {
+	e.pricingCache = cache
+	return e
+}
+
+// pluginPricingVersionKeys are the plugin metadata keys read as the version of its pricing data.
+var pluginPricingVersionKeys = []string{"pricing_version", "pricingVersion"}
+
+// pricingFingerprint identifies a pricing request to one plugin at its current pricing
+// version, so that prices cached from an older plugin or older pricing data are never
+// served once the plugin is updated.
+func pricingFingerprint(client *pluginhost.Client, resource ResourceDescriptor) string {
+	return hashParts(client.Name, pluginPricingVersion(client), resourceFingerprint(resource))
+}
+
+// pluginPricingVersion is the pricing data version a plugin advertises in its metadata, or
+// failing that the plugin's own version.
+func pluginPricingVersion(client *pluginhost.Client) string {
+	if client.Metadata == nil {
+		return ""
+	}
+	for _, key := range pluginPricingVersionKeys {
+		if version := client.Metadata.Metadata[key]; version != "" {
+			return key + "=" + version
+		}
+	}
+	return client.Metadata.Version
+}
+
+// lookup returns the cached entry for key and whether it is still within the TTL of
+// plugin.
+func (c *PricingCache) lookup(plugin, key string) (pricingCacheEntry, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		data, err := os.ReadFile(c.path(key))
+		if err != nil || json.Unmarshal(data, &entry) != nil || entry.ETag == "" {
+			return pricingCacheEntry{}, false, false
+		}
+		c.entries[key] = entry
+	}
+	return entry, time.Now().Sub(entry.StoredAt) < c.ttlFor(plugin, entry.TTL), true
+}
+
+// store saves result under key with its ETag, pricing date and advertised TTL, restarting
+// the TTL.
+func (c *PricingCache) store(key string, meta pricingCacheMeta, result CostResult) error {
+	result.Breakdown = maps.Clone(result.Breakdown)
+	result.Sustainability = maps.Clone(result.Sustainability)
+	entry := pricingCacheEntry{
+		ETag:        meta.etag,
+		PricingDate: meta.pricingDate,
+		StoredAt:    time.Now(),
+		TTL:         meta.ttl,
+		Result:      result,
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding pricing cache entry: %w", err)
+	}
+	if mkdirErr := os.MkdirAll(c.dir, pricingCacheDirPerm); mkdirErr != nil {
+		return fmt.Errorf("creating pricing cache directory: %w", mkdirErr)
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("writing pricing cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err = errors.Join(writeErr, closeErr); err != nil {
+		return fmt.Errorf("writing pricing cache entry: %w", err)
+	}
+	if renameErr := os.Rename(tmp.Name(), c.path(key)); renameErr != nil {
+		return fmt.Errorf("writing pricing cache entry: %w", renameErr)
+	}
+	return nil
+}
+
+func (c *PricingCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// cachedResult copies a cached price onto the resource being priced. Maps are cloned
+// because later adjustments such as commitment blending modify results in place.
+func cachedResult(entry pricingCacheEntry, resource ResourceDescriptor) *CostResult {
+	result := entry.Result
+	result.ResourceType = resource.Type
+	result.ResourceID = resource.ID
+	result.Breakdown = maps.Clone(result.Breakdown)
+	result.Sustainability = maps.Clone(result.Sustainability)
+	return &result
+}
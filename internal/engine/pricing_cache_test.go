@@ -0,0 +1,184 @@
+package engine_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// etagAPI prices every resource at monthly and tags the price with etag, answering
+// not-modified when a request carries the current etag.
+type etagAPI struct {
+	proto.CostSourceClient
+
+	mu          sync.Mutex
+	etag        string
+	ttl         time.Duration
+	monthly     float64
+	calls       int
+	ifNoneMatch []string
+}
+
+func (a *etagAPI) GetProjectedCost(
+	_ context.Context,
+	in *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls++
+	ifNoneMatch := in.Resources[0].IfNoneMatch
+	a.ifNoneMatch = append(a.ifNoneMatch, ifNoneMatch)
+	if a.etag != "" && ifNoneMatch == a.etag {
+		return &proto.GetProjectedCostResponse{
+			Results: []*proto.CostResult{{ETag: a.etag, NotModified: true}},
+		}, nil
+	}
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{
+			Currency:      "USD",
+			MonthlyCost:   a.monthly,
+			CostBreakdown: map[string]float64{"compute": a.monthly},
+			ETag:          a.etag,
+			CacheTTL:      a.ttl,
+		}},
+	}, nil
+}
+
+func projectWithCache(t *testing.T, api *etagAPI, cache *engine.PricingCache, id string) engine.CostResult {
+	t.Helper()
+	clients := []*pluginhost.Client{{Name: "pricing", API: api}}
+	results, err := newTestEngine(t, clients, nil).
+		WithPricingCache(cache).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+			Type:       "aws:ec2/instance:Instance",
+			ID:         id,
+			Provider:   "aws",
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	return results[0]
+}
+
+func TestPricingCache_FreshEntrySkipsPlugin(t *testing.T) {
+	dir := t.TempDir()
+	api := &etagAPI{etag: `"v1"`, monthly: 10}
+
+	first := projectWithCache(t, api, engine.NewPricingCache(dir, time.Hour), "web-1")
+	assert.InDelta(t, 10.0, first.Monthly, 0.001)
+
+	// A new cache on the same directory stands in for a later run.
+	api.monthly = 99
+	second := projectWithCache(t, api, engine.NewPricingCache(dir, time.Hour), "web-2")
+	assert.Equal(t, 1, api.calls, "a fresh cached price must not call the plugin")
+	assert.Equal(t, "web-2", second.ResourceID)
+	assert.Equal(t, "pricing", second.Adapter)
+	assert.InDelta(t, 10.0, second.Monthly, 0.001)
+	assert.InDelta(t, 10.0, second.Breakdown["compute"], 0.001)
+}
+
+func TestPricingCache_StaleEntryRevalidates(t *testing.T) {
+	dir := t.TempDir()
+	api := &etagAPI{etag: `"v1"`, monthly: 10}
+	cache := engine.NewPricingCache(dir, time.Nanosecond)
+
+	projectWithCache(t, api, cache, "web-1")
+	api.monthly = 99 // ignored while the ETag still matches
+	result := projectWithCache(t, api, cache, "web-1")
+
+	assert.Equal(t, 2, api.calls)
+	assert.Equal(t, []string{"", `"v1"`}, api.ifNoneMatch)
+	assert.InDelta(t, 10.0, result.Monthly, 0.001, "not-modified must reuse the cached price")
+
+	api.etag = `"v2"`
+	result = projectWithCache(t, api, cache, "web-1")
+	assert.InDelta(t, 99.0, result.Monthly, 0.001, "a changed ETag must replace the cached price")
+	assert.Equal(t, `"v1"`, api.ifNoneMatch[2])
+}
+
+func TestPricingCache_PricesWithoutETagAreNotCached(t *testing.T) {
+	dir := t.TempDir()
+	api := &etagAPI{monthly: 10}
+
+	projectWithCache(t, api, engine.NewPricingCache(dir, time.Hour), "web-1")
+	projectWithCache(t, api, engine.NewPricingCache(dir, time.Hour), "web-1")
+
+	assert.Equal(t, 2, api.calls)
+	assert.Equal(t, []string{"", ""}, api.ifNoneMatch)
+}
+
+func TestPricingCache_TTLPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		advertised time.Duration
+		pluginTTLs map[string]time.Duration
+		wantCalls  int
+	}{
+		{name: "default", wantCalls: 2},
+		{name: "advertised by plugin", advertised: time.Hour, wantCalls: 1},
+		{name: "configured for plugin", pluginTTLs: map[string]time.Duration{"pricing": time.Hour}, wantCalls: 1},
+		{name: "other plugin configured", pluginTTLs: map[string]time.Duration{"spot": time.Hour}, wantCalls: 2},
+		{
+			name:       "configuration beats advertisement",
+			advertised: time.Hour,
+			pluginTTLs: map[string]time.Duration{"pricing": time.Nanosecond},
+			wantCalls:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			api := &etagAPI{etag: `"v1"`, ttl: tt.advertised, monthly: 10}
+			newCache := func() *engine.PricingCache {
+				return engine.NewPricingCache(dir, time.Nanosecond).WithPluginTTLs(tt.pluginTTLs)
+			}
+
+			projectWithCache(t, api, newCache(), "web-1")
+			projectWithCache(t, api, newCache(), "web-1")
+			assert.Equal(t, tt.wantCalls, api.calls)
+		})
+	}
+}
+
+func TestPricingCache_PricingVersionInvalidates(t *testing.T) {
+	dir := t.TempDir()
+	api := &etagAPI{etag: `"v1"`, monthly: 10}
+	project := func(metadata *proto.PluginMetadata) engine.CostResult {
+		clients := []*pluginhost.Client{{Name: "pricing", API: api, Metadata: metadata}}
+		results, err := newTestEngine(t, clients, nil).
+			WithPricingCache(engine.NewPricingCache(dir, time.Hour)).
+			GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+				Type:       "aws:ec2/instance:Instance",
+				ID:         "web",
+				Provider:   "aws",
+				Properties: map[string]interface{}{"instanceType": "t3.micro"},
+			}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		return results[0]
+	}
+
+	project(&proto.PluginMetadata{Version: "1.0.0"})
+	api.monthly = 20
+	assert.InDelta(t, 10.0, project(&proto.PluginMetadata{Version: "1.0.0"}).Monthly, 0.001)
+	assert.Equal(t, 1, api.calls)
+
+	assert.InDelta(t, 20.0, project(&proto.PluginMetadata{Version: "1.1.0"}).Monthly, 0.001,
+		"a plugin update must not be served prices cached by the old version")
+	assert.Equal(t, 2, api.calls)
+
+	api.monthly = 30
+	advertised := &proto.PluginMetadata{Version: "1.1.0", Metadata: map[string]string{"pricing_version": "2026-10"}}
+	assert.InDelta(t, 30.0, project(advertised).Monthly, 0.001, "a new pricing version invalidates the cache")
+	assert.InDelta(t, 30.0, project(advertised).Monthly, 0.001)
+	assert.Equal(t, 3, api.calls)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rshade/finfocus/internal/pluginhost"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetDefaultMonthlyByType(t *testing.T) {
@@ -169,7 +170,7 @@ func TestTryStoragePricing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			monthly, hourly, ok := tryStoragePricing(tt.pricing, tt.resource)
+			monthly, hourly, ok := tryStoragePricing(tt.pricing, tt.resource, hoursPerMonth)
 			assert.Equal(t, tt.expectedOk, ok)
 			if tt.expectedOk {
 				assert.InDelta(t, tt.expectedMonthly, monthly, 0.0001)
@@ -208,7 +209,7 @@ func TestTryFallbackNumericValue(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m, h, found := tryFallbackNumericValue(tt.pricing)
+			m, h, found := tryFallbackNumericValue(tt.pricing, hoursPerMonth)
 			assert.Equal(t, tt.wantFound, found)
 			if found {
 				assert.InDelta(t, tt.wantMonthly, m, 0.0001)
@@ -225,7 +226,8 @@ func TestGetActualCost_Wrapper(t *testing.T) {
 	// Since we are in the engine package, we can just create an engine with no clients/loader
 	// and expect it to return results (even if empty or placeholders).
 
-	e := New([]*pluginhost.Client{}, nil)
+	e, err := New([]*pluginhost.Client{}, nil)
+	require.NoError(t, err)
 
 	resources := []ResourceDescriptor{
 		{Type: "aws:s3:Bucket", ID: "bucket-1", Provider: "aws"},
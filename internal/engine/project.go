@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 // OutputFormat specifies the output format for cost results (table, JSON, NDJSON).
@@ -19,6 +20,8 @@ const (
 	OutputJSON OutputFormat = "json"
 	// OutputNDJSON renders results as newline-delimited JSON for streaming.
 	OutputNDJSON OutputFormat = "ndjson"
+	// OutputGitHubActions renders results as GitHub Actions workflow annotations.
+	OutputGitHubActions OutputFormat = "github-actions"
 )
 
 const (
@@ -37,16 +40,61 @@ const (
 // The results parameter is the slice of CostResult to be rendered.
 // It returns an error if rendering fails or if the provided format is unsupported.
 func RenderResults(writer io.Writer, format OutputFormat, results []CostResult) error {
+	return RenderResultsWithOptions(writer, format, results, RenderOptions{})
+}
+
+// RenderOptions controls optional presentation features of RenderResultsWithOptions.
+type RenderOptions struct {
+	// Annotations lists annotation keys to render as extra table columns, in order.
+	// Structured formats always include the full annotations map on each result.
+	Annotations []string
+
+	// GitHubFile is the file= property attached to GitHub Actions annotations.
+	GitHubFile string
+	// CostWarningThreshold flags resources above this monthly cost in GitHub Actions
+	// output. Zero disables the check.
+	CostWarningThreshold float64
+
+	// Envelope, when non-nil, wraps JSON output in a versioned OutputEnvelope with
+	// the given provenance metadata.
+	Envelope *EnvelopeMeta
+	// ValidateEnvelope checks the envelope against EnvelopeSchema before it is written.
+	ValidateEnvelope bool
+
+	// Normalize orders resources by cost per vCPU and GB (least efficient first) and adds a
+	// COST EFFICIENCY section to the table.
+	Normalize bool
+
+	// AllocationTags lists the normalized allocation tag keys rendered as CSV columns, in order.
+	AllocationTags []string
+
+	// Omitted summarizes results left out by TopResults. Tables and markdown end with a
+	// line naming the omitted spend; JSON output carries it as the omitted field.
+	Omitted *OmittedResults
+}
+
+// RenderResultsWithOptions behaves like RenderResults but applies the given
+// RenderOptions to the table output.
+func RenderResultsWithOptions(writer io.Writer, format OutputFormat, results []CostResult, opts RenderOptions) error {
 	// Aggregate results for enhanced reporting
 	aggregated := AggregateResults(results)
+	aggregated.Omitted = opts.Omitted
 
 	switch format {
 	case OutputTable:
-		return renderTable(writer, aggregated)
+		return renderTable(writer, aggregated, opts)
 	case OutputJSON:
 		return renderJSON(writer, aggregated)
 	case OutputNDJSON:
 		return renderNDJSON(writer, results) // NDJSON doesn't need aggregation
+	case OutputCSV:
+		return renderCSV(writer, results, opts.AllocationTags)
+	case OutputFOCUS:
+		return RenderFOCUS(writer, results, time.Now())
+	case OutputGitHubActions:
+		return WriteGitHubAnnotations(writer, BuildGitHubAnnotations(results, nil, opts))
+	case OutputMarkdown:
+		return renderMarkdown(writer, results, opts.Omitted)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -68,6 +116,9 @@ func RenderResults(writer io.Writer, format OutputFormat, results []CostResult)
 //
 // It returns an error if the selected renderer fails or if the format is unsupported.
 func RenderActualCostResults(writer io.Writer, format OutputFormat, results []CostResult, showConfidence bool) error {
+	if isNestedGrouping(results) && (format == OutputTable || format == OutputJSON) {
+		return renderCostGroups(writer, format, results)
+	}
 	switch format {
 	case OutputTable:
 		return renderActualCostTable(writer, results, showConfidence)
@@ -75,6 +126,10 @@ func RenderActualCostResults(writer io.Writer, format OutputFormat, results []Co
 		return RenderActualCostJSON(writer, results, showConfidence)
 	case OutputNDJSON:
 		return RenderActualCostNDJSON(writer, results, showConfidence)
+	case OutputFOCUS:
+		return RenderFOCUS(writer, results, time.Now())
+	case OutputMarkdown:
+		return RenderMarkdown(writer, results)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -168,30 +223,87 @@ func RenderCrossProviderAggregation(
 // writer is the destination for the rendered table. aggregated contains the precomputed
 // results to render.
 // Returns an error if writing to or flushing the tabulated output fails.
-func renderTable(writer io.Writer, aggregated *AggregatedResults) error {
+func renderTable(writer io.Writer, aggregated *AggregatedResults, opts RenderOptions) error {
 	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
 
 	renderSummary(w, aggregated)
+	renderUnsupported(w, aggregated.Summary)
 	renderBreakdowns(w, aggregated)
 	renderSustainabilitySummary(w, aggregated)
-	renderResourceDetails(w, aggregated)
+	if opts.Normalize {
+		aggregated.Resources = SortByEfficiency(aggregated.Resources)
+		renderEfficiency(w, aggregated.Resources)
+	}
+	renderResourceDetails(w, aggregated, opts.Annotations)
+	if aggregated.Omitted != nil {
+		fmt.Fprintf(w, "%s\n", aggregated.Omitted)
+	}
 
 	return w.Flush()
 }
 
 // renderSummary writes a COST SUMMARY section to w containing the total monthly cost,
 // total hourly cost, and the total number of resources from aggregated, followed by a blank line.
+// Results spanning currencies get a section per currency instead (see renderCurrencySummaries).
 //
 // Parameters:
 //   - w: destination writer for the formatted summary.
 //   - aggregated: aggregated results whose Summary (TotalMonthly, TotalHourly, Currency)
 //     and Resources are used to populate the output.
 func renderSummary(w io.Writer, aggregated *AggregatedResults) {
+	if aggregated.Summary.ByCurrency != nil {
+		renderCurrencySummaries(w, aggregated)
+		return
+	}
+	writeSummarySection(w, "COST SUMMARY", aggregated.Summary.TotalMonthly, aggregated.Summary.TotalHourly,
+		aggregated.Summary.Currency, len(aggregated.Resources))
+}
+
+// renderCurrencySummaries writes the resource count and the currencies of mixed-currency
+// results, then a COST SUMMARY section per currency with its own totals. No combined
+// total is shown: amounts in different currencies are only summed once a currency
+// transform has converted them.
+func renderCurrencySummaries(w io.Writer, aggregated *AggregatedResults) {
+	currencies := SortedCurrencies(aggregated.Summary.ByCurrency)
 	fmt.Fprintf(w, "COST SUMMARY\n")
 	fmt.Fprintf(w, "============\n")
-	fmt.Fprintf(w, "Total Monthly Cost:\t%.2f %s\n", aggregated.Summary.TotalMonthly, aggregated.Summary.Currency)
-	fmt.Fprintf(w, "Total Hourly Cost:\t%.2f %s\n", aggregated.Summary.TotalHourly, aggregated.Summary.Currency)
 	fmt.Fprintf(w, "Total Resources:\t%d\n", len(aggregated.Resources))
+	fmt.Fprintf(w, "Currencies:\t%s (not converted, no combined total)\n", strings.Join(currencies, ", "))
+	fmt.Fprintf(w, "\n")
+	for _, currency := range currencies {
+		subtotal := aggregated.Summary.ByCurrency[currency]
+		writeSummarySection(w, "COST SUMMARY ("+currency+")", subtotal.TotalMonthly, subtotal.TotalHourly,
+			currency, subtotal.ResourceCount)
+	}
+}
+
+// renderUnsupported writes an UNSUPPORTED RESOURCES section counting the resources that no
+// plugin or spec could price, by type, so a low total is not mistaken for a cheap stack.
+// Nothing is written when every resource is supported.
+func renderUnsupported(w io.Writer, summary CostSummary) {
+	if summary.UnsupportedCount == 0 {
+		return
+	}
+	title := "UNSUPPORTED RESOURCES"
+	fmt.Fprintf(w, "%s\n%s\n", title, strings.Repeat("=", len(title)))
+	fmt.Fprintf(w, "Supported Resources:\t%d\n", summary.SupportedCount)
+	fmt.Fprintf(w, "Unsupported Resources:\t%d (not included in the totals)\n", summary.UnsupportedCount)
+	types := make([]string, 0, len(summary.UnsupportedTypes))
+	for resourceType := range summary.UnsupportedTypes {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+	for _, resourceType := range types {
+		fmt.Fprintf(w, "  %s:\t%d\n", resourceType, summary.UnsupportedTypes[resourceType])
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func writeSummarySection(w io.Writer, title string, monthly, hourly float64, currency string, resources int) {
+	fmt.Fprintf(w, "%s\n%s\n", title, strings.Repeat("=", len(title)))
+	fmt.Fprintf(w, "Total Monthly Cost:\t%.2f %s\n", monthly, currency)
+	fmt.Fprintf(w, "Total Hourly Cost:\t%.2f %s\n", hourly, currency)
+	fmt.Fprintf(w, "Total Resources:\t%d\n", resources)
 	fmt.Fprintf(w, "\n")
 }
 
@@ -200,55 +312,41 @@ func renderSummary(w io.Writer, aggregated *AggregatedResults) {
 // section header followed by lines in the form "name:\t<cost> <currency>" with costs
 // formatted to two decimal places and a blank line after the section. The writer w
 // receives the formatted output and aggregated provides the Summary (ByProvider,
-// ByService, ByAdapter and Currency) used for the breakdowns.
+// ByService, ByAdapter and Currency) used for the breakdowns. Mixed-currency results
+// are broken down per currency, with the currency in the section headers.
 func renderBreakdowns(w io.Writer, aggregated *AggregatedResults) {
-	// Print breakdown by provider (sorted for deterministic output - SC-003 fix)
-	if len(aggregated.Summary.ByProvider) > 0 {
-		fmt.Fprintf(w, "BY PROVIDER\n")
-		fmt.Fprintf(w, "-----------\n")
-		providers := make([]string, 0, len(aggregated.Summary.ByProvider))
-		for provider := range aggregated.Summary.ByProvider {
-			providers = append(providers, provider)
-		}
-		sort.Strings(providers)
-		for _, provider := range providers {
-			cost := aggregated.Summary.ByProvider[provider]
-			fmt.Fprintf(w, "%s:\t%.2f %s\n", provider, cost, aggregated.Summary.Currency)
-		}
-		fmt.Fprintf(w, "\n")
+	if aggregated.Summary.ByCurrency == nil {
+		summary := aggregated.Summary
+		writeBreakdown(w, "BY PROVIDER", summary.ByProvider, summary.Currency)
+		writeBreakdown(w, "BY SERVICE", summary.ByService, summary.Currency)
+		writeBreakdown(w, "BY ADAPTER", summary.ByAdapter, summary.Currency)
+		return
+	}
+	for _, currency := range SortedCurrencies(aggregated.Summary.ByCurrency) {
+		subtotal := aggregated.Summary.ByCurrency[currency]
+		suffix := " (" + currency + ")"
+		writeBreakdown(w, "BY PROVIDER"+suffix, subtotal.ByProvider, currency)
+		writeBreakdown(w, "BY SERVICE"+suffix, subtotal.ByService, currency)
+		writeBreakdown(w, "BY ADAPTER"+suffix, subtotal.ByAdapter, currency)
 	}
+}
 
-	// Print breakdown by service (sorted for deterministic output - SC-003 fix)
-	if len(aggregated.Summary.ByService) > 0 {
-		fmt.Fprintf(w, "BY SERVICE\n")
-		fmt.Fprintf(w, "----------\n")
-		services := make([]string, 0, len(aggregated.Summary.ByService))
-		for service := range aggregated.Summary.ByService {
-			services = append(services, service)
-		}
-		sort.Strings(services)
-		for _, service := range services {
-			cost := aggregated.Summary.ByService[service]
-			fmt.Fprintf(w, "%s:\t%.2f %s\n", service, cost, aggregated.Summary.Currency)
-		}
-		fmt.Fprintf(w, "\n")
+// writeBreakdown writes one breakdown section, sorted by name for deterministic output
+// (SC-003 fix); empty breakdowns are skipped.
+func writeBreakdown(w io.Writer, title string, costs map[string]float64, currency string) {
+	if len(costs) == 0 {
+		return
 	}
-
-	// Print breakdown by adapter (sorted for deterministic output - SC-003 fix)
-	if len(aggregated.Summary.ByAdapter) > 0 {
-		fmt.Fprintf(w, "BY ADAPTER\n")
-		fmt.Fprintf(w, "----------\n")
-		adapters := make([]string, 0, len(aggregated.Summary.ByAdapter))
-		for adapter := range aggregated.Summary.ByAdapter {
-			adapters = append(adapters, adapter)
-		}
-		sort.Strings(adapters)
-		for _, adapter := range adapters {
-			cost := aggregated.Summary.ByAdapter[adapter]
-			fmt.Fprintf(w, "%s:\t%.2f %s\n", adapter, cost, aggregated.Summary.Currency)
-		}
-		fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "%s\n%s\n", title, strings.Repeat("-", len(title)))
+	names := make([]string, 0, len(costs))
+	for name := range costs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s:\t%.2f %s\n", name, costs[name], currency)
 	}
+	fmt.Fprintf(w, "\n")
 }
 
 // renderSustainabilitySummary aggregates sustainability metrics across all resources
@@ -292,11 +390,20 @@ func renderSustainabilitySummary(w io.Writer, aggregated *AggregatedResults) {
 // Parameters:
 //   - w: destination writer for the rendered table.
 //   - aggregated: aggregated results containing the resources to render.
-func renderResourceDetails(w io.Writer, aggregated *AggregatedResults) {
+//   - annotationKeys: annotation keys rendered as additional columns before Notes.
+func renderResourceDetails(w io.Writer, aggregated *AggregatedResults, annotationKeys []string) {
 	fmt.Fprintf(w, "RESOURCE DETAILS\n")
 	fmt.Fprintf(w, "================\n")
-	fmt.Fprintln(w, "Resource\tAdapter\tMonthly\tHourly\tCurrency\tNotes")
-	fmt.Fprintln(w, "--------\t-------\t-------\t------\t--------\t-----")
+
+	headers := []string{"Resource", "Adapter", "Monthly", "Hourly", "Currency"}
+	headers = append(headers, annotationKeys...)
+	headers = append(headers, "Notes")
+	separators := make([]string, len(headers))
+	for i, h := range headers {
+		separators[i] = strings.Repeat("-", len(h))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(separators, "\t"))
 
 	for _, result := range aggregated.Resources {
 		resource := fmt.Sprintf("%s/%s", result.ResourceType, result.ResourceID)
@@ -306,14 +413,21 @@ func renderResourceDetails(w io.Writer, aggregated *AggregatedResults) {
 
 		notes := formatResourceNotes(result)
 
-		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.4f\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.4f\t%s\t",
 			resource,
 			result.Adapter,
 			result.Monthly,
 			result.Hourly,
 			result.Currency,
-			notes,
 		)
+		for _, key := range annotationKeys {
+			value := result.Annotations[key]
+			if value == "" {
+				value = "-"
+			}
+			fmt.Fprintf(w, "%s\t", value)
+		}
+		fmt.Fprintf(w, "%s\n", notes)
 	}
 }
 
@@ -358,18 +472,11 @@ func renderActualCostTable(writer io.Writer, results []CostResult, showConfidenc
 	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
 
 	// Check if we have actual cost data to determine appropriate headers
-	hasActualCosts := false
-	for _, result := range results {
-		if result.TotalCost > 0 || result.CostPeriod != "" {
-			hasActualCosts = true
-			break
-		}
-	}
-
-	renderActualCostHeader(w, hasActualCosts, showConfidence)
+	actual := hasActualCosts(results)
+	renderActualCostHeader(w, actual, showConfidence)
 
 	for _, result := range results {
-		renderActualCostRow(w, result, hasActualCosts, showConfidence)
+		renderActualCostRow(w, result, actual, showConfidence)
 	}
 
 	return w.Flush()
@@ -514,6 +621,20 @@ func renderNDJSON(writer io.Writer, results []CostResult) error {
 	return nil
 }
 
+// periodColumnLabel returns the header of the period column of a cross-provider table.
+func periodColumnLabel(groupBy GroupBy) string {
+	switch groupBy { //nolint:exhaustive // Other groupings are rendered by month.
+	case GroupByDaily:
+		return "Date"
+	case GroupByQuarterly:
+		return "Quarter"
+	case GroupByYearly:
+		return "Year"
+	default:
+		return "Month"
+	}
+}
+
 // renderCrossProviderTable writes a cross-provider cost table to stdout.
 // It formats one row per aggregation period and one column per provider, with the first
 // column labeled "Date" when groupBy is GroupByDaily or "Month" otherwise.
@@ -542,7 +663,7 @@ func renderNDJSON(writer io.Writer, results []CostResult) error {
 // Parameters:
 //   - writer: destination for the formatted table output.
 //   - aggregations: slice of CrossProviderAggregation values to render as rows.
-//   - groupBy: controls whether the first column is labeled "Date" (GroupByDaily) or "Month".
+//   - groupBy: labels the first column (see periodColumnLabel).
 //
 // The function returns any error encountered while writing to the writer or flushing the tabwriter.
 func renderCrossProviderTable(
@@ -573,11 +694,8 @@ func renderCrossProviderTable(
 	sort.Strings(providers) // Sort alphabetically for consistent ordering
 
 	// Print header
-	if groupBy == GroupByDaily {
-		fmt.Fprintf(w, "Date\tTotal Cost")
-	} else {
-		fmt.Fprintf(w, "Month\tTotal Cost")
-	}
+	label := periodColumnLabel(groupBy)
+	fmt.Fprintf(w, "%s\tTotal Cost", label)
 
 	for _, provider := range providers {
 		fmt.Fprintf(w, "\t%s", provider)
@@ -585,11 +703,7 @@ func renderCrossProviderTable(
 	fmt.Fprintf(w, "\n")
 
 	// Print separator
-	if groupBy == GroupByDaily {
-		fmt.Fprintf(w, "----\t----------")
-	} else {
-		fmt.Fprintf(w, "-----\t----------")
-	}
+	fmt.Fprintf(w, "%s\t----------", strings.Repeat("-", len(label)))
 
 	for range providers {
 		fmt.Fprintf(w, "\t--------")
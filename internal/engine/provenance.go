@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// Causes of a cost change between two snapshots.
+const (
+	ChangeCauseInfrastructure = "infrastructure"
+	ChangeCausePricing        = "pricing"
+	ChangeCauseUnknown        = "unknown"
+)
+
+const (
+	// specPricingVersionLen is the number of hex digits kept from a spec pricing hash.
+	specPricingVersionLen = 12
+
+	adapterLocalSpec = "local-spec"
+	// adapterLocalSpecEstimate marks actual costs estimated from a spec because no plugin
+	// reported billed data.
+	adapterLocalSpecEstimate = "local-spec-estimate"
+)
+
+// specPricingDateKeys are the spec metadata keys read as the date its prices were published.
+var specPricingDateKeys = []string{"pricing_date", "pricingDate"}
+
+// PricingProvenance records where a result's price came from, so that a later run can tell
+// whether a cost changed because the resource changed or because the pricing data did.
+type PricingProvenance struct {
+	// Source is the plugin name, or "local-spec".
+	Source string `json:"source"`
+	// SourceVersion is the plugin version, or the spec format version.
+	SourceVersion string `json:"sourceVersion,omitempty"`
+	// PricingVersion identifies the pricing data: the plugin's ETag when it supplies one,
+	// or a hash of the spec's prices.
+	PricingVersion string `json:"pricingVersion,omitempty"`
+	// PricingDate is when the pricing data was published, if the source reports it.
+	PricingDate string `json:"pricingDate,omitempty"`
+	// InputFingerprint hashes the resource's type, provider and properties.
+	InputFingerprint string `json:"inputFingerprint"`
+}
+
+// hashParts returns the hex SHA-256 of parts, each terminated by a NUL byte.
+func hashParts(parts ...string) string {
+	sum := sha256.New()
+	for _, part := range parts {
+		sum.Write([]byte(part))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// resourceFingerprint hashes the inputs that determine a resource's price. The resource ID
+// is left out so identically configured resources fingerprint equally.
+func resourceFingerprint(resource ResourceDescriptor) string {
+	// json.Marshal sorts map keys, so equal property maps hash equally.
+	props, _ := json.Marshal(resource.Properties)
+	return hashParts(resource.Provider, resource.Type, string(props))
+}
+
+// pluginProvenance describes a price returned by client.
+func pluginProvenance(
+	client *pluginhost.Client,
+	resource ResourceDescriptor,
+	etag, pricingDate string,
+) *PricingProvenance {
+	p := &PricingProvenance{
+		Source:           client.Name,
+		PricingVersion:   etag,
+		PricingDate:      pricingDate,
+		InputFingerprint: resourceFingerprint(resource),
+	}
+	if client.Metadata != nil {
+		p.SourceVersion = client.Metadata.Version
+	}
+	return p
+}
+
+// specProvenance describes a price calculated from spec.
+func specProvenance(spec *PricingSpec, resource ResourceDescriptor) *PricingProvenance {
+	prices, _ := json.Marshal(spec.Pricing)
+	p := &PricingProvenance{
+		Source:           adapterLocalSpec,
+		PricingVersion:   "sha256:" + hashParts(spec.Currency, string(prices))[:specPricingVersionLen],
+		InputFingerprint: resourceFingerprint(resource),
+	}
+	if spec.Version > 0 {
+		p.SourceVersion = "v" + strconv.Itoa(spec.Version)
+	}
+	for _, key := range specPricingDateKeys {
+		if date, ok := spec.Metadata[key]; ok {
+			p.PricingDate = fmt.Sprint(date)
+			break
+		}
+	}
+	return p
+}
+
+// CostChange is a resource whose monthly cost differs between two snapshots, with the
+// most likely cause.
+type CostChange struct {
+	ResourceType string  `json:"resourceType"`
+	ResourceID   string  `json:"resourceId"`
+	Previous     float64 `json:"previous"`
+	Current      float64 `json:"current"`
+	Delta        float64 `json:"delta"`
+	Cause        string  `json:"cause"`
+	Detail       string  `json:"detail"`
+}
+
+// CostChangeExplanation splits the cost difference between two snapshots by cause.
+type CostChangeExplanation struct {
+	Currency            string       `json:"currency"`
+	Delta               float64      `json:"delta"`
+	InfrastructureDelta float64      `json:"infrastructureDelta"`
+	PricingDelta        float64      `json:"pricingDelta"`
+	UnknownDelta        float64      `json:"unknownDelta"`
+	Changes             []CostChange `json:"changes"`
+}
+
+// ExplainCostChanges compares a prior snapshot with current results and attributes each
+// resource's cost change to an infrastructure change (the resource was added, removed or
+// its inputs changed) or a pricing-data change (same inputs, different pricing source or
+// version). Changes are unknown when either side lacks provenance. Resources are matched
+// by type and ID.
+func ExplainCostChanges(prior, current []CostResult) *CostChangeExplanation {
+	explanation := &CostChangeExplanation{Currency: defaultCurrency, Changes: []CostChange{}}
+	for _, r := range current {
+		if r.Currency != "" {
+			explanation.Currency = r.Currency
+		}
+	}
+
+	for _, p := range matchResults(prior, current, urnIdentity) {
+		delta := p.delta()
+		if math.Abs(delta) < costEpsilon {
+			continue
+		}
+		change := CostChange{
+			ResourceType: p.resourceType,
+			ResourceID:   p.resourceID,
+			Previous:     p.before.monthly,
+			Current:      p.after.monthly,
+			Delta:        delta,
+		}
+		change.Cause, change.Detail = changeCause(p.before.present, p.after.present,
+			p.before.provenance, p.after.provenance)
+
+		explanation.Delta += delta
+		switch change.Cause {
+		case ChangeCauseInfrastructure:
+			explanation.InfrastructureDelta += delta
+		case ChangeCausePricing:
+			explanation.PricingDelta += delta
+		default:
+			explanation.UnknownDelta += delta
+		}
+		explanation.Changes = append(explanation.Changes, change)
+	}
+	return explanation
+}
+
+// changeCause classifies one resource's cost change and describes the evidence.
+func changeCause(inPrior, inCurrent bool, prior, current *PricingProvenance) (string, string) {
+	switch {
+	case !inPrior:
+		return ChangeCauseInfrastructure, "resource added"
+	case !inCurrent:
+		return ChangeCauseInfrastructure, "resource removed"
+	case prior == nil || current == nil:
+		return ChangeCauseUnknown, "no pricing provenance recorded"
+	case prior.InputFingerprint != current.InputFingerprint:
+		return ChangeCauseInfrastructure, "resource inputs changed"
+	}
+
+	var diffs []string
+	for _, d := range []struct{ name, from, to string }{
+		{"source", prior.Source, current.Source},
+		{"source version", prior.SourceVersion, current.SourceVersion},
+		{"pricing version", prior.PricingVersion, current.PricingVersion},
+		{"pricing date", prior.PricingDate, current.PricingDate},
+	} {
+		if d.from != d.to {
+			diffs = append(diffs, fmt.Sprintf("%s %s -> %s", d.name, orNone(d.from), orNone(d.to)))
+		}
+	}
+	if len(diffs) == 0 {
+		return ChangeCauseUnknown, "inputs and pricing source unchanged"
+	}
+	return ChangeCausePricing, strings.Join(diffs, ", ")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// RenderCostChanges writes an explanation as JSON, or as a summary line and a change table.
+func RenderCostChanges(writer io.Writer, format OutputFormat, explanation *CostChangeExplanation) error {
+	if format == OutputJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(explanation)
+	}
+	if format != OutputTable {
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	fmt.Fprintf(writer, "Cost change since snapshot: %+.2f %s (infrastructure %+.2f, pricing %+.2f, unknown %+.2f)\n",
+		explanation.Delta, explanation.Currency,
+		explanation.InfrastructureDelta, explanation.PricingDelta, explanation.UnknownDelta)
+	if len(explanation.Changes) == 0 {
+		fmt.Fprintln(writer, "No resource costs changed.")
+		return nil
+	}
+
+	fmt.Fprintln(writer)
+	w := tabwriter.NewWriter(writer, 0, 0, defaultTabPadding, ' ', 0)
+	fmt.Fprintln(w, "Resource\tPrevious\tCurrent\tChange\tCause")
+	fmt.Fprintln(w, "--------\t--------\t-------\t------\t-----")
+	for _, c := range explanation.Changes {
+		resource := fmt.Sprintf("%s/%s", c.ResourceType, c.ResourceID)
+		if len(resource) > maxResourceDisplayLen {
+			resource = resource[:maxResourceDisplayLen-len(truncationEllipsis)] + truncationEllipsis
+		}
+		fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%s\t%s (%s)\n",
+			resource, c.Previous, c.Current, formatDeltaWithPercent(c.Delta, c.Previous), c.Cause, c.Detail)
+	}
+	return w.Flush()
+}
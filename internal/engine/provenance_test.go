@@ -0,0 +1,109 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricingProvenance_Spec(t *testing.T) {
+	resource := engine.ResourceDescriptor{
+		Type:       "aws:ec2/instance:Instance",
+		ID:         "web",
+		Provider:   "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}
+
+	results, err := newScalingGroupTestEngine(t).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Nil(t, results[0].Provenance, "provenance is only recorded when enabled")
+
+	results, err = newScalingGroupTestEngine(t, engine.EngineOptions{PricingProvenance: true}).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	p := results[0].Provenance
+	require.NotNil(t, p)
+	assert.Equal(t, "local-spec", p.Source)
+	assert.Regexp(t, `^sha256:[0-9a-f]{12}$`, p.PricingVersion)
+	assert.NotEmpty(t, p.InputFingerprint)
+}
+
+func TestPricingProvenance_Plugin(t *testing.T) {
+	api := &etagAPI{etag: `"2026-10"`, monthly: 10}
+	clients := []*pluginhost.Client{{
+		Name:     "pricing",
+		API:      api,
+		Metadata: &proto.PluginMetadata{Version: "1.4.0"},
+	}}
+
+	results, err := newTestEngine(t, clients, nil, engine.EngineOptions{PricingProvenance: true}).
+		GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+			Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NotNil(t, results[0].Provenance)
+	assert.Equal(t, "pricing", results[0].Provenance.Source)
+	assert.Equal(t, "1.4.0", results[0].Provenance.SourceVersion)
+	assert.Equal(t, `"2026-10"`, results[0].Provenance.PricingVersion)
+}
+
+func TestExplainCostChanges(t *testing.T) {
+	provenance := func(fingerprint, pricingVersion string) *engine.PricingProvenance {
+		return &engine.PricingProvenance{
+			Source: "local-spec", PricingVersion: pricingVersion, InputFingerprint: fingerprint,
+		}
+	}
+	result := func(id string, monthly float64, p *engine.PricingProvenance) engine.CostResult {
+		return engine.CostResult{
+			ResourceType: "aws:ec2/instance:Instance", ResourceID: id, Currency: "USD",
+			Monthly: monthly, Provenance: p,
+		}
+	}
+
+	prior := []engine.CostResult{
+		result("resized", 10, provenance("small", "sha256:a")),
+		result("repriced", 10, provenance("same", "sha256:a")),
+		result("unchanged", 10, provenance("same", "sha256:a")),
+		result("legacy", 10, nil),
+		result("removed", 5, provenance("same", "sha256:a")),
+	}
+	current := []engine.CostResult{
+		result("resized", 40, provenance("large", "sha256:b")),
+		result("repriced", 12, provenance("same", "sha256:b")),
+		result("unchanged", 10, provenance("same", "sha256:a")),
+		result("legacy", 11, provenance("same", "sha256:a")),
+		result("added", 3, provenance("new", "sha256:a")),
+	}
+
+	explanation := engine.ExplainCostChanges(prior, current)
+	causes := make(map[string]string)
+	for _, c := range explanation.Changes {
+		causes[c.ResourceID] = c.Cause
+	}
+	assert.Equal(t, map[string]string{
+		"resized":  engine.ChangeCauseInfrastructure,
+		"repriced": engine.ChangeCausePricing,
+		"legacy":   engine.ChangeCauseUnknown,
+		"removed":  engine.ChangeCauseInfrastructure,
+		"added":    engine.ChangeCauseInfrastructure,
+	}, causes)
+	assert.InDelta(t, 28.0, explanation.InfrastructureDelta, 0.001)
+	assert.InDelta(t, 2.0, explanation.PricingDelta, 0.001)
+	assert.InDelta(t, 1.0, explanation.UnknownDelta, 0.001)
+	assert.Equal(t, "resized", explanation.Changes[0].ResourceID, "largest change first")
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderCostChanges(&buf, engine.OutputTable, explanation))
+	assert.Contains(t, buf.String(), "infrastructure +28.00, pricing +2.00, unknown +1.00")
+	assert.Contains(t, buf.String(), "pricing (pricing version sha256:a -> sha256:b)")
+}
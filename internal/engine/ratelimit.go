@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces calls evenly so that no more than the configured number of
+// requests per second are issued. A nil *rateLimiter imposes no limit.
+//
+// Billing APIs (AWS Cost Explorer, Azure Cost Management) throttle aggressively,
+// so the actual-cost worker pool shares a single limiter per query.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter returns a limiter allowing requestsPerSecond calls, or nil when
+// requestsPerSecond is not positive.
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// Wait blocks until the caller may proceed or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getActualCostWorkerCount returns the worker count for actual-cost queries. It honours
+// EngineOptions.ActualCostWorkers and otherwise falls back to getWorkerCount.
+func (e *Engine) getActualCostWorkerCount(jobCount int) int {
+	if jobCount == 0 {
+		return 0
+	}
+	if e.options.ActualCostWorkers > 0 {
+		return min(e.options.ActualCostWorkers, jobCount)
+	}
+	return e.getWorkerCount(jobCount)
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter_Unlimited(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0))
+	assert.Nil(t, newRateLimiter(-1))
+
+	var l *rateLimiter
+	require.NoError(t, l.Wait(context.Background()))
+}
+
+func TestRateLimiter_SpacesCalls(t *testing.T) {
+	l := newRateLimiter(50) // one call every 20ms
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 5 {
+		require.NoError(t, l.Wait(ctx))
+	}
+	// The first call is immediate, the remaining four are spaced 20ms apart.
+	assert.GreaterOrEqual(t, time.Since(start), 75*time.Millisecond)
+}
+
+func TestRateLimiter_ContextCancelled(t *testing.T) {
+	l := newRateLimiter(0.5) // one call every 2s
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := l.Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGetActualCostWorkerCount(t *testing.T) {
+	t.Run("option capped by job count", func(t *testing.T) {
+		e, err := New(nil, nil, EngineOptions{ActualCostWorkers: 4})
+		require.NoError(t, err)
+		assert.Equal(t, 4, e.getActualCostWorkerCount(100))
+		assert.Equal(t, 2, e.getActualCostWorkerCount(2))
+		assert.Equal(t, 0, e.getActualCostWorkerCount(0))
+	})
+
+	t.Run("unset falls back to the worker count", func(t *testing.T) {
+		e, err := New(nil, nil, EngineOptions{Workers: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 3, e.getActualCostWorkerCount(100))
+	})
+}
+
+func TestActualCostOptions_Validate(t *testing.T) {
+	_, err := New(nil, nil, EngineOptions{ActualCostWorkers: -1})
+	require.ErrorIs(t, err, ErrInvalidEngineOption)
+	for _, limit := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err = New(nil, nil, EngineOptions{ActualCostRateLimit: limit})
+		require.ErrorIs(t, err, ErrInvalidEngineOption)
+	}
+}
@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// reconcileAbsTolerance absorbs floating-point noise in totals near zero.
+	reconcileAbsTolerance = 1e-6
+	// reconcileRelTolerance absorbs the rounding of summing many large amounts in a
+	// different order.
+	reconcileRelTolerance = 1e-9
+)
+
+// ErrTotalsMismatch is returned when aggregated totals do not add up to the costs of the
+// resources they were computed from, which means a resource was dropped or counted twice.
+var ErrTotalsMismatch = errors.New("aggregated totals do not reconcile with resource costs")
+
+// currencyTotals are the summed amounts of the results priced in one currency.
+type currencyTotals struct {
+	monthly, hourly, totalCost float64
+}
+
+// sumByCurrency sums results per currency, an empty currency counting as USD.
+func sumByCurrency(results []CostResult) map[string]currencyTotals {
+	sums := make(map[string]currencyTotals)
+	for _, r := range results {
+		t := sums[resultCurrency(r)]
+		t.monthly += r.Monthly
+		t.hourly += r.Hourly
+		t.totalCost += r.TotalCost
+		sums[resultCurrency(r)] = t
+	}
+	return sums
+}
+
+// ReconcileTotals checks that aggregated, the grouped form of raw, carries the same
+// monthly, hourly and total cost in every currency as raw does. Amounts in different
+// currencies are never compared with each other.
+func ReconcileTotals(raw, aggregated []CostResult) error {
+	want, got := sumByCurrency(raw), sumByCurrency(aggregated)
+	currencies := make([]string, 0, len(want)+len(got))
+	for currency := range want {
+		currencies = append(currencies, currency)
+	}
+	for currency := range got {
+		if _, ok := want[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Strings(currencies)
+
+	var errs []error
+	for _, currency := range currencies {
+		w, g := want[currency], got[currency]
+		errs = append(errs,
+			reconcileAmount(currency+" monthly", w.monthly, g.monthly),
+			reconcileAmount(currency+" hourly", w.hourly, g.hourly),
+			reconcileAmount(currency+" total cost", w.totalCost, g.totalCost))
+	}
+	return errors.Join(errs...)
+}
+
+// ReconcileSummary checks that a cost summary is internally consistent: its total equals
+// the sum of its resources, and each breakdown by provider, service and adapter adds up to
+// the total, separately per currency when the summary is split by currency.
+func ReconcileSummary(summary CostSummary) error {
+	if summary.ByCurrency == nil {
+		return reconcileSubtotal("", summary.Resources, summary.TotalMonthly, summary.TotalHourly,
+			summary.ByProvider, summary.ByService, summary.ByAdapter)
+	}
+
+	var errs []error
+	groups := GroupByCurrency(summary.Resources)
+	for _, currency := range SortedCurrencies(summary.ByCurrency) {
+		s := summary.ByCurrency[currency]
+		errs = append(errs, reconcileSubtotal(currency+" ", groups[currency], s.TotalMonthly, s.TotalHourly,
+			s.ByProvider, s.ByService, s.ByAdapter))
+		if s.ResourceCount != len(groups[currency]) {
+			errs = append(errs, fmt.Errorf("%w: %s resource count is %d, but %d resources are priced in %s",
+				ErrTotalsMismatch, currency, s.ResourceCount, len(groups[currency]), currency))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reconcileSubtotal checks one currency's totals and breakdowns against its resources.
+func reconcileSubtotal(
+	label string, resources []CostResult, monthly, hourly float64, breakdowns ...map[string]float64,
+) error {
+	var sumMonthly, sumHourly float64
+	for _, r := range resources {
+		sumMonthly += r.Monthly
+		sumHourly += r.Hourly
+	}
+	errs := []error{
+		reconcileAmount(label+"monthly", sumMonthly, monthly),
+		reconcileAmount(label+"hourly", sumHourly, hourly),
+	}
+	for i, name := range []string{"provider", "service", "adapter"} {
+		var sum float64
+		for _, amount := range breakdowns[i] {
+			sum += amount
+		}
+		errs = append(errs, reconcileAmount(label+"monthly by "+name, monthly, sum))
+	}
+	return errors.Join(errs...)
+}
+
+// reconcileAmount reports a mismatch between the expected and aggregated amount beyond
+// floating-point tolerance.
+func reconcileAmount(label string, want, got float64) error {
+	tolerance := math.Max(reconcileAbsTolerance, reconcileRelTolerance*math.Max(math.Abs(want), math.Abs(got)))
+	if math.Abs(want-got) <= tolerance {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is %.6f, expected %.6f (off by %+.6f)", ErrTotalsMismatch, label, got, want, got-want)
+}
+
+// verifyGrouping checks grouped against raw when totals verification is enabled.
+func (e *Engine) verifyGrouping(raw, grouped []CostResult) error {
+	if !e.options.VerifyTotals {
+		return nil
+	}
+	if err := ReconcileTotals(raw, grouped); err != nil {
+		return fmt.Errorf("grouping results: %w", err)
+	}
+	return e.verifySummary(grouped)
+}
+
+// verifySummary checks the summary of results when totals verification is enabled.
+func (e *Engine) verifySummary(results []CostResult) error {
+	if !e.options.VerifyTotals {
+		return nil
+	}
+	if err := ReconcileSummary(AggregateResults(results).Summary); err != nil {
+		return fmt.Errorf("summarizing results: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package engine_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcileFixture spans providers, days and currencies, including an unpriced resource.
+func reconcileFixture() []engine.CostResult {
+	jan1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan2 := jan1.AddDate(0, 0, 1)
+	return []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Adapter: "aws", Currency: "USD",
+			Monthly: 73, Hourly: 0.1, TotalCost: 2.4, StartDate: jan1, DailyCosts: []float64{2.4}},
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "api", Adapter: "aws", Currency: "USD",
+			Monthly: 146, Hourly: 0.2, TotalCost: 4.8, StartDate: jan2},
+		{ResourceType: "aws:rds/instance:Instance", ResourceID: "db", Adapter: "aws", Currency: "EUR",
+			Monthly: 200, Hourly: 0.27, TotalCost: 6.5, StartDate: jan1},
+		{ResourceType: "azure:compute:VirtualMachine", ResourceID: "vm", Adapter: "azure", Currency: "EUR",
+			Monthly: 50.5, Hourly: 0.07, StartDate: jan2},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "logs", Adapter: "none", StartDate: jan1},
+	}
+}
+
+func TestReconcileTotals_Grouping(t *testing.T) {
+	results := reconcileFixture()
+	eng := newTestEngine(t, nil, nil)
+
+	for _, groupBy := range []engine.GroupBy{
+		engine.GroupByResource, engine.GroupByType, engine.GroupByProvider,
+		engine.GroupByDate, engine.GroupByDaily, engine.GroupByMonthly,
+	} {
+		t.Run(string(groupBy), func(t *testing.T) {
+			grouped := eng.GroupResults(results, groupBy)
+			require.NoError(t, engine.ReconcileTotals(results, grouped))
+			require.NoError(t, engine.ReconcileSummary(engine.AggregateResults(grouped).Summary))
+		})
+	}
+
+	expr, err := engine.ParseExpression("provider")
+	require.NoError(t, err)
+	grouped := eng.GroupResultsByExpression(results, expr, nil)
+	require.NoError(t, engine.ReconcileTotals(results, grouped))
+
+	var aws []engine.CostResult
+	for _, r := range grouped {
+		if r.ResourceType == "aws" {
+			aws = append(aws, r)
+		}
+	}
+	require.Len(t, aws, 2, "a group spanning currencies is aggregated per currency")
+	assert.Equal(t, "EUR", aws[0].Currency)
+	assert.InDelta(t, 200.0, aws[0].Monthly, 0.0001)
+	assert.Equal(t, "USD", aws[1].Currency)
+	assert.InDelta(t, 219.0, aws[1].Monthly, 0.0001)
+}
+
+func TestReconcileTotals_Mismatch(t *testing.T) {
+	results := reconcileFixture()
+
+	err := engine.ReconcileTotals(results, results[1:])
+	require.ErrorIs(t, err, engine.ErrTotalsMismatch)
+	assert.ErrorContains(t, err, "USD monthly is 146.000000, expected 219.000000 (off by -73.000000)")
+
+	doubled := append(append([]engine.CostResult{}, results...), results[2])
+	err = engine.ReconcileTotals(results, doubled)
+	require.ErrorIs(t, err, engine.ErrTotalsMismatch)
+	assert.ErrorContains(t, err, "EUR monthly")
+	assert.NotContains(t, err.Error(), "USD", "currencies are reconciled separately")
+}
+
+func TestReconcileSummary(t *testing.T) {
+	summary := engine.AggregateResults(reconcileFixture()[:2]).Summary
+	require.NoError(t, engine.ReconcileSummary(summary))
+
+	summary.ByService["ec2"] += 10
+	err := engine.ReconcileSummary(summary)
+	require.ErrorIs(t, err, engine.ErrTotalsMismatch)
+	assert.ErrorContains(t, err, "monthly by service is 229.000000, expected 219.000000")
+
+	mixed := engine.AggregateResults(reconcileFixture()).Summary
+	require.NoError(t, engine.ReconcileSummary(mixed))
+	eur := mixed.ByCurrency["EUR"]
+	eur.ResourceCount = 3
+	mixed.ByCurrency["EUR"] = eur
+	assert.ErrorContains(t, engine.ReconcileSummary(mixed), "EUR resource count is 3")
+}
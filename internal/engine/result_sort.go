@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKey names the field results are ordered by with --sort.
+type SortKey string
+
+const (
+	// SortByMonthly orders results by monthly cost.
+	SortByMonthly SortKey = "monthly"
+	// SortByHourly orders results by hourly cost.
+	SortByHourly SortKey = "hourly"
+	// SortByTotal orders results by actual total cost.
+	SortByTotal SortKey = "total"
+	// SortByName orders results by resource ID.
+	SortByName SortKey = "name"
+	// SortByType orders results by resource type.
+	SortByType SortKey = "type"
+)
+
+// ErrInvalidSort is returned by ParseResultSort for an unknown key or direction.
+var ErrInvalidSort = errors.New("invalid sort")
+
+// ResultSort is a parsed --sort value.
+type ResultSort struct {
+	Key        SortKey
+	Descending bool
+}
+
+// ParseResultSort parses "key" or "key:asc" / "key:desc", where key is monthly, hourly,
+// total, name or type. Without a direction costs sort from highest to lowest and names
+// and types alphabetically.
+func ParseResultSort(value string) (ResultSort, error) {
+	keyPart, direction, hasDirection := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+	key := SortKey(keyPart)
+	var s ResultSort
+	switch key {
+	case SortByMonthly, SortByHourly, SortByTotal:
+		s = ResultSort{Key: key, Descending: true}
+	case SortByName, SortByType:
+		s = ResultSort{Key: key}
+	default:
+		return ResultSort{}, fmt.Errorf("%w %q: key must be monthly, hourly, total, name or type", ErrInvalidSort, value)
+	}
+	if hasDirection {
+		switch direction {
+		case "asc":
+			s.Descending = false
+		case "desc":
+			s.Descending = true
+		default:
+			return ResultSort{}, fmt.Errorf("%w %q: direction must be asc or desc", ErrInvalidSort, value)
+		}
+	}
+	return s, nil
+}
+
+// String returns the sort in the "key:direction" form ParseResultSort accepts.
+func (s ResultSort) String() string {
+	if s.Descending {
+		return string(s.Key) + ":desc"
+	}
+	return string(s.Key) + ":asc"
+}
+
+// SortResults returns a copy of results ordered by s. Results that tie on the key are
+// ordered by resource ID, so the output is the same on every run.
+func SortResults(results []CostResult, s ResultSort) []CostResult {
+	sorted := make([]CostResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if c := compareSortKey(sorted[i], sorted[j], s.Key); c != 0 {
+			return (c < 0) != s.Descending
+		}
+		return sorted[i].ResourceID < sorted[j].ResourceID
+	})
+	return sorted
+}
+
+// compareSortKey compares a and b by key, returning -1, 0 or 1.
+func compareSortKey(a, b CostResult, key SortKey) int {
+	switch key {
+	case SortByMonthly:
+		return cmp.Compare(a.Monthly, b.Monthly)
+	case SortByHourly:
+		return cmp.Compare(a.Hourly, b.Hourly)
+	case SortByTotal:
+		return cmp.Compare(a.TotalCost, b.TotalCost)
+	case SortByName:
+		return strings.Compare(a.ResourceID, b.ResourceID)
+	case SortByType:
+		return strings.Compare(a.ResourceType, b.ResourceType)
+	default:
+		return 0
+	}
+}
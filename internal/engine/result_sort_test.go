@@ -0,0 +1,62 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResultSort(t *testing.T) {
+	tests := []struct {
+		value string
+		want  engine.ResultSort
+	}{
+		{"monthly", engine.ResultSort{Key: engine.SortByMonthly, Descending: true}},
+		{"monthly:asc", engine.ResultSort{Key: engine.SortByMonthly}},
+		{"Hourly:DESC", engine.ResultSort{Key: engine.SortByHourly, Descending: true}},
+		{"total", engine.ResultSort{Key: engine.SortByTotal, Descending: true}},
+		{"name", engine.ResultSort{Key: engine.SortByName}},
+		{"type:desc", engine.ResultSort{Key: engine.SortByType, Descending: true}},
+	}
+	for _, tt := range tests {
+		got, err := engine.ParseResultSort(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "cost", "monthly:up", "name:"} {
+		_, err := engine.ParseResultSort(value)
+		require.ErrorIs(t, err, engine.ErrInvalidSort, value)
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "c", ResourceType: "aws:s3/bucket:Bucket", Monthly: 5, Hourly: 0.01},
+		{ResourceID: "b", ResourceType: "aws:ec2/instance:Instance", Monthly: 20, Hourly: 0.03},
+		{ResourceID: "a", ResourceType: "aws:s3/bucket:Bucket", Monthly: 5, Hourly: 0.02},
+		{ResourceID: "d", ResourceType: "aws:rds/instance:Instance", Monthly: 12, Hourly: 0.04},
+	}
+	ids := func(rs []engine.CostResult) []string {
+		out := make([]string, len(rs))
+		for i, r := range rs {
+			out[i] = r.ResourceID
+		}
+		return out
+	}
+	sortBy := func(value string) []string {
+		s, err := engine.ParseResultSort(value)
+		require.NoError(t, err)
+		return ids(engine.SortResults(results, s))
+	}
+
+	assert.Equal(t, []string{"b", "d", "a", "c"}, sortBy("monthly"), "ties are broken by resource ID")
+	assert.Equal(t, []string{"a", "c", "d", "b"}, sortBy("monthly:asc"))
+	assert.Equal(t, []string{"d", "b", "a", "c"}, sortBy("hourly"))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, sortBy("name"))
+	assert.Equal(t, []string{"b", "d", "a", "c"}, sortBy("type"))
+	assert.Equal(t, []string{"a", "c", "d", "b"}, sortBy("type:desc"))
+	assert.Equal(t, []string{"c", "b", "a", "d"}, ids(results), "the input is not reordered")
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Plugin call retry defaults.
+const (
+	defaultRetryAttempts  = 3
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	maxRetryDelay         = 2 * time.Second
+	retryBackoffFactor    = 2
+)
+
+// pluginAttemptsError is a plugin call failure that was retried, recording how many
+// attempts were made.
+type pluginAttemptsError struct {
+	err      error
+	attempts int
+}
+
+func (e *pluginAttemptsError) Error() string { return e.err.Error() }
+
+func (e *pluginAttemptsError) Unwrap() error { return e.err }
+
+// pluginCallAttempts returns how many times the plugin call that failed with err was
+// attempted.
+func pluginCallAttempts(err error) int {
+	var retried *pluginAttemptsError
+	if errors.As(err, &retried) {
+		return retried.attempts
+	}
+	return 1
+}
+
+// isTransientPluginError reports whether a plugin call failed in a way that may succeed
+// when tried again, such as a plugin reconnecting to its billing API.
+func isTransientPluginError(err error) bool {
+	switch status.Code(err) { //nolint:exhaustive // Every other code fails fast.
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryPlugin runs call, retrying transient failures with exponential backoff up to
+// EngineOptions.RetryAttempts attempts in all. It stops early once ctx is done, and
+// failures after more than one attempt record the attempt count.
+func retryPlugin[T any](
+	ctx context.Context,
+	e *Engine,
+	client *pluginhost.Client,
+	call func() (T, error),
+) (T, error) {
+	attempts := e.options.RetryAttempts
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+	delay := e.options.RetryBaseDelay
+	if delay <= 0 {
+		delay = defaultRetryBaseDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := call()
+		if err == nil || !isTransientPluginError(err) || attempt >= attempts || ctx.Err() != nil {
+			if err != nil && attempt > 1 {
+				err = &pluginAttemptsError{err: err, attempts: attempt}
+			}
+			return resp, err
+		}
+
+		logging.FromContext(ctx).Debug().Ctx(ctx).
+			Str("component", "engine").
+			Str("plugin", client.Name).
+			Int("attempt", attempt).
+			Dur("backoff", delay).
+			Err(err).
+			Msg("retrying transient plugin failure")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, &pluginAttemptsError{err: err, attempts: attempt}
+		}
+		delay = min(delay*retryBackoffFactor, maxRetryDelay)
+	}
+}
@@ -0,0 +1,172 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyAPI fails its first failures calls with code, then prices every resource at 10.
+type flakyAPI struct {
+	proto.CostSourceClient
+
+	code     codes.Code
+	failures int32
+	calls    atomic.Int32
+}
+
+func (a *flakyAPI) fail() error {
+	if a.calls.Add(1) <= a.failures {
+		return status.Error(a.code, "billing API reconnecting")
+	}
+	return nil
+}
+
+func (a *flakyAPI) GetProjectedCost(
+	_ context.Context,
+	_ *proto.GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	if err := a.fail(); err != nil {
+		return nil, err
+	}
+	return &proto.GetProjectedCostResponse{
+		Results: []*proto.CostResult{{Currency: "USD", MonthlyCost: 10}},
+	}, nil
+}
+
+func (a *flakyAPI) GetActualCost(
+	_ context.Context,
+	_ *proto.GetActualCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	if err := a.fail(); err != nil {
+		return nil, err
+	}
+	return &proto.GetActualCostResponse{
+		Results: []*proto.ActualCostResult{{Currency: "USD", TotalCost: 10}},
+	}, nil
+}
+
+var retryTestResource = engine.ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws"}
+
+func TestGetProjectedCost_RetriesTransientFailures(t *testing.T) {
+	for _, code := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded} {
+		t.Run(code.String(), func(t *testing.T) {
+			api := &flakyAPI{code: code, failures: 2}
+			eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-ce", API: api}}, nil,
+				engine.EngineOptions{RetryBaseDelay: time.Millisecond})
+
+			result, err := eng.GetProjectedCostWithErrors(context.Background(),
+				[]engine.ResourceDescriptor{retryTestResource})
+			require.NoError(t, err)
+			assert.Empty(t, result.Errors)
+			require.Len(t, result.Results, 1)
+			assert.InDelta(t, 10.0, result.Results[0].Monthly, 0.001)
+			assert.Equal(t, int32(3), api.calls.Load())
+		})
+	}
+}
+
+func TestGetProjectedCost_RetryGivesUp(t *testing.T) {
+	api := &flakyAPI{code: codes.Unavailable, failures: 100}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-ce", API: api}}, nil,
+		engine.EngineOptions{RetryAttempts: 2, RetryBaseDelay: time.Millisecond})
+
+	result, err := eng.GetProjectedCostWithErrors(context.Background(),
+		[]engine.ResourceDescriptor{retryTestResource})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, codes.Unavailable, status.Code(result.Errors[0].Error))
+	assert.Equal(t, int32(2), api.calls.Load())
+}
+
+func TestGetProjectedCost_NonTransientFailsFast(t *testing.T) {
+	api := &flakyAPI{code: codes.InvalidArgument, failures: 100}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-ce", API: api}}, nil,
+		engine.EngineOptions{RetryBaseDelay: time.Millisecond})
+
+	result, err := eng.GetProjectedCostWithErrors(context.Background(),
+		[]engine.ResourceDescriptor{retryTestResource})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, int32(1), api.calls.Load())
+}
+
+func TestGetActualCost_RetriesTransientFailures(t *testing.T) {
+	api := &flakyAPI{code: codes.Unavailable, failures: 1}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-ce", API: api}}, nil,
+		engine.EngineOptions{RetryBaseDelay: time.Millisecond})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results, err := eng.GetActualCost(context.Background(),
+		[]engine.ResourceDescriptor{retryTestResource}, from, from.AddDate(0, 0, 10))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.InDelta(t, 10.0, results[0].TotalCost, 0.001)
+	assert.Equal(t, int32(2), api.calls.Load())
+}
+
+func TestGetProjectedCost_RetryStopsWhenContextEnds(t *testing.T) {
+	api := &flakyAPI{code: codes.Unavailable, failures: 100}
+	eng := newTestEngine(t, []*pluginhost.Client{{Name: "aws-ce", API: api}}, nil,
+		engine.EngineOptions{RetryAttempts: 10, RetryBaseDelay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _ = eng.GetProjectedCostWithErrors(ctx, []engine.ResourceDescriptor{retryTestResource})
+	assert.Less(t, time.Since(start), time.Second, "backoff does not outlive the context")
+	assert.Equal(t, int32(1), api.calls.Load())
+}
+
+func TestGetActualCostWithOptions_LogsPartialErrors(t *testing.T) {
+	clients := []*pluginhost.Client{
+		{Name: "aws-ce", API: &flakyAPI{code: codes.InvalidArgument, failures: 100}},
+		{Name: "kubecost", API: &flakyAPI{code: codes.Unavailable, failures: 100}},
+	}
+	eng := newTestEngine(t, clients, nil, engine.EngineOptions{RetryBaseDelay: time.Millisecond})
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).WithContext(context.Background())
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results, err := eng.GetActualCostWithOptions(ctx, engine.ActualCostRequest{
+		Resources: []engine.ResourceDescriptor{retryTestResource},
+		From:      from,
+		To:        from.AddDate(0, 0, 10),
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "No actual cost data available (2 plugin call(s) failed)", results[0].Notes)
+
+	var failures []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["message"] == "plugin call failed for actual cost" {
+			failures = append(failures, entry)
+		}
+	}
+	require.Len(t, failures, 2)
+	for i, plugin := range []string{"aws-ce", "kubecost"} {
+		assert.Equal(t, "warn", failures[i]["level"])
+		assert.Equal(t, plugin, failures[i]["plugin"])
+		assert.Equal(t, "web", failures[i]["resource_id"])
+	}
+	assert.InDelta(t, 1.0, failures[0]["attempts"], 0, "non-transient failures are not retried")
+	assert.InDelta(t, 3.0, failures[1]["attempts"], 0)
+}
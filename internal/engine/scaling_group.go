@@ -0,0 +1,255 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Scaling group resource types recognized by the engine.
+const (
+	awsAutoScalingGroupType   = "aws:autoscaling/group:Group"
+	gcpNodePoolType           = "gcp:container/nodePool:NodePool"
+	azureNativeScaleSetType   = "azure-native:compute:VirtualMachineScaleSet"
+	azureLinuxScaleSetType    = "azure:compute/linuxVirtualMachineScaleSet:LinuxVirtualMachineScaleSet"
+	azureWindowsScaleSetType  = "azure:compute/windowsVirtualMachineScaleSet:WindowsVirtualMachineScaleSet"
+	awsInstanceType           = "aws:ec2/instance:Instance"
+	gcpInstanceType           = "gcp:compute/instance:Instance"
+	azureNativeVMType         = "azure-native:compute:VirtualMachine"
+	azureLinuxVMType          = "azure:compute/linuxVirtualMachine:LinuxVirtualMachine"
+	azureWindowsVMType        = "azure:compute/windowsVirtualMachine:WindowsVirtualMachine"
+	defaultScalingGroupWeight = 1
+)
+
+// scalingGroupLocationKeys are copied from the group to the per-instance descriptor so that
+// plugins and specs resolve the same region.
+var scalingGroupLocationKeys = []string{"region", "zone", "location", "availabilityZone"}
+
+// ScalingCapacity records how a scaling group (ASG, node pool, scale set) was priced.
+// The group is priced as Instances × the cost of one InstanceType instance; MonthlyMin
+// and MonthlyMax give the cost range at the group's minimum and maximum capacity.
+type ScalingCapacity struct {
+	InstanceType string `json:"instanceType"`
+	Desired      int    `json:"desired"`
+	Min          int    `json:"min"`
+	Max          int    `json:"max"`
+	// Weight is the capacity units contributed by one instance (mixed-instance ASGs).
+	Weight     int     `json:"weight,omitempty"`
+	Instances  int     `json:"instances"`
+	MonthlyMin float64 `json:"monthlyMin"`
+	MonthlyMax float64 `json:"monthlyMax"`
+}
+
+// scalingGroup pairs the original group resource with the single instance used to price it.
+type scalingGroup struct {
+	group    ResourceDescriptor
+	unit     ResourceDescriptor
+	capacity ScalingCapacity
+}
+
+// detectScalingGroup recognizes capacity-based resources and returns the per-instance
+// descriptor to price. It reports false when the resource is not a scaling group or its
+// instance type cannot be determined from the properties.
+func detectScalingGroup(resource ResourceDescriptor) (*scalingGroup, bool) {
+	var (
+		sg *scalingGroup
+		ok bool
+	)
+	switch resource.Type {
+	case awsAutoScalingGroupType:
+		sg, ok = detectAWSAutoScalingGroup(resource)
+	case gcpNodePoolType:
+		sg, ok = detectGCPNodePool(resource)
+	case azureNativeScaleSetType:
+		sg, ok = detectAzureNativeScaleSet(resource)
+	case azureLinuxScaleSetType:
+		sg, ok = detectAzureScaleSet(resource, azureLinuxVMType)
+	case azureWindowsScaleSetType:
+		sg, ok = detectAzureScaleSet(resource, azureWindowsVMType)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	sg.group = resource
+	sg.unit.ID = resource.ID
+	sg.unit.Provider = resource.Provider
+	for _, key := range scalingGroupLocationKeys {
+		if v, found := resource.Properties[key]; found {
+			sg.unit.Properties[key] = v
+		}
+	}
+	if sg.capacity.Weight == 0 {
+		sg.capacity.Weight = defaultScalingGroupWeight
+	}
+	sg.capacity.Instances = instancesForCapacity(sg.capacity.Desired, sg.capacity.Weight)
+	return sg, true
+}
+
+// detectAWSAutoScalingGroup reads desiredCapacity/minSize/maxSize. The instance type comes
+// from the first mixedInstancesPolicy override (with its weightedCapacity), or from an
+// instanceType property when the group was enriched with its launch template.
+func detectAWSAutoScalingGroup(resource ResourceDescriptor) (*scalingGroup, bool) {
+	props := resource.Properties
+	minSize := intProperty(props, "minSize", 0)
+	maxSize := intProperty(props, "maxSize", minSize)
+	desired := intProperty(props, "desiredCapacity", minSize)
+
+	instanceType, _ := getStringProperty(props, "instanceType")
+	weight := 0
+	if override, found := firstMixedInstancesOverride(props); found {
+		if t, hasType := getStringProperty(override, "instanceType"); hasType {
+			instanceType = t
+			weight = intProperty(override, "weightedCapacity", 0)
+		}
+	}
+	if instanceType == "" {
+		return nil, false
+	}
+
+	return &scalingGroup{
+		unit: ResourceDescriptor{
+			Type:       awsInstanceType,
+			Properties: map[string]interface{}{"instanceType": instanceType},
+		},
+		capacity: ScalingCapacity{
+			InstanceType: instanceType,
+			Desired:      desired,
+			Min:          minSize,
+			Max:          maxSize,
+			Weight:       weight,
+		},
+	}, true
+}
+
+// firstMixedInstancesOverride returns mixedInstancesPolicy.launchTemplate.overrides[0], the
+// highest-priority instance type of a mixed-instance ASG.
+func firstMixedInstancesOverride(props map[string]interface{}) (map[string]interface{}, bool) {
+	policy, ok := props["mixedInstancesPolicy"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	template, ok := policy["launchTemplate"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	overrides, ok := template["overrides"].([]interface{})
+	if !ok || len(overrides) == 0 {
+		return nil, false
+	}
+	override, ok := overrides[0].(map[string]interface{})
+	return override, ok
+}
+
+// detectGCPNodePool reads nodeCount (or initialNodeCount), the autoscaling bounds and
+// nodeConfig.machineType.
+func detectGCPNodePool(resource ResourceDescriptor) (*scalingGroup, bool) {
+	props := resource.Properties
+	nodeConfig, _ := props["nodeConfig"].(map[string]interface{})
+	machineType, ok := getStringProperty(nodeConfig, "machineType")
+	if !ok {
+		return nil, false
+	}
+
+	desired := intProperty(props, "nodeCount", intProperty(props, "initialNodeCount", 1))
+	minNodes, maxNodes := desired, desired
+	if autoscaling, hasAutoscaling := props["autoscaling"].(map[string]interface{}); hasAutoscaling {
+		minNodes = intProperty(autoscaling, "minNodeCount", desired)
+		maxNodes = intProperty(autoscaling, "maxNodeCount", desired)
+	}
+
+	return &scalingGroup{
+		unit: ResourceDescriptor{
+			Type:       gcpInstanceType,
+			Properties: map[string]interface{}{"machineType": machineType, "sku": machineType},
+		},
+		capacity: ScalingCapacity{InstanceType: machineType, Desired: desired, Min: minNodes, Max: maxNodes},
+	}, true
+}
+
+// detectAzureNativeScaleSet reads sku.name and sku.capacity. Autoscale bounds live in a
+// separate resource, so the range collapses to the current capacity.
+func detectAzureNativeScaleSet(resource ResourceDescriptor) (*scalingGroup, bool) {
+	sku, _ := resource.Properties["sku"].(map[string]interface{})
+	size, ok := getStringProperty(sku, "name")
+	if !ok {
+		return nil, false
+	}
+	capacity := intProperty(sku, "capacity", 1)
+	return newAzureScaleSet(azureNativeVMType, size, capacity), true
+}
+
+// detectAzureScaleSet handles the classic azure provider, where sku is the VM size and
+// instances the capacity.
+func detectAzureScaleSet(resource ResourceDescriptor, unitType string) (*scalingGroup, bool) {
+	size, ok := getStringProperty(resource.Properties, "sku")
+	if !ok {
+		return nil, false
+	}
+	capacity := intProperty(resource.Properties, "instances", 1)
+	return newAzureScaleSet(unitType, size, capacity), true
+}
+
+func newAzureScaleSet(unitType, size string, capacity int) *scalingGroup {
+	return &scalingGroup{
+		unit: ResourceDescriptor{
+			Type:       unitType,
+			Properties: map[string]interface{}{"vmSize": size, "sku": size},
+		},
+		capacity: ScalingCapacity{InstanceType: size, Desired: capacity, Min: capacity, Max: capacity},
+	}
+}
+
+// apply converts per-instance results back into group results: costs are multiplied by
+// the instance count at desired capacity, the min/max range is recorded, and the result
+// is attributed to the original group resource.
+func (sg *scalingGroup) apply(results []CostResult) {
+	for i := range results {
+		r := &results[i]
+		r.ResourceType = sg.group.Type
+		r.ResourceID = sg.group.ID
+		if r.Adapter == "none" {
+			r.Notes = fmt.Sprintf("No pricing information available for instance type %s", sg.capacity.InstanceType)
+			continue
+		}
+
+		unitMonthly := r.Monthly
+		factor := float64(sg.capacity.Instances)
+		r.Monthly *= factor
+		r.Hourly *= factor
+		for k, v := range r.Breakdown {
+			r.Breakdown[k] = v * factor
+		}
+
+		capacity := sg.capacity
+		capacity.MonthlyMin = unitMonthly * float64(instancesForCapacity(capacity.Min, capacity.Weight))
+		capacity.MonthlyMax = unitMonthly * float64(instancesForCapacity(capacity.Max, capacity.Weight))
+		r.Capacity = &capacity
+
+		note := fmt.Sprintf("Scaling group priced as %d × %s (capacity %d, min %d, max %d: %.2f–%.2f %s/month)",
+			capacity.Instances, capacity.InstanceType, capacity.Desired, capacity.Min, capacity.Max,
+			capacity.MonthlyMin, capacity.MonthlyMax, r.Currency)
+		if r.Notes != "" {
+			note += "; " + r.Notes
+		}
+		r.Notes = note
+	}
+}
+
+// instancesForCapacity returns the number of instances needed to provide capacity units
+// when each instance contributes weight units.
+func instancesForCapacity(capacity, weight int) int {
+	if weight <= 1 {
+		return capacity
+	}
+	return int(math.Ceil(float64(capacity) / float64(weight)))
+}
+
+// intProperty returns the numeric property key as an int, or def when absent or invalid.
+func intProperty(props map[string]interface{}, key string, def int) int {
+	if v, ok := props[key]; ok {
+		if f, isNum := parseFloatValue(v); isNum && f >= 0 {
+			return int(f)
+		}
+	}
+	return def
+}
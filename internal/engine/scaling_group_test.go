@@ -0,0 +1,131 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScalingGroupTestEngine(t *testing.T, opts ...engine.EngineOptions) *engine.Engine {
+	t.Helper()
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+		"gcp-compute-e2-medium.yaml": "provider: gcp\nservice: compute\nsku: e2-medium\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.03\n",
+	})
+	return newTestEngine(t, nil, loader, opts...)
+}
+
+func unitMonthly(t *testing.T, eng *engine.Engine, resource engine.ResourceDescriptor) float64 {
+	t.Helper()
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{resource})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	return results[0].Monthly
+}
+
+func TestScalingGroup_AWSAutoScalingGroup(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	unit := unitMonthly(t, eng, engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: "i", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	})
+	require.Greater(t, unit, 0.0)
+
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type:     "aws:autoscaling/group:Group",
+		ID:       "web-asg",
+		Provider: "aws",
+		Properties: map[string]interface{}{
+			"instanceType":    "t3.micro",
+			"desiredCapacity": 4.0,
+			"minSize":         2.0,
+			"maxSize":         10.0,
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	r := results[0]
+	assert.Equal(t, "aws:autoscaling/group:Group", r.ResourceType)
+	assert.Equal(t, "web-asg", r.ResourceID)
+	assert.InDelta(t, unit*4, r.Monthly, 0.0001)
+	require.NotNil(t, r.Capacity)
+	assert.Equal(t, 4, r.Capacity.Instances)
+	assert.InDelta(t, unit*2, r.Capacity.MonthlyMin, 0.0001)
+	assert.InDelta(t, unit*10, r.Capacity.MonthlyMax, 0.0001)
+	assert.Contains(t, r.Notes, "4 × t3.micro")
+}
+
+func TestScalingGroup_MixedInstancesWeighted(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	unit := unitMonthly(t, eng, engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: "i", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	})
+
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type:     "aws:autoscaling/group:Group",
+		ID:       "mixed",
+		Provider: "aws",
+		Properties: map[string]interface{}{
+			"desiredCapacity": 5.0,
+			"minSize":         1.0,
+			"maxSize":         8.0,
+			"mixedInstancesPolicy": map[string]interface{}{
+				"launchTemplate": map[string]interface{}{
+					"overrides": []interface{}{
+						map[string]interface{}{"instanceType": "t3.micro", "weightedCapacity": "2"},
+						map[string]interface{}{"instanceType": "t3.small", "weightedCapacity": "4"},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NotNil(t, results[0].Capacity)
+
+	// 5 capacity units at 2 units per instance needs 3 instances.
+	assert.Equal(t, 3, results[0].Capacity.Instances)
+	assert.Equal(t, 2, results[0].Capacity.Weight)
+	assert.InDelta(t, unit*3, results[0].Monthly, 0.0001)
+	assert.InDelta(t, unit*4, results[0].Capacity.MonthlyMax, 0.0001)
+}
+
+func TestScalingGroup_GCPNodePool(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type:     "gcp:container/nodePool:NodePool",
+		ID:       "pool",
+		Provider: "gcp",
+		Properties: map[string]interface{}{
+			"nodeCount":   3.0,
+			"nodeConfig":  map[string]interface{}{"machineType": "e2-medium"},
+			"autoscaling": map[string]interface{}{"minNodeCount": 1.0, "maxNodeCount": 6.0},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NotNil(t, results[0].Capacity)
+	assert.Equal(t, "local-spec", results[0].Adapter)
+	assert.Equal(t, 3, results[0].Capacity.Instances)
+	assert.InDelta(t, results[0].Capacity.MonthlyMax, results[0].Monthly*2, 0.0001)
+}
+
+func TestScalingGroup_UnknownInstanceTypeUnchanged(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	results, err := eng.GetProjectedCost(context.Background(), []engine.ResourceDescriptor{{
+		Type:       "aws:autoscaling/group:Group",
+		ID:         "asg",
+		Provider:   "aws",
+		Properties: map[string]interface{}{"desiredCapacity": 3.0},
+	}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Nil(t, results[0].Capacity)
+}
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+)
+
+// tryActualCostFromSpec estimates the cost of resource over the requested range from its
+// local pricing spec, as the spec's hourly rate times the hours in the range. It is the
+// last fallback of the actual-cost path, used only when no plugin returned a cost, and
+// returns nil when the range is open or no spec matches the resource.
+func (e *Engine) tryActualCostFromSpec(
+	ctx context.Context,
+	resource ResourceDescriptor,
+	request ActualCostRequest,
+) *CostResult {
+	if e.loader == nil || request.From.IsZero() || request.To.IsZero() || !request.To.After(request.From) {
+		return nil
+	}
+	projected := e.getProjectedCostFromSpec(ctx, resource)
+	if projected == nil {
+		return nil
+	}
+
+	hours := request.To.Sub(request.From).Hours()
+	result := *projected
+	result.Adapter = adapterLocalSpecEstimate
+	result.TotalCost = projected.Hourly * hours
+	result.Confidence = ConfidenceLow
+	result.Notes = fmt.Sprintf("ESTIMATE, not billed data: %.0f hours at the spec's hourly rate; %s",
+		hours, projected.Notes)
+	result.StartDate = request.From
+	result.EndDate = request.To
+	result.CostPeriod = FormatPeriod(request.From, request.To)
+	return &result
+}
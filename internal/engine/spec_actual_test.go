@@ -0,0 +1,100 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// billedOnlyAPI reports an actual cost for the resource billed and nothing for others.
+type billedOnlyAPI struct {
+	proto.CostSourceClient
+}
+
+func (billedOnlyAPI) GetActualCost(
+	_ context.Context,
+	req *proto.GetActualCostRequest,
+	_ ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	if req.ResourceIDs[0] != "billed" {
+		return &proto.GetActualCostResponse{}, nil
+	}
+	return &proto.GetActualCostResponse{
+		Results: []*proto.ActualCostResult{{Currency: "USD", TotalCost: 30}},
+	}, nil
+}
+
+func TestGetActualCost_SpecEstimateFallback(t *testing.T) {
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+	})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+	instance := func(id string) engine.ResourceDescriptor {
+		return engine.ResourceDescriptor{
+			Type: "aws:ec2/instance:Instance", ID: id, Provider: "aws",
+			Properties: map[string]interface{}{"instanceType": "t3.micro"},
+		}
+	}
+	clients := []*pluginhost.Client{{Name: "billing", API: billedOnlyAPI{}}}
+
+	res, err := newTestEngine(t, clients, loader).GetActualCostWithOptionsAndErrors(
+		context.Background(), engine.ActualCostRequest{
+			Resources: []engine.ResourceDescriptor{instance("billed"), instance("unbilled")},
+			From:      from,
+			To:        to,
+		})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 2)
+
+	billed, estimated := res.Results[0], res.Results[1]
+	if billed.ResourceID != "billed" {
+		billed, estimated = estimated, billed
+	}
+	assert.Equal(t, "billing", billed.Adapter, "the spec is not consulted when a plugin reports a cost")
+	assert.InDelta(t, 30.0, billed.TotalCost, 1e-9)
+
+	assert.Equal(t, "local-spec-estimate", estimated.Adapter)
+	assert.InDelta(t, 240*0.01, estimated.TotalCost, 1e-9)
+	assert.Equal(t, "USD", estimated.Currency)
+	assert.Equal(t, engine.ConfidenceLow, estimated.Confidence)
+	assert.Contains(t, estimated.Notes, "ESTIMATE, not billed data")
+	assert.Equal(t, from, estimated.StartDate)
+	assert.Equal(t, to, estimated.EndDate)
+
+	res, err = newTestEngine(t, clients, nil).GetActualCostWithOptionsAndErrors(context.Background(),
+		engine.ActualCostRequest{Resources: []engine.ResourceDescriptor{instance("unbilled")}, From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 1)
+	assert.Equal(t, "none", res.Results[0].Adapter, "without specs the placeholder remains")
+}
+
+func TestGetActualCostWithOptions_SpecEstimateWithoutPlugins(t *testing.T) {
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+	})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results, err := newTestEngine(t, nil, loader).GetActualCostWithOptions(
+		context.Background(), engine.ActualCostRequest{
+			Resources: []engine.ResourceDescriptor{{
+				Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+				Properties: map[string]interface{}{"instanceType": "t3.micro"},
+			}},
+			From: from,
+			To:   from.AddDate(0, 0, 10),
+		})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "local-spec-estimate", results[0].Adapter)
+	assert.InDelta(t, 240*0.01, results[0].TotalCost, 1e-9)
+}
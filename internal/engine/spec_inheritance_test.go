@@ -0,0 +1,100 @@
+package engine_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeInheritanceSpecs writes a default EC2 spec, a t3.micro spec extending it, and two
+// RDS specs extending each other.
+func writeInheritanceSpecs(t *testing.T) string {
+	t.Helper()
+	return writeSpecDir(t, map[string]string{
+		"aws-ec2-default.yaml": "provider: aws\nservice: ec2\nsku: default\ncurrency: EUR\n" +
+			"pricing:\n  onDemandHourly: 0.02\n",
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\nextends: default\n" +
+			"pricing:\n  onDemandHourly: 0.01\n",
+		"aws-rds-db.t3.micro.yaml": "provider: aws\nservice: rds\nsku: db.t3.micro\nextends: default\n",
+		"aws-rds-default.yaml":     "provider: aws\nservice: rds\nsku: default\nextends: db.t3.micro\n",
+	})
+}
+
+func ec2Instance(id, instanceType string) engine.ResourceDescriptor {
+	return engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: id, Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": instanceType},
+	}
+}
+
+func TestGetProjectedCost_SpecInheritanceWithFallback(t *testing.T) {
+	dir := writeInheritanceSpecs(t)
+	specific := ec2Instance("specific", "t3.micro")
+	fallback := ec2Instance("fallback", "t3.nano") // no spec, falls back to aws-ec2-default
+
+	// Whether the specific spec or the default it extends is loaded first, both resolve
+	// the same way.
+	for name, order := range map[string][]engine.ResourceDescriptor{
+		"specific first": {specific, fallback},
+		"default first":  {fallback, specific},
+	} {
+		t.Run(name, func(t *testing.T) {
+			results, err := newTestEngine(t, nil, spec.NewLoader(dir)).GetProjectedCost(context.Background(), order)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+
+			byID := map[string]engine.CostResult{}
+			for _, r := range results {
+				byID[r.ResourceID] = r
+			}
+			assert.InDelta(t, 7.3, byID["specific"].Monthly, 0.001, "child pricing overrides the parent")
+			assert.Equal(t, "EUR", byID["specific"].Currency, "currency is inherited")
+			assert.InDelta(t, 14.6, byID["fallback"].Monthly, 0.001)
+			assert.Equal(t, "EUR", byID["fallback"].Currency)
+		})
+	}
+}
+
+func TestGetProjectedCost_SpecInheritanceCached(t *testing.T) {
+	dir := writeInheritanceSpecs(t)
+	eng := newTestEngine(t, nil, spec.NewCachingLoader(spec.NewLoader(dir)))
+	resources := []engine.ResourceDescriptor{ec2Instance("web", "t3.micro")}
+
+	first, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	second, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "unchanged specs are served from the cache")
+
+	// Editing the parent changes the spec's merged currency.
+	parent := filepath.Join(dir, "aws-ec2-default.yaml")
+	require.NoError(t, os.WriteFile(parent,
+		[]byte("provider: aws\nservice: ec2\nsku: default\ncurrency: GBP\npricing:\n  onDemandHourly: 0.02\n"), 0o600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(parent, later, later))
+
+	third, err := eng.GetProjectedCost(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, third, 1)
+	assert.Equal(t, "GBP", third[0].Currency, "edited specs are reloaded")
+	assert.InDelta(t, 7.3, third[0].Monthly, 0.001)
+}
+
+func TestGetProjectedCost_SpecInheritanceCycle(t *testing.T) {
+	dir := writeInheritanceSpecs(t)
+	results, err := newTestEngine(t, nil, spec.NewLoader(dir)).GetProjectedCost(context.Background(),
+		[]engine.ResourceDescriptor{{
+			Type: "aws:rds/instance:Instance", ID: "db", Provider: "aws",
+			Properties: map[string]interface{}{"instanceClass": "db.t3.micro"},
+		}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "none", results[0].Adapter, "specs in an extends cycle are not used")
+}
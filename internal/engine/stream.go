@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// streamError is the NDJSON line StreamResults ends with when a result cannot be encoded.
+type streamError struct {
+	Error      string `json:"error"`
+	ResourceID string `json:"resourceId,omitempty"`
+}
+
+// StreamResults writes each CostResult received from results to writer as one JSON line,
+// as it arrives, flushing the writer after every line when it buffers (a *bufio.Writer or
+// an http.ResponseWriter), so consumers can process results before the last is computed.
+//
+// When a result cannot be encoded, StreamResults writes a final {"error": ...} line in
+// place of it and returns the error. On any error the rest of the channel is drained, so
+// the producer is never left blocked. It returns when results is closed.
+func StreamResults(writer io.Writer, results <-chan CostResult) error {
+	var err error
+	for result := range results {
+		if err != nil {
+			continue // Drain the rest so the producer can finish.
+		}
+		err = streamResult(writer, result)
+	}
+	return err
+}
+
+// streamResult writes result to writer as a JSON line, or an error line in its place when
+// it cannot be encoded.
+func streamResult(writer io.Writer, result CostResult) error {
+	line, err := json.Marshal(result)
+	if err == nil {
+		return writeStreamLine(writer, line)
+	}
+	err = fmt.Errorf("encoding result for %s: %w", result.ResourceID, err)
+	line, _ = json.Marshal(streamError{Error: err.Error(), ResourceID: result.ResourceID})
+	if writeErr := writeStreamLine(writer, line); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// writeStreamLine writes line and a newline to writer and flushes it.
+func writeStreamLine(writer io.Writer, line []byte) error {
+	if _, err := writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing NDJSON line: %w", err)
+	}
+	switch w := writer.(type) {
+	case interface{ Flush() error }:
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("flushing NDJSON line: %w", err)
+		}
+	case http.Flusher:
+		w.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package engine_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineWriter records what had been flushed through a bufio.Writer at each flush.
+type lineWriter struct {
+	flushed []string
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.flushed = append(w.flushed, string(p))
+	return len(p), nil
+}
+
+func TestStreamResults(t *testing.T) {
+	results := make(chan engine.CostResult)
+	go func() {
+		defer close(results)
+		results <- engine.CostResult{ResourceID: "web", Currency: "USD", Monthly: 7.5}
+		results <- engine.CostResult{ResourceID: "db", Currency: "USD", Monthly: 12.5}
+	}()
+
+	sink := &lineWriter{}
+	require.NoError(t, engine.StreamResults(bufio.NewWriter(sink), results))
+
+	require.Len(t, sink.flushed, 2, "each result is flushed as it arrives")
+	for i, id := range []string{"web", "db"} {
+		var decoded engine.CostResult
+		require.NoError(t, json.Unmarshal([]byte(sink.flushed[i]), &decoded))
+		assert.Equal(t, id, decoded.ResourceID)
+		assert.True(t, strings.HasSuffix(sink.flushed[i], "}\n"))
+	}
+}
+
+func TestStreamResults_EncodingError(t *testing.T) {
+	results := make(chan engine.CostResult)
+	go func() {
+		defer close(results)
+		results <- engine.CostResult{ResourceID: "web", Currency: "USD", Monthly: 7.5}
+		results <- engine.CostResult{ResourceID: "broken", Currency: "USD", Monthly: math.NaN()}
+		results <- engine.CostResult{ResourceID: "after", Currency: "USD", Monthly: 1}
+	}()
+
+	var buf bytes.Buffer
+	err := engine.StreamResults(&buf, results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "the stream ends at the failed result")
+	var last struct {
+		Error      string `json:"error"`
+		ResourceID string `json:"resourceId"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &last))
+	assert.Equal(t, "broken", last.ResourceID)
+	assert.Contains(t, last.Error, "unsupported value")
+}
@@ -0,0 +1,62 @@
+package engine
+
+import "sort"
+
+// monthlyCostStats returns the minimum, maximum, mean and median monthly cost of results.
+// Placeholder results for resources no plugin or spec could price, and the $0 results of
+// internal resources, are left out so they do not drag the statistics toward zero; with
+// no priced results every statistic is zero.
+func monthlyCostStats(results []CostResult) (float64, float64, float64, float64) {
+	costs := make([]float64, 0, len(results))
+	var total float64
+	for _, r := range results {
+		if (r.Adapter == "none" && r.Monthly == 0) || r.Adapter == adapterInternal {
+			continue
+		}
+		costs = append(costs, r.Monthly)
+		total += r.Monthly
+	}
+	if len(costs) == 0 {
+		return 0, 0, 0, 0
+	}
+	sort.Float64s(costs)
+
+	mid := len(costs) / 2 //nolint:mnd // Halving to find the middle element.
+	median := costs[mid]
+	if len(costs)%2 == 0 {
+		median = (costs[mid-1] + costs[mid]) / 2 //nolint:mnd // Mean of the two middle elements.
+	}
+	return costs[0], costs[len(costs)-1], total / float64(len(costs)), median
+}
+
+// resourceCoverage counts the results that were priced and the placeholder results for
+// resources no plugin or spec supports, with the placeholders counted by resource type.
+// Internal resources count as neither. The type counts are nil when every resource is
+// supported.
+func resourceCoverage(results []CostResult) (int, int, map[string]int) {
+	var supported, unsupported int
+	var types map[string]int
+	for _, r := range results {
+		if r.Adapter == adapterInternal {
+			continue
+		}
+		if r.Adapter != "none" {
+			supported++
+			continue
+		}
+		unsupported++
+		if types == nil {
+			types = make(map[string]int)
+		}
+		types[r.ResourceType]++
+	}
+	return supported, unsupported, types
+}
+
+// percentOfTotal returns monthly as a percentage of total, or zero when total is zero.
+func percentOfTotal(monthly, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return monthly / total * maxPercent
+}
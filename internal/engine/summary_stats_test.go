@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateResults_MonthlyStats(t *testing.T) {
+	priced := func(monthly float64) engine.CostResult {
+		return engine.CostResult{ResourceType: "aws:ec2:Instance", Adapter: "aws", Currency: "USD", Monthly: monthly}
+	}
+	placeholder := engine.CostResult{ResourceType: "aws:s3:Bucket", Adapter: "none", Currency: "USD"}
+
+	tests := []struct {
+		name    string
+		results []engine.CostResult
+		min     float64
+		max     float64
+		mean    float64
+		median  float64
+	}{
+		{name: "empty"},
+		{name: "only placeholders", results: []engine.CostResult{placeholder}},
+		{
+			name:    "odd count",
+			results: []engine.CostResult{priced(30), placeholder, priced(10), priced(200)},
+			min:     10, max: 200, mean: 80, median: 30,
+		},
+		{
+			name:    "even count",
+			results: []engine.CostResult{priced(40), priced(10), placeholder, priced(20), priced(130)},
+			min:     10, max: 130, mean: 50, median: 30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := engine.AggregateResults(tt.results)
+			assert.InDelta(t, tt.min, agg.Summary.MinMonthly, 1e-9)
+			assert.InDelta(t, tt.max, agg.Summary.MaxMonthly, 1e-9)
+			assert.InDelta(t, tt.mean, agg.Summary.MeanMonthly, 1e-9)
+			assert.InDelta(t, tt.median, agg.Summary.MedianMonthly, 1e-9)
+			assert.Len(t, agg.Summary.Resources, len(tt.results))
+		})
+	}
+}
+
+func TestAggregateResults_PercentOfTotal(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", Adapter: "aws", Currency: "USD", Monthly: 25},
+		{ResourceType: "aws:rds:Instance", Adapter: "aws", Currency: "USD", Monthly: 75},
+		{ResourceType: "aws:s3:Bucket", Adapter: "none", Currency: "USD"},
+	}
+
+	agg := engine.AggregateResults(results)
+	assert.InDelta(t, 25.0, agg.Resources[0].PercentOfTotal, 1e-9)
+	assert.InDelta(t, 75.0, agg.Resources[1].PercentOfTotal, 1e-9)
+	assert.Zero(t, agg.Resources[2].PercentOfTotal)
+	assert.Zero(t, results[0].PercentOfTotal, "input results must not be modified")
+
+	var sum float64
+	for _, r := range agg.Resources {
+		sum += r.PercentOfTotal
+	}
+	assert.InDelta(t, 100.0, sum, 1e-9)
+
+	t.Run("zero total", func(t *testing.T) {
+		agg := engine.AggregateResults([]engine.CostResult{
+			{ResourceType: "aws:s3:Bucket", Adapter: "none", Currency: "USD"},
+		})
+		assert.Zero(t, agg.Resources[0].PercentOfTotal)
+	})
+}
+
+func TestAggregateResults_ResourceCoverage(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", Adapter: "aws", Currency: "USD", Monthly: 25},
+		{ResourceType: "aws:iam:Role", Adapter: "none", Currency: "USD"},
+		{ResourceType: "aws:s3:Bucket", Adapter: "none", Currency: "USD"},
+		{ResourceType: "aws:iam:Role", Adapter: "none", Currency: "USD"},
+	}
+
+	agg := engine.AggregateResults(results)
+	assert.Equal(t, 1, agg.Summary.SupportedCount)
+	assert.Equal(t, 3, agg.Summary.UnsupportedCount)
+	assert.Equal(t, map[string]int{"aws:iam:Role": 2, "aws:s3:Bucket": 1}, agg.Summary.UnsupportedTypes)
+
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderResults(&buf, engine.OutputTable, results))
+	assert.Contains(t, buf.String(), "UNSUPPORTED RESOURCES")
+	assert.Regexp(t, `aws:iam:Role:\s+2`, buf.String())
+
+	t.Run("fully supported", func(t *testing.T) {
+		agg := engine.AggregateResults(results[:1])
+		assert.Equal(t, 1, agg.Summary.SupportedCount)
+		assert.Zero(t, agg.Summary.UnsupportedCount)
+		assert.Nil(t, agg.Summary.UnsupportedTypes)
+
+		var out bytes.Buffer
+		require.NoError(t, engine.RenderResults(&out, engine.OutputTable, results[:1]))
+		assert.NotContains(t, out.String(), "UNSUPPORTED RESOURCES")
+	})
+
+	t.Run("internal resources", func(t *testing.T) {
+		agg := engine.AggregateResults(append([]engine.CostResult{
+			engine.ZeroCostResult("pulumi:providers:aws", "default"),
+		}, results...))
+		assert.Equal(t, 1, agg.Summary.SupportedCount)
+		assert.Equal(t, 3, agg.Summary.UnsupportedCount)
+		assert.NotContains(t, agg.Summary.UnsupportedTypes, "pulumi:providers:aws")
+		assert.InDelta(t, 25.0, agg.Summary.MinMonthly, 1e-9)
+		assert.InDelta(t, 25.0, agg.Summary.MedianMonthly, 1e-9)
+	})
+
+	t.Run("mixed currencies", func(t *testing.T) {
+		agg := engine.AggregateResults(append([]engine.CostResult{
+			{ResourceType: "azure:compute:VirtualMachine", Adapter: "azure", Currency: "EUR", Monthly: 10},
+		}, results...))
+		assert.Equal(t, 2, agg.Summary.SupportedCount)
+		assert.Equal(t, 3, agg.Summary.UnsupportedCount)
+	})
+}
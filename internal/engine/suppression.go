@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Prefixes understood by ParseRecommendationSuppression.
+const (
+	suppressTypePrefix     = "type:"
+	suppressResourcePrefix = "resource:"
+	suppressOnSeparator    = " on "
+)
+
+// RecommendationSuppression hides recommendations a team has acknowledged. Non-empty
+// fields must all match: an ID rule hides a single recommendation, a Type rule hides
+// an action type everywhere, and Type plus ResourceID hides that action on one resource.
+type RecommendationSuppression struct {
+	ID         string
+	Type       string
+	ResourceID string
+}
+
+// ParseRecommendationSuppression parses a config entry of the form "<rec-id>",
+// "type:<ACTION>", "resource:<id>", or "type:<ACTION> on <resource-id>".
+func ParseRecommendationSuppression(entry string) (RecommendationSuppression, error) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return RecommendationSuppression{}, errors.New("empty recommendation suppression")
+	}
+
+	rule := RecommendationSuppression{}
+	target, resource, hasResource := strings.Cut(entry, suppressOnSeparator)
+	target = strings.TrimSpace(target)
+
+	switch {
+	case strings.HasPrefix(target, suppressTypePrefix):
+		rule.Type = strings.TrimSpace(strings.TrimPrefix(target, suppressTypePrefix))
+	case strings.HasPrefix(target, suppressResourcePrefix):
+		rule.ResourceID = strings.TrimSpace(strings.TrimPrefix(target, suppressResourcePrefix))
+	default:
+		rule.ID = target
+	}
+
+	if hasResource {
+		if rule.ResourceID != "" {
+			return RecommendationSuppression{}, fmt.Errorf("suppression %q names a resource twice", entry)
+		}
+		rule.ResourceID = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(resource), suppressResourcePrefix))
+	}
+
+	if rule.ID == "" && rule.Type == "" && rule.ResourceID == "" {
+		return RecommendationSuppression{}, fmt.Errorf("suppression %q matches nothing", entry)
+	}
+	return rule, nil
+}
+
+// ParseRecommendationSuppressions parses every entry, failing on the first invalid one.
+func ParseRecommendationSuppressions(entries []string) ([]RecommendationSuppression, error) {
+	rules := make([]RecommendationSuppression, 0, len(entries))
+	for _, entry := range entries {
+		rule, err := ParseRecommendationSuppression(entry)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Matches reports whether rec is hidden by this rule. Type comparison ignores case.
+func (s RecommendationSuppression) Matches(rec Recommendation) bool {
+	if s.ID != "" && s.ID != rec.ID {
+		return false
+	}
+	if s.Type != "" && !strings.EqualFold(s.Type, rec.Type) {
+		return false
+	}
+	if s.ResourceID != "" && s.ResourceID != rec.ResourceID {
+		return false
+	}
+	return true
+}
+
+// FilterSuppressedRecommendations returns the recommendations not matched by any rule and
+// the number that were suppressed.
+func FilterSuppressedRecommendations(
+	recs []Recommendation,
+	rules []RecommendationSuppression,
+) ([]Recommendation, int) {
+	if len(rules) == 0 {
+		return recs, 0
+	}
+	kept := make([]Recommendation, 0, len(recs))
+	for _, rec := range recs {
+		if !isSuppressed(rec, rules) {
+			kept = append(kept, rec)
+		}
+	}
+	return kept, len(recs) - len(kept)
+}
+
+func isSuppressed(rec Recommendation, rules []RecommendationSuppression) bool {
+	for _, rule := range rules {
+		if rule.Matches(rec) {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressedRecommendationIDs returns the IDs of ID-only rules, which plugins can exclude
+// server-side via GetRecommendationsRequest.ExcludedRecommendationIDs.
+func suppressedRecommendationIDs(rules []RecommendationSuppression) []string {
+	var ids []string
+	for _, rule := range rules {
+		if rule.ID != "" && rule.Type == "" && rule.ResourceID == "" {
+			ids = append(ids, rule.ID)
+		}
+	}
+	return ids
+}
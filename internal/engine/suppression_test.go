@@ -0,0 +1,69 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecommendationSuppression(t *testing.T) {
+	tests := []struct {
+		entry   string
+		want    engine.RecommendationSuppression
+		wantErr string
+	}{
+		{entry: "rec-123", want: engine.RecommendationSuppression{ID: "rec-123"}},
+		{entry: "type:RIGHTSIZE", want: engine.RecommendationSuppression{Type: "RIGHTSIZE"}},
+		{entry: "resource:i-abc", want: engine.RecommendationSuppression{ResourceID: "i-abc"}},
+		{
+			entry: "type:RIGHTSIZE on urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			want: engine.RecommendationSuppression{
+				Type:       "RIGHTSIZE",
+				ResourceID: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			},
+		},
+		{entry: "  ", wantErr: "empty"},
+		{entry: "type:", wantErr: "matches nothing"},
+		{entry: "resource:a on b", wantErr: "names a resource twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			got, err := engine.ParseRecommendationSuppression(tt.entry)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterSuppressedRecommendations(t *testing.T) {
+	recs := []engine.Recommendation{
+		{ID: "rec-1", ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 10},
+		{ID: "rec-2", ResourceID: "db", Type: "RIGHTSIZE", EstimatedSavings: 20},
+		{ID: "rec-3", ResourceID: "web", Type: "TERMINATE", EstimatedSavings: 30},
+		{ID: "rec-4", ResourceID: "cache", Type: "DELETE_UNUSED", EstimatedSavings: 40},
+	}
+
+	rules, err := engine.ParseRecommendationSuppressions([]string{
+		"rec-4",
+		"type:rightsize on web",
+	})
+	require.NoError(t, err)
+
+	kept, suppressed := engine.FilterSuppressedRecommendations(recs, rules)
+	assert.Equal(t, 2, suppressed)
+	require.Len(t, kept, 2)
+	assert.Equal(t, "rec-2", kept[0].ID)
+	assert.Equal(t, "rec-3", kept[1].ID)
+
+	kept, suppressed = engine.FilterSuppressedRecommendations(recs, nil)
+	assert.Zero(t, suppressed)
+	assert.Len(t, kept, 4)
+}
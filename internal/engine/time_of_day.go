@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// timeOfDayPricingKey is the spec pricing block holding time-varying hourly rates:
+	//
+	//	pricing:
+	//	  onDemandHourly: 0.10
+	//	  time_of_day:
+	//	    peak_hourly: 0.12
+	//	    off_peak_hourly: 0.07
+	//	    peak_hours: "08-20"   # default 08-20
+	//	    peak_days: weekdays   # weekdays (default) or daily
+	//	    seasonal:             # monthly multipliers, averaged over the year
+	//	      dec: 1.2
+	//
+	// A rate that is not given falls back to the spec's flat hourly rate.
+	timeOfDayPricingKey = "time_of_day"
+
+	// scheduleTag declares when a resource runs: 24x7 (the default), business-hours, or an
+	// hour range such as "06-22" optionally followed by "weekdays".
+	scheduleTag = "finfocus:schedule"
+
+	// scheduledScalingProperty marks a resource that is scaled to zero outside a schedule.
+	// true means business hours; a string is read like scheduleTag.
+	scheduledScalingProperty = "scheduledScaling"
+
+	schedule24x7          = "24x7"
+	scheduleBusinessHours = "business-hours"
+	scheduleWeekdays      = "weekdays"
+	scheduleDaily         = "daily"
+
+	businessHoursStart = 8
+	businessHoursEnd   = 18
+	defaultPeakStart   = 8
+	defaultPeakEnd     = 20
+
+	// weekdaysPerWeek counts Monday to Friday; days are numbered from Monday as 0.
+	weekdaysPerWeek = 5
+	hoursPerWeek    = hoursPerDay * daysPerWeek
+	monthsPerYear   = 12
+	// monthsPerQuarter is the length of a calendar quarter.
+	monthsPerQuarter = 3
+)
+
+// seasonMonths are the keys of the seasonal multipliers, in calendar order.
+var seasonMonths = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+// hourWindow is a range of hours of the day, on every day or on weekdays only. A window
+// whose end is before its start wraps past midnight.
+type hourWindow struct {
+	start, end   int
+	weekdaysOnly bool
+}
+
+func (w hourWindow) contains(day, hour int) bool {
+	if w.weekdaysOnly && day >= weekdaysPerWeek {
+		return false
+	}
+	if w.start <= w.end {
+		return hour >= w.start && hour < w.end
+	}
+	return hour >= w.start || hour < w.end
+}
+
+// runSchedule is when a resource is expected to run, with its display name.
+type runSchedule struct {
+	name   string
+	window hourWindow
+}
+
+var alwaysRunning = runSchedule{name: schedule24x7, window: hourWindow{start: 0, end: hoursPerDay}}
+
+// timeOfDayRates are the parsed time_of_day block of a spec.
+type timeOfDayRates struct {
+	peak, offPeak float64
+	peakWindow    hourWindow
+	seasonal      float64
+}
+
+// resourceSchedule reads the running schedule from the finfocus:schedule tag or the
+// scheduledScaling property. It reports false when neither is set, and returns 24x7 with
+// a note when the value cannot be read.
+func resourceSchedule(resource ResourceDescriptor) (runSchedule, string, bool) {
+	tags, _ := resource.Properties["tags"].(map[string]interface{})
+	if value, ok := tags[scheduleTag].(string); ok && value != "" {
+		return parseScheduleValue(value)
+	}
+	switch value := resource.Properties[scheduledScalingProperty].(type) {
+	case bool:
+		if value {
+			return parseScheduleValue(scheduleBusinessHours)
+		}
+	case string:
+		if value != "" {
+			return parseScheduleValue(value)
+		}
+	}
+	return alwaysRunning, "", false
+}
+
+func parseScheduleValue(value string) (runSchedule, string, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	switch normalized {
+	case schedule24x7, "always":
+		return alwaysRunning, "", true
+	case scheduleBusinessHours:
+		window := hourWindow{start: businessHoursStart, end: businessHoursEnd, weekdaysOnly: true}
+		return runSchedule{name: scheduleBusinessHours, window: window}, "", true
+	}
+	fields := strings.Fields(normalized)
+	if len(fields) == 0 || len(fields) > 2 {
+		return alwaysRunning, fmt.Sprintf("unrecognized schedule %q", value), true
+	}
+	window, ok := parseHourRange(fields[0])
+	if ok && len(fields) == 2 {
+		window.weekdaysOnly = fields[1] == scheduleWeekdays
+		ok = window.weekdaysOnly || fields[1] == scheduleDaily
+	}
+	if !ok {
+		return alwaysRunning, fmt.Sprintf("unrecognized schedule %q", value), true
+	}
+	return runSchedule{name: normalized, window: window}, "", true
+}
+
+// parseHourRange parses "HH-HH" with hours from 0 to 24.
+func parseHourRange(s string) (hourWindow, bool) {
+	startText, endText, found := strings.Cut(s, "-")
+	if !found {
+		return hourWindow{}, false
+	}
+	start, startErr := strconv.Atoi(strings.TrimSuffix(startText, ":00"))
+	end, endErr := strconv.Atoi(strings.TrimSuffix(endText, ":00"))
+	if startErr != nil || endErr != nil || start < 0 || start >= hoursPerDay || end < 0 || end > hoursPerDay ||
+		start == end {
+		return hourWindow{}, false
+	}
+	return hourWindow{start: start, end: end}, true
+}
+
+// parseTimeOfDayRates reads the time_of_day block of a spec, defaulting missing rates to
+// flatHourly.
+func parseTimeOfDayRates(pricing map[string]interface{}, flatHourly float64) (timeOfDayRates, bool) {
+	block, ok := pricing[timeOfDayPricingKey].(map[string]interface{})
+	if !ok {
+		return timeOfDayRates{}, false
+	}
+	rates := timeOfDayRates{
+		peak:       flatHourly,
+		offPeak:    flatHourly,
+		peakWindow: hourWindow{start: defaultPeakStart, end: defaultPeakEnd, weekdaysOnly: true},
+		seasonal:   1,
+	}
+	if peak, found := parseFloatValue(block["peak_hourly"]); found {
+		rates.peak = peak
+	}
+	if offPeak, found := parseFloatValue(block["off_peak_hourly"]); found {
+		rates.offPeak = offPeak
+	}
+	if hours, isStr := block["peak_hours"].(string); isStr {
+		if window, valid := parseHourRange(hours); valid {
+			rates.peakWindow.start, rates.peakWindow.end = window.start, window.end
+		}
+	}
+	if days, isStr := block["peak_days"].(string); isStr {
+		rates.peakWindow.weekdaysOnly = !strings.EqualFold(days, scheduleDaily)
+	}
+	if seasonal, isMap := block["seasonal"].(map[string]interface{}); isMap {
+		var sum float64
+		for _, month := range seasonMonths {
+			multiplier := 1.0
+			for key, value := range seasonal {
+				if v, found := parseFloatValue(value); found && strings.EqualFold(key, month) {
+					multiplier = v
+				}
+			}
+			sum += multiplier
+		}
+		rates.seasonal = sum / monthsPerYear
+	}
+	return rates, true
+}
+
+// applyRunSchedule blends a spec's hourly pricing over the hours of a week the resource
+// runs. Specs priced per hour are scaled to the resource's schedule, and specs with a
+// time_of_day block charge their peak and off-peak rates for the hours that fall in each.
+// It returns the blended monthly and average hourly cost and a note on the assumed
+// schedule, or the costs unchanged and no note when neither applies. A month has hours
+// hours.
+func applyRunSchedule(
+	pricing map[string]interface{},
+	resource ResourceDescriptor,
+	monthly, hourly, hours float64,
+) (float64, float64, string) {
+	_, flatHourly, hourlyPriced := tryHourlyRates(pricing, hours)
+	if !hourlyPriced {
+		flatHourly = hourly
+	}
+	rates, timeVarying := parseTimeOfDayRates(pricing, flatHourly)
+	schedule, scheduleNote, scheduled := resourceSchedule(resource)
+	if !timeVarying && (!scheduled || !hourlyPriced) {
+		return monthly, hourly, ""
+	}
+	if !timeVarying {
+		rates = timeOfDayRates{peak: flatHourly, offPeak: flatHourly, seasonal: 1}
+	}
+
+	var weekly float64
+	runningHours := 0
+	for day := range daysPerWeek {
+		for hour := range hoursPerDay {
+			if !schedule.window.contains(day, hour) {
+				continue
+			}
+			runningHours++
+			if rates.peakWindow.contains(day, hour) {
+				weekly += rates.peak
+			} else {
+				weekly += rates.offPeak
+			}
+		}
+	}
+	blendedMonthly := weekly * rates.seasonal * hours / hoursPerWeek
+
+	name := schedule.name
+	if scheduleNote != "" {
+		name += " (" + scheduleNote + ")"
+	}
+	note := fmt.Sprintf("Schedule %s: %d of %d hours/week running", name, runningHours, hoursPerWeek)
+	if timeVarying {
+		note += fmt.Sprintf(", peak %g/hour, off-peak %g/hour", rates.peak, rates.offPeak)
+		if rates.seasonal != 1 {
+			note += fmt.Sprintf(", seasonal x%.2f", rates.seasonal)
+		}
+	}
+	return blendedMonthly, blendedMonthly / hours, note
+}
@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTimeOfDayTestEngine(t *testing.T) *engine.Engine {
+	t.Helper()
+	loader := writeSpecs(t, map[string]string{
+		"aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.1\n",
+		"aws-ec2-m5.large.yaml": "provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.1\n  time_of_day:\n    peak_hourly: 0.2\n",
+		"aws-ec2-c5.large.yaml": "provider: aws\nservice: ec2\nsku: c5.large\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.1\n  time_of_day:\n    seasonal:\n      dec: 2.2\n",
+		"aws-s3-default.yaml": "provider: aws\nservice: s3\nsku: default\ncurrency: USD\n" +
+			"pricing:\n  monthlyEstimate: 5\n",
+	})
+	return newTestEngine(t, nil, loader)
+}
+
+func TestGetProjectedCost_RunSchedule(t *testing.T) {
+	const weekToMonth = 730.0 / 168.0
+
+	tests := []struct {
+		name         string
+		resourceType string
+		properties   map[string]interface{}
+		wantMonthly  float64
+		wantNote     string
+	}{
+		{
+			name:        "flat rate without schedule",
+			properties:  map[string]interface{}{"instanceType": "t3.micro"},
+			wantMonthly: 73,
+		},
+		{
+			name: "flat rate on business hours",
+			properties: map[string]interface{}{
+				"instanceType": "t3.micro",
+				"tags":         map[string]interface{}{"finfocus:schedule": "business-hours"},
+			},
+			wantMonthly: 50 * 0.1 * weekToMonth,
+			wantNote:    "Schedule business-hours: 50 of 168 hours/week running",
+		},
+		{
+			name: "custom daily range",
+			properties: map[string]interface{}{
+				"instanceType": "t3.micro",
+				"tags":         map[string]interface{}{"finfocus:schedule": "06-22 daily"},
+			},
+			wantMonthly: 112 * 0.1 * weekToMonth,
+			wantNote:    "112 of 168 hours/week",
+		},
+		{
+			name: "window past midnight",
+			properties: map[string]interface{}{
+				"instanceType": "t3.micro",
+				"tags":         map[string]interface{}{"finfocus:schedule": "22-06 weekdays"},
+			},
+			wantMonthly: 40 * 0.1 * weekToMonth,
+			wantNote:    "40 of 168 hours/week",
+		},
+		{
+			name:        "peak and off-peak rates on 24x7",
+			properties:  map[string]interface{}{"instanceType": "m5.large"},
+			wantMonthly: (60*0.2 + 108*0.1) * weekToMonth,
+			wantNote:    "Schedule 24x7: 168 of 168 hours/week running, peak 0.2/hour, off-peak 0.1/hour",
+		},
+		{
+			name:        "scheduled scaling runs business hours at peak",
+			properties:  map[string]interface{}{"instanceType": "m5.large", "scheduledScaling": true},
+			wantMonthly: 50 * 0.2 * weekToMonth,
+			wantNote:    "Schedule business-hours",
+		},
+		{
+			name:        "seasonal multiplier averaged over the year",
+			properties:  map[string]interface{}{"instanceType": "c5.large"},
+			wantMonthly: 73 * 1.1,
+			wantNote:    "seasonal x1.10",
+		},
+		{
+			name: "unrecognized schedule assumes 24x7",
+			properties: map[string]interface{}{
+				"instanceType": "t3.micro",
+				"tags":         map[string]interface{}{"finfocus:schedule": "nights"},
+			},
+			wantMonthly: 73,
+			wantNote:    `Schedule 24x7 (unrecognized schedule "nights")`,
+		},
+		{
+			name:         "monthly priced resources ignore schedules",
+			resourceType: "aws:s3/bucket:Bucket",
+			properties: map[string]interface{}{
+				"tags": map[string]interface{}{"finfocus:schedule": "business-hours"},
+			},
+			wantMonthly: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceType := tt.resourceType
+			if resourceType == "" {
+				resourceType = "aws:ec2/instance:Instance"
+			}
+			results, err := newTimeOfDayTestEngine(t).GetProjectedCost(context.Background(),
+				[]engine.ResourceDescriptor{{Type: resourceType, ID: "web", Provider: "aws", Properties: tt.properties}})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			assert.InDelta(t, tt.wantMonthly, results[0].Monthly, 0.0001)
+			assert.InDelta(t, tt.wantMonthly/730, results[0].Hourly, 0.0001)
+			if tt.wantNote == "" {
+				assert.NotContains(t, results[0].Notes, "Schedule")
+			} else {
+				assert.Contains(t, results[0].Notes, tt.wantNote)
+			}
+		})
+	}
+}
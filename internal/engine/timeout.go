@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+const (
+	// defaultPerResourceAllowance is added to the base query timeout for every resource.
+	defaultPerResourceAllowance = 100 * time.Millisecond
+	// defaultMaxQueryTimeout caps the scaled query timeout.
+	defaultMaxQueryTimeout = 10 * time.Minute
+
+	// envQueryTimeoutBase overrides the base overall query timeout (Go duration syntax).
+	envQueryTimeoutBase = "FINFOCUS_QUERY_TIMEOUT_BASE"
+	// envQueryTimeoutPerResource overrides the per-resource allowance.
+	envQueryTimeoutPerResource = "FINFOCUS_QUERY_TIMEOUT_PER_RESOURCE"
+	// envQueryTimeoutMax overrides the timeout cap.
+	envQueryTimeoutMax = "FINFOCUS_QUERY_TIMEOUT_MAX"
+)
+
+// queryTimeout returns the overall timeout for a query over resourceCount resources:
+// base + resourceCount*perResource, capped at max. Each component can be overridden
+// via environment variables; invalid or non-positive values are ignored.
+func queryTimeout(resourceCount int) time.Duration {
+	base := durationFromEnv(envQueryTimeoutBase, defaultQueryTimeout)
+	perResource := durationFromEnv(envQueryTimeoutPerResource, defaultPerResourceAllowance)
+	maxTimeout := durationFromEnv(envQueryTimeoutMax, defaultMaxQueryTimeout)
+
+	timeout := base + time.Duration(resourceCount)*perResource
+	if maxTimeout < base {
+		maxTimeout = base
+	}
+	return min(timeout, maxTimeout)
+}
+
+// withQueryTimeout applies the scaled query timeout unless ctx already has a deadline,
+// in which case the caller's deadline is treated as an explicit override.
+func withQueryTimeout(ctx context.Context, resourceCount int) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+
+	timeout := queryTimeout(resourceCount)
+	logging.FromContext(ctx).Debug().
+		Ctx(ctx).
+		Str("component", "engine").
+		Int("resource_count", resourceCount).
+		Dur("query_timeout", timeout).
+		Msg("computed overall query timeout")
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// perResourceTimeout returns the configured per-resource timeout, or the default.
+func (e *Engine) perResourceTimeout() time.Duration {
+	if e.options.PerResourceTimeout > 0 {
+		return e.options.PerResourceTimeout
+	}
+	return defaultPerResourceTimeout
+}
+
+// durationFromEnv parses a positive duration from the named environment variable.
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	if val := os.Getenv(name); val != "" {
+		if d, err := time.ParseDuration(val); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
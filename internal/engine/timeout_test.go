@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		resourceCount int
+		want          time.Duration
+	}{
+		{name: "no resources", resourceCount: 0, want: 60 * time.Second},
+		{name: "scales per resource", resourceCount: 2000, want: 260 * time.Second},
+		{name: "capped at max", resourceCount: 100000, want: 10 * time.Minute},
+		{
+			name:          "custom base and allowance",
+			env:           map[string]string{envQueryTimeoutBase: "10s", envQueryTimeoutPerResource: "1s"},
+			resourceCount: 5,
+			want:          15 * time.Second,
+		},
+		{
+			name:          "custom cap",
+			env:           map[string]string{envQueryTimeoutMax: "90s"},
+			resourceCount: 1000,
+			want:          90 * time.Second,
+		},
+		{
+			name:          "cap below base keeps base",
+			env:           map[string]string{envQueryTimeoutMax: "1s"},
+			resourceCount: 10,
+			want:          60 * time.Second,
+		},
+		{
+			name:          "invalid values ignored",
+			env:           map[string]string{envQueryTimeoutBase: "soon", envQueryTimeoutPerResource: "-1s"},
+			resourceCount: 10,
+			want:          61 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{envQueryTimeoutBase, envQueryTimeoutPerResource, envQueryTimeoutMax} {
+				t.Setenv(k, tt.env[k])
+			}
+			assert.Equal(t, tt.want, queryTimeout(tt.resourceCount))
+		})
+	}
+}
+
+func TestWithQueryTimeout_RespectsExistingDeadline(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	parentDeadline, _ := parent.Deadline()
+
+	ctx, childCancel := withQueryTimeout(parent, 5000)
+	defer childCancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, parentDeadline, deadline)
+}
+
+func TestWithQueryTimeout_AppliesScaledDeadline(t *testing.T) {
+	ctx, cancel := withQueryTimeout(context.Background(), 100)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(70*time.Second), deadline, 2*time.Second)
+}
+
+func TestPerResourceTimeout(t *testing.T) {
+	eng, err := New(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, defaultPerResourceTimeout, eng.perResourceTimeout())
+
+	eng, err = New(nil, nil, EngineOptions{PerResourceTimeout: 2 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, eng.perResourceTimeout())
+
+	_, err = New(nil, nil, EngineOptions{PerResourceTimeout: -time.Second})
+	require.ErrorIs(t, err, ErrInvalidEngineOption)
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Pipeline stages recorded by Timings.
+const (
+	StageIngest       = "ingest"
+	StagePluginLaunch = "plugin launch"
+	StagePluginCalls  = "plugin calls"
+	StageSpecLoad     = "spec loading"
+	StageAggregation  = "aggregation"
+	StageRender       = "render"
+)
+
+// contextKeyTimings carries the *Timings recorder for the current run.
+const contextKeyTimings ContextKey = "timings"
+
+// Timings accumulates wall-clock durations per pipeline stage and per plugin for the
+// --timing breakdown. All methods are safe for concurrent use and no-ops on a nil receiver,
+// so instrumented code does not need to check whether timing is enabled.
+//
+// Plugin and spec durations are summed across worker goroutines, so with concurrency they
+// can exceed the elapsed time of the run.
+type Timings struct {
+	mu          sync.Mutex
+	start       time.Time
+	stages      map[string]time.Duration
+	order       []string
+	pluginTime  map[string]time.Duration
+	pluginCalls map[string]int
+}
+
+// NewTimings returns a recorder whose total is measured from now.
+func NewTimings() *Timings {
+	return &Timings{
+		start:       time.Now(),
+		stages:      make(map[string]time.Duration),
+		pluginTime:  make(map[string]time.Duration),
+		pluginCalls: make(map[string]int),
+	}
+}
+
+// ContextWithTimings attaches t to ctx.
+func ContextWithTimings(ctx context.Context, t *Timings) context.Context {
+	return context.WithValue(ctx, contextKeyTimings, t)
+}
+
+// TimingsFromContext returns the recorder attached to ctx, or nil when timing is disabled.
+func TimingsFromContext(ctx context.Context) *Timings {
+	t, _ := ctx.Value(contextKeyTimings).(*Timings)
+	return t
+}
+
+// Add records d against stage.
+func (t *Timings) Add(stage string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, seen := t.stages[stage]; !seen {
+		t.order = append(t.order, stage)
+	}
+	t.stages[stage] += d
+}
+
+// Track starts timing stage and returns a function that stops it, for use with defer.
+func (t *Timings) Track(stage string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { t.Add(stage, time.Since(start)) }
+}
+
+// TrackPlugin times a single call to plugin and also counts it towards StagePluginCalls.
+func (t *Timings) TrackPlugin(plugin string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		t.Add(StagePluginCalls, d)
+		t.mu.Lock()
+		t.pluginTime[plugin] += d
+		t.pluginCalls[plugin]++
+		t.mu.Unlock()
+	}
+}
+
+// Stage returns the time recorded for stage.
+func (t *Timings) Stage(stage string) time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stages[stage]
+}
+
+// Write prints the stage breakdown, in the order stages were first recorded, followed by
+// per-plugin call totals and the elapsed time since NewTimings.
+func (t *Timings) Write(w io.Writer) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIMING\tDURATION")
+	for _, stage := range t.order {
+		fmt.Fprintf(tw, "%s\t%s\n", stage, t.stages[stage].Round(time.Microsecond))
+		if stage != StagePluginCalls {
+			continue
+		}
+		plugins := make([]string, 0, len(t.pluginTime))
+		for name := range t.pluginTime {
+			plugins = append(plugins, name)
+		}
+		sort.Strings(plugins)
+		for _, name := range plugins {
+			fmt.Fprintf(tw, "  %s (%d calls)\t%s\n",
+				name, t.pluginCalls[name], t.pluginTime[name].Round(time.Microsecond))
+		}
+	}
+	fmt.Fprintf(tw, "total\t%s\n", time.Since(t.start).Round(time.Microsecond))
+	return tw.Flush()
+}
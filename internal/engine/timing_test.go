@@ -0,0 +1,71 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimings_NilIsNoop(t *testing.T) {
+	var timings *engine.Timings
+	timings.Add(engine.StageIngest, time.Second)
+	timings.Track(engine.StageRender)()
+	timings.TrackPlugin("aws")()
+
+	assert.Zero(t, timings.Stage(engine.StageIngest))
+	assert.Nil(t, engine.TimingsFromContext(context.Background()))
+
+	var buf bytes.Buffer
+	require.NoError(t, timings.Write(&buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestTimings_Breakdown(t *testing.T) {
+	timings := engine.NewTimings()
+	ctx := engine.ContextWithTimings(context.Background(), timings)
+	require.Same(t, timings, engine.TimingsFromContext(ctx))
+
+	timings.Add(engine.StageIngest, 5*time.Millisecond)
+	timings.Add(engine.StageIngest, 5*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			timings.TrackPlugin("aws-public")()
+		}()
+	}
+	wg.Wait()
+	timings.Add(engine.StageRender, time.Millisecond)
+
+	assert.Equal(t, 10*time.Millisecond, timings.Stage(engine.StageIngest))
+
+	var buf bytes.Buffer
+	require.NoError(t, timings.Write(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "ingest")
+	assert.Contains(t, out, "10ms")
+	assert.Contains(t, out, "aws-public (4 calls)")
+	assert.Contains(t, out, "total")
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("ingest")), bytes.Index(buf.Bytes(), []byte("render")))
+}
+
+func TestTimings_SpecLoadingRecorded(t *testing.T) {
+	eng := newScalingGroupTestEngine(t)
+	timings := engine.NewTimings()
+	ctx := engine.ContextWithTimings(context.Background(), timings)
+
+	_, err := eng.GetProjectedCost(ctx, []engine.ResourceDescriptor{{
+		Type: "aws:ec2/instance:Instance", ID: "i", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}})
+	require.NoError(t, err)
+	assert.Positive(t, timings.Stage(engine.StageSpecLoad))
+}
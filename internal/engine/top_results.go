@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OmittedResults summarizes the results TopResults left out, so that output limited to
+// the top resources still accounts for the rest of the spend. Omitted results spanning
+// currencies are totalled per currency in ByCurrency, leaving Currency and the combined
+// totals empty, as in CostSummary.
+type OmittedResults struct {
+	Count      int                         `json:"count"`
+	Monthly    float64                     `json:"monthly"`
+	Hourly     float64                     `json:"hourly"`
+	Currency   string                      `json:"currency,omitempty"`
+	ByCurrency map[string]CurrencySubtotal `json:"byCurrency,omitempty"`
+}
+
+// TopResults returns the first n results and a summary of the rest, or all results and
+// nil when n is zero or there are no more than n. Results should already be sorted, e.g.
+// by SortResults with monthly cost descending.
+func TopResults(results []CostResult, n int) ([]CostResult, *OmittedResults) {
+	if n <= 0 || len(results) <= n {
+		return results, nil
+	}
+	rest := results[n:]
+	omitted := &OmittedResults{Count: len(rest)}
+	if byCurrency := summarizeByCurrency(rest); byCurrency != nil {
+		omitted.ByCurrency = byCurrency
+		return results[:n], omitted
+	}
+	omitted.Currency = resultCurrency(rest[0])
+	for _, r := range rest {
+		omitted.Monthly += r.Monthly
+		omitted.Hourly += r.Hourly
+	}
+	return results[:n], omitted
+}
+
+// String describes the omitted results for the end of a table, e.g.
+// "... and 12 more (total $340.50/month)".
+func (o *OmittedResults) String() string {
+	totals := []string{formatOmittedAmount(o.Monthly, o.Currency)}
+	if o.ByCurrency != nil {
+		totals = totals[:0]
+		for _, currency := range SortedCurrencies(o.ByCurrency) {
+			totals = append(totals, formatOmittedAmount(o.ByCurrency[currency].TotalMonthly, currency))
+		}
+	}
+	return fmt.Sprintf("... and %d more (total %s)", o.Count, strings.Join(totals, ", "))
+}
+
+// formatOmittedAmount formats a monthly amount with its currency symbol, or its ISO code
+// when the currency has no symbol.
+func formatOmittedAmount(amount float64, currency string) string {
+	if symbol := getCurrencySymbol(currency); symbol != currency {
+		return fmt.Sprintf("%s%.2f/month", symbol, amount)
+	}
+	return fmt.Sprintf("%.2f %s/month", amount, currency)
+}
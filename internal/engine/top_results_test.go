@@ -0,0 +1,72 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopResults(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "a", Monthly: 300, Hourly: 0.4, Currency: "USD"},
+		{ResourceID: "b", Monthly: 200, Hourly: 0.3, Currency: "USD"},
+		{ResourceID: "c", Monthly: 100, Hourly: 0.1, Currency: "USD"},
+		{ResourceID: "d", Monthly: 40.5, Hourly: 0.05, Currency: "USD"},
+	}
+
+	top, omitted := engine.TopResults(results, 2)
+	require.Len(t, top, 2)
+	assert.Equal(t, "a", top[0].ResourceID)
+	assert.Equal(t, "b", top[1].ResourceID)
+	require.NotNil(t, omitted)
+	assert.Equal(t, 2, omitted.Count)
+	assert.InDelta(t, 140.5, omitted.Monthly, 0.001)
+	assert.InDelta(t, 0.15, omitted.Hourly, 0.001)
+	assert.Equal(t, "USD", omitted.Currency)
+	assert.Equal(t, "... and 2 more (total $140.50/month)", omitted.String())
+
+	for _, n := range []int{0, 4, 10} {
+		top, omitted = engine.TopResults(results, n)
+		assert.Len(t, top, len(results), "n=%d", n)
+		assert.Nil(t, omitted, "n=%d", n)
+	}
+}
+
+func TestTopResults_MixedCurrencies(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "a", Monthly: 300, Currency: "USD"},
+		{ResourceID: "b", Monthly: 200, Currency: "EUR"},
+		{ResourceID: "c", Monthly: 100, Currency: "USD"},
+		{ResourceID: "d", Monthly: 50, Currency: "SEK"},
+	}
+
+	_, omitted := engine.TopResults(results, 1)
+	require.NotNil(t, omitted)
+	assert.Empty(t, omitted.Currency)
+	assert.Len(t, omitted.ByCurrency, 3)
+	assert.Equal(t, "... and 3 more (total €200.00/month, 50.00 SEK/month, $100.00/month)", omitted.String())
+}
+
+func TestRenderResultsWithOptions_Omitted(t *testing.T) {
+	results := []engine.CostResult{{ResourceID: "a", ResourceType: "aws:ec2:Instance", Monthly: 10, Currency: "USD"}}
+	omitted := &engine.OmittedResults{Count: 3, Monthly: 5.25, Currency: "USD"}
+
+	var table bytes.Buffer
+	require.NoError(t, engine.RenderResultsWithOptions(&table, engine.OutputTable, results,
+		engine.RenderOptions{Omitted: omitted}))
+	assert.Contains(t, table.String(), "... and 3 more (total $5.25/month)")
+
+	var md bytes.Buffer
+	require.NoError(t, engine.RenderResultsWithOptions(&md, engine.OutputMarkdown, results,
+		engine.RenderOptions{Omitted: omitted}))
+	assert.Contains(t, md.String(), `... and 3 more (total \$5.25/month)`)
+
+	var out bytes.Buffer
+	require.NoError(t, engine.RenderResultsWithOptions(&out, engine.OutputJSON, results,
+		engine.RenderOptions{Omitted: omitted}))
+	assert.Contains(t, out.String(), `"omitted": {`)
+	assert.NotContains(t, out.String(), "... and")
+}
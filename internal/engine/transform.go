@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Result transform types accepted by NewResultTransform.
+const (
+	TransformDiscount = "discount"
+	TransformMarkup   = "markup"
+	TransformRound    = "round"
+	TransformCurrency = "currency"
+)
+
+// maxRoundDecimals bounds the precision of the round transform.
+const maxRoundDecimals = 10
+
+// ErrUnknownTransform is returned for a transform type that does not exist.
+var ErrUnknownTransform = errors.New("unknown result transform")
+
+// ResultTransform post-processes a cost result before it is aggregated or rendered.
+type ResultTransform interface {
+	// Name identifies the transform in errors and logs.
+	Name() string
+	// Transform adjusts r in place.
+	Transform(r *CostResult)
+}
+
+// TransformSpec configures one built-in transform. Only the fields of its Type are used:
+//
+//	discount  Percent   lowers every cost by Percent (0-100), e.g. an enterprise agreement
+//	markup    Percent   raises every cost by Percent (>= 0), e.g. management overhead
+//	round     Decimals  rounds Monthly, Hourly and TotalCost to Decimals places (0-10)
+//	currency  From, To, Rate  multiplies costs in From (any currency when empty) by Rate
+//	                    and relabels them To
+type TransformSpec struct {
+	Type     string
+	Percent  float64
+	Decimals int
+	From     string
+	To       string
+	Rate     float64
+}
+
+// TransformChain applies transforms in order. Order matters around rounding: a markup
+// after a round step produces unrounded costs again.
+type TransformChain []ResultTransform
+
+// NewTransformChain builds a chain from specs, in order.
+func NewTransformChain(specs []TransformSpec) (TransformChain, error) {
+	chain := make(TransformChain, 0, len(specs))
+	for i, spec := range specs {
+		t, err := NewResultTransform(spec)
+		if err != nil {
+			return nil, fmt.Errorf("transform %d: %w", i+1, err)
+		}
+		chain = append(chain, t)
+	}
+	return chain, nil
+}
+
+// Apply runs every transform of the chain over each result.
+func (c TransformChain) Apply(results []CostResult) {
+	for i := range results {
+		for _, t := range c {
+			t.Transform(&results[i])
+		}
+	}
+}
+
+// NewResultTransform validates spec and returns the transform it describes.
+func NewResultTransform(spec TransformSpec) (ResultTransform, error) {
+	switch strings.ToLower(strings.TrimSpace(spec.Type)) {
+	case TransformDiscount:
+		if spec.Percent < 0 || spec.Percent > maxPercent {
+			return nil, fmt.Errorf("discount %.2f%% is outside 0-100", spec.Percent)
+		}
+		return scaleTransform{name: TransformDiscount, factor: 1 - spec.Percent/maxPercent}, nil
+	case TransformMarkup:
+		if spec.Percent < 0 {
+			return nil, fmt.Errorf("markup %.2f%% is negative", spec.Percent)
+		}
+		return scaleTransform{name: TransformMarkup, factor: 1 + spec.Percent/maxPercent}, nil
+	case TransformRound:
+		if spec.Decimals < 0 || spec.Decimals > maxRoundDecimals {
+			return nil, fmt.Errorf("round decimals %d is outside 0-%d", spec.Decimals, maxRoundDecimals)
+		}
+		return roundTransform{decimals: spec.Decimals}, nil
+	case TransformCurrency:
+		if spec.To == "" {
+			return nil, errors.New("currency transform needs a target currency")
+		}
+		if spec.Rate <= 0 {
+			return nil, fmt.Errorf("currency rate %g must be positive", spec.Rate)
+		}
+		return currencyTransform{
+			from: strings.ToUpper(spec.From),
+			to:   strings.ToUpper(spec.To),
+			rate: spec.Rate,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTransform, spec.Type)
+	}
+}
+
+// WithResultTransforms sets the chain applied to every projected and actual cost result
+// and returns the engine for chaining.
+func (e *Engine) WithResultTransforms(chain TransformChain) *Engine {
+	e.transforms = chain
+	return e
+}
+
+// finalizeResults is the last step of every projected and actual cost pipeline: it
+// attaches the resource's annotations to its results and runs the transform chain.
+func (e *Engine) finalizeResults(results []CostResult, resource ResourceDescriptor) {
+	annotateResults(results, resource.Annotations)
+	e.transforms.Apply(results)
+}
+
+// scaleTransform multiplies every cost of a result by factor.
+type scaleTransform struct {
+	name   string
+	factor float64
+}
+
+func (t scaleTransform) Name() string { return t.name }
+
+func (t scaleTransform) Transform(r *CostResult) { scaleCosts(r, t.factor) }
+
+// roundTransform rounds the headline costs of a result.
+type roundTransform struct {
+	decimals int
+}
+
+func (roundTransform) Name() string { return TransformRound }
+
+func (t roundTransform) Transform(r *CostResult) {
+	pow := math.Pow10(t.decimals)
+	round := func(v float64) float64 { return math.Round(v*pow) / pow }
+	r.Monthly = round(r.Monthly)
+	r.Hourly = round(r.Hourly)
+	r.TotalCost = round(r.TotalCost)
+}
+
+// currencyTransform converts results from one currency to another at a fixed rate.
+type currencyTransform struct {
+	from string
+	to   string
+	rate float64
+}
+
+func (currencyTransform) Name() string { return TransformCurrency }
+
+func (t currencyTransform) Transform(r *CostResult) {
+	if t.from != "" && !strings.EqualFold(r.Currency, t.from) {
+		return
+	}
+	scaleCosts(r, t.rate)
+	r.Currency = t.to
+}
+
+// scaleCosts multiplies every monetary field of r by factor. Slices and maps are copied,
+// as results may share them with cached responses.
+func scaleCosts(r *CostResult, factor float64) {
+	r.Monthly *= factor
+	r.Hourly *= factor
+	r.TotalCost *= factor
+	r.Delta *= factor
+	if r.DailyCosts != nil {
+		daily := make([]float64, len(r.DailyCosts))
+		for i, v := range r.DailyCosts {
+			daily[i] = v * factor
+		}
+		r.DailyCosts = daily
+	}
+	if r.Breakdown != nil {
+		breakdown := make(map[string]float64, len(r.Breakdown))
+		for k, v := range r.Breakdown {
+			breakdown[k] = v * factor
+		}
+		r.Breakdown = breakdown
+	}
+	if r.Commitment != nil {
+		commitment := *r.Commitment
+		commitment.OnDemandMonthly *= factor
+		r.Commitment = &commitment
+	}
+	if r.Interval != nil {
+		interval := *r.Interval
+		interval.Low *= factor
+		interval.High *= factor
+		r.Interval = &interval
+	}
+}
@@ -0,0 +1,106 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransformChain(t *testing.T) {
+	chain, err := engine.NewTransformChain([]engine.TransformSpec{
+		{Type: engine.TransformDiscount, Percent: 20},
+		{Type: engine.TransformMarkup, Percent: 10},
+		{Type: engine.TransformCurrency, From: "usd", To: "eur", Rate: 0.5},
+		{Type: engine.TransformRound, Decimals: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, chain, 4)
+
+	results := []engine.CostResult{
+		{Currency: "USD", Monthly: 100, Hourly: 0.137, Breakdown: map[string]float64{"compute": 100}},
+		{Currency: "GBP", Monthly: 100},
+	}
+	breakdown := results[0].Breakdown
+	chain.Apply(results)
+
+	assert.InDelta(t, 44.0, results[0].Monthly, 1e-9)
+	assert.InDelta(t, 0.1, results[0].Hourly, 1e-9)
+	assert.Equal(t, "EUR", results[0].Currency)
+	assert.InDelta(t, 44.0, results[0].Breakdown["compute"], 1e-9)
+	assert.InDelta(t, 100.0, breakdown["compute"], 1e-9, "shared maps are not modified")
+
+	assert.InDelta(t, 88.0, results[1].Monthly, 1e-9, "currency step skips other currencies")
+	assert.Equal(t, "GBP", results[1].Currency)
+}
+
+func TestNewResultTransform_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec engine.TransformSpec
+	}{
+		{"unknown type", engine.TransformSpec{Type: "tax"}},
+		{"discount over 100", engine.TransformSpec{Type: engine.TransformDiscount, Percent: 120}},
+		{"negative markup", engine.TransformSpec{Type: engine.TransformMarkup, Percent: -5}},
+		{"negative decimals", engine.TransformSpec{Type: engine.TransformRound, Decimals: -1}},
+		{"currency without target", engine.TransformSpec{Type: engine.TransformCurrency, Rate: 1.1}},
+		{"currency without rate", engine.TransformSpec{Type: engine.TransformCurrency, To: "EUR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.NewResultTransform(tt.spec)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := engine.NewTransformChain([]engine.TransformSpec{{Type: "tax"}})
+	require.ErrorIs(t, err, engine.ErrUnknownTransform)
+	assert.Contains(t, err.Error(), "transform 1")
+}
+
+func TestWithResultTransforms_Projected(t *testing.T) {
+	chain, err := engine.NewTransformChain([]engine.TransformSpec{{Type: engine.TransformDiscount, Percent: 50}})
+	require.NoError(t, err)
+	eng := newScalingGroupTestEngine(t).WithResultTransforms(chain)
+
+	monthly := unitMonthly(t, eng, engine.ResourceDescriptor{
+		Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	})
+	assert.InDelta(t, 3.65, monthly, 1e-9)
+
+	result, err := eng.GetProjectedCostWithErrors(context.Background(), []engine.ResourceDescriptor{{
+		Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.InDelta(t, 3.65, result.Results[0].Monthly, 1e-9)
+}
+
+func TestWithResultTransforms_Actual(t *testing.T) {
+	chain, err := engine.NewTransformChain([]engine.TransformSpec{{Type: engine.TransformDiscount, Percent: 50}})
+	require.NoError(t, err)
+	clients := []*pluginhost.Client{{Name: "billing", API: &fixedActualAPI{total: 40}}}
+	eng := newTestEngine(t, clients, nil).WithResultTransforms(chain)
+
+	resources := []engine.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws"}}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	results, err := eng.GetActualCost(context.Background(), resources, from, to)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.InDelta(t, 20, results[0].TotalCost, 1e-9)
+
+	withErrors, err := eng.GetActualCostWithOptionsAndErrors(context.Background(), engine.ActualCostRequest{
+		Resources: resources, From: from, To: to,
+	})
+	require.NoError(t, err)
+	require.Len(t, withErrors.Results, 1)
+	assert.InDelta(t, 20, withErrors.Results[0].TotalCost, 1e-9)
+}
@@ -33,6 +33,17 @@ type ResourceDescriptor struct {
 	ID         string
 	Provider   string
 	Properties map[string]interface{}
+	// Annotations holds contextual metadata (owner, ticket, purpose) extracted
+	// during ingest. It is carried through to CostResult and never affects pricing.
+	Annotations map[string]string
+	// AllocationTags holds the configured cost allocation tags keyed by normalized name,
+	// with an empty value for tags the resource does not carry.
+	AllocationTags map[string]string
+	// Dependencies lists the URNs of the resource's parent and of the resources it depends
+	// on, when the ingest source records them.
+	Dependencies []string
+	// Parent is the URN of the component or stack the resource belongs to, when known.
+	Parent string
 }
 
 // Validate checks that the ResourceDescriptor has valid fields and returns an error if validation fails.
@@ -132,6 +143,9 @@ type SustainabilityMetric struct {
 //		Currency:        "USD",
 //	}
 type Recommendation struct {
+	// ID is the plugin-assigned recommendation identifier, used for suppression.
+	ID string `json:"id,omitempty"`
+
 	// ResourceID identifies the resource this recommendation applies to.
 	ResourceID string `json:"resourceId,omitempty"`
 
@@ -183,6 +197,48 @@ type CostResult struct {
 	// MEDIUM: Runtime-based estimate from Pulumi timestamps
 	// LOW: Imported resource (timestamp may be inaccurate)
 	Confidence Confidence `json:"confidence,omitempty"`
+
+	// Completeness scores how many pricing-relevant properties of a projected resource were
+	// resolvable; it caps Confidence.
+	Completeness *EstimateCompleteness `json:"completeness,omitempty"`
+
+	// Annotations carries the resource annotations from ResourceDescriptor so
+	// reports can tie costs to ownership. Purely informational.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Identity is the resource's finfocus:id tag, a stable identity that lets diffs match
+	// the resource across renames and environments.
+	Identity string `json:"identity,omitempty"`
+
+	// Capacity is set for scaling groups priced as capacity × per-instance cost.
+	Capacity *ScalingCapacity `json:"capacity,omitempty"`
+
+	// Efficiency normalizes Monthly by the resource's vCPU and memory size when known.
+	Efficiency *CostEfficiency `json:"efficiency,omitempty"`
+
+	// Provenance records the pricing source and version when provenance recording is enabled.
+	Provenance *PricingProvenance `json:"provenance,omitempty"`
+
+	// Commitment is set when Monthly was blended from on-demand and committed rates.
+	Commitment *CommitmentAdjustment `json:"commitment,omitempty"`
+
+	// CostRule names the cost rule that priced or adjusted this result, if any.
+	CostRule string `json:"costRule,omitempty"`
+
+	// Interval bounds Monthly by the historical variance of similar estimates, when known.
+	Interval *ConfidenceInterval `json:"interval,omitempty"`
+
+	// PercentOfTotal is the share of the summary's monthly total, from 0 to 100, that
+	// Monthly makes up. AggregateResults sets it; it is zero when the total is zero.
+	PercentOfTotal float64 `json:"percentOfTotal,omitempty"`
+
+	// GroupPath is the key of each dimension of the group a multi-dimension grouping
+	// aggregated this result into, outermost first (see GroupResultsByDimensions).
+	GroupPath []string `json:"groupPath,omitempty"`
+
+	// AllocationTags are emitted as top-level JSON fields and CSV columns (see MarshalJSON)
+	// so that cost allocation tools can read them directly.
+	AllocationTags map[string]string `json:"-"`
 }
 
 // ErrorDetail captures information about a failed resource cost calculation.
@@ -225,6 +281,9 @@ func (c *CostResultWithErrors) ErrorSummary() string {
 		summary.WriteString(
 			fmt.Sprintf("  - %s (%s): %v\n", err.ResourceType, err.ResourceID, err.Error),
 		)
+		if guidance := PluginErrorGuidance(err); guidance != "" {
+			summary.WriteString("    hint: " + guidance + "\n")
+		}
 	}
 
 	return summary.String()
@@ -232,11 +291,13 @@ func (c *CostResultWithErrors) ErrorSummary() string {
 
 // ActualCostRequest contains parameters for querying historical actual costs with filtering and grouping.
 type ActualCostRequest struct {
-	Resources          []ResourceDescriptor
-	From               time.Time
-	To                 time.Time
-	Adapter            string
-	GroupBy            string
+	Resources []ResourceDescriptor
+	From      time.Time
+	To        time.Time
+	Adapter   string
+	GroupBy   string
+	// GroupByKey is the tag key results are grouped by when GroupBy is GroupByTag.
+	GroupByKey         string
 	Tags               map[string]string
 	EstimateConfidence bool // Show confidence level in output
 }
@@ -291,10 +352,13 @@ type GroupBy string
 //   - GroupByResource: Groups by individual resource (ResourceType/ResourceID)
 //   - GroupByType: Groups by resource type (e.g., "aws:ec2:Instance")
 //   - GroupByProvider: Groups by cloud provider (e.g., "aws", "azure", "gcp")
+//   - GroupByTag: Groups by the value of one tag, such as a cost center (see GroupResultsByTag)
 //
 // Time-Based Groupings:
 //   - GroupByDaily: Groups by calendar date ("2006-01-02") for daily trends
 //   - GroupByMonthly: Groups by month ("2006-01") for monthly analysis
+//   - GroupByQuarterly: Groups by calendar quarter ("2006-Q1") for quarterly reviews
+//   - GroupByYearly: Groups by calendar year ("2006") for annual reviews
 //   - GroupByDate: Deprecated legacy date-key grouping (non time-based for cross-provider).
 //     Prefer GroupByDaily for time-based aggregations.
 //
@@ -307,13 +371,16 @@ type GroupBy string
 //   - Time-based groupings require results with valid StartDate/EndDate fields
 //   - Cross-provider aggregation only supports time-based groupings
 const (
-	GroupByResource GroupBy = "resource"
-	GroupByType     GroupBy = "type"
-	GroupByProvider GroupBy = "provider"
-	GroupByDate     GroupBy = "date" // Deprecated: use GroupByDaily
-	GroupByDaily    GroupBy = "daily"
-	GroupByMonthly  GroupBy = "monthly"
-	GroupByNone     GroupBy = ""
+	GroupByResource  GroupBy = "resource"
+	GroupByType      GroupBy = "type"
+	GroupByProvider  GroupBy = "provider"
+	GroupByTag       GroupBy = "tag"
+	GroupByDate      GroupBy = "date" // Deprecated: use GroupByDaily
+	GroupByDaily     GroupBy = "daily"
+	GroupByMonthly   GroupBy = "monthly"
+	GroupByQuarterly GroupBy = "quarterly"
+	GroupByYearly    GroupBy = "yearly"
+	GroupByNone      GroupBy = ""
 )
 
 // IsValid returns true if the GroupBy value is valid.
@@ -338,7 +405,7 @@ const (
 //	switch groupBy {
 //	case GroupByDaily, GroupByMonthly:
 //		// Time-based processing
-//	case GroupByResource, GroupByType, GroupByProvider:
+//	case GroupByResource, GroupByType, GroupByProvider, GroupByTag:
 //		// Resource-based processing
 //	}
 func (g GroupBy) IsValid() bool {
@@ -346,9 +413,12 @@ func (g GroupBy) IsValid() bool {
 	case GroupByResource,
 		GroupByType,
 		GroupByProvider,
+		GroupByTag,
 		GroupByDate,
 		GroupByDaily,
 		GroupByMonthly,
+		GroupByQuarterly,
+		GroupByYearly,
 		GroupByNone:
 		return true
 	default:
@@ -366,6 +436,8 @@ func (g GroupBy) IsValid() bool {
 // Time-Based GroupBy Values:
 //   - GroupByDaily: Requires daily cost data aggregation
 //   - GroupByMonthly: Requires monthly cost data aggregation
+//   - GroupByQuarterly: Requires quarterly cost data aggregation
+//   - GroupByYearly: Requires yearly cost data aggregation
 //   - GroupByDate: Deprecated legacy date-key grouping (non time-based for cross-provider)
 //
 // Non-Time-Based GroupBy Values:
@@ -375,7 +447,7 @@ func (g GroupBy) IsValid() bool {
 //   - GroupByNone: No grouping applied
 //
 // Returns:
-//   - true: For GroupByDaily and GroupByMonthly only
+//   - true: For GroupByDaily, GroupByMonthly, GroupByQuarterly and GroupByYearly only
 //   - false: For all other GroupBy values
 //
 // Usage Examples:
@@ -392,7 +464,12 @@ func (g GroupBy) IsValid() bool {
 //		return engine.GroupResults(results, groupBy)
 //	}
 func (g GroupBy) IsTimeBasedGrouping() bool {
-	return g == GroupByDaily || g == GroupByMonthly
+	switch g { //nolint:exhaustive // Every other grouping is not time-based.
+	case GroupByDaily, GroupByMonthly, GroupByQuarterly, GroupByYearly:
+		return true
+	default:
+		return false
+	}
 }
 
 // String returns the string representation of the GroupBy.
@@ -432,21 +509,44 @@ type ProjectedCostRequest struct {
 // PricingSpec is an alias to the PricingSpec from the spec package to ensure type consistency.
 type PricingSpec = spec.PricingSpec
 
+// errSpecInheritance is returned by the spec loader for a spec whose extends chain cannot
+// be resolved.
+var errSpecInheritance = spec.ErrSpecInheritance
+
 // CostSummary provides aggregated cost totals grouped by provider, service, and adapter.
+// When the results are priced in more than one currency, ByCurrency holds a subtotal
+// per currency instead: Currency is empty and the combined totals, breakdowns and
+// statistics stay zero, since amounts in different currencies cannot be added.
+//
+// MinMonthly, MaxMonthly, MeanMonthly and MedianMonthly describe the distribution of
+// per-resource monthly costs, leaving out placeholder results for unpriced resources and
+// the $0 results of internal Pulumi resources. SupportedCount and UnsupportedCount count
+// the priced and placeholder results, internal resources counting as neither, and
+// UnsupportedTypes counts the placeholders by resource type, whatever the currencies.
 type CostSummary struct {
-	TotalMonthly float64            `json:"totalMonthly"`
-	TotalHourly  float64            `json:"totalHourly"`
-	Currency     string             `json:"currency"`
-	ByProvider   map[string]float64 `json:"byProvider"`
-	ByService    map[string]float64 `json:"byService"`
-	ByAdapter    map[string]float64 `json:"byAdapter"`
-	Resources    []CostResult       `json:"resources"`
+	TotalMonthly     float64                     `json:"totalMonthly"`
+	TotalHourly      float64                     `json:"totalHourly"`
+	Currency         string                      `json:"currency"`
+	MinMonthly       float64                     `json:"minMonthly"`
+	MaxMonthly       float64                     `json:"maxMonthly"`
+	MeanMonthly      float64                     `json:"meanMonthly"`
+	MedianMonthly    float64                     `json:"medianMonthly"`
+	SupportedCount   int                         `json:"supportedCount"`
+	UnsupportedCount int                         `json:"unsupportedCount"`
+	UnsupportedTypes map[string]int              `json:"unsupportedTypes,omitempty"`
+	ByProvider       map[string]float64          `json:"byProvider"`
+	ByService        map[string]float64          `json:"byService"`
+	ByAdapter        map[string]float64          `json:"byAdapter"`
+	ByCurrency       map[string]CurrencySubtotal `json:"byCurrency,omitempty"`
+	Resources        []CostResult                `json:"resources"`
 }
 
 // AggregatedResults contains cost results with summary and aggregation data.
 type AggregatedResults struct {
 	Summary   CostSummary  `json:"summary"`
 	Resources []CostResult `json:"resources"`
+	// Omitted summarizes the results left out when output is limited with TopResults.
+	Omitted *OmittedResults `json:"omitted,omitempty"`
 }
 
 // RecommendationError captures error information when fetching recommendations from a plugin.
@@ -461,6 +561,9 @@ type RecommendationsResult struct {
 	Errors          []RecommendationError `json:"errors"`
 	TotalSavings    float64               `json:"totalSavings"`
 	Currency        string                `json:"currency"`
+	// Suppressed counts recommendations hidden by suppression rules; their savings are
+	// excluded from TotalSavings.
+	Suppressed int `json:"suppressed,omitempty"`
 }
 
 // HasErrors returns true if any errors were encountered.
@@ -19,6 +19,8 @@ func TestGroupBy_IsValid(t *testing.T) {
 		{"valid date", GroupByDate, true},
 		{"valid daily", GroupByDaily, true},
 		{"valid monthly", GroupByMonthly, true},
+		{"valid quarterly", GroupByQuarterly, true},
+		{"valid yearly", GroupByYearly, true},
 		{"valid none", GroupByNone, true},
 		{"invalid empty string not GroupByNone", GroupBy(""), true}, // Empty string is GroupByNone
 		{"invalid random", GroupBy("random"), false},
@@ -45,6 +47,8 @@ func TestGroupBy_IsTimeBasedGrouping(t *testing.T) {
 	}{
 		{"daily is time-based", GroupByDaily, true},
 		{"monthly is time-based", GroupByMonthly, true},
+		{"quarterly is time-based", GroupByQuarterly, true},
+		{"yearly is time-based", GroupByYearly, true},
 		{"resource is not time-based", GroupByResource, false},
 		{"type is not time-based", GroupByType, false},
 		{"provider is not time-based", GroupByProvider, false},
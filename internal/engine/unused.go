@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// recommendationTypeDeleteUnused is the action type of possibly unused resources, the
+	// short name plugins use for the same action.
+	recommendationTypeDeleteUnused = "DELETE_UNUSED"
+
+	// unusedRecommendationPrefix precedes the resource ID in the ID of unused resource
+	// recommendations, so they can be suppressed like plugin recommendations.
+	unusedRecommendationPrefix = "finfocus-unused:"
+
+	// standaloneTag marks a waste-prone resource as deliberately unreferenced, such as an
+	// Elastic IP allowlisted by a partner and attached outside Pulumi.
+	standaloneTag = "finfocus:standalone"
+)
+
+// wasteProneType describes a resource type that costs money while serving nothing until
+// another resource references it or one of its attachment properties is set.
+type wasteProneType struct {
+	label       string
+	attachProps []string
+}
+
+// wasteProneTypes are the resource types checked for being unused. Types that are useful
+// on their own, such as buckets, functions and databases, are deliberately absent.
+var wasteProneTypes = map[string]wasteProneType{
+	"aws:ebs/volume:Volume":             {label: "EBS volume"},
+	"aws:ec2/eip:Eip":                   {label: "Elastic IP", attachProps: []string{"instance", "networkInterface"}},
+	"aws:ec2/natGateway:NatGateway":     {label: "NAT gateway"},
+	"aws:lb/loadBalancer:LoadBalancer":  {label: "load balancer"},
+	"aws:alb/loadBalancer:LoadBalancer": {label: "load balancer"},
+	"aws:elb/loadBalancer:LoadBalancer": {label: "classic load balancer", attachProps: []string{"instances"}},
+	"aws:ec2/networkInterface:NetworkInterface": {
+		label: "network interface", attachProps: []string{"attachments"},
+	},
+
+	"azure-native:compute:Disk":             {label: "managed disk", attachProps: []string{"managedBy"}},
+	"azure-native:network:PublicIPAddress":  {label: "public IP", attachProps: []string{"ipConfiguration"}},
+	"azure-native:network:NetworkInterface": {label: "network interface", attachProps: []string{"virtualMachine"}},
+	"azure:compute/managedDisk:ManagedDisk": {label: "managed disk"},
+	"azure:network/publicIp:PublicIp":       {label: "public IP"},
+
+	"gcp:compute/disk:Disk":                   {label: "persistent disk", attachProps: []string{"users"}},
+	"gcp:compute/address:Address":             {label: "static IP", attachProps: []string{"users"}},
+	"gcp:compute/globalAddress:GlobalAddress": {label: "static IP", attachProps: []string{"users"}},
+	"gcp:compute/targetPool:TargetPool":       {label: "target pool", attachProps: []string{"instances"}},
+}
+
+// DetectUnusedResources flags waste-prone resources that cost money but that no other
+// resource references, such as detached volumes and unassociated IPs, and recommends
+// deleting them with their monthly cost as savings. Resources tagged finfocus:standalone
+// and those whose attachment property is set are skipped. Without dependency data every
+// resource would look unreferenced, so nothing is flagged.
+func DetectUnusedResources(resources []ResourceDescriptor, results []CostResult) []Recommendation {
+	costs := make(map[string]CostResult, len(results))
+	for _, r := range results {
+		if existing, ok := costs[r.ResourceID]; !ok || r.Monthly > existing.Monthly {
+			costs[r.ResourceID] = r
+		}
+	}
+
+	var recommendations []Recommendation
+	for _, c := range unusedCandidates(resources) {
+		cost, priced := costs[c.resource.ID]
+		if !priced || cost.Monthly <= 0 {
+			continue
+		}
+		recommendations = append(recommendations, Recommendation{
+			ID:         unusedRecommendationPrefix + c.resource.ID,
+			ResourceID: c.resource.ID,
+			Type:       recommendationTypeDeleteUnused,
+			Description: fmt.Sprintf("Possibly unused %s: no other resource references it; delete it if it "+
+				"is not attached outside this stack", c.kind.label),
+			EstimatedSavings: cost.Monthly,
+			Currency:         cost.Currency,
+		})
+	}
+	return recommendations
+}
+
+// UnusedResourceRecommendations prices the waste-prone resources that nothing references
+// and returns DetectUnusedResources for them, leaving out suppressed recommendations.
+func (e *Engine) UnusedResourceRecommendations(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+) ([]Recommendation, error) {
+	candidates := unusedCandidates(resources)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	toPrice := make([]ResourceDescriptor, 0, len(candidates))
+	for _, c := range candidates {
+		toPrice = append(toPrice, c.resource)
+	}
+	results, err := e.GetProjectedCost(ctx, toPrice)
+	if err != nil {
+		return nil, err
+	}
+	var recommendations []Recommendation
+	for _, rec := range DetectUnusedResources(resources, results) {
+		if !isSuppressed(rec, e.suppressions) {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	return recommendations, nil
+}
+
+// unusedCandidate is a waste-prone resource that nothing references.
+type unusedCandidate struct {
+	resource ResourceDescriptor
+	kind     wasteProneType
+}
+
+// unusedCandidates returns the waste-prone resources no other resource depends on, or
+// nothing when the resources carry no dependency data.
+func unusedCandidates(resources []ResourceDescriptor) []unusedCandidate {
+	if !hasDependencyData(resources) {
+		return nil
+	}
+	referenced := make(map[string]bool)
+	for _, r := range resources {
+		for _, dep := range r.Dependencies {
+			referenced[dep] = true
+		}
+	}
+	var candidates []unusedCandidate
+	for _, r := range resources {
+		kind, wasteProne := wasteProneTypes[r.Type]
+		if !wasteProne || referenced[r.ID] || isStandalone(r) || hasAttachment(r.Properties, kind.attachProps) {
+			continue
+		}
+		candidates = append(candidates, unusedCandidate{resource: r, kind: kind})
+	}
+	return candidates
+}
+
+func isStandalone(r ResourceDescriptor) bool {
+	tags, _ := r.Properties["tags"].(map[string]interface{})
+	value, ok := tags[standaloneTag]
+	return ok && strings.EqualFold(fmt.Sprint(value), "true")
+}
+
+// hasAttachment reports whether any of the attachment properties is set to a non-empty
+// value.
+func hasAttachment(props map[string]interface{}, attachProps []string) bool {
+	for _, key := range attachProps {
+		switch v := props[key].(type) {
+		case nil:
+		case string:
+			if v != "" {
+				return true
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				return true
+			}
+		case map[string]interface{}:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
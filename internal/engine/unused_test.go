@@ -0,0 +1,139 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	unusedTestVolumeType   = "aws:ebs/volume:Volume"
+	unusedTestInstanceType = "aws:ec2/instance:Instance"
+)
+
+func TestDetectUnusedResources(t *testing.T) {
+	instance := engine.ResourceDescriptor{
+		Type: unusedTestInstanceType, ID: "web", Dependencies: []string{"data"},
+	}
+	tests := []struct {
+		name      string
+		resources []engine.ResourceDescriptor
+		results   []engine.CostResult
+		wantIDs   []string
+	}{
+		{
+			name: "unreferenced volume",
+			resources: []engine.ResourceDescriptor{
+				instance,
+				{Type: unusedTestVolumeType, ID: "data"},
+				{Type: unusedTestVolumeType, ID: "orphan"},
+			},
+			results: []engine.CostResult{
+				{ResourceID: "data", Monthly: 8, Currency: "USD"},
+				{ResourceID: "orphan", Monthly: 10, Currency: "USD"},
+			},
+			wantIDs: []string{"orphan"},
+		},
+		{
+			name: "attached elastic IP",
+			resources: []engine.ResourceDescriptor{
+				instance,
+				{Type: "aws:ec2/eip:Eip", ID: "ip", Properties: map[string]interface{}{"instance": "i-123"}},
+			},
+			results: []engine.CostResult{{ResourceID: "ip", Monthly: 3.6}},
+		},
+		{
+			name: "standalone tag",
+			resources: []engine.ResourceDescriptor{
+				instance,
+				{Type: "aws:ec2/eip:Eip", ID: "ip", Properties: map[string]interface{}{
+					"tags": map[string]interface{}{"finfocus:standalone": "true"},
+				}},
+			},
+			results: []engine.CostResult{{ResourceID: "ip", Monthly: 3.6}},
+		},
+		{
+			name: "types useful on their own",
+			resources: []engine.ResourceDescriptor{
+				instance,
+				{Type: "aws:s3/bucket:Bucket", ID: "bucket"},
+			},
+			results: []engine.CostResult{{ResourceID: "bucket", Monthly: 2}},
+		},
+		{
+			name: "free resources",
+			resources: []engine.ResourceDescriptor{
+				instance,
+				{Type: unusedTestVolumeType, ID: "orphan"},
+			},
+			results: []engine.CostResult{{ResourceID: "orphan"}},
+		},
+		{
+			name: "no dependency data",
+			resources: []engine.ResourceDescriptor{
+				{Type: unusedTestInstanceType, ID: "web"},
+				{Type: unusedTestVolumeType, ID: "orphan"},
+			},
+			results: []engine.CostResult{{ResourceID: "orphan", Monthly: 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := engine.DetectUnusedResources(tt.resources, tt.results)
+
+			var ids []string
+			for _, rec := range recs {
+				ids = append(ids, rec.ResourceID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestDetectUnusedResources_Recommendation(t *testing.T) {
+	recs := engine.DetectUnusedResources(
+		[]engine.ResourceDescriptor{
+			{Type: unusedTestInstanceType, ID: "web", Dependencies: []string{"sg"}},
+			{Type: unusedTestVolumeType, ID: "orphan"},
+		},
+		[]engine.CostResult{
+			{ResourceID: "orphan", Adapter: "a", Monthly: 8, Currency: "USD"},
+			{ResourceID: "orphan", Adapter: "b", Monthly: 10, Currency: "USD"},
+		},
+	)
+
+	require.Len(t, recs, 1)
+	assert.Equal(t, "finfocus-unused:orphan", recs[0].ID)
+	assert.Equal(t, "DELETE_UNUSED", recs[0].Type)
+	assert.Contains(t, recs[0].Description, "Possibly unused EBS volume")
+	assert.InDelta(t, 10.0, recs[0].EstimatedSavings, 0.0001)
+	assert.Equal(t, "USD", recs[0].Currency)
+}
+
+func TestUnusedResourceRecommendations(t *testing.T) {
+	clients := []*pluginhost.Client{{Name: "aws-public", API: &fixedCostAPI{monthly: 12}}}
+	resources := []engine.ResourceDescriptor{
+		{Type: unusedTestInstanceType, ID: "web", Dependencies: []string{"data"}},
+		{Type: unusedTestVolumeType, ID: "data"},
+		{Type: unusedTestVolumeType, ID: "orphan"},
+		{Type: "aws:ec2/eip:Eip", ID: "ip"},
+	}
+
+	recs, err := newTestEngine(t, clients, nil).UnusedResourceRecommendations(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, recs, 2)
+	assert.Equal(t, "orphan", recs[0].ResourceID)
+	assert.InDelta(t, 12.0, recs[0].EstimatedSavings, 0.0001)
+	assert.Equal(t, "ip", recs[1].ResourceID)
+
+	recs, err = newTestEngine(t, clients, nil).
+		WithRecommendationSuppressions([]engine.RecommendationSuppression{{ResourceID: "ip"}}).
+		UnusedResourceRecommendations(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	assert.Equal(t, "orphan", recs[0].ResourceID)
+}
@@ -0,0 +1,91 @@
+package ingest
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NormalizeTagKey converts tag keys written in different conventions to a single
+// lower-case, hyphenated form so that "CostCenter", "cost_center", "Cost Center" and
+// "cost-center" all resolve to "cost-center".
+func NormalizeTagKey(key string) string {
+	var b strings.Builder
+	prevLower := false
+	pendingSep := false
+	for _, r := range strings.TrimSpace(key) {
+		switch {
+		case r == '-' || r == '_' || r == ' ' || r == '.' || r == ':' || r == '/':
+			pendingSep = b.Len() > 0
+			prevLower = false
+			continue
+		case unicode.IsUpper(r) && prevLower:
+			pendingSep = true
+		}
+		if pendingSep {
+			b.WriteByte('-')
+			pendingSep = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+		prevLower = unicode.IsLower(r) || unicode.IsDigit(r)
+	}
+	return b.String()
+}
+
+// ExtractAllocationTags returns the value of each allocation tag key, keyed by its
+// normalized form. Unlike ExtractAnnotations every key is present in the result, with an
+// empty value when the resource does not carry it, so that exports have a stable shape.
+//
+// A dedicated top-level property takes precedence over the "tags" and "labels" maps, and
+// keys are compared after normalization.
+func ExtractAllocationTags(inputs map[string]interface{}, keys []string) map[string]string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]string, len(keys))
+	for _, key := range keys {
+		tags[NormalizeTagKey(key)] = ""
+	}
+	fillAllocationTags(tags, inputs)
+	for _, mapKey := range annotationTagMaps {
+		fillAllocationTags(tags, inputs[mapKey])
+	}
+	return tags
+}
+
+// fillAllocationTags copies scalar values from raw (a property or tag map) into wanted keys
+// that are still empty. Keys are visited in sorted order so that "CostCenter" and
+// "cost_center" on the same resource resolve deterministically.
+func fillAllocationTags(tags map[string]string, raw interface{}) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		normalized := NormalizeTagKey(k)
+		if current, wanted := tags[normalized]; !wanted || current != "" {
+			continue
+		}
+		if value, isScalar := scalarString(m[k]); isScalar && value != "" {
+			tags[normalized] = value
+		}
+	}
+}
+
+// ResourceEnvironment returns the environment a resource belongs to: the value of tagKey on
+// the resource, looked up as by ExtractAllocationTags, or the stack name of its URN when the
+// tag is absent.
+func ResourceEnvironment(urn string, inputs map[string]interface{}, tagKey string) string {
+	if tagKey != "" {
+		if env := ExtractAllocationTags(inputs, []string{tagKey})[NormalizeTagKey(tagKey)]; env != "" {
+			return env
+		}
+	}
+	return extractStackFromURN(urn)
+}
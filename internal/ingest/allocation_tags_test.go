@@ -0,0 +1,59 @@
+package ingest_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/ingest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTagKey(t *testing.T) {
+	for input, want := range map[string]string{
+		"cost-center":  "cost-center",
+		"CostCenter":   "cost-center",
+		"cost_center":  "cost-center",
+		"Cost Center":  "cost-center",
+		"costCenter":   "cost-center",
+		"team":         "team",
+		"Team":         "team",
+		"app:tier":     "app-tier",
+		"  Env__Name ": "env-name",
+	} {
+		assert.Equal(t, want, ingest.NormalizeTagKey(input), input)
+	}
+}
+
+func TestExtractAllocationTags(t *testing.T) {
+	inputs := map[string]interface{}{
+		"environment": "prod",
+		"tags": map[string]interface{}{
+			"Team":        "platform",
+			"Environment": "staging",
+		},
+		"labels": map[string]interface{}{
+			"cost_center": "cc-42",
+		},
+	}
+
+	tags := ingest.ExtractAllocationTags(inputs, []string{"team", "CostCenter", "environment", "owner"})
+	assert.Equal(t, map[string]string{
+		"team":        "platform",
+		"cost-center": "cc-42",
+		"environment": "prod",
+		"owner":       "",
+	}, tags)
+
+	assert.Nil(t, ingest.ExtractAllocationTags(inputs, nil))
+}
+
+func TestResourceEnvironment(t *testing.T) {
+	const urn = "urn:pulumi:prod-us::shop::aws:ec2/instance:Instance::web"
+
+	assert.Equal(t, "staging", ingest.ResourceEnvironment(urn,
+		map[string]interface{}{"tags": map[string]interface{}{"Environment": "staging"}}, "environment"))
+	assert.Equal(t, "qa", ingest.ResourceEnvironment(urn,
+		map[string]interface{}{"labels": map[string]interface{}{"env_name": "qa"}}, "EnvName"))
+	assert.Equal(t, "prod-us", ingest.ResourceEnvironment(urn, nil, "environment"))
+	assert.Equal(t, "prod-us", ingest.ResourceEnvironment(urn, map[string]interface{}{"environment": ""}, ""))
+	assert.Empty(t, ingest.ResourceEnvironment("not-a-urn", nil, "environment"))
+}
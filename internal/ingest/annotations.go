@@ -0,0 +1,92 @@
+package ingest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// annotationTagMaps lists the input properties that hold key/value tag maps.
+// AWS and Azure use "tags", GCP and Kubernetes use "labels".
+var annotationTagMaps = []string{"tags", "labels"}
+
+// ExtractAnnotations returns the values of the requested annotation keys found in
+// a resource's inputs.
+//
+// A dedicated top-level property (e.g. inputs["owner"]) takes precedence. Otherwise
+// the tag maps ("tags", then "labels") are searched, matching keys case-insensitively
+// so that an "Owner" tag satisfies the "owner" annotation. Keys with no value are
+// omitted; nil is returned when nothing matched.
+func ExtractAnnotations(inputs map[string]interface{}, keys []string) map[string]string {
+	if len(inputs) == 0 || len(keys) == 0 {
+		return nil
+	}
+
+	var annotations map[string]string
+	for _, key := range keys {
+		value, ok := lookupAnnotation(inputs, key)
+		if !ok {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string, len(keys))
+		}
+		annotations[key] = value
+	}
+	return annotations
+}
+
+// lookupAnnotation resolves a single annotation key from a dedicated property or tag map.
+func lookupAnnotation(inputs map[string]interface{}, key string) (string, bool) {
+	if raw, ok := inputs[key]; ok {
+		if value, isScalar := scalarString(raw); isScalar && value != "" {
+			return value, true
+		}
+	}
+
+	for _, mapKey := range annotationTagMaps {
+		tags, ok := inputs[mapKey].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for tagKey, raw := range tags {
+			if !strings.EqualFold(tagKey, key) {
+				continue
+			}
+			if value, isScalar := scalarString(raw); isScalar && value != "" {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// scalarString formats a scalar property value. Maps and slices are rejected because
+// they cannot be rendered meaningfully in a single report column.
+func scalarString(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case bool, float64, int, int64:
+		return fmt.Sprintf("%v", v), true
+	default:
+		return "", false
+	}
+}
+
+// MapResourcesWithAnnotations converts Pulumi resources to ResourceDescriptors and
+// populates each descriptor's Annotations with the requested keys.
+func MapResourcesWithAnnotations(
+	resources []PulumiResource,
+	annotationKeys []string,
+) ([]engine.ResourceDescriptor, error) {
+	descriptors, err := MapResources(resources)
+	if err != nil {
+		return nil, err
+	}
+	for i := range descriptors {
+		descriptors[i].Annotations = ExtractAnnotations(descriptors[i].Properties, annotationKeys)
+	}
+	return descriptors, nil
+}
@@ -0,0 +1,95 @@
+package ingest_test
+
+import (
+	"testing"
+
+	"github.com/rshade/finfocus/internal/ingest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   map[string]interface{}
+		keys     []string
+		expected map[string]string
+	}{
+		{
+			name:     "dedicated property",
+			inputs:   map[string]interface{}{"owner": "team-a"},
+			keys:     []string{"owner"},
+			expected: map[string]string{"owner": "team-a"},
+		},
+		{
+			name: "aws tags case-insensitive",
+			inputs: map[string]interface{}{
+				"tags": map[string]interface{}{"Owner": "team-b", "Ticket": "OPS-42"},
+			},
+			keys:     []string{"owner", "ticket"},
+			expected: map[string]string{"owner": "team-b", "ticket": "OPS-42"},
+		},
+		{
+			name: "gcp labels",
+			inputs: map[string]interface{}{
+				"labels": map[string]interface{}{"purpose": "batch"},
+			},
+			keys:     []string{"purpose"},
+			expected: map[string]string{"purpose": "batch"},
+		},
+		{
+			name: "dedicated property wins over tag",
+			inputs: map[string]interface{}{
+				"owner": "direct",
+				"tags":  map[string]interface{}{"owner": "tagged"},
+			},
+			keys:     []string{"owner"},
+			expected: map[string]string{"owner": "direct"},
+		},
+		{
+			name: "non-scalar property falls back to tags",
+			inputs: map[string]interface{}{
+				"owner": map[string]interface{}{"name": "x"},
+				"tags":  map[string]interface{}{"owner": "tagged"},
+			},
+			keys:     []string{"owner"},
+			expected: map[string]string{"owner": "tagged"},
+		},
+		{
+			name:     "missing keys",
+			inputs:   map[string]interface{}{"instanceType": "t3.micro"},
+			keys:     []string{"owner"},
+			expected: nil,
+		},
+		{
+			name:     "no keys requested",
+			inputs:   map[string]interface{}{"owner": "team-a"},
+			keys:     nil,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ingest.ExtractAnnotations(tt.inputs, tt.keys))
+		})
+	}
+}
+
+func TestMapResourcesWithAnnotations(t *testing.T) {
+	resources := []ingest.PulumiResource{
+		{
+			Type: "aws:ec2/instance:Instance",
+			URN:  "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			Inputs: map[string]interface{}{
+				"tags": map[string]interface{}{"Owner": "team-a"},
+			},
+		},
+	}
+
+	descriptors, err := ingest.MapResourcesWithAnnotations(resources, []string{"owner"})
+	require.NoError(t, err)
+	require.Len(t, descriptors, 1)
+	assert.Equal(t, map[string]string{"owner": "team-a"}, descriptors[0].Annotations)
+	assert.Equal(t, "aws", descriptors[0].Provider)
+}
@@ -14,10 +14,12 @@ func MapResource(pulumiResource PulumiResource) (engine.ResourceDescriptor, erro
 	provider := extractProvider(pulumiResource.Type)
 
 	return engine.ResourceDescriptor{
-		Type:       pulumiResource.Type,
-		ID:         pulumiResource.URN,
-		Provider:   provider,
-		Properties: pulumiResource.Inputs,
+		Type:         pulumiResource.Type,
+		ID:           pulumiResource.URN,
+		Provider:     provider,
+		Properties:   pulumiResource.Inputs,
+		Dependencies: pulumiResource.Dependencies,
+		Parent:       pulumiResource.Parent,
 	}, nil
 }
 
@@ -33,10 +33,12 @@ type PulumiStep struct {
 
 // PulumiState represents the state of a resource in a Pulumi step.
 type PulumiState struct {
-	Type     string                 `json:"type"`
-	URN      string                 `json:"urn"`
-	Inputs   map[string]interface{} `json:"inputs"`
-	Provider string                 `json:"provider"`
+	Type         string                 `json:"type"`
+	URN          string                 `json:"urn"`
+	Inputs       map[string]interface{} `json:"inputs"`
+	Provider     string                 `json:"provider"`
+	Parent       string                 `json:"parent,omitempty"`
+	Dependencies []string               `json:"dependencies,omitempty"`
 }
 
 // PulumiResource contains the detailed information about a resource in a Pulumi step.
@@ -45,6 +47,10 @@ type PulumiResource struct {
 	URN      string
 	Provider string
 	Inputs   map[string]interface{}
+	// Dependencies lists the URNs of the resource's parent and the resources it depends on.
+	Dependencies []string
+	// Parent is the URN of the component or stack the resource belongs to.
+	Parent string
 }
 
 // LoadPulumiPlan loads and parses a Pulumi plan JSON file from the specified path.
@@ -115,6 +121,8 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 		if step.Op == "create" || step.Op == "update" || step.Op == "same" {
 			resType := step.Type
 			inputs := step.Inputs
+			var dependencies []string
+			var parent string
 
 			// Prioritize NewState for Create/Update operations if available
 			if step.NewState != nil {
@@ -124,6 +132,8 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 				if inputs == nil {
 					inputs = step.NewState.Inputs
 				}
+				dependencies = mergeDependencies(step.NewState.Parent, step.NewState.Dependencies)
+				parent = step.NewState.Parent
 			}
 
 			if resType == "" {
@@ -131,10 +141,12 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 			}
 
 			resources = append(resources, PulumiResource{
-				Type:     resType,
-				URN:      step.URN,
-				Provider: extractProviderFromURN(step.URN),
-				Inputs:   inputs,
+				Type:         resType,
+				URN:          step.URN,
+				Provider:     extractProviderFromURN(step.URN),
+				Inputs:       inputs,
+				Dependencies: dependencies,
+				Parent:       parent,
 			})
 			log.Debug().
 				Ctx(ctx).
@@ -160,6 +172,20 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 	return resources
 }
 
+// mergeDependencies returns the parent URN followed by the dependency URNs, without
+// duplicates or empty entries.
+func mergeDependencies(parent string, dependencies []string) []string {
+	seen := make(map[string]bool, len(dependencies)+1)
+	var merged []string
+	for _, urn := range append([]string{parent}, dependencies...) {
+		if urn != "" && !seen[urn] {
+			seen[urn] = true
+			merged = append(merged, urn)
+		}
+	}
+	return merged
+}
+
 func extractTypeFromURN(urn string) string {
 	parts := strings.Split(urn, "::")
 	if len(parts) >= minURNParts {
@@ -168,6 +194,16 @@ func extractTypeFromURN(urn string) string {
 	return ""
 }
 
+// extractStackFromURN returns the stack segment of urn:pulumi:<stack>::<project>::..., or
+// an empty string for a malformed URN.
+func extractStackFromURN(urn string) string {
+	parts := strings.Split(urn, "::")
+	if len(parts) < minURNParts {
+		return ""
+	}
+	return strings.TrimPrefix(parts[0], "urn:pulumi:")
+}
+
 func extractProviderFromURN(urn string) string {
 	parts := strings.Split(urn, "::")
 	if len(parts) >= minURNParts {
@@ -455,3 +455,43 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestPulumiPlan_GetResources_Dependencies(t *testing.T) {
+	const vpc = "urn:pulumi:dev::app::aws:ec2/vpc:Vpc::main"
+	const network = "urn:pulumi:dev::app::my:network:Network::core"
+	plan := ingest.PulumiPlan{Steps: []ingest.PulumiStep{
+		{Op: "create", URN: vpc, Type: "aws:ec2/vpc:Vpc"},
+		{
+			Op:   "create",
+			URN:  "urn:pulumi:dev::app::aws:ec2/subnet:Subnet::a",
+			Type: "aws:ec2/subnet:Subnet",
+			NewState: &ingest.PulumiState{
+				Parent:       network,
+				Dependencies: []string{vpc},
+			},
+		},
+	}}
+
+	resources := plan.GetResources()
+	if len(resources) != 2 {
+		t.Fatalf("GetResources() returned %d resources, want 2", len(resources))
+	}
+	if len(resources[0].Dependencies) != 0 {
+		t.Errorf("resource without newState has dependencies %v", resources[0].Dependencies)
+	}
+	got := resources[1].Dependencies
+	if len(got) != 2 || got[0] != network || got[1] != vpc {
+		t.Errorf("Dependencies = %v, want [%s %s]", got, network, vpc)
+	}
+
+	descriptors, err := ingest.MapResources(resources)
+	if err != nil {
+		t.Fatalf("MapResources() error = %v", err)
+	}
+	if len(descriptors[1].Dependencies) != 2 {
+		t.Errorf("descriptor Dependencies = %v, want 2 entries", descriptors[1].Dependencies)
+	}
+	if descriptors[1].Parent != network {
+		t.Errorf("descriptor Parent = %q, want %q", descriptors[1].Parent, network)
+	}
+}
@@ -51,6 +51,10 @@ type StackExportResource struct {
 	Provider string                 `json:"provider,omitempty"`
 	Inputs   map[string]interface{} `json:"inputs,omitempty"`
 	Outputs  map[string]interface{} `json:"outputs,omitempty"`
+	// Parent is the URN of the component or stack the resource belongs to.
+	Parent string `json:"parent,omitempty"`
+	// Dependencies lists the URNs of the resources this resource depends on.
+	Dependencies []string `json:"dependencies,omitempty"`
 	// Created tracks when the remote resource was first added to state.
 	// Available since Pulumi v3.60.0 (March 2023).
 	Created *time.Time `json:"created,omitempty"`
@@ -163,10 +167,12 @@ func MapStateResource(resource StackExportResource) (engine.ResourceDescriptor,
 	}
 
 	return engine.ResourceDescriptor{
-		Type:       resource.Type,
-		ID:         resource.URN,
-		Provider:   provider,
-		Properties: properties,
+		Type:         resource.Type,
+		ID:           resource.URN,
+		Provider:     provider,
+		Properties:   properties,
+		Dependencies: mergeDependencies(resource.Parent, resource.Dependencies),
+		Parent:       resource.Parent,
 	}, nil
 }
 
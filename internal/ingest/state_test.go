@@ -409,3 +409,20 @@ func TestGetCustomResourcesWithContext(t *testing.T) {
 	resources := state.GetCustomResourcesWithContext(ctx)
 	assert.Len(t, resources, 2)
 }
+
+func TestMapStateResource_Dependencies(t *testing.T) {
+	desc, err := ingest.MapStateResource(ingest.StackExportResource{
+		URN:    "urn:pulumi:dev::project::aws:ec2/instance:Instance::web",
+		Type:   "aws:ec2/instance:Instance",
+		Parent: "urn:pulumi:dev::project::pulumi:pulumi:Stack::project-dev",
+		Dependencies: []string{
+			"urn:pulumi:dev::project::aws:ec2/subnet:Subnet::private",
+			"urn:pulumi:dev::project::pulumi:pulumi:Stack::project-dev",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"urn:pulumi:dev::project::pulumi:pulumi:Stack::project-dev",
+		"urn:pulumi:dev::project::aws:ec2/subnet:Subnet::private",
+	}, desc.Dependencies, "the parent comes first and duplicates are dropped")
+}
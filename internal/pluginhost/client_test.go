@@ -27,6 +27,21 @@ type mockCostSourceServer struct {
 	pluginInfo     *pbc.GetPluginInfoResponse
 	pluginInfoErr  error
 	pluginInfoWait time.Duration
+	// health, when set, is served by an ObservabilityService on the same connection.
+	health *pbc.HealthCheckResponse
+}
+
+type mockObservabilityServer struct {
+	pbc.UnimplementedObservabilityServiceServer
+
+	resp *pbc.HealthCheckResponse
+}
+
+func (s *mockObservabilityServer) HealthCheck(
+	context.Context,
+	*pbc.HealthCheckRequest,
+) (*pbc.HealthCheckResponse, error) {
+	return s.resp, nil
 }
 
 func (s *mockCostSourceServer) Name(
@@ -73,6 +88,9 @@ func setupMockServer(_ *testing.T, srv *mockCostSourceServer) (*grpcMockLauncher
 	listener := bufconn.Listen(bufSize)
 	s := grpc.NewServer()
 	pbc.RegisterCostSourceServiceServer(s, srv)
+	if srv.health != nil {
+		pbc.RegisterObservabilityServiceServer(s, &mockObservabilityServer{resp: srv.health})
+	}
 	go func() {
 		if err := s.Serve(listener); err != nil {
 			if !errors.Is(err, grpc.ErrServerStopped) {
@@ -154,3 +172,40 @@ func TestGetPluginInfo_Timeout(t *testing.T) {
 	assert.Equal(t, "slow-plugin", client.Name)
 	assert.Nil(t, client.Metadata)
 }
+
+func TestClientValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		health  *pbc.HealthCheckResponse
+		wantErr string
+	}{
+		{name: "no health check", health: nil},
+		{name: "serving", health: &pbc.HealthCheckResponse{Status: pbc.HealthCheckResponse_STATUS_SERVING}},
+		{
+			name: "not serving",
+			health: &pbc.HealthCheckResponse{
+				Status:  pbc.HealthCheckResponse_STATUS_NOT_SERVING,
+				Message: "no AWS credentials found",
+			},
+			wantErr: "plugin not ready: no AWS credentials found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			launcher, cleanup := setupMockServer(t, &mockCostSourceServer{name: "aws", health: tt.health})
+			defer cleanup()
+
+			client, err := pluginhost.NewClient(context.Background(), launcher, "dummy")
+			require.NoError(t, err)
+			defer client.Close()
+
+			err = client.Validate(context.Background())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, pluginhost.ErrPluginNotReady)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -15,6 +15,8 @@
 //   - 10-second connection timeout
 //   - 100ms retry delays
 //   - Automatic cleanup on failure
+//   - gRPC keepalive pings (FINFOCUS_GRPC_KEEPALIVE_TIME/_TIMEOUT)
+//   - Optional idle shutdown and lazy relaunch (FINFOCUS_GRPC_IDLE_TIMEOUT)
 //
 // # Platform Support
 //
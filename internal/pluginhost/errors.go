@@ -1,10 +1,16 @@
 package pluginhost
 
 import (
+	"errors"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// ErrPluginNotReady is returned by Client.Validate when a plugin reports that it cannot
+// serve requests, typically because of missing credentials or a wrong region.
+var ErrPluginNotReady = errors.New("plugin not ready")
+
 // IsUnimplementedError checks if the error is a gRPC Unimplemented error.
 func IsUnimplementedError(err error) bool {
 	return status.Code(err) == codes.Unimplemented
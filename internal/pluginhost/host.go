@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/proto"
 	"google.golang.org/grpc"
@@ -18,6 +19,10 @@ type contextKey string
 const SkipVersionCheckKey contextKey = "skip_version_check"
 
 // Client wraps a gRPC connection to a plugin and provides the cost source API.
+//
+// When an idle timeout is configured (see ConnectionOptions), API closes the plugin after
+// a period without calls and relaunches it on the next one; Conn then refers to the
+// initial connection only and should not be used directly.
 type Client struct {
 	Name     string
 	Metadata *proto.PluginMetadata
@@ -39,6 +44,11 @@ func NewClient(ctx context.Context, launcher Launcher, binPath string) (*Client,
 	}
 
 	api := proto.NewCostSourceClient(conn)
+	if idle := ConnectionOptionsFromEnv().IdleTimeout; idle > 0 {
+		ic := newIdleClient(ctx, launcher, binPath, idle, conn, closeFn)
+		api = ic
+		closeFn = ic.Close
+	}
 
 	// Get plugin name (legacy method, fast)
 	nameResp, err := api.Name(ctx, &proto.Empty{})
@@ -82,6 +92,41 @@ func NewClient(ctx context.Context, launcher Launcher, binPath string) (*Client,
 	return client, nil
 }
 
+// validateTimeout bounds the pre-run health check of a plugin.
+const validateTimeout = 10 * time.Second
+
+// Validate asks the plugin whether it is ready to serve cost queries, so that a plugin
+// without credentials fails once up front instead of once per resource. Plugins that do not
+// implement the health check RPC are assumed ready and Validate returns nil.
+func (c *Client) Validate(ctx context.Context) error {
+	checker, ok := c.API.(proto.HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, validateTimeout)
+	defer cancel()
+
+	resp, err := checker.HealthCheck(checkCtx)
+	if err != nil {
+		if IsUnimplementedError(err) {
+			logging.FromContext(ctx).Debug().
+				Str("plugin", c.Name).
+				Msg("Plugin does not support HealthCheck, skipping validation")
+			return nil
+		}
+		return fmt.Errorf("%w: %w", ErrPluginNotReady, err)
+	}
+
+	if resp.GetStatus() == pbc.HealthCheckResponse_STATUS_NOT_SERVING {
+		if msg := resp.GetMessage(); msg != "" {
+			return fmt.Errorf("%w: %s", ErrPluginNotReady, msg)
+		}
+		return ErrPluginNotReady
+	}
+	return nil
+}
+
 func handleGetPluginInfoError(ctx context.Context, pluginName string, err error) {
 	log := logging.FromContext(ctx)
 	if IsUnimplementedError(err) {
@@ -0,0 +1,294 @@
+package pluginhost
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+// Environment variables tuning long-lived plugin connections.
+const (
+	// EnvKeepaliveTime is the interval between keepalive pings on an inactive connection.
+	EnvKeepaliveTime = "FINFOCUS_GRPC_KEEPALIVE_TIME"
+	// EnvKeepaliveTimeout is how long to wait for a ping ack before the connection is closed.
+	EnvKeepaliveTimeout = "FINFOCUS_GRPC_KEEPALIVE_TIMEOUT"
+	// EnvIdleTimeout closes a plugin connection unused for this long; 0 disables it.
+	EnvIdleTimeout = "FINFOCUS_GRPC_IDLE_TIMEOUT"
+)
+
+// Defaults are conservative: five minutes matches the grpc-go server's minimum permitted
+// ping interval, so plugins built on the SDK never reject pings as abusive, and short CLI
+// runs finish long before the first ping is sent. The idle timeout is off by default.
+const (
+	defaultKeepaliveTime    = 5 * time.Minute
+	defaultKeepaliveTimeout = 20 * time.Second
+	defaultIdleTimeout      = time.Duration(0)
+)
+
+// ErrClientClosed is returned by calls made through a Client after Close.
+var ErrClientClosed = errors.New("plugin client closed")
+
+// ConnectionOptions configures keepalive probing and idle shutdown of plugin connections.
+type ConnectionOptions struct {
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// IdleTimeout closes the connection and plugin process after this much inactivity.
+	// The next call relaunches the plugin transparently. Zero keeps connections open.
+	IdleTimeout time.Duration
+}
+
+// ConnectionOptionsFromEnv returns the default options with any FINFOCUS_GRPC_* overrides
+// applied. Invalid or negative durations are ignored.
+func ConnectionOptionsFromEnv() ConnectionOptions {
+	return ConnectionOptions{
+		KeepaliveTime:    durationFromEnv(EnvKeepaliveTime, defaultKeepaliveTime),
+		KeepaliveTimeout: durationFromEnv(EnvKeepaliveTimeout, defaultKeepaliveTimeout),
+		IdleTimeout:      durationFromEnv(EnvIdleTimeout, defaultIdleTimeout),
+	}
+}
+
+func durationFromEnv(name string, def time.Duration) time.Duration {
+	if val := os.Getenv(name); val != "" {
+		if d, err := time.ParseDuration(val); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return def
+}
+
+// dialOptions returns the gRPC options shared by all launchers: insecure local transport,
+// trace propagation and keepalive parameters. PermitWithoutStream stays false so idle
+// connections are only probed while a call is in flight.
+func dialOptions() []grpc.DialOption {
+	opts := ConnectionOptionsFromEnv()
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(TraceInterceptor()),
+	}
+	if opts.KeepaliveTime > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    opts.KeepaliveTime,
+			Timeout: opts.KeepaliveTimeout,
+		}))
+	}
+	return dialOpts
+}
+
+// idleClient implements proto.CostSourceClient over a connection that is closed after
+// IdleTimeout without calls and re-established on the next call.
+type idleClient struct {
+	ctx      context.Context //nolint:containedctx // Plugin processes live as long as the context given to NewClient.
+	launcher Launcher
+	binPath  string
+	timeout  time.Duration
+
+	mu       sync.Mutex
+	api      proto.CostSourceClient
+	closeFn  func() error
+	active   int
+	lastUsed time.Time
+	timer    *time.Timer
+	closed   bool
+}
+
+func newIdleClient(
+	ctx context.Context,
+	launcher Launcher,
+	binPath string,
+	timeout time.Duration,
+	conn *grpc.ClientConn,
+	closeFn func() error,
+) *idleClient {
+	c := &idleClient{
+		ctx:      ctx,
+		launcher: launcher,
+		binPath:  binPath,
+		timeout:  timeout,
+		api:      proto.NewCostSourceClient(conn),
+		closeFn:  closeFn,
+		lastUsed: time.Now(),
+	}
+	c.timer = time.AfterFunc(timeout, c.expire)
+	return c
+}
+
+// acquire returns a live API, relaunching the plugin if it was closed for inactivity.
+func (c *idleClient) acquire() (proto.CostSourceClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+	if c.api == nil {
+		logging.FromContext(c.ctx).Debug().
+			Ctx(c.ctx).
+			Str("component", "pluginhost").
+			Str("operation", "reconnect_plugin").
+			Str("plugin_path", c.binPath).
+			Msg("relaunching idle plugin")
+		conn, closeFn, err := c.launcher.Start(c.ctx, c.binPath)
+		if err != nil {
+			return nil, err
+		}
+		c.api = proto.NewCostSourceClient(conn)
+		c.closeFn = closeFn
+	}
+	c.active++
+	c.timer.Stop()
+	return c.api, nil
+}
+
+// release marks a call finished and re-arms the idle timer once no calls are in flight.
+func (c *idleClient) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	c.lastUsed = time.Now()
+	if c.active == 0 && !c.closed {
+		c.timer.Reset(c.timeout)
+	}
+}
+
+// expire closes the connection if it has been idle for the full timeout. A timer that
+// fired while a new call was being acquired finds the connection busy or recently used.
+func (c *idleClient) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || c.api == nil || c.active > 0 || time.Since(c.lastUsed) < c.timeout {
+		return
+	}
+	logging.FromContext(c.ctx).Debug().
+		Ctx(c.ctx).
+		Str("component", "pluginhost").
+		Str("operation", "idle_close_plugin").
+		Str("plugin_path", c.binPath).
+		Dur("idle_timeout", c.timeout).
+		Msg("closing idle plugin connection")
+	if err := c.closeFn(); err != nil {
+		logging.FromContext(c.ctx).Warn().
+			Ctx(c.ctx).
+			Str("component", "pluginhost").
+			Err(err).
+			Msg("error closing idle plugin connection")
+	}
+	c.api = nil
+	c.closeFn = nil
+}
+
+// Close stops the idle timer and closes the current connection, if any.
+func (c *idleClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	c.timer.Stop()
+	if c.closeFn == nil {
+		return nil
+	}
+	closeFn := c.closeFn
+	c.api = nil
+	c.closeFn = nil
+	return closeFn()
+}
+
+// call runs fn against a live connection while holding it open.
+func call[T any](c *idleClient, fn func(api proto.CostSourceClient) (T, error)) (T, error) {
+	api, err := c.acquire()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer c.release()
+	return fn(api)
+}
+
+func (c *idleClient) Name(
+	ctx context.Context,
+	in *proto.Empty,
+	opts ...grpc.CallOption,
+) (*proto.NameResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*proto.NameResponse, error) {
+		return api.Name(ctx, in, opts...)
+	})
+}
+
+func (c *idleClient) GetProjectedCost(
+	ctx context.Context,
+	in *proto.GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*proto.GetProjectedCostResponse, error) {
+		return api.GetProjectedCost(ctx, in, opts...)
+	})
+}
+
+func (c *idleClient) GetActualCost(
+	ctx context.Context,
+	in *proto.GetActualCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*proto.GetActualCostResponse, error) {
+		return api.GetActualCost(ctx, in, opts...)
+	})
+}
+
+func (c *idleClient) GetRecommendations(
+	ctx context.Context,
+	in *proto.GetRecommendationsRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetRecommendationsResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*proto.GetRecommendationsResponse, error) {
+		return api.GetRecommendations(ctx, in, opts...)
+	})
+}
+
+func (c *idleClient) GetPluginInfo(
+	ctx context.Context,
+	in *proto.Empty,
+	opts ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*pbc.GetPluginInfoResponse, error) {
+		return api.GetPluginInfo(ctx, in, opts...)
+	})
+}
+
+func (c *idleClient) DryRun(
+	ctx context.Context,
+	in *pbc.DryRunRequest,
+	opts ...grpc.CallOption,
+) (*pbc.DryRunResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*pbc.DryRunResponse, error) {
+		return api.DryRun(ctx, in, opts...)
+	})
+}
+
+// HealthCheck forwards to the underlying client, reporting codes.Unimplemented when it
+// cannot check health.
+func (c *idleClient) HealthCheck(
+	ctx context.Context,
+	opts ...grpc.CallOption,
+) (*pbc.HealthCheckResponse, error) {
+	return call(c, func(api proto.CostSourceClient) (*pbc.HealthCheckResponse, error) {
+		checker, ok := api.(proto.HealthChecker)
+		if !ok {
+			return nil, status.Error(codes.Unimplemented, "health check not supported")
+		}
+		return checker.HealthCheck(ctx, opts...)
+	})
+}
+
+// Compile-time checks that idleClient satisfies the cost source API.
+var (
+	_ proto.CostSourceClient = (*idleClient)(nil)
+	_ proto.HealthChecker    = (*idleClient)(nil)
+)
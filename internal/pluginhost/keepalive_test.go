@@ -0,0 +1,103 @@
+package pluginhost_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// countingLauncher records how many times the plugin was started and closed.
+type countingLauncher struct {
+	inner  *grpcMockLauncher
+	starts atomic.Int32
+	closes atomic.Int32
+}
+
+func (l *countingLauncher) Start(
+	ctx context.Context,
+	path string,
+	args ...string,
+) (*grpc.ClientConn, func() error, error) {
+	l.starts.Add(1)
+	conn, closeFn, err := l.inner.Start(ctx, path, args...)
+	return conn, func() error {
+		l.closes.Add(1)
+		return closeFn()
+	}, err
+}
+
+func TestConnectionOptionsFromEnv(t *testing.T) {
+	t.Setenv(pluginhost.EnvKeepaliveTime, "")
+	t.Setenv(pluginhost.EnvKeepaliveTimeout, "")
+	t.Setenv(pluginhost.EnvIdleTimeout, "")
+
+	opts := pluginhost.ConnectionOptionsFromEnv()
+	assert.Equal(t, 5*time.Minute, opts.KeepaliveTime)
+	assert.Equal(t, 20*time.Second, opts.KeepaliveTimeout)
+	assert.Zero(t, opts.IdleTimeout)
+
+	t.Setenv(pluginhost.EnvKeepaliveTime, "30s")
+	t.Setenv(pluginhost.EnvKeepaliveTimeout, "invalid")
+	t.Setenv(pluginhost.EnvIdleTimeout, "10m")
+
+	opts = pluginhost.ConnectionOptionsFromEnv()
+	assert.Equal(t, 30*time.Second, opts.KeepaliveTime)
+	assert.Equal(t, 20*time.Second, opts.KeepaliveTimeout)
+	assert.Equal(t, 10*time.Minute, opts.IdleTimeout)
+}
+
+func TestNewClient_IdleTimeoutRelaunchesPlugin(t *testing.T) {
+	t.Setenv(pluginhost.EnvIdleTimeout, "50ms")
+
+	srv := &mockCostSourceServer{
+		name:       "idle-plugin",
+		pluginInfo: &pbc.GetPluginInfoResponse{Version: "1.0.0", SpecVersion: "0.4.14"},
+	}
+	mock, cleanup := setupMockServer(t, srv)
+	defer cleanup()
+	launcher := &countingLauncher{inner: mock}
+
+	ctx := context.Background()
+	client, err := pluginhost.NewClient(ctx, launcher, "dummy")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), launcher.starts.Load())
+
+	require.Eventually(t, func() bool { return launcher.closes.Load() == 1 },
+		time.Second, 10*time.Millisecond, "idle connection should be closed")
+
+	resp, err := client.API.Name(ctx, &proto.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "idle-plugin", resp.GetName())
+	assert.Equal(t, int32(2), launcher.starts.Load())
+
+	require.NoError(t, client.Close())
+	assert.Equal(t, int32(2), launcher.closes.Load())
+
+	_, err = client.API.Name(ctx, &proto.Empty{})
+	require.ErrorIs(t, err, pluginhost.ErrClientClosed)
+}
+
+func TestNewClient_NoIdleTimeoutKeepsConnection(t *testing.T) {
+	t.Setenv(pluginhost.EnvIdleTimeout, "")
+
+	srv := &mockCostSourceServer{name: "steady-plugin"}
+	mock, cleanup := setupMockServer(t, srv)
+	defer cleanup()
+	launcher := &countingLauncher{inner: mock}
+
+	client, err := pluginhost.NewClient(context.Background(), launcher, "dummy")
+	require.NoError(t, err)
+	defer client.Close()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), launcher.starts.Load())
+	assert.Zero(t, launcher.closes.Load())
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/rshade/finfocus/internal/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
@@ -62,7 +61,9 @@ type ProcessLauncher struct {
 	timeout       time.Duration
 	portListeners map[int]*portListener
 	mu            sync.Mutex
-	maxRetries    int // Maximum number of launch retries
+	maxRetries    int                  // Maximum number of launch retries
+	sandbox       *Sandbox             // Restrictions applied to plugin processes; nil runs them unrestricted
+	sandboxed     map[*exec.Cmd]string // Private directories of sandboxed plugin processes
 }
 
 // NewProcessLauncher creates a new ProcessLauncher configured with the package default timeout and an initialized map for tracking reserved port listeners.
@@ -76,6 +77,7 @@ func NewProcessLauncher() *ProcessLauncher {
 		timeout:       defaultTimeout,
 		portListeners: make(map[int]*portListener),
 		maxRetries:    maxRetries,
+		sandboxed:     make(map[*exec.Cmd]string),
 	}
 }
 
@@ -85,6 +87,7 @@ func NewProcessLauncherWithRetries(maxRetries int) *ProcessLauncher {
 		timeout:       defaultTimeout,
 		portListeners: make(map[int]*portListener),
 		maxRetries:    maxRetries,
+		sandboxed:     make(map[*exec.Cmd]string),
 	}
 }
 
@@ -381,35 +384,48 @@ func (p *ProcessLauncher) startPlugin(
 			Msg("PORT environment variable detected in parent environment (will be ignored, plugin uses --port flag)")
 	}
 
-	//nolint:gosec // Plugin path is validated before execution
-	cmd := exec.CommandContext(
-		ctx,
-		path,
-		append(args, fmt.Sprintf("--port=%d", port))...)
-	// Set FINFOCUS_PLUGIN_PORT environment variable for plugin port communication.
-	// The --port flag is authoritative; FINFOCUS_PLUGIN_PORT is for debugging/tooling.
-	// Note: PORT is intentionally NOT set (issue #232) - plugins should use --port flag
-	// or pluginsdk.GetPort() which reads FINFOCUS_PLUGIN_PORT.
-	cmd.Env = append(os.Environ(),
-		fmt.Sprintf("%s=%d", pluginsdk.EnvPort, port),
-	)
-	cmd.Stdout = os.Stderr
-
 	// In analyzer mode, suppress plugin stderr to prevent verbose logs from cluttering Pulumi preview output
 	// This addresses issue #401 where plugin JSON messages appear in user-facing output
-	if os.Getenv(constants.EnvAnalyzerMode) == "true" {
+	analyzerMode := os.Getenv(constants.EnvAnalyzerMode) == "true"
+	if analyzerMode {
 		log.Debug().
 			Ctx(ctx).
 			Str("component", "pluginhost").
 			Str("plugin_path", path).
 			Msg("suppressing plugin stderr output in analyzer mode")
-		cmd.Stderr = io.Discard
-	} else {
-		cmd.Stderr = os.Stderr
 	}
-	// Set WaitDelay before Start to avoid race condition with watchCtx goroutine
-	cmd.WaitDelay = processWaitDelay
 
+	newCmd := func() *exec.Cmd {
+		//nolint:gosec // Plugin path is validated before execution
+		cmd := exec.CommandContext(
+			ctx,
+			path,
+			append(args, fmt.Sprintf("--port=%d", port))...)
+		// Set FINFOCUS_PLUGIN_PORT environment variable for plugin port communication.
+		// The --port flag is authoritative; FINFOCUS_PLUGIN_PORT is for debugging/tooling.
+		// Note: PORT is intentionally NOT set (issue #232) - plugins should use --port flag
+		// or pluginsdk.GetPort() which reads FINFOCUS_PLUGIN_PORT.
+		cmd.Env = []string{fmt.Sprintf("%s=%d", pluginsdk.EnvPort, port)}
+		cmd.Stdout = os.Stderr
+		if analyzerMode {
+			cmd.Stderr = io.Discard
+		} else {
+			cmd.Stderr = os.Stderr
+		}
+		// Set WaitDelay before Start to avoid race condition with watchCtx goroutine
+		cmd.WaitDelay = processWaitDelay
+		return cmd
+	}
+
+	if p.sandbox != nil {
+		cmd, err := p.startSandboxed(ctx, newCmd)
+		if err != nil {
+			return nil, fmt.Errorf("starting plugin: %w", err)
+		}
+		return cmd, nil
+	}
+	cmd := newCmd()
+	cmd.Env = append(os.Environ(), cmd.Env...)
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("starting plugin: %w", err)
 	}
@@ -446,9 +462,7 @@ func (p *ProcessLauncher) connectToPlugin(
 }
 
 func (p *ProcessLauncher) tryConnect(address string) (*grpc.ClientConn, error) {
-	return grpc.NewClient(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(TraceInterceptor()))
+	return grpc.NewClient(address, dialOptions()...)
 }
 
 func (p *ProcessLauncher) isConnectionReady(ctx context.Context, conn *grpc.ClientConn) bool {
@@ -469,6 +483,7 @@ func (p *ProcessLauncher) killProcess(cmd *exec.Cmd) {
 	if cmd != nil && cmd.Process != nil {
 		_ = cmd.Process.Kill()
 		_ = cmd.Wait()
+		p.releaseSandbox(cmd)
 	}
 }
 
@@ -497,6 +512,7 @@ func (p *ProcessLauncher) createCloseFn(
 			pid := cmd.Process.Pid
 			_ = cmd.Process.Kill()
 			_ = cmd.Wait()
+			p.releaseSandbox(cmd)
 			log.Debug().
 				Ctx(ctx).
 				Str("component", "pluginhost").
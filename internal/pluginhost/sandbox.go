@@ -0,0 +1,162 @@
+package pluginhost
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// sandboxEnvPrefix marks the variables that configure plugins themselves, such as
+// FINFOCUS_LOG_LEVEL and plugin credentials; sandboxed plugins keep them.
+const sandboxEnvPrefix = "FINFOCUS_"
+
+// sandboxBaseEnv are the variables a sandboxed plugin keeps from the parent environment
+// besides the FINFOCUS_ ones: what a binary needs to run, not the caller's credentials.
+var sandboxBaseEnv = []string{"PATH", "LANG", "LC_ALL", "TZ", "SYSTEMROOT"}
+
+// Sandbox restricts the plugin processes a ProcessLauncher starts, for running plugins
+// that are not fully trusted:
+//
+//   - The environment is reduced to PATH, locale and time zone, the FINFOCUS_ variables,
+//     and the variables named in AllowEnv, so cloud credentials and tokens of the caller
+//     do not leak into the plugin.
+//   - Each plugin runs in a private temporary directory, which is its working directory,
+//     HOME and TMPDIR, and is removed when the plugin is closed.
+//   - On Linux the plugin runs in new user, mount, PID, IPC and UTS namespaces, so it
+//     cannot see or signal other processes, and is killed if finfocus dies.
+//
+// The network stays shared because plugins are reached over loopback TCP, and no
+// seccomp profile is installed. Where namespaces are unavailable, such as on other
+// platforms or kernels that disable unprivileged user namespaces, plugins run with the
+// reduced environment and temporary directory only, and a warning is logged.
+type Sandbox struct {
+	// AllowEnv names further variables passed to plugins; a trailing * matches a
+	// prefix, as in "AWS_*".
+	AllowEnv []string
+}
+
+// sandboxFallbackOnce limits the namespace fallback warning to one per run.
+var sandboxFallbackOnce sync.Once
+
+// WithSandbox makes the launcher start plugins restricted by sandbox; nil disables it.
+func (p *ProcessLauncher) WithSandbox(sandbox *Sandbox) *ProcessLauncher {
+	p.sandbox = sandbox
+	return p
+}
+
+// env returns the environment of a sandboxed plugin whose private directory is dir.
+func (s *Sandbox) env(dir string) []string {
+	env := []string{"HOME=" + dir, "TMPDIR=" + dir, "TEMP=" + dir, "TMP=" + dir}
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if s.allows(name) {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
+
+// allows reports whether the variable name is passed to sandboxed plugins.
+func (s *Sandbox) allows(name string) bool {
+	switch name {
+	case "HOME", "TMPDIR", "TEMP", "TMP":
+		return false
+	}
+	if strings.HasPrefix(name, sandboxEnvPrefix) {
+		return true
+	}
+	for _, allowed := range sandboxBaseEnv {
+		if envNameMatches(name, allowed) {
+			return true
+		}
+	}
+	for _, allowed := range s.AllowEnv {
+		if envNameMatches(name, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// envNameMatches reports whether name matches pattern, a variable name optionally ending
+// in * to match a prefix. Names are case-insensitive on Windows.
+func envNameMatches(name, pattern string) bool {
+	if runtime.GOOS == "windows" {
+		name, pattern = strings.ToUpper(name), strings.ToUpper(pattern)
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return name == pattern
+}
+
+// startSandboxed starts the command built by newCmd inside the sandbox, falling back to
+// the reduced environment alone when the kernel refuses to create namespaces.
+func (p *ProcessLauncher) startSandboxed(ctx context.Context, newCmd func() *exec.Cmd) (*exec.Cmd, error) {
+	dir, err := os.MkdirTemp("", "finfocus-plugin-")
+	if err != nil {
+		return nil, fmt.Errorf("creating plugin sandbox directory: %w", err)
+	}
+
+	prepare := func() *exec.Cmd {
+		cmd := newCmd()
+		cmd.Dir = dir
+		cmd.Env = append(p.sandbox.env(dir), cmd.Env...)
+		return cmd
+	}
+	cmd := prepare()
+	cmd.SysProcAttr = sandboxSysProcAttr()
+	err = cmd.Start()
+	if err != nil && cmd.SysProcAttr != nil && isNamespaceUnavailable(err) {
+		sandboxFallbackOnce.Do(func() {
+			logging.FromContext(ctx).Warn().
+				Ctx(ctx).
+				Str("component", "pluginhost").
+				Err(err).
+				Msg("plugin sandbox namespaces are unavailable; plugins run with a reduced environment only")
+		})
+		cmd = prepare()
+		err = cmd.Start()
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	if cmd.SysProcAttr == nil {
+		warnSandboxUnsupported(ctx)
+	}
+
+	p.mu.Lock()
+	p.sandboxed[cmd] = dir
+	p.mu.Unlock()
+	return cmd, nil
+}
+
+// releaseSandbox removes the private directory of a sandboxed plugin that has exited.
+func (p *ProcessLauncher) releaseSandbox(cmd *exec.Cmd) {
+	p.mu.Lock()
+	dir, ok := p.sandboxed[cmd]
+	delete(p.sandboxed, cmd)
+	p.mu.Unlock()
+	if ok {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// warnSandboxUnsupported logs, once, that the platform offers no process isolation.
+func warnSandboxUnsupported(ctx context.Context) {
+	sandboxFallbackOnce.Do(func() {
+		logging.FromContext(ctx).Warn().
+			Ctx(ctx).
+			Str("component", "pluginhost").
+			Str("os", runtime.GOOS).
+			Msg("plugin sandbox namespaces are not supported on this platform; " +
+				"plugins run with a reduced environment only")
+	})
+}
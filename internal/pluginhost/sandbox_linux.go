@@ -0,0 +1,27 @@
+package pluginhost
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// sandboxSysProcAttr starts plugins in new user, mount, PID, IPC and UTS namespaces.
+// The user namespace maps the caller's IDs onto themselves, so the plugin can still read
+// its own binary, and lets the other namespaces be created without privileges.
+func sandboxSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+		Pdeathsig:   syscall.SIGKILL,
+	}
+}
+
+// isNamespaceUnavailable reports whether starting a process failed because namespaces
+// could not be created, as when unprivileged user namespaces are disabled.
+func isNamespaceUnavailable(err error) bool {
+	return errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL) ||
+		errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EUSERS) || errors.Is(err, syscall.EACCES)
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package pluginhost
+
+import "syscall"
+
+// sandboxSysProcAttr returns nil: process isolation is only implemented on Linux.
+func sandboxSysProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+// isNamespaceUnavailable is never consulted without namespaces.
+func isNamespaceUnavailable(error) bool {
+	return false
+}
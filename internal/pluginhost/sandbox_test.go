@@ -0,0 +1,74 @@
+package pluginhost
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandbox_Allows(t *testing.T) {
+	sandbox := &Sandbox{AllowEnv: []string{"AWS_REGION", "GOOGLE_*"}}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "PATH", want: true},
+		{name: "FINFOCUS_LOG_LEVEL", want: true},
+		{name: "AWS_REGION", want: true},
+		{name: "GOOGLE_APPLICATION_CREDENTIALS", want: true},
+		{name: "AWS_SECRET_ACCESS_KEY", want: false},
+		{name: "GITHUB_TOKEN", want: false},
+		{name: "HOME", want: false},
+		{name: "TMPDIR", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sandbox.allows(tt.name))
+		})
+	}
+}
+
+func TestProcessLauncher_StartSandboxed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sandbox test uses a shell script")
+	}
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("FINFOCUS_SANDBOX_TEST", "kept")
+	t.Setenv("CUSTOM_SETTING", "allowed")
+
+	script := createScript(t, "#!/bin/sh\necho \"dir=$(pwd)\"\nenv\n", ".sh")
+	launcher := NewProcessLauncher().WithSandbox(&Sandbox{AllowEnv: []string{"CUSTOM_*"}})
+
+	var stdout bytes.Buffer
+	cmd, err := launcher.startSandboxed(context.Background(), func() *exec.Cmd {
+		c := exec.Command(script)
+		c.Env = []string{"FINFOCUS_PLUGIN_PORT=1234"}
+		c.Stdout = &stdout
+		return c
+	})
+	require.NoError(t, err)
+	require.NoError(t, cmd.Wait())
+
+	dir := cmd.Dir
+	out := stdout.String()
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Contains(t, out, "dir="+resolved)
+	assert.Contains(t, out, "HOME="+dir)
+	assert.Contains(t, out, "FINFOCUS_PLUGIN_PORT=1234")
+	assert.Contains(t, out, "FINFOCUS_SANDBOX_TEST=kept")
+	assert.Contains(t, out, "CUSTOM_SETTING=allowed")
+	assert.NotContains(t, out, "AWS_SECRET_ACCESS_KEY")
+
+	launcher.releaseSandbox(cmd)
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "sandbox directory should be removed")
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/rshade/finfocus/internal/logging"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
@@ -85,9 +84,7 @@ func (s *StdioLauncher) Start(
 
 	address := listener.Addr().String()
 
-	conn, err := grpc.NewClient(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(TraceInterceptor()))
+	conn, err := grpc.NewClient(address, dialOptions()...)
 	if err != nil {
 		log.Error().
 			Ctx(ctx).
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 	"github.com/rshade/finfocus/internal/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -22,6 +24,21 @@ const (
 	awsProvider = "aws"
 )
 
+// gRPC metadata keys for conditional projected-cost requests. The plugin protocol has no
+// ETag fields, so plugins that version their pricing data opt in through metadata: they
+// return MetadataETag in the response header, and when a request carries a matching
+// MetadataIfNoneMatch they may set MetadataNotModified to "true" instead of re-pricing.
+// Plugins that ignore these keys keep working unchanged. MetadataPricingDate optionally
+// reports when the plugin's pricing data was published, and MetadataCacheTTL how long the
+// result may be reused before revalidating, as a Go duration ("5m") or in seconds.
+const (
+	MetadataIfNoneMatch = "finfocus-if-none-match"
+	MetadataETag        = "finfocus-etag"
+	MetadataNotModified = "finfocus-not-modified"
+	MetadataPricingDate = "finfocus-pricing-date"
+	MetadataCacheTTL    = "finfocus-cache-ttl"
+)
+
 // ErrorDetail captures information about a failed resource cost calculation.
 type ErrorDetail struct {
 	ResourceType string
@@ -280,6 +297,9 @@ type ResourceDescriptor struct {
 	Type       string
 	Provider   string
 	Properties map[string]string
+	// IfNoneMatch is the ETag of a cached response for this resource. When set, the plugin
+	// may answer with a not-modified result instead of a price.
+	IfNoneMatch string
 }
 
 // GetProjectedCostRequest contains resources for which projected costs should be calculated.
@@ -296,6 +316,16 @@ type CostResult struct {
 	Notes          string
 	CostBreakdown  map[string]float64
 	Sustainability map[string]SustainabilityMetric
+	// ETag identifies the pricing data behind this result, if the plugin supplied one.
+	ETag string
+	// PricingDate is when the plugin's pricing data was published, if it said so.
+	PricingDate string
+	// CacheTTL is how long the plugin recommends reusing this result; zero when it did not
+	// say.
+	CacheTTL time.Duration
+	// NotModified is set when the plugin confirmed the request's IfNoneMatch ETag is
+	// still current. Cost fields are empty and the caller should reuse its cached result.
+	NotModified bool
 }
 
 // SustainabilityMetric represents a single sustainability impact measurement.
@@ -449,16 +479,24 @@ type CostSourceClient interface {
 	) (*pbc.DryRunResponse, error)
 }
 
+// HealthChecker is implemented by cost source clients that can reach the plugin's
+// ObservabilityService. Plugins that do not serve it answer with codes.Unimplemented.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context, opts ...grpc.CallOption) (*pbc.HealthCheckResponse, error)
+}
+
 // NewCostSourceClient creates a new cost source client using the real proto client.
 func NewCostSourceClient(conn *grpc.ClientConn) CostSourceClient {
 	return &clientAdapter{
 		client: pbc.NewCostSourceServiceClient(conn),
+		health: pbc.NewObservabilityServiceClient(conn),
 	}
 }
 
 // clientAdapter adapts the generated client to our internal interface.
 type clientAdapter struct {
 	client pbc.CostSourceServiceClient
+	health pbc.ObservabilityServiceClient
 }
 
 func (c *clientAdapter) Name(
@@ -489,6 +527,13 @@ func (c *clientAdapter) DryRun(
 	return c.client.DryRun(ctx, in, opts...)
 }
 
+func (c *clientAdapter) HealthCheck(
+	ctx context.Context,
+	opts ...grpc.CallOption,
+) (*pbc.HealthCheckResponse, error) {
+	return c.health.HealthCheck(ctx, &pbc.HealthCheckRequest{}, opts...)
+}
+
 // resolveSKUAndRegion extracts the SKU and region from resource properties based on the cloud provider.
 // It recognizes provider values such as "aws", "azure", "azure-native", "gcp", and "google-native" and
 // uses provider-specific extraction; for other providers it uses generic extraction helpers.
@@ -540,6 +585,7 @@ func (c *clientAdapter) GetProjectedCost(
 ) (*GetProjectedCostResponse, error) {
 	// Convert internal request to proto request
 	var results []*CostResult
+	var callErr error
 
 	for _, resource := range in.Resources {
 		// Extract SKU and region from properties using intelligent mapping
@@ -556,13 +602,38 @@ func (c *clientAdapter) GetProjectedCost(
 			},
 		}
 
-		resp, err := c.client.GetProjectedCost(ctx, req, opts...)
+		callCtx := ctx
+		if resource.IfNoneMatch != "" {
+			callCtx = metadata.AppendToOutgoingContext(ctx, MetadataIfNoneMatch, resource.IfNoneMatch)
+		}
+		var header, trailer metadata.MD
+		resp, err := c.client.GetProjectedCost(callCtx, req,
+			append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
 		if err != nil {
 			// Continue to next resource on error
+			if callErr == nil {
+				callErr = newPluginError(err, trailer)
+			}
+			continue
+		}
+
+		etag := firstMetadataValue(header, MetadataETag)
+		if resource.IfNoneMatch != "" && strings.EqualFold(firstMetadataValue(header, MetadataNotModified), "true") {
+			if etag == "" {
+				etag = resource.IfNoneMatch
+			}
+			results = append(results, &CostResult{
+				ETag:        etag,
+				NotModified: true,
+				CacheTTL:    parseCacheTTL(firstMetadataValue(header, MetadataCacheTTL)),
+			})
 			continue
 		}
 
 		result := &CostResult{
+			ETag:        etag,
+			PricingDate: firstMetadataValue(header, MetadataPricingDate),
+			CacheTTL:    parseCacheTTL(firstMetadataValue(header, MetadataCacheTTL)),
 			Currency:    resp.GetCurrency(),
 			MonthlyCost: resp.GetCostPerMonth(),
 			HourlyCost:  resp.GetUnitPrice(), // Assuming hourly for now
@@ -596,9 +667,38 @@ func (c *clientAdapter) GetProjectedCost(
 		results = append(results, result)
 	}
 
+	// Report the failure when no resource could be priced, so callers see why.
+	if len(results) == 0 && callErr != nil {
+		return nil, callErr
+	}
 	return &GetProjectedCostResponse{Results: results}, nil
 }
 
+// firstMetadataValue returns the first value of key in md, or "" when absent.
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// parseCacheTTL reads a MetadataCacheTTL value, either a Go duration or whole seconds. It
+// returns zero for values that are missing, malformed or not positive.
+func parseCacheTTL(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, atoiErr := strconv.Atoi(value)
+		if atoiErr != nil {
+			return 0
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	return max(ttl, 0)
+}
+
 func (c *clientAdapter) GetActualCost(
 	ctx context.Context,
 	in *GetActualCostRequest,
@@ -606,6 +706,7 @@ func (c *clientAdapter) GetActualCost(
 ) (*GetActualCostResponse, error) {
 	// Convert internal request to proto request
 	var results []*ActualCostResult
+	var callErr error
 
 	for _, resourceID := range in.ResourceIDs {
 		req := &pbc.GetActualCostRequest{
@@ -615,9 +716,13 @@ func (c *clientAdapter) GetActualCost(
 			Tags:       make(map[string]string), // Empty tags for now
 		}
 
-		resp, err := c.client.GetActualCost(ctx, req, opts...)
+		var trailer metadata.MD
+		resp, err := c.client.GetActualCost(ctx, req, append(opts, grpc.Trailer(&trailer))...)
 		if err != nil {
 			// Continue to next resource on error
+			if callErr == nil {
+				callErr = newPluginError(err, trailer)
+			}
 			continue
 		}
 
@@ -665,6 +770,10 @@ func (c *clientAdapter) GetActualCost(
 		results = append(results, result)
 	}
 
+	// Report the failure when no resource returned costs, so callers see why.
+	if len(results) == 0 && callErr != nil {
+		return nil, callErr
+	}
 	return &GetActualCostResponse{Results: results}, nil
 }
 
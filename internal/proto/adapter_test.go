@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // mockCostSourceClient is a mock implementation of CostSourceClient for testing.
@@ -647,6 +648,100 @@ func TestClientAdapter_GetProjectedCost(t *testing.T) {
 	})
 }
 
+// etagServiceClient answers projected-cost calls with an ETag header, replying
+// not-modified when the request's If-None-Match matches.
+type etagServiceClient struct {
+	pbc.CostSourceServiceClient
+
+	etag        string
+	cacheTTL    string
+	ifNoneMatch []string
+}
+
+func (c *etagServiceClient) GetProjectedCost(
+	ctx context.Context,
+	_ *pbc.GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*pbc.GetProjectedCostResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ifNoneMatch := strings.Join(md.Get(MetadataIfNoneMatch), ",")
+	c.ifNoneMatch = append(c.ifNoneMatch, ifNoneMatch)
+
+	header := metadata.Pairs(MetadataETag, c.etag)
+	if c.cacheTTL != "" {
+		header.Set(MetadataCacheTTL, c.cacheTTL)
+	}
+	if ifNoneMatch == c.etag {
+		header.Set(MetadataNotModified, "true")
+	}
+	for _, opt := range opts {
+		if h, ok := opt.(grpc.HeaderCallOption); ok {
+			*h.HeaderAddr = header
+		}
+	}
+	return &pbc.GetProjectedCostResponse{Currency: "USD", CostPerMonth: 7.3}, nil
+}
+
+func TestClientAdapter_GetProjectedCost_ETag(t *testing.T) {
+	service := &etagServiceClient{etag: `"v1"`}
+	adapter := &clientAdapter{client: service}
+	resource := &ResourceDescriptor{Type: "aws:ec2:Instance", Provider: "aws"}
+
+	resp, err := adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{resource},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, `"v1"`, resp.Results[0].ETag)
+	assert.False(t, resp.Results[0].NotModified)
+	assert.InDelta(t, 7.3, resp.Results[0].MonthlyCost, 0.001)
+
+	resource.IfNoneMatch = `"v1"`
+	resp, err = adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{resource},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.True(t, resp.Results[0].NotModified)
+	assert.Zero(t, resp.Results[0].MonthlyCost)
+	assert.Equal(t, []string{"", `"v1"`}, service.ifNoneMatch)
+}
+
+func TestClientAdapter_GetProjectedCost_CacheTTL(t *testing.T) {
+	service := &etagServiceClient{etag: `"v1"`, cacheTTL: "5m"}
+	adapter := &clientAdapter{client: service}
+	resource := &ResourceDescriptor{Type: "aws:ec2:Instance", Provider: "aws"}
+
+	resp, err := adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{resource},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, 5*time.Minute, resp.Results[0].CacheTTL)
+
+	resource.IfNoneMatch = `"v1"`
+	resp, err = adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{resource},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.True(t, resp.Results[0].NotModified)
+	assert.Equal(t, 5*time.Minute, resp.Results[0].CacheTTL)
+}
+
+func TestParseCacheTTL(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"":      0,
+		"90s":   90 * time.Second,
+		"24h":   24 * time.Hour,
+		"300":   5 * time.Minute,
+		"-5m":   0,
+		"later": 0,
+	} {
+		assert.Equal(t, want, parseCacheTTL(value), value)
+	}
+}
+
 // Test clientAdapter.GetActualCost method.
 func TestClientAdapter_GetActualCost(t *testing.T) {
 	t.Run("successful actual cost query", func(t *testing.T) {
@@ -0,0 +1,110 @@
+package proto
+
+import (
+	"errors"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataErrorCode is the gRPC trailer key a plugin sets on a failed call to classify the
+// failure with one of the ErrorCode values, so FinFocus can tell the user how to fix it.
+// Without it, a failure is classified from an ErrorDetail attached to the gRPC status, and
+// then from the gRPC status code itself.
+const MetadataErrorCode = "finfocus-error-code"
+
+// Structured plugin error codes.
+const (
+	ErrorCodeAuthFailed        = "auth-failed"
+	ErrorCodeRegionUnsupported = "region-unsupported"
+	ErrorCodeRateLimited       = "rate-limited"
+	ErrorCodeResourceNotFound  = "resource-not-found"
+)
+
+// PluginError is a failed plugin call with its structured error code, if any.
+type PluginError struct {
+	// Code is the error code the plugin reported, or one derived from the gRPC status.
+	// Empty when the failure could not be classified.
+	Code string
+	// Message is the plugin's description of the failure.
+	Message string
+
+	err error
+}
+
+// Error returns the plugin's message followed by the code.
+func (e *PluginError) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return e.Message + " (" + e.Code + ")"
+}
+
+// Unwrap returns the underlying gRPC error.
+func (e *PluginError) Unwrap() error {
+	return e.err
+}
+
+// PluginErrorCode returns the structured error code carried by err, or "" when there is none.
+func PluginErrorCode(err error) string {
+	var pluginErr *PluginError
+	if errors.As(err, &pluginErr) {
+		return pluginErr.Code
+	}
+	return ""
+}
+
+// newPluginError classifies a failed plugin call from the MetadataErrorCode trailer, an
+// ErrorDetail in the gRPC status, or the gRPC status code, in that order.
+func newPluginError(err error, trailer metadata.MD) *PluginError {
+	st := status.Convert(err)
+	pluginErr := &PluginError{Code: firstMetadataValue(trailer, MetadataErrorCode), Message: st.Message(), err: err}
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*pbc.ErrorDetail); ok {
+			if pluginErr.Code == "" {
+				pluginErr.Code = errorCodeFromDetail(d.GetCode())
+			}
+			if d.GetMessage() != "" {
+				pluginErr.Message = d.GetMessage()
+			}
+			break
+		}
+	}
+	if pluginErr.Code == "" {
+		pluginErr.Code = errorCodeFromStatus(st.Code())
+	}
+	return pluginErr
+}
+
+// errorCodeFromDetail maps the error codes of the plugin protocol to error codes.
+func errorCodeFromDetail(code pbc.ErrorCode) string {
+	switch code { //nolint:exhaustive // Other codes have no specific guidance.
+	case pbc.ErrorCode_ERROR_CODE_INVALID_CREDENTIALS, pbc.ErrorCode_ERROR_CODE_MISSING_API_KEY,
+		pbc.ErrorCode_ERROR_CODE_PERMISSION_DENIED:
+		return ErrorCodeAuthFailed
+	case pbc.ErrorCode_ERROR_CODE_UNSUPPORTED_REGION:
+		return ErrorCodeRegionUnsupported
+	case pbc.ErrorCode_ERROR_CODE_RATE_LIMITED:
+		return ErrorCodeRateLimited
+	case pbc.ErrorCode_ERROR_CODE_RESOURCE_NOT_FOUND:
+		return ErrorCodeResourceNotFound
+	default:
+		return ""
+	}
+}
+
+// errorCodeFromStatus maps gRPC status codes with an unambiguous meaning to error codes.
+func errorCodeFromStatus(code codes.Code) string {
+	switch code { //nolint:exhaustive // Other codes have no specific guidance.
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return ErrorCodeAuthFailed
+	case codes.ResourceExhausted:
+		return ErrorCodeRateLimited
+	case codes.NotFound:
+		return ErrorCodeResourceNotFound
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,116 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// failingServiceClient fails every cost call with err, setting the error code trailer
+// when code is not empty.
+type failingServiceClient struct {
+	pbc.CostSourceServiceClient
+
+	err  error
+	code string
+}
+
+func (c *failingServiceClient) fail(opts []grpc.CallOption) error {
+	for _, opt := range opts {
+		if tr, ok := opt.(grpc.TrailerCallOption); ok && c.code != "" {
+			*tr.TrailerAddr = metadata.Pairs(MetadataErrorCode, c.code)
+		}
+	}
+	return c.err
+}
+
+func (c *failingServiceClient) GetProjectedCost(
+	_ context.Context,
+	_ *pbc.GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*pbc.GetProjectedCostResponse, error) {
+	return nil, c.fail(opts)
+}
+
+func (c *failingServiceClient) GetActualCost(
+	_ context.Context,
+	_ *pbc.GetActualCostRequest,
+	opts ...grpc.CallOption,
+) (*pbc.GetActualCostResponse, error) {
+	return nil, c.fail(opts)
+}
+
+// statusWithDetail returns an error carrying the plugin protocol's ErrorDetail.
+func statusWithDetail(t *testing.T) error {
+	t.Helper()
+	st, err := status.New(codes.FailedPrecondition, "unsupported").WithDetails(&pbc.ErrorDetail{
+		Code:    pbc.ErrorCode_ERROR_CODE_UNSUPPORTED_REGION,
+		Message: "region ap-south-2 is not priced",
+	})
+	require.NoError(t, err)
+	return st.Err()
+}
+
+func TestClientAdapter_PluginErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   *failingServiceClient
+		wantCode string
+		wantMsg  string
+	}{
+		{
+			name: "code from trailer",
+			client: &failingServiceClient{
+				err:  status.Error(codes.Unavailable, "eu-north-9"),
+				code: ErrorCodeRegionUnsupported,
+			},
+			wantCode: ErrorCodeRegionUnsupported,
+			wantMsg:  "eu-north-9 (region-unsupported)",
+		},
+		{
+			name:     "code from status",
+			client:   &failingServiceClient{err: status.Error(codes.Unauthenticated, "token expired")},
+			wantCode: ErrorCodeAuthFailed,
+			wantMsg:  "token expired (auth-failed)",
+		},
+		{
+			name:     "code from status detail",
+			client:   &failingServiceClient{err: statusWithDetail(t)},
+			wantCode: ErrorCodeRegionUnsupported,
+			wantMsg:  "region ap-south-2 is not priced (region-unsupported)",
+		},
+		{
+			name:     "unclassified",
+			client:   &failingServiceClient{err: status.Error(codes.Internal, "boom")},
+			wantCode: "",
+			wantMsg:  "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &clientAdapter{client: tt.client}
+
+			_, err := adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+				Resources: []*ResourceDescriptor{{Type: "aws:ec2:Instance", Provider: "aws"}},
+			})
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, PluginErrorCode(err))
+			assert.Equal(t, tt.wantMsg, err.Error())
+			assert.ErrorIs(t, err, tt.client.err)
+
+			_, err = adapter.GetActualCost(context.Background(), &GetActualCostRequest{ResourceIDs: []string{"i-1"}})
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, PluginErrorCode(err))
+		})
+	}
+
+	assert.Empty(t, PluginErrorCode(errors.New("plain")))
+}
@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LockfileName is the default name of the plugin lockfile, committed next to the project
+// so every machine resolves the same plugin versions.
+const LockfileName = "finfocus.lock"
+
+// lockfileVersion is the format version written to new lockfiles.
+const lockfileVersion = 1
+
+// ErrLockedPluginMissing is returned when a plugin version pinned in the lockfile is not
+// installed.
+var ErrLockedPluginMissing = errors.New("locked plugin version not installed")
+
+// LockedPlugin pins a plugin to an exact version.
+type LockedPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Lockfile records the plugin versions a project resolves against.
+type Lockfile struct {
+	Version int            `json:"version"`
+	Plugins []LockedPlugin `json:"plugins"`
+}
+
+// NewLockfile pins the given plugins, sorted by name.
+func NewLockfile(plugins []PluginInfo) *Lockfile {
+	lock := &Lockfile{Version: lockfileVersion}
+	for _, p := range plugins {
+		lock.Set(p.Name, p.Version)
+	}
+	return lock
+}
+
+// LoadLockfile reads and validates a lockfile.
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading lockfile: %w", err)
+	}
+	var lock Lockfile
+	if unmarshalErr := json.Unmarshal(data, &lock); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing lockfile %s: %w", path, unmarshalErr)
+	}
+	seen := make(map[string]bool, len(lock.Plugins))
+	for i, p := range lock.Plugins {
+		if p.Name == "" || p.Version == "" {
+			return nil, fmt.Errorf("lockfile %s: plugin %d needs a name and a version", path, i+1)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("lockfile %s: plugin %s is pinned more than once", path, p.Name)
+		}
+		seen[p.Name] = true
+	}
+	return &lock, nil
+}
+
+// Write saves the lockfile as indented JSON.
+func (l *Lockfile) Write(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding lockfile: %w", err)
+	}
+	//nolint:gosec // G306: the lockfile is committed and shared, so it is world-readable
+	if writeErr := os.WriteFile(path, append(data, '\n'), 0o644); writeErr != nil {
+		return fmt.Errorf("writing lockfile: %w", writeErr)
+	}
+	return nil
+}
+
+// Set pins name to version, replacing any existing pin, and keeps the plugins sorted.
+func (l *Lockfile) Set(name, version string) {
+	for i := range l.Plugins {
+		if l.Plugins[i].Name == name {
+			l.Plugins[i].Version = version
+			return
+		}
+	}
+	l.Plugins = append(l.Plugins, LockedPlugin{Name: name, Version: version})
+	sort.Slice(l.Plugins, func(i, j int) bool { return l.Plugins[i].Name < l.Plugins[j].Name })
+}
+
+// Pinned returns the version name is pinned to.
+func (l *Lockfile) Pinned(name string) (string, bool) {
+	for _, p := range l.Plugins {
+		if p.Name == name {
+			return p.Version, true
+		}
+	}
+	return "", false
+}
+
+// Resolve picks the installed plugin matching each pin. Versions match when they are
+// semantically equal, so v1.2.0 and 1.2.0 are the same pin. Installed plugins without a
+// pin are not resolved. It returns ErrLockedPluginMissing naming every pin that is not
+// installed.
+func (l *Lockfile) Resolve(installed []PluginInfo) ([]PluginInfo, error) {
+	resolved := make([]PluginInfo, 0, len(l.Plugins))
+	var missing []string
+	for _, pin := range l.Plugins {
+		found := false
+		for _, p := range installed {
+			if p.Name == pin.Name && sameVersion(p.Version, pin.Version) {
+				resolved = append(resolved, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, pin.Name+"@"+pin.Version)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s (install with 'finfocus plugin install <name>@<version>')",
+			ErrLockedPluginMissing, strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+// sameVersion compares versions semantically, falling back to exact string comparison
+// when either is not valid semver.
+func sameVersion(a, b string) bool {
+	cmp, err := CompareVersions(a, b)
+	if err != nil {
+		return a == b
+	}
+	return cmp == 0
+}
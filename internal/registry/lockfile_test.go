@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockfile_WriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockfileName)
+	lock := NewLockfile([]PluginInfo{
+		{Name: "kubecost", Version: "v2.1.0"},
+		{Name: "aws-public", Version: "v0.1.0"},
+	})
+	require.NoError(t, lock.Write(path))
+
+	loaded, err := LoadLockfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, lockfileVersion, loaded.Version)
+	assert.Equal(t, []LockedPlugin{
+		{Name: "aws-public", Version: "v0.1.0"},
+		{Name: "kubecost", Version: "v2.1.0"},
+	}, loaded.Plugins)
+
+	loaded.Set("kubecost", "v2.2.0")
+	version, ok := loaded.Pinned("kubecost")
+	assert.True(t, ok)
+	assert.Equal(t, "v2.2.0", version)
+	_, ok = loaded.Pinned("vantage")
+	assert.False(t, ok)
+}
+
+func TestLoadLockfile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"invalid json", "{", "parsing lockfile"},
+		{"missing version", `{"plugins":[{"name":"kubecost"}]}`, "needs a name and a version"},
+		{"duplicate pin", `{"plugins":[{"name":"a","version":"v1.0.0"},{"name":"a","version":"v2.0.0"}]}`,
+			"pinned more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), LockfileName)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			_, err := LoadLockfile(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := LoadLockfile(filepath.Join(t.TempDir(), "missing.lock"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestResolvePlugins_Locked(t *testing.T) {
+	reg := &Registry{root: createMultiVersionPluginDir(t)}
+
+	t.Run("without lockfile resolves latest", func(t *testing.T) {
+		plugins, _, err := reg.resolvePlugins("")
+		require.NoError(t, err)
+		require.Len(t, plugins, 1)
+		assert.Equal(t, "v2.0.0", plugins[0].Version)
+	})
+
+	t.Run("lockfile pins older version", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), LockfileName)
+		require.NoError(t, NewLockfile([]PluginInfo{{Name: "testplugin", Version: "1.0.0"}}).Write(path))
+
+		plugins, _, err := reg.resolvePlugins(path)
+		require.NoError(t, err)
+		require.Len(t, plugins, 1)
+		assert.Equal(t, "v1.0.0", plugins[0].Version)
+	})
+
+	t.Run("missing pinned version", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), LockfileName)
+		lock := NewLockfile([]PluginInfo{
+			{Name: "testplugin", Version: "v3.0.0"},
+			{Name: "kubecost", Version: "v1.0.0"},
+		})
+		require.NoError(t, lock.Write(path))
+
+		_, _, err := reg.resolvePlugins(path)
+		require.ErrorIs(t, err, ErrLockedPluginMissing)
+		assert.Contains(t, err.Error(), "testplugin@v3.0.0")
+		assert.Contains(t, err.Error(), "kubecost@v1.0.0")
+	})
+
+	t.Run("unpinned plugins are skipped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), LockfileName)
+		require.NoError(t, NewLockfile(nil).Write(path))
+
+		plugins, _, err := reg.resolvePlugins(path)
+		require.NoError(t, err)
+		assert.Empty(t, plugins)
+	})
+}
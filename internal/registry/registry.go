@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/rshade/finfocus/internal/config"
@@ -26,10 +28,20 @@ func NewDefault() *Registry {
 	cfg := config.New()
 	return &Registry{
 		root:     cfg.PluginDir,
-		launcher: pluginhost.NewProcessLauncher(),
+		launcher: NewLauncher(cfg),
 	}
 }
 
+// NewLauncher returns the ProcessLauncher for plugins under cfg, sandboxed when
+// sandbox.enabled is set.
+func NewLauncher(cfg *config.Config) *pluginhost.ProcessLauncher {
+	launcher := pluginhost.NewProcessLauncher()
+	if cfg.Sandbox.Enabled {
+		launcher.WithSandbox(&pluginhost.Sandbox{AllowEnv: cfg.Sandbox.AllowEnv})
+	}
+	return launcher
+}
+
 // ListPlugins scans the plugin directory and returns metadata for all discovered plugins.
 // It returns an empty list if the plugin directory doesn't exist.
 func (r *Registry) ListPlugins() ([]PluginInfo, error) {
@@ -194,13 +206,47 @@ func (r *Registry) findBinary(dir string) string {
 	return ""
 }
 
+// OpenOptions controls how Open launches plugins. The zero value launches every plugin
+// sequentially, which is the historical eager behavior.
+type OpenOptions struct {
+	// MaxConcurrent caps how many plugins are launched at the same time. Zero or one
+	// launches plugins one after another.
+	MaxConcurrent int
+	// Providers, when set, skips plugins whose plugin.manifest.json declares none of these
+	// providers, so a stack without Azure resources never launches an Azure-only plugin.
+	// Plugins without a manifest or without declared providers are always launched.
+	Providers []string
+	// Offline skips plugin discovery and launch entirely; no clients are returned.
+	Offline bool
+	// LockfilePath, when set, resolves plugins strictly against the versions pinned in that
+	// lockfile instead of the latest installed ones. Plugins without a pin are not launched,
+	// and a pinned version that is not installed fails with ErrLockedPluginMissing.
+	LockfilePath string
+}
+
 // Open launches plugin processes and returns active gRPC clients with a cleanup function.
 // If onlyName is non-empty, only that specific plugin is opened.
 func (r *Registry) Open(
 	ctx context.Context,
 	onlyName string,
+) ([]*pluginhost.Client, func(), error) {
+	return r.OpenWithOptions(ctx, onlyName, OpenOptions{})
+}
+
+// OpenWithOptions behaves like Open but applies the given launch options.
+func (r *Registry) OpenWithOptions(
+	ctx context.Context,
+	onlyName string,
+	opts OpenOptions,
 ) ([]*pluginhost.Client, func(), error) {
 	log := logging.FromContext(ctx)
+	if opts.Offline {
+		log.Info().
+			Ctx(ctx).
+			Str("component", "registry").
+			Msg("offline mode: skipping plugin discovery and launch")
+		return nil, func() {}, nil
+	}
 	log.Debug().
 		Ctx(ctx).
 		Str("component", "registry").
@@ -209,7 +255,7 @@ func (r *Registry) Open(
 		Str("plugin_root", r.root).
 		Msg("opening plugins")
 
-	plugins, warnings, err := r.ListLatestPlugins()
+	plugins, warnings, err := r.resolvePlugins(opts.LockfilePath)
 	if err != nil {
 		log.Error().
 			Ctx(ctx).
@@ -229,9 +275,19 @@ func (r *Registry) Open(
 
 	var filteredPlugins []PluginInfo
 	for _, plugin := range plugins {
-		if onlyName == "" || plugin.Name == onlyName {
-			filteredPlugins = append(filteredPlugins, plugin)
+		if onlyName != "" && plugin.Name != onlyName {
+			continue
 		}
+		if !pluginSupportsAnyProvider(plugin, opts.Providers) {
+			log.Debug().
+				Ctx(ctx).
+				Str("component", "registry").
+				Str("plugin_name", plugin.Name).
+				Strs("providers", opts.Providers).
+				Msg("skipping plugin: manifest declares none of the stack's providers")
+			continue
+		}
+		filteredPlugins = append(filteredPlugins, plugin)
 	}
 
 	log.Debug().
@@ -240,51 +296,123 @@ func (r *Registry) Open(
 		Int("discovered_plugins", len(filteredPlugins)).
 		Msg("latest plugins discovered after filtering")
 
-	var clients []*pluginhost.Client
+	clients := r.launchPlugins(ctx, filteredPlugins, opts.MaxConcurrent)
 	cleanup := func() {
 		for _, c := range clients {
 			_ = c.Close()
 		}
 	}
 
-	for _, plugin := range filteredPlugins {
-		log.Debug().
-			Ctx(ctx).
-			Str("component", "registry").
-			Str("plugin_name", plugin.Name).
-			Str("plugin_version", plugin.Version).
-			Str("plugin_path", plugin.Path).
-			Msg("attempting to connect to plugin")
+	log.Info().
+		Ctx(ctx).
+		Str("component", "registry").
+		Int("connected_plugins", len(clients)).
+		Msg("plugin discovery complete")
 
-		client, clientErr := pluginhost.NewClient(ctx, r.launcher, plugin.Path)
-		if clientErr != nil {
-			log.Warn().
-				Ctx(ctx).
-				Str("component", "registry").
-				Str("plugin_name", plugin.Name).
-				Str("plugin_path", plugin.Path).
-				Err(clientErr).
-				Msg("failed to connect to plugin")
-			continue
+	return clients, cleanup, nil
+}
+
+// resolvePlugins returns the latest installed plugins, or the plugins pinned in the
+// lockfile at lockfilePath when it is set.
+func (r *Registry) resolvePlugins(lockfilePath string) ([]PluginInfo, []string, error) {
+	if lockfilePath == "" {
+		return r.ListLatestPlugins()
+	}
+	lock, err := LoadLockfile(lockfilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	installed, err := r.ListPlugins()
+	if err != nil {
+		return nil, nil, err
+	}
+	plugins, err := lock.Resolve(installed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plugins, nil, nil
+}
+
+// launchPlugins connects to each plugin, running up to maxConcurrent launches at once.
+// Plugins that fail to start are logged and skipped; the returned clients keep the order
+// of plugins.
+func (r *Registry) launchPlugins(ctx context.Context, plugins []PluginInfo, maxConcurrent int) []*pluginhost.Client {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	launched := make([]*pluginhost.Client, len(plugins))
+	sem := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+	for i, plugin := range plugins {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			launched[i] = r.launchPlugin(ctx, plugin)
+		}()
+	}
+	wg.Wait()
+
+	var clients []*pluginhost.Client
+	for _, c := range launched {
+		if c != nil {
+			clients = append(clients, c)
 		}
+	}
+	return clients
+}
 
-		log.Debug().
+func (r *Registry) launchPlugin(ctx context.Context, plugin PluginInfo) *pluginhost.Client {
+	log := logging.FromContext(ctx)
+	log.Debug().
+		Ctx(ctx).
+		Str("component", "registry").
+		Str("plugin_name", plugin.Name).
+		Str("plugin_version", plugin.Version).
+		Str("plugin_path", plugin.Path).
+		Msg("attempting to connect to plugin")
+
+	client, clientErr := pluginhost.NewClient(ctx, r.launcher, plugin.Path)
+	if clientErr != nil {
+		log.Warn().
 			Ctx(ctx).
 			Str("component", "registry").
 			Str("plugin_name", plugin.Name).
-			Str("plugin_version", plugin.Version).
-			Msg("plugin connected successfully")
-
-		clients = append(clients, client)
+			Str("plugin_path", plugin.Path).
+			Err(clientErr).
+			Msg("failed to connect to plugin")
+		return nil
 	}
 
-	log.Info().
+	log.Debug().
 		Ctx(ctx).
 		Str("component", "registry").
-		Int("connected_plugins", len(clients)).
-		Msg("plugin discovery complete")
+		Str("plugin_name", plugin.Name).
+		Str("plugin_version", plugin.Version).
+		Msg("plugin connected successfully")
+	return client
+}
 
-	return clients, cleanup, nil
+// pluginSupportsAnyProvider reports whether the plugin's manifest declares at least one of
+// providers. It returns true when providers is empty or the manifest is missing, unreadable
+// or declares no providers, since support cannot then be ruled out without launching.
+func pluginSupportsAnyProvider(plugin PluginInfo, providers []string) bool {
+	if len(providers) == 0 {
+		return true
+	}
+	manifest, err := LoadManifest(filepath.Join(filepath.Dir(plugin.Path), "plugin.manifest.json"))
+	if err != nil || len(manifest.Providers) == 0 {
+		return true
+	}
+	for _, declared := range manifest.Providers {
+		for _, provider := range providers {
+			if strings.EqualFold(declared, provider) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // PluginInfo contains metadata about a discovered plugin.
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -723,3 +724,89 @@ func verifyRegistryOpenResult(
 		cleanup() // Should not panic
 	}
 }
+
+func TestRegistry_OpenWithOptions_SkipsUnsupportedProviders(t *testing.T) {
+	dir := createMultiplePluginsDir(t)
+	manifest := `{"name":"kubecost","version":"v2.1.0","providers":["kubernetes"]}`
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "kubecost", "v2.1.0", "plugin.manifest.json"), []byte(manifest), 0o600))
+
+	mock := &mockLauncher{}
+	reg := &Registry{root: dir, launcher: mock}
+
+	_, cleanup, err := reg.OpenWithOptions(context.Background(), "", OpenOptions{Providers: []string{"aws"}})
+	require.NoError(t, err)
+	defer cleanup()
+
+	binName := func(name string) string {
+		if runtime.GOOS == "windows" {
+			return name + ".exe"
+		}
+		return name
+	}
+	assert.Equal(t, 1, mock.startCalled[binName("aws-plugin")], "plugin without manifest is launched")
+	assert.Zero(t, mock.startCalled[binName("kubecost")], "kubernetes-only plugin is skipped for an AWS stack")
+}
+
+func TestRegistry_OpenWithOptions_Offline(t *testing.T) {
+	mock := &mockLauncher{}
+	reg := &Registry{root: createMultiplePluginsDir(t), launcher: mock}
+
+	clients, cleanup, err := reg.OpenWithOptions(context.Background(), "", OpenOptions{Offline: true})
+	require.NoError(t, err)
+	defer cleanup()
+
+	assert.Empty(t, clients)
+	assert.Empty(t, mock.startCalled, "no plugin is launched in offline mode")
+}
+
+// concurrencyLauncher records the peak number of simultaneous launches.
+type concurrencyLauncher struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+	starts  int
+}
+
+func (l *concurrencyLauncher) Start(
+	_ context.Context,
+	_ string,
+	_ ...string,
+) (*grpc.ClientConn, func() error, error) {
+	l.mu.Lock()
+	l.running++
+	l.starts++
+	l.peak = max(l.peak, l.running)
+	l.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	l.mu.Lock()
+	l.running--
+	l.mu.Unlock()
+	return nil, nil, errors.New("mock launch failed")
+}
+
+func TestRegistry_OpenWithOptions_MaxConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		pluginDir := filepath.Join(dir, name, "v1.0.0")
+		require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+		binPath := filepath.Join(pluginDir, name)
+		if runtime.GOOS == "windows" {
+			binPath += ".exe"
+		}
+		require.NoError(t, os.WriteFile(binPath, []byte("#!/bin/bash\nexit 1"), 0o755))
+	}
+
+	launcher := &concurrencyLauncher{}
+	reg := &Registry{root: dir, launcher: launcher}
+
+	_, cleanup, err := reg.OpenWithOptions(context.Background(), "", OpenOptions{MaxConcurrent: 2})
+	require.NoError(t, err)
+	defer cleanup()
+
+	assert.Equal(t, 4, launcher.starts)
+	assert.LessOrEqual(t, launcher.peak, 2)
+	assert.Greater(t, launcher.peak, 1, "launches should overlap when a cap above one is set")
+}
@@ -0,0 +1,80 @@
+package spec
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// CachingLoader is a Loader that parses each spec once and serves it from memory until a
+// file it was read from, or a spec directory, changes on disk. It is safe for concurrent
+// use. Cached specs are shared between callers and must not be modified.
+type CachingLoader struct {
+	loader *Loader
+
+	mu      sync.RWMutex
+	entries map[string]cachedSpec
+}
+
+// cachedSpec is the result of a lookup with the versions of the files it was read from.
+// Lookups that found no spec are cached too, as the engine's fallback ladder repeats them
+// for every resource.
+type cachedSpec struct {
+	spec           *PricingSpec
+	err            error
+	version        string
+	sourcesVersion string
+}
+
+// NewCachingLoader returns a CachingLoader that reads specs through loader.
+func NewCachingLoader(loader *Loader) *CachingLoader {
+	return &CachingLoader{loader: loader, entries: make(map[string]cachedSpec)}
+}
+
+// LoadSpec loads a pricing specification by provider, service, and SKU.
+func (c *CachingLoader) LoadSpec(provider, service, sku string) (interface{}, error) {
+	return c.LoadSpecWithContext(context.Background(), provider, service, sku)
+}
+
+// LoadSpecWithContext loads a pricing specification by provider, service, and SKU,
+// reading it from disk only when it is not cached or its files changed since.
+func (c *CachingLoader) LoadSpecWithContext(
+	ctx context.Context,
+	provider, service, sku string,
+) (interface{}, error) {
+	key := specKey(provider, service, sku)
+	version := c.loader.SpecVersion(provider, service, sku)
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok || entry.version != version || entry.spec.SourcesVersion() != entry.sourcesVersion {
+		spec, err := c.loader.loadResolved(ctx, provider, service, sku, nil)
+		if err != nil && !errors.Is(err, ErrSpecNotFound) {
+			// Unreadable or invalid specs are reported every time rather than cached.
+			return nil, err
+		}
+		entry = cachedSpec{spec: spec, err: err, version: version, sourcesVersion: spec.SourcesVersion()}
+		c.mu.Lock()
+		c.entries[key] = entry
+		c.mu.Unlock()
+	}
+
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return entry.spec, nil
+}
+
+// SpecVersion identifies what a lookup of provider-service-sku would find on disk right
+// now, as Loader.SpecVersion does.
+func (c *CachingLoader) SpecVersion(provider, service, sku string) string {
+	return c.loader.SpecVersion(provider, service, sku)
+}
+
+// ClearCache drops every cached spec, so the next lookups read from disk.
+func (c *CachingLoader) ClearCache() {
+	c.mu.Lock()
+	c.entries = make(map[string]cachedSpec)
+	c.mu.Unlock()
+}
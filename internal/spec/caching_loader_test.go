@@ -0,0 +1,77 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingLoader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aws-ec2-t3.micro.yaml")
+	writeSpec := func(hourly string, modified time.Time) {
+		content := "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: " + hourly + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(path, modified, modified))
+		require.NoError(t, os.Chtimes(dir, modified, modified))
+	}
+	hourly := func(loaded interface{}) interface{} {
+		return loaded.(*PricingSpec).Pricing["onDemandHourly"]
+	}
+	start := time.Now().Add(-time.Hour)
+	writeSpec("0.01", start)
+	loader := NewCachingLoader(NewLoader(dir))
+
+	first, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	second, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	assert.Same(t, first.(*PricingSpec), second.(*PricingSpec), "unchanged specs are served from the cache")
+
+	writeSpec("0.02", start.Add(time.Minute))
+	reloaded, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.02, hourly(reloaded), 1e-9, "edited specs are read again")
+
+	loader.ClearCache()
+	cleared, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	assert.NotSame(t, reloaded.(*PricingSpec), cleared.(*PricingSpec))
+
+	_, err = loader.LoadSpec("aws", "ec2", "m5.large")
+	require.ErrorIs(t, err, ErrSpecNotFound)
+	added := filepath.Join(dir, "aws-ec2-m5.large.yaml")
+	require.NoError(t, os.WriteFile(added,
+		[]byte("provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\npricing:\n  onDemandHourly: 0.096\n"), 0o600))
+	require.NoError(t, os.Chtimes(dir, start.Add(2*time.Minute), start.Add(2*time.Minute)))
+	found, err := loader.LoadSpec("aws", "ec2", "m5.large")
+	require.NoError(t, err, "specs added after a failed lookup are found")
+	assert.InDelta(t, 0.096, hourly(found), 1e-9)
+}
+
+func TestCachingLoader_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-t3.micro.yaml"),
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"), 0o600))
+	loader := NewCachingLoader(NewLoader(dir))
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%4 == 0 {
+				loader.ClearCache()
+			}
+			loaded, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+			assert.NoError(t, err)
+			assert.Equal(t, "t3.micro", loaded.(*PricingSpec).SKU)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,36 @@
+package spec
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnknownCurrency is returned by ValidateSpec for a currency that is not an ISO 4217 code.
+var ErrUnknownCurrency = errors.New("currency is not an ISO 4217 code")
+
+// activeCurrencyCodes are the active ISO 4217 codes, including funds codes and precious
+// metals but not the XTS testing and XXX no-currency codes.
+const activeCurrencyCodes = `
+AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD
+BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUC CUP CVE CZK DJF DKK DOP
+DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR
+IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD
+MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB
+PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD
+SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS
+VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XUA YER
+ZAR ZMW ZWG ZWL`
+
+// currencyCodes is the set of activeCurrencyCodes.
+var currencyCodes = func() map[string]bool {
+	codes := make(map[string]bool)
+	for _, code := range strings.Fields(activeCurrencyCodes) {
+		codes[code] = true
+	}
+	return codes
+}()
+
+// IsCurrencyCode reports whether code is an active ISO 4217 currency code, in any case.
+func IsCurrencyCode(code string) bool {
+	return currencyCodes[strings.ToUpper(code)]
+}
@@ -9,13 +9,21 @@
 //
 // # Specification Format
 //
-// Example pricing spec (aws-ec2-t3-micro.yaml):
+// Example pricing spec (aws-ec2-t3.micro.yaml):
 //
-//	resource_type: aws:ec2:Instance
-//	instance_type: t3.micro
+//	version: 2
+//	provider: aws
+//	service: ec2
+//	sku: t3.micro
+//	currency: USD
 //	pricing:
-//	  hourly: 0.0104
-//	  currency: USD
+//	  onDemandHourly: 0.0104
+//
+// # Format Versions
+//
+// The version field tracks the spec format; specs without one are version 1.
+// MigrateSpecData and the "finfocus spec migrate" command upgrade older specs,
+// e.g. renaming the legacy pricing.hourly key to pricing.onDemandHourly.
 //
 // # Usage
 //
@@ -23,4 +31,10 @@
 //   - No plugin is available for a resource type
 //   - Plugin returns no pricing data
 //   - Plugin query fails
+//
+// # Caching
+//
+// Loader reads a spec from disk on every lookup. NewCachingLoader wraps it to parse each
+// spec once and read it again only when its file, a spec it extends, or a spec directory
+// changes, which is checked with a stat of each rather than by watching them.
 package spec
@@ -2,11 +2,14 @@ package spec
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/rshade/finfocus/internal/logging"
 	"gopkg.in/yaml.v3"
@@ -15,16 +18,29 @@ import (
 const (
 	// ExpectedPartsCount is the number of parts expected in a spec filename (provider-service-sku).
 	ExpectedPartsCount = 3
+
+	// Extensions of YAML and JSON spec files.
+	yamlExt = ".yaml"
+	jsonExt = ".json"
 )
 
 var (
 	// ErrSpecNotFound is returned when a requested spec file does not exist.
 	ErrSpecNotFound = errors.New("spec file not found")
+
+	// ErrSpecInheritance is returned when the extends chain of a spec cannot be resolved,
+	// because a parent is missing or the chain loops back on itself.
+	ErrSpecInheritance = errors.New("spec inheritance")
 )
 
 // Loader loads pricing specifications from a directory.
 type Loader struct {
-	specDir string
+	specDir      string
+	fallbackDirs []string
+
+	// shadowChecked records the YAML spec paths already checked for a JSON spec of the
+	// same name, so the warning about it is logged once rather than on every lookup.
+	shadowChecked sync.Map
 }
 
 // NewLoader creates a new spec loader for the given directory.
@@ -32,14 +48,32 @@ func NewLoader(specDir string) *Loader {
 	return &Loader{specDir: specDir}
 }
 
+// NewLoaderWithFallback creates a spec loader that searches specDir first and then each
+// fallback directory in order (e.g. the cached copy of a remote spec source).
+func NewLoaderWithFallback(specDir string, fallbackDirs ...string) *Loader {
+	return &Loader{specDir: specDir, fallbackDirs: fallbackDirs}
+}
+
 // PricingSpec represents a pricing specification for a cloud service SKU.
 type PricingSpec struct {
+	// Version is the spec format version; zero means a legacy (version 1) spec.
+	Version  int                    `yaml:"version,omitempty"`
 	Provider string                 `yaml:"provider"`
 	Service  string                 `yaml:"service"`
 	SKU      string                 `yaml:"sku"`
 	Currency string                 `yaml:"currency"`
 	Pricing  map[string]interface{} `yaml:"pricing"`
 	Metadata map[string]interface{} `yaml:"metadata,omitempty"`
+	// HoursPerMonth converts the spec's hourly rates to monthly costs and back, for
+	// chargeback models that use 720 (30×24) or 730.485 hours. Zero uses the engine's
+	// setting, 730 by default.
+	HoursPerMonth float64 `yaml:"hoursPerMonth,omitempty"`
+	// Extends names the spec this one inherits from, as provider-service-sku or, within
+	// the same provider and service, as a bare SKU such as "default". The currency and any
+	// pricing and metadata keys this spec does not set are taken from the parent.
+	Extends string `yaml:"extends,omitempty"`
+	// Sources are the files the spec was read and merged from, the spec's own file first.
+	Sources []string `yaml:"-" json:"-"`
 }
 
 // LoadSpec loads a pricing specification by provider, service, and SKU.
@@ -48,13 +82,173 @@ func (l *Loader) LoadSpec(provider, service, sku string) (interface{}, error) {
 }
 
 // LoadSpecWithContext loads a pricing specification by provider, service, and SKU with logging.
+// The returned spec has its extends chain resolved.
 func (l *Loader) LoadSpecWithContext(
 	ctx context.Context,
 	provider, service, sku string,
 ) (interface{}, error) {
+	spec, err := l.loadResolved(ctx, provider, service, sku, nil)
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// loadResolved returns the spec with its extends chain merged in. chain holds the specs
+// that extend this one, to detect cycles.
+func (l *Loader) loadResolved(
+	ctx context.Context,
+	provider, service, sku string,
+	chain []string,
+) (*PricingSpec, error) {
+	key := specKey(provider, service, sku)
+	for _, seen := range chain {
+		if seen == key {
+			return nil, fmt.Errorf("%w: cycle %s", ErrSpecInheritance, strings.Join(append(chain, key), " -> "))
+		}
+	}
+
+	spec, err := l.loadSpecFromDirs(ctx, provider, service, sku)
+	if err != nil {
+		return nil, err
+	}
+	return l.resolveExtends(ctx, spec, key, append(chain, key))
+}
+
+// resolveExtends merges the resolved parent of spec, stored as key, into it. Specs without
+// extends are returned as they are.
+func (l *Loader) resolveExtends(
+	ctx context.Context,
+	spec *PricingSpec,
+	key string,
+	chain []string,
+) (*PricingSpec, error) {
+	if spec.Extends == "" {
+		return spec, nil
+	}
+	provider, service, sku := parseExtends(spec.Extends, spec.Provider, spec.Service)
+	parent, err := l.loadResolved(ctx, provider, service, sku, chain)
+	if errors.Is(err, ErrSpecNotFound) {
+		return nil, fmt.Errorf("%w: %s extends %s, which was not found", ErrSpecInheritance, key, spec.Extends)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mergeSpecs(parent, spec), nil
+}
+
+// loadSpecFromDirs reads the spec from the spec directory or, failing that, the first
+// fallback directory that has it.
+func (l *Loader) loadSpecFromDirs(ctx context.Context, provider, service, sku string) (*PricingSpec, error) {
+	for _, dir := range l.dirs() {
+		spec, err := l.loadSpecFromDir(ctx, dir, provider, service, sku)
+		if errors.Is(err, ErrSpecNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return spec, nil
+	}
+	return nil, ErrSpecNotFound
+}
+
+// dirs returns the spec directory followed by the fallback directories.
+func (l *Loader) dirs() []string {
+	return append([]string{l.specDir}, l.fallbackDirs...)
+}
+
+// specKey is the provider-service-sku name a spec is stored under.
+func specKey(provider, service, sku string) string {
+	return fmt.Sprintf("%s-%s-%s", provider, service, sku)
+}
+
+// parseExtends resolves an extends reference. Full provider-service-sku names are split
+// like spec filenames; anything shorter is a SKU of the same provider and service.
+func parseExtends(extends, provider, service string) (string, string, string) {
+	if p, s, sku, ok := ParseSpecFilename(extends + ".yaml"); ok {
+		return p, s, sku
+	}
+	return provider, service, extends
+}
+
+// MergeSpecs layers override on top of base, such as a team's spec over an org-wide one.
+// Pricing and metadata are merged key by key, nested maps included, with the override's
+// values winning; the currency and hours per month are the override's unless it leaves
+// them unset. A nil spec leaves the other as it is, and neither input is modified.
+func MergeSpecs(base, override *PricingSpec) *PricingSpec {
+	switch {
+	case base == nil:
+		return override
+	case override == nil:
+		return base
+	}
+	return mergeSpecs(base, override)
+}
+
+// mergeSpecs returns child with the currency, hours per month, pricing and metadata it does
+// not set taken from parent. Nested maps, such as a data_transfer pricing block, are merged key by key.
+func mergeSpecs(parent, child *PricingSpec) *PricingSpec {
+	merged := *child
+	if merged.Currency == "" {
+		merged.Currency = parent.Currency
+	}
+	if merged.HoursPerMonth == 0 {
+		merged.HoursPerMonth = parent.HoursPerMonth
+	}
+	merged.Pricing = mergeMaps(parent.Pricing, child.Pricing)
+	merged.Metadata = mergeMaps(parent.Metadata, child.Metadata)
+	merged.Sources = append(slices.Clone(child.Sources), parent.Sources...)
+	return &merged
+}
+
+// mergeMaps returns a copy of base with the keys of override set over it. Nested maps and
+// lists are copied too, so changing the result never changes base or override.
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	if base == nil && override == nil {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = copyValue(v)
+	}
+	for k, v := range override {
+		nested, isMap := v.(map[string]interface{})
+		baseNested, baseIsMap := merged[k].(map[string]interface{})
+		if isMap && baseIsMap {
+			merged[k] = mergeMaps(baseNested, nested)
+			continue
+		}
+		merged[k] = copyValue(v)
+	}
+	return merged
+}
+
+// copyValue returns a deep copy of a decoded spec value's maps and lists.
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return mergeMaps(v, nil)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// loadSpecFromDir reads provider-service-sku.yaml, or provider-service-sku.json when there
+// is no YAML spec, from dir, returning ErrSpecNotFound when neither exists.
+func (l *Loader) loadSpecFromDir(
+	ctx context.Context,
+	dir, provider, service, sku string,
+) (*PricingSpec, error) {
 	log := logging.FromContext(ctx)
-	filename := fmt.Sprintf("%s-%s-%s.yaml", provider, service, sku)
-	path := filepath.Join(l.specDir, filename)
+	key := specKey(provider, service, sku)
+	path := filepath.Join(dir, key+yamlExt)
 
 	log.Debug().
 		Ctx(ctx).
@@ -67,6 +261,12 @@ func (l *Loader) LoadSpecWithContext(
 		Msg("loading pricing spec")
 
 	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		path = filepath.Join(dir, key+jsonExt)
+		data, err = os.ReadFile(path)
+	} else if err == nil {
+		l.warnShadowedJSON(ctx, path, filepath.Join(dir, key+jsonExt))
+	}
 	if err != nil {
 		if os.IsNotExist(err) {
 			log.Debug().
@@ -88,14 +288,23 @@ func (l *Loader) LoadSpecWithContext(
 	}
 
 	var spec PricingSpec
-	if unmarshalErr := yaml.Unmarshal(data, &spec); unmarshalErr != nil {
+	if unmarshalErr := unmarshalSpec(path, data, &spec); unmarshalErr != nil {
 		log.Error().
 			Ctx(ctx).
 			Str("component", "spec").
 			Err(unmarshalErr).
 			Str("spec_path", path).
-			Msg("failed to parse spec YAML")
-		return nil, fmt.Errorf("parsing spec YAML: %w", unmarshalErr)
+			Msg("failed to parse spec file")
+		return nil, unmarshalErr
+	}
+
+	if spec.Version < CurrentSpecVersion && hasLegacyPricingKeys(&spec) {
+		log.Warn().
+			Ctx(ctx).
+			Str("component", "spec").
+			Str("spec_path", path).
+			Int("spec_version", max(spec.Version, legacySpecVersion)).
+			Msg("pricing spec uses an outdated format; run 'finfocus spec migrate' to upgrade it")
 	}
 
 	log.Debug().
@@ -107,9 +316,42 @@ func (l *Loader) LoadSpecWithContext(
 		Str("currency", spec.Currency).
 		Msg("spec loaded successfully")
 
+	spec.Sources = []string{path}
 	return &spec, nil
 }
 
+// warnShadowedJSON warns when a JSON spec exists next to the YAML spec at yamlPath, which
+// wins over it. Each YAML path is checked once per Loader.
+func (l *Loader) warnShadowedJSON(ctx context.Context, yamlPath, jsonPath string) {
+	if _, checked := l.shadowChecked.LoadOrStore(yamlPath, true); checked {
+		return
+	}
+	if _, err := os.Stat(jsonPath); err != nil {
+		return
+	}
+	logging.FromContext(ctx).Warn().
+		Ctx(ctx).
+		Str("component", "spec").
+		Str("spec_path", yamlPath).
+		Str("ignored_path", jsonPath).
+		Msg("both YAML and JSON specs exist; using the YAML spec")
+}
+
+// unmarshalSpec parses a YAML or, for .json paths, JSON spec. JSON is read with the YAML
+// decoder after checking its syntax, so both formats share PricingSpec's field names.
+func unmarshalSpec(path string, data []byte, spec *PricingSpec) error {
+	if filepath.Ext(path) == jsonExt {
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parsing spec JSON: %w", err)
+		}
+	}
+	if err := yaml.Unmarshal(data, spec); err != nil {
+		return fmt.Errorf("parsing spec YAML: %w", err)
+	}
+	return nil
+}
+
 // ListSpecs returns a list of all available spec filenames in the spec directory.
 func (l *Loader) ListSpecs() ([]string, error) {
 	entries, err := os.ReadDir(l.specDir)
@@ -122,8 +364,17 @@ func (l *Loader) ListSpecs() ([]string, error) {
 
 	var specs []string
 	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".yaml" {
+		if entry.IsDir() {
+			continue
+		}
+		// JSON files are only specs when named like one; other tools' JSON can sit alongside.
+		switch filepath.Ext(entry.Name()) {
+		case yamlExt:
 			specs = append(specs, entry.Name())
+		case jsonExt:
+			if _, _, _, ok := ParseSpecFilename(entry.Name()); ok {
+				specs = append(specs, entry.Name())
+			}
 		}
 	}
 
@@ -134,7 +385,7 @@ func (l *Loader) ListSpecs() ([]string, error) {
 func ParseSpecFilename(filename string) (string, string, string, bool) {
 	// Remove extension
 	ext := filepath.Ext(filename)
-	if ext != ".yaml" && ext != ".yml" {
+	if ext != yamlExt && ext != ".yml" && ext != jsonExt {
 		return "", "", "", false
 	}
 
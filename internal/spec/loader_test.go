@@ -1,11 +1,15 @@
 package spec
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -224,6 +228,12 @@ func TestParseSpecFilename_Valid(t *testing.T) {
 			service:  "rds",
 			sku:      "db.t3.medium",
 		},
+		{
+			filename: "gcp-compute-e2-micro.json",
+			provider: "gcp",
+			service:  "compute",
+			sku:      "e2-micro",
+		},
 		{
 			filename: "azure-compute-standard-d2s-v3.yaml",
 			provider: "azure",
@@ -279,7 +289,7 @@ func TestParseSpecFilename_Invalid(t *testing.T) {
 			reason:   "no hyphens",
 		},
 		{
-			filename: "aws-ec2-t3.micro.json",
+			filename: "aws-ec2-t3.micro.txt",
 			reason:   "wrong extension",
 		},
 		{
@@ -464,6 +474,20 @@ func TestValidateSpec(t *testing.T) {
 			},
 			wantErr: "currency is required",
 		},
+		{
+			name: "negative hours per month",
+			spec: &PricingSpec{
+				Provider:      "aws",
+				Service:       "ec2",
+				SKU:           "t3.micro",
+				Currency:      "USD",
+				HoursPerMonth: -720,
+				Pricing: map[string]interface{}{
+					"hourly_cost": 0.0104,
+				},
+			},
+			wantErr: "hoursPerMonth must be a positive number, got -720",
+		},
 		{
 			name: "empty pricing",
 			spec: &PricingSpec{
@@ -547,3 +571,181 @@ metadata:
 	assert.Contains(t, pricingSpec.Metadata, "region")
 	assert.Contains(t, pricingSpec.Metadata, "description")
 }
+
+// TestLoadSpec_Extends tests that specs inherit the currency, pricing and metadata they do
+// not set from the spec they extend.
+func TestLoadSpec_Extends(t *testing.T) {
+	dir := t.TempDir()
+	specs := map[string]string{
+		"aws-ec2-default.yaml": "provider: aws\nservice: ec2\nsku: default\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.02\n  data_transfer:\n    egress_per_gb: 0.09\n    inter_az_per_gb: 0.01\n" +
+			"metadata:\n  source: list-price\n",
+		"aws-ec2-m5.yaml": "provider: aws\nservice: ec2\nsku: m5\nextends: default\n" +
+			"pricing:\n  data_transfer:\n    egress_per_gb: 0.05\n",
+		"aws-ec2-m5.large.yaml": "provider: aws\nservice: ec2\nsku: m5.large\nextends: aws-ec2-m5\n" +
+			"pricing:\n  onDemandHourly: 0.096\n",
+	}
+	for name, content := range specs {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	loader := NewLoader(dir)
+
+	loaded, err := loader.LoadSpec("aws", "ec2", "m5.large")
+	require.NoError(t, err)
+	spec := loaded.(*PricingSpec)
+	assert.Equal(t, "m5.large", spec.SKU)
+	assert.Equal(t, "USD", spec.Currency)
+	assert.Equal(t, map[string]interface{}{
+		"onDemandHourly": 0.096,
+		"data_transfer":  map[string]interface{}{"egress_per_gb": 0.05, "inter_az_per_gb": 0.01},
+	}, spec.Pricing)
+	assert.Equal(t, map[string]interface{}{"source": "list-price"}, spec.Metadata)
+
+	loaded, err = loader.LoadSpec("aws", "ec2", "default")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.02, loaded.(*PricingSpec).Pricing["onDemandHourly"], 1e-9, "parents are not modified")
+}
+
+// TestLoadSpec_ExtendsErrors tests that missing parents and cycles are reported rather than
+// treated as a missing spec.
+func TestLoadSpec_ExtendsErrors(t *testing.T) {
+	dir := t.TempDir()
+	specs := map[string]string{
+		"aws-ec2-orphan.yaml": "provider: aws\nservice: ec2\nsku: orphan\nextends: missing\n",
+		"aws-ec2-a.yaml":      "provider: aws\nservice: ec2\nsku: a\nextends: b\n",
+		"aws-ec2-b.yaml":      "provider: aws\nservice: ec2\nsku: b\nextends: aws-ec2-a\n",
+	}
+	for name, content := range specs {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	loader := NewLoader(dir)
+
+	_, err := loader.LoadSpec("aws", "ec2", "orphan")
+	require.ErrorIs(t, err, ErrSpecInheritance)
+	require.NotErrorIs(t, err, ErrSpecNotFound)
+	assert.ErrorContains(t, err, "aws-ec2-orphan extends missing, which was not found")
+
+	_, err = loader.LoadSpec("aws", "ec2", "a")
+	require.ErrorIs(t, err, ErrSpecInheritance)
+	assert.ErrorContains(t, err, "cycle aws-ec2-a -> aws-ec2-b -> aws-ec2-a")
+}
+
+// TestMergeSpecs tests layering an override spec over a base spec.
+func TestMergeSpecs(t *testing.T) {
+	base := &PricingSpec{
+		Provider: "aws", Service: "ec2", SKU: "m5.large", Currency: "USD", HoursPerMonth: 730,
+		Pricing: map[string]interface{}{
+			"onDemandHourly": 0.096,
+			"data_transfer":  map[string]interface{}{"egress_per_gb": 0.09, "inter_az_per_gb": 0.01},
+		},
+		Metadata: map[string]interface{}{"source": "list-price"},
+	}
+	override := &PricingSpec{
+		Provider: "aws", Service: "ec2", SKU: "m5.large",
+		Pricing: map[string]interface{}{
+			"onDemandHourly": 0.08,
+			"data_transfer":  map[string]interface{}{"egress_per_gb": 0.05},
+		},
+		Metadata: map[string]interface{}{"team": "payments"},
+	}
+
+	merged := MergeSpecs(base, override)
+	require.NotNil(t, merged)
+	assert.Equal(t, "USD", merged.Currency)
+	assert.InDelta(t, 730.0, merged.HoursPerMonth, 1e-9)
+	assert.Equal(t, map[string]interface{}{
+		"onDemandHourly": 0.08,
+		"data_transfer":  map[string]interface{}{"egress_per_gb": 0.05, "inter_az_per_gb": 0.01},
+	}, merged.Pricing)
+	assert.Equal(t, map[string]interface{}{"source": "list-price", "team": "payments"}, merged.Metadata)
+
+	assert.InDelta(t, 0.096, base.Pricing["onDemandHourly"], 1e-9, "base is not modified")
+	assert.Equal(t, map[string]interface{}{"egress_per_gb": 0.05}, override.Pricing["data_transfer"],
+		"override is not modified")
+
+	assert.Same(t, override, MergeSpecs(nil, override))
+	assert.Same(t, base, MergeSpecs(base, nil))
+	assert.Nil(t, MergeSpecs(nil, nil))
+}
+
+func TestMergeSpecs_CopiesNestedValues(t *testing.T) {
+	base := &PricingSpec{
+		Provider: "aws", Service: "ec2", SKU: "default",
+		Pricing: map[string]interface{}{
+			"time_of_day": map[string]interface{}{"peak_hourly": 0.2},
+			"data_transfer": map[string]interface{}{
+				"inter_region": map[string]interface{}{"us-east-1->eu-west-1": 0.02},
+			},
+			"tiers": []interface{}{map[string]interface{}{"upTo": 100}},
+		},
+		Metadata: map[string]interface{}{"owner": map[string]interface{}{"team": "platform"}},
+	}
+	override := &PricingSpec{
+		Provider: "aws", Service: "ec2", SKU: "t3.micro",
+		Pricing: map[string]interface{}{
+			"data_transfer": map[string]interface{}{"egress_per_gb": 0.09},
+		},
+	}
+
+	merged := MergeSpecs(base, override)
+	require.NotNil(t, merged)
+	merged.Pricing["time_of_day"].(map[string]interface{})["peak_hourly"] = 0.5
+	transfer := merged.Pricing["data_transfer"].(map[string]interface{})
+	transfer["inter_region"].(map[string]interface{})["us-east-1->eu-west-1"] = 0.05
+	merged.Pricing["tiers"].([]interface{})[0].(map[string]interface{})["upTo"] = 200
+	merged.Metadata["owner"].(map[string]interface{})["team"] = "payments"
+
+	assert.Equal(t, map[string]interface{}{"peak_hourly": 0.2}, base.Pricing["time_of_day"])
+	assert.Equal(t, map[string]interface{}{
+		"inter_region": map[string]interface{}{"us-east-1->eu-west-1": 0.02},
+	}, base.Pricing["data_transfer"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"upTo": 100}}, base.Pricing["tiers"])
+	assert.Equal(t, map[string]interface{}{"team": "platform"}, base.Metadata["owner"])
+}
+
+// TestLoadSpec_JSON tests that JSON specs are loaded like YAML ones, and that the YAML spec
+// wins when both exist.
+func TestLoadSpec_JSON(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"aws-ec2-t3.micro.json": `{"provider": "aws", "service": "ec2", "sku": "t3.micro", "currency": "USD",
+			"pricing": {"onDemandHourly": 0.0104, "data_transfer": {"egress_per_gb": 0.09}}}`,
+		"aws-ec2-m5.large.json": `{"provider": "aws", "service": "ec2", "sku": "m5.large", "currency": "USD",
+			"pricing": {"onDemandHourly": 0.2}}`,
+		"aws-ec2-m5.large.yaml": "provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\n" +
+			"pricing:\n  onDemandHourly: 0.096\n",
+		"aws-ec2-broken.json": `{"provider": "aws", "service": "ec2", "sku": "broken"`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	loader := NewLoader(dir)
+
+	loaded, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	spec := loaded.(*PricingSpec)
+	assert.Equal(t, "USD", spec.Currency)
+	assert.Equal(t, map[string]interface{}{
+		"onDemandHourly": 0.0104,
+		"data_transfer":  map[string]interface{}{"egress_per_gb": 0.09},
+	}, spec.Pricing)
+	assert.Equal(t, []string{filepath.Join(dir, "aws-ec2-t3.micro.json")}, spec.Sources)
+	require.NoError(t, ValidateSpec(spec))
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).WithContext(context.Background())
+	for range 3 {
+		loaded, err = loader.LoadSpecWithContext(ctx, "aws", "ec2", "m5.large")
+		require.NoError(t, err)
+		assert.InDelta(t, 0.096, loaded.(*PricingSpec).Pricing["onDemandHourly"], 1e-9, "YAML wins over JSON")
+	}
+	assert.Equal(t, 1, strings.Count(logs.String(), "both YAML and JSON specs exist"),
+		"the shadowed JSON spec is reported once")
+
+	_, err = loader.LoadSpec("aws", "ec2", "broken")
+	require.ErrorContains(t, err, "parsing spec JSON")
+
+	names, err := loader.ListSpecs()
+	require.NoError(t, err)
+	assert.Contains(t, names, "aws-ec2-t3.micro.json")
+}
@@ -0,0 +1,341 @@
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// CurrentSpecVersion is the spec format version written by MigrateSpecData. Specs
+	// without a version field are treated as version 1.
+	CurrentSpecVersion = 2
+
+	legacySpecVersion = 1
+	yamlIndent        = 2
+)
+
+// ErrUnsupportedSpecVersion is returned for specs declaring a version newer than this build supports.
+var ErrUnsupportedSpecVersion = errors.New("unsupported spec version")
+
+// MigrationResult describes the migration of one spec file.
+type MigrationResult struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	// Changes lists each edit in the order it was applied.
+	Changes []string
+	// Err is set when the file could not be read, migrated or written.
+	Err error
+}
+
+// Migrated reports whether the spec needed an upgrade.
+func (r MigrationResult) Migrated() bool {
+	return r.Err == nil && r.FromVersion != r.ToVersion
+}
+
+// specMigrations upgrades a spec from the keyed version to the next one, returning a
+// description of each change.
+var specMigrations = map[int]func(root *yaml.Node) ([]string, error){
+	legacySpecVersion: migrateV1ToV2,
+}
+
+// legacyPricingKeys maps version 1 price keys to the names the engine reads.
+var legacyPricingKeys = []struct{ from, to string }{
+	{"hourly", "onDemandHourly"},
+	{"hourly_rate", "hourlyRate"},
+	{"monthly", "monthlyEstimate"},
+	{"price_per_gb_month", "pricePerGBMonth"},
+}
+
+// hasLegacyPricingKeys reports whether a parsed spec uses version 1 price keys that the
+// engine does not read, so its prices would be silently ignored.
+func hasLegacyPricingKeys(spec *PricingSpec) bool {
+	if _, ok := spec.Pricing["currency"]; ok {
+		return true
+	}
+	for _, k := range legacyPricingKeys {
+		if _, ok := spec.Pricing[k.from]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateSpecData upgrades a YAML spec to CurrentSpecVersion. Values are preserved, as are
+// key order and comments where possible. Specs already at the current version are
+// returned unchanged.
+func MigrateSpecData(data []byte) ([]byte, MigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, MigrationResult{}, fmt.Errorf("parsing spec YAML: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, MigrationResult{}, errors.New("spec is not a YAML mapping")
+	}
+	root := doc.Content[0]
+
+	from, err := specVersion(root)
+	if err != nil {
+		return nil, MigrationResult{}, err
+	}
+	result := MigrationResult{FromVersion: from, ToVersion: from}
+	if from == CurrentSpecVersion {
+		return data, result, nil
+	}
+
+	for v := from; v < CurrentSpecVersion; v++ {
+		changes, migrateErr := specMigrations[v](root)
+		if migrateErr != nil {
+			return nil, result, fmt.Errorf("migrating from version %d: %w", v, migrateErr)
+		}
+		result.Changes = append(result.Changes, changes...)
+	}
+	setVersion(root, CurrentSpecVersion)
+	result.ToVersion = CurrentSpecVersion
+	result.Changes = append(result.Changes, fmt.Sprintf("set version to %d", CurrentSpecVersion))
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent)
+	if encodeErr := enc.Encode(&doc); encodeErr != nil {
+		return nil, result, fmt.Errorf("encoding spec YAML: %w", encodeErr)
+	}
+	if closeErr := enc.Close(); closeErr != nil {
+		return nil, result, fmt.Errorf("encoding spec YAML: %w", closeErr)
+	}
+	return buf.Bytes(), result, nil
+}
+
+// MigrateFile migrates a single spec file, replacing it atomically unless dryRun is set.
+func MigrateFile(path string, dryRun bool) MigrationResult {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MigrationResult{Path: path, Err: fmt.Errorf("reading spec file: %w", err)}
+	}
+	migrated, result, err := MigrateSpecData(data)
+	result.Path = path
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if dryRun || !result.Migrated() {
+		return result
+	}
+	if writeErr := writeFileAtomic(path, migrated); writeErr != nil {
+		result.Err = writeErr
+	}
+	return result
+}
+
+// MigrateDir migrates every .yaml and .yml spec in dir, sorted by filename.
+func MigrateDir(dir string, dryRun bool) ([]MigrationResult, error) {
+	names, err := specFileNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]MigrationResult, 0, len(names))
+	for _, name := range names {
+		results = append(results, MigrateFile(filepath.Join(dir, name), dryRun))
+	}
+	return results, nil
+}
+
+// specFileNames returns the names of the .yaml and .yml files in dir, and of files with
+// any of the extra extensions that are named like specs, sorted.
+func specFileNames(dir string, extraExts ...string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		extra := slices.Contains(extraExts, ext)
+		if extra {
+			_, _, _, extra = ParseSpecFilename(entry.Name())
+		}
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml" || extra) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// specVersion reads the version field, defaulting to 1 when absent.
+func specVersion(root *yaml.Node) (int, error) {
+	i := mappingIndex(root, "version")
+	if i < 0 {
+		return legacySpecVersion, nil
+	}
+	v, err := strconv.Atoi(root.Content[i+1].Value)
+	if err != nil || v < legacySpecVersion {
+		return 0, fmt.Errorf("invalid spec version %q", root.Content[i+1].Value)
+	}
+	if v > CurrentSpecVersion {
+		return 0, fmt.Errorf("%w: %d (latest is %d)", ErrUnsupportedSpecVersion, v, CurrentSpecVersion)
+	}
+	return v, nil
+}
+
+// migrateV1ToV2 moves flat price fields under pricing, renames legacy price keys, moves
+// pricing.currency to the top level, and fills provider/service/sku from the legacy
+// resource_type and instance_type fields.
+func migrateV1ToV2(root *yaml.Node) ([]string, error) {
+	var changes []string
+
+	pricing := mappingValue(root, "pricing")
+	if pricing != nil && pricing.Kind != yaml.MappingNode {
+		return nil, errors.New("pricing is not a mapping")
+	}
+	for _, k := range legacyPricingKeys {
+		for _, name := range []string{k.from, k.to} {
+			i := mappingIndex(root, name)
+			if i < 0 {
+				continue
+			}
+			if pricing == nil {
+				pricing = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				root.Content = append(root.Content, scalarNode("pricing"), pricing)
+			}
+			key, value := removeMappingEntry(root, i)
+			pricing.Content = append(pricing.Content, key, value)
+			changes = append(changes, fmt.Sprintf("moved top-level %s into pricing", name))
+		}
+	}
+
+	if pricing != nil {
+		for _, k := range legacyPricingKeys {
+			i := mappingIndex(pricing, k.from)
+			if i < 0 {
+				continue
+			}
+			if mappingIndex(pricing, k.to) >= 0 {
+				removeMappingEntry(pricing, i)
+				changes = append(changes, fmt.Sprintf("removed pricing.%s (pricing.%s is already set)", k.from, k.to))
+				continue
+			}
+			pricing.Content[i].Value = k.to
+			changes = append(changes, fmt.Sprintf("renamed pricing.%s to pricing.%s", k.from, k.to))
+		}
+
+		if i := mappingIndex(pricing, "currency"); i >= 0 {
+			_, value := removeMappingEntry(pricing, i)
+			if existing := mappingValue(root, "currency"); existing == nil || existing.Value == "" {
+				setScalar(root, "currency", value.Value)
+				changes = append(changes, "moved pricing.currency to currency")
+			} else {
+				changes = append(changes, "removed pricing.currency (currency is already set)")
+			}
+		}
+	}
+
+	if resourceType := mappingValue(root, "resource_type"); resourceType != nil {
+		// aws:ec2:Instance or aws:ec2/instance:Instance
+		if provider, rest, found := strings.Cut(resourceType.Value, ":"); found {
+			changes = append(changes, fillScalar(root, "provider", strings.ToLower(provider))...)
+			service, _, _ := strings.Cut(rest, ":")
+			service, _, _ = strings.Cut(service, "/")
+			changes = append(changes, fillScalar(root, "service", strings.ToLower(service))...)
+		}
+	}
+	if instanceType := mappingValue(root, "instance_type"); instanceType != nil {
+		changes = append(changes, fillScalar(root, "sku", instanceType.Value)...)
+	}
+
+	return changes, nil
+}
+
+// fillScalar sets key when it is missing or empty, returning the change made, if any.
+func fillScalar(root *yaml.Node, key, value string) []string {
+	if existing := mappingValue(root, key); (existing != nil && existing.Value != "") || value == "" {
+		return nil
+	}
+	setScalar(root, key, value)
+	return []string{fmt.Sprintf("set %s to %q", key, value)}
+}
+
+// setVersion writes the version field as the first key of the spec.
+func setVersion(root *yaml.Node, version int) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}
+	if i := mappingIndex(root, "version"); i >= 0 {
+		root.Content[i+1] = value
+		return
+	}
+	root.Content = append([]*yaml.Node{scalarNode("version"), value}, root.Content...)
+}
+
+func setScalar(root *yaml.Node, key, value string) {
+	if i := mappingIndex(root, key); i >= 0 {
+		root.Content[i+1] = scalarNode(value)
+		return
+	}
+	root.Content = append(root.Content, scalarNode(key), scalarNode(value))
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// mappingIndex returns the index of key's key node in a mapping node's content, or -1.
+func mappingIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(m, key); i >= 0 {
+		return m.Content[i+1]
+	}
+	return nil
+}
+
+func removeMappingEntry(m *yaml.Node, i int) (*yaml.Node, *yaml.Node) {
+	key, value := m.Content[i], m.Content[i+1]
+	m.Content = append(m.Content[:i], m.Content[i+2:]...)
+	return key, value
+}
+
+// writeFileAtomic replaces path via a temporary file in the same directory, keeping the
+// original file mode.
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("reading spec file: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary spec file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing spec file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("writing spec file: %w", closeErr)
+	}
+	if chmodErr := os.Chmod(tmpPath, info.Mode().Perm()); chmodErr != nil {
+		return fmt.Errorf("writing spec file: %w", chmodErr)
+	}
+	if renameErr := os.Rename(tmpPath, path); renameErr != nil {
+		return fmt.Errorf("replacing spec file: %w", renameErr)
+	}
+	return nil
+}
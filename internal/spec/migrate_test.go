@@ -0,0 +1,96 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const legacySpecYAML = `# t3.micro on-demand pricing
+resource_type: aws:ec2:Instance
+instance_type: t3.micro
+pricing:
+  hourly: 0.0104 # us-east-1
+  currency: USD
+`
+
+func TestMigrateSpecData_Legacy(t *testing.T) {
+	out, result, err := MigrateSpecData([]byte(legacySpecYAML))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.FromVersion)
+	assert.Equal(t, CurrentSpecVersion, result.ToVersion)
+	assert.True(t, result.Migrated())
+	assert.Contains(t, result.Changes, "renamed pricing.hourly to pricing.onDemandHourly")
+	assert.Contains(t, result.Changes, "moved pricing.currency to currency")
+
+	var migrated PricingSpec
+	require.NoError(t, yaml.Unmarshal(out, &migrated))
+	assert.Equal(t, CurrentSpecVersion, migrated.Version)
+	assert.Equal(t, "aws", migrated.Provider)
+	assert.Equal(t, "ec2", migrated.Service)
+	assert.Equal(t, "t3.micro", migrated.SKU)
+	assert.Equal(t, "USD", migrated.Currency)
+	assert.InDelta(t, 0.0104, migrated.Pricing["onDemandHourly"], 1e-9)
+	assert.NotContains(t, migrated.Pricing, "currency")
+	require.NoError(t, ValidateSpec(&migrated))
+
+	assert.Contains(t, string(out), "# us-east-1", "comments are preserved")
+}
+
+func TestMigrateSpecData_FlatHourly(t *testing.T) {
+	in := "provider: gcp\nservice: compute\nsku: e2-micro\ncurrency: USD\nhourly: 0.008\nmonthly: 6\n"
+	out, result, err := MigrateSpecData([]byte(in))
+	require.NoError(t, err)
+	assert.Contains(t, result.Changes, "moved top-level hourly into pricing")
+
+	var migrated PricingSpec
+	require.NoError(t, yaml.Unmarshal(out, &migrated))
+	assert.InDelta(t, 0.008, migrated.Pricing["onDemandHourly"], 1e-9)
+	assert.EqualValues(t, 6, migrated.Pricing["monthlyEstimate"])
+}
+
+func TestMigrateSpecData_AlreadyCurrent(t *testing.T) {
+	in := []byte("version: 2\nprovider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n")
+	out, result, err := MigrateSpecData(in)
+	require.NoError(t, err)
+	assert.False(t, result.Migrated())
+	assert.Empty(t, result.Changes)
+	assert.Equal(t, in, out)
+}
+
+func TestMigrateSpecData_NewerVersion(t *testing.T) {
+	_, _, err := MigrateSpecData([]byte("version: 99\nprovider: aws\n"))
+	require.ErrorIs(t, err, ErrUnsupportedSpecVersion)
+}
+
+func TestMigrateDir_DryRunAndWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aws-ec2-t3.micro.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(legacySpecYAML), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("- not\n- a mapping\n"), 0o600))
+
+	results, err := MigrateDir(dir, true)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Error(t, results[1].Err, "broken.yaml sorts second")
+	assert.True(t, results[0].Migrated())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, legacySpecYAML, string(data), "dry run leaves files untouched")
+
+	results, err = MigrateDir(dir, false)
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+
+	loaded, err := NewLoader(dir).LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	assert.Equal(t, CurrentSpecVersion, loaded.(*PricingSpec).Version)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -0,0 +1,332 @@
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Remote source types.
+const (
+	// RemoteTypeGit fetches specs by shallow-cloning a git repository.
+	RemoteTypeGit = "git"
+	// RemoteTypeHTTP fetches specs listed in a JSON index served over HTTP(S).
+	RemoteTypeHTTP = "http"
+)
+
+const (
+	// remoteSyncMarker records the time of the last successful sync inside the cache directory.
+	remoteSyncMarker = ".last-sync"
+	// remoteFetchTimeout bounds a single remote fetch.
+	remoteFetchTimeout = 2 * time.Minute
+	// maxRemoteSpecSize limits the size of a single downloaded spec file.
+	maxRemoteSpecSize = 1 << 20
+	// remoteDirPerm is the permission used for the remote cache directory.
+	remoteDirPerm = 0o750
+	// remoteFilePerm is the permission used for cached spec files.
+	remoteFilePerm = 0o600
+)
+
+// ErrRemoteNotConfigured is returned when a sync is requested without a remote URL.
+var ErrRemoteNotConfigured = errors.New("remote spec source is not configured")
+
+// RemoteSource describes a shared spec repository and where its specs are cached locally.
+//
+// For RemoteTypeHTTP the URL points to a JSON index of the form
+// {"specs": ["aws-ec2-t3.micro.yaml", ...]}; each entry is resolved relative to the index URL.
+// For RemoteTypeGit the URL is cloned and every *.yaml spec file in the repository is cached.
+type RemoteSource struct {
+	URL      string
+	Type     string
+	TTL      time.Duration
+	CacheDir string
+
+	// HTTPClient is used for HTTP sources; http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// RemoteSyncResult reports the outcome of a sync.
+type RemoteSyncResult struct {
+	// Skipped is true when the cache was still fresh and no fetch was performed.
+	Skipped bool
+	// Cached lists the spec filenames written to the cache.
+	Cached []string
+	// Rejected maps filenames that failed validation to the reason.
+	Rejected map[string]string
+	// Warnings maps cached filenames to problems that did not keep them out of the cache,
+	// such as pricing keys that do not fit the resource (see SpecWarnings).
+	Warnings map[string][]string
+}
+
+// remoteIndex is the JSON document served by HTTP spec sources.
+type remoteIndex struct {
+	Specs []string `json:"specs"`
+}
+
+// ResolvedType returns the configured type, inferring git for ".git" and git@ URLs.
+func (r *RemoteSource) ResolvedType() string {
+	if r.Type != "" {
+		return r.Type
+	}
+	if strings.HasSuffix(r.URL, ".git") || strings.HasPrefix(r.URL, "git@") {
+		return RemoteTypeGit
+	}
+	return RemoteTypeHTTP
+}
+
+// IsFresh reports whether the cache was synced within the TTL.
+func (r *RemoteSource) IsFresh() bool {
+	info, err := os.Stat(filepath.Join(r.CacheDir, remoteSyncMarker))
+	if err != nil {
+		return false
+	}
+	return r.TTL > 0 && time.Since(info.ModTime()) < r.TTL
+}
+
+// Sync fetches the remote specs, validates them and replaces the local cache.
+// Unless force is set, a fresh cache is left untouched. On any fetch failure the
+// existing cache is preserved so that callers can keep working offline.
+func (r *RemoteSource) Sync(ctx context.Context, force bool) (*RemoteSyncResult, error) {
+	if r.URL == "" {
+		return nil, ErrRemoteNotConfigured
+	}
+	if !force && r.IsFresh() {
+		return &RemoteSyncResult{Skipped: true}, nil
+	}
+
+	log := logging.FromContext(ctx)
+	log.Debug().Ctx(ctx).Str("component", "spec").Str("url", r.URL).
+		Str("type", r.ResolvedType()).Msg("syncing remote specs")
+
+	ctx, cancel := context.WithTimeout(ctx, remoteFetchTimeout)
+	defer cancel()
+
+	parent := filepath.Dir(r.CacheDir)
+	if err := os.MkdirAll(parent, remoteDirPerm); err != nil {
+		return nil, fmt.Errorf("creating spec cache parent: %w", err)
+	}
+	staging, err := os.MkdirTemp(parent, ".remote-staging-")
+	if err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	var files map[string][]byte
+	switch r.ResolvedType() {
+	case RemoteTypeGit:
+		files, err = r.fetchGit(ctx)
+	case RemoteTypeHTTP:
+		files, err = r.fetchHTTP(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported remote spec type %q", r.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RemoteSyncResult{Rejected: make(map[string]string), Warnings: make(map[string][]string)}
+	for name, data := range files {
+		reason, warnings := validateRemoteSpec(name, data)
+		if reason != "" {
+			result.Rejected[name] = reason
+			log.Warn().Ctx(ctx).Str("component", "spec").Str("file", name).
+				Str("reason", reason).Msg("rejected remote spec")
+			continue
+		}
+		if len(warnings) > 0 {
+			result.Warnings[name] = warnings
+			log.Warn().Ctx(ctx).Str("component", "spec").Str("file", name).
+				Strs("warnings", warnings).Msg("cached remote spec with warnings")
+		}
+		if writeErr := os.WriteFile(filepath.Join(staging, name), data, remoteFilePerm); writeErr != nil {
+			return nil, fmt.Errorf("writing %s: %w", name, writeErr)
+		}
+		result.Cached = append(result.Cached, name)
+	}
+
+	if markErr := os.WriteFile(filepath.Join(staging, remoteSyncMarker), nil, remoteFilePerm); markErr != nil {
+		return nil, fmt.Errorf("writing sync marker: %w", markErr)
+	}
+	if swapErr := replaceDir(staging, r.CacheDir); swapErr != nil {
+		return nil, swapErr
+	}
+
+	log.Info().Ctx(ctx).Str("component", "spec").Int("cached", len(result.Cached)).
+		Int("rejected", len(result.Rejected)).Msg("remote specs synced")
+	return result, nil
+}
+
+// EnsureFresh syncs when the cache is stale and falls back to the existing cache when
+// the remote is unreachable. It returns the cache directory to search for specs.
+func (r *RemoteSource) EnsureFresh(ctx context.Context) string {
+	if _, err := r.Sync(ctx, false); err != nil {
+		logging.FromContext(ctx).Warn().Ctx(ctx).Str("component", "spec").Err(err).
+			Str("cache_dir", r.CacheDir).Msg("remote spec sync failed, using cached copy")
+	}
+	return r.CacheDir
+}
+
+// fetchHTTP downloads the index and each listed spec.
+func (r *RemoteSource) fetchHTTP(ctx context.Context) (map[string][]byte, error) {
+	base, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing remote spec URL: %w", err)
+	}
+
+	indexData, err := r.httpGet(ctx, base.String())
+	if err != nil {
+		return nil, fmt.Errorf("fetching spec index: %w", err)
+	}
+	var index remoteIndex
+	if unmarshalErr := json.Unmarshal(indexData, &index); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing spec index: %w", unmarshalErr)
+	}
+
+	files := make(map[string][]byte, len(index.Specs))
+	for _, entry := range index.Specs {
+		name := path.Base(entry)
+		ref, parseErr := url.Parse(entry)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid index entry %q: %w", entry, parseErr)
+		}
+		data, getErr := r.httpGet(ctx, base.ResolveReference(ref).String())
+		if getErr != nil {
+			return nil, fmt.Errorf("fetching %s: %w", entry, getErr)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+func (r *RemoteSource) httpGet(ctx context.Context, target string) ([]byte, error) {
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, target)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRemoteSpecSize))
+}
+
+// fetchGit shallow-clones the repository and collects its spec files.
+func (r *RemoteSource) fetchGit(ctx context.Context) (map[string][]byte, error) {
+	checkout, err := os.MkdirTemp("", "finfocus-spec-git-")
+	if err != nil {
+		return nil, fmt.Errorf("creating checkout directory: %w", err)
+	}
+	defer os.RemoveAll(checkout)
+
+	//nolint:gosec // URL comes from the user's own configuration.
+	cmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--quiet", r.URL, checkout)
+	if out, cloneErr := cmd.CombinedOutput(); cloneErr != nil {
+		return nil, fmt.Errorf("cloning %s: %w: %s", r.URL, cloneErr, strings.TrimSpace(string(out)))
+	}
+
+	files := make(map[string][]byte)
+	walkErr := filepath.WalkDir(checkout, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, _, _, ok := ParseSpecFilename(d.Name()); !ok {
+			return nil
+		}
+		data, readErr := os.ReadFile(p)
+		if readErr != nil {
+			return readErr
+		}
+		files[d.Name()] = data
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("reading cloned specs: %w", walkErr)
+	}
+	return files, nil
+}
+
+// validateRemoteSpec returns a rejection reason, or "" when the spec is acceptable, along
+// with its warnings. Pricing that does not fit the resource is only a warning here, so a
+// shared repository that predates that check keeps syncing; its specs are still usable.
+func validateRemoteSpec(name string, data []byte) (string, []string) {
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "invalid filename", nil
+	}
+	if _, _, _, ok := ParseSpecFilename(name); !ok {
+		return "filename does not follow provider-service-sku.yaml", nil
+	}
+	var spec PricingSpec
+	if err := unmarshalSpec(name, data, &spec); err != nil {
+		return err.Error(), nil
+	}
+	err := ValidateSpec(&spec)
+	fieldErrs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // ValidateSpec joins its errors.
+		fieldErrs = joined.Unwrap()
+	}
+	var problems []error
+	var warnings []string
+	for _, fieldErr := range fieldErrs {
+		switch {
+		case fieldErr == nil:
+		case errors.Is(fieldErr, ErrPricingMismatch):
+			warnings = append(warnings, fieldErr.Error())
+		default:
+			problems = append(problems, fieldErr)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Join(problems...).Error(), nil
+	}
+	return "", append(warnings, SpecWarnings(&spec)...)
+}
+
+// replaceDir swaps staging into place at target, keeping the old directory until the
+// rename succeeds so that a failure never leaves the cache empty.
+func replaceDir(staging, target string) error {
+	backup := target + ".old"
+	_ = os.RemoveAll(backup)
+
+	hadTarget := false
+	if _, err := os.Stat(target); err == nil {
+		if renameErr := os.Rename(target, backup); renameErr != nil {
+			return fmt.Errorf("moving old spec cache aside: %w", renameErr)
+		}
+		hadTarget = true
+	}
+
+	if err := os.Rename(staging, target); err != nil {
+		if hadTarget {
+			_ = os.Rename(backup, target)
+		}
+		return fmt.Errorf("installing spec cache: %w", err)
+	}
+	_ = os.RemoveAll(backup)
+	return nil
+}
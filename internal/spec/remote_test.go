@@ -0,0 +1,133 @@
+package spec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const remoteValidSpec = `provider: aws
+service: ec2
+sku: t3.micro
+currency: USD
+pricing:
+  onDemandHourly: 0.0104
+`
+
+func newRemoteSpecServer(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRemoteSource_SyncHTTP(t *testing.T) {
+	srv := newRemoteSpecServer(t, map[string]string{
+		"/specs/index.json":            `{"specs": ["aws-ec2-t3.micro.yaml", "aws-rds-broken.yaml"]}`,
+		"/specs/aws-ec2-t3.micro.yaml": remoteValidSpec,
+		"/specs/aws-rds-broken.yaml":   "provider: aws\n",
+	})
+	cacheDir := filepath.Join(t.TempDir(), "remote")
+	source := &RemoteSource{URL: srv.URL + "/specs/index.json", TTL: time.Hour, CacheDir: cacheDir}
+
+	result, err := source.Sync(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aws-ec2-t3.micro.yaml"}, result.Cached)
+	assert.Contains(t, result.Rejected, "aws-rds-broken.yaml")
+	assert.FileExists(t, filepath.Join(cacheDir, "aws-ec2-t3.micro.yaml"))
+	assert.NoFileExists(t, filepath.Join(cacheDir, "aws-rds-broken.yaml"))
+	assert.True(t, source.IsFresh())
+
+	// A fresh cache is not refetched unless forced.
+	result, err = source.Sync(context.Background(), false)
+	require.NoError(t, err)
+	assert.True(t, result.Skipped)
+}
+
+func TestRemoteSource_SyncKeepsMismatchedPricing(t *testing.T) {
+	srv := newRemoteSpecServer(t, map[string]string{
+		"/specs/index.json": `{"specs": ["aws-ec2-t3.micro.yaml", "aws-ec2-m5.large.yaml"]}`,
+		"/specs/aws-ec2-t3.micro.yaml": "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: USD\n" +
+			"pricing:\n  pricePerGBMonth: 0.1\n",
+		"/specs/aws-ec2-m5.large.yaml": "provider: aws\nservice: ec2\nsku: m5.large\ncurrency: USD\n" +
+			"pricing:\n  pricePerGBMonth: 0.1\n  hourlyRate: 0.096\n",
+	})
+	cacheDir := filepath.Join(t.TempDir(), "remote")
+	source := &RemoteSource{URL: srv.URL + "/specs/index.json", TTL: time.Hour, CacheDir: cacheDir}
+
+	result, err := source.Sync(context.Background(), true)
+	require.NoError(t, err)
+	assert.Empty(t, result.Rejected, "a per-GB price on a compute spec does not reject it")
+	assert.ElementsMatch(t, []string{"aws-ec2-t3.micro.yaml", "aws-ec2-m5.large.yaml"}, result.Cached)
+	assert.FileExists(t, filepath.Join(cacheDir, "aws-ec2-t3.micro.yaml"))
+	require.Len(t, result.Warnings["aws-ec2-t3.micro.yaml"], 1)
+	assert.Contains(t, result.Warnings["aws-ec2-t3.micro.yaml"][0], "pricePerGBMonth is priced per GB")
+	assert.Len(t, result.Warnings["aws-ec2-m5.large.yaml"], 2, "SpecWarnings are reported too")
+}
+
+func TestRemoteSource_OfflineKeepsCache(t *testing.T) {
+	srv := newRemoteSpecServer(t, map[string]string{
+		"/index.json":            `{"specs": ["aws-ec2-t3.micro.yaml"]}`,
+		"/aws-ec2-t3.micro.yaml": remoteValidSpec,
+	})
+	cacheDir := filepath.Join(t.TempDir(), "remote")
+	source := &RemoteSource{URL: srv.URL + "/index.json", CacheDir: cacheDir}
+
+	_, err := source.Sync(context.Background(), true)
+	require.NoError(t, err)
+
+	srv.Close()
+	_, err = source.Sync(context.Background(), true)
+	require.Error(t, err)
+	assert.FileExists(t, filepath.Join(cacheDir, "aws-ec2-t3.micro.yaml"))
+
+	// EnsureFresh swallows the failure and still points at the cache.
+	assert.Equal(t, cacheDir, source.EnsureFresh(context.Background()))
+}
+
+func TestRemoteSource_NotConfigured(t *testing.T) {
+	_, err := (&RemoteSource{}).Sync(context.Background(), true)
+	require.ErrorIs(t, err, ErrRemoteNotConfigured)
+}
+
+func TestRemoteSource_ResolvedType(t *testing.T) {
+	assert.Equal(t, RemoteTypeGit, (&RemoteSource{URL: "https://github.com/acme/specs.git"}).ResolvedType())
+	assert.Equal(t, RemoteTypeGit, (&RemoteSource{URL: "git@github.com:acme/specs"}).ResolvedType())
+	assert.Equal(t, RemoteTypeHTTP, (&RemoteSource{URL: "https://example.com/index.json"}).ResolvedType())
+	assert.Equal(t, RemoteTypeHTTP, (&RemoteSource{URL: "x.git", Type: RemoteTypeHTTP}).ResolvedType())
+}
+
+func TestLoader_FallbackDirs(t *testing.T) {
+	localDir := t.TempDir()
+	remoteDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "aws-ec2-t3.micro.yaml"), []byte(remoteValidSpec), 0o600))
+
+	loader := NewLoaderWithFallback(localDir, remoteDir)
+	loaded, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	assert.Equal(t, "t3.micro", loaded.(*PricingSpec).SKU)
+
+	// Local specs take precedence over the fallback.
+	local := "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: EUR\npricing:\n  onDemandHourly: 1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "aws-ec2-t3.micro.yaml"), []byte(local), 0o600))
+	loaded, err = loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", loaded.(*PricingSpec).Currency)
+
+	_, err = loader.LoadSpec("aws", "rds", "db.t3.micro")
+	require.ErrorIs(t, err, ErrSpecNotFound)
+}
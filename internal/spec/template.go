@@ -0,0 +1,218 @@
+package spec
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateDirName is the directory under the spec directory holding user templates,
+// which add to or replace the built-in ones by name.
+const TemplateDirName = "templates"
+
+// builtinTemplateSource is the Source of templates shipped with finfocus.
+const builtinTemplateSource = "built-in"
+
+//go:embed templates/*.yaml
+var builtinTemplateFS embed.FS
+
+// templateCostKeys are the pricing keys the engine derives a cost from; a template must
+// set at least one of them.
+var templateCostKeys = []string{"onDemandHourly", "hourlyRate", "monthlyEstimate", "pricePerGBMonth"}
+
+// templateNamePattern restricts template names to what can be typed as a flag value.
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ErrTemplateNotFound is returned when no template matches the requested name or provider.
+var ErrTemplateNotFound = errors.New("spec template not found")
+
+// Template pre-fills a new pricing spec with the structure, field names and defaults
+// that suit one kind of resource of a provider, such as AWS EC2 instances.
+type Template struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Provider    string `yaml:"provider"`
+	// Service and SKU are the defaults used when the spec author does not give them.
+	Service string `yaml:"service"`
+	SKU     string `yaml:"sku"`
+	// SKUProperty names the resource property whose value the SKU corresponds to, such as
+	// instanceType for EC2 or vmSize for Azure virtual machines.
+	SKUProperty string                 `yaml:"skuProperty,omitempty"`
+	Currency    string                 `yaml:"currency"`
+	Pricing     map[string]interface{} `yaml:"pricing"`
+	Metadata    map[string]interface{} `yaml:"metadata,omitempty"`
+	// Source is "built-in" or the path of the user template file.
+	Source string `yaml:"-"`
+}
+
+// ValidateTemplate checks that a template names its provider, service and currency, and
+// prices resources with at least one numeric pricing key the engine understands.
+func ValidateTemplate(t *Template) error {
+	if !templateNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("template name %q must be lowercase letters, digits and dashes", t.Name)
+	}
+	for field, value := range map[string]string{
+		"provider": t.Provider, "service": t.Service, "sku": t.SKU, "currency": t.Currency,
+	} {
+		if value == "" {
+			return fmt.Errorf("template %q: %s is required", t.Name, field)
+		}
+	}
+	for _, key := range templateCostKeys {
+		if _, ok := t.Pricing[key].(float64); ok {
+			return nil
+		}
+		if _, ok := t.Pricing[key].(int); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("template %q: pricing must set one of %s", t.Name, strings.Join(templateCostKeys, ", "))
+}
+
+// LoadTemplates returns the built-in templates together with the user templates in
+// userDir, keyed by name. A user template with the name of a built-in one replaces it.
+// A missing userDir is not an error; an invalid template is.
+func LoadTemplates(userDir string) (map[string]*Template, error) {
+	templates := make(map[string]*Template)
+	if err := loadTemplatesFrom(builtinTemplateFS, "templates", builtinTemplateSource, templates); err != nil {
+		return nil, err
+	}
+	if userDir == "" {
+		return templates, nil
+	}
+	if _, err := os.Stat(userDir); errors.Is(err, fs.ErrNotExist) {
+		return templates, nil
+	}
+	if err := loadTemplatesFrom(os.DirFS(userDir), ".", userDir, templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// loadTemplatesFrom parses and validates the YAML templates in dir of fsys into templates.
+func loadTemplatesFrom(fsys fs.FS, dir, source string, templates map[string]*Template) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("reading spec templates: %w", err)
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, readErr := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, entry.Name())))
+		if readErr != nil {
+			return fmt.Errorf("reading spec template %s: %w", entry.Name(), readErr)
+		}
+		var t Template
+		if err = yaml.Unmarshal(data, &t); err != nil {
+			return fmt.Errorf("parsing spec template %s: %w", entry.Name(), err)
+		}
+		if err = ValidateTemplate(&t); err != nil {
+			return fmt.Errorf("spec template %s: %w", entry.Name(), err)
+		}
+		t.Source = source
+		if source != builtinTemplateSource {
+			t.Source = filepath.Join(source, entry.Name())
+		}
+		templates[t.Name] = &t
+	}
+	return nil
+}
+
+// SortedTemplateNames returns the template names in alphabetical order.
+func SortedTemplateNames(templates map[string]*Template) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FindTemplate returns the template called name, or when name is empty the provider's
+// default template, which is named after the provider.
+func FindTemplate(templates map[string]*Template, name, provider string) (*Template, error) {
+	if name == "" {
+		name = strings.ToLower(provider)
+	}
+	if t, ok := templates[name]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: %q (available: %s)", ErrTemplateNotFound, name,
+		strings.Join(SortedTemplateNames(templates), ", "))
+}
+
+// genericTemplateName names the template TemplateFor falls back to.
+const genericTemplateName = "generic"
+
+// TemplateFor returns the template for resources of provider's service, preferring the
+// provider's default template. When none matches it returns a generic template that
+// prices the resource per hour in USD.
+func TemplateFor(templates map[string]*Template, provider, service string) *Template {
+	for _, name := range SortedTemplateNames(templates) {
+		if t := templates[name]; strings.EqualFold(t.Provider, provider) && strings.EqualFold(t.Service, service) {
+			return t
+		}
+	}
+	return &Template{
+		Name:        genericTemplateName,
+		Description: "resource priced per hour",
+		Provider:    provider,
+		Service:     service,
+		Currency:    "USD",
+		Pricing:     map[string]interface{}{"onDemandHourly": 0},
+		Source:      builtinTemplateSource,
+	}
+}
+
+// NewSpec returns a spec pre-filled from the template, for service and sku when given or
+// the template's defaults otherwise. Pricing and metadata are copied, so the spec can be
+// edited without changing the template.
+func (t *Template) NewSpec(service, sku string) *PricingSpec {
+	if service == "" {
+		service = t.Service
+	}
+	if sku == "" {
+		sku = t.SKU
+	}
+	return &PricingSpec{
+		Version:  CurrentSpecVersion,
+		Provider: t.Provider,
+		Service:  service,
+		SKU:      sku,
+		Currency: t.Currency,
+		Pricing:  maps.Clone(t.Pricing),
+		Metadata: maps.Clone(t.Metadata),
+	}
+}
+
+// MarshalSkeleton encodes a spec generated from the template as YAML, headed by comments
+// that say where it came from and what is left for the author to fill in.
+func (t *Template) MarshalSkeleton(spec *PricingSpec) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Generated from the %s spec template: %s.\n", t.Name, t.Description)
+	b.WriteString("# TODO: replace the zero prices with real ones before relying on this spec.\n")
+	if t.SKUProperty != "" {
+		fmt.Fprintf(&b, "# The sku matches the %s property of the resource.\n", t.SKUProperty)
+	}
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(yamlIndent)
+	if err := enc.Encode(spec); err != nil {
+		return nil, fmt.Errorf("encoding spec YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding spec YAML: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadTemplates_Builtin(t *testing.T) {
+	templates, err := LoadTemplates(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"aws", "aws-ebs", "aws-rds", "azure", "gcp"}, SortedTemplateNames(templates))
+	for _, tmpl := range templates {
+		assert.Equal(t, builtinTemplateSource, tmpl.Source)
+		require.NoError(t, ValidateSpec(tmpl.NewSpec("", "")), tmpl.Name)
+	}
+}
+
+func TestLoadTemplates_UserOverridesBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte(`name: azure
+description: Custom Azure VM
+provider: azure
+service: compute
+sku: Standard_D2s_v5
+currency: EUR
+pricing:
+  onDemandHourly: 0
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+
+	templates, err := LoadTemplates(dir)
+	require.NoError(t, err)
+	azure := templates["azure"]
+	assert.Equal(t, "EUR", azure.Currency)
+	assert.Equal(t, filepath.Join(dir, "azure.yaml"), azure.Source)
+	assert.Contains(t, templates, "aws")
+}
+
+func TestLoadTemplates_InvalidUserTemplate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.yaml"),
+		[]byte("name: bad\nprovider: aws\nservice: s3\nsku: standard\ncurrency: USD\npricing:\n  tier: hot\n"),
+		0o600))
+
+	_, err := LoadTemplates(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pricing must set one of")
+}
+
+func TestValidateTemplate(t *testing.T) {
+	valid := func() *Template {
+		return &Template{
+			Name: "aws-lambda", Provider: "aws", Service: "lambda", SKU: "arm64", Currency: "USD",
+			Pricing: map[string]interface{}{"monthlyEstimate": 0.0},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(*Template)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*Template) {}},
+		{name: "bad name", mutate: func(t *Template) { t.Name = "AWS Lambda" }, wantErr: "lowercase"},
+		{name: "no provider", mutate: func(t *Template) { t.Provider = "" }, wantErr: "provider is required"},
+		{name: "no currency", mutate: func(t *Template) { t.Currency = "" }, wantErr: "currency is required"},
+		{
+			name:    "non-numeric cost",
+			mutate:  func(t *Template) { t.Pricing = map[string]interface{}{"onDemandHourly": "free"} },
+			wantErr: "pricing must set one of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := valid()
+			tt.mutate(tmpl)
+			err := ValidateTemplate(tmpl)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFindTemplate(t *testing.T) {
+	templates, err := LoadTemplates("")
+	require.NoError(t, err)
+
+	tmpl, err := FindTemplate(templates, "", "Azure")
+	require.NoError(t, err)
+	assert.Equal(t, "azure", tmpl.Name)
+
+	tmpl, err = FindTemplate(templates, "aws-ebs", "aws")
+	require.NoError(t, err)
+	assert.Equal(t, "ebs", tmpl.Service)
+
+	_, err = FindTemplate(templates, "", "oracle")
+	require.ErrorIs(t, err, ErrTemplateNotFound)
+	assert.Contains(t, err.Error(), "aws, aws-ebs")
+}
+
+func TestTemplateFor(t *testing.T) {
+	templates, err := LoadTemplates("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "aws", TemplateFor(templates, "aws", "ec2").Name)
+	assert.Equal(t, "aws-rds", TemplateFor(templates, "AWS", "rds").Name)
+
+	generic := TemplateFor(templates, "aws", "s3")
+	assert.Equal(t, "generic", generic.Name)
+	require.NoError(t, ValidateSpec(generic.NewSpec("", "bucket")))
+}
+
+func TestTemplate_NewSpecAndSkeleton(t *testing.T) {
+	templates, err := LoadTemplates("")
+	require.NoError(t, err)
+	tmpl := templates["azure"]
+
+	spec := tmpl.NewSpec("", "Standard_D2s_v5")
+	spec.Pricing["onDemandHourly"] = 0.096
+	assert.Equal(t, 0, tmpl.Pricing["onDemandHourly"], "template must not share maps with the spec")
+	assert.Equal(t, "compute", spec.Service)
+	assert.Equal(t, CurrentSpecVersion, spec.Version)
+
+	data, err := tmpl.MarshalSkeleton(spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Generated from the azure spec template")
+	assert.Contains(t, string(data), "# The sku matches the vmSize property")
+
+	var parsed PricingSpec
+	require.NoError(t, yaml.Unmarshal(data, &parsed))
+	assert.Equal(t, "Standard_D2s_v5", parsed.SKU)
+	assert.Equal(t, "eastus", parsed.Metadata["region"])
+}
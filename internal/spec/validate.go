@@ -1,25 +1,209 @@
 package spec
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 )
 
-// ValidateSpec validates that a pricing spec has all required fields.
+// DefaultValidateConcurrency is the number of spec files ValidateDir reads at once when no
+// limit is given. It is kept well below common file descriptor limits.
+const DefaultValidateConcurrency = 16
+
+// ErrPricingMismatch is returned by ValidateSpec for pricing keys that do not apply to the
+// resource the spec prices, such as a per-GB storage price on a compute instance.
+var ErrPricingMismatch = errors.New("pricing does not fit the resource")
+
+// ValidationResult is the outcome of validating one spec file.
+type ValidationResult struct {
+	Path string
+	// Err is set when the file could not be read or parsed, or is not a valid spec.
+	Err error
+	// Warnings are problems that do not make the spec invalid; see SpecWarnings.
+	Warnings []string
+}
+
+// FieldError is a validation problem with one field of a spec, such as "currency" or
+// "pricing.pricePerGBMonth". Its message is that of Err alone.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string { return e.Err.Error() }
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// ValidateSpec validates that a pricing spec has all required fields and that its pricing
+// keys fit the resource it prices. Every problem found is reported, joined into one error,
+// as a *FieldError naming the field at fault.
 func ValidateSpec(spec *PricingSpec) error {
+	var errs []error
+	invalid := func(field string, err error) {
+		errs = append(errs, &FieldError{Field: field, Err: err})
+	}
 	if spec.Provider == "" {
-		return errors.New("provider is required")
+		invalid("provider", errors.New("provider is required"))
 	}
 	if spec.Service == "" {
-		return errors.New("service is required")
+		invalid("service", errors.New("service is required"))
 	}
 	if spec.SKU == "" {
-		return errors.New("SKU is required")
+		invalid("sku", errors.New("SKU is required"))
 	}
-	if spec.Currency == "" {
-		return errors.New("currency is required")
+	switch {
+	case spec.Currency == "":
+		invalid("currency", errors.New("currency is required"))
+	case !IsCurrencyCode(spec.Currency):
+		invalid("currency", fmt.Errorf("%w: %q", ErrUnknownCurrency, spec.Currency))
 	}
 	if len(spec.Pricing) == 0 {
-		return errors.New("pricing information is required")
+		invalid("pricing", errors.New("pricing information is required"))
+	}
+	if spec.HoursPerMonth < 0 || math.IsNaN(spec.HoursPerMonth) || math.IsInf(spec.HoursPerMonth, 0) {
+		invalid("hoursPerMonth", fmt.Errorf("hoursPerMonth must be a positive number, got %v", spec.HoursPerMonth))
+	}
+	if spec.Version > CurrentSpecVersion {
+		invalid("version",
+			fmt.Errorf("%w: %d (latest is %d)", ErrUnsupportedSpecVersion, spec.Version, CurrentSpecVersion))
+	}
+	if key, ok := storagePricingKey(spec.Pricing); ok && spec.Service != "" && !isStorageSpec(spec) {
+		invalid("pricing."+key, fmt.Errorf("%w: %s is priced per GB but %s-%s is not a storage or database resource",
+			ErrPricingMismatch, key, spec.Service, spec.SKU))
+	}
+	return errors.Join(errs...)
+}
+
+// SpecWarnings returns problems with a spec that do not make it invalid, such as pricing
+// keys the engine ignores.
+func SpecWarnings(spec *PricingSpec) []string {
+	var warnings []string
+	storageKey, storage := storagePricingKey(spec.Pricing)
+	hourlyKey, hourly := hourlyPricingKey(spec.Pricing)
+	if storage && hourly {
+		warnings = append(warnings, fmt.Sprintf(
+			"pricing sets both %s and %s; only the hourly rate is used", hourlyKey, storageKey))
+	}
+	return warnings
+}
+
+// storagePricingKeys and hourlyPricingKeys are the pricing keys the engine prices storage
+// by size and resources by the hour with. Hourly rates win when a spec sets both.
+var (
+	storagePricingKeys = []string{"pricePerGBMonth"}
+	hourlyPricingKeys  = []string{"onDemandHourly", "hourlyRate"}
+)
+
+// storageServicePatterns mark the services and SKUs whose resources have a storage size
+// that per-GB prices are multiplied by: volumes, disks, buckets and databases.
+var storageServicePatterns = []string{
+	"storage", "s3", "ebs", "efs", "fsx", "glacier", "disk", "volume", "blob", "bucket",
+	"filestore", "snapshot", "backup", "rds", "sql", "database", "docdb",
+}
+
+// storagePricingKey returns the first per-GB storage key set in pricing.
+func storagePricingKey(pricing map[string]interface{}) (string, bool) {
+	return firstPricingKey(pricing, storagePricingKeys)
+}
+
+// hourlyPricingKey returns the first hourly rate key set in pricing.
+func hourlyPricingKey(pricing map[string]interface{}) (string, bool) {
+	return firstPricingKey(pricing, hourlyPricingKeys)
+}
+
+func firstPricingKey(pricing map[string]interface{}, keys []string) (string, bool) {
+	for _, key := range keys {
+		if _, ok := pricing[key]; ok {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// isStorageSpec reports whether the spec's service or SKU matches a storage pattern.
+func isStorageSpec(spec *PricingSpec) bool {
+	name := strings.ToLower(spec.Service + "-" + spec.SKU)
+	for _, pattern := range storageServicePatterns {
+		if strings.Contains(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFile reads and validates one spec file, also checking that its name matches the
+// provider-service-sku.yaml name the loader looks it up by. All problems with a spec that
+// parses are reported together, along with its warnings.
+func ValidateFile(path string) ValidationResult {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ValidationResult{Path: path, Err: fmt.Errorf("reading spec file: %w", err)}
+	}
+	var spec PricingSpec
+	if err = unmarshalSpec(path, data, &spec); err != nil {
+		return ValidationResult{Path: path, Err: err}
+	}
+	// A spec that extends another is validated with the currency and pricing it inherits
+	key := specKey(spec.Provider, spec.Service, spec.SKU)
+	resolved, err := NewLoader(filepath.Dir(path)).resolveExtends(context.Background(), &spec, key, []string{key})
+	if err != nil {
+		return ValidationResult{Path: path, Err: &FieldError{Field: "extends", Err: err}}
+	}
+	errs := []error{ValidateSpec(resolved)}
+
+	name := filepath.Base(path)
+	want := fmt.Sprintf("%s-%s-%s", spec.Provider, spec.Service, spec.SKU)
+	ext := filepath.Ext(name)
+	if got := name[:len(name)-len(ext)]; got != want {
+		errs = append(errs, &FieldError{
+			Field: "filename",
+			Err:   fmt.Errorf("filename %q does not match provider-service-sku %q", name, want+ext),
+		})
+	}
+	return ValidationResult{Path: path, Err: errors.Join(errs...), Warnings: SpecWarnings(resolved)}
+}
+
+// ValidateDir validates every .yaml, .yml and .json spec in dir, reading up to concurrency files
+// at a time (DefaultValidateConcurrency when concurrency is not positive). Results are
+// sorted by filename regardless of the order in which files finish.
+func ValidateDir(ctx context.Context, dir string, concurrency int) ([]ValidationResult, error) {
+	names, err := specFileNames(dir, jsonExt)
+	if err != nil {
+		return nil, err
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultValidateConcurrency
+	}
+
+	results := make([]ValidationResult, len(names))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(names)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = ValidateFile(filepath.Join(dir, names[i]))
+			}
+		}()
+	}
+
+	var cancelled error
+	for i := range names {
+		if cancelled = ctx.Err(); cancelled != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if cancelled != nil {
+		return nil, cancelled
 	}
-	return nil
+	return results, nil
 }
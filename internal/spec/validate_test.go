@@ -0,0 +1,134 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validSpecYAML = "provider: aws\nservice: ec2\nsku: %s\ncurrency: USD\npricing:\n  onDemandHourly: 0.01\n"
+
+func TestValidateDir(t *testing.T) {
+	dir := t.TempDir()
+	const count = 50
+	for i := range count {
+		sku := fmt.Sprintf("m%02d", i)
+		content := fmt.Sprintf(validSpecYAML, sku)
+		if i%10 == 0 {
+			content = "provider: aws\nservice: ec2\nsku: " + sku + "\n"
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-"+sku+".yaml"), []byte(content), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-wrong.yaml"),
+		[]byte(fmt.Sprintf(validSpecYAML, "right")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a spec"), 0o600))
+
+	results, err := ValidateDir(context.Background(), dir, 4)
+	require.NoError(t, err)
+	require.Len(t, results, count+1)
+
+	var failed []string
+	for i, r := range results {
+		if i > 0 {
+			assert.Less(t, results[i-1].Path, r.Path, "results are sorted by filename")
+		}
+		if r.Err != nil {
+			failed = append(failed, filepath.Base(r.Path))
+		}
+	}
+	assert.Equal(t, []string{
+		"aws-ec2-m00.yaml", "aws-ec2-m10.yaml", "aws-ec2-m20.yaml", "aws-ec2-m30.yaml",
+		"aws-ec2-m40.yaml", "aws-ec2-wrong.yaml",
+	}, failed)
+	assert.EqualError(t, results[0].Err, "currency is required\npricing information is required")
+	assert.ErrorContains(t, results[count].Err, `does not match provider-service-sku "aws-ec2-right.yaml"`)
+}
+
+func TestValidateDir_Errors(t *testing.T) {
+	_, err := ValidateDir(context.Background(), filepath.Join(t.TempDir(), "missing"), 0)
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-a.yaml"), []byte(fmt.Sprintf(validSpecYAML, "a")), 0o600))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ValidateDir(ctx, dir, 1)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestValidateFile_Extends(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws-ec2-default.yaml"),
+		[]byte(fmt.Sprintf(validSpecYAML, "default")), 0o600))
+	child := filepath.Join(dir, "aws-ec2-t3.micro.yaml")
+	require.NoError(t, os.WriteFile(child,
+		[]byte("provider: aws\nservice: ec2\nsku: t3.micro\nextends: default\n"), 0o600))
+	orphan := filepath.Join(dir, "aws-ec2-orphan.yaml")
+	require.NoError(t, os.WriteFile(orphan,
+		[]byte("provider: aws\nservice: ec2\nsku: orphan\nextends: missing\n"), 0o600))
+
+	require.NoError(t, ValidateFile(child).Err, "currency and pricing are inherited")
+	require.ErrorIs(t, ValidateFile(orphan).Err, ErrSpecInheritance)
+}
+
+func TestValidateFile_Currency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		wantErr  string
+	}{
+		{name: "valid", currency: "EUR"},
+		{name: "lowercase", currency: "jpy"},
+		{name: "invalid", currency: "DOLLARS", wantErr: `currency is not an ISO 4217 code: "DOLLARS"`},
+		{name: "empty", currency: `""`, wantErr: "currency is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "aws-ec2-t3.micro.yaml")
+			content := "provider: aws\nservice: ec2\nsku: t3.micro\ncurrency: " + tt.currency +
+				"\npricing:\n  onDemandHourly: 0.01\n"
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			result := ValidateFile(path)
+			assert.Equal(t, path, result.Path)
+			if tt.wantErr == "" {
+				assert.NoError(t, result.Err)
+				return
+			}
+			require.EqualError(t, result.Err, tt.wantErr)
+		})
+	}
+	assert.ErrorIs(t, ValidateSpec(&PricingSpec{
+		Provider: "aws", Service: "ec2", SKU: "t3.micro", Currency: "XYZ",
+		Pricing: map[string]interface{}{"onDemandHourly": 0.01},
+	}), ErrUnknownCurrency)
+}
+
+func TestValidateSpec_StoragePricing(t *testing.T) {
+	storage := map[string]interface{}{"pricePerGBMonth": 0.1}
+	both := map[string]interface{}{"pricePerGBMonth": 0.1, "onDemandHourly": 0.02}
+
+	for _, service := range []string{"ebs", "s3", "rds"} {
+		assert.NoError(t, ValidateSpec(&PricingSpec{
+			Provider: "aws", Service: service, SKU: "standard", Currency: "USD", Pricing: storage,
+		}), service)
+	}
+
+	err := ValidateSpec(&PricingSpec{Provider: "aws", Service: "ec2", SKU: "m5.large", Currency: "USD", Pricing: storage})
+	require.ErrorIs(t, err, ErrPricingMismatch)
+	assert.ErrorContains(t, err, "ec2-m5.large")
+
+	err = ValidateSpec(&PricingSpec{Service: "ec2", SKU: "m5.large", Currency: "dollars", Pricing: storage})
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+	require.ErrorIs(t, err, ErrPricingMismatch)
+	assert.ErrorContains(t, err, "provider is required", "every problem is reported")
+
+	assert.Empty(t, SpecWarnings(&PricingSpec{Pricing: storage}))
+	assert.Equal(t, []string{"pricing sets both onDemandHourly and pricePerGBMonth; only the hourly rate is used"},
+		SpecWarnings(&PricingSpec{Pricing: both}))
+}
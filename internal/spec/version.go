@@ -0,0 +1,46 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SpecVersion identifies what a lookup of provider-service-sku would find on disk right
+// now: the modification time of each spec directory, which changes when specs are added,
+// removed or replaced by rename, and the modification time and size of the spec's YAML and
+// JSON files in each of them. Together with SourcesVersion of the spec the lookup returned, it changes
+// whenever the file or a spec it extends is edited, so cached specs can be checked cheaply
+// without reading them again.
+func (l *Loader) SpecVersion(provider, service, sku string) string {
+	key := specKey(provider, service, sku)
+	var parts []string
+	for _, dir := range l.dirs() {
+		parts = append(parts, fileVersion(dir),
+			fileVersion(filepath.Join(dir, key+yamlExt)), fileVersion(filepath.Join(dir, key+jsonExt)))
+	}
+	return strings.Join(parts, ";")
+}
+
+// SourcesVersion identifies the current state of the files the spec was loaded from, and
+// is empty for a nil spec.
+func (s *PricingSpec) SourcesVersion() string {
+	if s == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(s.Sources))
+	for _, source := range s.Sources {
+		parts = append(parts, fileVersion(source))
+	}
+	return strings.Join(parts, ";")
+}
+
+// fileVersion is the path with its modification time and size, or marked absent.
+func fileVersion(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return path + "@absent"
+	}
+	return fmt.Sprintf("%s@%d:%d", path, info.ModTime().UnixNano(), info.Size())
+}
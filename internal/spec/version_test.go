@@ -0,0 +1,48 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecVersion(t *testing.T) {
+	dir := t.TempDir()
+	fallback := t.TempDir()
+	loader := NewLoaderWithFallback(dir, fallback)
+	touch := func(path, content string, offset time.Duration) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		mtime := time.Now().Add(offset)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+		require.NoError(t, os.Chtimes(filepath.Dir(path), mtime, mtime))
+	}
+
+	parent := filepath.Join(fallback, "aws-ec2-default.yaml")
+	touch(parent, "provider: aws\nservice: ec2\nsku: default\ncurrency: USD\npricing:\n  onDemandHourly: 0.02\n", 0)
+	child := filepath.Join(dir, "aws-ec2-t3.micro.yaml")
+	touch(child, "provider: aws\nservice: ec2\nsku: t3.micro\nextends: default\n", 0)
+
+	loaded, err := loader.LoadSpec("aws", "ec2", "t3.micro")
+	require.NoError(t, err)
+	spec := loaded.(*PricingSpec)
+	assert.Equal(t, []string{child, parent}, spec.Sources)
+
+	version, sources := loader.SpecVersion("aws", "ec2", "t3.micro"), spec.SourcesVersion()
+	assert.Equal(t, version, loader.SpecVersion("aws", "ec2", "t3.micro"), "versions are stable")
+	assert.Equal(t, sources, spec.SourcesVersion())
+
+	touch(parent, "provider: aws\nservice: ec2\nsku: default\ncurrency: EUR\npricing:\n  onDemandHourly: 0.02\n",
+		time.Minute)
+	assert.NotEqual(t, sources, spec.SourcesVersion(), "editing an extended spec changes the sources version")
+
+	touch(filepath.Join(dir, "aws-ec2-t3.nano.yaml"), "provider: aws\nservice: ec2\nsku: t3.nano\n", 2*time.Minute)
+	assert.NotEqual(t, version, loader.SpecVersion("aws", "ec2", "t3.micro"),
+		"adding specs changes the directory version")
+
+	assert.Empty(t, (*PricingSpec)(nil).SourcesVersion())
+}
@@ -8,6 +8,6 @@
 // The validator ensures:
 //   - Required fields are present (resource_type, pricing)
 //   - Pricing values are valid numbers
-//   - Currency codes are recognized
+//   - Currency codes are active ISO 4217 codes, in any case (see spec.IsCurrencyCode)
 //   - Resource types follow expected patterns
 package specvalidate
@@ -69,11 +69,48 @@ func extractProvider(resourceType string) string {
 }
 
 // RenderCostSummary renders a styled summary of the cost results using Lip Gloss.
+// Results priced in more than one currency get a total line per currency; amounts in
+// different currencies are never added together.
 func RenderCostSummary(results []engine.CostResult, width int) string {
 	if len(results) == 0 {
 		return InfoStyle.Render("No results to display.")
 	}
 
+	// Create content.
+	var content strings.Builder
+
+	// Header.
+	content.WriteString(HeaderStyle.Render("COST SUMMARY"))
+	content.WriteString("\n")
+
+	groups := engine.GroupByCurrency(results)
+	if len(groups) <= 1 {
+		writeCostTotals(&content, results, "Total Cost:    ", func(v float64) string {
+			return fmt.Sprintf("$%.2f", v)
+		})
+	} else {
+		currencies := make([]string, 0, len(groups))
+		for currency := range groups {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for i, currency := range currencies {
+			if i > 0 {
+				content.WriteString("\n")
+			}
+			writeCostTotals(&content, groups[currency], "Total "+currency+":     ", func(v float64) string {
+				return fmt.Sprintf("%.2f %s", v, currency)
+			})
+		}
+	}
+
+	// Box it. Use width-2 to account for borders.
+	return BoxStyle.Width(width - borderPadding).Render(content.String())
+}
+
+// writeCostTotals writes the total line and the provider breakdown of results, all of
+// which share a currency, formatting amounts with format.
+func writeCostTotals(content *strings.Builder, results []engine.CostResult, label string, format func(float64) string) {
 	totalCost := 0.0
 	providerCosts := make(map[string]float64)
 
@@ -89,16 +126,9 @@ func RenderCostSummary(results []engine.CostResult, width int) string {
 		providerCosts[provider] += cost
 	}
 
-	// Create content.
-	var content strings.Builder
-
-	// Header.
-	content.WriteString(HeaderStyle.Render("COST SUMMARY"))
-	content.WriteString("\n")
-
 	// Total Line.
-	content.WriteString(LabelStyle.Render("Total Cost:    "))
-	content.WriteString(ValueStyle.Render(fmt.Sprintf("$%.2f", totalCost)))
+	content.WriteString(LabelStyle.Render(label))
+	content.WriteString(ValueStyle.Render(format(totalCost)))
 	content.WriteString(LabelStyle.Render("    Resources: "))
 	content.WriteString(ValueStyle.Render(strconv.Itoa(len(results))))
 	content.WriteString("\n")
@@ -122,13 +152,10 @@ func RenderCostSummary(results []engine.CostResult, width int) string {
 		if totalCost > 0 {
 			pct = (pc.Cost / totalCost) * 100 //nolint:mnd // Percentage calculation.
 		}
-		part := fmt.Sprintf("%s: $%.2f (%.1f%%)", pc.Name, pc.Cost, pct)
+		part := fmt.Sprintf("%s: %s (%.1f%%)", pc.Name, format(pc.Cost), pct)
 		providerParts = append(providerParts, part)
 	}
 	content.WriteString(LabelStyle.Render(strings.Join(providerParts, "  ")))
-
-	// Box it. Use width-2 to account for borders.
-	return BoxStyle.Width(width - borderPadding).Render(content.String())
 }
 
 // NewResultTable creates and configures a new table model for cost results.
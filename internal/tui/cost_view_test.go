@@ -117,6 +117,21 @@ func TestRenderCostSummary(t *testing.T) {
 				"bucket:", "$25.00",
 			},
 		},
+		{
+			name: "mixed currencies",
+			results: []engine.CostResult{
+				{ResourceType: "aws:ec2/instance", Monthly: 100.0, Currency: "USD"},
+				{ResourceType: "azure:compute/vm", Monthly: 80.0, Currency: "EUR"},
+				{ResourceType: "azure:storage/account", Monthly: 20.0, Currency: "EUR"},
+			},
+			width: 100,
+			contains: []string{
+				"Total EUR:", "100.00 EUR",
+				"Total USD:", "100.00 USD",
+				"azure: 100.00 EUR (100.0%)",
+				"aws: 100.00 USD (100.0%)",
+			},
+		},
 	}
 
 	for _, tt := range tests {
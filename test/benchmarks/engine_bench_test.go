@@ -14,7 +14,10 @@ import (
 // with a single resource to establish baseline performance.
 func BenchmarkEngine_GetProjectedCost_Single(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -41,7 +44,10 @@ func BenchmarkEngine_GetProjectedCost_Single(b *testing.B) {
 // with multiple resources (batch of 10) to evaluate batching performance.
 func BenchmarkEngine_GetProjectedCost_Multiple(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	// Create 10 resources for batch testing
 	resources := make([]engine.ResourceDescriptor, 10)
@@ -70,7 +76,10 @@ func BenchmarkEngine_GetProjectedCost_Multiple(b *testing.B) {
 // with a large batch of resources (100) to identify performance at scale.
 func BenchmarkEngine_GetProjectedCost_Large(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	// Create 100 resources for large batch testing
 	resources := make([]engine.ResourceDescriptor, 100)
@@ -99,7 +108,10 @@ func BenchmarkEngine_GetProjectedCost_Large(b *testing.B) {
 // with a single resource to establish baseline performance for actual cost queries.
 func BenchmarkEngine_GetActualCost_Single(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -125,7 +137,10 @@ func BenchmarkEngine_GetActualCost_Single(b *testing.B) {
 // with multiple resources (batch of 10) to evaluate batching for actual cost queries.
 func BenchmarkEngine_GetActualCost_Multiple(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	// Create 10 resources for batch testing
 	resources := make([]engine.ResourceDescriptor, 10)
@@ -180,7 +195,10 @@ func BenchmarkEngine_ResourceDescriptor_Allocation(b *testing.B) {
 // performance of GetProjectedCost when accessed by multiple goroutines in parallel.
 func BenchmarkEngine_GetProjectedCost_Concurrent(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -208,7 +226,10 @@ func BenchmarkEngine_GetProjectedCost_Concurrent(b *testing.B) {
 // when using context timeout to measure cancellation overhead.
 func BenchmarkEngine_GetProjectedCost_WithTimeout(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -268,7 +289,10 @@ func BenchmarkEngine_Properties_Conversion(b *testing.B) {
 func BenchmarkEngine_GetProjectedCost_NoClients(b *testing.B) {
 	b.ReportAllocs()
 	// Test fallback performance when no plugins are available
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	resources := []engine.ResourceDescriptor{
 		{
@@ -318,7 +342,10 @@ func createResources(n int) []engine.ResourceDescriptor {
 // BenchmarkEngine_GetProjectedCost_1K benchmarks projected cost with 1,000 resources.
 func BenchmarkEngine_GetProjectedCost_1K(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	resources := createResources(1000)
 
 	b.ResetTimer()
@@ -333,7 +360,10 @@ func BenchmarkEngine_GetProjectedCost_1K(b *testing.B) {
 // BenchmarkEngine_GetProjectedCost_10K benchmarks projected cost with 10,000 resources.
 func BenchmarkEngine_GetProjectedCost_10K(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	resources := createResources(10000)
 
 	b.ResetTimer()
@@ -348,7 +378,10 @@ func BenchmarkEngine_GetProjectedCost_10K(b *testing.B) {
 // BenchmarkEngine_GetProjectedCost_100K benchmarks projected cost with 100,000 resources.
 func BenchmarkEngine_GetProjectedCost_100K(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	resources := createResources(100000)
 
 	b.ResetTimer()
@@ -363,7 +396,10 @@ func BenchmarkEngine_GetProjectedCost_100K(b *testing.B) {
 // BenchmarkEngine_GetActualCost_1K benchmarks actual cost with 1,000 resources.
 func BenchmarkEngine_GetActualCost_1K(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	resources := createResources(1000)
 	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
@@ -380,7 +416,10 @@ func BenchmarkEngine_GetActualCost_1K(b *testing.B) {
 // BenchmarkEngine_GetActualCost_10K benchmarks actual cost with 10,000 resources.
 func BenchmarkEngine_GetActualCost_10K(b *testing.B) {
 	b.ReportAllocs()
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	resources := createResources(10000)
 	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
@@ -43,7 +43,10 @@ func BenchmarkScale1K(b *testing.B) {
 	}
 
 	resources := convertToResourceDescriptors(plan)
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -68,7 +71,10 @@ func BenchmarkScale10K(b *testing.B) {
 	}
 
 	resources := convertToResourceDescriptors(plan)
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -93,7 +99,10 @@ func BenchmarkScale100K(b *testing.B) {
 	}
 
 	resources := convertToResourceDescriptors(plan)
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -118,7 +127,10 @@ func BenchmarkDeeplyNested(b *testing.B) {
 	}
 
 	resources := convertToResourceDescriptors(plan)
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
 	ctx := context.Background()
 
 	b.ResetTimer()
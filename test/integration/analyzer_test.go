@@ -101,7 +101,7 @@ func TestAnalyzer_FullStackFlow(t *testing.T) {
 	require.NotNil(t, infoResp)
 	assert.Equal(t, "finfocus", infoResp.GetName())
 	assert.Equal(t, "1.0.0-test", infoResp.GetVersion())
-	assert.Len(t, infoResp.GetPolicies(), 2)
+	assert.Len(t, infoResp.GetPolicies(), 3)
 
 	// Step 4: Analyze Stack
 	props, _ := structpb.NewStruct(map[string]interface{}{
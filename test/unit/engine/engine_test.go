@@ -27,7 +27,8 @@ func TestGetProjectedCost_WithPlugin(t *testing.T) {
 	}
 
 	// Create engine with plugin client
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	// Test data
 	resources := []engine.ResourceDescriptor{
@@ -70,7 +71,8 @@ func TestGetProjectedCost_MultipleResources(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -96,7 +98,8 @@ func TestGetProjectedCost_MultipleResources(t *testing.T) {
 // TestGetProjectedCost_NoPlugin tests fallback when no plugin available.
 func TestGetProjectedCost_NoPlugin(t *testing.T) {
 	// Create engine with no plugins and no spec loader
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -129,7 +132,8 @@ func TestGetProjectedCost_PluginError(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -166,7 +170,8 @@ func TestGetProjectedCost_MultiPluginSupport(t *testing.T) {
 		{Name: "plugin2", API: proto.NewCostSourceClient(conn2)},
 	}
 
-	eng := engine.New(clients, nil)
+	eng, err := engine.New(clients, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -198,7 +203,8 @@ func TestGetProjectedCost_PartialData(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -231,7 +237,8 @@ func TestGetProjectedCost_HighCost(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -260,7 +267,8 @@ func TestGetProjectedCost_ZeroCost(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:s3/bucket:Bucket", ID: "bucket-001", Provider: "aws"},
@@ -290,7 +298,8 @@ func TestGetProjectedCost_MultiCurrency(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -322,7 +331,8 @@ func TestGetProjectedCost_WithBreakdown(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:lambda/function:Function", ID: "func-001", Provider: "aws"},
@@ -359,7 +369,8 @@ func TestGetActualCost_WithPlugin(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-1234567890abcdef0", Provider: "aws"},
@@ -386,7 +397,8 @@ func TestGetActualCost_WithPlugin(t *testing.T) {
 
 // TestGetActualCost_NoPlugin tests actual cost with no plugin.
 func TestGetActualCost_NoPlugin(t *testing.T) {
-	eng := engine.New(nil, nil)
+	eng, err := engine.New(nil, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
@@ -421,7 +433,8 @@ func TestGetActualCost_TimeRange(t *testing.T) {
 		API:  proto.NewCostSourceClient(conn),
 	}
 
-	eng := engine.New([]*pluginhost.Client{client}, nil)
+	eng, err := engine.New([]*pluginhost.Client{client}, nil)
+	require.NoError(t, err)
 
 	resources := []engine.ResourceDescriptor{
 		{Type: "aws:ec2/instance:Instance", ID: "i-001", Provider: "aws"},
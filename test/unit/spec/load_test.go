@@ -251,7 +251,7 @@ func TestListSpecs_MultipleSpecs(t *testing.T) {
 	}
 }
 
-// TestListSpecs_FiltersNonYAMLFiles tests that non-YAML files are excluded.
+// TestListSpecs_FiltersNonYAMLFiles tests that files other than YAML and JSON specs are excluded.
 func TestListSpecs_FiltersNonYAMLFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -264,6 +264,7 @@ func TestListSpecs_FiltersNonYAMLFiles(t *testing.T) {
 		{"aws-rds-db.t3.medium.yaml", true},
 		{"readme.txt", false},
 		{"config.json", false},
+		{"aws-ec2-m5.large.json", true},
 		{"notes.md", false},
 	}
 
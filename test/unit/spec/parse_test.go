@@ -279,7 +279,6 @@ func TestParseSpecFilename_InvalidFormats(t *testing.T) {
 		{"aws.yaml", "only one part"},
 		{".yaml", "empty filename"},
 		{"nohyphens.yaml", "no hyphens"},
-		{"aws-ec2-t3.micro.json", "wrong extension (.json)"},
 		{"aws-ec2-t3.micro.txt", "wrong extension (.txt)"},
 		{"aws--t3.micro.yaml", "empty service part"},
 		{"-ec2-t3.micro.yaml", "empty provider"},
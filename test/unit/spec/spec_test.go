@@ -228,8 +228,16 @@ func TestSpecFilePatterns(t *testing.T) {
 			isValid:  true,
 		},
 		{
-			name:     "invalid extension",
+			name:     "json extension",
 			filename: "aws-ec2-t3.micro.json",
+			provider: "aws",
+			service:  "ec2",
+			sku:      "t3.micro",
+			isValid:  true,
+		},
+		{
+			name:     "invalid extension",
+			filename: "aws-ec2-t3.micro.txt",
 			isValid:  false,
 		},
 		{